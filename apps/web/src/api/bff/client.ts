@@ -113,10 +113,14 @@ export const bffClient = {
     },
 
     /**
-     * Cancel the entire event (Organizer only)
+     * Cancel the entire event (Organizer only).
+     * version is the event version the organizer was looking at; the
+     * request fails with 412 if the event changed since.
      */
-    async cancelEvent(id: string): Promise<any> {
-        const res = await apiClient.post(`/events/${id}/cancel-event`);
+    async cancelEvent(id: string, version: number): Promise<any> {
+        const res = await apiClient.post(`/events/${id}/cancel-event`, undefined, {
+            headers: { 'If-Match': `"${version}"` }
+        });
         return res.data;
     },
 
@@ -26,6 +26,16 @@ export const PaginatedResponseSchema = <T extends z.ZodTypeAny>(itemSchema: T) =
 /**
  * 3. View Models
  */
+export const ImagePlaceholderSchema = z.object({
+    width: z.number().nullish(),
+    height: z.number().nullish(),
+    blurhash: z.string().nullish(),
+    dominant_color: z.string().nullish(),
+}).passthrough();
+
+// Keyed by cover upload id
+const CoverPlaceholdersSchema = z.record(z.string(), ImagePlaceholderSchema).nullish().catch(null);
+
 export const EventCardSchema = z.preprocess(
     (val: any) => {
         if (!val) return val;
@@ -49,6 +59,7 @@ export const EventCardSchema = z.preprocess(
         title: z.string().catch("Untitled Event"),
         cover_image: z.string().nullish(),
         cover_image_ids: z.array(z.string()).nullish().catch([]),
+        cover_placeholders: CoverPlaceholdersSchema,
         start_time: z.string().catch(() => new Date().toISOString()),
         end_time: z.string().nullish().catch(null),
         city: z.string().catch("Unknown City"),
@@ -84,6 +95,7 @@ export const EventSchema = z.object({
     category: z.string().catch(""),
     cover_image: z.string().nullish().catch(null),
     cover_image_ids: z.array(z.string()).nullish().catch([]),
+    cover_placeholders: CoverPlaceholdersSchema,
     start_time: z.string().catch(() => new Date().toISOString()),
     end_time: z.string().nullish().catch(null),
     location: z.string().catch(""),
@@ -93,6 +105,7 @@ export const EventSchema = z.object({
     owner_id: z.string().catch(""),
     organizer_name: z.string().catch("-"),
     created_by: z.string().catch(""),
+    version: z.number().nullish().catch(null),
 }).passthrough();
 
 export const EventViewSchema = z.object({
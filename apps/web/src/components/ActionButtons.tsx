@@ -17,6 +17,7 @@ import { Textarea } from "./ui/Textarea";
 
 interface ActionButtonsProps {
     eventId: string;
+    eventVersion?: number | null;
     status: string;
     canJoin: boolean;
     canCancel: boolean;
@@ -28,6 +29,7 @@ interface ActionButtonsProps {
 
 export function ActionButtons({
     eventId,
+    eventVersion,
     status,
     canJoin,
     canCancel,
@@ -88,10 +90,12 @@ export function ActionButtons({
         }
     });
 
+    // The owner's cancel is conditional on the version shown, so the button
+    // stays disabled until the event has one.
     const cancelEventMutation = useMutation({
-        mutationFn: () => {
+        mutationFn: (version: number) => {
             setIsLockActive(true);
-            return bffClient.cancelEvent(eventId);
+            return bffClient.cancelEvent(eventId, version);
         },
         onSuccess: () => {
             toast.success("Event has been cancelled.");
@@ -215,15 +219,15 @@ export function ActionButtons({
                             variant="destructive"
                             onClick={() => {
                                 if (canEdit) {
-                                    if (window.confirm("Are you sure you want to cancel this event? This cannot be undone.")) {
-                                        cancelEventMutation.mutate();
+                                    if (eventVersion && window.confirm("Are you sure you want to cancel this event? This cannot be undone.")) {
+                                        cancelEventMutation.mutate(eventVersion);
                                     }
                                 } else {
                                     setModerationAction('cancel');
                                     setIsModerationDialogOpen(true);
                                 }
                             }}
-                            disabled={isPending}
+                            disabled={isPending || (canEdit && !eventVersion)}
                             className="w-full sm:w-auto min-w-[140px]"
                         >
                             {isPending && moderationAction === 'cancel' ? <Loader2 className="h-4 w-4 animate-spin" /> : <><Trash2 className="mr-2 h-4 w-4" /> Cancel Event</>}
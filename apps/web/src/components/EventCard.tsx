@@ -1,7 +1,7 @@
 import { useNavigate } from "react-router-dom";
 import { Calendar, MapPin, Tag } from "lucide-react";
 import type { EventCard as EventCardType } from "@/types/api";
-import { getPublicUrl } from "@/lib/mediaApi";
+import { getPublicUrl, placeholderStyle } from "@/lib/mediaApi";
 
 interface EventCardProps {
     event: EventCardType;
@@ -20,7 +20,10 @@ export function EventCard({ event }: EventCardProps) {
             className="group relative flex flex-col overflow-hidden rounded-xl glass-card glass-card-hover cursor-pointer"
             onClick={() => navigate(`/events/${event.id}`)}
         >
-            <div className="aspect-video w-full overflow-hidden bg-muted">
+            <div
+                className="aspect-video w-full overflow-hidden bg-muted"
+                style={placeholderStyle(event.cover_placeholders, event.cover_image_ids?.[0])}
+            >
                 {imageUrl ? (
                     <img
                         src={imageUrl}
@@ -0,0 +1,30 @@
+import { apiClient } from './apiClient';
+
+/**
+ * Optional emails the user gets. Reminders are on until turned off; the
+ * weekly digest is opt-in and goes out at digest_hour on digest_day in
+ * the user's timezone.
+ */
+export interface EmailPreferences {
+    reminders: boolean;
+    digest: boolean;
+    /** Empty means all cities */
+    digest_city: string;
+    digest_day: 'monday' | 'tuesday' | 'wednesday' | 'thursday' | 'friday' | 'saturday' | 'sunday';
+    digest_hour: number;
+    /** IANA name, e.g. Intl.DateTimeFormat().resolvedOptions().timeZone */
+    timezone: string;
+}
+
+export async function getEmailPreferences(): Promise<EmailPreferences> {
+    const response = await apiClient.get<EmailPreferences>('/me/email-preferences');
+    return response.data;
+}
+
+/**
+ * Change some preferences; fields left out keep their value.
+ */
+export async function updateEmailPreferences(changes: Partial<EmailPreferences>): Promise<EmailPreferences> {
+    const response = await apiClient.put<EmailPreferences>('/me/email-preferences', changes);
+    return response.data;
+}
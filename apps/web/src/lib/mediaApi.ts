@@ -9,9 +9,19 @@ export interface RequestUploadResponse {
 
 export interface UploadStatusResponse {
     id: string;
-    status: 'PENDING' | 'UPLOADED' | 'PROCESSING' | 'READY' | 'FAILED';
+    status: 'PENDING' | 'UPLOADED' | 'PROCESSING' | 'READY' | 'FAILED' | 'QUARANTINED';
     derived_urls?: Record<string, string>;
     error?: string;
+    crop?: ImageCrop;
+}
+
+/**
+ * What to keep in view when an image is cropped to a fixed aspect ratio,
+ * as fractions (0-1) of the image. Set either focus or rect.
+ */
+export interface ImageCrop {
+    focus?: { x: number; y: number };
+    rect?: { x: number; y: number; width: number; height: number };
 }
 
 export const CDN_BASE_URL = import.meta.env.VITE_CDN_URL || 'http://cityevents.local/public';
@@ -20,6 +30,15 @@ export function getPublicUrl(id: string, purpose: 'avatar' | 'event_cover', size
     return `${CDN_BASE_URL}/derived/${purpose}/${id}_${size}.jpg`;
 }
 
+/**
+ * URL that redirects to the best format (AVIF/WebP/JPEG) the browser accepts.
+ * Costs one redirect; prefer getPublicUrl where a JPEG is good enough.
+ */
+export function getImageUrl(id: string, size?: string): string {
+    const query = size ? `?size=${encodeURIComponent(size)}` : '';
+    return `/api/media/${id}/image${query}`;
+}
+
 /**
  * Request a presigned URL for uploading an image.
  * @param purpose - 'avatar' or 'event_cover'
@@ -71,14 +90,26 @@ export async function uploadToPresignedUrl(
 /**
  * Mark upload as complete and trigger processing.
  * @param uploadId - The upload ID from requestUpload
+ * @param crop - Optional focal point or crop rectangle
  */
-export async function completeUpload(uploadId: string): Promise<{ status: string }> {
-    const response = await apiClient.post<{ status: string }>('/media/complete', { upload_id: uploadId });
+export async function completeUpload(uploadId: string, crop?: ImageCrop): Promise<{ status: string }> {
+    const response = await apiClient.post<{ status: string }>('/media/complete', { upload_id: uploadId, crop });
     return response.data;
 }
 
 /**
- * Poll upload status until READY or FAILED.
+ * Change the crop of a READY image without uploading it again. The cropped
+ * sizes are regenerated in the background; poll the status for new URLs.
+ * @param crop - The new crop, or null for a center crop
+ */
+export async function recropImage(uploadId: string, crop: ImageCrop | null): Promise<UploadStatusResponse> {
+    const response = await apiClient.post<UploadStatusResponse>('/media/recrop', { upload_id: uploadId, crop });
+    return response.data;
+}
+
+/**
+ * Poll upload status until READY, FAILED or QUARANTINED (held for a
+ * moderator; the error explains it).
  * @param uploadId - The upload ID
  * @param onStatusChange - Callback when status changes
  * @param maxAttempts - Max polling attempts (default 30)
@@ -98,7 +129,7 @@ export async function pollUploadStatus(
             onStatusChange(status);
         }
 
-        if (status.status === 'READY' || status.status === 'FAILED') {
+        if (status.status === 'READY' || status.status === 'FAILED' || status.status === 'QUARANTINED') {
             return status;
         }
 
@@ -114,7 +145,8 @@ export async function pollUploadStatus(
 export async function uploadImage(
     file: File,
     purpose: 'avatar' | 'event_cover',
-    onProgress?: (progress: number, phase: 'uploading' | 'processing') => void
+    onProgress?: (progress: number, phase: 'uploading' | 'processing') => void,
+    crop?: ImageCrop
 ): Promise<UploadStatusResponse> {
     // 1. Request presigned URL
     const { upload_id, presigned_url } = await requestUpload(purpose);
@@ -125,7 +157,7 @@ export async function uploadImage(
     });
 
     // 3. Mark complete
-    await completeUpload(upload_id);
+    await completeUpload(upload_id, crop);
 
     // 4. Poll for result
     onProgress?.(0, 'processing');
@@ -138,3 +170,15 @@ export async function uploadImage(
     onProgress?.(100, 'processing');
     return result;
 }
+
+/**
+ * Background for an image slot until the image loads: the cover's dominant
+ * color from the BFF's cover_placeholders, if known.
+ */
+export function placeholderStyle(
+    placeholders: Record<string, { dominant_color?: string | null }> | null | undefined,
+    uploadId: string | null | undefined
+): { backgroundColor?: string } {
+    const color = uploadId ? placeholders?.[uploadId]?.dominant_color : undefined;
+    return color ? { backgroundColor: color } : {};
+}
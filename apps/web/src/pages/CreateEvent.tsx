@@ -32,6 +32,9 @@ export function CreateEvent() {
     const eventIdParam = searchParams.get("id");
     const [loading, setLoading] = useState(false);
     const [fetching, setFetching] = useState(false);
+    // Version of the loaded draft, sent as If-Match so a concurrent edit is not overwritten.
+    // Saving an existing draft is disabled until it is known.
+    const [version, setVersion] = useState<number | null>(null);
     // Two fixed independent cover image slots
     const [coverImage1, setCoverImage1] = useState<{ url: string; uploadId: string } | null>(null);
     const [coverImage2, setCoverImage2] = useState<{ url: string; uploadId: string } | null>(null);
@@ -85,6 +88,7 @@ export function CreateEvent() {
         try {
             const data = await getEventDetail(id);
             const ev = data.event;
+            setVersion(ev.version ?? null);
 
             // Format timestamps for datetime-local input
             const formatTime = (iso: string) => {
@@ -159,18 +163,35 @@ export function CreateEvent() {
 
             console.log("Submitting payload:", payload);
 
+            let currentVersion: number | null = null;
             if (eventId) {
                 // Update existing draft
-                await apiClient.patch(`/events/${eventId}`, payload);
+                const res = await apiClient.patch(`/events/${eventId}`, payload, {
+                    headers: { 'If-Match': `"${version}"` }
+                });
+                currentVersion = res.data.version ?? null;
             } else {
                 // Create new event
                 const res = await apiClient.post("/events", payload);
                 eventId = res.data.id;
+                currentVersion = res.data.version ?? null;
             }
 
             if (publish) {
+                if (!currentVersion) {
+                    // Saved, but publishing needs the new version; reload the draft so the user can retry.
+                    toast.error("Draft saved, but it could not be published. Please publish it again.");
+                    if (eventIdParam) {
+                        await loadEvent(eventIdParam);
+                    } else {
+                        navigate(`/events/new?id=${eventId}`);
+                    }
+                    return;
+                }
                 // 2. If user chose "Publish Now", call the publish endpoint
-                await apiClient.post(`/events/${eventId}/publish`);
+                await apiClient.post(`/events/${eventId}/publish`, undefined, {
+                    headers: { 'If-Match': `"${currentVersion}"` }
+                });
                 toast.success(eventIdParam ? "Draft updated and published!" : "Event created and published!");
                 navigate(`/events/${eventId}`);
             } else {
@@ -178,6 +199,10 @@ export function CreateEvent() {
                 navigate("/me/events");
             }
         } catch (err: any) {
+            if (err.response?.status === 412) {
+                toast.error("This event was changed elsewhere. Reload to see the latest version.");
+                return;
+            }
             toast.error(err.response?.data?.error?.message || "Failed to process request");
         } finally {
             setLoading(false);
@@ -395,14 +420,14 @@ export function CreateEvent() {
                                 variant="outline"
                                 className="flex-1 rounded-2xl h-14 font-bold uppercase tracking-widest glass-card border-white/20 hover:bg-white/20 dark:hover:bg-slate-800 transition-all flex items-center gap-2"
                                 onClick={() => handleSubmit(false)}
-                                disabled={loading}
+                                disabled={loading || (!!eventIdParam && !version)}
                             >
                                 <Save className="w-4 h-4" /> Save as Draft
                             </Button>
                             <Button
                                 className="flex-[2] rounded-2xl h-14 font-bold uppercase tracking-widest bg-emerald-600 hover:bg-emerald-700 shadow-lg shadow-emerald-500/30 flex items-center justify-center gap-2 active:scale-[0.98] transition-all"
                                 onClick={() => handleSubmit(true)}
-                                disabled={loading}
+                                disabled={loading || (!!eventIdParam && !version)}
                             >
                                 <Send className="w-4 h-4" /> {loading ? "Publishing..." : "Publish Event Now"}
                             </Button>
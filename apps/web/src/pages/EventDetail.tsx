@@ -5,7 +5,7 @@ import { bffClient } from "@/api/bff/client";
 import { LoadingState } from "@/components/LoadingState";
 import { ErrorState } from "@/components/ErrorState";
 import { ActionButtons } from "@/components/ActionButtons";
-import { getPublicUrl } from "@/lib/mediaApi";
+import { getPublicUrl, placeholderStyle } from "@/lib/mediaApi";
 import { Calendar, MapPin, Users, User, ArrowLeft, AlertCircle, FileText, ChevronLeft, ChevronRight } from "lucide-react";
 import { Button } from "@/components/ui/button";
 
@@ -101,7 +101,10 @@ export function EventDetail() {
                     <div className="grid grid-cols-1 lg:grid-cols-3 gap-12 items-center">
                         {/* Cover Image Carousel */}
                         <div className="aspect-[4/3] glass-card rounded-3xl lg:col-span-1 shadow-2xl p-2 group overflow-hidden relative">
-                            <div className="w-full h-full rounded-2xl overflow-hidden bg-emerald-100 dark:bg-emerald-950/50 flex items-center justify-center relative">
+                            <div
+                                className="w-full h-full rounded-2xl overflow-hidden bg-emerald-100 dark:bg-emerald-950/50 flex items-center justify-center relative"
+                                style={placeholderStyle(event.cover_placeholders, event.cover_image_ids?.[currentImageIndex])}
+                            >
                                 {coverImages.length > 0 ? (
                                     <>
                                         <img
@@ -215,6 +218,7 @@ export function EventDetail() {
                             <div className="pt-10">
                                 <ActionButtons
                                     eventId={event.id}
+                                    eventVersion={event.version}
                                     status={participation?.status || 'none'}
                                     canJoin={actions.can_join && !degraded?.participation} // 5.1 Write Blocking
                                     canCancel={actions.can_cancel && !degraded?.participation}
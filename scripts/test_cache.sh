@@ -66,6 +66,8 @@ CREATE_RESP=$(curl -s -X POST "$EVENT_HOST/event/v1/events" \
   }')
 
 EVENT_ID=$(echo "$CREATE_RESP" | jq -r '.data.id')
+# 写操作需要 If-Match，版本号即 ETag
+EVENT_VERSION=$(echo "$CREATE_RESP" | jq -r '.data.version')
 
 if [ "$EVENT_ID" == "null" ] || [ -z "$EVENT_ID" ]; then
     echo "Create Response: $CREATE_RESP"
@@ -75,12 +77,15 @@ success "Event created. ID: $EVENT_ID"
 
 # 3. 发布活动 (Publish)
 log "3. Publishing Event..."
-PUB_CODE=$(curl -s -o /dev/null -w "%{http_code}" -X POST "$EVENT_HOST/event/v1/events/$EVENT_ID/publish" \
-  -H "Authorization: Bearer $TOKEN")
+PUB_RESP=$(curl -s -w "\n%{http_code}" -X POST "$EVENT_HOST/event/v1/events/$EVENT_ID/publish" \
+  -H "Authorization: Bearer $TOKEN" \
+  -H "If-Match: \"$EVENT_VERSION\"")
+PUB_CODE=$(echo "$PUB_RESP" | tail -n 1)
 
 if [ "$PUB_CODE" -ne 200 ]; then
     error "Publish failed with code $PUB_CODE"
 fi
+EVENT_VERSION=$(echo "$PUB_RESP" | sed '$d' | jq -r '.data.version')
 success "Event published."
 
 # 4. 获取详情 - 第一次 (Cache Miss & Set)
@@ -105,6 +110,7 @@ log "6. Updating Event Title (Expect Cache INVALIDATE)"
 NEW_TITLE="Auto Test Event - UPDATED"
 UPDATE_CODE=$(curl -s -o /dev/null -w "%{http_code}" -X PATCH "$EVENT_HOST/event/v1/events/$EVENT_ID" \
   -H "Authorization: Bearer $TOKEN" \
+  -H "If-Match: \"$EVENT_VERSION\"" \
   -H "Content-Type: application/json" \
   -d "{\"title\": \"$NEW_TITLE\"}")
 
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Account events go through the outbox (see AccountOutbox) and use the
+// envelope consumed by event-service and join-service.
+const (
+	RoutingKeyUserBanned   = "auth.user.banned"
+	RoutingKeyUserUnbanned = "auth.user.unbanned"
+
+	RoutingKeyDeletionRequested = "auth.user.deletion_requested"
+	RoutingKeyExportRequested   = "auth.user.export_requested"
+
+	// Replies from the services taking part in a data request.
+	RoutingKeyUserDataDeleted  = "user.data.deleted"
+	RoutingKeyUserDataExported = "user.data.exported"
+
+	accountEventVersion  = 1
+	accountEventProducer = "auth-service"
+)
+
+type AccountEventEnvelope[T any] struct {
+	Version    int       `json:"version"`
+	Producer   string    `json:"producer"`
+	MessageID  string    `json:"message_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Payload    T         `json:"payload"`
+}
+
+// UserBannedEvent is the payload of auth.user.banned.
+type UserBannedEvent struct {
+	UserID   string    `json:"user_id"`
+	ActorID  string    `json:"actor_id,omitempty"`
+	BannedAt time.Time `json:"banned_at"`
+}
+
+// UserUnbannedEvent is the payload of auth.user.unbanned. Consumers do not
+// restore anything on unban; it is informational.
+type UserUnbannedEvent struct {
+	UserID     string    `json:"user_id"`
+	ActorID    string    `json:"actor_id,omitempty"`
+	UnbannedAt time.Time `json:"unbanned_at"`
+}
+
+// DataRequestedEvent is the payload of auth.user.deletion_requested and
+// auth.user.export_requested. Each service answers with user.data.deleted
+// or user.data.exported carrying the same request_id.
+type DataRequestedEvent struct {
+	RequestID   string    `json:"request_id"`
+	UserID      string    `json:"user_id"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// DataRequestReply is the payload of user.data.deleted / user.data.exported.
+// Data is the service's export part and is empty for deletions.
+type DataRequestReply struct {
+	RequestID string          `json:"request_id"`
+	UserID    string          `json:"user_id"`
+	Service   string          `json:"service"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+func newOutboxMessage[T any](routingKey, userID string, payload T, now time.Time) (OutboxMessage, error) {
+	messageID := uuid.NewString()
+	body, err := json.Marshal(AccountEventEnvelope[T]{
+		Version:    accountEventVersion,
+		Producer:   accountEventProducer,
+		MessageID:  messageID,
+		OccurredAt: now,
+		Payload:    payload,
+	})
+	if err != nil {
+		return OutboxMessage{}, err
+	}
+	return OutboxMessage{
+		MessageID:   messageID,
+		RoutingKey:  routingKey,
+		AggregateID: userID,
+		Body:        body,
+		CreatedAt:   now,
+	}, nil
+}
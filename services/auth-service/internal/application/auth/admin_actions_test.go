@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -96,6 +97,47 @@ func TestBanUser_Success_LocksUser_AuditsSuccess(t *testing.T) {
 	requireAuditField(t, e, "result", "success")
 }
 
+func TestBanUser_WithOutbox_RecordsEventOnce(t *testing.T) {
+	t.Parallel()
+
+	svc, users, _, _, _, _, _, _ := newSvcForTest(t)
+	outbox := &fakeOutbox{users: users}
+	svc.WithOutbox(outbox)
+	users.byID["u1"] = domain.User{ID: "u1", Email: "u@x.com", Role: "user"}
+	users.byEmail["u@x.com"] = users.byID["u1"]
+
+	for i := 0; i < 2; i++ {
+		if err := svc.BanUser(context.Background(), "admin1", string(domain.RoleAdmin), "u1"); err != nil {
+			t.Fatalf("ban #%d: expected nil, got %v", i+1, err)
+		}
+	}
+	if !users.byID["u1"].Locked {
+		t.Fatalf("expected locked=true")
+	}
+	if len(outbox.msgs) != 1 {
+		t.Fatalf("expected one outbox message for a repeated ban, got %d", len(outbox.msgs))
+	}
+
+	msg := outbox.msgs[0]
+	if msg.RoutingKey != RoutingKeyUserBanned {
+		t.Fatalf("routing key = %q", msg.RoutingKey)
+	}
+	var env AccountEventEnvelope[UserBannedEvent]
+	if err := json.Unmarshal(msg.Body, &env); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if env.Version != 1 || env.MessageID != msg.MessageID || env.Payload.UserID != "u1" || env.Payload.ActorID != "admin1" {
+		t.Fatalf("unexpected envelope: %+v", env)
+	}
+
+	if err := svc.UnbanUser(context.Background(), "admin1", string(domain.RoleAdmin), "u1"); err != nil {
+		t.Fatalf("unban: expected nil, got %v", err)
+	}
+	if users.byID["u1"].Locked || len(outbox.msgs) != 2 || outbox.msgs[1].RoutingKey != RoutingKeyUserUnbanned {
+		t.Fatalf("expected unlocked user and an auth.user.unbanned message, got %+v", outbox.msgs)
+	}
+}
+
 func TestUnbanUser_InsufficientRole(t *testing.T) {
 	t.Parallel()
 
@@ -26,6 +26,13 @@ func (s *Service) UpdateAvatarURL(ctx context.Context, userID, avatarURL string)
 		}
 	}
 
+	// The upload must be the user's own READY avatar; claiming is idempotent
+	if avatarID != nil && s.media != nil {
+		if err := s.media.ClaimUpload(ctx, userID, "avatar", *avatarID); err != nil {
+			return "", err
+		}
+	}
+
 	// Update DB
 	prevID, err := s.users.UpdateAvatarImageID(ctx, userID, avatarID)
 	if err != nil {
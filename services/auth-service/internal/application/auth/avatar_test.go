@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/domain"
+)
+
+// fakeMedia rejects every id listed in bad and records the rest.
+type fakeMedia struct {
+	bad     map[string]bool
+	claimed []string
+}
+
+func (f *fakeMedia) ClaimUpload(ctx context.Context, ownerID, purpose, id string) error {
+	if purpose != "avatar" || f.bad[id] {
+		return domain.ErrInvalidField("avatar_url", "not_owner")
+	}
+	f.claimed = append(f.claimed, ownerID+":"+id)
+	return nil
+}
+
+func TestUpdateAvatarURL_ClaimsUpload(t *testing.T) {
+	t.Parallel()
+
+	const own = "0b4cdb0e-6a0e-4d5b-9d0b-4b7ad5b1f0a1"
+	const foreign = "5f3f7c4e-2f6d-4f57-8f39-1cbe0c6e9a02"
+
+	svc, _, _, _, _, _, _, _ := newSvcForTest(t)
+	media := &fakeMedia{bad: map[string]bool{foreign: true}}
+	svc.WithMediaChecker(media)
+	ctx := context.Background()
+
+	_, err := svc.UpdateAvatarURL(ctx, "u1", "https://cdn/avatars/"+foreign+"/512.webp")
+	requireErrCode(t, err, "invalid_field")
+
+	if _, err := svc.UpdateAvatarURL(ctx, "u1", "https://cdn/avatars/"+own); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(media.claimed) != 1 || media.claimed[0] != "u1:"+own {
+		t.Fatalf("expected own upload claimed, got %v", media.claimed)
+	}
+}
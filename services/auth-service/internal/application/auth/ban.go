@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/baechuer/real-time-ressys/services/auth-service/internal/domain"
 )
@@ -80,7 +81,7 @@ func (s *Service) BanUser(ctx context.Context, actorID, actorRole, targetUserID
 	}
 
 	// Execute lock first, then audit
-	if err := s.users.LockUser(ctx, targetUserID); err != nil {
+	if err := s.setLocked(ctx, targetUserID, true, actorID); err != nil {
 		audit("error", err, nil)
 		return err
 	}
@@ -88,3 +89,36 @@ func (s *Service) BanUser(ctx context.Context, actorID, actorRole, targetUserID
 	audit("success", nil, map[string]string{"target_role": target.Role})
 	return nil
 }
+
+// setLocked locks or unlocks the account. With an outbox the change and its
+// auth.user.banned / auth.user.unbanned event commit together; repeating a
+// ban or unban records no second event.
+func (s *Service) setLocked(ctx context.Context, userID string, locked bool, actorID string) error {
+	if s.outbox == nil {
+		if locked {
+			return s.users.LockUser(ctx, userID)
+		}
+		return s.users.UnlockUser(ctx, userID)
+	}
+
+	now := time.Now().UTC()
+	var (
+		msg OutboxMessage
+		err error
+	)
+	if locked {
+		msg, err = newOutboxMessage(RoutingKeyUserBanned, userID, UserBannedEvent{
+			UserID: userID, ActorID: actorID, BannedAt: now,
+		}, now)
+	} else {
+		msg, err = newOutboxMessage(RoutingKeyUserUnbanned, userID, UserUnbannedEvent{
+			UserID: userID, ActorID: actorID, UnbannedAt: now,
+		}, now)
+	}
+	if err != nil {
+		return domain.ErrInternal(err)
+	}
+
+	_, err = s.outbox.SetLockedWithEvent(ctx, userID, locked, msg)
+	return err
+}
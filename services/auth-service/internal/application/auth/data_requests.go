@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/domain"
+)
+
+// DataExportArchive is the downloadable result of a data export: one part
+// per service, keyed by service name.
+type DataExportArchive struct {
+	RequestID   string                     `json:"request_id"`
+	UserID      string                     `json:"user_id"`
+	GeneratedAt time.Time                  `json:"generated_at"`
+	Services    map[string]json.RawMessage `json:"services"`
+}
+
+// RequestAccountDeletion starts the deletion saga. Password accounts must
+// confirm with their password. The account is locked and every session
+// revoked right away; the user row is anonymized once all services have
+// acknowledged auth.user.deletion_requested.
+func (s *Service) RequestAccountDeletion(ctx context.Context, userID, password string) (domain.DataRequest, error) {
+	const action = "account.deletion_requested"
+
+	if s.dataRequests == nil {
+		return domain.DataRequest{}, domain.ErrNotImplemented()
+	}
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return domain.DataRequest{}, domain.ErrTokenMissing()
+	}
+
+	u, err := s.users.GetByID(ctx, userID)
+	if err != nil {
+		return domain.DataRequest{}, err
+	}
+	if u.PasswordHash != "" {
+		if password == "" {
+			return domain.DataRequest{}, domain.ErrMissingField("password")
+		}
+		if err := s.hasher.Compare(u.PasswordHash, password); err != nil {
+			return domain.DataRequest{}, domain.ErrInvalidCredentials()
+		}
+	}
+
+	req, created, err := s.createDataRequest(ctx, userID, domain.DataRequestDeletion, RoutingKeyDeletionRequested)
+	if err != nil {
+		s.audit(action, map[string]string{"user_id": userID, "result": "error", "error_code": domainCode(err)})
+		return domain.DataRequest{}, err
+	}
+	if !created {
+		return req, nil
+	}
+
+	// Through s.users so a cached token version is invalidated as well.
+	if _, err := s.users.BumpTokenVersion(ctx, userID); err != nil {
+		return domain.DataRequest{}, err
+	}
+	_ = s.sessions.RevokeAll(ctx, userID)
+
+	s.audit(action, map[string]string{"user_id": userID, "request_id": req.ID, "result": "success"})
+	return req, nil
+}
+
+// RequestDataExport starts the export saga. The archive can be downloaded
+// once every service has sent its part.
+func (s *Service) RequestDataExport(ctx context.Context, userID string) (domain.DataRequest, error) {
+	if s.dataRequests == nil {
+		return domain.DataRequest{}, domain.ErrNotImplemented()
+	}
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return domain.DataRequest{}, domain.ErrTokenMissing()
+	}
+	if _, err := s.users.GetByID(ctx, userID); err != nil {
+		return domain.DataRequest{}, err
+	}
+
+	req, created, err := s.createDataRequest(ctx, userID, domain.DataRequestExport, RoutingKeyExportRequested)
+	if err != nil {
+		return domain.DataRequest{}, err
+	}
+	if created {
+		s.audit("account.export_requested", map[string]string{"user_id": userID, "request_id": req.ID, "result": "success"})
+	}
+	return req, nil
+}
+
+func (s *Service) createDataRequest(ctx context.Context, userID string, kind domain.DataRequestKind, routingKey string) (domain.DataRequest, bool, error) {
+	now := time.Now().UTC()
+	req := domain.DataRequest{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Kind:      kind,
+		Status:    domain.DataRequestPending,
+		Services:  append([]string(nil), s.dataServices...),
+		CreatedAt: now,
+	}
+
+	msg, err := newOutboxMessage(routingKey, userID, DataRequestedEvent{
+		RequestID: req.ID, UserID: userID, RequestedAt: now,
+	}, now)
+	if err != nil {
+		return domain.DataRequest{}, false, domain.ErrInternal(err)
+	}
+	return s.dataRequests.CreateDataRequest(ctx, req, msg)
+}
+
+// GetDataRequest returns one of the user's own data requests.
+func (s *Service) GetDataRequest(ctx context.Context, userID, requestID string) (domain.DataRequest, error) {
+	if s.dataRequests == nil {
+		return domain.DataRequest{}, domain.ErrNotImplemented()
+	}
+	req, err := s.dataRequests.GetDataRequest(ctx, strings.TrimSpace(requestID))
+	if err != nil {
+		return domain.DataRequest{}, err
+	}
+	if req.UserID != userID {
+		return domain.DataRequest{}, domain.ErrDataRequestNotFound()
+	}
+	return req, nil
+}
+
+// AdminGetDataRequest returns any data request; deleted users can no longer
+// sign in to check on theirs.
+func (s *Service) AdminGetDataRequest(ctx context.Context, requestID string) (domain.DataRequest, error) {
+	if s.dataRequests == nil {
+		return domain.DataRequest{}, domain.ErrNotImplemented()
+	}
+	return s.dataRequests.GetDataRequest(ctx, strings.TrimSpace(requestID))
+}
+
+// ExportArchive assembles a completed export of the user's own data.
+func (s *Service) ExportArchive(ctx context.Context, userID, requestID string) (DataExportArchive, error) {
+	req, err := s.GetDataRequest(ctx, userID, requestID)
+	if err != nil {
+		return DataExportArchive{}, err
+	}
+	if req.Kind != domain.DataRequestExport {
+		return DataExportArchive{}, domain.ErrDataRequestNotFound()
+	}
+	if req.Status != domain.DataRequestCompleted {
+		return DataExportArchive{}, domain.ErrExportNotReady()
+	}
+
+	parts, err := s.dataRequests.ExportParts(ctx, req.ID)
+	if err != nil {
+		return DataExportArchive{}, err
+	}
+	return DataExportArchive{
+		RequestID:   req.ID,
+		UserID:      req.UserID,
+		GeneratedAt: *req.CompletedAt,
+		Services:    parts,
+	}, nil
+}
+
+// RecordDataRequestReply stores a service's user.data.deleted or
+// user.data.exported reply. Replies are idempotent per service.
+func (s *Service) RecordDataRequestReply(ctx context.Context, reply DataRequestReply) error {
+	if s.dataRequests == nil {
+		return domain.ErrNotImplemented()
+	}
+	reply.RequestID = strings.TrimSpace(reply.RequestID)
+	reply.Service = strings.TrimSpace(reply.Service)
+	if reply.RequestID == "" {
+		return domain.ErrMissingField("request_id")
+	}
+	if reply.Service == "" {
+		return domain.ErrMissingField("service")
+	}
+	if string(reply.Data) == "null" {
+		reply.Data = nil
+	}
+
+	req, completed, err := s.dataRequests.RecordAck(ctx, reply.RequestID, reply.Service, reply.Data, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if completed {
+		s.audit("account.data_request_completed", map[string]string{
+			"user_id":    req.UserID,
+			"request_id": req.ID,
+			"kind":       string(req.Kind),
+			"result":     "success",
+		})
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/domain"
+)
+
+func TestRequestAccountDeletion_RequiresPassword(t *testing.T) {
+	t.Parallel()
+
+	svc, users, _, _, _, _, _, _ := newSvcForTest(t)
+	store := newFakeDataRequests(users)
+	svc.WithDataRequests(store, []string{"join-service"})
+	users.byID["u1"] = domain.User{ID: "u1", Email: "u@x.com", Role: "user", PasswordHash: "hash:secret"}
+
+	_, err := svc.RequestAccountDeletion(context.Background(), "u1", "")
+	requireDomainCode(t, err, domainCode(domain.ErrMissingField("password")))
+
+	_, err = svc.RequestAccountDeletion(context.Background(), "u1", "wrong")
+	requireDomainCode(t, err, domainCode(domain.ErrInvalidCredentials()))
+
+	if len(store.msgs) != 0 || users.byID["u1"].Locked {
+		t.Fatalf("expected nothing recorded without a valid password")
+	}
+}
+
+func TestRequestAccountDeletion_LocksRevokesAndCompletesOnAcks(t *testing.T) {
+	t.Parallel()
+
+	svc, users, _, _, sessions, _, _, audits := newSvcForTest(t)
+	store := newFakeDataRequests(users)
+	svc.WithDataRequests(store, []string{"join-service", "event-service"})
+	users.byID["u1"] = domain.User{ID: "u1", Email: "u@x.com", Role: "user", PasswordHash: "hash:secret"}
+	if _, err := sessions.CreateRefreshToken(context.Background(), "u1", time.Hour); err != nil {
+		t.Fatalf("seed session: %v", err)
+	}
+
+	req, err := svc.RequestAccountDeletion(context.Background(), "u1", "secret")
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if !users.byID["u1"].Locked {
+		t.Fatalf("expected the account to be locked")
+	}
+	if len(sessions.byToken) != 0 {
+		t.Fatalf("expected every session revoked, got %d", len(sessions.byToken))
+	}
+
+	// A second request returns the pending one and records nothing new.
+	again, err := svc.RequestAccountDeletion(context.Background(), "u1", "secret")
+	if err != nil || again.ID != req.ID || len(store.msgs) != 1 {
+		t.Fatalf("expected the pending request back, got %+v err=%v msgs=%d", again, err, len(store.msgs))
+	}
+
+	msg := store.msgs[0]
+	var env AccountEventEnvelope[DataRequestedEvent]
+	if err := json.Unmarshal(msg.Body, &env); err != nil {
+		t.Fatalf("decode envelope: %v", err)
+	}
+	if msg.RoutingKey != RoutingKeyDeletionRequested || env.Payload.RequestID != req.ID || env.Payload.UserID != "u1" {
+		t.Fatalf("unexpected message %q %+v", msg.RoutingKey, env.Payload)
+	}
+
+	reply := DataRequestReply{RequestID: req.ID, UserID: "u1", Service: "join-service"}
+	for i := 0; i < 2; i++ { // redelivered reply
+		if err := svc.RecordDataRequestReply(context.Background(), reply); err != nil {
+			t.Fatalf("reply: %v", err)
+		}
+	}
+	if got, _ := store.GetDataRequest(context.Background(), req.ID); got.Status != domain.DataRequestPending {
+		t.Fatalf("expected pending until event-service acks, got %s", got.Status)
+	}
+
+	reply.Service = "event-service"
+	if err := svc.RecordDataRequestReply(context.Background(), reply); err != nil {
+		t.Fatalf("reply: %v", err)
+	}
+	got, _ := store.GetDataRequest(context.Background(), req.ID)
+	if got.Status != domain.DataRequestCompleted || len(got.PendingServices()) != 0 {
+		t.Fatalf("expected completed, got %+v", got)
+	}
+	e := requireAuditAction(t, audits, "account.data_request_completed")
+	requireAuditField(t, e, "kind", string(domain.DataRequestDeletion))
+}
+
+func TestExportArchive_NotReadyThenAssembled(t *testing.T) {
+	t.Parallel()
+
+	svc, users, _, _, _, _, _, _ := newSvcForTest(t)
+	store := newFakeDataRequests(users)
+	svc.WithDataRequests(store, []string{"join-service"})
+	users.byID["u1"] = domain.User{ID: "u1", Email: "u@x.com", Role: "user"}
+
+	req, err := svc.RequestDataExport(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if users.byID["u1"].Locked {
+		t.Fatalf("an export must not lock the account")
+	}
+
+	_, err = svc.ExportArchive(context.Background(), "u1", req.ID)
+	requireDomainCode(t, err, domainCode(domain.ErrExportNotReady()))
+
+	_, err = svc.ExportArchive(context.Background(), "someone-else", req.ID)
+	requireDomainCode(t, err, domainCode(domain.ErrDataRequestNotFound()))
+
+	err = svc.RecordDataRequestReply(context.Background(), DataRequestReply{
+		RequestID: req.ID, UserID: "u1", Service: "join-service",
+		Data: json.RawMessage(`{"joins":[]}`),
+	})
+	if err != nil {
+		t.Fatalf("reply: %v", err)
+	}
+
+	archive, err := svc.ExportArchive(context.Background(), "u1", req.ID)
+	if err != nil {
+		t.Fatalf("expected archive, got %v", err)
+	}
+	if string(archive.Services["join-service"]) != `{"joins":[]}` || archive.UserID != "u1" {
+		t.Fatalf("unexpected archive %+v", archive)
+	}
+}
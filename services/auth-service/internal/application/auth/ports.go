@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/baechuer/real-time-ressys/services/auth-service/internal/domain"
@@ -129,6 +130,60 @@ type AvatarUpdatedEvent struct {
 	OldAvatarID string `json:"old_avatar_id"`
 }
 
+/*
+MediaChecker
+------------
+Claims an upload in media-service before it becomes a user's avatar. It
+fails with a validation error when the upload is missing, belongs to
+someone else, is not an avatar or is not READY; on success media-service
+keeps the upload from being cleaned up.
+*/
+type MediaChecker interface {
+	ClaimUpload(ctx context.Context, ownerID, purpose, id string) error
+}
+
+/*
+AccountOutbox
+-------------
+Changes an account's locked flag and records the matching account event
+in the same transaction; an outbox relay publishes it afterwards, so a ban
+reaches other services even if RabbitMQ is down at the time.
+changed is false (and nothing is recorded) when the account was already
+in the requested state.
+*/
+type AccountOutbox interface {
+	SetLockedWithEvent(ctx context.Context, userID string, locked bool, msg OutboxMessage) (changed bool, err error)
+}
+
+type OutboxMessage struct {
+	MessageID   string
+	RoutingKey  string
+	AggregateID string // user the event is about; relayed in order per user
+	Body        []byte // full envelope JSON
+	CreatedAt   time.Time
+}
+
+/*
+DataRequestStore
+----------------
+Persistence port for account deletion / data export sagas.
+CreateDataRequest records the request and its outbox message in one
+transaction and returns the user's pending request of the same kind instead
+when there is one (created=false). A deletion also locks the account and
+the service then bumps its token version; an export
+stores auth-service's own part (users + oauth_identities) up front.
+RecordAck stores a service's acknowledgment (with its export part, if any);
+once every required service has acknowledged the request is completed and,
+for deletions, the user row is anonymized in the same transaction.
+completed is true only for the acknowledgment that completed it.
+*/
+type DataRequestStore interface {
+	CreateDataRequest(ctx context.Context, req domain.DataRequest, msg OutboxMessage) (out domain.DataRequest, created bool, err error)
+	GetDataRequest(ctx context.Context, id string) (domain.DataRequest, error)
+	RecordAck(ctx context.Context, requestID, service string, part []byte, at time.Time) (req domain.DataRequest, completed bool, err error)
+	ExportParts(ctx context.Context, requestID string) (map[string]json.RawMessage, error)
+}
+
 /*
 OAuthIdentityRepo
 -----------------
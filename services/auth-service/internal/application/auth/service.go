@@ -17,6 +17,11 @@ type Service struct {
 	sessions SessionStore
 	ott      OneTimeTokenStore
 	pub      EventPublisher
+	outbox   AccountOutbox // optional; without it bans publish nothing
+	media    MediaChecker  // optional; without it avatar ids are not checked
+
+	dataRequests DataRequestStore // optional; deletion/export return 501 without it
+	dataServices []string         // services that must acknowledge a data request
 
 	accessTTL  time.Duration
 	refreshTTL time.Duration
@@ -100,6 +105,27 @@ func (s *Service) WithAudit(fn func(action string, fields map[string]string)) *S
 	return s
 }
 
+// WithOutbox makes ban/unban record auth.user.banned / auth.user.unbanned
+// through the outbox.
+func (s *Service) WithOutbox(o AccountOutbox) *Service {
+	s.outbox = o
+	return s
+}
+
+// WithMediaChecker makes avatar updates claim the upload in media-service.
+func (s *Service) WithMediaChecker(m MediaChecker) *Service {
+	s.media = m
+	return s
+}
+
+// WithDataRequests enables account deletion and data export. services are
+// the services that must acknowledge each request before it completes.
+func (s *Service) WithDataRequests(store DataRequestStore, services []string) *Service {
+	s.dataRequests = store
+	s.dataServices = services
+	return s
+}
+
 // issueTokens issues an access token + refresh token for a user.
 func (s *Service) issueTokens(ctx context.Context, userID, role string) (AuthTokens, error) {
 	access, err := s.signer.SignAccessToken(userID, role, s.accessTTL)
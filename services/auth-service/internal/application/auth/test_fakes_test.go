@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -444,6 +445,107 @@ func (p *fakePublisher) PublishAvatarUpdated(ctx context.Context, evt AvatarUpda
 	return nil
 }
 
+// fakeOutbox flips the fake user's locked flag and keeps the messages it
+// would have committed with it.
+type fakeOutbox struct {
+	users *fakeUserRepo
+	msgs  []OutboxMessage
+}
+
+func (o *fakeOutbox) SetLockedWithEvent(ctx context.Context, userID string, locked bool, msg OutboxMessage) (bool, error) {
+	o.users.mu.Lock()
+	defer o.users.mu.Unlock()
+
+	u, ok := o.users.byID[userID]
+	if !ok {
+		return false, domain.ErrUserNotFound()
+	}
+	if u.Locked == locked {
+		return false, nil
+	}
+	u.Locked = locked
+	o.users.byID[userID] = u
+	o.users.byEmail[u.Email] = u
+	o.msgs = append(o.msgs, msg)
+	return true, nil
+}
+
+// fakeDataRequests keeps data requests in memory; like the postgres store
+// it locks the user on a deletion and completes a request once every
+// service has acknowledged.
+type fakeDataRequests struct {
+	users *fakeUserRepo
+
+	reqs  map[string]domain.DataRequest
+	parts map[string]map[string]json.RawMessage
+	msgs  []OutboxMessage
+}
+
+func newFakeDataRequests(users *fakeUserRepo) *fakeDataRequests {
+	return &fakeDataRequests{
+		users: users,
+		reqs:  map[string]domain.DataRequest{},
+		parts: map[string]map[string]json.RawMessage{},
+	}
+}
+
+func (f *fakeDataRequests) CreateDataRequest(ctx context.Context, req domain.DataRequest, msg OutboxMessage) (domain.DataRequest, bool, error) {
+	for _, r := range f.reqs {
+		if r.UserID == req.UserID && r.Kind == req.Kind && r.Status == domain.DataRequestPending {
+			return r, false, nil
+		}
+	}
+	if req.Kind == domain.DataRequestDeletion {
+		f.users.mu.Lock()
+		u := f.users.byID[req.UserID]
+		u.Locked = true
+		f.users.byID[req.UserID] = u
+		f.users.mu.Unlock()
+	}
+	req.Acked = []string{}
+	f.reqs[req.ID] = req
+	f.parts[req.ID] = map[string]json.RawMessage{}
+	f.msgs = append(f.msgs, msg)
+	return req, true, nil
+}
+
+func (f *fakeDataRequests) GetDataRequest(ctx context.Context, id string) (domain.DataRequest, error) {
+	r, ok := f.reqs[id]
+	if !ok {
+		return domain.DataRequest{}, domain.ErrDataRequestNotFound()
+	}
+	return r, nil
+}
+
+func (f *fakeDataRequests) RecordAck(ctx context.Context, requestID, service string, part []byte, at time.Time) (domain.DataRequest, bool, error) {
+	r, ok := f.reqs[requestID]
+	if !ok {
+		return domain.DataRequest{}, false, domain.ErrDataRequestNotFound()
+	}
+	if _, seen := f.parts[requestID][service]; !seen {
+		r.Acked = append(r.Acked, service)
+		f.parts[requestID][service] = part
+	}
+	completed := false
+	if r.Status == domain.DataRequestPending && len(r.PendingServices()) == 0 {
+		r.Status = domain.DataRequestCompleted
+		r.CompletedAt = &at
+		completed = true
+	}
+	f.reqs[requestID] = r
+	return r, completed, nil
+}
+
+func (f *fakeDataRequests) ExportParts(ctx context.Context, requestID string) (map[string]json.RawMessage, error) {
+	out := map[string]json.RawMessage{}
+	for k, v := range f.parts[requestID] {
+		if len(v) > 0 {
+			out[k] = v
+		}
+	}
+	return out, nil
+}
+
 /*
 Service factory for tests
 */
@@ -93,7 +93,7 @@ func (s *Service) UnbanUser(
 	}
 
 	// --- apply change ---
-	if err := s.users.UnlockUser(ctx, targetUserID); err != nil {
+	if err := s.setLocked(ctx, targetUserID, false, actorID); err != nil {
 		audit("error", err, nil)
 		return err
 	}
@@ -11,6 +11,7 @@ import (
 	"github.com/baechuer/real-time-ressys/services/auth-service/internal/config"
 	"github.com/baechuer/real-time-ressys/services/auth-service/internal/domain"
 	"github.com/baechuer/real-time-ressys/services/auth-service/internal/infrastructure/db/postgres"
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/infrastructure/mediaservice"
 	"github.com/baechuer/real-time-ressys/services/auth-service/internal/infrastructure/memory"
 	rabbitmq_pub "github.com/baechuer/real-time-ressys/services/auth-service/internal/infrastructure/messaging/rabbitmq"
 	"github.com/baechuer/real-time-ressys/services/auth-service/internal/infrastructure/oauth"
@@ -207,6 +208,24 @@ func newServer(deps Deps) (*http.Server, func(), error) {
 		},
 	)
 
+	authSvc = authSvc.WithOutbox(userRepo).
+		WithDataRequests(postgres.NewDataRequestRepo(sqlDB), cfg.DataRequestServices).
+		WithMediaChecker(mediaservice.NewClient(cfg.MediaServiceURL, cfg.InternalSecret))
+
+	// 7b) outbox relay: publishes account events (bans, data requests)
+	if op, ok := pub.(postgres.OutboxPublisher); ok {
+		relayCtx, stopRelay := context.WithCancel(context.Background())
+		postgres.NewOutboxRelay(sqlDB, op, cfg.OutboxPollInterval).Start(relayCtx)
+		cleanupFns = append(cleanupFns, stopRelay)
+	}
+
+	// 7c) replies to deletion / export requests
+	if _, ok := pub.(*rabbitmq_pub.Publisher); ok {
+		consumerCtx, stopConsumer := context.WithCancel(context.Background())
+		rabbitmq_pub.NewConsumer(cfg.RabbitURL, cfg.RabbitExchange, authSvc).Start(consumerCtx)
+		cleanupFns = append(cleanupFns, stopConsumer)
+	}
+
 	authSvc = authSvc.WithAudit(func(action string, fields map[string]string) {
 		evt := logger.Logger.Info().
 			Bool("audit", true).
@@ -279,6 +298,7 @@ func newServer(deps Deps) (*http.Server, func(), error) {
 		RLSessionsRevoke:       rl("auth.sessions.revoke", 5, time.Minute),
 		RLModActions:           rl("auth.mod.actions", 30, time.Minute),
 		RLAdminActions:         rl("auth.admin.actions", 60, time.Minute),
+		RLDataRequests:         rl("auth.data_requests", 3, time.Hour),
 	})
 	if err != nil {
 		runCleanup(cleanupFns)
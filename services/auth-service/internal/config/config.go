@@ -31,6 +31,12 @@ type Config struct {
 	RabbitURL      string
 	RabbitExchange string
 
+	// Outbox relay (account events: bans, data requests)
+	OutboxPollInterval time.Duration
+
+	// Services that must acknowledge account deletion / data export requests
+	DataRequestServices []string
+
 	// Cache tuning
 	TokenVersionCacheTTL time.Duration
 
@@ -53,7 +59,8 @@ type Config struct {
 	AllowedRedirects   []string      // whitelist for redirect_to
 
 	// Media
-	CDNBaseURL string
+	CDNBaseURL      string
+	MediaServiceURL string // avatar uploads are claimed there (X-Internal-Secret)
 
 	// Debug toggles
 	DBDebug bool
@@ -144,11 +151,17 @@ func Load() (*Config, error) {
 		return nil, err
 	}
 
+	cfg.OutboxPollInterval, err = getDuration("OUTBOX_POLL_INTERVAL", 2*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
 	cfg.RabbitURL = strings.TrimSpace(os.Getenv("RABBIT_URL"))
 	if cfg.RabbitURL == "" {
 		return nil, fmt.Errorf("missing required env var: RABBIT_URL")
 	}
 	cfg.RabbitExchange = getEnv("RABBIT_EXCHANGE", "city.events")
+	cfg.DataRequestServices = parseStringList(getEnv("DATA_REQUEST_SERVICES", "event-service,join-service,feed-service,media-worker,email-service"))
 
 	// Timeouts (optional)
 	cfg.HTTPReadTimeout, err = getDuration("HTTP_READ_TIMEOUT", 10*time.Second)
@@ -178,6 +191,7 @@ func Load() (*Config, error) {
 	cfg.FrontendOrigin = getEnv("FRONTEND_ORIGIN", "http://localhost:3000")
 	cfg.AllowedRedirects = parseStringList(getEnv("ALLOWED_REDIRECTS", "/,/events,/profile,/settings"))
 	cfg.CDNBaseURL = getEnv("CDN_BASE_URL", "http://localhost:9000/public")
+	cfg.MediaServiceURL = getEnv("MEDIA_SERVICE_URL", "http://localhost:8085")
 
 	return cfg, nil
 }
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// DataRequestKind is what a user asked to be done with their personal data.
+type DataRequestKind string
+
+const (
+	DataRequestDeletion DataRequestKind = "deletion"
+	DataRequestExport   DataRequestKind = "export"
+)
+
+type DataRequestStatus string
+
+const (
+	DataRequestPending   DataRequestStatus = "pending"
+	DataRequestCompleted DataRequestStatus = "completed"
+)
+
+// DataRequest tracks one deletion or export saga. Every service in Services
+// must acknowledge before the request completes.
+type DataRequest struct {
+	ID          string
+	UserID      string
+	Kind        DataRequestKind
+	Status      DataRequestStatus
+	Services    []string // services that must acknowledge
+	Acked       []string // services that have acknowledged
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
+
+// PendingServices lists the services that have not acknowledged yet.
+func (r DataRequest) PendingServices() []string {
+	acked := make(map[string]bool, len(r.Acked))
+	for _, s := range r.Acked {
+		acked[s] = true
+	}
+	out := []string{}
+	for _, s := range r.Services {
+		if !acked[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -154,6 +154,10 @@ func ErrVerifyTokenNotFound() *Error {
 	return New(KindNotFound, "verify_token_not_found", "verification token not found")
 }
 
+func ErrDataRequestNotFound() *Error {
+	return New(KindNotFound, "data_request_not_found", "data request not found")
+}
+
 // ----------------------
 // Conflict (409)
 // ----------------------
@@ -166,6 +170,10 @@ func ErrUsernameAlreadyExists() *Error {
 	return New(KindConflict, "username_already_exists", "username already registered")
 }
 
+func ErrExportNotReady() *Error {
+	return New(KindConflict, "export_not_ready", "export is still being assembled")
+}
+
 func ErrAccountLocked() *Error {
 	return New(KindForbidden, "account_locked", "account locked")
 }
@@ -0,0 +1,292 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/application/auth"
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/domain"
+)
+
+// authServiceName is the key of auth-service's own part of an export.
+const authServiceName = "auth-service"
+
+// DataRequestRepo implements auth.DataRequestStore.
+type DataRequestRepo struct {
+	db *sql.DB
+}
+
+func NewDataRequestRepo(db *sql.DB) *DataRequestRepo {
+	return &DataRequestRepo{db: db}
+}
+
+type sqlQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+const dataRequestColumns = `id, user_id, kind, status, services, created_at, completed_at`
+
+// authExportPartSQL is auth-service's section of an export: the account
+// and its linked OAuth identities. Secrets (password hash, token version)
+// are left out.
+const authExportPartSQL = `
+SELECT jsonb_build_object(
+  'user', jsonb_build_object(
+    'id', u.id,
+    'email', u.email,
+    'username', u.username,
+    'role', u.role,
+    'email_verified', u.email_verified,
+    'avatar_image_id', u.avatar_image_id,
+    'created_at', u.created_at
+  ),
+  'oauth_identities', COALESCE((
+    SELECT jsonb_agg(jsonb_build_object(
+      'provider', o.provider,
+      'email', o.email,
+      'created_at', o.created_at
+    ) ORDER BY o.created_at)
+    FROM oauth_identities o
+    WHERE o.user_id = u.id
+  ), '[]'::jsonb)
+)
+FROM users u
+WHERE u.id = $1
+`
+
+func (r *DataRequestRepo) CreateDataRequest(ctx context.Context, req domain.DataRequest, msg auth.OutboxMessage) (domain.DataRequest, bool, error) {
+	req.UserID = strings.TrimSpace(req.UserID)
+	if req.UserID == "" {
+		return domain.DataRequest{}, false, domain.ErrMissingField("user_id")
+	}
+	services, err := json.Marshal(req.Services)
+	if err != nil {
+		return domain.DataRequest{}, false, domain.ErrInternal(err)
+	}
+	if req.Services == nil {
+		services = []byte("[]")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Lock the user row first: concurrent requests for the same user queue
+	// up here, and a missing user is reported before anything is written.
+	var exists bool
+	if err := tx.QueryRowContext(ctx, `SELECT TRUE FROM users WHERE id = $1 FOR UPDATE`, req.UserID).Scan(&exists); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.DataRequest{}, false, domain.ErrUserNotFound()
+		}
+		return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+	}
+
+	const ins = `
+INSERT INTO data_requests (id, user_id, kind, status, services, created_at)
+VALUES ($1, $2, $3, 'pending', $4, $5)
+ON CONFLICT (user_id, kind) WHERE status = 'pending' DO NOTHING;
+`
+	res, err := tx.ExecContext(ctx, ins, req.ID, req.UserID, req.Kind, services, req.CreatedAt)
+	if err != nil {
+		return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		existing, err := getDataRequest(ctx, tx, `
+SELECT `+dataRequestColumns+`
+FROM data_requests
+WHERE user_id = $1 AND kind = $2 AND status = 'pending'`, req.UserID, req.Kind)
+		if err != nil {
+			return domain.DataRequest{}, false, err
+		}
+		return existing, false, nil
+	}
+
+	switch req.Kind {
+	case domain.DataRequestDeletion:
+		if _, err := tx.ExecContext(ctx, `UPDATE users SET locked = TRUE WHERE id = $1`, req.UserID); err != nil {
+			return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+		}
+	case domain.DataRequestExport:
+		const part = `
+INSERT INTO data_request_acks (request_id, service, part, acked_at)
+VALUES ($1, $2, (` + authExportPartSQL + `), $3);
+`
+		if _, err := tx.ExecContext(ctx, part, req.ID, authServiceName, req.CreatedAt); err != nil {
+			return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+		}
+	}
+
+	const outbox = `
+INSERT INTO outbox (message_id, routing_key, aggregate_id, body, created_at)
+VALUES ($1, $2, $3, $4, $5);
+`
+	if _, err := tx.ExecContext(ctx, outbox, msg.MessageID, msg.RoutingKey, msg.AggregateID, msg.Body, msg.CreatedAt); err != nil {
+		return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+	}
+
+	// With no services to wait for the request is done straight away.
+	out, _, err := completeIfAcked(ctx, tx, req.ID, req.CreatedAt)
+	if err != nil {
+		return domain.DataRequest{}, false, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+	}
+	return out, true, nil
+}
+
+func (r *DataRequestRepo) GetDataRequest(ctx context.Context, id string) (domain.DataRequest, error) {
+	if id == "" {
+		return domain.DataRequest{}, domain.ErrDataRequestNotFound()
+	}
+	return getDataRequest(ctx, r.db, `SELECT `+dataRequestColumns+` FROM data_requests WHERE id = $1`, id)
+}
+
+func (r *DataRequestRepo) RecordAck(ctx context.Context, requestID, service string, part []byte, at time.Time) (domain.DataRequest, bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := getDataRequest(ctx, tx, `SELECT `+dataRequestColumns+` FROM data_requests WHERE id = $1 FOR UPDATE`, requestID); err != nil {
+		return domain.DataRequest{}, false, err
+	}
+
+	var partArg any
+	if len(part) > 0 {
+		partArg = part
+	}
+	const ins = `
+INSERT INTO data_request_acks (request_id, service, part, acked_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (request_id, service) DO NOTHING;
+`
+	if _, err := tx.ExecContext(ctx, ins, requestID, service, partArg, at); err != nil {
+		return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+	}
+
+	out, completed, err := completeIfAcked(ctx, tx, requestID, at)
+	if err != nil {
+		return domain.DataRequest{}, false, err
+	}
+	if err := tx.Commit(); err != nil {
+		return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+	}
+	return out, completed, nil
+}
+
+func (r *DataRequestRepo) ExportParts(ctx context.Context, requestID string) (map[string]json.RawMessage, error) {
+	rows, err := r.db.QueryContext(ctx, `
+SELECT service, part
+FROM data_request_acks
+WHERE request_id = $1 AND part IS NOT NULL
+ORDER BY service`, requestID)
+	if err != nil {
+		return nil, domain.ErrDBUnavailable(err)
+	}
+	defer rows.Close()
+
+	out := map[string]json.RawMessage{}
+	for rows.Next() {
+		var (
+			service string
+			part    []byte
+		)
+		if err := rows.Scan(&service, &part); err != nil {
+			return nil, domain.ErrDBUnavailable(err)
+		}
+		out[service] = json.RawMessage(part)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, domain.ErrDBUnavailable(err)
+	}
+	return out, nil
+}
+
+// completeIfAcked completes a pending request whose services have all
+// acknowledged; a completed deletion anonymizes the user in the same
+// transaction. completed reports whether this call completed it.
+func completeIfAcked(ctx context.Context, tx *sql.Tx, requestID string, now time.Time) (domain.DataRequest, bool, error) {
+	req, err := getDataRequest(ctx, tx, `SELECT `+dataRequestColumns+` FROM data_requests WHERE id = $1`, requestID)
+	if err != nil {
+		return domain.DataRequest{}, false, err
+	}
+	if req.Status != domain.DataRequestPending || len(req.PendingServices()) > 0 {
+		return req, false, nil
+	}
+
+	if req.Kind == domain.DataRequestDeletion {
+		const anonymize = `
+UPDATE users
+SET email = 'deleted+' || id::text || '@deleted.invalid',
+    username = NULL,
+    password_hash = '',
+    avatar_image_id = NULL,
+    email_verified = FALSE,
+    locked = TRUE,
+    token_version = token_version + 1
+WHERE id = $1;
+`
+		if _, err := tx.ExecContext(ctx, anonymize, req.UserID); err != nil {
+			return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM oauth_identities WHERE user_id = $1`, req.UserID); err != nil {
+			return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+		}
+	}
+
+	t := now.UTC()
+	if _, err := tx.ExecContext(ctx, `UPDATE data_requests SET status = 'completed', completed_at = $2 WHERE id = $1`, req.ID, t); err != nil {
+		return domain.DataRequest{}, false, domain.ErrDBUnavailable(err)
+	}
+	req.Status = domain.DataRequestCompleted
+	req.CompletedAt = &t
+	return req, true, nil
+}
+
+// getDataRequest loads one request and the services that acknowledged it.
+func getDataRequest(ctx context.Context, q sqlQueryer, query string, args ...any) (domain.DataRequest, error) {
+	var (
+		req      domain.DataRequest
+		services []byte
+	)
+	err := q.QueryRowContext(ctx, query, args...).Scan(
+		&req.ID, &req.UserID, &req.Kind, &req.Status, &services, &req.CreatedAt, &req.CompletedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.DataRequest{}, domain.ErrDataRequestNotFound()
+	}
+	if err != nil {
+		return domain.DataRequest{}, domain.ErrDBUnavailable(err)
+	}
+	if err := json.Unmarshal(services, &req.Services); err != nil {
+		return domain.DataRequest{}, domain.ErrInternal(err)
+	}
+
+	rows, err := q.QueryContext(ctx, `SELECT service FROM data_request_acks WHERE request_id = $1 ORDER BY acked_at, service`, req.ID)
+	if err != nil {
+		return domain.DataRequest{}, domain.ErrDBUnavailable(err)
+	}
+	defer rows.Close()
+	req.Acked = []string{}
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			return domain.DataRequest{}, domain.ErrDBUnavailable(err)
+		}
+		req.Acked = append(req.Acked, s)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.DataRequest{}, domain.ErrDBUnavailable(err)
+	}
+	return req, nil
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/logger"
+)
+
+const (
+	outboxBatchSize = 50
+	// Failed rows back off 2^attempts seconds, capped at 5 minutes.
+	outboxMaxBackoffSeconds = 300
+)
+
+// OutboxPublisher publishes an already-encoded outbox body.
+type OutboxPublisher interface {
+	PublishRaw(ctx context.Context, routingKey, messageID string, body []byte) error
+}
+
+// OutboxRelay publishes outbox rows in insertion order per user. Rows are
+// claimed with FOR UPDATE SKIP LOCKED, so several replicas can run it;
+// consumers dedupe on message_id.
+type OutboxRelay struct {
+	db       *sql.DB
+	pub      OutboxPublisher
+	interval time.Duration
+}
+
+func NewOutboxRelay(db *sql.DB, pub OutboxPublisher, interval time.Duration) *OutboxRelay {
+	if interval <= 0 {
+		interval = 2 * time.Second
+	}
+	return &OutboxRelay{db: db, pub: pub, interval: interval}
+}
+
+func (o *OutboxRelay) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(o.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := o.RelayOnce(ctx); err != nil && ctx.Err() == nil {
+					logger.Logger.Warn().Err(err).Msg("outbox relay failed")
+				}
+			}
+		}
+	}()
+}
+
+// relayBatchSQL picks due rows that have no older unsent row for the same
+// user. A row backing off after a failure therefore holds back that user's
+// later events (a ban is never overtaken by the unban that follows it),
+// while other users' events keep flowing. A batch carries at most one row
+// per user.
+const relayBatchSQL = `
+SELECT o.id, o.message_id, o.routing_key, o.body
+FROM outbox o
+WHERE o.sent_at IS NULL AND o.next_attempt_at <= NOW()
+  AND NOT EXISTS (
+    SELECT 1 FROM outbox earlier
+    WHERE earlier.aggregate_id = o.aggregate_id
+      AND earlier.sent_at IS NULL
+      AND earlier.id < o.id
+  )
+ORDER BY o.id
+LIMIT $1
+FOR UPDATE OF o SKIP LOCKED`
+
+// RelayOnce publishes one batch and reports how many rows were sent. It
+// stops at the first failure; the rows left behind are picked up again once
+// nothing older for their user is pending.
+func (o *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	rows, err := tx.QueryContext(ctx, relayBatchSQL, outboxBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	type row struct {
+		id         int64
+		messageID  string
+		routingKey string
+		body       []byte
+	}
+	var batch []row
+	for rows.Next() {
+		var m row
+		if err := rows.Scan(&m.id, &m.messageID, &m.routingKey, &m.body); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		batch = append(batch, m)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, m := range batch {
+		if perr := o.pub.PublishRaw(ctx, m.routingKey, m.messageID, m.body); perr != nil {
+			if _, err := tx.ExecContext(ctx, `
+UPDATE outbox
+SET attempts = attempts + 1,
+    last_error = $2,
+    next_attempt_at = NOW() + make_interval(secs => LEAST(POWER(2, attempts + 1), $3))
+WHERE id = $1`, m.id, perr.Error(), outboxMaxBackoffSeconds); err != nil {
+				return sent, err
+			}
+			logger.Logger.Warn().Err(perr).
+				Str("message_id", m.messageID).
+				Str("routing_key", m.routingKey).
+				Msg("outbox publish failed; will retry")
+			break
+		}
+
+		if _, err := tx.ExecContext(ctx, `UPDATE outbox SET sent_at = NOW(), last_error = NULL WHERE id = $1`, m.id); err != nil {
+			return sent, err
+		}
+		sent++
+	}
+
+	return sent, tx.Commit()
+}
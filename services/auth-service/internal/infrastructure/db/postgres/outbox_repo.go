@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/application/auth"
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/domain"
+)
+
+// SetLockedWithEvent implements auth.AccountOutbox: the locked flag and the
+// outbox row commit together. Nothing is recorded when the flag already has
+// the requested value.
+func (r *UserRepo) SetLockedWithEvent(ctx context.Context, userID string, locked bool, msg auth.OutboxMessage) (bool, error) {
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return false, domain.ErrMissingField("user_id")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, domain.ErrDBUnavailable(err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	const upd = `
+UPDATE users
+SET locked = $2
+WHERE id = $1 AND locked <> $2;
+`
+	res, err := tx.ExecContext(ctx, upd, userID, locked)
+	if err != nil {
+		return false, domain.ErrDBUnavailable(err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE id = $1)`, userID).Scan(&exists); err != nil {
+			return false, domain.ErrDBUnavailable(err)
+		}
+		if !exists {
+			return false, domain.ErrUserNotFound()
+		}
+		return false, nil
+	}
+
+	const ins = `
+INSERT INTO outbox (message_id, routing_key, aggregate_id, body, created_at)
+VALUES ($1, $2, $3, $4, $5);
+`
+	if _, err := tx.ExecContext(ctx, ins, msg.MessageID, msg.RoutingKey, msg.AggregateID, msg.Body, msg.CreatedAt); err != nil {
+		return false, domain.ErrDBUnavailable(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, domain.ErrDBUnavailable(err)
+	}
+	return true, nil
+}
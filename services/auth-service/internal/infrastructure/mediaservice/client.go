@@ -0,0 +1,78 @@
+package mediaservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/domain"
+)
+
+const claimPath = "/media/v1/internal/uploads/claim"
+
+// Client claims uploads through media-service's internal routes. It
+// implements auth.MediaChecker.
+type Client struct {
+	baseURL        string
+	internalSecret string
+	http           *http.Client
+}
+
+func NewClient(baseURL, internalSecret string) *Client {
+	return &Client{
+		baseURL:        baseURL,
+		internalSecret: internalSecret,
+		http:           &http.Client{Timeout: 3 * time.Second},
+	}
+}
+
+// ClaimUpload checks that id is a READY upload of ownerID with the given
+// purpose and marks it in use. A rejection becomes an invalid_field error
+// on avatar_url carrying media-service's reason.
+func (c *Client) ClaimUpload(ctx context.Context, ownerID, purpose, id string) error {
+	body, err := json.Marshal(map[string]any{
+		"owner_id": ownerID,
+		"purpose":  purpose,
+		"ids":      []string{id},
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+claimPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Internal-Secret", c.internalSecret)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("media-service POST %s: status %d", claimPath, resp.StatusCode)
+	}
+
+	var out struct {
+		OK    bool `json:"ok"`
+		Items []struct {
+			Reason string `json:"reason"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return err
+	}
+	if out.OK {
+		return nil
+	}
+	reason := "rejected"
+	if len(out.Items) > 0 && out.Items[0].Reason != "" {
+		reason = out.Items[0].Reason
+	}
+	return domain.ErrInvalidField("avatar_url", reason)
+}
@@ -25,3 +25,8 @@ func (p *NoopPublisher) PublishAvatarUpdated(ctx context.Context, evt auth.Avata
 	log.Printf("[noop-pub] avatar updated: user_id=%s old_id=%s", evt.UserID, evt.OldAvatarID)
 	return nil
 }
+
+func (p *NoopPublisher) PublishRaw(ctx context.Context, routingKey, messageID string, body []byte) error {
+	log.Printf("[noop-pub] %s: message_id=%s", routingKey, messageID)
+	return nil
+}
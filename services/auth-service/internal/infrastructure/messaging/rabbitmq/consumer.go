@@ -0,0 +1,135 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/application/auth"
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/logger"
+)
+
+const dataRequestQueue = "auth-service.data_requests"
+
+// DataRequestReplyHandler records replies to deletion / export requests
+// (implemented by *auth.Service).
+type DataRequestReplyHandler interface {
+	RecordDataRequestReply(ctx context.Context, reply auth.DataRequestReply) error
+}
+
+// Consumer receives user.data.deleted / user.data.exported replies.
+type Consumer struct {
+	url      string
+	exchange string
+	handler  DataRequestReplyHandler
+}
+
+func NewConsumer(url, exchange string, handler DataRequestReplyHandler) *Consumer {
+	if exchange == "" {
+		exchange = DefaultExchange
+	}
+	return &Consumer{url: url, exchange: exchange, handler: handler}
+}
+
+// Start consumes until ctx is done, reconnecting after failures.
+func (c *Consumer) Start(ctx context.Context) {
+	go func() {
+		for {
+			if err := c.consume(ctx); err != nil && ctx.Err() == nil {
+				logger.Logger.Warn().Err(err).Msg("data request consumer failed; retrying in 5s")
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}()
+}
+
+func (c *Consumer) consume(ctx context.Context) error {
+	conn, err := amqp.Dial(c.url)
+	if err != nil {
+		return fmt.Errorf("rabbitmq dial: %w", err)
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("rabbitmq channel: %w", err)
+	}
+	defer ch.Close()
+
+	if err := ch.ExchangeDeclare(c.exchange, "topic", true, false, false, false, nil); err != nil {
+		return fmt.Errorf("exchange declare: %w", err)
+	}
+	q, err := ch.QueueDeclare(dataRequestQueue, true, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("queue declare: %w", err)
+	}
+	for _, key := range []string{auth.RoutingKeyUserDataDeleted, auth.RoutingKeyUserDataExported} {
+		if err := ch.QueueBind(q.Name, key, c.exchange, false, nil); err != nil {
+			return fmt.Errorf("queue bind %s: %w", key, err)
+		}
+	}
+	if err := ch.Qos(10, 0, false); err != nil {
+		return fmt.Errorf("qos: %w", err)
+	}
+
+	msgs, err := ch.Consume(q.Name, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("consume: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case d, ok := <-msgs:
+			if !ok {
+				return amqp.ErrClosed
+			}
+			c.handle(ctx, d)
+		}
+	}
+}
+
+func (c *Consumer) handle(ctx context.Context, d amqp.Delivery) {
+	// Replies come enveloped (event-service) or bare (join-service's outbox).
+	var env struct {
+		Payload json.RawMessage `json:"payload"`
+	}
+	var reply auth.DataRequestReply
+	raw := d.Body
+	if err := json.Unmarshal(d.Body, &env); err == nil && len(env.Payload) > 0 {
+		raw = env.Payload
+	}
+	if err := json.Unmarshal(raw, &reply); err != nil {
+		logger.Logger.Error().Err(err).Str("routing_key", d.RoutingKey).Msg("malformed data request reply")
+		_ = d.Nack(false, false)
+		return
+	}
+
+	err := c.handler.RecordDataRequestReply(ctx, reply)
+	var derr *domain.Error
+	switch {
+	case err == nil:
+		_ = d.Ack(false)
+	case errors.As(err, &derr) && derr.Kind != domain.KindInfrastructure && derr.Kind != domain.KindInternal:
+		// Unknown request or a malformed reply: retrying will not help.
+		logger.Logger.Warn().Err(err).
+			Str("request_id", reply.RequestID).
+			Str("service", reply.Service).
+			Msg("data request reply dropped")
+		_ = d.Ack(false)
+	default:
+		logger.Logger.Warn().Err(err).Str("request_id", reply.RequestID).Msg("data request reply failed; requeueing")
+		time.Sleep(time.Second) // keep a DB outage from spinning the queue
+		_ = d.Nack(false, true)
+	}
+}
@@ -94,6 +94,11 @@ func (p *Publisher) PublishAvatarUpdated(ctx context.Context, evt auth.AvatarUpd
 	return p.publishJSON(ctx, "auth.avatar.updated", evt)
 }
 
+// PublishRaw publishes an already-encoded outbox body (postgres.OutboxPublisher).
+func (p *Publisher) PublishRaw(ctx context.Context, routingKey, messageID string, body []byte) error {
+	return p.publish(ctx, routingKey, messageID, body)
+}
+
 // ---- internal ----
 
 func (p *Publisher) connect() error {
@@ -150,7 +155,10 @@ func (p *Publisher) publishJSON(ctx context.Context, routingKey string, payload
 	if err != nil {
 		return fmt.Errorf("marshal payload: %w", err)
 	}
+	return p.publish(ctx, routingKey, "", body)
+}
 
+func (p *Publisher) publish(ctx context.Context, routingKey, messageID string, body []byte) error {
 	// Ensure there is a deadline to avoid blocking forever.
 	if _, ok := ctx.Deadline(); !ok {
 		var cancel context.CancelFunc
@@ -187,6 +195,7 @@ drain:
 			ContentType:  "application/json",
 			DeliveryMode: amqp.Persistent,
 			Timestamp:    time.Now(),
+			MessageId:    messageID,
 			Body:         body,
 		},
 	); err != nil {
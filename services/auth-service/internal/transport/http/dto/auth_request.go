@@ -172,6 +172,23 @@ func (r *SetUserRoleRequest) Validate() error {
 	return nil
 }
 
+// InternalBanUserRequest names the moderator on whose behalf another
+// service bans an account; the usual role rules apply to them.
+type InternalBanUserRequest struct {
+	ActorID   string `json:"actor_id"`
+	ActorRole string `json:"actor_role"`
+}
+
+func (r *InternalBanUserRequest) Validate() error {
+	if strings.TrimSpace(r.ActorID) == "" {
+		return domain.ErrMissingField("actor_id")
+	}
+	if strings.TrimSpace(r.ActorRole) == "" {
+		return domain.ErrMissingField("actor_role")
+	}
+	return nil
+}
+
 // -------- Sessions --------
 
 type SessionsRevokeRequest struct{}
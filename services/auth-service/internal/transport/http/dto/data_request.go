@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// AccountDeletionRequest confirms a deletion. Password is required for
+// accounts that have one (OAuth-only accounts send nothing).
+type AccountDeletionRequest struct {
+	Password string `json:"password"`
+}
+
+// DataRequestData describes an account deletion or data export request.
+type DataRequestData struct {
+	RequestID       string     `json:"request_id"`
+	Kind            string     `json:"kind"`   // "deletion" | "export"
+	Status          string     `json:"status"` // "pending" | "completed"
+	PendingServices []string   `json:"pending_services"`
+	CreatedAt       time.Time  `json:"created_at"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
+}
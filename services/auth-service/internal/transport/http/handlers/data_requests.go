@@ -0,0 +1,152 @@
+package http_handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/infrastructure/security"
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/transport/http/dto"
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/transport/http/middleware"
+	"github.com/baechuer/real-time-ressys/services/auth-service/internal/transport/http/response"
+)
+
+// ---- Account deletion / data export ----
+
+func toDataRequestData(req domain.DataRequest) dto.DataRequestData {
+	return dto.DataRequestData{
+		RequestID:       req.ID,
+		Kind:            string(req.Kind),
+		Status:          string(req.Status),
+		PendingServices: req.PendingServices(),
+		CreatedAt:       req.CreatedAt,
+		CompletedAt:     req.CompletedAt,
+	}
+}
+
+func dataRequestIDParam(r *http.Request) (string, error) {
+	id := chi.URLParam(r, "id")
+	if _, err := uuid.Parse(id); err != nil {
+		return "", domain.ErrInvalidField("id", "must be uuid")
+	}
+	return id, nil
+}
+
+// RequestAccountDeletion POST /auth/v1/me/deletion
+// Locks the account and signs the user out; other services then delete or
+// anonymize their data. Returns 202 with the request to track.
+func (h *AuthHandler) RequestAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.WriteError(w, r, domain.ErrTokenInvalid())
+		return
+	}
+
+	// OAuth-only accounts have no password to confirm with and may send no body.
+	var req dto.AccountDeletionRequest
+	if r.ContentLength != 0 {
+		if err := response.DecodeJSON(r, &req); err != nil {
+			response.WriteError(w, r, err)
+			return
+		}
+	}
+
+	dr, err := h.svc.RequestAccountDeletion(r.Context(), userID, req.Password)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	security.ClearRefreshToken(w, h.secureCookies)
+	response.WriteJSON(w, http.StatusAccepted, response.Envelope{Data: toDataRequestData(dr)})
+}
+
+// RequestDataExport POST /auth/v1/me/export
+func (h *AuthHandler) RequestDataExport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.WriteError(w, r, domain.ErrTokenInvalid())
+		return
+	}
+
+	dr, err := h.svc.RequestDataExport(r.Context(), userID)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.WriteJSON(w, http.StatusAccepted, response.Envelope{Data: toDataRequestData(dr)})
+}
+
+// GetDataRequest GET /auth/v1/me/data-requests/{id}
+func (h *AuthHandler) GetDataRequest(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.WriteError(w, r, domain.ErrTokenInvalid())
+		return
+	}
+	id, err := dataRequestIDParam(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	dr, err := h.svc.GetDataRequest(r.Context(), userID, id)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.OK(w, toDataRequestData(dr))
+}
+
+// DownloadDataExport GET /auth/v1/me/export/{id}/download
+// The archive is served as a JSON attachment; 409 while parts are missing.
+func (h *AuthHandler) DownloadDataExport(w http.ResponseWriter, r *http.Request) {
+	userID, ok := middleware.UserIDFromContext(r.Context())
+	if !ok {
+		response.WriteError(w, r, domain.ErrTokenInvalid())
+		return
+	}
+	id, err := dataRequestIDParam(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	archive, err := h.svc.ExportArchive(r.Context(), userID, id)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	body, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		response.WriteError(w, r, domain.ErrInternal(err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="cityevents-export-%s.json"`, id))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// AdminGetDataRequest GET /auth/v1/admin/data-requests/{id}
+// Deleted users cannot sign in, so support tracks deletions here.
+func (h *AuthHandler) AdminGetDataRequest(w http.ResponseWriter, r *http.Request) {
+	id, err := dataRequestIDParam(r)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	dr, err := h.svc.AdminGetDataRequest(r.Context(), id)
+	if err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	response.OK(w, toDataRequestData(dr))
+}
@@ -38,3 +38,33 @@ func (h *AuthHandler) InternalGetUser(w http.ResponseWriter, r *http.Request) {
 
 	response.OK(w, data)
 }
+
+// InternalBanUser bans an account on behalf of the moderator named in the
+// body. event-service calls it when a user report is actioned with ban_user.
+func (h *AuthHandler) InternalBanUser(w http.ResponseWriter, r *http.Request) {
+	targetID := chi.URLParam(r, "id")
+	if strings.TrimSpace(targetID) == "" {
+		response.WriteError(w, r, domain.ErrMissingField("id"))
+		return
+	}
+
+	var req dto.InternalBanUserRequest
+	if err := response.DecodeJSON(r, &req); err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	if err := h.svc.BanUser(r.Context(), req.ActorID, req.ActorRole, targetID); err != nil {
+		response.WriteError(w, r, err)
+		return
+	}
+
+	response.OK(w, dto.BanUserData{
+		Status: "banned",
+		UserID: targetID,
+	})
+}
@@ -46,11 +46,19 @@ type AuthHandler interface {
 	// Profile
 	UpdateAvatar(w http.ResponseWriter, r *http.Request)
 
+	// Account deletion / data export
+	RequestAccountDeletion(w http.ResponseWriter, r *http.Request)
+	RequestDataExport(w http.ResponseWriter, r *http.Request)
+	GetDataRequest(w http.ResponseWriter, r *http.Request)
+	DownloadDataExport(w http.ResponseWriter, r *http.Request)
+	AdminGetDataRequest(w http.ResponseWriter, r *http.Request)
+
 	// Optional
 	MeStatus(w http.ResponseWriter, r *http.Request)
 
 	// Internal (Service-to-Service)
 	InternalGetUser(w http.ResponseWriter, r *http.Request)
+	InternalBanUser(w http.ResponseWriter, r *http.Request)
 }
 
 // OAuthHandler handles OAuth endpoints
@@ -89,6 +97,7 @@ type Deps struct {
 	RLSessionsRevoke func(http.Handler) http.Handler
 	RLModActions     func(http.Handler) http.Handler
 	RLAdminActions   func(http.Handler) http.Handler
+	RLDataRequests   func(http.Handler) http.Handler
 }
 
 func New(deps Deps) (http.Handler, error) {
@@ -175,6 +184,17 @@ func New(deps Deps) (http.Handler, error) {
 		r.With(deps.AuthMW).Get("/me/status", deps.Auth.MeStatus)
 		r.With(deps.AuthMW).Patch("/me/avatar", deps.Auth.UpdateAvatar)
 
+		// --- Account deletion / data export ---
+		if deps.RLDataRequests != nil {
+			r.With(deps.AuthMW, deps.RLDataRequests).Post("/me/deletion", deps.Auth.RequestAccountDeletion)
+			r.With(deps.AuthMW, deps.RLDataRequests).Post("/me/export", deps.Auth.RequestDataExport)
+		} else {
+			r.With(deps.AuthMW).Post("/me/deletion", deps.Auth.RequestAccountDeletion)
+			r.With(deps.AuthMW).Post("/me/export", deps.Auth.RequestDataExport)
+		}
+		r.With(deps.AuthMW).Get("/me/data-requests/{id}", deps.Auth.GetDataRequest)
+		r.With(deps.AuthMW).Get("/me/export/{id}/download", deps.Auth.DownloadDataExport)
+
 		// Permission management
 		r.With(deps.AuthMW, deps.AdminMW).Get("/admin", deps.Auth.Admin)
 
@@ -202,6 +222,7 @@ func New(deps Deps) (http.Handler, error) {
 			r.Post("/users/{id}/role", deps.Auth.AdminSetUserRole)
 			r.Get("/users/{id}/status", deps.Auth.AdminUserStatus)
 			r.Post("/users/{id}/sessions/revoke", deps.Auth.AdminRevokeSessions)
+			r.Get("/data-requests/{id}", deps.Auth.AdminGetDataRequest)
 		})
 
 		// --- Email verification ---
@@ -239,6 +260,7 @@ func New(deps Deps) (http.Handler, error) {
 	r.Route("/internal", func(r chi.Router) {
 		r.Use(deps.InternalAuthMW)
 		r.Get("/users/{id}", deps.Auth.InternalGetUser)
+		r.Post("/users/{id}/ban", deps.Auth.InternalBanUser)
 	})
 
 	return r, nil
@@ -78,9 +78,27 @@ func (a fakeAuth) SessionsRevoke(w http.ResponseWriter, r *http.Request) {
 
 func (a fakeAuth) MeStatus(w http.ResponseWriter, r *http.Request)        { a.write(w, 200, "me_status") }
 func (a fakeAuth) InternalGetUser(w http.ResponseWriter, r *http.Request) {}
+func (a fakeAuth) InternalBanUser(w http.ResponseWriter, r *http.Request) {
+	a.write(w, 200, "internal_ban")
+}
 func (a fakeAuth) UpdateAvatar(w http.ResponseWriter, r *http.Request) {
 	a.write(w, 200, "update_avatar")
 }
+func (a fakeAuth) RequestAccountDeletion(w http.ResponseWriter, r *http.Request) {
+	a.write(w, 202, "deletion")
+}
+func (a fakeAuth) RequestDataExport(w http.ResponseWriter, r *http.Request) {
+	a.write(w, 202, "export")
+}
+func (a fakeAuth) GetDataRequest(w http.ResponseWriter, r *http.Request) {
+	a.write(w, 200, "data_request")
+}
+func (a fakeAuth) DownloadDataExport(w http.ResponseWriter, r *http.Request) {
+	a.write(w, 200, "export_download")
+}
+func (a fakeAuth) AdminGetDataRequest(w http.ResponseWriter, r *http.Request) {
+	a.write(w, 200, "admin_data_request")
+}
 
 // Middleware helper
 func noopMW(next http.Handler) http.Handler { return next }
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_outbox_unsent;
+DROP TABLE IF EXISTS outbox;
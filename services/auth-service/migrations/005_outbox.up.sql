@@ -0,0 +1,17 @@
+-- Transactional outbox for account events (auth.user.banned / auth.user.unbanned).
+-- Rows are written in the same transaction as the account change and
+-- published by the outbox relay; body is the full message envelope.
+CREATE TABLE IF NOT EXISTS outbox (
+  id BIGSERIAL PRIMARY KEY,
+  message_id UUID NOT NULL UNIQUE,
+  routing_key TEXT NOT NULL,
+  body JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  sent_at TIMESTAMPTZ,                          -- NULL until published
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_error TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_unsent
+  ON outbox (next_attempt_at, id) WHERE sent_at IS NULL;
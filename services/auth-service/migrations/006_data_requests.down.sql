@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS data_request_acks;
+DROP INDEX IF EXISTS ux_data_requests_pending;
+DROP TABLE IF EXISTS data_requests;
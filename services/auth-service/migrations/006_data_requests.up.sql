@@ -0,0 +1,27 @@
+-- Account deletion / data export sagas.
+-- services lists who must acknowledge (JSON array of service names); a
+-- request completes once every one of them has a row in data_request_acks.
+-- user_id has no foreign key: deleted users are anonymized, and requests
+-- outlive that as the record of what was done.
+CREATE TABLE IF NOT EXISTS data_requests (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL,
+  kind TEXT NOT NULL CHECK (kind IN ('deletion','export')),
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending','completed')),
+  services JSONB NOT NULL DEFAULT '[]',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  completed_at TIMESTAMPTZ
+);
+
+-- At most one pending request of each kind per user
+CREATE UNIQUE INDEX IF NOT EXISTS ux_data_requests_pending
+  ON data_requests (user_id, kind) WHERE status = 'pending';
+
+-- part is the service's export section (NULL for deletions)
+CREATE TABLE IF NOT EXISTS data_request_acks (
+  request_id UUID NOT NULL REFERENCES data_requests(id) ON DELETE CASCADE,
+  service TEXT NOT NULL,
+  part JSONB,
+  acked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (request_id, service)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_outbox_unsent_aggregate;
+ALTER TABLE outbox DROP COLUMN IF EXISTS aggregate_id;
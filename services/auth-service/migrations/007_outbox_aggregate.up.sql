@@ -0,0 +1,9 @@
+-- The relay publishes a user's events in order: a row is held back while
+-- an older row for the same user is unsent (e.g. backing off after a
+-- failure), so a ban and the unban after it cannot reach consumers reversed.
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS aggregate_id TEXT;
+
+UPDATE outbox SET aggregate_id = body->'payload'->>'user_id' WHERE aggregate_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_outbox_unsent_aggregate
+  ON outbox (aggregate_id, id) WHERE sent_at IS NULL;
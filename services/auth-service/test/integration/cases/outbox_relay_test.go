@@ -0,0 +1,82 @@
+//go:build integration
+
+package cases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	pg "github.com/baechuer/real-time-ressys/services/auth-service/internal/infrastructure/db/postgres"
+	itinfra "github.com/baechuer/real-time-ressys/services/auth-service/test/integration/infra"
+)
+
+// recordingPublisher fails the routing keys in failOn and records the rest.
+type recordingPublisher struct {
+	failOn map[string]bool
+	sent   []string
+}
+
+func (p *recordingPublisher) PublishRaw(ctx context.Context, routingKey, messageID string, body []byte) error {
+	if p.failOn[routingKey] {
+		return errors.New("broker unavailable")
+	}
+	p.sent = append(p.sent, routingKey)
+	return nil
+}
+
+// A ban that failed and is backing off must not be overtaken by the unban
+// recorded after it; other users' events still go out.
+func Test_OutboxRelay_KeepsPerUserOrderAcrossBackoff(t *testing.T) {
+	env, err := itinfra.LoadEnv()
+	require.NoError(t, err)
+
+	d := MustNewDeps(t, env)
+	defer d.Close(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err = d.DB.ExecContext(ctx, `TRUNCATE TABLE outbox RESTART IDENTITY`)
+	require.NoError(t, err)
+
+	insert := func(routingKey, userID string) {
+		_, err := d.DB.ExecContext(ctx, `
+INSERT INTO outbox (message_id, routing_key, aggregate_id, body)
+VALUES ($1, $2, $3, '{}')`, uuid.NewString(), routingKey, userID)
+		require.NoError(t, err)
+	}
+	insert("auth.user.banned", "alice")
+	insert("auth.user.unbanned", "alice")
+	insert("auth.user.banned", "bob")
+
+	pub := &recordingPublisher{failOn: map[string]bool{"auth.user.banned": true}}
+	relay := pg.NewOutboxRelay(d.DB, pub, time.Second)
+
+	// alice's ban fails and backs off; the relay stops before bob's.
+	n, err := relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, n)
+
+	// Her unban is due but held back behind the ban.
+	pub.failOn = map[string]bool{}
+	_, err = d.DB.ExecContext(ctx, `UPDATE outbox SET next_attempt_at = NOW() + INTERVAL '1 hour' WHERE routing_key = 'auth.user.banned' AND aggregate_id = 'alice'`)
+	require.NoError(t, err)
+	n, err = relay.RelayOnce(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []string{"auth.user.banned"}, pub.sent, "only bob's ban goes out")
+
+	// Once the ban is due again both of alice's events go out, in order.
+	_, err = d.DB.ExecContext(ctx, `UPDATE outbox SET next_attempt_at = NOW() WHERE sent_at IS NULL`)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = relay.RelayOnce(ctx)
+		require.NoError(t, err)
+	}
+	require.Equal(t, []string{"auth.user.banned", "auth.user.banned", "auth.user.unbanned"}, pub.sent)
+}
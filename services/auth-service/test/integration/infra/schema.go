@@ -74,6 +74,25 @@ CREATE TABLE IF NOT EXISTS oauth_identities (
     updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
     UNIQUE(provider, provider_user_id)
 );
+`)
+	if err != nil {
+		return err
+	}
+
+	// 4) Create outbox (migrations 005 + 007)
+	_, err = db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS outbox (
+  id BIGSERIAL PRIMARY KEY,
+  message_id UUID NOT NULL UNIQUE,
+  routing_key TEXT NOT NULL,
+  aggregate_id TEXT,
+  body JSONB NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  sent_at TIMESTAMPTZ,
+  attempts INT NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_error TEXT
+);
 `)
 	return err
 }
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ListComments returns a keyset page of an event's discussion. Only cursor
+// and limit are passed through; the first page also carries pinned posts.
+func (h *EventHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		sendError(w, r, "validation_failed", "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 1500*time.Millisecond)
+	defer cancel()
+
+	page, err := h.eventClient.ListComments(ctx, eventID, commentPageQuery(r))
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to fetch comments")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *EventHandler) ListReplies(w http.ResponseWriter, r *http.Request) {
+	eventID, commentID, ok := commentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 1500*time.Millisecond)
+	defer cancel()
+
+	page, err := h.eventClient.ListReplies(ctx, eventID, commentID, commentPageQuery(r))
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to fetch replies")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *EventHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		sendError(w, r, "validation_failed", "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	var body struct {
+		ParentID string `json:"parent_id,omitempty"`
+		Body     string `json:"body"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendError(w, r, "validation_failed", "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	c, err := h.eventClient.CreateComment(r.Context(), bearerToken, eventID, body)
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to post comment")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(c)
+}
+
+func (h *EventHandler) CreateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		sendError(w, r, "validation_failed", "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	var body struct {
+		Title string `json:"title"`
+		Body  string `json:"body"`
+		Pin   bool   `json:"pin,omitempty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendError(w, r, "validation_failed", "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	c, err := h.eventClient.CreateAnnouncement(r.Context(), bearerToken, eventID, body)
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to post announcement")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(c)
+}
+
+func (h *EventHandler) PinComment(w http.ResponseWriter, r *http.Request) {
+	h.setCommentPinned(w, r, true)
+}
+
+func (h *EventHandler) UnpinComment(w http.ResponseWriter, r *http.Request) {
+	h.setCommentPinned(w, r, false)
+}
+
+func (h *EventHandler) setCommentPinned(w http.ResponseWriter, r *http.Request, pinned bool) {
+	eventID, commentID, ok := commentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	c, err := h.eventClient.SetCommentPinned(r.Context(), bearerToken, eventID, commentID, pinned)
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to update pin")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+// DeleteComment lets authors delete their own posts and organizers remove
+// posts on their events.
+func (h *EventHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	eventID, commentID, ok := commentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	if err := h.eventClient.DeleteComment(r.Context(), bearerToken, eventID, commentID, r.URL.Query().Get("reason")); err != nil {
+		handleDownstreamError(w, r, err, "failed to delete comment")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AdminRemoveComment is moderator removal; a reason is required so the
+// removal can be audited.
+func (h *EventHandler) AdminRemoveComment(w http.ResponseWriter, r *http.Request) {
+	eventID, commentID, ok := commentIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var reqBody struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		sendError(w, r, "validation_failed", "invalid request body", http.StatusBadRequest)
+		return
+	}
+	reason := strings.TrimSpace(reqBody.Reason)
+	if reason == "" {
+		sendError(w, r, "validation_failed", "reason is required", http.StatusBadRequest)
+		return
+	}
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	if err := h.eventClient.DeleteComment(r.Context(), bearerToken, eventID, commentID, reason); err != nil {
+		handleDownstreamError(w, r, err, "failed to remove comment as admin")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func commentIDs(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	eventID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		sendError(w, r, "validation_failed", "invalid event id", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+	commentID, err := uuid.Parse(chi.URLParam(r, "comment_id"))
+	if err != nil {
+		sendError(w, r, "validation_failed", "invalid comment id", http.StatusBadRequest)
+		return uuid.Nil, uuid.Nil, false
+	}
+	return eventID, commentID, true
+}
+
+func commentPageQuery(r *http.Request) url.Values {
+	in := r.URL.Query()
+	out := url.Values{}
+	for _, k := range []string{"cursor", "limit"} {
+		if v := strings.TrimSpace(in.Get(k)); v != "" {
+			out.Set(k, v)
+		}
+	}
+	return out
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/baechuer/real-time-ressys/services/bff-service/internal/domain"
 	"github.com/baechuer/real-time-ressys/services/bff-service/internal/downstream"
@@ -24,8 +25,38 @@ func sendError(w http.ResponseWriter, r *http.Request, code string, message stri
 func handleDownstreamError(w http.ResponseWriter, r *http.Request, err error, defaultMsg string) {
 	var se *downstream.StatusError
 	if errors.As(err, &se) {
+		if se.Data != nil {
+			sendErrorWithData(w, r, se)
+			return
+		}
 		sendError(w, r, se.Code, se.Message, se.StatusCode)
 		return
 	}
 	sendError(w, r, "internal_error", defaultMsg, http.StatusBadGateway)
 }
+
+// sendErrorWithData relays a downstream 412 together with the current
+// representation and its ETag, so the client can merge and retry.
+func sendErrorWithData(w http.ResponseWriter, r *http.Request, se *downstream.StatusError) {
+	resp := struct {
+		domain.APIError
+		Data json.RawMessage `json:"data"`
+	}{Data: se.Data}
+	resp.Error.Code = se.Code
+	resp.Error.Message = se.Message
+	resp.Error.RequestID = middleware.GetRequestID(r.Context())
+
+	if se.ETag != "" {
+		w.Header().Set("ETag", se.ETag)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(se.StatusCode)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// setETag exposes an event's version for the next If-Match.
+func setETag(w http.ResponseWriter, ev *domain.Event) {
+	if ev != nil && ev.Version > 0 {
+		w.Header().Set("ETag", `"`+strconv.FormatInt(ev.Version, 10)+`"`)
+	}
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/middleware"
+	"github.com/google/uuid"
+)
+
+// EmailPreferencesHandler lets users read and change which optional emails
+// they get. It proxies to email-service's internal endpoints.
+type EmailPreferencesHandler struct {
+	emailServiceURL string
+	internalSecret  string
+	httpClient      *http.Client
+}
+
+// NewEmailPreferencesHandler creates a new email preferences handler.
+func NewEmailPreferencesHandler(emailServiceURL, internalSecret string) *EmailPreferencesHandler {
+	return &EmailPreferencesHandler{
+		emailServiceURL: emailServiceURL,
+		internalSecret:  internalSecret,
+		httpClient:      &http.Client{},
+	}
+}
+
+// Get returns the caller's email preferences.
+func (h *EmailPreferencesHandler) Get(w http.ResponseWriter, r *http.Request) {
+	h.proxy(w, r, http.MethodGet, false)
+}
+
+// Update changes the fields present in the body, e.g. {"reminders": false}
+// or {"digest": true, "digest_city": "Sydney"}.
+func (h *EmailPreferencesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	h.proxy(w, r, http.MethodPut, true)
+}
+
+func (h *EmailPreferencesHandler) proxy(w http.ResponseWriter, r *http.Request, method string, withBody bool) {
+	userID := middleware.GetUserID(r.Context())
+	if userID == uuid.Nil {
+		h.errorResponse(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var body io.Reader
+	if withBody {
+		body = r.Body
+	}
+	proxyURL := h.emailServiceURL + "/internal/users/" + url.PathEscape(userID.String()) + "/email-preferences"
+	req, err := http.NewRequestWithContext(r.Context(), method, proxyURL, body)
+	if err != nil {
+		h.errorResponse(w, http.StatusInternalServerError, "failed to create request")
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Internal-Secret", h.internalSecret)
+	req.Header.Set("X-Request-ID", r.Header.Get("X-Request-ID"))
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		h.errorResponse(w, http.StatusBadGateway, "email service unavailable")
+		return
+	}
+	defer resp.Body.Close()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+func (h *EmailPreferencesHandler) errorResponse(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
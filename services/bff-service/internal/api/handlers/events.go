@@ -23,12 +23,32 @@ type EventClient interface {
 	GetOwnEvent(ctx context.Context, eventID uuid.UUID, bearerToken string) (*domain.Event, error)
 	ListEvents(ctx context.Context, query url.Values) (*domain.PaginatedResponse[domain.EventCard], error)
 	CreateEvent(ctx context.Context, bearerToken string, body interface{}) (*domain.Event, error)
-	PublishEvent(ctx context.Context, bearerToken, eventID string) (*domain.Event, error)
-	UpdateEvent(ctx context.Context, bearerToken, eventID string, body interface{}) (*domain.Event, error)
-	CancelEvent(ctx context.Context, bearerToken, eventID string, body interface{}) (*domain.Event, error)
+	PublishEvent(ctx context.Context, bearerToken, eventID, ifMatch string) (*domain.Event, error)
+	UpdateEvent(ctx context.Context, bearerToken, eventID string, body interface{}, ifMatch string) (*domain.Event, error)
+	CancelEvent(ctx context.Context, bearerToken, eventID string, body interface{}, ifMatch string) (*domain.Event, error)
 	ListMine(ctx context.Context, bearerToken string, query url.Values) (*domain.PaginatedResponse[domain.EventCard], error)
 	GetCitySuggestions(ctx context.Context, query string) ([]string, error)
 	UnpublishEvent(ctx context.Context, bearerToken, eventID string, body interface{}) (*domain.Event, error)
+
+	ListComments(ctx context.Context, eventID uuid.UUID, query url.Values) (*domain.CommentPage, error)
+	ListReplies(ctx context.Context, eventID, commentID uuid.UUID, query url.Values) (*domain.CommentPage, error)
+	CreateComment(ctx context.Context, bearerToken string, eventID uuid.UUID, body interface{}) (*domain.Comment, error)
+	CreateAnnouncement(ctx context.Context, bearerToken string, eventID uuid.UUID, body interface{}) (*domain.Comment, error)
+	SetCommentPinned(ctx context.Context, bearerToken string, eventID, commentID uuid.UUID, pinned bool) (*domain.Comment, error)
+	DeleteComment(ctx context.Context, bearerToken string, eventID, commentID uuid.UUID, reason string) error
+
+	ListReviews(ctx context.Context, eventID uuid.UUID, query url.Values) (*domain.ReviewPage, error)
+	GetOrganizerReputation(ctx context.Context, organizerID string) (*domain.RatingSummary, error)
+	SubmitReview(ctx context.Context, bearerToken string, eventID uuid.UUID, body interface{}) (*domain.Review, error)
+	SetReviewHidden(ctx context.Context, bearerToken string, eventID, reviewID uuid.UUID, hidden bool, reason string) (*domain.Review, error)
+
+	ListRevisions(ctx context.Context, bearerToken string, eventID uuid.UUID, query url.Values) (*domain.RevisionPage, error)
+
+	CreateReport(ctx context.Context, bearerToken string, body interface{}) (*domain.Report, error)
+	ListModerationQueue(ctx context.Context, bearerToken string, query url.Values) (*domain.ModerationQueue, error)
+	GetModerationCase(ctx context.Context, bearerToken string, caseID uuid.UUID) (*domain.ModerationCase, error)
+	TriageCase(ctx context.Context, bearerToken string, caseID uuid.UUID) (*domain.ModerationCase, error)
+	ResolveCase(ctx context.Context, bearerToken string, caseID uuid.UUID, body interface{}) (*domain.ModerationCase, error)
 }
 
 type JoinClient interface {
@@ -42,29 +62,49 @@ type AuthClient interface {
 	GetUser(ctx context.Context, userID uuid.UUID) (*domain.User, error)
 }
 
+type FeedClient interface {
+	GetSimilar(ctx context.Context, eventID uuid.UUID, limit int) ([]domain.SimilarEvent, error)
+}
+
 type EventHandler struct {
 	eventClient EventClient
 	joinClient  JoinClient
 	authClient  AuthClient
+	feedClient  FeedClient  // optional: "similar events" on the event view
+	mediaClient MediaClient // optional: cover placeholders, see SetMediaClient
 }
 
-func NewEventHandler(ec EventClient, jc JoinClient, ac AuthClient) *EventHandler {
+func NewEventHandler(ec EventClient, jc JoinClient, ac AuthClient, fc FeedClient) *EventHandler {
 	return &EventHandler{
 		eventClient: ec,
 		joinClient:  jc,
 		authClient:  ac,
+		feedClient:  fc,
 	}
 }
 
 type EventViewResponse struct {
-	Event         *domain.Event         `json:"event"`
-	Participation *domain.Participation `json:"participation"`
-	Actions       domain.ActionPolicy   `json:"actions"`
-	Degraded      *DegradedInfo         `json:"degraded,omitempty"`
+	Event               *domain.Event         `json:"event"`
+	Participation       *domain.Participation `json:"participation"`
+	Actions             domain.ActionPolicy   `json:"actions"`
+	Similar             []domain.SimilarEvent `json:"similar,omitempty"`
+	OrganizerReputation *domain.RatingSummary `json:"organizer_reputation,omitempty"`
+	Degraded            *DegradedInfo         `json:"degraded,omitempty"`
 }
 
 type DegradedInfo struct {
-	Participation string `json:"participation"`
+	Participation string `json:"participation,omitempty"`
+	Similar       string `json:"similar,omitempty"`
+	Reputation    string `json:"reputation,omitempty"`
+	Placeholders  string `json:"placeholders,omitempty"`
+}
+
+// degradedReason maps a downstream failure to the value reported in DegradedInfo
+func degradedReason(err error) string {
+	if err == downstream.ErrTimeout {
+		return "timeout"
+	}
+	return "unavailable"
 }
 
 func (h *EventHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
@@ -76,6 +116,7 @@ func (h *EventHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 		handleDownstreamError(w, r, err, "failed to fetch events")
 		return
 	}
+	h.attachCardPlaceholders(r.Context(), res.Items)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(res)
@@ -96,6 +137,7 @@ func (h *EventHandler) ListFeed(w http.ResponseWriter, r *http.Request) {
 		handleDownstreamError(w, r, err, "failed to fetch events")
 		return
 	}
+	h.attachCardPlaceholders(r.Context(), res.Items)
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(res)
@@ -174,16 +216,19 @@ func (h *EventHandler) ListMyJoins(w http.ResponseWriter, r *http.Request) {
 		}
 
 		card := domain.EventCard{
-			ID:         ev.ID, // Use Event ID not Join ID
-			Title:      ev.Title,
-			CoverImage: ev.CoverImage,
-			StartTime:  ev.StartTime,
-			City:       ev.City,
-			Category:   ev.Category,
+			ID:            ev.ID, // Use Event ID not Join ID
+			Title:         ev.Title,
+			CoverImage:    ev.CoverImage,
+			CoverImageIDs: ev.CoverImageIDs,
+			StartTime:     ev.StartTime,
+			City:          ev.City,
+			Category:      ev.Category,
 		}
 		finalItems = append(finalItems, card)
 	}
 
+	h.attachCardPlaceholders(r.Context(), finalItems)
+
 	payload := domain.PaginatedResponse[domain.EventCard]{
 		Items:      finalItems,
 		NextCursor: joinRes.NextCursor,
@@ -211,6 +256,7 @@ func (h *EventHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		ev.CreatedBy = ev.OwnerID
 	}
 
+	setETag(w, ev)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(ev)
@@ -224,7 +270,7 @@ func (h *EventHandler) PublishEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	bearerToken := middleware.GetBearerToken(r.Context())
-	ev, err := h.eventClient.PublishEvent(r.Context(), bearerToken, eventID)
+	ev, err := h.eventClient.PublishEvent(r.Context(), bearerToken, eventID, r.Header.Get("If-Match"))
 	if err != nil {
 		handleDownstreamError(w, r, err, "failed to publish event")
 		return
@@ -233,6 +279,7 @@ func (h *EventHandler) PublishEvent(w http.ResponseWriter, r *http.Request) {
 		ev.CreatedBy = ev.OwnerID
 	}
 
+	setETag(w, ev)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(ev)
@@ -252,7 +299,7 @@ func (h *EventHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	bearerToken := middleware.GetBearerToken(r.Context())
-	ev, err := h.eventClient.UpdateEvent(r.Context(), bearerToken, id, body)
+	ev, err := h.eventClient.UpdateEvent(r.Context(), bearerToken, id, body, r.Header.Get("If-Match"))
 	if err != nil {
 		handleDownstreamError(w, r, err, "failed to update event")
 		return
@@ -261,6 +308,7 @@ func (h *EventHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
 		ev.CreatedBy = ev.OwnerID
 	}
 
+	setETag(w, ev)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(ev)
@@ -286,6 +334,7 @@ func (h *EventHandler) ListCreatedEvents(w http.ResponseWriter, r *http.Request)
 		handleDownstreamError(w, r, err, "failed to fetch created events")
 		return
 	}
+	h.attachCardPlaceholders(r.Context(), res.Items)
 
 	// Adapt Page based to Cursor based for infinite scroll compatibility
 	// NextCursor = Page + 1
@@ -318,12 +367,18 @@ func (h *EventHandler) GetEventView(w http.ResponseWriter, r *http.Request) {
 	bearerToken := middleware.GetBearerToken(r.Context())
 
 	var (
-		event    *domain.Event
-		eventErr error
-		part     *domain.Participation
-		partErr  error
-		user     *domain.User
-		userErr  error
+		event      *domain.Event
+		eventErr   error
+		part       *domain.Participation
+		partErr    error
+		user       *domain.User
+		userErr    error
+		similar    []domain.SimilarEvent
+		similarErr error
+		rep        *domain.RatingSummary
+		repErr     error
+		covers     map[string]domain.ImagePlaceholder
+		coversErr  error
 	)
 
 	// 1. Fetch Event (Mandatory)
@@ -351,10 +406,41 @@ func (h *EventHandler) GetEventView(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// 2. Fetch Participation and Organizer info in parallel
+	// 2. Fetch Participation, Organizer info and Similar events in parallel
 	var wg sync.WaitGroup
 	wg.Add(2)
 
+	// Recommendations are decorative: tight budget, never fail the view
+	if h.feedClient != nil && event.Status == domain.EventStatusPublished {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), 150*time.Millisecond)
+			defer cancel()
+			similar, similarErr = h.feedClient.GetSimilar(ctx, eventID, 6)
+		}()
+	}
+
+	// Reputation is informational like recommendations: never fail the view
+	if event.Status == domain.EventStatusPublished && event.OwnerID != uuid.Nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), 300*time.Millisecond)
+			defer cancel()
+			rep, repErr = h.eventClient.GetOrganizerReputation(ctx, event.OwnerID.String())
+		}()
+	}
+
+	// Cover placeholders are decorative too
+	if h.mediaClient != nil && len(event.CoverImageIDs) > 0 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			covers, coversErr = h.fetchPlaceholders(r.Context(), event.CoverImageIDs)
+		}()
+	}
+
 	go func() {
 		defer wg.Done()
 		ctx, cancel := context.WithTimeout(r.Context(), 800*time.Millisecond)
@@ -380,16 +466,37 @@ func (h *EventHandler) GetEventView(w http.ResponseWriter, r *http.Request) {
 
 	if partErr != nil {
 		isDegraded = true
-		msg := "unavailable"
-		if partErr == downstream.ErrTimeout {
-			msg = "timeout"
-		}
 		degradedInfo = &DegradedInfo{
-			Participation: msg,
+			Participation: degradedReason(partErr),
 		}
 		part = nil
 	}
 
+	// Missing recommendations do not affect the action policy
+	if similarErr != nil {
+		if degradedInfo == nil {
+			degradedInfo = &DegradedInfo{}
+		}
+		degradedInfo.Similar = degradedReason(similarErr)
+		similar = nil
+	}
+	if repErr != nil {
+		if degradedInfo == nil {
+			degradedInfo = &DegradedInfo{}
+		}
+		degradedInfo.Reputation = degradedReason(repErr)
+		rep = nil
+	}
+
+	if coversErr != nil {
+		if degradedInfo == nil {
+			degradedInfo = &DegradedInfo{}
+		}
+		degradedInfo.Placeholders = degradedReason(coversErr)
+	} else if len(covers) > 0 {
+		event.CoverPlaceholders = covers
+	}
+
 	if userErr == nil && user != nil {
 		event.OrganizerName = user.Email
 	} else {
@@ -399,10 +506,12 @@ func (h *EventHandler) GetEventView(w http.ResponseWriter, r *http.Request) {
 	policy := domain.CalculateActionPolicy(event, part, userID, userRole, time.Now().UTC(), isDegraded)
 
 	resp := EventViewResponse{
-		Event:         event,
-		Participation: part,
-		Actions:       policy,
-		Degraded:      degradedInfo,
+		Event:               event,
+		Participation:       part,
+		Actions:             policy,
+		Similar:             similar,
+		OrganizerReputation: rep,
+		Degraded:            degradedInfo,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -534,12 +643,13 @@ func (h *EventHandler) CancelEvent(w http.ResponseWriter, r *http.Request) {
 	}
 
 	bearerToken := middleware.GetBearerToken(r.Context())
-	ev, err := h.eventClient.CancelEvent(r.Context(), bearerToken, eventID.String(), nil)
+	ev, err := h.eventClient.CancelEvent(r.Context(), bearerToken, eventID.String(), nil, r.Header.Get("If-Match"))
 	if err != nil {
 		handleDownstreamError(w, r, err, "failed to cancel event")
 		return
 	}
 
+	setETag(w, ev)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(ev)
 }
@@ -560,13 +670,20 @@ func (h *EventHandler) AdminCancelEvent(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Moderators cancel whatever is current unless they pin a version.
+	ifMatch := r.Header.Get("If-Match")
+	if ifMatch == "" {
+		ifMatch = "*"
+	}
+
 	bearerToken := middleware.GetBearerToken(r.Context())
-	ev, err := h.eventClient.CancelEvent(r.Context(), bearerToken, eventID.String(), reqBody)
+	ev, err := h.eventClient.CancelEvent(r.Context(), bearerToken, eventID.String(), reqBody, ifMatch)
 	if err != nil {
 		handleDownstreamError(w, r, err, "failed to cancel event as admin")
 		return
 	}
 
+	setETag(w, ev)
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(ev)
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -54,24 +55,24 @@ func (m *mockEventClient) CreateEvent(ctx context.Context, bearerToken string, b
 	return args.Get(0).(*domain.Event), args.Error(1)
 }
 
-func (m *mockEventClient) PublishEvent(ctx context.Context, bearerToken, eventID string) (*domain.Event, error) {
-	args := m.Called(ctx, bearerToken, eventID)
+func (m *mockEventClient) PublishEvent(ctx context.Context, bearerToken, eventID, ifMatch string) (*domain.Event, error) {
+	args := m.Called(ctx, bearerToken, eventID, ifMatch)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Event), args.Error(1)
 }
 
-func (m *mockEventClient) UpdateEvent(ctx context.Context, bearerToken, eventID string, body interface{}) (*domain.Event, error) {
-	args := m.Called(ctx, bearerToken, eventID, body)
+func (m *mockEventClient) UpdateEvent(ctx context.Context, bearerToken, eventID string, body interface{}, ifMatch string) (*domain.Event, error) {
+	args := m.Called(ctx, bearerToken, eventID, body, ifMatch)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*domain.Event), args.Error(1)
 }
 
-func (m *mockEventClient) CancelEvent(ctx context.Context, bearerToken, eventID string, body interface{}) (*domain.Event, error) {
-	args := m.Called(ctx, bearerToken, eventID, body)
+func (m *mockEventClient) CancelEvent(ctx context.Context, bearerToken, eventID string, body interface{}, ifMatch string) (*domain.Event, error) {
+	args := m.Called(ctx, bearerToken, eventID, body, ifMatch)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -86,6 +87,131 @@ func (m *mockEventClient) UnpublishEvent(ctx context.Context, bearerToken, event
 	return args.Get(0).(*domain.Event), args.Error(1)
 }
 
+func (m *mockEventClient) ListComments(ctx context.Context, eventID uuid.UUID, query url.Values) (*domain.CommentPage, error) {
+	args := m.Called(ctx, eventID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CommentPage), args.Error(1)
+}
+
+func (m *mockEventClient) ListReplies(ctx context.Context, eventID, commentID uuid.UUID, query url.Values) (*domain.CommentPage, error) {
+	args := m.Called(ctx, eventID, commentID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CommentPage), args.Error(1)
+}
+
+func (m *mockEventClient) CreateComment(ctx context.Context, bearerToken string, eventID uuid.UUID, body interface{}) (*domain.Comment, error) {
+	args := m.Called(ctx, bearerToken, eventID, body)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Comment), args.Error(1)
+}
+
+func (m *mockEventClient) ListReviews(ctx context.Context, eventID uuid.UUID, query url.Values) (*domain.ReviewPage, error) {
+	args := m.Called(ctx, eventID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ReviewPage), args.Error(1)
+}
+
+func (m *mockEventClient) GetOrganizerReputation(ctx context.Context, organizerID string) (*domain.RatingSummary, error) {
+	args := m.Called(ctx, organizerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RatingSummary), args.Error(1)
+}
+
+func (m *mockEventClient) SubmitReview(ctx context.Context, bearerToken string, eventID uuid.UUID, body interface{}) (*domain.Review, error) {
+	args := m.Called(ctx, bearerToken, eventID, body)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Review), args.Error(1)
+}
+
+func (m *mockEventClient) SetReviewHidden(ctx context.Context, bearerToken string, eventID, reviewID uuid.UUID, hidden bool, reason string) (*domain.Review, error) {
+	args := m.Called(ctx, bearerToken, eventID, reviewID, hidden, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Review), args.Error(1)
+}
+
+func (m *mockEventClient) CreateReport(ctx context.Context, bearerToken string, body interface{}) (*domain.Report, error) {
+	args := m.Called(ctx, bearerToken, body)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Report), args.Error(1)
+}
+
+func (m *mockEventClient) ListRevisions(ctx context.Context, bearerToken string, eventID uuid.UUID, query url.Values) (*domain.RevisionPage, error) {
+	args := m.Called(ctx, bearerToken, eventID, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.RevisionPage), args.Error(1)
+}
+
+func (m *mockEventClient) ListModerationQueue(ctx context.Context, bearerToken string, query url.Values) (*domain.ModerationQueue, error) {
+	args := m.Called(ctx, bearerToken, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ModerationQueue), args.Error(1)
+}
+
+func (m *mockEventClient) GetModerationCase(ctx context.Context, bearerToken string, caseID uuid.UUID) (*domain.ModerationCase, error) {
+	args := m.Called(ctx, bearerToken, caseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ModerationCase), args.Error(1)
+}
+
+func (m *mockEventClient) TriageCase(ctx context.Context, bearerToken string, caseID uuid.UUID) (*domain.ModerationCase, error) {
+	args := m.Called(ctx, bearerToken, caseID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ModerationCase), args.Error(1)
+}
+
+func (m *mockEventClient) ResolveCase(ctx context.Context, bearerToken string, caseID uuid.UUID, body interface{}) (*domain.ModerationCase, error) {
+	args := m.Called(ctx, bearerToken, caseID, body)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ModerationCase), args.Error(1)
+}
+
+func (m *mockEventClient) CreateAnnouncement(ctx context.Context, bearerToken string, eventID uuid.UUID, body interface{}) (*domain.Comment, error) {
+	args := m.Called(ctx, bearerToken, eventID, body)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Comment), args.Error(1)
+}
+
+func (m *mockEventClient) SetCommentPinned(ctx context.Context, bearerToken string, eventID, commentID uuid.UUID, pinned bool) (*domain.Comment, error) {
+	args := m.Called(ctx, bearerToken, eventID, commentID, pinned)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Comment), args.Error(1)
+}
+
+func (m *mockEventClient) DeleteComment(ctx context.Context, bearerToken string, eventID, commentID uuid.UUID, reason string) error {
+	args := m.Called(ctx, bearerToken, eventID, commentID, reason)
+	return args.Error(0)
+}
+
 func (m *mockEventClient) ListMine(ctx context.Context, bearerToken string, query url.Values) (*domain.PaginatedResponse[domain.EventCard], error) {
 	args := m.Called(ctx, bearerToken, query)
 	if args.Get(0) == nil {
@@ -144,11 +270,23 @@ func (m *mockAuthClient) GetUser(ctx context.Context, userID uuid.UUID) (*domain
 	return args.Get(0).(*domain.User), args.Error(1)
 }
 
+type mockFeedClient struct {
+	mock.Mock
+}
+
+func (m *mockFeedClient) GetSimilar(ctx context.Context, eventID uuid.UUID, limit int) ([]domain.SimilarEvent, error) {
+	args := m.Called(ctx, eventID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]domain.SimilarEvent), args.Error(1)
+}
+
 func TestGetEventView_Success(t *testing.T) {
 	ec := new(mockEventClient)
 	jc := new(mockJoinClient)
 	ac := new(mockAuthClient)
-	h := NewEventHandler(ec, jc, ac)
+	h := NewEventHandler(ec, jc, ac, nil)
 
 	eventID := uuid.New()
 	userID := uuid.New()
@@ -189,7 +327,7 @@ func TestGetEventView_Degraded(t *testing.T) {
 	ec := new(mockEventClient)
 	jc := new(mockJoinClient)
 	ac := new(mockAuthClient)
-	h := NewEventHandler(ec, jc, ac)
+	h := NewEventHandler(ec, jc, ac, nil)
 
 	eventID := uuid.New()
 	userID := uuid.New()
@@ -224,11 +362,137 @@ func TestGetEventView_Degraded(t *testing.T) {
 	assert.Equal(t, "participation_unavailable", res.Actions.Reason)
 }
 
+func newEventViewRequest(eventID, userID uuid.UUID) *http.Request {
+	req := httptest.NewRequest("GET", "/api/events/"+eventID.String()+"/view", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", eventID.String())
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = context.WithValue(ctx, middleware.UserIDKey, userID)
+	return req.WithContext(ctx)
+}
+
+func TestGetEventView_WithSimilar(t *testing.T) {
+	ec := new(mockEventClient)
+	jc := new(mockJoinClient)
+	ac := new(mockAuthClient)
+	fc := new(mockFeedClient)
+	h := NewEventHandler(ec, jc, ac, fc)
+
+	eventID := uuid.New()
+	userID := uuid.New()
+	event := &domain.Event{ID: eventID, Title: "Test Event", Status: domain.EventStatusPublished, StartTime: time.Now().Add(24 * time.Hour)}
+	similar := []domain.SimilarEvent{{ID: uuid.New(), Title: "Also Good", CoJoined: 2}}
+
+	ec.On("GetEvent", mock.Anything, eventID).Return(event, nil)
+	jc.On("GetParticipation", mock.Anything, eventID, userID, mock.Anything).Return(&domain.Participation{Status: domain.StatusNone}, nil)
+	ac.On("GetUser", mock.Anything, mock.Anything).Return(&domain.User{Email: "test@example.com"}, nil)
+	fc.On("GetSimilar", mock.Anything, eventID, 6).Return(similar, nil)
+
+	w := httptest.NewRecorder()
+	h.GetEventView(w, newEventViewRequest(eventID, userID))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var res EventViewResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Len(t, res.Similar, 1)
+	assert.Equal(t, "Also Good", res.Similar[0].Title)
+	assert.Nil(t, res.Degraded)
+}
+
+func TestGetEventView_SimilarUnavailable(t *testing.T) {
+	ec := new(mockEventClient)
+	jc := new(mockJoinClient)
+	ac := new(mockAuthClient)
+	fc := new(mockFeedClient)
+	h := NewEventHandler(ec, jc, ac, fc)
+
+	eventID := uuid.New()
+	userID := uuid.New()
+	event := &domain.Event{ID: eventID, Title: "Test Event", Status: domain.EventStatusPublished, StartTime: time.Now().Add(24 * time.Hour)}
+
+	ec.On("GetEvent", mock.Anything, eventID).Return(event, nil)
+	jc.On("GetParticipation", mock.Anything, eventID, userID, mock.Anything).Return(&domain.Participation{Status: domain.StatusNone}, nil)
+	ac.On("GetUser", mock.Anything, mock.Anything).Return(&domain.User{Email: "test@example.com"}, nil)
+	fc.On("GetSimilar", mock.Anything, eventID, 6).Return(nil, downstream.ErrTimeout)
+
+	w := httptest.NewRecorder()
+	h.GetEventView(w, newEventViewRequest(eventID, userID))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var res EventViewResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Empty(t, res.Similar)
+	assert.NotNil(t, res.Degraded)
+	assert.Equal(t, "timeout", res.Degraded.Similar)
+	assert.Empty(t, res.Degraded.Participation)
+	assert.NotEqual(t, "participation_unavailable", res.Actions.Reason)
+}
+
+func TestGetEventView_OrganizerReputation(t *testing.T) {
+	ec := new(mockEventClient)
+	jc := new(mockJoinClient)
+	ac := new(mockAuthClient)
+	h := NewEventHandler(ec, jc, ac, nil)
+
+	eventID := uuid.New()
+	userID := uuid.New()
+	ownerID := uuid.New()
+	event := &domain.Event{ID: eventID, OwnerID: ownerID, Title: "Test Event", Status: domain.EventStatusPublished, StartTime: time.Now().Add(24 * time.Hour)}
+
+	ec.On("GetEvent", mock.Anything, eventID).Return(event, nil)
+	ec.On("GetOrganizerReputation", mock.Anything, ownerID.String()).Return(&domain.RatingSummary{Average: 4.5, Count: 12}, nil)
+	jc.On("GetParticipation", mock.Anything, eventID, userID, mock.Anything).Return(&domain.Participation{Status: domain.StatusNone}, nil)
+	ac.On("GetUser", mock.Anything, mock.Anything).Return(&domain.User{Email: "test@example.com"}, nil)
+
+	w := httptest.NewRecorder()
+	h.GetEventView(w, newEventViewRequest(eventID, userID))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var res EventViewResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	if assert.NotNil(t, res.OrganizerReputation) {
+		assert.Equal(t, 4.5, res.OrganizerReputation.Average)
+		assert.Equal(t, 12, res.OrganizerReputation.Count)
+	}
+	assert.Nil(t, res.Degraded)
+}
+
+func TestGetEventView_ReputationUnavailable(t *testing.T) {
+	ec := new(mockEventClient)
+	jc := new(mockJoinClient)
+	ac := new(mockAuthClient)
+	h := NewEventHandler(ec, jc, ac, nil)
+
+	eventID := uuid.New()
+	userID := uuid.New()
+	event := &domain.Event{ID: eventID, OwnerID: uuid.New(), Title: "Test Event", Status: domain.EventStatusPublished, StartTime: time.Now().Add(24 * time.Hour)}
+
+	ec.On("GetEvent", mock.Anything, eventID).Return(event, nil)
+	ec.On("GetOrganizerReputation", mock.Anything, mock.Anything).Return(nil, downstream.ErrUnavailable)
+	jc.On("GetParticipation", mock.Anything, eventID, userID, mock.Anything).Return(&domain.Participation{Status: domain.StatusNone}, nil)
+	ac.On("GetUser", mock.Anything, mock.Anything).Return(&domain.User{Email: "test@example.com"}, nil)
+
+	w := httptest.NewRecorder()
+	h.GetEventView(w, newEventViewRequest(eventID, userID))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var res EventViewResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Nil(t, res.OrganizerReputation)
+	assert.NotNil(t, res.Degraded)
+	assert.Equal(t, "unavailable", res.Degraded.Reputation)
+	assert.NotEqual(t, "participation_unavailable", res.Actions.Reason)
+}
+
 func TestGetEventView_EventNotFound(t *testing.T) {
 	ec := new(mockEventClient)
 	jc := new(mockJoinClient)
 	ac := new(mockAuthClient)
-	h := NewEventHandler(ec, jc, ac)
+	h := NewEventHandler(ec, jc, ac, nil)
 
 	eventID := uuid.New()
 	ec.On("GetEvent", mock.Anything, eventID).Return(nil, downstream.ErrNotFound)
@@ -251,7 +515,7 @@ func TestGetEventView_EventNotFound(t *testing.T) {
 
 func TestListCreatedEvents_Success(t *testing.T) {
 	ec := new(mockEventClient)
-	h := NewEventHandler(ec, nil, nil)
+	h := NewEventHandler(ec, nil, nil, nil)
 
 	userID := uuid.New()
 	token := "valid-token"
@@ -292,3 +556,222 @@ func TestListCreatedEvents_Success(t *testing.T) {
 	assert.Equal(t, 5, result.Items[0].ActiveParticipants)
 	assert.Equal(t, "2", result.NextCursor)
 }
+
+func TestListComments_PassesOnlyCursorAndLimit(t *testing.T) {
+	ec := new(mockEventClient)
+	h := NewEventHandler(ec, nil, nil, nil)
+
+	eventID := uuid.New()
+	want := url.Values{"cursor": {"c1"}, "limit": {"10"}}
+	page := &domain.CommentPage{Items: []domain.Comment{{ID: uuid.New(), Body: "hi"}}, HasMore: true, NextCursor: "c2"}
+	ec.On("ListComments", mock.Anything, eventID, want).Return(page, nil)
+
+	req := httptest.NewRequest("GET", "/api/events/"+eventID.String()+"/comments?cursor=c1&limit=10&sort=top", nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", eventID.String())
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	w := httptest.NewRecorder()
+	h.ListComments(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var result domain.CommentPage
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &result))
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, "c2", result.NextCursor)
+	ec.AssertExpectations(t)
+}
+
+func TestAdminRemoveComment_RequiresReason(t *testing.T) {
+	ec := new(mockEventClient)
+	h := NewEventHandler(ec, nil, nil, nil)
+
+	eventID, commentID := uuid.New(), uuid.New()
+	newReq := func(body string) *http.Request {
+		req := httptest.NewRequest("DELETE", "/api/admin/events/"+eventID.String()+"/comments/"+commentID.String(), strings.NewReader(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", eventID.String())
+		rctx.URLParams.Add("comment_id", commentID.String())
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, middleware.BearerTokenKey, "Bearer admin")
+		return req.WithContext(ctx)
+	}
+
+	w := httptest.NewRecorder()
+	h.AdminRemoveComment(w, newReq(`{"reason":"  "}`))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	ec.AssertNotCalled(t, "DeleteComment", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	ec.On("DeleteComment", mock.Anything, "Bearer admin", eventID, commentID, "spam").Return(nil)
+	w = httptest.NewRecorder()
+	h.AdminRemoveComment(w, newReq(`{"reason":"spam"}`))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	ec.AssertExpectations(t)
+}
+
+func TestResolveCase_BanUser(t *testing.T) {
+	ec := new(mockEventClient)
+	ac := new(mockAuthClient)
+	h := NewEventHandler(ec, nil, ac, nil)
+
+	caseID, userID := uuid.New(), uuid.New()
+	newReq := func(body string) *http.Request {
+		req := httptest.NewRequest("POST", "/api/admin/reports/"+caseID.String()+"/resolve", strings.NewReader(body))
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("case_id", caseID.String())
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, middleware.BearerTokenKey, "Bearer mod")
+		return req.WithContext(ctx)
+	}
+
+	// event-service bans in auth-service; its refusal is relayed as is.
+	ec.On("ResolveCase", mock.Anything, "Bearer mod", caseID, mock.Anything).
+		Return(nil, &downstream.StatusError{StatusCode: 403, Code: "forbidden", Message: "cannot moderate admin"}).Once()
+	w := httptest.NewRecorder()
+	h.ResolveCase(w, newReq(`{"status":"actioned","action":"ban_user"}`))
+	assert.Equal(t, http.StatusForbidden, w.Code)
+
+	resolved := &domain.ModerationCase{ID: caseID, TargetType: "user", TargetID: userID.String(), Status: "actioned", Action: "ban_user"}
+	ec.On("ResolveCase", mock.Anything, "Bearer mod", caseID, mock.Anything).Return(resolved, nil).Once()
+	w = httptest.NewRecorder()
+	h.ResolveCase(w, newReq(`{"status":"actioned","action":"ban_user"}`))
+	assert.Equal(t, http.StatusOK, w.Code)
+	ec.AssertExpectations(t)
+	ac.AssertExpectations(t)
+}
+
+func TestUpdateEvent_IfMatch(t *testing.T) {
+	ec := new(mockEventClient)
+	h := NewEventHandler(ec, nil, nil, nil)
+
+	eventID := uuid.New()
+	newReq := func(ifMatch string) *http.Request {
+		req := httptest.NewRequest("PATCH", "/api/events/"+eventID.String(), strings.NewReader(`{"title":"New"}`))
+		req.Header.Set("If-Match", ifMatch)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("id", eventID.String())
+		ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+		ctx = context.WithValue(ctx, middleware.BearerTokenKey, "Bearer owner")
+		return req.WithContext(ctx)
+	}
+
+	ec.On("UpdateEvent", mock.Anything, "Bearer owner", eventID.String(), mock.Anything, `"2"`).
+		Return(&domain.Event{ID: eventID, Title: "New", Version: 3}, nil).Once()
+	w := httptest.NewRecorder()
+	h.UpdateEvent(w, newReq(`"2"`))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+	// A stale version is relayed as 412 with the current event.
+	ec.On("UpdateEvent", mock.Anything, "Bearer owner", eventID.String(), mock.Anything, `"2"`).
+		Return(nil, &downstream.StatusError{
+			StatusCode: http.StatusPreconditionFailed,
+			Code:       "precondition_failed",
+			Message:    "event has been modified",
+			ETag:       `"3"`,
+			Data:       json.RawMessage(`{"title":"New","version":3}`),
+		}).Once()
+	w = httptest.NewRecorder()
+	h.UpdateEvent(w, newReq(`"2"`))
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code)
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+	var body struct {
+		Error struct {
+			Code string `json:"code"`
+		} `json:"error"`
+		Data domain.Event `json:"data"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, "precondition_failed", body.Error.Code)
+	assert.Equal(t, int64(3), body.Data.Version)
+	ec.AssertExpectations(t)
+}
+
+type mockMediaClient struct {
+	mock.Mock
+}
+
+func (m *mockMediaClient) GetPlaceholders(ctx context.Context, ids []string) (map[string]domain.ImagePlaceholder, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]domain.ImagePlaceholder), args.Error(1)
+}
+
+func TestListEvents_InlinesFirstCoverPlaceholder(t *testing.T) {
+	ec := new(mockEventClient)
+	mc := new(mockMediaClient)
+	h := NewEventHandler(ec, nil, nil, nil)
+	h.SetMediaClient(mc)
+
+	first, second := uuid.NewString(), uuid.NewString()
+	page := &domain.PaginatedResponse[domain.EventCard]{Items: []domain.EventCard{
+		{ID: uuid.New(), Title: "With cover", CoverImageIDs: []string{first, second}},
+		{ID: uuid.New(), Title: "No cover"},
+	}}
+	ph := domain.ImagePlaceholder{Width: 1600, Height: 900, BlurHash: "LEHV6nWB2yk8pyo0adR*.7kCMdnj", DominantColor: "#1478c8"}
+
+	ec.On("ListEvents", mock.Anything, mock.Anything).Return(page, nil)
+	mc.On("GetPlaceholders", mock.Anything, []string{first}).Return(map[string]domain.ImagePlaceholder{first: ph}, nil)
+
+	w := httptest.NewRecorder()
+	h.ListEvents(w, httptest.NewRequest("GET", "/api/events", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var res domain.PaginatedResponse[domain.EventCard]
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(t, map[string]domain.ImagePlaceholder{first: ph}, res.Items[0].CoverPlaceholders)
+	assert.Nil(t, res.Items[1].CoverPlaceholders)
+	mc.AssertExpectations(t)
+}
+
+func TestGetEventView_CoverPlaceholders(t *testing.T) {
+	coverID := uuid.NewString()
+	ph := domain.ImagePlaceholder{Width: 800, Height: 600, BlurHash: "LEHV6nWB2yk8pyo0adR*.7kCMdnj", DominantColor: "#336699"}
+
+	cases := []struct {
+		name     string
+		found    map[string]domain.ImagePlaceholder
+		err      error
+		degraded string
+	}{
+		{"inlined", map[string]domain.ImagePlaceholder{coverID: ph}, nil, ""},
+		{"media-service down", nil, downstream.ErrTimeout, "timeout"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ec := new(mockEventClient)
+			jc := new(mockJoinClient)
+			ac := new(mockAuthClient)
+			mc := new(mockMediaClient)
+			h := NewEventHandler(ec, jc, ac, nil)
+			h.SetMediaClient(mc)
+
+			eventID := uuid.New()
+			userID := uuid.New()
+			event := &domain.Event{ID: eventID, Title: "Test Event", CoverImageIDs: []string{coverID}, StartTime: time.Now().Add(24 * time.Hour)}
+
+			ec.On("GetEvent", mock.Anything, eventID).Return(event, nil)
+			jc.On("GetParticipation", mock.Anything, eventID, userID, mock.Anything).Return(&domain.Participation{Status: domain.StatusNone}, nil)
+			ac.On("GetUser", mock.Anything, mock.Anything).Return(&domain.User{Email: "test@example.com"}, nil)
+			mc.On("GetPlaceholders", mock.Anything, []string{coverID}).Return(c.found, c.err)
+
+			w := httptest.NewRecorder()
+			h.GetEventView(w, newEventViewRequest(eventID, userID))
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			var res EventViewResponse
+			assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+			if c.degraded == "" {
+				assert.Equal(t, c.found, res.Event.CoverPlaceholders)
+				assert.Nil(t, res.Degraded)
+			} else {
+				assert.Empty(t, res.Event.CoverPlaceholders)
+				assert.Equal(t, c.degraded, res.Degraded.Placeholders)
+				assert.Empty(t, res.Degraded.Participation)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/internal/downstream"
+	"github.com/baechuer/real-time-ressys/services/bff-service/internal/logger"
+	"github.com/baechuer/real-time-ressys/services/bff-service/middleware"
+	"github.com/google/uuid"
+)
+
+// anonCookieName is the HMAC-signed cookie feed-service issues to visitors
+const anonCookieName = "anon_id"
+
+// IdentityMerger links anonymous feed activity to an account on sign-in.
+type IdentityMerger struct {
+	feedClient *downstream.FeedClient
+	timeout    time.Duration
+}
+
+func NewIdentityMerger(feedClient *downstream.FeedClient) *IdentityMerger {
+	return &IdentityMerger{feedClient: feedClient, timeout: 3 * time.Second}
+}
+
+// AfterLogin is a proxy.LoginHook. The merge runs in the background so the
+// login response is never delayed or failed by feed-service.
+func (m *IdentityMerger) AfterLogin(userID uuid.UUID, r *http.Request) {
+	c, err := r.Cookie(anonCookieName)
+	if err != nil || c.Value == "" {
+		return
+	}
+	// Detach from the request lifetime but keep its values (request id)
+	ctx := context.WithoutCancel(r.Context())
+	reqID := middleware.GetRequestID(ctx)
+
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, m.timeout)
+		defer cancel()
+
+		if err := m.feedClient.MergeAnonymous(ctx, userID.String(), c.Value); err != nil {
+			logger.Log.Warn().
+				Err(err).
+				Str("user_id", userID.String()).
+				Str("request_id", reqID).
+				Msg("anon_identity_merge_failed")
+		}
+	}()
+}
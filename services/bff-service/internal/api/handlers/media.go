@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/baechuer/real-time-ressys/services/bff-service/middleware"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 )
 
@@ -58,13 +60,24 @@ func (h *MediaHandler) RequestUpload(w http.ResponseWriter, r *http.Request) {
 
 // CompleteUpload proxies complete request to media-service.
 func (h *MediaHandler) CompleteUpload(w http.ResponseWriter, r *http.Request) {
+	h.proxyAsUser(w, r, "/media/v1/complete")
+}
+
+// Recrop proxies a crop change for a READY upload to media-service.
+func (h *MediaHandler) Recrop(w http.ResponseWriter, r *http.Request) {
+	h.proxyAsUser(w, r, "/media/v1/recrop")
+}
+
+// proxyAsUser POSTs the request body to media-service on behalf of the
+// authenticated user.
+func (h *MediaHandler) proxyAsUser(w http.ResponseWriter, r *http.Request, path string) {
 	userID := middleware.GetUserID(r.Context())
 	if userID == uuid.Nil {
 		h.errorResponse(w, http.StatusUnauthorized, "authentication required")
 		return
 	}
 
-	proxyURL := h.mediaServiceURL + "/media/v1/complete"
+	proxyURL := h.mediaServiceURL + path
 	req, err := http.NewRequestWithContext(r.Context(), "POST", proxyURL, r.Body)
 	if err != nil {
 		h.errorResponse(w, http.StatusInternalServerError, "failed to create request")
@@ -116,6 +129,43 @@ func (h *MediaHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 	io.Copy(w, resp.Body)
 }
 
+// GetImage passes the image redirect through so media-service can pick a
+// format from the caller's Accept header.
+func (h *MediaHandler) GetImage(w http.ResponseWriter, r *http.Request) {
+	proxyURL := h.mediaServiceURL + "/media/v1/image/" + url.PathEscape(chi.URLParam(r, "id"))
+	if size := r.URL.Query().Get("size"); size != "" {
+		proxyURL += "?size=" + url.QueryEscape(size)
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), "GET", proxyURL, nil)
+	if err != nil {
+		h.errorResponse(w, http.StatusInternalServerError, "failed to create request")
+		return
+	}
+
+	req.Header.Set("Accept", r.Header.Get("Accept"))
+	req.Header.Set("X-Request-ID", r.Header.Get("X-Request-ID"))
+
+	// Hand the redirect to the browser instead of fetching the image.
+	client := *h.httpClient
+	client.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	resp, err := client.Do(req)
+	if err != nil {
+		h.errorResponse(w, http.StatusBadGateway, "media service unavailable")
+		return
+	}
+	defer resp.Body.Close()
+
+	for _, k := range []string{"Location", "Vary", "Cache-Control", "Content-Type"} {
+		if v := resp.Header.Get(k); v != "" {
+			w.Header().Set(k, v)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
 func (h *MediaHandler) errorResponse(w http.ResponseWriter, status int, message string) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// MediaAdminHandler lets admins inspect and adjust a user's media usage and
+// quota, and moderators review quarantined uploads. It proxies to
+// media-service's internal endpoints.
+type MediaAdminHandler struct {
+	mediaServiceURL string
+	internalSecret  string
+	httpClient      *http.Client
+}
+
+// NewMediaAdminHandler creates a new media admin handler.
+func NewMediaAdminHandler(mediaServiceURL, internalSecret string) *MediaAdminHandler {
+	return &MediaAdminHandler{
+		mediaServiceURL: mediaServiceURL,
+		internalSecret:  internalSecret,
+		httpClient:      &http.Client{},
+	}
+}
+
+// GetUsage returns the user's usage, effective quota and any override.
+func (h *MediaAdminHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	if path, ok := h.idPath(w, r, "/users/", "/usage", "invalid user ID"); ok {
+		h.proxy(w, r, http.MethodGet, path, false)
+	}
+}
+
+// SetQuota overrides the user's upload and storage limits.
+func (h *MediaAdminHandler) SetQuota(w http.ResponseWriter, r *http.Request) {
+	if path, ok := h.idPath(w, r, "/users/", "/quota", "invalid user ID"); ok {
+		h.proxy(w, r, http.MethodPut, path, true)
+	}
+}
+
+// ResetQuota returns the user to the default limits.
+func (h *MediaAdminHandler) ResetQuota(w http.ResponseWriter, r *http.Request) {
+	if path, ok := h.idPath(w, r, "/users/", "/quota", "invalid user ID"); ok {
+		h.proxy(w, r, http.MethodDelete, path, false)
+	}
+}
+
+// ListQuarantine lists uploads the classifier flagged, oldest first, with
+// a short-lived preview link each. Passes limit through.
+func (h *MediaAdminHandler) ListQuarantine(w http.ResponseWriter, r *http.Request) {
+	path := "/moderation/queue"
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		path += "?" + url.Values{"limit": {limit}}.Encode()
+	}
+	h.proxy(w, r, http.MethodGet, path, false)
+}
+
+// ApproveUpload releases a quarantined upload for processing.
+func (h *MediaAdminHandler) ApproveUpload(w http.ResponseWriter, r *http.Request) {
+	if path, ok := h.idPath(w, r, "/moderation/", "/approve", "invalid upload ID"); ok {
+		h.proxy(w, r, http.MethodPost, path, false)
+	}
+}
+
+// RejectUpload rejects a quarantined or published upload with a reason;
+// event-service then detaches it from any event.
+func (h *MediaAdminHandler) RejectUpload(w http.ResponseWriter, r *http.Request) {
+	if path, ok := h.idPath(w, r, "/moderation/", "/reject", "invalid upload ID"); ok {
+		h.proxy(w, r, http.MethodPost, path, true)
+	}
+}
+
+// idPath builds prefix+{id}+suffix from the route's id, writing a 400 when
+// it is not a UUID.
+func (h *MediaAdminHandler) idPath(w http.ResponseWriter, r *http.Request, prefix, suffix, invalid string) (string, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.errorResponse(w, http.StatusBadRequest, invalid)
+		return "", false
+	}
+	return prefix + url.PathEscape(id.String()) + suffix, true
+}
+
+// proxy forwards to media-service's internal API. The caller's user ID
+// goes along as X-Moderator-ID for the moderation audit trail.
+func (h *MediaAdminHandler) proxy(w http.ResponseWriter, r *http.Request, method, path string, withBody bool) {
+	var body io.Reader
+	if withBody {
+		body = r.Body
+	}
+	proxyURL := h.mediaServiceURL + "/media/v1/internal" + path
+	req, err := http.NewRequestWithContext(r.Context(), method, proxyURL, body)
+	if err != nil {
+		h.errorResponse(w, http.StatusInternalServerError, "failed to create request")
+		return
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Internal-Secret", h.internalSecret)
+	req.Header.Set("X-Request-ID", r.Header.Get("X-Request-ID"))
+	req.Header.Set("X-Moderator-ID", middleware.GetUserID(r.Context()).String())
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		h.errorResponse(w, http.StatusBadGateway, "media service unavailable")
+		return
+	}
+	defer resp.Body.Close()
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(resp.StatusCode)
+	io.Copy(w, resp.Body)
+}
+
+func (h *MediaAdminHandler) errorResponse(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// CreateReport files a report of an event, comment or user. Repeat reports
+// by the same user come back with "duplicate": true and status 200.
+func (h *EventHandler) CreateReport(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		TargetType string `json:"target_type"`
+		TargetID   string `json:"target_id"`
+		EventID    string `json:"event_id,omitempty"`
+		Reason     string `json:"reason"`
+		Details    string `json:"details,omitempty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendError(w, r, "validation_failed", "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	rp, err := h.eventClient.CreateReport(r.Context(), bearerToken, body)
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to submit report")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if !rp.Duplicate {
+		w.WriteHeader(http.StatusCreated)
+	}
+	json.NewEncoder(w).Encode(rp)
+}
+
+// ListModerationQueue passes status, target_type, cursor and limit through.
+func (h *EventHandler) ListModerationQueue(w http.ResponseWriter, r *http.Request) {
+	in := r.URL.Query()
+	query := url.Values{}
+	for _, k := range []string{"status", "target_type", "cursor", "limit"} {
+		if v := strings.TrimSpace(in.Get(k)); v != "" {
+			query.Set(k, v)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 1500*time.Millisecond)
+	defer cancel()
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	page, err := h.eventClient.ListModerationQueue(ctx, bearerToken, query)
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to fetch moderation queue")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+func (h *EventHandler) GetModerationCase(w http.ResponseWriter, r *http.Request) {
+	caseID, ok := caseIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	c, err := h.eventClient.GetModerationCase(r.Context(), bearerToken, caseID)
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to fetch moderation case")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+func (h *EventHandler) TriageCase(w http.ResponseWriter, r *http.Request) {
+	caseID, ok := caseIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	c, err := h.eventClient.TriageCase(r.Context(), bearerToken, caseID)
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to triage case")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+// ResolveCase actions or dismisses a case. event-service carries out the
+// action, including "ban_user", which it bans in auth-service before the
+// case closes.
+func (h *EventHandler) ResolveCase(w http.ResponseWriter, r *http.Request) {
+	caseID, ok := caseIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Status string `json:"status"`
+		Action string `json:"action,omitempty"`
+		Note   string `json:"note,omitempty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendError(w, r, "validation_failed", "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	c, err := h.eventClient.ResolveCase(r.Context(), bearerToken, caseID, body)
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to resolve case")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+func caseIDParam(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	caseID, err := uuid.Parse(chi.URLParam(r, "case_id"))
+	if err != nil {
+		sendError(w, r, "validation_failed", "invalid case id", http.StatusBadRequest)
+		return uuid.Nil, false
+	}
+	return caseID, true
+}
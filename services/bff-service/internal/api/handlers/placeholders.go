@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/internal/domain"
+)
+
+// maxPlaceholderIDs matches media-service's batch limit.
+const maxPlaceholderIDs = 100
+
+// placeholderBudget bounds the placeholder lookup; a slow media-service
+// only costs the blurred previews.
+const placeholderBudget = 150 * time.Millisecond
+
+type MediaClient interface {
+	GetPlaceholders(ctx context.Context, ids []string) (map[string]domain.ImagePlaceholder, error)
+}
+
+// SetMediaClient enables cover placeholders on cards and the event view.
+func (h *EventHandler) SetMediaClient(mc MediaClient) {
+	h.mediaClient = mc
+}
+
+// fetchPlaceholders returns the placeholders for ids, or nil when there is
+// no media client or nothing to look up.
+func (h *EventHandler) fetchPlaceholders(ctx context.Context, ids []string) (map[string]domain.ImagePlaceholder, error) {
+	if h.mediaClient == nil || len(ids) == 0 {
+		return nil, nil
+	}
+	if len(ids) > maxPlaceholderIDs {
+		ids = ids[:maxPlaceholderIDs]
+	}
+	ctx, cancel := context.WithTimeout(ctx, placeholderBudget)
+	defer cancel()
+	return h.mediaClient.GetPlaceholders(ctx, ids)
+}
+
+// attachCardPlaceholders adds the first cover's placeholder to each card.
+// Failures leave the cards as they are.
+func (h *EventHandler) attachCardPlaceholders(ctx context.Context, cards []domain.EventCard) {
+	ids := make([]string, 0, len(cards))
+	for _, c := range cards {
+		if len(c.CoverImageIDs) > 0 {
+			ids = append(ids, c.CoverImageIDs[0])
+		}
+	}
+
+	found, err := h.fetchPlaceholders(ctx, ids)
+	if err != nil || len(found) == 0 {
+		return
+	}
+	for i := range cards {
+		if len(cards[i].CoverImageIDs) == 0 {
+			continue
+		}
+		id := cards[i].CoverImageIDs[0]
+		if p, ok := found[id]; ok {
+			cards[i].CoverPlaceholders = map[string]domain.ImagePlaceholder{id: p}
+		}
+	}
+}
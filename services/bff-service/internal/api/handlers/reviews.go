@@ -0,0 +1,109 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ListReviews returns a keyset page of an event's reviews with its rating
+// summary. Only cursor and limit are passed through.
+func (h *EventHandler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		sendError(w, r, "validation_failed", "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 1500*time.Millisecond)
+	defer cancel()
+
+	page, err := h.eventClient.ListReviews(ctx, eventID, commentPageQuery(r))
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to fetch reviews")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
+// SubmitReview creates or replaces the caller's review; event-service checks
+// attendance and the review window.
+func (h *EventHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		sendError(w, r, "validation_failed", "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	var body struct {
+		Rating int    `json:"rating"`
+		Body   string `json:"body,omitempty"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		sendError(w, r, "validation_failed", "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	rv, err := h.eventClient.SubmitReview(r.Context(), bearerToken, eventID, body)
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to submit review")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rv)
+}
+
+// AdminHideReview takes a review out of public view; a reason is required
+// so the action can be audited.
+func (h *EventHandler) AdminHideReview(w http.ResponseWriter, r *http.Request) {
+	var reqBody struct {
+		Reason string `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+		sendError(w, r, "validation_failed", "invalid request body", http.StatusBadRequest)
+		return
+	}
+	reason := strings.TrimSpace(reqBody.Reason)
+	if reason == "" {
+		sendError(w, r, "validation_failed", "reason is required", http.StatusBadRequest)
+		return
+	}
+	h.setReviewHidden(w, r, true, reason)
+}
+
+func (h *EventHandler) AdminUnhideReview(w http.ResponseWriter, r *http.Request) {
+	h.setReviewHidden(w, r, false, "")
+}
+
+func (h *EventHandler) setReviewHidden(w http.ResponseWriter, r *http.Request, hidden bool, reason string) {
+	eventID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		sendError(w, r, "validation_failed", "invalid event id", http.StatusBadRequest)
+		return
+	}
+	reviewID, err := uuid.Parse(chi.URLParam(r, "review_id"))
+	if err != nil {
+		sendError(w, r, "validation_failed", "invalid review id", http.StatusBadRequest)
+		return
+	}
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	rv, err := h.eventClient.SetReviewHidden(r.Context(), bearerToken, eventID, reviewID, hidden, reason)
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to update review visibility")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rv)
+}
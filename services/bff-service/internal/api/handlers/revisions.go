@@ -0,0 +1,35 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/middleware"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// ListRevisions returns an event's change history; event-service limits it
+// to the organizer team and moderators.
+func (h *EventHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		sendError(w, r, "validation_failed", "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 1500*time.Millisecond)
+	defer cancel()
+
+	bearerToken := middleware.GetBearerToken(r.Context())
+	page, err := h.eventClient.ListRevisions(ctx, bearerToken, eventID, commentPageQuery(r))
+	if err != nil {
+		handleDownstreamError(w, r, err, "failed to fetch event history")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
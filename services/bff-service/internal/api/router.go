@@ -38,8 +38,8 @@ func NewRouter(cfg *config.Config) http.Handler {
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   cfg.CORSAllowedOrigins,
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Idempotency-Key", "X-Request-Id"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Idempotency-Key", "X-Request-Id", "If-Match"},
+		ExposedHeaders:   []string{"Link", "ETag"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -78,7 +78,9 @@ func NewRouter(cfg *config.Config) http.Handler {
 	eventClient := downstream.NewEventClient(cfg.EventServiceURL)
 	joinClient := downstream.NewJoinClient(cfg.JoinServiceURL)
 	authClient := downstream.NewAuthClient(cfg.AuthServiceURL, cfg.InternalSecretKey)
-	eventHandler := handlers.NewEventHandler(eventClient, joinClient, authClient)
+	feedClient := downstream.NewFeedClient(cfg.FeedServiceURL, cfg.InternalSecretKey)
+	eventHandler := handlers.NewEventHandler(eventClient, joinClient, authClient, feedClient)
+	eventHandler.SetMediaClient(downstream.NewMediaClient(cfg.MediaServiceURL, cfg.InternalSecretKey))
 
 	// 6. Readiness checks (for downstream services)
 	readinessHandler := handlers.NewReadinessHandler(
@@ -104,6 +106,8 @@ func NewRouter(cfg *config.Config) http.Handler {
 		if err != nil {
 			log.Fatalf("Invalid Auth URL: %v", err)
 		}
+		// Fold pre-signup browsing into the new account's feed profile
+		proxy.OnLogin(authProxy, handlers.NewIdentityMerger(feedClient).AfterLogin)
 		r.Mount("/auth", authProxy)
 
 		// Feed Service Proxy (for recommendation feed)
@@ -116,8 +120,12 @@ func NewRouter(cfg *config.Config) http.Handler {
 		// Public Routes (No Auth Required, but context is populated if token is present)
 		r.Get("/feed", eventHandler.ListFeed) // Legacy fallback
 		r.Get("/events/{id}/view", eventHandler.GetEventView)
+		r.Get("/events/{id}/comments", eventHandler.ListComments)
+		r.Get("/events/{id}/comments/{comment_id}/replies", eventHandler.ListReplies)
+		r.Get("/events/{id}/reviews", eventHandler.ListReviews)
 		r.Get("/meta/cities", eventHandler.GetCitySuggestions)
 		r.Get("/media/{id}/status", handlers.NewMediaHandler(cfg.MediaServiceURL).GetStatus)
+		r.Get("/media/{id}/image", handlers.NewMediaHandler(cfg.MediaServiceURL).GetImage)
 
 		// Business Handlers (Authenticated)
 		r.Group(func(r chi.Router) {
@@ -129,20 +137,54 @@ func NewRouter(cfg *config.Config) http.Handler {
 			r.Post("/events/{id}/publish", eventHandler.PublishEvent)
 			r.Post("/events/{id}/cancel-event", eventHandler.CancelEvent)
 			r.Post("/events/{id}/unpublish", eventHandler.UnpublishEvent)
+			r.Get("/events/{id}/revisions", eventHandler.ListRevisions)
 			r.Post("/events/{id}/join", eventHandler.JoinEvent)
 			r.Post("/events/{id}/cancel", eventHandler.CancelJoin)
+			r.Post("/events/{id}/comments", eventHandler.CreateComment)
+			r.Delete("/events/{id}/comments/{comment_id}", eventHandler.DeleteComment)
+			r.Put("/events/{id}/comments/{comment_id}/pin", eventHandler.PinComment)
+			r.Delete("/events/{id}/comments/{comment_id}/pin", eventHandler.UnpinComment)
+			r.Post("/events/{id}/announcements", eventHandler.CreateAnnouncement)
+			r.Put("/events/{id}/review", eventHandler.SubmitReview)
+			r.Post("/reports", eventHandler.CreateReport)
 
 			// Media Upload Routes
 			mediaHandler := handlers.NewMediaHandler(cfg.MediaServiceURL)
 			r.Post("/media/request-upload", mediaHandler.RequestUpload)
 			r.Post("/media/complete", mediaHandler.CompleteUpload)
+			r.Post("/media/recrop", mediaHandler.Recrop)
+
+			// Email preferences (reminders, weekly digest)
+			emailPrefs := handlers.NewEmailPreferencesHandler(cfg.EmailServiceURL, cfg.InternalSecretKey)
+			r.Get("/me/email-preferences", emailPrefs.Get)
+			r.Put("/me/email-preferences", emailPrefs.Update)
 		})
 
+		mediaAdmin := handlers.NewMediaAdminHandler(cfg.MediaServiceURL, cfg.InternalSecretKey)
+
 		// Admin/Moderator Routes
 		r.Group(func(r chi.Router) {
 			r.Use(RequireRole("admin", "moderator"))
 			r.Post("/admin/events/{id}/cancel", eventHandler.AdminCancelEvent)
 			r.Post("/admin/events/{id}/unpublish", eventHandler.AdminUnpublishEvent)
+			r.Delete("/admin/events/{id}/comments/{comment_id}", eventHandler.AdminRemoveComment)
+			r.Put("/admin/events/{id}/reviews/{review_id}/hide", eventHandler.AdminHideReview)
+			r.Delete("/admin/events/{id}/reviews/{review_id}/hide", eventHandler.AdminUnhideReview)
+			r.Get("/admin/reports", eventHandler.ListModerationQueue)
+			r.Get("/admin/reports/{case_id}", eventHandler.GetModerationCase)
+			r.Post("/admin/reports/{case_id}/triage", eventHandler.TriageCase)
+			r.Post("/admin/reports/{case_id}/resolve", eventHandler.ResolveCase)
+			r.Get("/admin/media/quarantine", mediaAdmin.ListQuarantine)
+			r.Post("/admin/media/{id}/approve", mediaAdmin.ApproveUpload)
+			r.Post("/admin/media/{id}/reject", mediaAdmin.RejectUpload)
+		})
+
+		// Admin-only: media usage and quotas
+		r.Group(func(r chi.Router) {
+			r.Use(RequireRole("admin"))
+			r.Get("/admin/users/{id}/media-usage", mediaAdmin.GetUsage)
+			r.Put("/admin/users/{id}/media-quota", mediaAdmin.SetQuota)
+			r.Delete("/admin/users/{id}/media-quota", mediaAdmin.ResetQuota)
 		})
 	})
 
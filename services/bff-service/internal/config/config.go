@@ -14,6 +14,7 @@ type Config struct {
 	JoinServiceURL     string
 	FeedServiceURL     string
 	MediaServiceURL    string
+	EmailServiceURL    string
 	JWTSecret          string
 	InternalSecretKey  string
 	RLEnabled          bool
@@ -36,6 +37,7 @@ func Load() *Config {
 		JoinServiceURL:     getEnv("JOIN_SERVICE_URL", "http://join-service:8080"),
 		FeedServiceURL:     getEnv("FEED_SERVICE_URL", "http://feed-service:8084"),
 		MediaServiceURL:    getEnv("MEDIA_SERVICE_URL", "http://media-service:8085"),
+		EmailServiceURL:    getEnv("EMAIL_SERVICE_URL", "http://email-service:8090"),
 		JWTSecret:          getEnv("JWT_SECRET", "change-me-secret"),
 		InternalSecretKey:  getEnv("INTERNAL_SECRET_KEY", "sharedkey"),
 		RLEnabled:          getEnvBool("RATE_LIMIT_ENABLED", true),
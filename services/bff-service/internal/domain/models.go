@@ -13,22 +13,26 @@ var (
 )
 
 type Event struct {
-	ID                 uuid.UUID `json:"id"`
-	Title              string    `json:"title"`
-	Description        string    `json:"description"`
-	City               string    `json:"city"`
-	Category           string    `json:"category"`
-	CoverImage         string    `json:"cover_image,omitempty"`
-	CoverImageIDs      []string  `json:"cover_image_ids,omitempty"`
-	StartTime          time.Time `json:"start_time"`
-	EndTime            time.Time `json:"end_time"`
-	Location           string    `json:"location"`
-	Capacity           int       `json:"capacity"`
-	ActiveParticipants int       `json:"active_participants"`
-	CreatedBy          uuid.UUID `json:"created_by"` // Deprecated?
-	OwnerID            uuid.UUID `json:"owner_id"`
-	OrganizerName      string    `json:"organizer_name,omitempty"`
-	Status             string    `json:"status"` // "draft", "published", "canceled"
+	ID            uuid.UUID `json:"id"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	City          string    `json:"city"`
+	Category      string    `json:"category"`
+	CoverImage    string    `json:"cover_image,omitempty"`
+	CoverImageIDs []string  `json:"cover_image_ids,omitempty"`
+	// CoverPlaceholders maps cover upload id to its placeholder; filled by
+	// the BFF from media-service.
+	CoverPlaceholders  map[string]ImagePlaceholder `json:"cover_placeholders,omitempty"`
+	StartTime          time.Time                   `json:"start_time"`
+	EndTime            time.Time                   `json:"end_time"`
+	Location           string                      `json:"location"`
+	Capacity           int                         `json:"capacity"`
+	ActiveParticipants int                         `json:"active_participants"`
+	CreatedBy          uuid.UUID                   `json:"created_by"` // Deprecated?
+	OwnerID            uuid.UUID                   `json:"owner_id"`
+	OrganizerName      string                      `json:"organizer_name,omitempty"`
+	Status             string                      `json:"status"` // "draft", "published", "canceled"
+	Version            int64                       `json:"version,omitempty"`
 }
 
 type User struct {
@@ -47,15 +51,38 @@ const (
 )
 
 type EventCard struct {
-	ID                 uuid.UUID `json:"id"`
-	Title              string    `json:"title"`
-	CoverImage         string    `json:"cover_image,omitempty"`
-	CoverImageIDs      []string  `json:"cover_image_ids,omitempty"`
-	StartTime          time.Time `json:"start_time"`
-	EndTime            time.Time `json:"end_time"`
-	City               string    `json:"city"`
-	Category           string    `json:"category"`
-	ActiveParticipants int       `json:"active_participants"`
+	ID            uuid.UUID `json:"id"`
+	Title         string    `json:"title"`
+	CoverImage    string    `json:"cover_image,omitempty"`
+	CoverImageIDs []string  `json:"cover_image_ids,omitempty"`
+	// CoverPlaceholders holds the placeholder of the first cover only.
+	CoverPlaceholders  map[string]ImagePlaceholder `json:"cover_placeholders,omitempty"`
+	StartTime          time.Time                   `json:"start_time"`
+	EndTime            time.Time                   `json:"end_time"`
+	City               string                      `json:"city"`
+	Category           string                      `json:"category"`
+	ActiveParticipants int                         `json:"active_participants"`
+}
+
+// ImagePlaceholder lets the web app reserve space and paint a blurred
+// preview before the cover image loads.
+type ImagePlaceholder struct {
+	Width         int    `json:"width,omitempty"`
+	Height        int    `json:"height,omitempty"`
+	BlurHash      string `json:"blurhash,omitempty"`
+	DominantColor string `json:"dominant_color,omitempty"`
+}
+
+// SimilarEvent is an item-to-item recommendation from feed-service
+type SimilarEvent struct {
+	ID            uuid.UUID `json:"id"`
+	Title         string    `json:"title"`
+	City          string    `json:"city"`
+	Tags          []string  `json:"tags"`
+	StartTime     time.Time `json:"start_time"`
+	CoverImageIDs []string  `json:"cover_image_ids,omitempty"`
+	Score         float64   `json:"score"`
+	CoJoined      int       `json:"co_joined"` // people who joined both events
 }
 
 type PaginatedResponse[T any] struct {
@@ -97,6 +124,127 @@ type ActionPolicy struct {
 	Reason         string `json:"reason,omitempty"`
 }
 
+// Comment is a post in an event discussion (kind comment or announcement).
+// Deleted posts arrive as tombstones with an empty body.
+type Comment struct {
+	ID         uuid.UUID  `json:"id"`
+	EventID    uuid.UUID  `json:"event_id"`
+	ParentID   *uuid.UUID `json:"parent_id,omitempty"`
+	AuthorID   string     `json:"author_id"`
+	Kind       string     `json:"kind"`
+	Title      string     `json:"title,omitempty"`
+	Body       string     `json:"body"`
+	Pinned     bool       `json:"pinned"`
+	ReplyCount int        `json:"reply_count"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	Deleted    bool       `json:"deleted"`
+	Removed    bool       `json:"removed,omitempty"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+}
+
+// CommentPage is a keyset page of a discussion; Pinned is only set on the
+// first page of the top-level list.
+type CommentPage struct {
+	Pinned     []Comment `json:"pinned,omitempty"`
+	Items      []Comment `json:"items"`
+	NextCursor string    `json:"next_cursor"`
+	HasMore    bool      `json:"has_more"`
+}
+
+// RatingSummary aggregates the visible reviews of an event or organizer.
+type RatingSummary struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+type Review struct {
+	ID           uuid.UUID  `json:"id"`
+	EventID      uuid.UUID  `json:"event_id"`
+	OrganizerID  string     `json:"organizer_id"`
+	AuthorID     string     `json:"author_id"`
+	Rating       int        `json:"rating"`
+	Body         string     `json:"body,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	Hidden       bool       `json:"hidden,omitempty"`
+	HiddenAt     *time.Time `json:"hidden_at,omitempty"`
+	HiddenReason string     `json:"hidden_reason,omitempty"`
+}
+
+// ReviewPage is a keyset page of an event's visible reviews with the
+// event's rating summary.
+type ReviewPage struct {
+	Summary    RatingSummary `json:"summary"`
+	Items      []Review      `json:"items"`
+	NextCursor string        `json:"next_cursor"`
+	HasMore    bool          `json:"has_more"`
+}
+
+// Report is a user's report as echoed back to the reporter, or one of a
+// case's reports in the moderator view (ReporterID set).
+type Report struct {
+	ID         uuid.UUID `json:"id"`
+	TargetType string    `json:"target_type,omitempty"`
+	TargetID   string    `json:"target_id,omitempty"`
+	Reason     string    `json:"reason"`
+	Details    string    `json:"details,omitempty"`
+	ReporterID string    `json:"reporter_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	Duplicate  bool      `json:"duplicate,omitempty"`
+}
+
+// ModerationCase aggregates the reports of one event, comment or user.
+type ModerationCase struct {
+	ID             uuid.UUID      `json:"id"`
+	TargetType     string         `json:"target_type"`
+	TargetID       string         `json:"target_id"`
+	EventID        string         `json:"event_id,omitempty"`
+	Status         string         `json:"status"`
+	ReportCount    int            `json:"report_count"`
+	ReasonCounts   map[string]int `json:"reason_counts"`
+	CreatedAt      time.Time      `json:"created_at"`
+	LastReportedAt time.Time      `json:"last_reported_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	AutoHiddenAt   *time.Time     `json:"auto_hidden_at,omitempty"`
+	TriagedBy      string         `json:"triaged_by,omitempty"`
+	TriagedAt      *time.Time     `json:"triaged_at,omitempty"`
+	Action         string         `json:"action,omitempty"`
+	ResolutionNote string         `json:"resolution_note,omitempty"`
+	ResolvedBy     string         `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time     `json:"resolved_at,omitempty"`
+	Reports        []Report       `json:"reports,omitempty"`
+}
+
+type ModerationQueue struct {
+	Items      []ModerationCase `json:"items"`
+	NextCursor string           `json:"next_cursor"`
+	HasMore    bool             `json:"has_more"`
+}
+
+// Revision is one entry of an event's change history.
+type Revision struct {
+	ID        uuid.UUID              `json:"id"`
+	Version   int64                  `json:"version"`
+	Action    string                 `json:"action"`
+	ActorID   string                 `json:"actor_id"`
+	ActorRole string                 `json:"actor_role,omitempty"`
+	Changes   map[string]FieldChange `json:"changes"`
+	Reason    string                 `json:"reason,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type FieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type RevisionPage struct {
+	Items      []Revision `json:"items"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+	HasMore    bool       `json:"has_more"`
+}
+
 type APIError struct {
 	Error struct {
 		Code      string `json:"code"`
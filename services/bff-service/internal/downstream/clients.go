@@ -26,6 +26,11 @@ type StatusError struct {
 	StatusCode int
 	Code       string
 	Message    string
+
+	// ETag and Data carry the resource's current state on a 412 so it can
+	// be handed back to the client.
+	ETag string
+	Data json.RawMessage
 }
 
 func (e *StatusError) Error() string {
@@ -33,13 +38,21 @@ func (e *StatusError) Error() string {
 }
 
 func decodeError(resp *http.Response) error {
-	var apiErr domain.APIError
+	var apiErr struct {
+		domain.APIError
+		Data json.RawMessage `json:"data"`
+	}
 	if err := json.NewDecoder(resp.Body).Decode(&apiErr); err == nil && apiErr.Error.Code != "" {
-		return &StatusError{
+		se := &StatusError{
 			StatusCode: resp.StatusCode,
 			Code:       apiErr.Error.Code,
 			Message:    apiErr.Error.Message,
 		}
+		if resp.StatusCode == http.StatusPreconditionFailed {
+			se.ETag = resp.Header.Get("ETag")
+			se.Data = apiErr.Data
+		}
+		return se
 	}
 	return &StatusError{
 		StatusCode: resp.StatusCode,
@@ -174,7 +187,9 @@ func (c *EventClient) CreateEvent(ctx context.Context, bearerToken string, body
 	return &wrapper.Data, nil
 }
 
-func (c *EventClient) PublishEvent(ctx context.Context, bearerToken, eventID string) (*domain.Event, error) {
+// PublishEvent, UpdateEvent and CancelEvent forward the caller's If-Match;
+// event-service rejects the write with 412 if the event has moved on.
+func (c *EventClient) PublishEvent(ctx context.Context, bearerToken, eventID, ifMatch string) (*domain.Event, error) {
 	url := fmt.Sprintf("%s/event/v1/events/%s/publish", c.BaseURL, eventID)
 
 	req, err := http.NewRequestWithContext(ctx, "POST", url, nil)
@@ -185,6 +200,9 @@ func (c *EventClient) PublishEvent(ctx context.Context, bearerToken, eventID str
 	if bearerToken != "" {
 		req.Header.Set("Authorization", bearerToken)
 	}
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
@@ -247,7 +265,7 @@ func (c *EventClient) UnpublishEvent(ctx context.Context, bearerToken, eventID s
 	return &wrapper.Data, nil
 }
 
-func (c *EventClient) UpdateEvent(ctx context.Context, bearerToken, eventID string, body interface{}) (*domain.Event, error) {
+func (c *EventClient) UpdateEvent(ctx context.Context, bearerToken, eventID string, body interface{}, ifMatch string) (*domain.Event, error) {
 	url := fmt.Sprintf("%s/event/v1/events/%s", c.BaseURL, eventID)
 
 	jsonBody, err := json.Marshal(body)
@@ -264,6 +282,9 @@ func (c *EventClient) UpdateEvent(ctx context.Context, bearerToken, eventID stri
 	if bearerToken != "" {
 		req.Header.Set("Authorization", bearerToken)
 	}
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
@@ -283,7 +304,7 @@ func (c *EventClient) UpdateEvent(ctx context.Context, bearerToken, eventID stri
 	return &wrapper.Data, nil
 }
 
-func (c *EventClient) CancelEvent(ctx context.Context, bearerToken, eventID string, body interface{}) (*domain.Event, error) {
+func (c *EventClient) CancelEvent(ctx context.Context, bearerToken, eventID string, body interface{}, ifMatch string) (*domain.Event, error) {
 	url := fmt.Sprintf("%s/event/v1/events/%s/cancel", c.BaseURL, eventID)
 
 	var reader io.Reader
@@ -307,6 +328,9 @@ func (c *EventClient) CancelEvent(ctx context.Context, bearerToken, eventID stri
 	if bearerToken != "" {
 		req.Header.Set("Authorization", bearerToken)
 	}
+	if ifMatch != "" {
+		req.Header.Set("If-Match", ifMatch)
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
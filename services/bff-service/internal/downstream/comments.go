@@ -0,0 +1,128 @@
+package downstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ListComments pages the top-level posts of an event; query carries
+// cursor and limit through unchanged.
+func (c *EventClient) ListComments(ctx context.Context, eventID uuid.UUID, query url.Values) (*domain.CommentPage, error) {
+	u := fmt.Sprintf("%s/event/v1/events/%s/comments", c.BaseURL, eventID)
+	return c.getCommentPage(ctx, u, query)
+}
+
+func (c *EventClient) ListReplies(ctx context.Context, eventID, commentID uuid.UUID, query url.Values) (*domain.CommentPage, error) {
+	u := fmt.Sprintf("%s/event/v1/events/%s/comments/%s/replies", c.BaseURL, eventID, commentID)
+	return c.getCommentPage(ctx, u, query)
+}
+
+func (c *EventClient) CreateComment(ctx context.Context, bearerToken string, eventID uuid.UUID, body interface{}) (*domain.Comment, error) {
+	u := fmt.Sprintf("%s/event/v1/events/%s/comments", c.BaseURL, eventID)
+	var out domain.Comment
+	if err := c.doComment(ctx, http.MethodPost, u, bearerToken, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *EventClient) CreateAnnouncement(ctx context.Context, bearerToken string, eventID uuid.UUID, body interface{}) (*domain.Comment, error) {
+	u := fmt.Sprintf("%s/event/v1/events/%s/announcements", c.BaseURL, eventID)
+	var out domain.Comment
+	if err := c.doComment(ctx, http.MethodPost, u, bearerToken, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *EventClient) SetCommentPinned(ctx context.Context, bearerToken string, eventID, commentID uuid.UUID, pinned bool) (*domain.Comment, error) {
+	u := fmt.Sprintf("%s/event/v1/events/%s/comments/%s/pin", c.BaseURL, eventID, commentID)
+	method := http.MethodPut
+	if !pinned {
+		method = http.MethodDelete
+	}
+	var out domain.Comment
+	if err := c.doComment(ctx, method, u, bearerToken, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteComment soft-deletes a post; reason is kept when a moderator or
+// organizer removes someone else's post.
+func (c *EventClient) DeleteComment(ctx context.Context, bearerToken string, eventID, commentID uuid.UUID, reason string) error {
+	u := fmt.Sprintf("%s/event/v1/events/%s/comments/%s", c.BaseURL, eventID, commentID)
+	if reason != "" {
+		u += "?" + url.Values{"reason": {reason}}.Encode()
+	}
+	return c.doComment(ctx, http.MethodDelete, u, bearerToken, nil, nil)
+}
+
+func (c *EventClient) getCommentPage(ctx context.Context, rawURL string, query url.Values) (*domain.CommentPage, error) {
+	u, _ := url.Parse(rawURL)
+	u.RawQuery = query.Encode()
+
+	var page domain.CommentPage
+	if err := c.doComment(ctx, http.MethodGet, u.String(), "", nil, &page); err != nil {
+		return nil, err
+	}
+	if page.Items == nil {
+		page.Items = make([]domain.Comment, 0)
+	}
+	return &page, nil
+}
+
+// doComment sends one discussion request and decodes the data envelope
+// into out (nil for 204 responses).
+func (c *EventClient) doComment(ctx context.Context, method, url, bearerToken string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		jsonBody, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(jsonBody)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return err
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if bearerToken != "" {
+		req.Header.Set("Authorization", bearerToken)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return ErrTimeout
+		}
+		return ErrUnavailable
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return decodeError(resp)
+	}
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	wrapper := dataEnvelope[json.RawMessage]{}
+	if err := json.NewDecoder(resp.Body).Decode(&wrapper); err != nil {
+		return err
+	}
+	return json.Unmarshal(wrapper.Data, out)
+}
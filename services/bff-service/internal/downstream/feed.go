@@ -1,25 +1,34 @@
 package downstream
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/internal/domain"
+	"github.com/google/uuid"
 )
 
 // FeedClient calls feed-service for trending/personalized feeds
 type FeedClient struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL           string
+	internalSecretKey string
+	httpClient        *http.Client
+	wrapper           *Client
 }
 
-func NewFeedClient(baseURL string) *FeedClient {
+func NewFeedClient(baseURL, internalSecretKey string) *FeedClient {
 	return &FeedClient{
-		baseURL: baseURL,
+		baseURL:           baseURL,
+		internalSecretKey: internalSecretKey,
 		httpClient: &http.Client{
 			Timeout: 200 * time.Millisecond, // Tight timeout for feed
 		},
+		wrapper: NewClient(DefaultClientConfig()),
 	}
 }
 
@@ -70,6 +79,38 @@ func (c *FeedClient) GetFeed(ctx context.Context, feedType, city, userID, anonID
 	return &result, nil
 }
 
+// GetSimilar fetches "similar events" recommendations for an event
+func (c *FeedClient) GetSimilar(ctx context.Context, eventID uuid.UUID, limit int) ([]domain.SimilarEvent, error) {
+	url := fmt.Sprintf("%s/api/feed/events/%s/similar?limit=%d", c.baseURL, eventID, limit)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, ErrTimeout
+		}
+		return nil, ErrUnavailable
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed-service returned %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Items []domain.SimilarEvent `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+
+	return result.Items, nil
+}
+
 // Track sends tracking event to feed-service
 func (c *FeedClient) Track(ctx context.Context, eventType, eventID, feedType, requestID, userID, anonID string, position int) error {
 	url := fmt.Sprintf("%s/api/feed/track", c.baseURL)
@@ -106,3 +147,29 @@ func (c *FeedClient) Track(ctx context.Context, eventType, eventID, feedType, re
 
 	return nil
 }
+
+// MergeAnonymous asks feed-service to fold the activity recorded under the
+// signed anon_id cookie into the user's profile. Safe to repeat.
+func (c *FeedClient) MergeAnonymous(ctx context.Context, userID, anonCookie string) error {
+	url := fmt.Sprintf("%s/api/feed/identity/merge", c.baseURL)
+
+	jsonBody, err := json.Marshal(map[string]string{"anon_cookie": anonCookie})
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.wrapper.DoWithBody(ctx, http.MethodPost, url, bytes.NewReader(jsonBody), map[string]string{
+		"Content-Type":      "application/json",
+		"X-User-ID":         userID,
+		"X-Internal-Secret": c.internalSecretKey,
+	})
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("feed-service merge returned %d", resp.StatusCode)
+	}
+	return nil
+}
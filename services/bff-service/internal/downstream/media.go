@@ -0,0 +1,66 @@
+package downstream
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/internal/domain"
+)
+
+// MediaClient calls media-service's internal endpoints
+type MediaClient struct {
+	baseURL           string
+	internalSecretKey string
+	httpClient        *http.Client
+}
+
+func NewMediaClient(baseURL, internalSecretKey string) *MediaClient {
+	return &MediaClient{
+		baseURL:           baseURL,
+		internalSecretKey: internalSecretKey,
+		httpClient: &http.Client{
+			Timeout: 200 * time.Millisecond, // Placeholders are decorative
+		},
+	}
+}
+
+// GetPlaceholders returns the placeholders of the READY uploads among ids
+func (c *MediaClient) GetPlaceholders(ctx context.Context, ids []string) (map[string]domain.ImagePlaceholder, error) {
+	body, err := json.Marshal(map[string][]string{"ids": ids})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/media/v1/internal/uploads/placeholders", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Internal-Secret", c.internalSecretKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return nil, ErrTimeout
+		}
+		return nil, ErrUnavailable
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("media-service returned %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Items map[string]domain.ImagePlaceholder `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
@@ -0,0 +1,66 @@
+package downstream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// CreateReport files the caller's report of an event, comment or user.
+func (c *EventClient) CreateReport(ctx context.Context, bearerToken string, body interface{}) (*domain.Report, error) {
+	u := fmt.Sprintf("%s/event/v1/reports", c.BaseURL)
+	var out domain.Report
+	if err := c.doComment(ctx, http.MethodPost, u, bearerToken, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListModerationQueue pages moderation cases; query carries status,
+// target_type, cursor and limit.
+func (c *EventClient) ListModerationQueue(ctx context.Context, bearerToken string, query url.Values) (*domain.ModerationQueue, error) {
+	u, _ := url.Parse(fmt.Sprintf("%s/event/v1/mod/reports", c.BaseURL))
+	u.RawQuery = query.Encode()
+
+	var out domain.ModerationQueue
+	if err := c.doComment(ctx, http.MethodGet, u.String(), bearerToken, nil, &out); err != nil {
+		return nil, err
+	}
+	if out.Items == nil {
+		out.Items = make([]domain.ModerationCase, 0)
+	}
+	return &out, nil
+}
+
+func (c *EventClient) GetModerationCase(ctx context.Context, bearerToken string, caseID uuid.UUID) (*domain.ModerationCase, error) {
+	u := fmt.Sprintf("%s/event/v1/mod/reports/%s", c.BaseURL, caseID)
+	var out domain.ModerationCase
+	if err := c.doComment(ctx, http.MethodGet, u, bearerToken, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *EventClient) TriageCase(ctx context.Context, bearerToken string, caseID uuid.UUID) (*domain.ModerationCase, error) {
+	u := fmt.Sprintf("%s/event/v1/mod/reports/%s/triage", c.BaseURL, caseID)
+	var out domain.ModerationCase
+	if err := c.doComment(ctx, http.MethodPost, u, bearerToken, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ResolveCase actions or dismisses a case; event-service carries out
+// event and comment actions itself.
+func (c *EventClient) ResolveCase(ctx context.Context, bearerToken string, caseID uuid.UUID, body interface{}) (*domain.ModerationCase, error) {
+	u := fmt.Sprintf("%s/event/v1/mod/reports/%s/resolve", c.BaseURL, caseID)
+	var out domain.ModerationCase
+	if err := c.doComment(ctx, http.MethodPost, u, bearerToken, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
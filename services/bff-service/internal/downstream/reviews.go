@@ -0,0 +1,60 @@
+package downstream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ListReviews pages an event's visible reviews; query carries cursor and
+// limit through unchanged.
+func (c *EventClient) ListReviews(ctx context.Context, eventID uuid.UUID, query url.Values) (*domain.ReviewPage, error) {
+	u, _ := url.Parse(fmt.Sprintf("%s/event/v1/events/%s/reviews", c.BaseURL, eventID))
+	u.RawQuery = query.Encode()
+
+	var page domain.ReviewPage
+	if err := c.doComment(ctx, http.MethodGet, u.String(), "", nil, &page); err != nil {
+		return nil, err
+	}
+	if page.Items == nil {
+		page.Items = make([]domain.Review, 0)
+	}
+	return &page, nil
+}
+
+func (c *EventClient) GetOrganizerReputation(ctx context.Context, organizerID string) (*domain.RatingSummary, error) {
+	u := fmt.Sprintf("%s/event/v1/organizers/%s/reputation", c.BaseURL, url.PathEscape(organizerID))
+	var out domain.RatingSummary
+	if err := c.doComment(ctx, http.MethodGet, u, "", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SubmitReview creates or replaces the caller's review of an event.
+func (c *EventClient) SubmitReview(ctx context.Context, bearerToken string, eventID uuid.UUID, body interface{}) (*domain.Review, error) {
+	u := fmt.Sprintf("%s/event/v1/events/%s/review", c.BaseURL, eventID)
+	var out domain.Review
+	if err := c.doComment(ctx, http.MethodPut, u, bearerToken, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetReviewHidden hides (with a reason) or restores a review; moderators only.
+func (c *EventClient) SetReviewHidden(ctx context.Context, bearerToken string, eventID, reviewID uuid.UUID, hidden bool, reason string) (*domain.Review, error) {
+	u := fmt.Sprintf("%s/event/v1/events/%s/reviews/%s/hide", c.BaseURL, eventID, reviewID)
+	method, body := http.MethodDelete, interface{}(nil)
+	if hidden {
+		method, body = http.MethodPut, map[string]string{"reason": reason}
+	}
+	var out domain.Review
+	if err := c.doComment(ctx, method, u, bearerToken, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
@@ -0,0 +1,27 @@
+package downstream
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ListRevisions pages an event's change history for its organizers and
+// moderators; query carries cursor and limit.
+func (c *EventClient) ListRevisions(ctx context.Context, bearerToken string, eventID uuid.UUID, query url.Values) (*domain.RevisionPage, error) {
+	u, _ := url.Parse(fmt.Sprintf("%s/event/v1/events/%s/revisions", c.BaseURL, eventID))
+	u.RawQuery = query.Encode()
+
+	var out domain.RevisionPage
+	if err := c.doComment(ctx, http.MethodGet, u.String(), bearerToken, nil, &out); err != nil {
+		return nil, err
+	}
+	if out.Items == nil {
+		out.Items = make([]domain.Revision, 0)
+	}
+	return &out, nil
+}
@@ -0,0 +1,63 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// LoginHook is called after auth-service accepted a login or registration.
+// r is the upstream request and still carries the client's cookies.
+type LoginHook func(userID uuid.UUID, r *http.Request)
+
+// OnLogin installs hook on an auth proxy. Successful login/register responses
+// are buffered to read the user id and passed through unchanged.
+func OnLogin(p *httputil.ReverseProxy, hook LoginHook) {
+	p.ModifyResponse = func(resp *http.Response) error {
+		if !isLoginResponse(resp) {
+			return nil
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+
+		// auth-service AuthData: {"data": {"user": {"id": ...}, "tokens": {...}}}
+		var env struct {
+			Data struct {
+				User struct {
+					ID string `json:"id"`
+				} `json:"user"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &env); err != nil {
+			return nil
+		}
+		if userID, err := uuid.Parse(env.Data.User.ID); err == nil {
+			hook(userID, resp.Request)
+		}
+		return nil
+	}
+}
+
+func isLoginResponse(resp *http.Response) bool {
+	if resp.Request == nil || resp.Request.Method != http.MethodPost {
+		return false
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return false
+	}
+	if resp.Header.Get("Content-Encoding") != "" {
+		return false
+	}
+	path := resp.Request.URL.Path
+	return strings.HasSuffix(path, "/login") || strings.HasSuffix(path, "/register")
+}
@@ -0,0 +1,70 @@
+package proxy_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baechuer/real-time-ressys/services/bff-service/internal/proxy"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const loginBody = `{"data":{"user":{"id":"7b0d3c52-2a5e-4b1f-9d3e-0c7a1f2b3c4d","email":"a@b.c"},"tokens":{"access_token":"t"}}}`
+
+func TestOnLogin_CallsHookAndPassesBodyThrough(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(loginBody))
+	}))
+	defer upstream.Close()
+
+	p, err := proxy.New(upstream.URL, "/api/auth", "/auth/v1")
+	require.NoError(t, err)
+
+	var gotUser uuid.UUID
+	var gotCookie string
+	proxy.OnLogin(p, func(userID uuid.UUID, r *http.Request) {
+		gotUser = userID
+		if c, err := r.Cookie("anon_id"); err == nil {
+			gotCookie = c.Value
+		}
+	})
+
+	req := httptest.NewRequest("POST", "http://bff/api/auth/login", nil)
+	req.AddCookie(&http.Cookie{Name: "anon_id", Value: "signed-anon"})
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, loginBody, w.Body.String())
+	assert.Equal(t, "7b0d3c52-2a5e-4b1f-9d3e-0c7a1f2b3c4d", gotUser.String())
+	assert.Equal(t, "signed-anon", gotCookie)
+}
+
+func TestOnLogin_IgnoresFailedAndOtherRoutes(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/auth/v1/login" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(loginBody))
+	}))
+	defer upstream.Close()
+
+	p, err := proxy.New(upstream.URL, "/api/auth", "/auth/v1")
+	require.NoError(t, err)
+
+	called := false
+	proxy.OnLogin(p, func(uuid.UUID, *http.Request) { called = true })
+
+	for _, path := range []string{"/api/auth/login", "/api/auth/refresh"} {
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, httptest.NewRequest("POST", "http://bff"+path, nil))
+	}
+
+	assert.False(t, called)
+}
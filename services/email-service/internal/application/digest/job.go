@@ -0,0 +1,247 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
+)
+
+// Subscriber is a user who opted in to the weekly digest. The digest goes
+// out during Hour on Day in the user's Location.
+type Subscriber struct {
+	UserID   string
+	City     string // empty => all cities
+	Day      time.Weekday
+	Hour     int
+	Location *time.Location
+}
+
+// Event is a feed item as the digest needs it.
+type Event struct {
+	ID           string
+	Title        string
+	City         string
+	StartTime    time.Time
+	CoverImageID string // empty => no cover
+}
+
+// Subscribers lists everyone opted in to the digest.
+type Subscribers interface {
+	DigestSubscribers(ctx context.Context) ([]Subscriber, error)
+}
+
+// Feed returns a user's personalized feed, best first.
+type Feed interface {
+	Personalized(ctx context.Context, userID, city string, limit int) ([]Event, error)
+}
+
+// Covers resolves cover upload IDs to image URLs. IDs without a ready
+// image are left out.
+type Covers interface {
+	CoverURLs(ctx context.Context, uploadIDs []string) (map[string]string, error)
+}
+
+// Claims makes sure only one job instance works on a user's week at a
+// time.
+type Claims interface {
+	Claim(ctx context.Context, userID, week string, ttl time.Duration) (bool, error)
+	Release(ctx context.Context, userID, week string) error
+}
+
+// Notifier sends the digest. It must be idempotent per user and week.
+type Notifier interface {
+	WeeklyDigest(ctx context.Context, userID, week string, items []notify.DigestItem) error
+}
+
+type Config struct {
+	Events     int    // top N events per digest
+	WebBaseURL string // event links: <WebBaseURL>/events/<id>
+}
+
+// claimTTL covers the whole send window, so a finished or empty digest is
+// not picked up again by the next run.
+const claimTTL = 2 * time.Hour
+
+// Job sends each subscriber their weekly digest from the personalized feed.
+type Job struct {
+	subs     Subscribers
+	feed     Feed
+	covers   Covers
+	claims   Claims
+	notifier Notifier
+	cfg      Config
+	now      func() time.Time
+	lg       zerolog.Logger
+}
+
+func NewJob(subs Subscribers, feed Feed, covers Covers, claims Claims, notifier Notifier, cfg Config, lg zerolog.Logger) *Job {
+	if cfg.Events <= 0 {
+		cfg.Events = 5
+	}
+	cfg.WebBaseURL = strings.TrimRight(cfg.WebBaseURL, "/")
+	return &Job{
+		subs:     subs,
+		feed:     feed,
+		covers:   covers,
+		claims:   claims,
+		notifier: notifier,
+		cfg:      cfg,
+		now:      time.Now,
+		lg:       lg.With().Str("component", "digest_job").Logger(),
+	}
+}
+
+// Run checks for subscribers in their send window every interval until ctx
+// is done. interval should be well under an hour.
+func (j *Job) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		if n, err := j.RunOnce(ctx); err != nil {
+			j.lg.Error().Err(err).Msg("digest run failed")
+		} else if n > 0 {
+			j.lg.Info().Int("digests", n).Msg("digests sent")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// RunOnce sends the digest to every subscriber whose send window is now and
+// reports how many were handed to the notifier.
+func (j *Job) RunOnce(ctx context.Context) (int, error) {
+	subs, err := j.subs.DigestSubscribers(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := j.now()
+	sent := 0
+	for _, sub := range subs {
+		if ctx.Err() != nil {
+			return sent, ctx.Err()
+		}
+		local := now.In(sub.Location)
+		if local.Weekday() != sub.Day || local.Hour() != sub.Hour {
+			continue
+		}
+		if j.send(ctx, sub, WeekOf(local), now) {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+func (j *Job) send(ctx context.Context, sub Subscriber, week string, now time.Time) bool {
+	lg := j.lg.With().Str("user_id", sub.UserID).Str("week", week).Logger()
+
+	ok, err := j.claims.Claim(ctx, sub.UserID, week, claimTTL)
+	if err != nil {
+		lg.Warn().Err(err).Msg("digest claim failed")
+		return false
+	}
+	if !ok {
+		return false
+	}
+
+	events, err := j.feed.Personalized(ctx, sub.UserID, sub.City, j.cfg.Events)
+	if err != nil {
+		lg.Warn().Err(err).Msg("load feed failed; will retry")
+		j.release(ctx, sub.UserID, week)
+		return false
+	}
+	items, covers := j.items(events, now, sub.Location)
+	if len(items) == 0 {
+		lg.Info().Msg("nothing upcoming; no digest this week")
+		return false
+	}
+	j.addCovers(ctx, items, covers)
+
+	if err := j.notifier.WeeklyDigest(ctx, sub.UserID, week, items); err != nil {
+		if isPermanent(err) {
+			lg.Warn().Err(err).Msg("digest dropped")
+			return false
+		}
+		lg.Warn().Err(err).Msg("digest send failed; will retry")
+		j.release(ctx, sub.UserID, week)
+		return false
+	}
+	return true
+}
+
+func (j *Job) release(ctx context.Context, userID, week string) {
+	if err := j.claims.Release(ctx, userID, week); err != nil {
+		j.lg.Warn().Err(err).Str("user_id", userID).Msg("digest claim release failed")
+	}
+}
+
+// items keeps the top N events that have not started, links them and puts
+// their start in the reader's zone. covers[i] is item i's cover upload ID.
+func (j *Job) items(events []Event, now time.Time, loc *time.Location) (items []notify.DigestItem, covers []string) {
+	items = make([]notify.DigestItem, 0, j.cfg.Events)
+	covers = make([]string, 0, j.cfg.Events)
+	for _, ev := range events {
+		if !ev.StartTime.After(now) {
+			continue
+		}
+		items = append(items, notify.DigestItem{
+			Title:     ev.Title,
+			City:      ev.City,
+			StartTime: ev.StartTime.In(loc),
+			URL:       j.cfg.WebBaseURL + "/events/" + url.PathEscape(ev.ID),
+		})
+		covers = append(covers, ev.CoverImageID)
+		if len(items) == j.cfg.Events {
+			break
+		}
+	}
+	return items, covers
+}
+
+// addCovers resolves the covers through media-service. They are
+// decoration: if that fails the digest goes out without them.
+func (j *Job) addCovers(ctx context.Context, items []notify.DigestItem, covers []string) {
+	if j.covers == nil {
+		return
+	}
+	ids := make([]string, 0, len(covers))
+	for _, id := range covers {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+	urls, err := j.covers.CoverURLs(ctx, ids)
+	if err != nil {
+		j.lg.Warn().Err(err).Msg("resolve covers failed; sending without")
+		return
+	}
+	for i, id := range covers {
+		items[i].CoverURL = urls[id]
+	}
+}
+
+// WeekOf is t's ISO week, e.g. "2026-W42".
+func WeekOf(t time.Time) string {
+	y, w := t.ISOWeek()
+	return fmt.Sprintf("%d-W%02d", y, w)
+}
+
+func isPermanent(err error) bool {
+	var pm interface{ Permanent() bool }
+	return errors.As(err, &pm) && pm.Permanent()
+}
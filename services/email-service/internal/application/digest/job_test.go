@@ -0,0 +1,175 @@
+package digest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
+)
+
+type fakeSubs []Subscriber
+
+func (f fakeSubs) DigestSubscribers(ctx context.Context) ([]Subscriber, error) { return f, nil }
+
+type fakeFeed struct {
+	events []Event
+	calls  []string // "<user>|<city>"
+}
+
+func (f *fakeFeed) Personalized(ctx context.Context, userID, city string, limit int) ([]Event, error) {
+	f.calls = append(f.calls, userID+"|"+city)
+	if len(f.events) > limit {
+		return f.events[:limit], nil
+	}
+	return f.events, nil
+}
+
+type fakeCovers map[string]string
+
+func (f fakeCovers) CoverURLs(ctx context.Context, uploadIDs []string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, id := range uploadIDs {
+		if u, ok := f[id]; ok {
+			out[id] = u
+		}
+	}
+	return out, nil
+}
+
+type memClaims map[string]bool
+
+func (m memClaims) Claim(ctx context.Context, userID, week string, ttl time.Duration) (bool, error) {
+	k := userID + "|" + week
+	if m[k] {
+		return false, nil
+	}
+	m[k] = true
+	return true, nil
+}
+
+func (m memClaims) Release(ctx context.Context, userID, week string) error {
+	delete(m, userID+"|"+week)
+	return nil
+}
+
+type fakeNotifier struct {
+	sent map[string][]notify.DigestItem // by "<user>|<week>"
+	err  error
+}
+
+func (n *fakeNotifier) WeeklyDigest(ctx context.Context, userID, week string, items []notify.DigestItem) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.sent[userID+"|"+week] = items
+	return nil
+}
+
+func newTestJob(subs fakeSubs, feed *fakeFeed, now time.Time) (*Job, memClaims, *fakeNotifier) {
+	claims := memClaims{}
+	n := &fakeNotifier{sent: map[string][]notify.DigestItem{}}
+	j := NewJob(subs, feed, fakeCovers{"c1": "http://cdn/public/derived/event_cover/c1_800.jpg"}, claims, n, Config{Events: 2, WebBaseURL: "http://web/"}, zerolog.Nop())
+	j.now = func() time.Time { return now }
+	return j, claims, n
+}
+
+func TestJob_SendsInLocalWindowOncePerWeek(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Skip("no tzdata")
+	}
+	// Mon 19 Oct 2026 09:10 in Sydney (AEDT, UTC+11)
+	now := time.Date(2026, 10, 18, 22, 10, 0, 0, time.UTC)
+	subs := fakeSubs{
+		{UserID: "u1", City: "Sydney", Day: time.Monday, Hour: 9, Location: sydney},
+		{UserID: "u2", City: "Sydney", Day: time.Monday, Hour: 9, Location: time.UTC}, // still Sunday in UTC
+	}
+	feed := &fakeFeed{events: []Event{
+		{ID: "e1", Title: "Meetup", City: "Sydney", StartTime: now.Add(48 * time.Hour), CoverImageID: "c1"},
+		{ID: "e2", Title: "Gig", City: "Sydney", StartTime: now.Add(72 * time.Hour)},
+		{ID: "e3", Title: "Run", City: "Sydney", StartTime: now.Add(96 * time.Hour)},
+	}}
+	j, _, n := newTestJob(subs, feed, now)
+
+	sent, err := j.RunOnce(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 1 || len(feed.calls) != 1 || feed.calls[0] != "u1|Sydney" {
+		t.Fatalf("sent=%d feed calls=%v", sent, feed.calls)
+	}
+	items := n.sent["u1|2026-W43"]
+	if len(items) != 2 {
+		t.Fatalf("expected top 2 events, got %+v", items)
+	}
+	if items[0].URL != "http://web/events/e1" || items[0].CoverURL != "http://cdn/public/derived/event_cover/c1_800.jpg" || items[1].CoverURL != "" {
+		t.Fatalf("unexpected links: %+v", items)
+	}
+
+	// A later run in the same window does nothing
+	if sent, _ := j.RunOnce(context.Background()); sent != 0 {
+		t.Fatalf("expected no resend, got %d", sent)
+	}
+}
+
+func TestJob_TransientFailureReleasesClaim(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	subs := fakeSubs{{UserID: "u1", Day: time.Monday, Hour: 9, Location: time.UTC}}
+	feed := &fakeFeed{events: []Event{{ID: "e1", Title: "Meetup", StartTime: now.Add(time.Hour)}}}
+	j, claims, n := newTestJob(subs, feed, now)
+
+	n.err = errors.New("smtp down")
+	if sent, _ := j.RunOnce(context.Background()); sent != 0 {
+		t.Fatalf("expected nothing sent, got %d", sent)
+	}
+	if claims["u1|2026-W43"] {
+		t.Fatal("expected the claim to be released for a retry")
+	}
+
+	n.err = nil
+	if sent, _ := j.RunOnce(context.Background()); sent != 1 {
+		t.Fatalf("expected the retry to send, got %d", sent)
+	}
+}
+
+func TestJob_SkipsStartedEvents(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	subs := fakeSubs{{UserID: "u1", Day: time.Monday, Hour: 9, Location: time.UTC}}
+	feed := &fakeFeed{events: []Event{{ID: "e1", Title: "Meetup", StartTime: now.Add(-time.Minute)}}}
+	j, _, n := newTestJob(subs, feed, now)
+
+	if sent, _ := j.RunOnce(context.Background()); sent != 0 || len(n.sent) != 0 {
+		t.Fatalf("expected no digest, got %d %v", sent, n.sent)
+	}
+}
+
+func TestWeekOf(t *testing.T) {
+	if got := WeekOf(time.Date(2027, 1, 1, 12, 0, 0, 0, time.UTC)); got != "2026-W53" {
+		t.Fatalf("got %s", got)
+	}
+}
+
+type failingCovers struct{}
+
+func (failingCovers) CoverURLs(ctx context.Context, uploadIDs []string) (map[string]string, error) {
+	return nil, errors.New("media-service down")
+}
+
+func TestJob_SendsWithoutCoversWhenMediaFails(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	subs := fakeSubs{{UserID: "u1", Day: time.Monday, Hour: 9, Location: time.UTC}}
+	feed := &fakeFeed{events: []Event{{ID: "e1", Title: "Meetup", StartTime: now.Add(time.Hour), CoverImageID: "c1"}}}
+	j, _, n := newTestJob(subs, feed, now)
+	j.covers = failingCovers{}
+
+	if sent, err := j.RunOnce(context.Background()); err != nil || sent != 1 {
+		t.Fatalf("sent=%d err=%v", sent, err)
+	}
+	if items := n.sent["u1|2026-W43"]; len(items) != 1 || items[0].CoverURL != "" {
+		t.Fatalf("unexpected items: %+v", items)
+	}
+}
@@ -21,6 +21,21 @@ type fakeSender struct {
 	lastResetTo   string
 	lastResetLink string
 
+	announcementCalls  int
+	lastAnnouncementTo string
+
+	changedCalls   int
+	lastChangedTo  string
+	lastChangeDesc []string
+
+	reminderCalls int
+	reminderErr   error
+
+	digestCalls     int
+	lastDigestItems []DigestItem
+
+	lastUnsubscribeURL string
+
 	// Optional: allow scripted failures
 	verifyErr error
 	resetErr  error
@@ -58,6 +73,70 @@ func (s *fakeSender) SendEventUnpublished(ctx context.Context, toEmail, eventID,
 	return nil
 }
 
+func (s *fakeSender) SendEventAnnouncement(ctx context.Context, toEmail, eventTitle, title, body string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.announcementCalls++
+	s.lastAnnouncementTo = toEmail
+	return nil
+}
+
+func (s *fakeSender) SendEventChanged(ctx context.Context, toEmail, eventTitle string, changes []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.changedCalls++
+	s.lastChangedTo = toEmail
+	s.lastChangeDesc = changes
+	return nil
+}
+
+func (s *fakeSender) SendEventReminder(ctx context.Context, toEmail, unsubscribeURL, eventTitle, city string, start time.Time, lead time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reminderCalls++
+	s.lastUnsubscribeURL = unsubscribeURL
+	return s.reminderErr
+}
+
+func (s *fakeSender) SendWeeklyDigest(ctx context.Context, toEmail, unsubscribeURL, week string, items []DigestItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.digestCalls++
+	s.lastUnsubscribeURL = unsubscribeURL
+	s.lastDigestItems = items
+	return nil
+}
+
+func (s *fakeSender) DigestCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.digestCalls
+}
+
+func (s *fakeSender) ReminderCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.reminderCalls
+}
+
+func (s *fakeSender) LastUnsubscribeURL() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastUnsubscribeURL
+}
+
+func (s *fakeSender) ChangedCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.changedCalls
+}
+
+func (s *fakeSender) AnnouncementCalls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.announcementCalls
+}
+
 func (s *fakeSender) VerifyCalls() int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
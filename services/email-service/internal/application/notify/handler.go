@@ -16,6 +16,11 @@ type Sender interface {
 	SendPasswordReset(ctx context.Context, toEmail, url string) error
 	SendEventCanceled(ctx context.Context, toEmail, eventID, reason string) error
 	SendEventUnpublished(ctx context.Context, toEmail, eventID, reason string) error
+	SendEventAnnouncement(ctx context.Context, toEmail, eventTitle, title, body string) error
+	SendEventChanged(ctx context.Context, toEmail, eventTitle string, changes []string) error
+	// unsubscribeURL is empty when there is no link to offer.
+	SendEventReminder(ctx context.Context, toEmail, unsubscribeURL, eventTitle, city string, start time.Time, lead time.Duration) error
+	SendWeeklyDigest(ctx context.Context, toEmail, unsubscribeURL, week string, items []DigestItem) error
 }
 
 type permanentMarker interface{ Permanent() bool }
@@ -33,10 +38,19 @@ type UserResolver interface {
 	GetEmail(ctx context.Context, userID string) (string, error)
 }
 
+// Preferences answers whether a user still wants optional emails.
+type Preferences interface {
+	RemindersEnabled(ctx context.Context, userID string) (bool, error)
+	DigestEnabled(ctx context.Context, userID string) (bool, error)
+}
+
 type Service struct {
 	sender   Sender
 	resolver UserResolver
 	idem     IdempotencyStore // nil => disabled
+	prefs    Preferences      // nil => everyone opted in
+	supp     Suppressions     // nil => no suppression list
+	unsub    UnsubscribeLinks // nil => no unsubscribe links
 	ttl      time.Duration
 	lg       zerolog.Logger
 }
@@ -51,6 +65,11 @@ func NewService(sender Sender, resolver UserResolver, idem IdempotencyStore, ttl
 	}
 }
 
+// SetPreferences enables opt-out checks for optional emails.
+func (s *Service) SetPreferences(p Preferences) {
+	s.prefs = p
+}
+
 func (s *Service) VerifyEmail(ctx context.Context, userID, email, link string) error {
 	s.lg.Info().Str("user_id", userID).Str("email", email).Msg("NotifyService.VerifyEmail called")
 	token := tokenFromLink(link)
@@ -67,6 +86,10 @@ func (s *Service) VerifyEmail(ctx context.Context, userID, email, link string) e
 		}
 	}
 
+	if skip, e := s.suppressed(ctx, userID, email, CategoryTransactional); e != nil || skip {
+		return e
+	}
+
 	err := s.sender.SendVerifyEmail(ctx, email, link)
 	if err != nil {
 		return err
@@ -97,6 +120,11 @@ func (s *Service) PasswordReset(ctx context.Context, userID, email, link string)
 			return nil
 		}
 	}
+
+	if skip, e := s.suppressed(ctx, userID, email, CategoryTransactional); e != nil || skip {
+		return e
+	}
+
 	err := s.sender.SendPasswordReset(ctx, email, link)
 	if err != nil {
 		return err
@@ -0,0 +1,83 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DigestItem is one event in a weekly digest, with links ready to render.
+type DigestItem struct {
+	Title     string
+	City      string
+	StartTime time.Time // in the reader's zone
+	URL       string
+	CoverURL  string // empty => no cover
+}
+
+// digestTTL outlives the ISO week the key is for.
+const digestTTL = 8 * 24 * time.Hour
+
+// WeeklyDigest sends a user's digest for week (ISO, e.g. "2026-W42") at
+// most once.
+func (s *Service) WeeklyDigest(ctx context.Context, userID, week string, items []DigestItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	// Key: email:sent:weekly_digest:<userID>:<week>
+	key := fmt.Sprintf("email:sent:weekly_digest:%s:%s", userID, week)
+	if s.idem != nil {
+		seen, e := s.idem.Seen(ctx, key)
+		if e != nil {
+			return e
+		}
+		if seen {
+			s.lg.Info().Str("user_id", userID).Str("week", week).Msg("idempotent skip")
+			return nil
+		}
+	}
+
+	// The job lists subscribers up front; this catches an unsubscribe since
+	if s.prefs != nil {
+		enabled, err := s.prefs.DigestEnabled(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("load preferences failed: %w", err)
+		}
+		if !enabled {
+			s.lg.Info().Str("user_id", userID).Msg("digest opted out; skipping")
+			return nil
+		}
+	}
+
+	email, err := s.resolver.GetEmail(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("resolve email failed: %w", err)
+	}
+	if email == "" {
+		s.lg.Warn().Str("user_id", userID).Msg("user has no email; dropping")
+		return nil
+	}
+
+	if skip, e := s.suppressed(ctx, userID, email, CategoryDigest); e != nil || skip {
+		return e
+	}
+
+	if err := s.sender.SendWeeklyDigest(ctx, email, s.unsubscribeURL(userID, CategoryDigest), week, items); err != nil {
+		return err
+	}
+
+	if s.idem != nil {
+		if e := s.idem.MarkSent(ctx, key, digestTTL); e != nil {
+			s.lg.Warn().Err(e).Str("key", key).Msg("idempotency mark failed (send already succeeded)")
+			return nil
+		}
+	}
+
+	s.lg.Info().
+		Str("user_id", userID).
+		Str("week", week).
+		Int("events", len(items)).
+		Msg("weekly digest email sent")
+	return nil
+}
@@ -2,7 +2,10 @@ package notify
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -38,6 +41,10 @@ func (s *Service) EventCanceled(ctx context.Context, eventID, userID, reason, ac
 		return nil
 	}
 
+	if skip, e := s.suppressed(ctx, userID, email, CategoryTransactional); e != nil || skip {
+		return e
+	}
+
 	// 3. Send Email
 	if err := s.sender.SendEventCanceled(ctx, email, eventID, reason); err != nil {
 		return err
@@ -84,6 +91,10 @@ func (s *Service) EventUnpublished(ctx context.Context, eventID, userID, reason,
 		return nil
 	}
 
+	if skip, e := s.suppressed(ctx, userID, email, CategoryTransactional); e != nil || skip {
+		return e
+	}
+
 	// 3. Send Email
 	if err := s.sender.SendEventUnpublished(ctx, email, eventID, reason); err != nil {
 		return err
@@ -98,3 +109,160 @@ func (s *Service) EventUnpublished(ctx context.Context, eventID, userID, reason,
 
 	return nil
 }
+
+// EventAnnouncement relays an organizer announcement to one participant.
+// join-service emits one message per active participant.
+func (s *Service) EventAnnouncement(ctx context.Context, announcementID, eventID, userID, eventTitle, title, body string) error {
+	// Key: email:sent:event_announcement:<announcementID>:<userID>
+	key := fmt.Sprintf("email:sent:event_announcement:%s:%s", announcementID, userID)
+	if s.idem != nil {
+		seen, e := s.idem.Seen(ctx, key)
+		if e != nil {
+			return e
+		}
+		if seen {
+			s.lg.Info().Str("announcement_id", announcementID).Str("user_id", userID).Msg("idempotent skip")
+			return nil
+		}
+	}
+
+	email, err := s.resolver.GetEmail(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("resolve email failed: %w", err)
+	}
+	if email == "" {
+		s.lg.Warn().Str("user_id", userID).Msg("user has no email; dropping")
+		return nil
+	}
+
+	if skip, e := s.suppressed(ctx, userID, email, CategoryTransactional); e != nil || skip {
+		return e
+	}
+
+	if err := s.sender.SendEventAnnouncement(ctx, email, eventTitle, title, body); err != nil {
+		return err
+	}
+
+	if s.idem != nil {
+		if e := s.idem.MarkSent(ctx, key, 7*24*time.Hour); e != nil {
+			s.lg.Warn().Err(e).Str("key", key).Msg("idempotency mark failed (send already succeeded)")
+			return nil
+		}
+	}
+
+	s.lg.Info().
+		Str("announcement_id", announcementID).
+		Str("event_id", eventID).
+		Str("user_id", userID).
+		Msg("event announcement email sent")
+	return nil
+}
+
+// EventChanged tells one participant that the time, place or capacity of
+// an event they joined changed. changes is the raw {"field":{"from","to"}}
+// map from event-service.
+func (s *Service) EventChanged(ctx context.Context, eventID, userID, eventTitle string, version int64, changes json.RawMessage) error {
+	lines, err := describeChanges(changes)
+	if err != nil {
+		return PermanentError{msg: "bad changes payload: " + err.Error()}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	// Key: email:sent:event_changed:<eventID>:<version>:<userID>
+	key := fmt.Sprintf("email:sent:event_changed:%s:%d:%s", eventID, version, userID)
+	if s.idem != nil {
+		seen, e := s.idem.Seen(ctx, key)
+		if e != nil {
+			return e
+		}
+		if seen {
+			s.lg.Info().Str("event_id", eventID).Int64("version", version).Str("user_id", userID).Msg("idempotent skip")
+			return nil
+		}
+	}
+
+	email, err := s.resolver.GetEmail(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("resolve email failed: %w", err)
+	}
+	if email == "" {
+		s.lg.Warn().Str("user_id", userID).Msg("user has no email; dropping")
+		return nil
+	}
+
+	if skip, e := s.suppressed(ctx, userID, email, CategoryTransactional); e != nil || skip {
+		return e
+	}
+
+	if err := s.sender.SendEventChanged(ctx, email, eventTitle, lines); err != nil {
+		return err
+	}
+
+	if s.idem != nil {
+		if e := s.idem.MarkSent(ctx, key, 7*24*time.Hour); e != nil {
+			s.lg.Warn().Err(e).Str("key", key).Msg("idempotency mark failed (send already succeeded)")
+			return nil
+		}
+	}
+
+	s.lg.Info().
+		Str("event_id", eventID).
+		Int64("version", version).
+		Str("user_id", userID).
+		Msg("event changed email sent")
+	return nil
+}
+
+var changeLabels = map[string]string{
+	"start_time": "Starts",
+	"end_time":   "Ends",
+	"city":       "City",
+	"capacity":   "Capacity",
+}
+
+// describeChanges renders each field change as "Label: from -> to",
+// ordered by field name so the email is stable across retries.
+func describeChanges(raw json.RawMessage) ([]string, error) {
+	var changes map[string]struct {
+		From any `json:"from"`
+		To   any `json:"to"`
+	}
+	if err := json.Unmarshal(raw, &changes); err != nil {
+		return nil, err
+	}
+
+	fields := make([]string, 0, len(changes))
+	for f := range changes {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+
+	lines := make([]string, 0, len(fields))
+	for _, f := range fields {
+		label, ok := changeLabels[f]
+		if !ok {
+			label = strings.ReplaceAll(f, "_", " ")
+		}
+		c := changes[f]
+		lines = append(lines, fmt.Sprintf("%s: %s -> %s", label, changeValue(c.From), changeValue(c.To)))
+	}
+	return lines, nil
+}
+
+func changeValue(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return "none"
+	case string:
+		if t, err := time.Parse(time.RFC3339Nano, x); err == nil {
+			return t.UTC().Format("Mon 2 Jan 2006 15:04 UTC")
+		}
+		return x
+	case float64:
+		return fmt.Sprintf("%g", x)
+	default:
+		return fmt.Sprint(x)
+	}
+}
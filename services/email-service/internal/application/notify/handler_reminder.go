@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// EventReminder reminds one participant that an event starts in about
+// lead. The start time is part of the key, so a rescheduled event gets
+// fresh reminders.
+func (s *Service) EventReminder(ctx context.Context, eventID, userID, title, city string, start time.Time, lead time.Duration) error {
+	// Key: email:sent:event_reminder:<eventID>:<userID>:<lead>:<start unix>
+	key := fmt.Sprintf("email:sent:event_reminder:%s:%s:%s:%d", eventID, userID, leadLabel(lead), start.Unix())
+	if s.idem != nil {
+		seen, e := s.idem.Seen(ctx, key)
+		if e != nil {
+			return e
+		}
+		if seen {
+			s.lg.Info().Str("event_id", eventID).Str("user_id", userID).Dur("lead", lead).Msg("idempotent skip")
+			return nil
+		}
+	}
+
+	if s.prefs != nil {
+		enabled, err := s.prefs.RemindersEnabled(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("load preferences failed: %w", err)
+		}
+		if !enabled {
+			s.lg.Info().Str("user_id", userID).Msg("reminders opted out; skipping")
+			return nil
+		}
+	}
+
+	email, err := s.resolver.GetEmail(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("resolve email failed: %w", err)
+	}
+	if email == "" {
+		s.lg.Warn().Str("user_id", userID).Msg("user has no email; dropping")
+		return nil
+	}
+
+	if skip, e := s.suppressed(ctx, userID, email, CategoryReminders); e != nil || skip {
+		return e
+	}
+
+	if err := s.sender.SendEventReminder(ctx, email, s.unsubscribeURL(userID, CategoryReminders), title, city, start, lead); err != nil {
+		return err
+	}
+
+	if s.idem != nil {
+		// Outlives the event; the reminder is pointless afterwards anyway
+		ttl := time.Until(start) + 24*time.Hour
+		if e := s.idem.MarkSent(ctx, key, ttl); e != nil {
+			s.lg.Warn().Err(e).Str("key", key).Msg("idempotency mark failed (send already succeeded)")
+			return nil
+		}
+	}
+
+	s.lg.Info().
+		Str("event_id", eventID).
+		Str("user_id", userID).
+		Dur("lead", lead).
+		Msg("event reminder email sent")
+	return nil
+}
+
+// leadLabel is "24h" or "1h", or minutes for leads that are not whole hours.
+func leadLabel(lead time.Duration) string {
+	if lead%time.Hour == 0 {
+		return fmt.Sprintf("%dh", lead/time.Hour)
+	}
+	return fmt.Sprintf("%dm", lead/time.Minute)
+}
@@ -2,6 +2,9 @@ package notify
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -257,3 +260,225 @@ func TestService_PasswordReset_SendSuccess_MarkFails_ReturnsNil(t *testing.T) {
 		t.Fatalf("expected MarkSent called once, got %d", idem.MarkCalls())
 	}
 }
+
+func TestService_EventAnnouncement_SendsOncePerUser(t *testing.T) {
+	ctx := context.Background()
+
+	sender := &fakeSender{}
+	idem := newFakeIdem()
+	svc := NewService(sender, &FakeUserResolver{Email: "attendee@example.com"}, idem, 24*time.Hour, testLogger())
+
+	for i := 0; i < 2; i++ {
+		if err := svc.EventAnnouncement(ctx, "a1", "e1", "u1", "Meetup", "Room change", "We moved to 4B"); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	}
+	if sender.AnnouncementCalls() != 1 {
+		t.Fatalf("expected sender called once, got %d", sender.AnnouncementCalls())
+	}
+	if seen, _ := idem.Seen(ctx, "email:sent:event_announcement:a1:u1"); !seen {
+		t.Fatalf("expected key marked sent")
+	}
+
+	if err := svc.EventAnnouncement(ctx, "a1", "e1", "u2", "Meetup", "Room change", "We moved to 4B"); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if sender.AnnouncementCalls() != 2 {
+		t.Fatalf("expected another participant to be mailed, got %d", sender.AnnouncementCalls())
+	}
+}
+
+func TestService_EventChanged_SendsOncePerVersion(t *testing.T) {
+	ctx := context.Background()
+
+	sender := &fakeSender{}
+	idem := newFakeIdem()
+	svc := NewService(sender, &FakeUserResolver{Email: "attendee@example.com"}, idem, 24*time.Hour, testLogger())
+
+	changes := json.RawMessage(`{"city":{"from":"Sydney","to":"Melbourne"},"start_time":{"from":"2026-05-01T09:00:00Z","to":"2026-05-02T10:30:00Z"}}`)
+	for i := 0; i < 2; i++ {
+		if err := svc.EventChanged(ctx, "e1", "u1", "Meetup", 3, changes); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	}
+	if sender.ChangedCalls() != 1 {
+		t.Fatalf("expected sender called once, got %d", sender.ChangedCalls())
+	}
+	want := []string{"City: Sydney -> Melbourne", "Starts: Fri 1 May 2026 09:00 UTC -> Sat 2 May 2026 10:30 UTC"}
+	if strings.Join(sender.lastChangeDesc, "|") != strings.Join(want, "|") {
+		t.Fatalf("unexpected change lines: %v", sender.lastChangeDesc)
+	}
+
+	if err := svc.EventChanged(ctx, "e1", "u1", "Meetup", 4, changes); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if sender.ChangedCalls() != 2 {
+		t.Fatalf("expected a later revision to be mailed, got %d", sender.ChangedCalls())
+	}
+
+	if err := svc.EventChanged(ctx, "e1", "u1", "Meetup", 5, json.RawMessage(`[]`)); err == nil {
+		t.Fatalf("expected permanent error for malformed changes")
+	}
+}
+
+type fakePrefs struct{ off map[string]bool }
+
+func (p fakePrefs) RemindersEnabled(ctx context.Context, userID string) (bool, error) {
+	return !p.off[userID], nil
+}
+
+func (p fakePrefs) DigestEnabled(ctx context.Context, userID string) (bool, error) {
+	return !p.off[userID], nil
+}
+
+func TestService_EventReminder_OncePerLeadAndStart_RespectsOptOut(t *testing.T) {
+	ctx := context.Background()
+
+	sender := &fakeSender{}
+	idem := newFakeIdem()
+	svc := NewService(sender, &FakeUserResolver{Email: "attendee@example.com"}, idem, 24*time.Hour, testLogger())
+	svc.SetPreferences(fakePrefs{off: map[string]bool{"u2": true}})
+
+	start := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	for i := 0; i < 2; i++ {
+		if err := svc.EventReminder(ctx, "e1", "u1", "Meetup", "Sydney", start, time.Hour); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	}
+	if sender.ReminderCalls() != 1 {
+		t.Fatalf("expected sender called once, got %d", sender.ReminderCalls())
+	}
+	if seen, _ := idem.Seen(ctx, fmt.Sprintf("email:sent:event_reminder:e1:u1:1h:%d", start.Unix())); !seen {
+		t.Fatalf("expected key marked sent")
+	}
+
+	// Rescheduled: a new start time gets a fresh reminder
+	if err := svc.EventReminder(ctx, "e1", "u1", "Meetup", "Sydney", start.Add(time.Hour), time.Hour); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if sender.ReminderCalls() != 2 {
+		t.Fatalf("expected a rescheduled reminder, got %d", sender.ReminderCalls())
+	}
+
+	if err := svc.EventReminder(ctx, "e1", "u2", "Meetup", "Sydney", start, time.Hour); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if sender.ReminderCalls() != 2 {
+		t.Fatalf("expected opted-out user to be skipped, got %d", sender.ReminderCalls())
+	}
+}
+
+func TestService_WeeklyDigest_OncePerWeek_RespectsOptOut(t *testing.T) {
+	ctx := context.Background()
+
+	sender := &fakeSender{}
+	idem := newFakeIdem()
+	svc := NewService(sender, &FakeUserResolver{Email: "reader@example.com"}, idem, 24*time.Hour, testLogger())
+	svc.SetPreferences(fakePrefs{off: map[string]bool{"u2": true}})
+
+	items := []DigestItem{{Title: "Meetup", City: "Sydney", URL: "http://web/events/e1"}}
+	for i := 0; i < 2; i++ {
+		if err := svc.WeeklyDigest(ctx, "u1", "2026-W42", items); err != nil {
+			t.Fatalf("expected nil, got %v", err)
+		}
+	}
+	if sender.DigestCalls() != 1 {
+		t.Fatalf("expected sender called once, got %d", sender.DigestCalls())
+	}
+	if seen, _ := idem.Seen(ctx, "email:sent:weekly_digest:u1:2026-W42"); !seen {
+		t.Fatalf("expected key marked sent")
+	}
+
+	if err := svc.WeeklyDigest(ctx, "u1", "2026-W43", items); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := svc.WeeklyDigest(ctx, "u2", "2026-W43", items); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := svc.WeeklyDigest(ctx, "u1", "2026-W44", nil); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if sender.DigestCalls() != 2 {
+		t.Fatalf("expected only the next week for u1 to be mailed, got %d", sender.DigestCalls())
+	}
+}
+
+type fakeSuppressions map[string]string
+
+func (f fakeSuppressions) Suppression(ctx context.Context, email string) (string, error) {
+	return f[email], nil
+}
+
+type fakeLinks struct{}
+
+func (fakeLinks) URL(userID string, c Category) string {
+	return "http://mail/unsubscribe?u=" + userID + "&c=" + string(c)
+}
+
+func TestService_Suppression_HardBounceBlocksAll_ComplaintSparesTransactional(t *testing.T) {
+	ctx := context.Background()
+	start := time.Now().Add(2 * time.Hour)
+
+	// Hard bounce: nothing goes out, not even a password reset
+	sender := &fakeSender{}
+	svc := NewService(sender, &FakeUserResolver{Email: "gone@example.com"}, newFakeIdem(), 24*time.Hour, testLogger())
+	svc.SetSuppressions(fakeSuppressions{"gone@example.com": ReasonHardBounce})
+
+	if err := svc.PasswordReset(ctx, "u1", "gone@example.com", "http://x/reset?token=t1"); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := svc.EventReminder(ctx, "e1", "u1", "Meetup", "Sydney", start, time.Hour); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if sender.ResetCalls() != 0 || sender.ReminderCalls() != 0 {
+		t.Fatalf("expected nothing sent to a hard-bounced address, got reset=%d reminder=%d", sender.ResetCalls(), sender.ReminderCalls())
+	}
+
+	// Complaint: optional mail stops, transactional mail still goes out
+	sender = &fakeSender{}
+	svc = NewService(sender, &FakeUserResolver{Email: "angry@example.com"}, newFakeIdem(), 24*time.Hour, testLogger())
+	svc.SetSuppressions(fakeSuppressions{"angry@example.com": ReasonComplaint})
+
+	if err := svc.PasswordReset(ctx, "u1", "angry@example.com", "http://x/reset?token=t2"); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if err := svc.EventReminder(ctx, "e1", "u1", "Meetup", "Sydney", start, time.Hour); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if sender.ResetCalls() != 1 {
+		t.Fatalf("expected password reset to bypass the complaint, got %d", sender.ResetCalls())
+	}
+	if sender.ReminderCalls() != 0 {
+		t.Fatalf("expected reminder suppressed, got %d", sender.ReminderCalls())
+	}
+}
+
+func TestService_PasswordReset_IgnoresCategoryOptOut(t *testing.T) {
+	ctx := context.Background()
+
+	sender := &fakeSender{}
+	svc := NewService(sender, &FakeUserResolver{Email: "test@example.com"}, newFakeIdem(), 24*time.Hour, testLogger())
+	svc.SetPreferences(fakePrefs{off: map[string]bool{"u1": true}})
+
+	if err := svc.PasswordReset(ctx, "u1", "test@example.com", "http://x/reset?token=t1"); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if sender.ResetCalls() != 1 {
+		t.Fatalf("expected password reset sent despite opt-outs, got %d", sender.ResetCalls())
+	}
+}
+
+func TestService_EventReminder_CarriesUnsubscribeLink(t *testing.T) {
+	ctx := context.Background()
+
+	sender := &fakeSender{}
+	svc := NewService(sender, &FakeUserResolver{Email: "attendee@example.com"}, newFakeIdem(), 24*time.Hour, testLogger())
+	svc.SetUnsubscribeLinks(fakeLinks{})
+
+	if err := svc.EventReminder(ctx, "e1", "u1", "Meetup", "Sydney", time.Now().Add(2*time.Hour), time.Hour); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if got := sender.LastUnsubscribeURL(); got != "http://mail/unsubscribe?u=u1&c=reminders" {
+		t.Fatalf("unexpected unsubscribe link %q", got)
+	}
+}
@@ -0,0 +1,69 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+)
+
+// Category groups emails for opt-outs and suppression. Transactional mail
+// (verification, password reset, notices about events the user is part
+// of) cannot be opted out of.
+type Category string
+
+const (
+	CategoryTransactional Category = "transactional"
+	CategoryReminders     Category = "reminders"
+	CategoryDigest        Category = "digest"
+)
+
+// Suppression reasons. A hard bounce stops all mail to the address; a
+// complaint stops everything but transactional mail.
+const (
+	ReasonHardBounce = "hard_bounce"
+	ReasonComplaint  = "complaint"
+)
+
+// Suppressions answers whether an address is on the suppression list.
+type Suppressions interface {
+	// Suppression returns the reason email is suppressed, or "".
+	Suppression(ctx context.Context, email string) (string, error)
+}
+
+// UnsubscribeLinks builds one-click unsubscribe URLs.
+type UnsubscribeLinks interface {
+	URL(userID string, c Category) string
+}
+
+// SetSuppressions enables suppression list checks before every send.
+func (s *Service) SetSuppressions(x Suppressions) {
+	s.supp = x
+}
+
+// SetUnsubscribeLinks adds unsubscribe links to optional emails.
+func (s *Service) SetUnsubscribeLinks(l UnsubscribeLinks) {
+	s.unsub = l
+}
+
+// suppressed reports (and logs) that mail of category c must not go to
+// email. Callers drop the message without retrying.
+func (s *Service) suppressed(ctx context.Context, userID, email string, c Category) (bool, error) {
+	if s.supp == nil {
+		return false, nil
+	}
+	reason, err := s.supp.Suppression(ctx, email)
+	if err != nil {
+		return false, fmt.Errorf("load suppression failed: %w", err)
+	}
+	if reason != ReasonHardBounce && (reason != ReasonComplaint || c == CategoryTransactional) {
+		return false, nil
+	}
+	s.lg.Warn().Str("user_id", userID).Str("reason", reason).Str("category", string(c)).Msg("address suppressed; dropping")
+	return true, nil
+}
+
+func (s *Service) unsubscribeURL(userID string, c Category) string {
+	if s.unsub == nil {
+		return ""
+	}
+	return s.unsub.URL(userID, c)
+}
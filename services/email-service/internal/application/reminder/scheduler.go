@@ -0,0 +1,277 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Leads are how long before the start each reminder goes out, longest
+// first.
+var Leads = []time.Duration{24 * time.Hour, time.Hour}
+
+// Event is what the scheduler knows about an upcoming event. StartTime is
+// zero until a snapshot with it arrives.
+type Event struct {
+	ID        string
+	Title     string
+	City      string
+	StartTime time.Time
+}
+
+// Claim is a due reminder leased to one scheduler run. LeaseUntil must be
+// passed back to Complete.
+type Claim struct {
+	EventID    string
+	Lead       time.Duration
+	LeaseUntil time.Time
+}
+
+// Store keeps events, their active participants and the due reminders.
+type Store interface {
+	GetEvent(ctx context.Context, eventID string) (*Event, error)
+	// SaveEvent stores ev and forgets it (and its participants) at expireAt.
+	SaveEvent(ctx context.Context, ev Event, expireAt time.Time) error
+	// DeleteEvent removes the event, its participants and pending reminders.
+	DeleteEvent(ctx context.Context, eventID string) error
+
+	AddParticipant(ctx context.Context, eventID, userID string, expireAt time.Time) error
+	RemoveParticipant(ctx context.Context, eventID, userID string) error
+	Participants(ctx context.Context, eventID string) ([]string, error)
+
+	// Schedule sets (or moves) the reminder with lead for eventID to at.
+	Schedule(ctx context.Context, eventID string, lead time.Duration, at time.Time) error
+	Unschedule(ctx context.Context, eventID string, lead time.Duration) error
+
+	// ClaimDue leases up to limit reminders due at now until now+lease, so
+	// parallel schedulers never take the same one. An unfinished claim comes
+	// due again when the lease runs out.
+	ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Claim, error)
+	// Complete removes a claimed reminder unless it was rescheduled since.
+	Complete(ctx context.Context, c Claim) error
+}
+
+// Notifier sends one participant's reminder. It must be idempotent per
+// event, user, lead and start time.
+type Notifier interface {
+	EventReminder(ctx context.Context, eventID, userID, title, city string, start time.Time, lead time.Duration) error
+}
+
+const (
+	claimLease = 5 * time.Minute
+	claimBatch = 100
+
+	// keepAfterStart is how long an event's state outlives its start.
+	keepAfterStart = 24 * time.Hour
+	// keepUnknown bounds participants of events whose start is not known.
+	keepUnknown = 30 * 24 * time.Hour
+)
+
+// Scheduler tracks upcoming events and their active participants from
+// event-service and join-service messages, and sends the reminders when
+// they come due.
+type Scheduler struct {
+	store    Store
+	notifier Notifier
+	now      func() time.Time
+	lg       zerolog.Logger
+}
+
+func NewScheduler(store Store, notifier Notifier, lg zerolog.Logger) *Scheduler {
+	return &Scheduler{
+		store:    store,
+		notifier: notifier,
+		now:      time.Now,
+		lg:       lg.With().Str("component", "reminder_scheduler").Logger(),
+	}
+}
+
+// EventScheduled records a snapshot of a published event and (re)schedules
+// its reminders. Empty fields keep what was known, so partial updates such
+// as event.changed work. Reminders whose time has passed are dropped.
+func (s *Scheduler) EventScheduled(ctx context.Context, ev Event) error {
+	if ev.ID == "" {
+		return nil
+	}
+	prev, err := s.store.GetEvent(ctx, ev.ID)
+	if err != nil {
+		return err
+	}
+	if prev != nil {
+		if ev.Title == "" {
+			ev.Title = prev.Title
+		}
+		if ev.City == "" {
+			ev.City = prev.City
+		}
+		if ev.StartTime.IsZero() {
+			ev.StartTime = prev.StartTime
+		}
+	}
+
+	now := s.now()
+	if err := s.store.SaveEvent(ctx, ev, expireAt(ev, now)); err != nil {
+		return err
+	}
+	if ev.StartTime.IsZero() {
+		return nil
+	}
+
+	for _, lead := range Leads {
+		at := ev.StartTime.Add(-lead)
+		if at.After(now) {
+			err = s.store.Schedule(ctx, ev.ID, lead, at)
+		} else {
+			err = s.store.Unschedule(ctx, ev.ID, lead)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EventEnded forgets a canceled or unpublished event.
+func (s *Scheduler) EventEnded(ctx context.Context, eventID string) error {
+	if eventID == "" {
+		return nil
+	}
+	return s.store.DeleteEvent(ctx, eventID)
+}
+
+// ParticipantJoined records an active participant. Waitlisted users are
+// added when promoted.
+func (s *Scheduler) ParticipantJoined(ctx context.Context, eventID, userID string) error {
+	if eventID == "" || userID == "" {
+		return nil
+	}
+	ev, err := s.store.GetEvent(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if ev == nil {
+		ev = &Event{ID: eventID}
+	}
+	return s.store.AddParticipant(ctx, eventID, userID, expireAt(*ev, s.now()))
+}
+
+// ParticipantLeft drops a participant who canceled, was kicked or banned.
+func (s *Scheduler) ParticipantLeft(ctx context.Context, eventID, userID string) error {
+	if eventID == "" || userID == "" {
+		return nil
+	}
+	return s.store.RemoveParticipant(ctx, eventID, userID)
+}
+
+// Run sends due reminders every interval until ctx is done.
+func (s *Scheduler) Run(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		if n, err := s.RunOnce(ctx); err != nil {
+			s.lg.Error().Err(err).Msg("reminder run failed")
+		} else if n > 0 {
+			s.lg.Info().Int("reminders", n).Msg("reminders processed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// RunOnce processes the reminders due now and reports how many were
+// completed.
+func (s *Scheduler) RunOnce(ctx context.Context) (int, error) {
+	claims, err := s.store.ClaimDue(ctx, s.now(), claimLease, claimBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for _, c := range claims {
+		if ctx.Err() != nil {
+			return done, ctx.Err()
+		}
+		if !s.deliver(ctx, c) {
+			// Left leased: it comes due again and delivered users are skipped
+			continue
+		}
+		if err := s.store.Complete(ctx, c); err != nil {
+			s.lg.Warn().Err(err).Str("event_id", c.EventID).Msg("reminder complete failed")
+			continue
+		}
+		done++
+	}
+	return done, nil
+}
+
+// deliver sends c to every participant and reports whether it is finished.
+func (s *Scheduler) deliver(ctx context.Context, c Claim) bool {
+	lg := s.lg.With().Str("event_id", c.EventID).Dur("lead", c.Lead).Logger()
+
+	ev, err := s.store.GetEvent(ctx, c.EventID)
+	if err != nil {
+		lg.Warn().Err(err).Msg("load event failed")
+		return false
+	}
+	if ev == nil || ev.StartTime.IsZero() {
+		return true
+	}
+	if superseded(ev.StartTime, c.Lead, s.now()) {
+		lg.Info().Msg("reminder too late; skipped")
+		return true
+	}
+
+	users, err := s.store.Participants(ctx, c.EventID)
+	if err != nil {
+		lg.Warn().Err(err).Msg("load participants failed")
+		return false
+	}
+
+	ok := true
+	for _, userID := range users {
+		err := s.notifier.EventReminder(ctx, ev.ID, userID, ev.Title, ev.City, ev.StartTime, c.Lead)
+		if err == nil {
+			continue
+		}
+		if isPermanent(err) {
+			lg.Warn().Err(err).Str("user_id", userID).Msg("reminder dropped")
+			continue
+		}
+		lg.Warn().Err(err).Str("user_id", userID).Msg("reminder send failed; will retry")
+		ok = false
+	}
+	return ok
+}
+
+// superseded reports whether a reminder with lead is pointless at now: the
+// event has started, or a shorter reminder is due as well.
+func superseded(start time.Time, lead time.Duration, now time.Time) bool {
+	if !start.After(now) {
+		return true
+	}
+	for _, l := range Leads {
+		if l < lead && !now.Before(start.Add(-l)) {
+			return true
+		}
+	}
+	return false
+}
+
+func expireAt(ev Event, now time.Time) time.Time {
+	if ev.StartTime.IsZero() {
+		return now.Add(keepUnknown)
+	}
+	return ev.StartTime.Add(keepAfterStart)
+}
+
+func isPermanent(err error) bool {
+	var pm interface{ Permanent() bool }
+	return errors.As(err, &pm) && pm.Permanent()
+}
@@ -0,0 +1,266 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// ---- in-memory Store ----
+
+type memStore struct {
+	events map[string]Event
+	users  map[string]map[string]bool
+	due    map[string]time.Time // "<event>|<lead>"
+}
+
+func newMemStore() *memStore {
+	return &memStore{events: map[string]Event{}, users: map[string]map[string]bool{}, due: map[string]time.Time{}}
+}
+
+func dueKey(eventID string, lead time.Duration) string { return eventID + "|" + lead.String() }
+
+func (m *memStore) GetEvent(ctx context.Context, eventID string) (*Event, error) {
+	ev, ok := m.events[eventID]
+	if !ok {
+		return nil, nil
+	}
+	return &ev, nil
+}
+
+func (m *memStore) SaveEvent(ctx context.Context, ev Event, expireAt time.Time) error {
+	m.events[ev.ID] = ev
+	return nil
+}
+
+func (m *memStore) DeleteEvent(ctx context.Context, eventID string) error {
+	delete(m.events, eventID)
+	delete(m.users, eventID)
+	for _, l := range Leads {
+		delete(m.due, dueKey(eventID, l))
+	}
+	return nil
+}
+
+func (m *memStore) AddParticipant(ctx context.Context, eventID, userID string, expireAt time.Time) error {
+	if m.users[eventID] == nil {
+		m.users[eventID] = map[string]bool{}
+	}
+	m.users[eventID][userID] = true
+	return nil
+}
+
+func (m *memStore) RemoveParticipant(ctx context.Context, eventID, userID string) error {
+	delete(m.users[eventID], userID)
+	return nil
+}
+
+func (m *memStore) Participants(ctx context.Context, eventID string) ([]string, error) {
+	var out []string
+	for u := range m.users[eventID] {
+		out = append(out, u)
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func (m *memStore) Schedule(ctx context.Context, eventID string, lead time.Duration, at time.Time) error {
+	m.due[dueKey(eventID, lead)] = at
+	return nil
+}
+
+func (m *memStore) Unschedule(ctx context.Context, eventID string, lead time.Duration) error {
+	delete(m.due, dueKey(eventID, lead))
+	return nil
+}
+
+func (m *memStore) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]Claim, error) {
+	var out []Claim
+	for id, ev := range m.events {
+		for _, l := range Leads {
+			k := dueKey(id, l)
+			if at, ok := m.due[k]; ok && !at.After(now) && len(out) < limit {
+				m.due[k] = now.Add(lease)
+				out = append(out, Claim{EventID: ev.ID, Lead: l, LeaseUntil: now.Add(lease)})
+			}
+		}
+	}
+	return out, nil
+}
+
+func (m *memStore) Complete(ctx context.Context, c Claim) error {
+	k := dueKey(c.EventID, c.Lead)
+	if m.due[k].Equal(c.LeaseUntil) {
+		delete(m.due, k)
+	}
+	return nil
+}
+
+// ---- Notifier ----
+
+type sent struct {
+	userID string
+	lead   time.Duration
+	start  time.Time
+}
+
+type fakeNotifier struct {
+	sent []sent
+	errs map[string]error // by user
+}
+
+func (n *fakeNotifier) EventReminder(ctx context.Context, eventID, userID, title, city string, start time.Time, lead time.Duration) error {
+	if err := n.errs[userID]; err != nil {
+		return err
+	}
+	n.sent = append(n.sent, sent{userID: userID, lead: lead, start: start})
+	return nil
+}
+
+type permErr struct{}
+
+func (permErr) Error() string   { return "bad address" }
+func (permErr) Permanent() bool { return true }
+
+func newTestScheduler(now time.Time) (*Scheduler, *memStore, *fakeNotifier) {
+	store := newMemStore()
+	n := &fakeNotifier{errs: map[string]error{}}
+	s := NewScheduler(store, n, zerolog.Nop())
+	s.now = func() time.Time { return now }
+	return s, store, n
+}
+
+func TestScheduler_SchedulesBothLeads(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, store, _ := newTestScheduler(now)
+	start := now.Add(48 * time.Hour)
+
+	if err := s.EventScheduled(context.Background(), Event{ID: "e1", Title: "Meetup", StartTime: start}); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := store.due[dueKey("e1", 24*time.Hour)]; !got.Equal(start.Add(-24 * time.Hour)) {
+		t.Errorf("24h reminder at %v", got)
+	}
+	if got := store.due[dueKey("e1", time.Hour)]; !got.Equal(start.Add(-time.Hour)) {
+		t.Errorf("1h reminder at %v", got)
+	}
+}
+
+func TestScheduler_PastLeadsAreDropped(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, store, _ := newTestScheduler(now)
+
+	_ = s.EventScheduled(context.Background(), Event{ID: "e1", StartTime: now.Add(48 * time.Hour)})
+	// Moved up to 3h from now: the 24h reminder no longer makes sense
+	_ = s.EventScheduled(context.Background(), Event{ID: "e1", StartTime: now.Add(3 * time.Hour)})
+
+	if _, ok := store.due[dueKey("e1", 24*time.Hour)]; ok {
+		t.Error("24h reminder should be unscheduled")
+	}
+	if _, ok := store.due[dueKey("e1", time.Hour)]; !ok {
+		t.Error("1h reminder should stay scheduled")
+	}
+}
+
+func TestScheduler_PartialUpdateKeepsKnownFields(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, store, _ := newTestScheduler(now)
+
+	_ = s.EventScheduled(context.Background(), Event{ID: "e1", Title: "Meetup", City: "Sydney", StartTime: now.Add(48 * time.Hour)})
+	_ = s.EventScheduled(context.Background(), Event{ID: "e1", StartTime: now.Add(72 * time.Hour)})
+
+	ev := store.events["e1"]
+	if ev.Title != "Meetup" || ev.City != "Sydney" || !ev.StartTime.Equal(now.Add(72*time.Hour)) {
+		t.Errorf("unexpected event %+v", ev)
+	}
+}
+
+func TestScheduler_RunOnce_SendsToActiveParticipants(t *testing.T) {
+	start := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	s, store, n := newTestScheduler(start.Add(-48 * time.Hour))
+	ctx := context.Background()
+
+	_ = s.EventScheduled(ctx, Event{ID: "e1", Title: "Meetup", StartTime: start})
+	_ = s.ParticipantJoined(ctx, "e1", "u1")
+	_ = s.ParticipantJoined(ctx, "e1", "u2")
+	_ = s.ParticipantLeft(ctx, "e1", "u2")
+
+	s.now = func() time.Time { return start.Add(-24 * time.Hour) }
+	done, err := s.RunOnce(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if done != 1 || len(n.sent) != 1 || n.sent[0].userID != "u1" || n.sent[0].lead != 24*time.Hour {
+		t.Fatalf("done=%d sent=%+v", done, n.sent)
+	}
+	if _, ok := store.due[dueKey("e1", 24*time.Hour)]; ok {
+		t.Error("completed reminder should be removed")
+	}
+
+	// Nothing else is due yet
+	if done, _ := s.RunOnce(ctx); done != 0 {
+		t.Errorf("expected nothing due, got %d", done)
+	}
+}
+
+func TestScheduler_RunOnce_TransientFailureKeepsClaim(t *testing.T) {
+	start := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	s, store, n := newTestScheduler(start.Add(-48 * time.Hour))
+	ctx := context.Background()
+
+	_ = s.EventScheduled(ctx, Event{ID: "e1", StartTime: start})
+	_ = s.ParticipantJoined(ctx, "e1", "u1")
+	_ = s.ParticipantJoined(ctx, "e1", "u2")
+	n.errs["u1"] = errors.New("smtp down")
+	n.errs["u2"] = permErr{}
+
+	s.now = func() time.Time { return start.Add(-time.Hour) }
+	done, err := s.RunOnce(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the superseded 24h reminder completes
+	if done != 1 {
+		t.Errorf("expected the 1h claim to stay open, got %d done", done)
+	}
+	if _, ok := store.due[dueKey("e1", time.Hour)]; !ok {
+		t.Error("reminder should stay leased for a retry")
+	}
+}
+
+func TestScheduler_RunOnce_SkipsSupersededReminder(t *testing.T) {
+	start := time.Date(2026, 3, 2, 18, 0, 0, 0, time.UTC)
+	s, _, n := newTestScheduler(start.Add(-48 * time.Hour))
+	ctx := context.Background()
+
+	_ = s.EventScheduled(ctx, Event{ID: "e1", StartTime: start})
+	_ = s.ParticipantJoined(ctx, "e1", "u1")
+
+	// Down for a day: both are due, only the 1h reminder goes out
+	s.now = func() time.Time { return start.Add(-30 * time.Minute) }
+	if _, err := s.RunOnce(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(n.sent) != 1 || n.sent[0].lead != time.Hour {
+		t.Fatalf("sent=%+v", n.sent)
+	}
+}
+
+func TestScheduler_EventEndedDropsReminders(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s, store, _ := newTestScheduler(now)
+	ctx := context.Background()
+
+	_ = s.EventScheduled(ctx, Event{ID: "e1", StartTime: now.Add(48 * time.Hour)})
+	_ = s.ParticipantJoined(ctx, "e1", "u1")
+	_ = s.EventEnded(ctx, "e1")
+
+	if len(store.due) != 0 || len(store.users["e1"]) != 0 {
+		t.Errorf("expected everything dropped: due=%v users=%v", store.due, store.users)
+	}
+}
@@ -0,0 +1,139 @@
+package userdata
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/rs/zerolog"
+)
+
+// UserResolver looks up the user's address; suppressions are keyed by it.
+// auth-service keeps the account until every service has answered, so
+// the lookup still works during a deletion.
+type UserResolver interface {
+	GetEmail(ctx context.Context, userID string) (string, error)
+}
+
+// PreferenceStore holds email preferences and the digest subscription.
+type PreferenceStore interface {
+	// Stored returns the saved preferences, or nil if there are none.
+	Stored(ctx context.Context, userID string) (any, error)
+	Delete(ctx context.Context, userID string) error
+}
+
+// SuppressionStore holds the suppression list.
+type SuppressionStore interface {
+	// Entry returns the suppression for email, or nil if there is none.
+	Entry(ctx context.Context, email string) (any, error)
+	Remove(ctx context.Context, email string) (bool, error)
+}
+
+// ReminderStore holds the participants of upcoming events.
+type ReminderStore interface {
+	ParticipantEvents(ctx context.Context, userID string) ([]string, error)
+	RemoveParticipant(ctx context.Context, eventID, userID string) error
+}
+
+// Export is email-service's part of a data export.
+type Export struct {
+	Preferences    any      `json:"preferences"`
+	Suppression    any      `json:"suppression"`
+	ReminderEvents []string `json:"reminder_events"`
+}
+
+// Service erases or exports what email-service keeps about a user for
+// auth-service's deletion and export requests. Idempotency and digest
+// claim keys also carry user IDs but expire within days, so they are left
+// to expire. Without Redis nothing is stored and both are no-ops.
+type Service struct {
+	resolver  UserResolver
+	prefs     PreferenceStore  // nil => nothing stored
+	supp      SuppressionStore // nil => nothing stored
+	reminders ReminderStore    // nil => nothing stored
+	lg        zerolog.Logger
+}
+
+func NewService(resolver UserResolver, lg zerolog.Logger) *Service {
+	return &Service{
+		resolver: resolver,
+		lg:       lg.With().Str("component", "userdata_service").Logger(),
+	}
+}
+
+// SetStores sets the Redis stores; any of them may be nil.
+func (s *Service) SetStores(prefs PreferenceStore, supp SuppressionStore, reminders ReminderStore) {
+	s.prefs = prefs
+	s.supp = supp
+	s.reminders = reminders
+}
+
+// DeleteUser removes the user's preferences, digest subscription, pending
+// reminders and the suppression entry for their address. Replays are safe.
+func (s *Service) DeleteUser(ctx context.Context, userID string) error {
+	if userID == "" {
+		return fmt.Errorf("empty user_id")
+	}
+	if s.prefs != nil {
+		if err := s.prefs.Delete(ctx, userID); err != nil {
+			return fmt.Errorf("delete preferences: %w", err)
+		}
+	}
+	if s.reminders != nil {
+		events, err := s.reminders.ParticipantEvents(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("list reminder events: %w", err)
+		}
+		for _, eventID := range events {
+			if err := s.reminders.RemoveParticipant(ctx, eventID, userID); err != nil {
+				return fmt.Errorf("remove from %s reminders: %w", eventID, err)
+			}
+		}
+	}
+	if s.supp != nil {
+		email, err := s.resolver.GetEmail(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("resolve email: %w", err)
+		}
+		if email != "" {
+			if _, err := s.supp.Remove(ctx, email); err != nil {
+				return fmt.Errorf("remove suppression: %w", err)
+			}
+		}
+	}
+	s.lg.Info().Str("user_id", userID).Msg("user email data deleted")
+	return nil
+}
+
+// ExportUser returns the user's stored email data as JSON.
+func (s *Service) ExportUser(ctx context.Context, userID string) (json.RawMessage, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("empty user_id")
+	}
+	out := Export{ReminderEvents: []string{}}
+	var err error
+	if s.prefs != nil {
+		if out.Preferences, err = s.prefs.Stored(ctx, userID); err != nil {
+			return nil, fmt.Errorf("read preferences: %w", err)
+		}
+	}
+	if s.reminders != nil {
+		events, err := s.reminders.ParticipantEvents(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("list reminder events: %w", err)
+		}
+		out.ReminderEvents = append(out.ReminderEvents, events...)
+	}
+	if s.supp != nil {
+		email, err := s.resolver.GetEmail(ctx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("resolve email: %w", err)
+		}
+		if email != "" {
+			if out.Suppression, err = s.supp.Entry(ctx, email); err != nil {
+				return nil, fmt.Errorf("read suppression: %w", err)
+			}
+		}
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,129 @@
+package userdata
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+type fakeResolver struct{ email string }
+
+func (f fakeResolver) GetEmail(ctx context.Context, userID string) (string, error) {
+	return f.email, nil
+}
+
+type memPrefs map[string]map[string]string
+
+func (m memPrefs) Stored(ctx context.Context, userID string) (any, error) {
+	if p, ok := m[userID]; ok {
+		return p, nil
+	}
+	return nil, nil
+}
+
+func (m memPrefs) Delete(ctx context.Context, userID string) error {
+	delete(m, userID)
+	return nil
+}
+
+type memSupp map[string]string
+
+func (m memSupp) Entry(ctx context.Context, email string) (any, error) {
+	if r, ok := m[email]; ok {
+		return map[string]string{"reason": r}, nil
+	}
+	return nil, nil
+}
+
+func (m memSupp) Remove(ctx context.Context, email string) (bool, error) {
+	_, ok := m[email]
+	delete(m, email)
+	return ok, nil
+}
+
+type memReminders map[string]map[string]bool // event -> users
+
+func (m memReminders) ParticipantEvents(ctx context.Context, userID string) ([]string, error) {
+	var out []string
+	for ev, users := range m {
+		if users[userID] {
+			out = append(out, ev)
+		}
+	}
+	return out, nil
+}
+
+func (m memReminders) RemoveParticipant(ctx context.Context, eventID, userID string) error {
+	delete(m[eventID], userID)
+	return nil
+}
+
+func newTestService() (*Service, memPrefs, memSupp, memReminders) {
+	prefs := memPrefs{"u1": {"digest": "1"}, "u2": {"digest": "0"}}
+	supp := memSupp{"u1@example.com": "complaint"}
+	rem := memReminders{"e1": {"u1": true, "u2": true}}
+	s := NewService(fakeResolver{email: "u1@example.com"}, zerolog.Nop())
+	s.SetStores(prefs, supp, rem)
+	return s, prefs, supp, rem
+}
+
+func TestDeleteUser_ErasesEverythingKeptForTheUser(t *testing.T) {
+	s, prefs, supp, rem := newTestService()
+
+	if err := s.DeleteUser(context.Background(), "u1"); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+
+	if _, ok := prefs["u1"]; ok {
+		t.Fatal("preferences not deleted")
+	}
+	if _, ok := supp["u1@example.com"]; ok {
+		t.Fatal("suppression not deleted")
+	}
+	if rem["e1"]["u1"] || !rem["e1"]["u2"] {
+		t.Fatalf("unexpected participants: %v", rem["e1"])
+	}
+	if _, ok := prefs["u2"]; !ok {
+		t.Fatal("other user's preferences deleted")
+	}
+}
+
+func TestExportUser_IncludesPreferencesSuppressionAndReminders(t *testing.T) {
+	s, _, _, _ := newTestService()
+
+	raw, err := s.ExportUser(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("ExportUser: %v", err)
+	}
+	var got struct {
+		Preferences    map[string]string `json:"preferences"`
+		Suppression    map[string]string `json:"suppression"`
+		ReminderEvents []string          `json:"reminder_events"`
+	}
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if got.Preferences["digest"] != "1" || got.Suppression["reason"] != "complaint" {
+		t.Fatalf("unexpected export: %s", raw)
+	}
+	if len(got.ReminderEvents) != 1 || got.ReminderEvents[0] != "e1" {
+		t.Fatalf("unexpected reminder events: %v", got.ReminderEvents)
+	}
+}
+
+func TestService_WithoutStoresIsANoOp(t *testing.T) {
+	s := NewService(fakeResolver{}, zerolog.Nop())
+
+	if err := s.DeleteUser(context.Background(), "u1"); err != nil {
+		t.Fatalf("DeleteUser: %v", err)
+	}
+	raw, err := s.ExportUser(context.Background(), "u1")
+	if err != nil {
+		t.Fatalf("ExportUser: %v", err)
+	}
+	if string(raw) != `{"preferences":null,"suppression":null,"reminder_events":[]}` {
+		t.Fatalf("unexpected export: %s", raw)
+	}
+}
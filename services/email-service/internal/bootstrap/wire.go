@@ -7,19 +7,29 @@ import (
 	"github.com/gomodule/redigo/redis"
 	"github.com/rs/zerolog/log"
 
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/digest"
 	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/reminder"
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/userdata"
 	"github.com/baechuer/real-time-ressys/services/email-service/internal/config"
 	"github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/client"
+	digestinfra "github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/digest"
 	infraemail "github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/email"
 	"github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/idempotency"
 	rmq "github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/messaging/rabbitmq"
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/preferences"
+	reminderinfra "github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/reminder"
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/suppression"
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/unsubscribe"
 	web "github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/web"
 )
 
 type App struct {
-	consumer *rmq.Consumer
-	web      *web.Server
-	cfg      *config.Config
+	consumer  *rmq.Consumer
+	reminders *reminder.Scheduler // nil => disabled
+	digest    *digest.Job         // nil => disabled
+	web       *web.Server
+	cfg       *config.Config
 }
 
 func NewApp() (*App, func(), error) {
@@ -70,6 +80,61 @@ func NewApp() (*App, func(), error) {
 
 	notifySvc := notify.NewService(sender, authClient, idem, cfg.EmailIdempotencyTTL, log.Logger)
 
+	// Answers auth-service's data requests even without Redis, when there is nothing to erase
+	userData := userdata.NewService(authClient, log.Logger)
+	keys = append(keys, rmq.DataRequestBindKeys...)
+
+	// Preferences, suppressions, event reminders and the weekly digest (Redis only)
+	var prefs web.PreferenceStore
+	var supp web.SuppressionStore
+	var unsub web.UnsubscribeVerifier
+	var scheduler *reminder.Scheduler
+	var tracker rmq.ReminderTracker
+	var digestJob *digest.Job
+	if redisPool != nil {
+		prefStore := preferences.NewRedisStore(redisPool, log.Logger)
+		notifySvc.SetPreferences(prefStore)
+		prefs = prefStore
+
+		suppStore := suppression.NewRedisStore(redisPool, log.Logger)
+		notifySvc.SetSuppressions(suppStore)
+		supp = suppStore
+
+		signer := unsubscribe.NewSigner(cfg.UnsubscribeSecret, cfg.EmailPublicBaseURL)
+		notifySvc.SetUnsubscribeLinks(signer)
+		unsub = signer
+
+		reminderStore := reminderinfra.NewRedisStore(redisPool, log.Logger)
+		userData.SetStores(prefStore, suppStore, reminderStore)
+
+		if cfg.RemindersEnabled {
+			scheduler = reminder.NewScheduler(reminderStore, notifySvc, log.Logger)
+			tracker = scheduler
+			keys = append(keys, rmq.ReminderBindKeys...)
+		}
+
+		if cfg.DigestEnabled {
+			digestJob = digest.NewJob(
+				prefStore,
+				client.NewFeedClient(cfg.FeedServiceURL, log.Logger),
+				client.NewMediaClient(cfg.MediaServiceURL, cfg.AuthInternalSecret, log.Logger),
+				digestinfra.NewRedisClaims(redisPool, log.Logger),
+				notifySvc,
+				digest.Config{
+					Events:     cfg.DigestEvents,
+					WebBaseURL: cfg.WebBaseURL,
+				},
+				log.Logger,
+			)
+		}
+	}
+	if scheduler == nil {
+		log.Info().Msg("event reminders disabled (need REDIS_ENABLED and REMINDERS_ENABLED)")
+	}
+	if digestJob == nil {
+		log.Info().Msg("weekly digest disabled (need REDIS_ENABLED and DIGEST_ENABLED)")
+	}
+
 	// Rabbit consumer
 	consumer := rmq.NewConsumer(rmq.Config{
 		RabbitURL:          cfg.RabbitURL,
@@ -79,7 +144,8 @@ func NewApp() (*App, func(), error) {
 		Prefetch:           cfg.Prefetch,
 		Tag:                cfg.ConsumeTag,
 		EmailPublicBaseURL: cfg.EmailPublicBaseURL,
-	}, notifySvc, log.Logger)
+	}, notifySvc, tracker, log.Logger)
+	consumer.SetUserData(userData)
 
 	// Web server (8090) + Redis RL
 	webSrv := web.NewServer(web.Config{
@@ -94,12 +160,21 @@ func NewApp() (*App, func(), error) {
 			TokenLimit:  cfg.RLTokenLimit,
 			TokenWindow: cfg.RLTokenWindow,
 		},
+
+		InternalSecret: cfg.AuthInternalSecret,
+		Preferences:    prefs,
+
+		Unsubscribe:         unsub,
+		Suppressions:        supp,
+		BounceWebhookSecret: cfg.BounceWebhookSecret,
 	}, log.Logger)
 
 	app := &App{
-		consumer: consumer,
-		web:      webSrv,
-		cfg:      cfg,
+		consumer:  consumer,
+		reminders: scheduler,
+		digest:    digestJob,
+		web:       webSrv,
+		cfg:       cfg,
 	}
 
 	cleanup := func() {
@@ -122,6 +197,14 @@ func (a *App) Start(ctx context.Context) error {
 	if err := a.consumer.Start(ctx); err != nil {
 		return err
 	}
+	if a.reminders != nil {
+		log.Info().Dur("interval", a.cfg.ReminderPollInterval).Msg("Starting event reminder scheduler...")
+		go a.reminders.Run(ctx, a.cfg.ReminderPollInterval)
+	}
+	if a.digest != nil {
+		log.Info().Dur("interval", a.cfg.DigestPollInterval).Msg("Starting weekly digest job...")
+		go a.digest.Run(ctx, a.cfg.DigestPollInterval)
+	}
 	log.Info().Msg("Starting Email Service web...")
 	return a.web.Start(ctx) // block
 }
@@ -46,6 +46,22 @@ type Config struct {
 
 	EmailIdempotencyTTL time.Duration
 
+	// Event reminders (need Redis)
+	RemindersEnabled     bool
+	ReminderPollInterval time.Duration
+
+	// Weekly digest (needs Redis)
+	DigestEnabled      bool
+	DigestPollInterval time.Duration
+	DigestEvents       int
+	FeedServiceURL     string
+	WebBaseURL         string // event links in emails
+	MediaServiceURL    string // cover images in emails
+
+	// Unsubscribe links and bounce handling (need Redis)
+	UnsubscribeSecret   string
+	BounceWebhookSecret string // empty => /webhooks/bounces disabled
+
 	// ---- NEW: HTTP/API Rate Limiting ----
 	RLEnabled     bool
 	RLIPLimit     int
@@ -104,6 +120,19 @@ func Load() (*Config, error) {
 
 	cfg.EmailIdempotencyTTL = getDuration("EMAIL_IDEMPOTENCY_TTL", 24*time.Hour)
 
+	cfg.RemindersEnabled = getBool("REMINDERS_ENABLED", true)
+	cfg.ReminderPollInterval = getDuration("REMINDER_POLL_INTERVAL", 30*time.Second)
+
+	cfg.DigestEnabled = getBool("DIGEST_ENABLED", true)
+	cfg.DigestPollInterval = getDuration("DIGEST_POLL_INTERVAL", 10*time.Minute)
+	cfg.DigestEvents = getInt("DIGEST_EVENTS", 5)
+	cfg.FeedServiceURL = strings.TrimRight(getEnv("FEED_SERVICE_URL", "http://localhost:8084"), "/")
+	cfg.WebBaseURL = strings.TrimRight(getEnv("WEB_BASE_URL", "http://localhost:5173"), "/")
+	cfg.MediaServiceURL = strings.TrimRight(getEnv("MEDIA_SERVICE_URL", "http://localhost:8085"), "/")
+
+	cfg.UnsubscribeSecret = getEnv("UNSUBSCRIBE_SECRET", "dev-unsubscribe-secret")
+	cfg.BounceWebhookSecret = getEnv("BOUNCE_WEBHOOK_SECRET", "")
+
 	// ---- Rate limiting defaults ----
 	cfg.RLEnabled = getBool("RL_ENABLED", false)
 	cfg.RLIPLimit = getInt("RL_IP_LIMIT", 30)
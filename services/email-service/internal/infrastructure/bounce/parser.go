@@ -0,0 +1,200 @@
+package bounce
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/textproto"
+	"strings"
+)
+
+// Kind is what the receiving side told us about an address.
+type Kind string
+
+const (
+	KindHardBounce Kind = "hard_bounce" // permanent failure, e.g. 5.1.1 no such user
+	KindSoftBounce Kind = "soft_bounce" // transient failure, e.g. mailbox full
+	KindComplaint  Kind = "complaint"   // recipient marked the mail as spam
+)
+
+// Report is one recipient's outcome.
+type Report struct {
+	Email  string
+	Kind   Kind
+	Status string // enhanced status code, e.g. "5.1.1"
+	Detail string // diagnostic text from the remote side
+}
+
+var ErrUnsupported = errors.New("unsupported bounce format")
+
+// Parse reads a bounce notification. contentType selects the format:
+//
+//   - application/json: a provider webhook, either one event or
+//     {"events":[...]}, each {"email","type":"bounce"|"complaint",
+//     "bounce_type":"hard"|"soft","status","diagnostic"}
+//   - multipart/report; report-type=delivery-status: a full RFC 3464 DSN
+//   - message/delivery-status: just the DSN status part
+func Parse(contentType string, body io.Reader) ([]Report, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+
+	switch {
+	case mediaType == "application/json":
+		return parseWebhook(body)
+	case mediaType == "message/delivery-status":
+		return parseDeliveryStatus(body)
+	case mediaType == "multipart/report" && strings.EqualFold(params["report-type"], "delivery-status"):
+		return parseReport(body, params["boundary"])
+	default:
+		return nil, ErrUnsupported
+	}
+}
+
+type webhookEvent struct {
+	Email      string `json:"email"`
+	Type       string `json:"type"`
+	BounceType string `json:"bounce_type"`
+	Status     string `json:"status"`
+	Diagnostic string `json:"diagnostic"`
+}
+
+func parseWebhook(body io.Reader) ([]Report, error) {
+	raw, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch struct {
+		Events []webhookEvent `json:"events"`
+	}
+	if err := json.Unmarshal(raw, &batch); err != nil {
+		return nil, fmt.Errorf("bad webhook json: %w", err)
+	}
+	if batch.Events == nil {
+		var one webhookEvent
+		if err := json.Unmarshal(raw, &one); err != nil {
+			return nil, fmt.Errorf("bad webhook json: %w", err)
+		}
+		batch.Events = []webhookEvent{one}
+	}
+
+	out := make([]Report, 0, len(batch.Events))
+	for _, ev := range batch.Events {
+		if ev.Email == "" {
+			continue
+		}
+		r := Report{Email: ev.Email, Status: ev.Status, Detail: ev.Diagnostic}
+		switch strings.ToLower(ev.Type) {
+		case "complaint":
+			r.Kind = KindComplaint
+		case "bounce":
+			r.Kind = bounceKind(ev.BounceType, ev.Status)
+		default:
+			continue
+		}
+		out = append(out, r)
+	}
+	return out, nil
+}
+
+// bounceKind trusts an explicit bounce_type and falls back to the status
+// class: 5.x.x is permanent, anything else is worth retrying.
+func bounceKind(bounceType, status string) Kind {
+	switch strings.ToLower(bounceType) {
+	case "hard", "permanent":
+		return KindHardBounce
+	case "soft", "transient":
+		return KindSoftBounce
+	}
+	if strings.HasPrefix(status, "5.") {
+		return KindHardBounce
+	}
+	return KindSoftBounce
+}
+
+// parseReport finds the message/delivery-status part of a multipart/report.
+func parseReport(body io.Reader, boundary string) ([]Report, error) {
+	if boundary == "" {
+		return nil, ErrUnsupported
+	}
+	mr := multipart.NewReader(body, boundary)
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return nil, fmt.Errorf("report has no delivery-status part")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bad multipart report: %w", err)
+		}
+		mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+		if mediaType == "message/delivery-status" {
+			return parseDeliveryStatus(part)
+		}
+	}
+}
+
+// parseDeliveryStatus reads the per-message block and then one block per
+// recipient, separated by blank lines (RFC 3464 section 2.1).
+func parseDeliveryStatus(body io.Reader) ([]Report, error) {
+	tp := textproto.NewReader(bufio.NewReader(body))
+
+	// Per-message fields (Reporting-MTA etc.) are not needed.
+	if _, err := tp.ReadMIMEHeader(); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("bad delivery-status: %w", err)
+	}
+
+	var out []Report
+	for {
+		h, err := tp.ReadMIMEHeader()
+		if len(h) > 0 {
+			if r, ok := recipientReport(h); ok {
+				out = append(out, r)
+			}
+		}
+		if err == io.EOF {
+			return out, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bad delivery-status: %w", err)
+		}
+	}
+}
+
+func recipientReport(h textproto.MIMEHeader) (Report, bool) {
+	addr := h.Get("Final-Recipient")
+	if addr == "" {
+		addr = h.Get("Original-Recipient")
+	}
+	// "rfc822; user@example.com"
+	if _, after, ok := strings.Cut(addr, ";"); ok {
+		addr = after
+	}
+	addr = strings.Trim(strings.TrimSpace(addr), "<>")
+	if addr == "" {
+		return Report{}, false
+	}
+
+	action := strings.ToLower(strings.TrimSpace(h.Get("Action")))
+	status := strings.TrimSpace(h.Get("Status"))
+	if i := strings.IndexAny(status, " ("); i > 0 {
+		status = status[:i]
+	}
+
+	r := Report{Email: addr, Status: status, Detail: strings.TrimSpace(h.Get("Diagnostic-Code"))}
+	switch {
+	case action == "failed" && strings.HasPrefix(status, "5."):
+		r.Kind = KindHardBounce
+	case action == "failed" || action == "delayed":
+		r.Kind = KindSoftBounce
+	default:
+		// delivered, relayed, expanded: nothing to do
+		return Report{}, false
+	}
+	return r, true
+}
@@ -0,0 +1,91 @@
+package bounce
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const dsn = "--b1\r\n" +
+	"Content-Type: text/plain\r\n" +
+	"\r\n" +
+	"Delivery to the following recipients failed.\r\n" +
+	"--b1\r\n" +
+	"Content-Type: message/delivery-status\r\n" +
+	"\r\n" +
+	"Reporting-MTA: dns; mx.example.net\r\n" +
+	"Arrival-Date: Sun, 18 Oct 2026 09:00:00 +0000\r\n" +
+	"\r\n" +
+	"Final-Recipient: rfc822; gone@example.com\r\n" +
+	"Action: failed\r\n" +
+	"Status: 5.1.1\r\n" +
+	"Diagnostic-Code: smtp; 550 5.1.1 user unknown\r\n" +
+	"\r\n" +
+	"Final-Recipient: rfc822; full@example.com\r\n" +
+	"Action: failed\r\n" +
+	"Status: 4.2.2\r\n" +
+	"\r\n" +
+	"Final-Recipient: rfc822; ok@example.com\r\n" +
+	"Action: delivered\r\n" +
+	"Status: 2.0.0\r\n" +
+	"\r\n" +
+	"--b1\r\n" +
+	"Content-Type: text/rfc822-headers\r\n" +
+	"\r\n" +
+	"Subject: Reminder\r\n" +
+	"--b1--\r\n"
+
+func TestParse_DSN(t *testing.T) {
+	reports, err := Parse(`multipart/report; report-type=delivery-status; boundary="b1"`, strings.NewReader(dsn))
+
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, Report{Email: "gone@example.com", Kind: KindHardBounce, Status: "5.1.1", Detail: "smtp; 550 5.1.1 user unknown"}, reports[0])
+	assert.Equal(t, "full@example.com", reports[1].Email)
+	assert.Equal(t, KindSoftBounce, reports[1].Kind)
+}
+
+func TestParse_DeliveryStatusOnly(t *testing.T) {
+	body := "Reporting-MTA: dns; mx.example.net\n\nFinal-Recipient: rfc822;<gone@example.com>\nAction: failed\nStatus: 5.0.0 (permanent failure)\n"
+
+	reports, err := Parse("message/delivery-status", strings.NewReader(body))
+
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, "gone@example.com", reports[0].Email)
+	assert.Equal(t, "5.0.0", reports[0].Status)
+	assert.Equal(t, KindHardBounce, reports[0].Kind)
+}
+
+func TestParse_Webhook(t *testing.T) {
+	body := `{"events":[
+		{"email":"gone@example.com","type":"bounce","bounce_type":"hard"},
+		{"email":"full@example.com","type":"bounce","status":"4.2.2"},
+		{"email":"angry@example.com","type":"complaint"},
+		{"email":"x@example.com","type":"delivered"}
+	]}`
+
+	reports, err := Parse("application/json; charset=utf-8", strings.NewReader(body))
+
+	require.NoError(t, err)
+	require.Len(t, reports, 3)
+	assert.Equal(t, KindHardBounce, reports[0].Kind)
+	assert.Equal(t, KindSoftBounce, reports[1].Kind)
+	assert.Equal(t, KindComplaint, reports[2].Kind)
+}
+
+func TestParse_SingleWebhookEvent(t *testing.T) {
+	reports, err := Parse("application/json", strings.NewReader(`{"email":"gone@example.com","type":"bounce","status":"5.1.1"}`))
+
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, KindHardBounce, reports[0].Kind)
+}
+
+func TestParse_Unsupported(t *testing.T) {
+	_, err := Parse("text/plain", strings.NewReader("hi"))
+
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
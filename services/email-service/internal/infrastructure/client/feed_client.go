@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/digest"
+)
+
+// FeedClient reads users' personalized feeds from feed-service.
+type FeedClient struct {
+	baseURL string
+	client  *http.Client
+	lg      zerolog.Logger
+}
+
+func NewFeedClient(baseURL string, lg zerolog.Logger) *FeedClient {
+	return &FeedClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: 5 * time.Second},
+		lg:      lg.With().Str("component", "feed_client").Logger(),
+	}
+}
+
+type feedResponse struct {
+	Items []struct {
+		ID            string    `json:"id"`
+		Title         string    `json:"title"`
+		City          string    `json:"city"`
+		StartTime     time.Time `json:"start_time"`
+		CoverImageIDs []string  `json:"cover_image_ids"`
+	} `json:"items"`
+}
+
+// Personalized returns the first page of userID's personalized feed. The
+// feed is keyed by X-User-ID (actor "u:<id>"), like a logged-in request
+// through the BFF.
+func (c *FeedClient) Personalized(ctx context.Context, userID, city string, limit int) ([]digest.Event, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("empty user_id")
+	}
+
+	q := url.Values{"type": {"personalized"}, "limit": {strconv.Itoa(limit)}}
+	if city != "" {
+		q.Set("city", city)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/api/feed?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-User-ID", userID)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed-service returned %d", resp.StatusCode)
+	}
+
+	var data feedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+
+	events := make([]digest.Event, 0, len(data.Items))
+	for _, it := range data.Items {
+		ev := digest.Event{ID: it.ID, Title: it.Title, City: it.City, StartTime: it.StartTime}
+		if len(it.CoverImageIDs) > 0 {
+			ev.CoverImageID = it.CoverImageIDs[0]
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
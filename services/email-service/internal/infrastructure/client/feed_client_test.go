@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestFeedClient_Personalized_SendsUserAndCity(t *testing.T) {
+	var gotUser, gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUser = r.Header.Get("X-User-ID")
+		gotQuery = r.URL.RawQuery
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"feed_type":"personalized","items":[{"id":"e1","title":"Meetup","city":"Sydney","start_time":"2026-10-20T08:00:00Z","cover_image_ids":["c1","c2"]},{"id":"e2","title":"Gig","city":"Sydney","start_time":"2026-10-21T08:00:00Z","cover_image_ids":null}]}`))
+	}))
+	defer server.Close()
+
+	client := NewFeedClient(server.URL, zerolog.Nop())
+
+	events, err := client.Personalized(context.Background(), "u1", "Sydney", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotUser != "u1" {
+		t.Errorf("expected X-User-ID u1, got %q", gotUser)
+	}
+	if gotQuery != "city=Sydney&limit=5&type=personalized" {
+		t.Errorf("unexpected query %q", gotQuery)
+	}
+	if len(events) != 2 || events[0].CoverImageID != "c1" || events[1].CoverImageID != "" || events[0].StartTime.IsZero() {
+		t.Errorf("unexpected events %+v", events)
+	}
+}
+
+func TestFeedClient_Personalized_Non200(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewFeedClient(server.URL, zerolog.Nop())
+	if _, err := client.Personalized(context.Background(), "u1", "", 5); err == nil {
+		t.Fatal("expected error")
+	}
+}
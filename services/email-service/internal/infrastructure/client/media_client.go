@@ -0,0 +1,77 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// coverSize is the event cover variant used in emails: 800px wide, any
+// aspect. Mail clients get the JPEG.
+const coverSize = "800"
+
+// MediaClient resolves upload IDs to image URLs through media-service, which
+// knows each upload's derived_keys.
+type MediaClient struct {
+	baseURL string
+	secret  string
+	client  *http.Client
+	lg      zerolog.Logger
+}
+
+func NewMediaClient(baseURL, secret string, lg zerolog.Logger) *MediaClient {
+	return &MediaClient{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		secret:  secret,
+		client:  &http.Client{Timeout: 5 * time.Second},
+		lg:      lg.With().Str("component", "media_client").Logger(),
+	}
+}
+
+type imageURLsRequest struct {
+	IDs  []string `json:"ids"`
+	Size string   `json:"size"`
+}
+
+type imageURLsResponse struct {
+	Items map[string]string `json:"items"`
+}
+
+// CoverURLs implements digest.Covers. Uploads that are not ready are left
+// out.
+func (c *MediaClient) CoverURLs(ctx context.Context, uploadIDs []string) (map[string]string, error) {
+	body, err := json.Marshal(imageURLsRequest{IDs: uploadIDs, Size: coverSize})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/media/v1/internal/uploads/image-urls", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.secret != "" {
+		req.Header.Set("X-Internal-Secret", c.secret)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("media-service returned %d", resp.StatusCode)
+	}
+
+	var data imageURLsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return nil, err
+	}
+	return data.Items, nil
+}
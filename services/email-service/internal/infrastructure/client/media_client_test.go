@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestMediaClient_CoverURLs(t *testing.T) {
+	var gotSecret, gotPath string
+	var gotBody imageURLsRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSecret = r.Header.Get("X-Internal-Secret")
+		gotPath = r.URL.Path
+		_ = json.NewDecoder(r.Body).Decode(&gotBody)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"items":{"c1":"http://cdn/public/derived/event_cover/c1_800.jpg"}}`))
+	}))
+	defer server.Close()
+
+	client := NewMediaClient(server.URL, "s3cret", zerolog.Nop())
+
+	urls, err := client.CoverURLs(context.Background(), []string{"c1", "c2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotSecret != "s3cret" || gotPath != "/media/v1/internal/uploads/image-urls" {
+		t.Errorf("unexpected request: secret=%q path=%q", gotSecret, gotPath)
+	}
+	if gotBody.Size != "800" || len(gotBody.IDs) != 2 {
+		t.Errorf("unexpected body: %+v", gotBody)
+	}
+	if len(urls) != 1 || urls["c1"] != "http://cdn/public/derived/event_cover/c1_800.jpg" {
+		t.Errorf("unexpected urls: %v", urls)
+	}
+}
+
+func TestMediaClient_CoverURLs_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewMediaClient(server.URL, "", zerolog.Nop())
+
+	if _, err := client.CoverURLs(context.Background(), []string{"c1"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
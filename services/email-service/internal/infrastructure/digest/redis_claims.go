@@ -0,0 +1,54 @@
+package digest
+
+import (
+	"context"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/rs/zerolog"
+)
+
+// keyClaimPrefix + <user_id>:<week> is held by the job instance sending
+// that digest.
+const keyClaimPrefix = "email:digest:claim:"
+
+// RedisClaims implements digest.Claims with SET NX.
+type RedisClaims struct {
+	pool *redis.Pool
+	lg   zerolog.Logger
+}
+
+func NewRedisClaims(pool *redis.Pool, lg zerolog.Logger) *RedisClaims {
+	return &RedisClaims{
+		pool: pool,
+		lg:   lg.With().Str("component", "digest_claims").Logger(),
+	}
+}
+
+func (c *RedisClaims) Claim(ctx context.Context, userID, week string, ttl time.Duration) (bool, error) {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	reply, err := redis.String(conn.Do("SET", keyClaimPrefix+userID+":"+week, "1", "NX", "PX", ttl.Milliseconds()))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return reply == "OK", nil
+}
+
+func (c *RedisClaims) Release(ctx context.Context, userID, week string) error {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("DEL", keyClaimPrefix+userID+":"+week)
+	return err
+}
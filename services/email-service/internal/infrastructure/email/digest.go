@@ -0,0 +1,68 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
+)
+
+// digestTmpl is the weekly digest: one card per event, cover first when
+// there is one.
+var digestTmpl = template.Must(template.New("digest").Funcs(template.FuncMap{
+	"when": digestWhen,
+}).Parse(`<!doctype html>
+<html>
+  <body style="font-family:Arial,Helvetica,sans-serif; line-height:1.4; max-width:600px;">
+    <h2>Picked for you this week</h2>
+    <p>Upcoming events we think you'll like.</p>
+    {{range .}}
+    <div style="margin:0 0 20px 0; border:1px solid #eee; border-radius:8px; overflow:hidden;">
+      {{if .CoverURL}}<a href="{{.URL}}"><img src="{{.CoverURL}}" alt="" width="600" style="display:block; width:100%; height:auto;"/></a>{{end}}
+      <div style="padding:12px 14px;">
+        <a href="{{.URL}}" style="font-size:16px; font-weight:bold; color:#111; text-decoration:none;">{{.Title}}</a>
+        <div style="color:#555; font-size:13px;">{{when .}}{{if .City}} · {{.City}}{{end}}</div>
+      </div>
+    </div>
+    {{end}}
+    <p style="color:#555; font-size:12px;">You get this email because you turned on the weekly digest.</p>
+  </body>
+</html>`))
+
+func (s *SMTPSender) SendWeeklyDigest(ctx context.Context, toEmail, unsubscribeURL, week string, items []notify.DigestItem) error {
+	subject := "Upcoming events picked for you"
+	htmlBody, err := renderDigestHTML(items)
+	if err != nil {
+		return PermanentError{msg: "render digest failed: " + err.Error()}
+	}
+	return s.send(ctx, toEmail, subject, renderDigestText(items), htmlBody, unsubscribeURL)
+}
+
+func renderDigestHTML(items []notify.DigestItem) (string, error) {
+	var b bytes.Buffer
+	if err := digestTmpl.Execute(&b, items); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func renderDigestText(items []notify.DigestItem) string {
+	var b strings.Builder
+	b.WriteString("Upcoming events we think you'll like:\n\n")
+	for _, it := range items {
+		fmt.Fprintf(&b, "%s\n%s", it.Title, digestWhen(it))
+		if it.City != "" {
+			fmt.Fprintf(&b, ", %s", it.City)
+		}
+		fmt.Fprintf(&b, "\n%s\n\n", it.URL)
+	}
+	return b.String()
+}
+
+// digestWhen shows the start in the zone the job put it in, the reader's.
+func digestWhen(item notify.DigestItem) string {
+	return item.StartTime.Format("Mon 2 Jan 15:04 MST")
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/rs/zerolog"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
 )
 
 // FakeSender is a development/testing sender.
@@ -62,6 +64,43 @@ func (s *FakeSender) SendEventUnpublished(ctx context.Context, to, eventID, reas
 	return s.maybeFail("event_unpublished")
 }
 
+func (s *FakeSender) SendEventAnnouncement(ctx context.Context, to, eventTitle, title, body string) error {
+	s.lg.Info().
+		Str("to", to).
+		Str("event_title", eventTitle).
+		Str("title", title).
+		Msg("FAKE send event announcement email")
+	return s.maybeFail("event_announcement")
+}
+
+func (s *FakeSender) SendEventChanged(ctx context.Context, to, eventTitle string, changes []string) error {
+	s.lg.Info().
+		Str("to", to).
+		Str("event_title", eventTitle).
+		Strs("changes", changes).
+		Msg("FAKE send event changed email")
+	return s.maybeFail("event_changed")
+}
+
+func (s *FakeSender) SendEventReminder(ctx context.Context, to, unsubscribeURL, eventTitle, city string, start time.Time, lead time.Duration) error {
+	s.lg.Info().
+		Str("to", to).
+		Str("event_title", eventTitle).
+		Time("start", start).
+		Dur("lead", lead).
+		Msg("FAKE send event reminder email")
+	return s.maybeFail("event_reminder")
+}
+
+func (s *FakeSender) SendWeeklyDigest(ctx context.Context, to, unsubscribeURL, week string, items []notify.DigestItem) error {
+	s.lg.Info().
+		Str("to", to).
+		Str("week", week).
+		Int("events", len(items)).
+		Msg("FAKE send weekly digest email")
+	return s.maybeFail("weekly_digest")
+}
+
 func (s *FakeSender) SendPasswordReset(ctx context.Context, toEmail, url string) error {
 	s.lg.Info().
 		Str("to", toEmail).
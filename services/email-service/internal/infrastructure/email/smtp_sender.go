@@ -56,7 +56,7 @@ func (s *SMTPSender) SendVerifyEmail(ctx context.Context, toEmail, url string) e
 		"Verify email",
 		url,
 	)
-	return s.send(ctx, toEmail, subject, text, htmlBody)
+	return s.send(ctx, toEmail, subject, text, htmlBody, "")
 }
 
 func (s *SMTPSender) SendPasswordReset(ctx context.Context, toEmail, url string) error {
@@ -68,40 +68,60 @@ func (s *SMTPSender) SendPasswordReset(ctx context.Context, toEmail, url string)
 		"Reset password",
 		url,
 	)
-	return s.send(ctx, toEmail, subject, text, htmlBody)
+	return s.send(ctx, toEmail, subject, text, htmlBody, "")
 }
 
 func (s *SMTPSender) SendEventCanceled(ctx context.Context, toEmail, eventID, reason string) error {
 	subject := "Event Canceled"
 	text := fmt.Sprintf("Your registered event (%s) has been canceled.\nReason: %s", eventID, reason)
-	return s.send(ctx, toEmail, subject, text, "")
+	return s.send(ctx, toEmail, subject, text, "", "")
 }
 
 func (s *SMTPSender) SendEventUnpublished(ctx context.Context, toEmail, eventID, reason string) error {
 	subject := "Event Unpublished"
 	text := fmt.Sprintf("Your event (%s) has been unpublished by a moderator.\nReason: %s", eventID, reason)
-	return s.send(ctx, toEmail, subject, text, "")
+	return s.send(ctx, toEmail, subject, text, "", "")
 }
 
-func (s *SMTPSender) send(ctx context.Context, to, subject, textBody, htmlBody string) error {
+func (s *SMTPSender) SendEventAnnouncement(ctx context.Context, toEmail, eventTitle, title, body string) error {
+	subject := fmt.Sprintf("%s: %s", eventTitle, title)
+	text := fmt.Sprintf("New announcement for %s\n\n%s\n\n%s", eventTitle, title, body)
+	return s.send(ctx, toEmail, subject, text, "", "")
+}
+
+func (s *SMTPSender) SendEventChanged(ctx context.Context, toEmail, eventTitle string, changes []string) error {
+	subject := fmt.Sprintf("%s has changed", eventTitle)
+	text := fmt.Sprintf("The organizer changed details of %s:\n\n%s\n", eventTitle, strings.Join(changes, "\n"))
+	return s.send(ctx, toEmail, subject, text, "", "")
+}
+
+func (s *SMTPSender) SendEventReminder(ctx context.Context, toEmail, unsubscribeURL, eventTitle, city string, start time.Time, lead time.Duration) error {
+	when := "tomorrow"
+	if lead < 24*time.Hour {
+		when = "soon"
+	}
+	subject := fmt.Sprintf("Reminder: %s starts %s", eventTitle, when)
+	where := ""
+	if city != "" {
+		where = " in " + city
+	}
+	text := fmt.Sprintf("%s starts %s%s.\n\nSee you there!\n", eventTitle, start.UTC().Format("Mon 2 Jan 2006 15:04 UTC"), where)
+	return s.send(ctx, toEmail, subject, text, "", unsubscribeURL)
+}
+
+// send delivers one message. A non-empty unsubscribeURL adds the RFC 8058
+// one-click List-Unsubscribe headers and an unsubscribe footer.
+func (s *SMTPSender) send(ctx context.Context, to, subject, textBody, htmlBody, unsubscribeURL string) error {
 	if s.timeout > 0 {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, s.timeout)
 		defer cancel()
 	}
 
-	m := mail.NewMsg()
-	if err := m.From(s.from); err != nil {
-		return PermanentError{msg: "invalid from address: " + err.Error()}
-	}
-	if err := m.To(to); err != nil {
-		return PermanentError{msg: "invalid to address: " + err.Error()}
+	m, err := s.buildMsg(to, subject, textBody, htmlBody, unsubscribeURL)
+	if err != nil {
+		return err
 	}
-	m.Subject(subject)
-
-	// Text fallback + HTML alternative
-	m.SetBodyString(mail.TypeTextPlain, textBody)
-	m.AddAlternativeString(mail.TypeTextHTML, htmlBody)
 
 	tlsPolicy := mail.TLSMandatory
 	if s.insecure {
@@ -136,6 +156,42 @@ func (s *SMTPSender) send(ctx context.Context, to, subject, textBody, htmlBody s
 	return nil
 }
 
+func (s *SMTPSender) buildMsg(to, subject, textBody, htmlBody, unsubscribeURL string) (*mail.Msg, error) {
+	m := mail.NewMsg()
+	if err := m.From(s.from); err != nil {
+		return nil, PermanentError{msg: "invalid from address: " + err.Error()}
+	}
+	if err := m.To(to); err != nil {
+		return nil, PermanentError{msg: "invalid to address: " + err.Error()}
+	}
+	m.Subject(subject)
+
+	if unsubscribeURL != "" {
+		m.SetGenHeader(mail.HeaderListUnsubscribe, "<"+unsubscribeURL+">")
+		m.SetGenHeader(mail.HeaderListUnsubscribePost, "List-Unsubscribe=One-Click")
+		textBody, htmlBody = withUnsubscribeFooter(textBody, htmlBody, unsubscribeURL)
+	}
+
+	// Text fallback + HTML alternative
+	m.SetBodyString(mail.TypeTextPlain, textBody)
+	m.AddAlternativeString(mail.TypeTextHTML, htmlBody)
+	return m, nil
+}
+
+// withUnsubscribeFooter appends the unsubscribe link to both bodies. The
+// HTML footer goes inside <body> when there is one.
+func withUnsubscribeFooter(textBody, htmlBody, unsubscribeURL string) (string, string) {
+	textBody += "\n--\nUnsubscribe: " + unsubscribeURL + "\n"
+	if htmlBody == "" {
+		return textBody, htmlBody
+	}
+	footer := fmt.Sprintf(`<p style="color:#555; font-size:12px;"><a href="%s" style="color:#555;">Unsubscribe</a></p>`, html.EscapeString(unsubscribeURL))
+	if i := strings.LastIndex(htmlBody, "</body>"); i >= 0 {
+		return textBody, htmlBody[:i] + footer + "\n" + htmlBody[i:]
+	}
+	return textBody, htmlBody + footer
+}
+
 func renderBasicHTML(title, intro, buttonText, link string) string {
 	// minimal safe escaping
 	escLink := html.EscapeString(link)
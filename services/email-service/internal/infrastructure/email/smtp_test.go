@@ -1,11 +1,15 @@
 package email
 
 import (
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
+	"github.com/wneessen/go-mail"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
 )
 
 func TestRenderBasicHTML(t *testing.T) {
@@ -46,3 +50,43 @@ func TestSMTPSender_Config(t *testing.T) {
 
 // 注意：由于 go-mail 内部 NewClient 会尝试解析主机名，
 // 真正的 send 逻辑建议使用 Integration Test (集成测试) 配合 Docker Mailpit。
+
+func TestRenderDigestHTML(t *testing.T) {
+	items := []notify.DigestItem{
+		{Title: "Jazz & Wine", City: "Sydney", StartTime: time.Date(2026, 10, 20, 8, 0, 0, 0, time.UTC), URL: "http://web/events/e1", CoverURL: "http://cdn/derived/event_cover/c1_800.jpg"},
+		{Title: "<b>Run</b>", StartTime: time.Date(2026, 10, 21, 8, 0, 0, 0, time.UTC), URL: "http://web/events/e2"},
+	}
+
+	htmlOutput, err := renderDigestHTML(items)
+
+	assert.NoError(t, err)
+	assert.Contains(t, htmlOutput, "Jazz &amp; Wine")
+	assert.Contains(t, htmlOutput, `src="http://cdn/derived/event_cover/c1_800.jpg"`)
+	assert.Contains(t, htmlOutput, "&lt;b&gt;Run&lt;/b&gt;")
+	assert.Equal(t, 1, strings.Count(htmlOutput, "<img"))
+	assert.Contains(t, renderDigestText(items), "Tue 20 Oct 08:00 UTC, Sydney\nhttp://web/events/e1")
+}
+
+func TestBuildMsg_ListUnsubscribe(t *testing.T) {
+	sender := NewSMTPSender(SMTPConfig{From: "noreply@test.com"}, zerolog.Nop())
+	link := "http://mail.test/unsubscribe?token=abc"
+
+	m, err := sender.buildMsg("a@b.com", "Reminder", "See you there!\n", "<html><body><p>Hi</p></body></html>", link)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"<" + link + ">"}, m.GetGenHeader(mail.HeaderListUnsubscribe))
+	assert.Equal(t, []string{"List-Unsubscribe=One-Click"}, m.GetGenHeader(mail.HeaderListUnsubscribePost))
+
+	m, err = sender.buildMsg("a@b.com", "Reset your password", "text", "", "")
+
+	assert.NoError(t, err)
+	assert.Empty(t, m.GetGenHeader(mail.HeaderListUnsubscribe))
+}
+
+func TestWithUnsubscribeFooter(t *testing.T) {
+	text, htmlBody := withUnsubscribeFooter("Hi\n", "<html><body><p>Hi</p></body></html>", "http://u/?a=1&b=2")
+
+	assert.Contains(t, text, "Unsubscribe: http://u/?a=1&b=2")
+	assert.Contains(t, htmlBody, `href="http://u/?a=1&amp;b=2"`)
+	assert.True(t, strings.HasSuffix(htmlBody, "</body></html>"))
+}
@@ -13,6 +13,8 @@ import (
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/rs/zerolog"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/reminder"
 )
 
 // Handler is the app-layer contract that MQ consumer calls.
@@ -21,13 +23,50 @@ type Handler interface {
 	PasswordReset(ctx context.Context, userID, email, url string) error
 	EventCanceled(ctx context.Context, eventID, userID, reason, actorRole string) error
 	EventUnpublished(ctx context.Context, eventID, userID, reason, actorRole string) error
+	EventAnnouncement(ctx context.Context, announcementID, eventID, userID, eventTitle, title, body string) error
+	EventChanged(ctx context.Context, eventID, userID, eventTitle string, version int64, changes json.RawMessage) error
+}
+
+// ReminderTracker follows events and their participants for reminder
+// emails. It is optional; without it those messages are dropped.
+type ReminderTracker interface {
+	EventScheduled(ctx context.Context, ev reminder.Event) error
+	EventEnded(ctx context.Context, eventID string) error
+	ParticipantJoined(ctx context.Context, eventID, userID string) error
+	ParticipantLeft(ctx context.Context, eventID, userID string) error
+}
+
+// ReminderBindKeys are the routing keys the reminder tracker needs.
+var ReminderBindKeys = []string{
+	"event.published", "event.updated", "event.changed", "event.canceled", "event.unpublished",
+	"join.created", "join.promoted", "join.canceled", "join.kicked", "join.banned",
+}
+
+// UserData erases or exports a user's email data for auth-service's data
+// requests.
+type UserData interface {
+	DeleteUser(ctx context.Context, userID string) error
+	ExportUser(ctx context.Context, userID string) (json.RawMessage, error)
 }
 
+// DataRequestBindKeys are the routing keys of auth-service's data requests.
+var DataRequestBindKeys = []string{rkDeletionRequested, rkExportRequested}
+
+const (
+	rkDeletionRequested = "auth.user.deletion_requested"
+	rkExportRequested   = "auth.user.export_requested"
+	rkUserDataDeleted   = "user.data.deleted"
+	rkUserDataExported  = "user.data.exported"
+
+	dataRequestService = "email-service"
+)
+
 // Publisher is the MQ publish contract used by Consumer.
 // It is an interface so unit tests can inject a fake publisher without real AMQP channels.
 type Publisher interface {
 	PublishRetry(ctx context.Context, tier string, orig amqp.Delivery, nextAttempt int, cause error) error
 	PublishFinal(ctx context.Context, orig amqp.Delivery, reason string, cause error) error
+	Publish(ctx context.Context, exchange, routingKey string, body []byte) error
 }
 
 type Config struct {
@@ -62,8 +101,10 @@ type Consumer struct {
 
 	publicBase string // http://localhost:8090
 
-	lg      zerolog.Logger
-	handler Handler
+	lg        zerolog.Logger
+	handler   Handler
+	reminders ReminderTracker // nil => reminders disabled
+	userData  UserData        // nil => data requests dropped
 
 	mu      sync.Mutex
 	running bool
@@ -78,7 +119,7 @@ type Consumer struct {
 	pub        Publisher
 }
 
-func NewConsumer(cfg Config, h Handler, lg zerolog.Logger) *Consumer {
+func NewConsumer(cfg Config, h Handler, reminders ReminderTracker, lg zerolog.Logger) *Consumer {
 	return &Consumer{
 		url:        cfg.RabbitURL,
 		exchange:   cfg.Exchange,
@@ -88,10 +129,16 @@ func NewConsumer(cfg Config, h Handler, lg zerolog.Logger) *Consumer {
 		tag:        cfg.Tag,
 		publicBase: strings.TrimRight(cfg.EmailPublicBaseURL, "/"),
 		handler:    h,
+		reminders:  reminders,
 		lg:         lg.With().Str("component", "rabbitmq_consumer").Logger(),
 	}
 }
 
+// SetUserData enables answering auth-service's data requests.
+func (c *Consumer) SetUserData(u UserData) {
+	c.userData = u
+}
+
 func (c *Consumer) Start(ctx context.Context) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -444,6 +491,11 @@ func (c *Consumer) handleDelivery(ctx context.Context, d amqp.Delivery) error {
 			return c.toFinalDLQ(ctx, d, "bad_json", err)
 		}
 		evt := env.Payload
+		if c.reminders != nil {
+			if err := c.reminders.EventEnded(ctx, evt.EventID); err != nil {
+				return c.onHandlerError(ctx, d, err)
+			}
+		}
 		if err := c.handler.EventCanceled(ctx, evt.EventID, evt.OwnerID, evt.Reason, evt.ActorRole); err != nil {
 			return c.onHandlerError(ctx, d, err)
 		}
@@ -464,11 +516,164 @@ func (c *Consumer) handleDelivery(ctx context.Context, d amqp.Delivery) error {
 			return c.toFinalDLQ(ctx, d, "bad_json", err)
 		}
 		evt := env.Payload
+		if c.reminders != nil {
+			if err := c.reminders.EventEnded(ctx, evt.EventID); err != nil {
+				return c.onHandlerError(ctx, d, err)
+			}
+		}
 		if err := c.handler.EventUnpublished(ctx, evt.EventID, evt.OwnerID, evt.Reason, evt.ActorRole); err != nil {
 			return c.onHandlerError(ctx, d, err)
 		}
 		return nil
 
+	case "email.event_announcement":
+		// Flat payload from join-service, one per active participant
+		type EventAnnouncementPayload struct {
+			AnnouncementID string `json:"announcement_id"`
+			EventID        string `json:"event_id"`
+			EventTitle     string `json:"event_title"`
+			UserID         string `json:"user_id"`
+			Title          string `json:"title"`
+			Body           string `json:"body"`
+		}
+		var evt EventAnnouncementPayload
+		if err := json.Unmarshal(d.Body, &evt); err != nil {
+			return c.toFinalDLQ(ctx, d, "bad_json", err)
+		}
+		if evt.AnnouncementID == "" || evt.UserID == "" {
+			return nil
+		}
+		if err := c.handler.EventAnnouncement(ctx, evt.AnnouncementID, evt.EventID, evt.UserID, evt.EventTitle, evt.Title, evt.Body); err != nil {
+			return c.onHandlerError(ctx, d, err)
+		}
+		return nil
+
+	case "email.event_changed":
+		// Flat payload from join-service, one per participant
+		type EventChangedPayload struct {
+			EventID      string          `json:"event_id"`
+			EventTitle   string          `json:"event_title"`
+			EventVersion int64           `json:"event_version"`
+			UserID       string          `json:"user_id"`
+			Changes      json.RawMessage `json:"changes"`
+		}
+		var evt EventChangedPayload
+		if err := json.Unmarshal(d.Body, &evt); err != nil {
+			return c.toFinalDLQ(ctx, d, "bad_json", err)
+		}
+		if evt.EventID == "" || evt.UserID == "" || len(evt.Changes) == 0 {
+			return nil
+		}
+		if err := c.handler.EventChanged(ctx, evt.EventID, evt.UserID, evt.EventTitle, evt.EventVersion, evt.Changes); err != nil {
+			return c.onHandlerError(ctx, d, err)
+		}
+		return nil
+
+	case "event.published", "event.updated":
+		if c.reminders == nil {
+			return nil
+		}
+		// Full snapshot from event-service
+		type EventSnapshotPayload struct {
+			EventID   string    `json:"event_id"`
+			Title     string    `json:"title"`
+			City      string    `json:"city"`
+			StartTime time.Time `json:"start_time"`
+		}
+		type Envelope struct {
+			Payload EventSnapshotPayload `json:"payload"`
+		}
+		var env Envelope
+		if err := json.Unmarshal(d.Body, &env); err != nil {
+			return c.toFinalDLQ(ctx, d, "bad_json", err)
+		}
+		evt := env.Payload
+		ev := reminder.Event{ID: evt.EventID, Title: evt.Title, City: evt.City, StartTime: evt.StartTime}
+		if err := c.reminders.EventScheduled(ctx, ev); err != nil {
+			return c.onHandlerError(ctx, d, err)
+		}
+		return nil
+
+	case "event.changed":
+		if c.reminders == nil {
+			return nil
+		}
+		// Material changes only; reminders care about the start and the city
+		type FieldChange struct {
+			To json.RawMessage `json:"to"`
+		}
+		type EventChangedPayload struct {
+			EventID    string                 `json:"event_id"`
+			EventTitle string                 `json:"event_title"`
+			Changes    map[string]FieldChange `json:"changes"`
+		}
+		type Envelope struct {
+			Payload EventChangedPayload `json:"payload"`
+		}
+		var env Envelope
+		if err := json.Unmarshal(d.Body, &env); err != nil {
+			return c.toFinalDLQ(ctx, d, "bad_json", err)
+		}
+		evt := env.Payload
+		ev := reminder.Event{ID: evt.EventID, Title: evt.EventTitle}
+		if ch, ok := evt.Changes["start_time"]; ok {
+			if err := json.Unmarshal(ch.To, &ev.StartTime); err != nil {
+				return c.toFinalDLQ(ctx, d, "bad_json", err)
+			}
+		}
+		if ch, ok := evt.Changes["city"]; ok {
+			_ = json.Unmarshal(ch.To, &ev.City)
+		}
+		if err := c.reminders.EventScheduled(ctx, ev); err != nil {
+			return c.onHandlerError(ctx, d, err)
+		}
+		return nil
+
+	case "join.created", "join.promoted", "join.canceled", "join.kicked", "join.banned":
+		if c.reminders == nil {
+			return nil
+		}
+		// Flat payload from join-service
+		type JoinPayload struct {
+			EventID string `json:"event_id"`
+			UserID  string `json:"user_id"`
+			Status  string `json:"status"` // join.created: "active" | "waitlisted"
+		}
+		var evt JoinPayload
+		if err := json.Unmarshal(d.Body, &evt); err != nil {
+			return c.toFinalDLQ(ctx, d, "bad_json", err)
+		}
+
+		var err error
+		switch {
+		case rk == "join.created" && evt.Status != "active":
+			return nil
+		case rk == "join.created" || rk == "join.promoted":
+			err = c.reminders.ParticipantJoined(ctx, evt.EventID, evt.UserID)
+		default:
+			err = c.reminders.ParticipantLeft(ctx, evt.EventID, evt.UserID)
+		}
+		if err != nil {
+			return c.onHandlerError(ctx, d, err)
+		}
+		return nil
+
+	case rkDeletionRequested, rkExportRequested:
+		if c.userData == nil {
+			return nil
+		}
+		var env DataRequestedMessage
+		if err := json.Unmarshal(d.Body, &env); err != nil {
+			return c.toFinalDLQ(ctx, d, "bad_json", err)
+		}
+		if env.Payload.RequestID == "" || env.Payload.UserID == "" {
+			return c.toFinalDLQ(ctx, d, "bad_payload", fmt.Errorf("missing request_id or user_id"))
+		}
+		if err := c.answerDataRequest(ctx, rk, env.Payload.RequestID, env.Payload.UserID); err != nil {
+			return c.onHandlerError(ctx, d, err)
+		}
+		return nil
+
 	default:
 		// HARDENING: Drop (Ack) unknown messages to prevent DLQ flooding (DoS risk).
 		// We do NOT log the body, only the routing key (sanitized).
@@ -483,6 +688,56 @@ func (c *Consumer) handleDelivery(ctx context.Context, d amqp.Delivery) error {
 
 // rewriteURL removed
 
+// DataRequestedMessage is auth-service's auth.user.deletion_requested /
+// auth.user.export_requested envelope.
+type DataRequestedMessage struct {
+	Payload struct {
+		RequestID string `json:"request_id"`
+		UserID    string `json:"user_id"`
+	} `json:"payload"`
+}
+
+// DataRequestReply is the user.data.deleted / user.data.exported reply.
+// Data is only set on exports.
+type DataRequestReply struct {
+	RequestID string          `json:"request_id"`
+	UserID    string          `json:"user_id"`
+	Service   string          `json:"service"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// answerDataRequest deletes or exports the user's data and replies to
+// auth-service. Replays reply again.
+func (c *Consumer) answerDataRequest(ctx context.Context, rk, requestID, userID string) error {
+	reply := DataRequestReply{RequestID: requestID, UserID: userID, Service: dataRequestService}
+	replyKey := rkUserDataDeleted
+	if rk == rkDeletionRequested {
+		if err := c.userData.DeleteUser(ctx, userID); err != nil {
+			return err
+		}
+	} else {
+		data, err := c.userData.ExportUser(ctx, userID)
+		if err != nil {
+			return err
+		}
+		reply.Data = data
+		replyKey = rkUserDataExported
+	}
+
+	body, err := json.Marshal(reply)
+	if err != nil {
+		return err
+	}
+	if c.pub == nil {
+		return fmt.Errorf("nil publisher")
+	}
+	if err := c.pub.Publish(ctx, c.exchange, replyKey, body); err != nil {
+		return fmt.Errorf("publish %s: %w", replyKey, err)
+	}
+	c.lg.Info().Str("request_id", requestID).Str("routing_key", rk).Msg("data request answered")
+	return nil
+}
+
 func (c *Consumer) onHandlerError(ctx context.Context, d amqp.Delivery, err error) error {
 	if isNonRetriable(err) {
 		return c.toFinalDLQ(ctx, d, "non_retriable", err)
@@ -9,6 +9,8 @@ import (
 
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/rs/zerolog"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/reminder"
 )
 
 type fakeHandler struct {
@@ -16,6 +18,8 @@ type fakeHandler struct {
 	resetCalled           int
 	eventCanceledCalls    int // Added for testing
 	eventUnpublishedCalls int // Added for testing
+	announcementCalls     int
+	changedCalls          int
 
 	verifyErr error
 	resetErr  error
@@ -57,6 +61,18 @@ func (h *fakeHandler) EventUnpublished(ctx context.Context, eventID, userID, rea
 	return nil
 }
 
+func (h *fakeHandler) EventAnnouncement(ctx context.Context, announcementID, eventID, userID, eventTitle, title, body string) error {
+	_ = ctx
+	h.announcementCalls++
+	return nil
+}
+
+func (h *fakeHandler) EventChanged(ctx context.Context, eventID, userID, eventTitle string, version int64, changes json.RawMessage) error {
+	_ = ctx
+	h.changedCalls++
+	return nil
+}
+
 type fakePublisher struct {
 	retryCalls []struct {
 		tier        string
@@ -67,8 +83,13 @@ type fakePublisher struct {
 		reason string
 		rk     string
 	}
-	retryErr error
-	finalErr error
+	published []struct {
+		rk   string
+		body []byte
+	}
+	retryErr   error
+	finalErr   error
+	publishErr error
 }
 
 func (p *fakePublisher) PublishRetry(ctx context.Context, tier string, orig amqp.Delivery, nextAttempt int, cause error) error {
@@ -91,6 +112,15 @@ func (p *fakePublisher) PublishFinal(ctx context.Context, orig amqp.Delivery, re
 	return p.finalErr
 }
 
+func (p *fakePublisher) Publish(ctx context.Context, exchange, routingKey string, body []byte) error {
+	_ = ctx
+	p.published = append(p.published, struct {
+		rk   string
+		body []byte
+	}{rk: routingKey, body: body})
+	return p.publishErr
+}
+
 func mustJSON(t *testing.T, v any) []byte {
 	t.Helper()
 	b, err := json.Marshal(v)
@@ -109,7 +139,7 @@ func newTestConsumer(h Handler, pub Publisher) *Consumer {
 		Prefetch:           1,
 		Tag:                "t",
 		EmailPublicBaseURL: "http://localhost:8090",
-	}, h, zerolog.Nop())
+	}, h, nil, zerolog.Nop())
 
 	// inject publisher directly (unit tests do not call connectAndDeclare)
 	c.pub = pub
@@ -192,6 +222,36 @@ func TestHandleDelivery_VerifyEmail_RewritesLinkTo8090(t *testing.T) {
 		}
 	})
 
+	t.Run("EventAnnouncement", func(t *testing.T) {
+		payload := `{"announcement_id": "a1", "event_id": "e1", "event_title": "Meetup", "user_id": "u1", "title": "Room change", "body": "4B"}`
+		d := amqp.Delivery{
+			RoutingKey: "email.event_announcement",
+			Body:       []byte(payload),
+		}
+
+		if err := c.handleDelivery(context.Background(), d); err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if h.announcementCalls != 1 {
+			t.Errorf("expected 1 call, got %d", h.announcementCalls)
+		}
+	})
+
+	t.Run("EventChanged", func(t *testing.T) {
+		payload := `{"event_id": "e1", "event_title": "Meetup", "event_version": 3, "user_id": "u1", "changes": {"city": {"from": "Sydney", "to": "Melbourne"}}}`
+		d := amqp.Delivery{
+			RoutingKey: "email.event_changed",
+			Body:       []byte(payload),
+		}
+
+		if err := c.handleDelivery(context.Background(), d); err != nil {
+			t.Errorf("expected nil error, got %v", err)
+		}
+		if h.changedCalls != 1 {
+			t.Errorf("expected 1 call, got %d", h.changedCalls)
+		}
+	})
+
 	t.Run("UnknownKey_Dropped", func(t *testing.T) {
 		// New hardening test: ensure unknown key returns nil (ack/drop) and doesn't error
 		d := amqp.Delivery{
@@ -353,3 +413,107 @@ func TestGetAttempt_SupportsTypes(t *testing.T) {
 		t.Fatalf("string expected 3 got %d", got)
 	}
 }
+
+type fakeTracker struct {
+	scheduled []reminder.Event
+	joined    []string
+	left      []string
+}
+
+func (f *fakeTracker) EventScheduled(ctx context.Context, ev reminder.Event) error {
+	f.scheduled = append(f.scheduled, ev)
+	return nil
+}
+func (f *fakeTracker) EventEnded(ctx context.Context, eventID string) error { return nil }
+func (f *fakeTracker) ParticipantJoined(ctx context.Context, eventID, userID string) error {
+	f.joined = append(f.joined, userID)
+	return nil
+}
+func (f *fakeTracker) ParticipantLeft(ctx context.Context, eventID, userID string) error {
+	f.left = append(f.left, userID)
+	return nil
+}
+
+func TestHandleDelivery_Join_TracksActiveParticipants(t *testing.T) {
+	tr := &fakeTracker{}
+	c := newTestConsumer(&fakeHandler{}, &fakePublisher{})
+	c.reminders = tr
+
+	deliveries := []amqp.Delivery{
+		{RoutingKey: "join.created", Body: []byte(`{"event_id":"e1","user_id":"u1","status":"active"}`)},
+		{RoutingKey: "join.created", Body: []byte(`{"event_id":"e1","user_id":"u2","status":"waitlisted"}`)},
+		{RoutingKey: "join.promoted", Body: []byte(`{"event_id":"e1","user_id":"u2"}`)},
+		{RoutingKey: "join.canceled", Body: []byte(`{"event_id":"e1","user_id":"u1","prev_status":"active"}`)},
+	}
+	for _, d := range deliveries {
+		if err := c.handleDelivery(context.Background(), d); err != nil {
+			t.Fatalf("%s: expected nil err, got %v", d.RoutingKey, err)
+		}
+	}
+	if len(tr.joined) != 2 || tr.joined[0] != "u1" || tr.joined[1] != "u2" {
+		t.Fatalf("unexpected joins: %v", tr.joined)
+	}
+	if len(tr.left) != 1 || tr.left[0] != "u1" {
+		t.Fatalf("unexpected leaves: %v", tr.left)
+	}
+}
+
+func TestHandleDelivery_EventChanged_ReschedulesReminders(t *testing.T) {
+	tr := &fakeTracker{}
+	c := newTestConsumer(&fakeHandler{}, &fakePublisher{})
+	c.reminders = tr
+
+	d := amqp.Delivery{
+		RoutingKey: "event.changed",
+		Body:       []byte(`{"payload":{"event_id":"e1","event_title":"Meetup","event_version":3,"changes":{"start_time":{"from":"2026-05-01T09:00:00Z","to":"2026-05-02T10:30:00Z"}}}}`),
+	}
+	if err := c.handleDelivery(context.Background(), d); err != nil {
+		t.Fatalf("expected nil err, got %v", err)
+	}
+	want := time.Date(2026, 5, 2, 10, 30, 0, 0, time.UTC)
+	if len(tr.scheduled) != 1 || !tr.scheduled[0].StartTime.Equal(want) || tr.scheduled[0].Title != "Meetup" {
+		t.Fatalf("unexpected schedule: %+v", tr.scheduled)
+	}
+}
+
+type fakeUserData struct {
+	deleted []string
+}
+
+func (f *fakeUserData) DeleteUser(ctx context.Context, userID string) error {
+	f.deleted = append(f.deleted, userID)
+	return nil
+}
+func (f *fakeUserData) ExportUser(ctx context.Context, userID string) (json.RawMessage, error) {
+	return json.RawMessage(`{"reminder_events":["e1"]}`), nil
+}
+
+func TestHandleDelivery_DataRequests_ReplyToAuthService(t *testing.T) {
+	ud := &fakeUserData{}
+	p := &fakePublisher{}
+	c := newTestConsumer(&fakeHandler{}, p)
+	c.SetUserData(ud)
+
+	body := []byte(`{"version":1,"producer":"auth-service","payload":{"request_id":"r1","user_id":"u1"}}`)
+	for _, rk := range []string{"auth.user.deletion_requested", "auth.user.export_requested"} {
+		if err := c.handleDelivery(context.Background(), amqp.Delivery{RoutingKey: rk, Body: body}); err != nil {
+			t.Fatalf("%s: expected nil err, got %v", rk, err)
+		}
+	}
+
+	if len(ud.deleted) != 1 || ud.deleted[0] != "u1" {
+		t.Fatalf("unexpected deletions: %v", ud.deleted)
+	}
+	if len(p.published) != 2 {
+		t.Fatalf("expected 2 replies, got %d", len(p.published))
+	}
+	var del, exp DataRequestReply
+	_ = json.Unmarshal(p.published[0].body, &del)
+	_ = json.Unmarshal(p.published[1].body, &exp)
+	if p.published[0].rk != "user.data.deleted" || del.RequestID != "r1" || del.Service != "email-service" || len(del.Data) != 0 {
+		t.Fatalf("unexpected deletion reply: %s %+v", p.published[0].rk, del)
+	}
+	if p.published[1].rk != "user.data.exported" || string(exp.Data) != `{"reminder_events":["e1"]}` {
+		t.Fatalf("unexpected export reply: %s %+v", p.published[1].rk, exp)
+	}
+}
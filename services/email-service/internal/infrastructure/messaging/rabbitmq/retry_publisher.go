@@ -139,6 +139,21 @@ func (p *RetryPublisher) PublishFinal(
 	return p.waitAckOrReturn(ctx, p.exDLQ, rkFinalDLQ)
 }
 
+// Publish sends a new message, e.g. a reply to another service, with the
+// same confirm+mandatory guarantees as the retry tiers.
+func (p *RetryPublisher) Publish(ctx context.Context, exchange, routingKey string, body []byte) error {
+	pub := amqp.Publishing{
+		ContentType:  "application/json",
+		Body:         body,
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now(),
+	}
+	if err := p.ch.PublishWithContext(ctx, exchange, routingKey, true, false, pub); err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+	return p.waitAckOrReturn(ctx, exchange, routingKey)
+}
+
 func (p *RetryPublisher) waitAckOrReturn(ctx context.Context, exchange, rk string) error {
 	timer := time.NewTimer(publishWait)
 	defer timer.Stop()
@@ -0,0 +1,263 @@
+package preferences
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // the runtime image has no zoneinfo
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/digest"
+)
+
+// Preferences are a user's email settings. Reminders are on until the user
+// turns them off; the weekly digest is opt-in.
+type Preferences struct {
+	Reminders bool `json:"reminders"`
+
+	Digest     bool   `json:"digest"`
+	DigestCity string `json:"digest_city"` // empty => all cities
+	DigestDay  string `json:"digest_day"`  // "monday" ... "sunday"
+	DigestHour int    `json:"digest_hour"` // 0-23, local time
+	Timezone   string `json:"timezone"`    // IANA, e.g. "Australia/Sydney"
+}
+
+// Default is what a user without stored preferences gets.
+func Default() Preferences {
+	return Preferences{
+		Reminders:  true,
+		DigestDay:  "monday",
+		DigestHour: 9,
+		Timezone:   "UTC",
+	}
+}
+
+// Validate checks the digest schedule.
+func (p Preferences) Validate() error {
+	if _, ok := parseWeekday(p.DigestDay); !ok {
+		return fmt.Errorf("invalid digest_day %q", p.DigestDay)
+	}
+	if p.DigestHour < 0 || p.DigestHour > 23 {
+		return fmt.Errorf("digest_hour must be 0-23")
+	}
+	if _, err := time.LoadLocation(p.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %q", p.Timezone)
+	}
+	return nil
+}
+
+// Keys:
+//
+//	email:prefs:<user_id>       hash  preference fields; booleans are "1" or "0"
+//	email:digest:subscribers    set   user IDs with the digest on
+const (
+	keyPrefix      = "email:prefs:"
+	keySubscribers = "email:digest:subscribers"
+)
+
+type RedisStore struct {
+	pool *redis.Pool
+	lg   zerolog.Logger
+}
+
+func NewRedisStore(pool *redis.Pool, lg zerolog.Logger) *RedisStore {
+	return &RedisStore{
+		pool: pool,
+		lg:   lg.With().Str("component", "preferences_store").Logger(),
+	}
+}
+
+func (s *RedisStore) Get(ctx context.Context, userID string) (Preferences, error) {
+	if userID == "" {
+		return Preferences{}, fmt.Errorf("empty user_id")
+	}
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return Preferences{}, err
+	}
+	defer conn.Close()
+
+	fields, err := redis.StringMap(conn.Do("HGETALL", keyPrefix+userID))
+	if err != nil {
+		return Preferences{}, err
+	}
+	return fromFields(fields), nil
+}
+
+func (s *RedisStore) Set(ctx context.Context, userID string, p Preferences) error {
+	if userID == "" {
+		return fmt.Errorf("empty user_id")
+	}
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_ = conn.Send("MULTI")
+	_ = conn.Send("HSET", keyPrefix+userID,
+		"reminders", flag(p.Reminders),
+		"digest", flag(p.Digest),
+		"digest_city", p.DigestCity,
+		"digest_day", p.DigestDay,
+		"digest_hour", p.DigestHour,
+		"timezone", p.Timezone,
+	)
+	if p.Digest {
+		_ = conn.Send("SADD", keySubscribers, userID)
+	} else {
+		_ = conn.Send("SREM", keySubscribers, userID)
+	}
+	_, err = conn.Do("EXEC")
+	return err
+}
+
+// Stored implements userdata.PreferenceStore: the saved preferences, or
+// nil if the user never changed the defaults.
+func (s *RedisStore) Stored(ctx context.Context, userID string) (any, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("empty user_id")
+	}
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	fields, err := redis.StringMap(conn.Do("HGETALL", keyPrefix+userID))
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fromFields(fields), nil
+}
+
+// Delete removes the user's preferences and digest subscription.
+func (s *RedisStore) Delete(ctx context.Context, userID string) error {
+	if userID == "" {
+		return fmt.Errorf("empty user_id")
+	}
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_ = conn.Send("MULTI")
+	_ = conn.Send("DEL", keyPrefix+userID)
+	_ = conn.Send("SREM", keySubscribers, userID)
+	_, err = conn.Do("EXEC")
+	return err
+}
+
+// RemindersEnabled implements notify.Preferences.
+func (s *RedisStore) RemindersEnabled(ctx context.Context, userID string) (bool, error) {
+	p, err := s.Get(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return p.Reminders, nil
+}
+
+// DigestEnabled implements notify.Preferences.
+func (s *RedisStore) DigestEnabled(ctx context.Context, userID string) (bool, error) {
+	p, err := s.Get(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return p.Digest, nil
+}
+
+// DigestSubscribers implements digest.Subscribers. Users whose stored
+// schedule no longer parses are skipped with a warning.
+func (s *RedisStore) DigestSubscribers(ctx context.Context) ([]digest.Subscriber, error) {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	ids, err := redis.Strings(conn.Do("SMEMBERS", keySubscribers))
+	if err != nil {
+		return nil, err
+	}
+
+	// One round trip for all hashes
+	for _, id := range ids {
+		_ = conn.Send("HGETALL", keyPrefix+id)
+	}
+	if err := conn.Flush(); err != nil {
+		return nil, err
+	}
+
+	subs := make([]digest.Subscriber, 0, len(ids))
+	for _, id := range ids {
+		fields, err := redis.StringMap(conn.Receive())
+		if err != nil {
+			return nil, err
+		}
+		p := fromFields(fields)
+		if !p.Digest {
+			continue
+		}
+		if err := p.Validate(); err != nil {
+			s.lg.Warn().Err(err).Str("user_id", id).Msg("bad digest schedule; skipping")
+			continue
+		}
+		day, _ := parseWeekday(p.DigestDay)
+		loc, _ := time.LoadLocation(p.Timezone)
+		subs = append(subs, digest.Subscriber{
+			UserID:   id,
+			City:     p.DigestCity,
+			Day:      day,
+			Hour:     p.DigestHour,
+			Location: loc,
+		})
+	}
+	return subs, nil
+}
+
+func fromFields(fields map[string]string) Preferences {
+	p := Default()
+	if v, ok := fields["reminders"]; ok {
+		p.Reminders = v == "1"
+	}
+	if v, ok := fields["digest"]; ok {
+		p.Digest = v == "1"
+	}
+	if v, ok := fields["digest_city"]; ok {
+		p.DigestCity = v
+	}
+	if v := fields["digest_day"]; v != "" {
+		p.DigestDay = v
+	}
+	if v, err := strconv.Atoi(fields["digest_hour"]); err == nil {
+		p.DigestHour = v
+	}
+	if v := fields["timezone"]; v != "" {
+		p.Timezone = v
+	}
+	return p
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if strings.EqualFold(s, d.String()) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+func flag(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
@@ -0,0 +1,286 @@
+package reminder
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/rs/zerolog"
+
+	app "github.com/baechuer/real-time-ressys/services/email-service/internal/application/reminder"
+)
+
+// Keys:
+//
+//	reminder:event:<event_id>         hash  title, city, start (unix seconds)
+//	reminder:participants:<event_id>  set   active user IDs
+//	reminder:due                      zset  "<event_id>|<lead seconds>" scored by due time (unix seconds)
+const (
+	keyEventPrefix        = "reminder:event:"
+	keyParticipantsPrefix = "reminder:participants:"
+	keyDue                = "reminder:due"
+)
+
+// claimScript leases the due members by moving their score to the lease
+// end, atomically, so two schedulers never claim the same reminder.
+var claimScript = redis.NewScript(1, `
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, tonumber(ARGV[3]))
+for _, m in ipairs(due) do
+  redis.call('ZADD', KEYS[1], ARGV[2], m)
+end
+return due
+`)
+
+// completeScript removes a member only while it still has the lease score;
+// a reschedule in the meantime wins.
+var completeScript = redis.NewScript(1, `
+if redis.call('ZSCORE', KEYS[1], ARGV[1]) == ARGV[2] then
+  return redis.call('ZREM', KEYS[1], ARGV[1])
+end
+return 0
+`)
+
+type RedisStore struct {
+	pool *redis.Pool
+	lg   zerolog.Logger
+}
+
+func NewRedisStore(pool *redis.Pool, lg zerolog.Logger) *RedisStore {
+	return &RedisStore{
+		pool: pool,
+		lg:   lg.With().Str("component", "reminder_store").Logger(),
+	}
+}
+
+func (s *RedisStore) GetEvent(ctx context.Context, eventID string) (*app.Event, error) {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	fields, err := redis.StringMap(conn.Do("HGETALL", keyEventPrefix+eventID))
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	ev := &app.Event{ID: eventID, Title: fields["title"], City: fields["city"]}
+	if v := fields["start"]; v != "" {
+		secs, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("bad start for %s: %w", eventID, err)
+		}
+		ev.StartTime = time.Unix(secs, 0).UTC()
+	}
+	return ev, nil
+}
+
+func (s *RedisStore) SaveEvent(ctx context.Context, ev app.Event, expireAt time.Time) error {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	key := keyEventPrefix + ev.ID
+	args := redis.Args{key, "title", ev.Title, "city", ev.City}
+	if !ev.StartTime.IsZero() {
+		args = args.Add("start", ev.StartTime.Unix())
+	}
+
+	_ = conn.Send("MULTI")
+	_ = conn.Send("HSET", args...)
+	_ = conn.Send("EXPIREAT", key, expireAt.Unix())
+	// Keeps participants as long as the event (no-op until someone joins)
+	_ = conn.Send("EXPIREAT", keyParticipantsPrefix+ev.ID, expireAt.Unix())
+	_, err = conn.Do("EXEC")
+	return err
+}
+
+func (s *RedisStore) DeleteEvent(ctx context.Context, eventID string) error {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	args := redis.Args{keyDue}
+	for _, lead := range app.Leads {
+		args = args.Add(dueMember(eventID, lead))
+	}
+
+	_ = conn.Send("MULTI")
+	_ = conn.Send("DEL", keyEventPrefix+eventID, keyParticipantsPrefix+eventID)
+	_ = conn.Send("ZREM", args...)
+	_, err = conn.Do("EXEC")
+	return err
+}
+
+func (s *RedisStore) AddParticipant(ctx context.Context, eventID, userID string, expireAt time.Time) error {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	key := keyParticipantsPrefix + eventID
+	_ = conn.Send("MULTI")
+	_ = conn.Send("SADD", key, userID)
+	_ = conn.Send("EXPIREAT", key, expireAt.Unix())
+	_, err = conn.Do("EXEC")
+	return err
+}
+
+func (s *RedisStore) RemoveParticipant(ctx context.Context, eventID, userID string) error {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("SREM", keyParticipantsPrefix+eventID, userID)
+	return err
+}
+
+func (s *RedisStore) Participants(ctx context.Context, eventID string) ([]string, error) {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return redis.Strings(conn.Do("SMEMBERS", keyParticipantsPrefix+eventID))
+}
+
+// ParticipantEvents returns the events whose reminders include userID.
+// It scans every participant set, which is fine for the rare data
+// request that needs it.
+func (s *RedisStore) ParticipantEvents(ctx context.Context, userID string) ([]string, error) {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var keys []string
+	cursor := "0"
+	for {
+		reply, err := redis.Values(conn.Do("SCAN", cursor, "MATCH", keyParticipantsPrefix+"*", "COUNT", 500))
+		if err != nil {
+			return nil, err
+		}
+		if cursor, err = redis.String(reply[0], nil); err != nil {
+			return nil, err
+		}
+		batch, err := redis.Strings(reply[1], nil)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, batch...)
+		if cursor == "0" {
+			break
+		}
+	}
+
+	for _, key := range keys {
+		_ = conn.Send("SISMEMBER", key, userID)
+	}
+	if err := conn.Flush(); err != nil {
+		return nil, err
+	}
+	events := []string{}
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		member, err := redis.Bool(conn.Receive())
+		if err != nil {
+			return nil, err
+		}
+		// SCAN may return a key more than once
+		if member && !seen[key] {
+			seen[key] = true
+			events = append(events, strings.TrimPrefix(key, keyParticipantsPrefix))
+		}
+	}
+	return events, nil
+}
+
+func (s *RedisStore) Schedule(ctx context.Context, eventID string, lead time.Duration, at time.Time) error {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("ZADD", keyDue, at.Unix(), dueMember(eventID, lead))
+	return err
+}
+
+func (s *RedisStore) Unschedule(ctx context.Context, eventID string, lead time.Duration) error {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("ZREM", keyDue, dueMember(eventID, lead))
+	return err
+}
+
+func (s *RedisStore) ClaimDue(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]app.Claim, error) {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	leaseUntil := now.Add(lease).Truncate(time.Second)
+	members, err := redis.Strings(claimScript.Do(conn, keyDue, now.Unix(), leaseUntil.Unix(), limit))
+	if err != nil {
+		return nil, err
+	}
+
+	claims := make([]app.Claim, 0, len(members))
+	for _, m := range members {
+		eventID, lead, ok := parseDueMember(m)
+		if !ok {
+			s.lg.Warn().Str("member", m).Msg("malformed due reminder; removing")
+			_, _ = conn.Do("ZREM", keyDue, m)
+			continue
+		}
+		claims = append(claims, app.Claim{EventID: eventID, Lead: lead, LeaseUntil: leaseUntil})
+	}
+	return claims, nil
+}
+
+func (s *RedisStore) Complete(ctx context.Context, c app.Claim) error {
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = completeScript.Do(conn, keyDue, dueMember(c.EventID, c.Lead), strconv.FormatInt(c.LeaseUntil.Unix(), 10))
+	return err
+}
+
+func dueMember(eventID string, lead time.Duration) string {
+	return eventID + "|" + strconv.FormatInt(int64(lead/time.Second), 10)
+}
+
+func parseDueMember(m string) (string, time.Duration, bool) {
+	eventID, secs, ok := strings.Cut(m, "|")
+	if !ok || eventID == "" {
+		return "", 0, false
+	}
+	n, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil || n <= 0 {
+		return "", 0, false
+	}
+	return eventID, time.Duration(n) * time.Second, true
+}
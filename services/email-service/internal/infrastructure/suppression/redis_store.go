@@ -0,0 +1,138 @@
+package suppression
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
+)
+
+// Entry is why an address stopped getting mail.
+type Entry struct {
+	Email  string    `json:"email"`
+	Reason string    `json:"reason"` // notify.ReasonHardBounce or notify.ReasonComplaint
+	Detail string    `json:"detail,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// Keys:
+//
+//	email:suppression:<lowercased email>   hash  reason, detail, at (unix seconds); no TTL
+const keyPrefix = "email:suppression:"
+
+type RedisStore struct {
+	pool *redis.Pool
+	lg   zerolog.Logger
+}
+
+func NewRedisStore(pool *redis.Pool, lg zerolog.Logger) *RedisStore {
+	return &RedisStore{
+		pool: pool,
+		lg:   lg.With().Str("component", "suppression_store").Logger(),
+	}
+}
+
+// Get returns the entry for email; ok is false when it is not suppressed.
+func (s *RedisStore) Get(ctx context.Context, email string) (Entry, bool, error) {
+	addr := normalize(email)
+	if addr == "" {
+		return Entry{}, false, fmt.Errorf("empty email")
+	}
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return Entry{}, false, err
+	}
+	defer conn.Close()
+
+	fields, err := redis.StringMap(conn.Do("HGETALL", keyPrefix+addr))
+	if err != nil {
+		return Entry{}, false, err
+	}
+	if fields["reason"] == "" {
+		return Entry{}, false, nil
+	}
+	e := Entry{Email: addr, Reason: fields["reason"], Detail: fields["detail"]}
+	if sec, err := strconv.ParseInt(fields["at"], 10, 64); err == nil {
+		e.At = time.Unix(sec, 0).UTC()
+	}
+	return e, true, nil
+}
+
+// Entry implements userdata.SuppressionStore.
+func (s *RedisStore) Entry(ctx context.Context, email string) (any, error) {
+	e, ok, err := s.Get(ctx, email)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Suppression implements notify.Suppressions.
+func (s *RedisStore) Suppression(ctx context.Context, email string) (string, error) {
+	e, ok, err := s.Get(ctx, email)
+	if err != nil || !ok {
+		return "", err
+	}
+	return e.Reason, nil
+}
+
+// Suppress records reason for email. A hard bounce is never downgraded to
+// a complaint, since the address still cannot take any mail.
+func (s *RedisStore) Suppress(ctx context.Context, email, reason, detail string) error {
+	addr := normalize(email)
+	if addr == "" {
+		return fmt.Errorf("empty email")
+	}
+	if reason != notify.ReasonHardBounce && reason != notify.ReasonComplaint {
+		return fmt.Errorf("unknown suppression reason %q", reason)
+	}
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	key := keyPrefix + addr
+	if reason == notify.ReasonComplaint {
+		cur, err := redis.String(conn.Do("HGET", key, "reason"))
+		if err != nil && err != redis.ErrNil {
+			return err
+		}
+		if cur == notify.ReasonHardBounce {
+			return nil
+		}
+	}
+	_, err = conn.Do("HSET", key,
+		"reason", reason,
+		"detail", detail,
+		"at", time.Now().Unix(),
+	)
+	return err
+}
+
+// Remove lifts the suppression, e.g. after the user fixed their mailbox.
+// It reports whether there was one.
+func (s *RedisStore) Remove(ctx context.Context, email string) (bool, error) {
+	addr := normalize(email)
+	if addr == "" {
+		return false, fmt.Errorf("empty email")
+	}
+	conn, err := s.pool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	n, err := redis.Int(conn.Do("DEL", keyPrefix+addr))
+	return n > 0, err
+}
+
+func normalize(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,37 @@
+package suppression
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/rafaeljusto/redigomock/v3"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSuppress_ComplaintDoesNotDowngradeHardBounce(t *testing.T) {
+	mockConn := redigomock.NewConn()
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return mockConn, nil
+		},
+	}
+	store := NewRedisStore(pool, zerolog.Nop())
+
+	mockConn.Command("HGET", "email:suppression:bob@example.com", "reason").Expect([]byte("hard_bounce"))
+	hset := mockConn.GenericCommand("HSET")
+
+	err := store.Suppress(context.Background(), " Bob@Example.com ", "complaint", "fbl")
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, mockConn.Stats(hset))
+}
+
+func TestSuppress_RejectsUnknownReason(t *testing.T) {
+	store := NewRedisStore(&redis.Pool{}, zerolog.Nop())
+
+	err := store.Suppress(context.Background(), "bob@example.com", "soft_bounce", "")
+
+	assert.Error(t, err)
+}
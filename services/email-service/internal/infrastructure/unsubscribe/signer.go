@@ -0,0 +1,74 @@
+package unsubscribe
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
+)
+
+var ErrInvalidToken = errors.New("invalid unsubscribe token")
+
+// Signer issues and checks the tokens in unsubscribe links. A token is
+// base64url("<user_id>|<category>|<issued unix>") + "." + base64url(HMAC).
+// Tokens do not expire: an old email's link must keep working.
+type Signer struct {
+	secret  []byte
+	baseURL string
+	now     func() time.Time
+}
+
+// NewSigner signs with secret; links point at <baseURL>/unsubscribe.
+func NewSigner(secret, baseURL string) *Signer {
+	return &Signer{
+		secret:  []byte(secret),
+		baseURL: strings.TrimRight(baseURL, "/"),
+		now:     time.Now,
+	}
+}
+
+func (s *Signer) Sign(userID string, c notify.Category) string {
+	payload := userID + "|" + string(c) + "|" + strconv.FormatInt(s.now().Unix(), 10)
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(payload)) + "." + enc.EncodeToString(s.mac(payload))
+}
+
+// Verify returns the user and category a token was issued for.
+func (s *Signer) Verify(token string) (string, notify.Category, error) {
+	enc := base64.RawURLEncoding
+	p, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", "", ErrInvalidToken
+	}
+	payload, err := enc.DecodeString(p)
+	if err != nil {
+		return "", "", ErrInvalidToken
+	}
+	got, err := enc.DecodeString(sig)
+	if err != nil || !hmac.Equal(got, s.mac(string(payload))) {
+		return "", "", ErrInvalidToken
+	}
+
+	parts := strings.Split(string(payload), "|")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", "", ErrInvalidToken
+	}
+	return parts[0], notify.Category(parts[1]), nil
+}
+
+// URL implements notify.UnsubscribeLinks.
+func (s *Signer) URL(userID string, c notify.Category) string {
+	return s.baseURL + "/unsubscribe?token=" + url.QueryEscape(s.Sign(userID, c))
+}
+
+func (s *Signer) mac(payload string) []byte {
+	h := hmac.New(sha256.New, s.secret)
+	h.Write([]byte(payload))
+	return h.Sum(nil)
+}
@@ -0,0 +1,49 @@
+package unsubscribe
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
+)
+
+func TestSigner_RoundTrip(t *testing.T) {
+	s := NewSigner("secret", "http://mail.test/")
+
+	userID, c, err := s.Verify(s.Sign("u1", notify.CategoryDigest))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", userID)
+	assert.Equal(t, notify.CategoryDigest, c)
+}
+
+func TestSigner_RejectsTampering(t *testing.T) {
+	s := NewSigner("secret", "http://mail.test")
+	token := s.Sign("u1", notify.CategoryReminders)
+
+	_, _, err := NewSigner("other", "http://mail.test").Verify(token)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	p, sig, _ := strings.Cut(token, ".")
+	forged := strings.Replace(p, p[:4], "AAAA", 1) + "." + sig
+	_, _, err = s.Verify(forged)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, _, err = s.Verify("garbage")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestSigner_URL(t *testing.T) {
+	s := NewSigner("secret", "http://mail.test/")
+
+	u, err := url.Parse(s.URL("u1", notify.CategoryReminders))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/unsubscribe", u.Path)
+	userID, _, err := s.Verify(u.Query().Get("token"))
+	assert.NoError(t, err)
+	assert.Equal(t, "u1", userID)
+}
@@ -0,0 +1,114 @@
+package web
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/bounce"
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/suppression"
+)
+
+// SuppressionStore keeps addresses that must not get mail.
+type SuppressionStore interface {
+	Get(ctx context.Context, email string) (suppression.Entry, bool, error)
+	Suppress(ctx context.Context, email, reason, detail string) error
+	Remove(ctx context.Context, email string) (bool, error)
+}
+
+const maxBounceBody = 1 << 20
+
+// requireWebhook only lets through callers with X-Webhook-Secret.
+func (s *Server) requireWebhook(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := r.Header.Get("X-Webhook-Secret")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(s.bounceSecret)) != 1 {
+			writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// handleBounces ingests a DSN or provider webhook. Hard bounces and
+// complaints are suppressed; soft bounces are only logged, the address may
+// work again tomorrow.
+func (s *Server) handleBounces(w http.ResponseWriter, r *http.Request) {
+	body := http.MaxBytesReader(w, r.Body, maxBounceBody)
+	reports, err := bounce.Parse(r.Header.Get("Content-Type"), body)
+	if errors.Is(err, bounce.ErrUnsupported) {
+		writeJSONError(w, http.StatusUnsupportedMediaType, err.Error())
+		return
+	}
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	suppressed := 0
+	for _, rep := range reports {
+		var reason string
+		switch rep.Kind {
+		case bounce.KindHardBounce:
+			reason = notify.ReasonHardBounce
+		case bounce.KindComplaint:
+			reason = notify.ReasonComplaint
+		default:
+			s.lg.Info().Str("email", rep.Email).Str("status", rep.Status).Msg("soft bounce; not suppressing")
+			continue
+		}
+
+		detail := rep.Status
+		if rep.Detail != "" {
+			detail += " " + rep.Detail
+		}
+		// Fail the whole request so the provider redelivers it; Suppress is
+		// idempotent.
+		if err := s.supp.Suppress(r.Context(), rep.Email, reason, detail); err != nil {
+			s.lg.Error().Err(err).Str("email", rep.Email).Msg("suppress failed")
+			writeJSONError(w, http.StatusInternalServerError, "failed to store suppression")
+			return
+		}
+		s.lg.Warn().Str("email", rep.Email).Str("reason", reason).Str("status", rep.Status).Msg("address suppressed")
+		suppressed++
+	}
+
+	writeJSON(w, http.StatusOK, map[string]int{
+		"received":   len(reports),
+		"suppressed": suppressed,
+	})
+}
+
+func (s *Server) handleGetSuppression(w http.ResponseWriter, r *http.Request) {
+	email := r.PathValue("email")
+	e, ok, err := s.supp.Get(r.Context(), email)
+	if err != nil {
+		s.lg.Error().Err(err).Msg("load suppression failed")
+		writeJSONError(w, http.StatusInternalServerError, "failed to load suppression")
+		return
+	}
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, "not suppressed")
+		return
+	}
+	writeJSON(w, http.StatusOK, e)
+}
+
+// handleDeleteSuppression lets support restore mail to a fixed address.
+func (s *Server) handleDeleteSuppression(w http.ResponseWriter, r *http.Request) {
+	email := r.PathValue("email")
+	ok, err := s.supp.Remove(r.Context(), email)
+	if err != nil {
+		s.lg.Error().Err(err).Msg("remove suppression failed")
+		writeJSONError(w, http.StatusInternalServerError, "failed to remove suppression")
+		return
+	}
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, "not suppressed")
+		return
+	}
+	s.lg.Info().Str("email", email).Msg("suppression removed")
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,111 @@
+package web
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/preferences"
+)
+
+// PreferenceStore reads and writes users' email settings.
+type PreferenceStore interface {
+	Get(ctx context.Context, userID string) (preferences.Preferences, error)
+	Set(ctx context.Context, userID string, p preferences.Preferences) error
+}
+
+// preferencesReq is a partial update; omitted fields keep their value.
+type preferencesReq struct {
+	Reminders  *bool   `json:"reminders"`
+	Digest     *bool   `json:"digest"`
+	DigestCity *string `json:"digest_city"`
+	DigestDay  *string `json:"digest_day"`
+	DigestHour *int    `json:"digest_hour"`
+	Timezone   *string `json:"timezone"`
+}
+
+func (req preferencesReq) apply(p *preferences.Preferences) {
+	if req.Reminders != nil {
+		p.Reminders = *req.Reminders
+	}
+	if req.Digest != nil {
+		p.Digest = *req.Digest
+	}
+	if req.DigestCity != nil {
+		p.DigestCity = strings.TrimSpace(*req.DigestCity)
+	}
+	if req.DigestDay != nil {
+		p.DigestDay = strings.ToLower(strings.TrimSpace(*req.DigestDay))
+	}
+	if req.DigestHour != nil {
+		p.DigestHour = *req.DigestHour
+	}
+	if req.Timezone != nil {
+		p.Timezone = strings.TrimSpace(*req.Timezone)
+	}
+}
+
+// requireInternal only lets through callers with X-Internal-Secret.
+func (s *Server) requireInternal(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		got := r.Header.Get("X-Internal-Secret")
+		if s.internalSecret == "" || subtle.ConstantTimeCompare([]byte(got), []byte(s.internalSecret)) != 1 {
+			writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleGetPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("id")
+	p, err := s.prefs.Get(r.Context(), userID)
+	if err != nil {
+		s.lg.Error().Err(err).Str("user_id", userID).Msg("load preferences failed")
+		writeJSONError(w, http.StatusInternalServerError, "failed to load preferences")
+		return
+	}
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) handlePutPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := r.PathValue("id")
+
+	var req preferencesReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "bad json")
+		return
+	}
+
+	p, err := s.prefs.Get(r.Context(), userID)
+	if err != nil {
+		s.lg.Error().Err(err).Str("user_id", userID).Msg("load preferences failed")
+		writeJSONError(w, http.StatusInternalServerError, "failed to load preferences")
+		return
+	}
+	req.apply(&p)
+	if err := p.Validate(); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := s.prefs.Set(r.Context(), userID, p); err != nil {
+		s.lg.Error().Err(err).Str("user_id", userID).Msg("save preferences failed")
+		writeJSONError(w, http.StatusInternalServerError, "failed to save preferences")
+		return
+	}
+
+	s.lg.Info().Str("user_id", userID).Bool("reminders", p.Reminders).Bool("digest", p.Digest).Msg("email preferences updated")
+	writeJSON(w, http.StatusOK, p)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,89 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/preferences"
+)
+
+type memPrefs map[string]preferences.Preferences
+
+func (m memPrefs) Get(ctx context.Context, userID string) (preferences.Preferences, error) {
+	if p, ok := m[userID]; ok {
+		return p, nil
+	}
+	return preferences.Default(), nil
+}
+
+func (m memPrefs) Set(ctx context.Context, userID string, p preferences.Preferences) error {
+	m[userID] = p
+	return nil
+}
+
+func newTestPrefsWeb(store memPrefs) http.Handler {
+	s := NewServer(Config{
+		Addr:           ":0",
+		InternalSecret: "s3cret",
+		Preferences:    store,
+	}, zerolog.Nop())
+	return s.srv.Handler
+}
+
+func TestPreferences_RequiresInternalSecret(t *testing.T) {
+	h := newTestPrefsWeb(memPrefs{})
+
+	req := httptest.NewRequest("GET", "http://email.local/internal/users/u1/email-preferences", nil)
+	w := do(h, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestPreferences_PutThenGet(t *testing.T) {
+	store := memPrefs{}
+	h := newTestPrefsWeb(store)
+
+	req := httptest.NewRequest("GET", "http://email.local/internal/users/u1/email-preferences", nil)
+	req.Header.Set("X-Internal-Secret", "s3cret")
+	w := do(h, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"reminders":true`) {
+		t.Fatalf("expected defaults, got %d %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest("PUT", "http://email.local/internal/users/u1/email-preferences", strings.NewReader(`{"reminders":false}`))
+	req.Header.Set("X-Internal-Secret", "s3cret")
+	w = do(h, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", w.Code, w.Body.String())
+	}
+	if store["u1"].Reminders {
+		t.Fatalf("expected reminders opted out")
+	}
+}
+
+func TestPreferences_DigestScheduleValidated(t *testing.T) {
+	store := memPrefs{}
+	h := newTestPrefsWeb(store)
+
+	req := httptest.NewRequest("PUT", "http://email.local/internal/users/u1/email-preferences", strings.NewReader(`{"digest":true,"timezone":"Mars/Olympus"}`))
+	req.Header.Set("X-Internal-Secret", "s3cret")
+	if w := do(h, req); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest("PUT", "http://email.local/internal/users/u1/email-preferences", strings.NewReader(`{"digest":true,"digest_city":"Sydney","digest_day":"Friday","digest_hour":18,"timezone":"Australia/Sydney"}`))
+	req.Header.Set("X-Internal-Secret", "s3cret")
+	if w := do(h, req); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", w.Code, w.Body.String())
+	}
+	p := store["u1"]
+	if !p.Digest || p.DigestDay != "friday" || p.DigestHour != 18 || !p.Reminders {
+		t.Fatalf("unexpected preferences %+v", p)
+	}
+}
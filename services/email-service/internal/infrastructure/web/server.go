@@ -25,6 +25,13 @@ type Server struct {
 	client   *http.Client
 
 	rl *middleware.RedisRateLimiter
+
+	internalSecret string
+	prefs          PreferenceStore
+
+	unsub        UnsubscribeVerifier
+	supp         SuppressionStore
+	bounceSecret string
 }
 
 type RateLimitConfig struct {
@@ -43,6 +50,17 @@ type Config struct {
 	RedisPool *redis.Pool
 
 	RateLimit RateLimitConfig
+
+	// InternalSecret guards /internal/*; Preferences nil disables those routes.
+	InternalSecret string
+	Preferences    PreferenceStore
+
+	// Unsubscribe nil (or Preferences nil) disables /unsubscribe.
+	Unsubscribe UnsubscribeVerifier
+	// Suppressions nil disables the suppression routes; an empty
+	// BounceWebhookSecret disables /webhooks/bounces.
+	Suppressions        SuppressionStore
+	BounceWebhookSecret string
 }
 
 func NewServer(cfg Config, lg zerolog.Logger) *Server {
@@ -55,6 +73,11 @@ func NewServer(cfg Config, lg zerolog.Logger) *Server {
 		client: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		internalSecret: cfg.InternalSecret,
+		prefs:          cfg.Preferences,
+		unsub:          cfg.Unsubscribe,
+		supp:           cfg.Suppressions,
+		bounceSecret:   cfg.BounceWebhookSecret,
 	}
 
 	// rate limiter (optional)
@@ -91,6 +114,10 @@ func NewServer(cfg Config, lg zerolog.Logger) *Server {
 	// pages
 	mux.HandleFunc("/verify", s.handleVerifyPage)
 	mux.HandleFunc("/reset", s.handleResetPage)
+	if s.unsub != nil && s.prefs != nil {
+		mux.HandleFunc("GET /unsubscribe", s.handleUnsubscribePage)
+		mux.HandleFunc("POST /unsubscribe", s.handleUnsubscribe)
+	}
 
 	// APIs with RL wrappers
 	if s.rl != nil {
@@ -103,6 +130,19 @@ func NewServer(cfg Config, lg zerolog.Logger) *Server {
 		mux.HandleFunc("/api/reset/confirm", s.handleAPIResetConfirm)
 	}
 
+	// internal APIs (BFF)
+	if s.prefs != nil {
+		mux.HandleFunc("GET /internal/users/{id}/email-preferences", s.requireInternal(s.handleGetPreferences))
+		mux.HandleFunc("PUT /internal/users/{id}/email-preferences", s.requireInternal(s.handlePutPreferences))
+	}
+	if s.supp != nil {
+		mux.HandleFunc("GET /internal/suppressions/{email}", s.requireInternal(s.handleGetSuppression))
+		mux.HandleFunc("DELETE /internal/suppressions/{email}", s.requireInternal(s.handleDeleteSuppression))
+		if s.bounceSecret != "" {
+			mux.HandleFunc("POST /webhooks/bounces", s.requireWebhook(s.handleBounces))
+		}
+	}
+
 	s.srv = &http.Server{Addr: s.addr, Handler: mux}
 	return s
 }
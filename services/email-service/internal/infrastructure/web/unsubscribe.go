@@ -0,0 +1,99 @@
+package web
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
+)
+
+// UnsubscribeVerifier checks tokens from unsubscribe links.
+type UnsubscribeVerifier interface {
+	Verify(token string) (userID string, c notify.Category, err error)
+}
+
+var errNotUnsubscribable = errors.New("this kind of email cannot be turned off")
+
+var categoryNames = map[notify.Category]string{
+	notify.CategoryReminders: "event reminders",
+	notify.CategoryDigest:    "the weekly digest",
+}
+
+// handleUnsubscribePage asks for confirmation. It must not change anything:
+// link scanners and prefetchers follow GET links in emails.
+func (s *Server) handleUnsubscribePage(w http.ResponseWriter, r *http.Request) {
+	token := tokenFromQuery(r)
+	_, c, err := s.checkUnsubscribe(token)
+	if err != nil {
+		s.writeUnsubscribeError(w, err)
+		return
+	}
+
+	action := template.HTMLEscapeString("/unsubscribe?token=" + url.QueryEscape(token))
+	writeHTML(w, `<!doctype html><html><body>
+<h3>Unsubscribe from `+categoryNames[c]+`?</h3>
+<p>You will still get account emails and notices about events you joined.</p>
+<form method="POST" action="`+action+`">
+  <button type="submit">Unsubscribe</button>
+</form>
+</body></html>`)
+}
+
+// handleUnsubscribe turns the category off. Mail clients POST here directly
+// for RFC 8058 one-click unsubscribe, so it needs no session or CSRF token:
+// the signed token is the authorization.
+func (s *Server) handleUnsubscribe(w http.ResponseWriter, r *http.Request) {
+	userID, c, err := s.checkUnsubscribe(tokenFromQuery(r))
+	if err != nil {
+		s.writeUnsubscribeError(w, err)
+		return
+	}
+
+	p, err := s.prefs.Get(r.Context(), userID)
+	if err != nil {
+		s.lg.Error().Err(err).Str("user_id", userID).Msg("load preferences failed")
+		http.Error(w, "failed to unsubscribe, please try again", http.StatusInternalServerError)
+		return
+	}
+	switch c {
+	case notify.CategoryReminders:
+		p.Reminders = false
+	case notify.CategoryDigest:
+		p.Digest = false
+	}
+	if err := s.prefs.Set(r.Context(), userID, p); err != nil {
+		s.lg.Error().Err(err).Str("user_id", userID).Msg("save preferences failed")
+		http.Error(w, "failed to unsubscribe, please try again", http.StatusInternalServerError)
+		return
+	}
+
+	s.lg.Info().Str("user_id", userID).Str("category", string(c)).Msg("unsubscribed")
+	writeHTML(w, `<!doctype html><html><body>
+<h3>You're unsubscribed from `+categoryNames[c]+`.</h3>
+<p>You can turn it back on in your email settings.</p>
+</body></html>`)
+}
+
+func (s *Server) checkUnsubscribe(token string) (string, notify.Category, error) {
+	if token == "" {
+		return "", "", errors.New("missing token")
+	}
+	userID, c, err := s.unsub.Verify(token)
+	if err != nil {
+		return "", "", err
+	}
+	if _, ok := categoryNames[c]; !ok {
+		return "", "", errNotUnsubscribable
+	}
+	return userID, c, nil
+}
+
+func (s *Server) writeUnsubscribeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errNotUnsubscribable) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	http.Error(w, "invalid unsubscribe link", http.StatusBadRequest)
+}
@@ -0,0 +1,128 @@
+package web
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/application/notify"
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/suppression"
+	"github.com/baechuer/real-time-ressys/services/email-service/internal/infrastructure/unsubscribe"
+)
+
+type memSuppressions map[string]suppression.Entry
+
+func (m memSuppressions) Get(ctx context.Context, email string) (suppression.Entry, bool, error) {
+	e, ok := m[email]
+	return e, ok, nil
+}
+
+func (m memSuppressions) Suppress(ctx context.Context, email, reason, detail string) error {
+	m[email] = suppression.Entry{Email: email, Reason: reason, Detail: detail}
+	return nil
+}
+
+func (m memSuppressions) Remove(ctx context.Context, email string) (bool, error) {
+	_, ok := m[email]
+	delete(m, email)
+	return ok, nil
+}
+
+func newTestUnsubscribeWeb(prefs memPrefs, supp memSuppressions, signer *unsubscribe.Signer) http.Handler {
+	s := NewServer(Config{
+		Addr:                ":0",
+		InternalSecret:      "s3cret",
+		Preferences:         prefs,
+		Unsubscribe:         signer,
+		Suppressions:        supp,
+		BounceWebhookSecret: "hook",
+	}, zerolog.Nop())
+	return s.srv.Handler
+}
+
+func TestUnsubscribe_GetConfirmsOnly_PostTurnsCategoryOff(t *testing.T) {
+	prefs := memPrefs{}
+	signer := unsubscribe.NewSigner("k", "http://email.local")
+	h := newTestUnsubscribeWeb(prefs, memSuppressions{}, signer)
+	target := signer.URL("u1", notify.CategoryDigest)
+
+	w := do(h, httptest.NewRequest("GET", target, nil))
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `method="POST"`) {
+		t.Fatalf("expected confirmation page, got %d %s", w.Code, w.Body.String())
+	}
+	if _, ok := prefs["u1"]; ok {
+		t.Fatalf("GET must not change preferences")
+	}
+
+	// RFC 8058 one-click POST
+	req := httptest.NewRequest("POST", target, strings.NewReader("List-Unsubscribe=One-Click"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = do(h, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", w.Code, w.Body.String())
+	}
+	if p := prefs["u1"]; p.Digest || !p.Reminders {
+		t.Fatalf("expected only the digest off, got %+v", p)
+	}
+}
+
+func TestUnsubscribe_RejectsBadAndTransactionalTokens(t *testing.T) {
+	signer := unsubscribe.NewSigner("k", "http://email.local")
+	h := newTestUnsubscribeWeb(memPrefs{}, memSuppressions{}, signer)
+
+	w := do(h, httptest.NewRequest("POST", "http://email.local/unsubscribe?token=nope", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a bad token, got %d", w.Code)
+	}
+
+	w = do(h, httptest.NewRequest("POST", signer.URL("u1", notify.CategoryTransactional), nil))
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for transactional, got %d", w.Code)
+	}
+}
+
+func TestBounces_SuppressesHardBouncesAndComplaints(t *testing.T) {
+	supp := memSuppressions{}
+	h := newTestUnsubscribeWeb(memPrefs{}, supp, unsubscribe.NewSigner("k", "http://email.local"))
+	body := `{"events":[
+		{"email":"gone@example.com","type":"bounce","bounce_type":"hard","status":"5.1.1"},
+		{"email":"full@example.com","type":"bounce","bounce_type":"soft"},
+		{"email":"angry@example.com","type":"complaint"}
+	]}`
+
+	req := httptest.NewRequest("POST", "http://email.local/webhooks/bounces", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := do(h, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without secret, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest("POST", "http://email.local/webhooks/bounces", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Secret", "hook")
+	w = do(h, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d %s", w.Code, w.Body.String())
+	}
+	if supp["gone@example.com"].Reason != notify.ReasonHardBounce || supp["angry@example.com"].Reason != notify.ReasonComplaint {
+		t.Fatalf("unexpected suppressions %+v", supp)
+	}
+	if _, ok := supp["full@example.com"]; ok {
+		t.Fatalf("soft bounce must not be suppressed")
+	}
+
+	// Support lifts it again
+	req = httptest.NewRequest("DELETE", "http://email.local/internal/suppressions/gone@example.com", nil)
+	req.Header.Set("X-Internal-Secret", "s3cret")
+	w = do(h, req)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", w.Code)
+	}
+	if _, ok := supp["gone@example.com"]; ok {
+		t.Fatalf("expected suppression removed")
+	}
+}
@@ -12,8 +12,11 @@ import (
 
 	"github.com/baechuer/real-time-ressys/services/event-service/internal/application/event"
 	"github.com/baechuer/real-time-ressys/services/event-service/internal/config"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/infrastructure/authservice"
 	"github.com/baechuer/real-time-ressys/services/event-service/internal/infrastructure/caching/redis"
 	"github.com/baechuer/real-time-ressys/services/event-service/internal/infrastructure/db/postgres"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/infrastructure/joinservice"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/infrastructure/mediaservice"
 	rabbitpub "github.com/baechuer/real-time-ressys/services/event-service/internal/infrastructure/messaging/rabbitmq"
 	"github.com/baechuer/real-time-ressys/services/event-service/internal/logger"
 	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/handlers"
@@ -137,6 +140,15 @@ func NewApp(cfg *config.Config, db *sql.DB) *App {
 	// ✅ IMPORTANT: event.New signature changed (publisher removed).
 	// Publishing is now done via outbox worker, not in request path.
 	svc := event.New(repo, sysClock{}, cache, cfg.CacheTTLDetails, cfg.CacheTTLList)
+	svc.SetInviteSecret(cfg.InviteTokenSecret)
+	svc.SetAttendanceChecker(joinservice.NewClient(cfg.JoinServiceURL, cfg.InternalSecret))
+	svc.SetMediaChecker(mediaservice.NewClient(cfg.MediaServiceURL, cfg.InternalSecret))
+	svc.SetAccountBanner(authservice.NewClient(cfg.AuthServiceURL, cfg.InternalSecret))
+	svc.SetReportAutoHideThreshold(cfg.ReportAutoHideThreshold)
+
+	// Scheduled publish/unpublish; replicas elect a leader per tick via an
+	// advisory lock, and transitions go through the same tx + outbox path.
+	event.NewScheduler(svc, postgres.NewSchedulerLock(db), cfg.SchedulerInterval).Start(context.Background())
 
 	// ✅ Start consumer to listen for join events (after service is created)
 	if cfg.RabbitURL != "" {
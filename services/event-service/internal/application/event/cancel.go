@@ -3,13 +3,14 @@ package event
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
 	"github.com/google/uuid"
 	zlog "github.com/rs/zerolog/log"
 )
 
-func (s *Service) Cancel(ctx context.Context, eventID, actorID, actorRole, reason string) (*domain.Event, error) {
+func (s *Service) Cancel(ctx context.Context, eventID, actorID, actorRole, reason string, ifMatch int64) (*domain.Event, error) {
 	var out *domain.Event
 
 	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
@@ -18,8 +19,11 @@ func (s *Service) Cancel(ctx context.Context, eventID, actorID, actorRole, reaso
 			return err
 		}
 
-		if !canManage(actorID, actorRole, ev.OwnerID) {
-			return domain.ErrForbidden("not allowed")
+		if err := authorize(ctx, r, ev, actorID, actorRole, domain.CollaboratorRole.CanManage); err != nil {
+			return err
+		}
+		if err := checkVersion(ev, ifMatch); err != nil {
+			return err
 		}
 
 		switch ev.Status {
@@ -28,48 +32,7 @@ func (s *Service) Cancel(ctx context.Context, eventID, actorID, actorRole, reaso
 		}
 
 		now := s.clock.Now().UTC()
-
-		ev.Status = domain.StatusCanceled
-		ev.CanceledAt = &now
-		ev.UpdatedAt = now
-
-		if err := r.Update(ctx, ev); err != nil {
-			return err
-		}
-
-		// --- Outbox (durable, at-least-once) ---
-		messageID := uuid.NewString()
-		env := DomainEventEnvelope[EventCanceledPayload]{
-			Version:    EventVersion,
-			Producer:   EventProducer,
-			MessageID:  messageID,
-			TraceID:    TraceIDFromContext(ctx),
-			OccurredAt: now,
-			Payload: EventCanceledPayload{
-				EventID:   ev.ID,
-				OwnerID:   ev.OwnerID,
-				City:      ev.City,
-				Category:  ev.Category,
-				StartTime: ev.StartTime,
-				EndTime:   ev.EndTime,
-				Capacity:  ev.Capacity,
-				Status:    string(ev.Status),
-				Reason:    reason,
-				ActorRole: actorRole,
-			},
-		}
-
-		body, err := json.Marshal(env)
-		if err != nil {
-			return err
-		}
-
-		if err := r.InsertOutbox(ctx, OutboxMessage{
-			MessageID:  messageID,
-			RoutingKey: "event.canceled",
-			Body:       body,
-			CreatedAt:  now,
-		}); err != nil {
+		if err := cancelTx(ctx, r, ev, now, reason, actorID, actorRole); err != nil {
 			return err
 		}
 
@@ -90,3 +53,53 @@ func (s *Service) Cancel(ctx context.Context, eventID, actorID, actorRole, reaso
 
 	return out, nil
 }
+
+// cancelTx cancels ev and writes event.canceled and the revision in the
+// same transaction.
+func cancelTx(ctx context.Context, r TxEventRepo, ev *domain.Event, now time.Time, reason, actorID, actorRole string) error {
+	before := *ev
+	ev.Status = domain.StatusCanceled
+	ev.CanceledAt = &now
+	ev.UpdatedAt = now
+
+	if err := r.Update(ctx, ev); err != nil {
+		return err
+	}
+	if err := recordRevisionTx(ctx, r, &before, ev, domain.RevisionCancel, actorID, actorRole, reason, now); err != nil {
+		return err
+	}
+
+	// --- Outbox (durable, at-least-once) ---
+	messageID := uuid.NewString()
+	env := DomainEventEnvelope[EventCanceledPayload]{
+		Version:    EventVersion,
+		Producer:   EventProducer,
+		MessageID:  messageID,
+		TraceID:    TraceIDFromContext(ctx),
+		OccurredAt: now,
+		Payload: EventCanceledPayload{
+			EventID:   ev.ID,
+			OwnerID:   ev.OwnerID,
+			City:      ev.City,
+			Category:  ev.Category,
+			StartTime: ev.StartTime,
+			EndTime:   ev.EndTime,
+			Capacity:  ev.Capacity,
+			Status:    string(ev.Status),
+			Reason:    reason,
+			ActorRole: actorRole,
+		},
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return r.InsertOutbox(ctx, OutboxMessage{
+		MessageID:  messageID,
+		RoutingKey: "event.canceled",
+		Body:       body,
+		CreatedAt:  now,
+	})
+}
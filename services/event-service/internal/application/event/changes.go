@@ -0,0 +1,55 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+// EventChange is one entry of the change feed read models reconcile from.
+// Only identity and state are exposed; consumers fetch published events
+// through GetBatch and treat every other status as a tombstone.
+type EventChange struct {
+	ID        string
+	Status    domain.EventStatus
+	UpdatedAt time.Time
+}
+
+type ChangesResult struct {
+	Items      []EventChange
+	NextCursor string
+}
+
+// ListChangedSince pages through every event (any status) updated at or after
+// since, ordered by (updated_at, id). Cursor is "updated_at|uuid" and takes
+// precedence over since. Rows touched while paging move to the end of the
+// feed, so a consumer that follows next_cursor to the end sees them again.
+func (s *Service) ListChangedSince(ctx context.Context, since time.Time, cursor string, limit int) (ChangesResult, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	afterUpdated, afterID, hasCursor, err := parseTimeCursorOrEmpty(cursor)
+	if err != nil {
+		return ChangesResult{}, err
+	}
+	if !hasCursor {
+		afterUpdated = since.UTC()
+	}
+
+	items, err := s.repo.ListChangedSince(ctx, hasCursor, afterUpdated, afterID, limit)
+	if err != nil {
+		return ChangesResult{}, err
+	}
+
+	next := ""
+	if len(items) == limit {
+		last := items[len(items)-1]
+		next = formatTimeCursor(last.UpdatedAt.UTC(), last.ID)
+	}
+	return ChangesResult{Items: items, NextCursor: next}, nil
+}
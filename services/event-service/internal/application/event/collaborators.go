@@ -0,0 +1,248 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/google/uuid"
+	zlog "github.com/rs/zerolog/log"
+)
+
+const (
+	RoutingKeyCollaboratorsUpdated = "event.collaborators.updated"
+	RoutingKeyOwnerTransferred     = "event.owner_transferred"
+)
+
+type SetCollaboratorCmd struct {
+	ActorID   string
+	ActorRole string
+	EventID   string
+	UserID    string
+	Role      string
+}
+
+// ListCollaborators is visible to the owner, every collaborator and staff.
+func (s *Service) ListCollaborators(ctx context.Context, eventID, actorID, actorRole string) ([]domain.Collaborator, error) {
+	ev, err := s.repo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorize(ctx, s.repo, ev, actorID, actorRole, anyCollaborator); err != nil {
+		return nil, err
+	}
+	return s.repo.ListCollaborators(ctx, eventID)
+}
+
+// SetCollaborator adds a user to the organizer team or changes their role.
+// Only the owner (or staff) manages the team.
+func (s *Service) SetCollaborator(ctx context.Context, cmd SetCollaboratorCmd) (*domain.Collaborator, error) {
+	userID := strings.TrimSpace(cmd.UserID)
+	role, ok := domain.ParseCollaboratorRole(cmd.Role)
+	if !ok {
+		return nil, domain.ErrValidationMeta("invalid role", map[string]string{
+			"role": "must be one of co_host, checkin_staff, editor",
+		})
+	}
+
+	var out *domain.Collaborator
+	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		ev, err := r.GetByIDForUpdate(ctx, cmd.EventID)
+		if err != nil {
+			return err
+		}
+		if !canManage(cmd.ActorID, cmd.ActorRole, ev.OwnerID) {
+			return domain.ErrForbidden("not allowed")
+		}
+		if ev.Status == domain.StatusCanceled {
+			return domain.ErrInvalidState("canceled event cannot be changed")
+		}
+		if userID == ev.OwnerID {
+			return domain.ErrValidationMeta("invalid user_id", map[string]string{
+				"user_id": "owner cannot be a collaborator",
+			})
+		}
+
+		current, err := r.ListCollaborators(ctx, ev.ID)
+		if err != nil {
+			return err
+		}
+		now := s.clock.Now().UTC()
+		c := domain.Collaborator{
+			EventID:   ev.ID,
+			UserID:    userID,
+			Role:      role,
+			AddedBy:   cmd.ActorID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+
+		existing := false
+		for _, it := range current {
+			if it.UserID == userID {
+				existing = true
+				c.CreatedAt = it.CreatedAt
+				if it.Role == role {
+					// no-op: nothing to propagate
+					out = &it
+					return nil
+				}
+			}
+		}
+		if !existing && len(current) >= domain.MaxCollaborators {
+			return domain.ErrValidationMeta("too many collaborators", map[string]string{
+				"user_id": "organizer team is full",
+			})
+		}
+
+		if err := r.UpsertCollaborator(ctx, c); err != nil {
+			return err
+		}
+		if err := insertCollaboratorsOutbox(ctx, r, RoutingKeyCollaboratorsUpdated, ev.ID, ev.OwnerID, "", now); err != nil {
+			return err
+		}
+		out = &c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RemoveCollaborator removes a user from the organizer team. Collaborators
+// may also remove themselves.
+func (s *Service) RemoveCollaborator(ctx context.Context, eventID, userID, actorID, actorRole string) error {
+	userID = strings.TrimSpace(userID)
+
+	return s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		ev, err := r.GetByIDForUpdate(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		self := strings.TrimSpace(actorID) != "" && actorID == userID
+		if !self && !canManage(actorID, actorRole, ev.OwnerID) {
+			return domain.ErrForbidden("not allowed")
+		}
+
+		removed, err := r.DeleteCollaborator(ctx, ev.ID, userID)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return domain.ErrNotFound("collaborator not found")
+		}
+		return insertCollaboratorsOutbox(ctx, r, RoutingKeyCollaboratorsUpdated, ev.ID, ev.OwnerID, "", s.clock.Now().UTC())
+	})
+}
+
+// TransferOwnership hands the event to another user. The previous owner
+// stays on the team as a co-host; the new owner's collaborator row, if any,
+// is dropped.
+func (s *Service) TransferOwnership(ctx context.Context, eventID, newOwnerID, actorID, actorRole string) (*domain.Event, error) {
+	newOwnerID = strings.TrimSpace(newOwnerID)
+
+	var out *domain.Event
+	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		ev, err := r.GetByIDForUpdate(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if !canManage(actorID, actorRole, ev.OwnerID) {
+			return domain.ErrForbidden("not allowed")
+		}
+		if ev.Status == domain.StatusCanceled {
+			return domain.ErrInvalidState("canceled event cannot be transferred")
+		}
+		if newOwnerID == ev.OwnerID {
+			return domain.ErrValidationMeta("invalid new_owner_id", map[string]string{
+				"new_owner_id": "already the owner",
+			})
+		}
+
+		now := s.clock.Now().UTC()
+		prevOwner := ev.OwnerID
+
+		if _, err := r.DeleteCollaborator(ctx, ev.ID, newOwnerID); err != nil {
+			return err
+		}
+		if err := r.SetOwner(ctx, ev.ID, newOwnerID, now); err != nil {
+			return err
+		}
+		if err := r.UpsertCollaborator(ctx, domain.Collaborator{
+			EventID:   ev.ID,
+			UserID:    prevOwner,
+			Role:      domain.RoleCoHost,
+			AddedBy:   actorID,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}); err != nil {
+			return err
+		}
+
+		ev.OwnerID = newOwnerID
+		ev.UpdatedAt = now
+
+		if err := insertCollaboratorsOutbox(ctx, r, RoutingKeyOwnerTransferred, ev.ID, ev.OwnerID, prevOwner, now); err != nil {
+			return err
+		}
+		out = ev
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// --- Cache Invalidation ---
+	if s.cache != nil {
+		key := cacheKeyEventDetails(out.ID)
+		if err := s.cache.Delete(ctx, key); err != nil {
+			zlog.Warn().Err(err).Str("key", key).Msg("cache invalidate failed")
+		}
+	}
+
+	return out, nil
+}
+
+// insertCollaboratorsOutbox snapshots the team as it stands inside the tx.
+func insertCollaboratorsOutbox(ctx context.Context, r TxEventRepo, routingKey, eventID, ownerID, prevOwnerID string, now time.Time) error {
+	team, err := r.ListCollaborators(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	members := make([]CollaboratorPayload, 0, len(team))
+	for _, c := range team {
+		members = append(members, CollaboratorPayload{UserID: c.UserID, Role: string(c.Role)})
+	}
+
+	messageID := uuid.NewString()
+	env := DomainEventEnvelope[EventCollaboratorsPayload]{
+		Version:    EventVersion,
+		Producer:   EventProducer,
+		MessageID:  messageID,
+		TraceID:    TraceIDFromContext(ctx),
+		OccurredAt: now,
+		Payload: EventCollaboratorsPayload{
+			EventID:         eventID,
+			OwnerID:         ownerID,
+			PreviousOwnerID: prevOwnerID,
+			Collaborators:   members,
+			UpdatedAt:       now,
+		},
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return r.InsertOutbox(ctx, OutboxMessage{
+		MessageID:  messageID,
+		RoutingKey: routingKey,
+		Body:       body,
+		CreatedAt:  now,
+	})
+}
@@ -0,0 +1,399 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+const RoutingKeyAnnouncementPosted = "event.announcement.posted"
+
+type PostCommentCmd struct {
+	ActorID  string
+	EventID  string
+	ParentID string // optional; replying to a reply joins the root's thread
+	Body     string
+}
+
+type PostAnnouncementCmd struct {
+	ActorID   string
+	ActorRole string
+	EventID   string
+	Title     string
+	Body      string
+	Pin       bool
+}
+
+type DeleteCommentCmd struct {
+	ActorID   string
+	ActorRole string
+	EventID   string
+	CommentID string
+	Reason    string // ignored when authors delete their own post
+}
+
+// CommentPage is one keyset page of a discussion. Pinned is only filled on
+// the first page of the top-level list.
+type CommentPage struct {
+	Pinned     []domain.Comment
+	Items      []domain.Comment
+	NextCursor string
+	HasMore    bool
+}
+
+// ListComments pages the top-level posts of a published event, newest first.
+func (s *Service) ListComments(ctx context.Context, eventID, cursor string, limit int) (*CommentPage, error) {
+	beforeCreated, beforeID, hasCursor, err := parseTimeCursorOrEmpty(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetPublic(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	limit = commentPageSize(limit)
+	items, err := s.repo.ListComments(ctx, eventID, hasCursor, beforeCreated, beforeID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := commentPage(items, limit)
+	if !hasCursor {
+		if page.Pinned, err = s.repo.ListPinnedComments(ctx, eventID); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// ListReplies pages a thread oldest first.
+func (s *Service) ListReplies(ctx context.Context, eventID, commentID, cursor string, limit int) (*CommentPage, error) {
+	afterCreated, afterID, hasCursor, err := parseTimeCursorOrEmpty(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetPublic(ctx, eventID); err != nil {
+		return nil, err
+	}
+	parent, err := s.repo.GetComment(ctx, eventID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if parent.IsReply() {
+		return nil, domain.ErrNotFound("comment not found")
+	}
+
+	limit = commentPageSize(limit)
+	items, err := s.repo.ListReplies(ctx, parent.ID, hasCursor, afterCreated, afterID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	return commentPage(items, limit), nil
+}
+
+// PostComment adds a comment or reply. Any signed-in user may take part in
+// the discussion of a published event.
+func (s *Service) PostComment(ctx context.Context, cmd PostCommentCmd) (*domain.Comment, error) {
+	if strings.TrimSpace(cmd.ActorID) == "" {
+		return nil, domain.ErrForbidden("not allowed")
+	}
+	_, body, err := domain.NormalizeCommentText(domain.CommentKindComment, "", cmd.Body)
+	if err != nil {
+		return nil, err
+	}
+	ev, err := s.repo.GetByID(ctx, cmd.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if ev.Status != domain.StatusPublished {
+		return nil, domain.ErrNotFound("event not found")
+	}
+
+	now := s.clock.Now().UTC()
+	c := domain.Comment{
+		ID:        uuid.NewString(),
+		EventID:   ev.ID,
+		AuthorID:  cmd.ActorID,
+		Kind:      domain.CommentKindComment,
+		Body:      body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err = s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		if cmd.ParentID != "" {
+			parent, err := threadRootForUpdate(ctx, r, ev.ID, cmd.ParentID)
+			if err != nil {
+				return err
+			}
+			if parent.IsDeleted() {
+				return domain.ErrInvalidState("cannot reply to a deleted comment")
+			}
+			parent.ReplyCount++
+			if err := r.UpdateComment(ctx, *parent); err != nil {
+				return err
+			}
+			c.ParentID = &parent.ID
+		}
+		return r.InsertComment(ctx, c)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// PostAnnouncement broadcasts a message from the organizer team. It is
+// written with event.announcement.posted in the same transaction;
+// join-service fans it out to the active participants as email.
+func (s *Service) PostAnnouncement(ctx context.Context, cmd PostAnnouncementCmd) (*domain.Comment, error) {
+	title, body, err := domain.NormalizeCommentText(domain.CommentKindAnnouncement, cmd.Title, cmd.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var out *domain.Comment
+	err = s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		ev, err := r.GetByIDForUpdate(ctx, cmd.EventID)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, r, ev, cmd.ActorID, cmd.ActorRole, domain.CollaboratorRole.CanManage); err != nil {
+			return err
+		}
+		if ev.Status != domain.StatusPublished {
+			return domain.ErrInvalidState("event must be published to post announcements")
+		}
+		if cmd.Pin {
+			if err := checkPinSlot(ctx, r, ev.ID); err != nil {
+				return err
+			}
+		}
+
+		now := s.clock.Now().UTC()
+		c := domain.Comment{
+			ID:        uuid.NewString(),
+			EventID:   ev.ID,
+			AuthorID:  cmd.ActorID,
+			Kind:      domain.CommentKindAnnouncement,
+			Title:     title,
+			Body:      body,
+			Pinned:    cmd.Pin,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := r.InsertComment(ctx, c); err != nil {
+			return err
+		}
+		if err := insertAnnouncementOutbox(ctx, r, ev, c); err != nil {
+			return err
+		}
+		out = &c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SetCommentPinned pins or unpins a top-level post. Managed by the owner
+// and co-hosts; at most domain.MaxPinnedComments are pinned at once.
+func (s *Service) SetCommentPinned(ctx context.Context, eventID, commentID, actorID, actorRole string, pinned bool) (*domain.Comment, error) {
+	var out *domain.Comment
+	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		// The event row lock serializes pin-slot checks.
+		ev, err := r.GetByIDForUpdate(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, r, ev, actorID, actorRole, domain.CollaboratorRole.CanManage); err != nil {
+			return err
+		}
+		c, err := r.GetCommentForUpdate(ctx, ev.ID, commentID)
+		if err != nil {
+			return err
+		}
+		out = c
+		if c.Pinned == pinned {
+			return nil
+		}
+		if pinned {
+			if c.IsReply() {
+				return domain.ErrInvalidState("replies cannot be pinned")
+			}
+			if c.IsDeleted() {
+				return domain.ErrInvalidState("deleted comments cannot be pinned")
+			}
+			if err := checkPinSlot(ctx, r, ev.ID); err != nil {
+				return err
+			}
+		}
+		c.Pinned = pinned
+		c.UpdatedAt = s.clock.Now().UTC()
+		return r.UpdateComment(ctx, *c)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DeleteComment soft-deletes a post. Authors delete their own; the owner,
+// co-hosts and moderators or admins (the same roles auth-service's ModMW
+// admits) remove anyone's, optionally with a reason.
+func (s *Service) DeleteComment(ctx context.Context, cmd DeleteCommentCmd) (*domain.Comment, error) {
+	if strings.TrimSpace(cmd.ActorID) == "" {
+		return nil, domain.ErrForbidden("not allowed")
+	}
+
+	var out *domain.Comment
+	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		ev, err := r.GetByIDForUpdate(ctx, cmd.EventID)
+		if err != nil {
+			return err
+		}
+		c, err := r.GetCommentForUpdate(ctx, ev.ID, cmd.CommentID)
+		if err != nil {
+			return err
+		}
+
+		reason := ""
+		if c.AuthorID != cmd.ActorID {
+			if err := authorize(ctx, r, ev, cmd.ActorID, cmd.ActorRole, domain.CollaboratorRole.CanManage); err != nil {
+				return err
+			}
+			reason = cmd.Reason
+		}
+
+		if err := removeCommentTx(ctx, r, c, cmd.ActorID, reason, s.clock.Now().UTC()); err != nil {
+			return err
+		}
+
+		out = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// removeCommentTx soft-deletes c and keeps its thread's reply count in step.
+func removeCommentTx(ctx context.Context, r TxEventRepo, c *domain.Comment, actorID, reason string, now time.Time) error {
+	if err := c.SoftDelete(actorID, reason, now); err != nil {
+		return err
+	}
+	if err := r.UpdateComment(ctx, *c); err != nil {
+		return err
+	}
+	return adjustReplyCount(ctx, r, c, -1, now)
+}
+
+// restoreCommentTx undoes removeCommentTx.
+func restoreCommentTx(ctx context.Context, r TxEventRepo, c *domain.Comment, now time.Time) error {
+	if err := c.Restore(now); err != nil {
+		return err
+	}
+	if err := r.UpdateComment(ctx, *c); err != nil {
+		return err
+	}
+	return adjustReplyCount(ctx, r, c, 1, now)
+}
+
+func adjustReplyCount(ctx context.Context, r TxEventRepo, c *domain.Comment, delta int, now time.Time) error {
+	if !c.IsReply() {
+		return nil
+	}
+	parent, err := r.GetCommentForUpdate(ctx, c.EventID, *c.ParentID)
+	if err != nil {
+		return err
+	}
+	parent.ReplyCount += delta
+	if parent.ReplyCount < 0 {
+		parent.ReplyCount = 0
+	}
+	parent.UpdatedAt = now
+	return r.UpdateComment(ctx, *parent)
+}
+
+// threadRootForUpdate locks the top-level post a reply belongs to.
+func threadRootForUpdate(ctx context.Context, r TxEventRepo, eventID, commentID string) (*domain.Comment, error) {
+	c, err := r.GetCommentForUpdate(ctx, eventID, commentID)
+	if err != nil {
+		return nil, err
+	}
+	if c.IsReply() {
+		return r.GetCommentForUpdate(ctx, eventID, *c.ParentID)
+	}
+	return c, nil
+}
+
+func checkPinSlot(ctx context.Context, r TxEventRepo, eventID string) error {
+	n, err := r.CountPinnedComments(ctx, eventID)
+	if err != nil {
+		return err
+	}
+	if n >= domain.MaxPinnedComments {
+		return domain.ErrInvalidState("too many pinned posts; unpin one first")
+	}
+	return nil
+}
+
+func commentPageSize(limit int) int {
+	if limit <= 0 {
+		return 20
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+// commentPage trims the extra row fetched to detect another page.
+func commentPage(items []domain.Comment, limit int) *CommentPage {
+	page := &CommentPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+		last := page.Items[limit-1]
+		page.NextCursor = formatTimeCursor(last.CreatedAt.UTC(), last.ID)
+	}
+	return page
+}
+
+func insertAnnouncementOutbox(ctx context.Context, r TxEventRepo, ev *domain.Event, c domain.Comment) error {
+	messageID := uuid.NewString()
+	env := DomainEventEnvelope[EventAnnouncementPayload]{
+		Version:    EventVersion,
+		Producer:   EventProducer,
+		MessageID:  messageID,
+		TraceID:    TraceIDFromContext(ctx),
+		OccurredAt: c.CreatedAt,
+		Payload: EventAnnouncementPayload{
+			AnnouncementID: c.ID,
+			EventID:        ev.ID,
+			EventTitle:     ev.Title,
+			AuthorID:       c.AuthorID,
+			Title:          c.Title,
+			Body:           c.Body,
+			CreatedAt:      c.CreatedAt,
+		},
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return r.InsertOutbox(ctx, OutboxMessage{
+		MessageID:  messageID,
+		RoutingKey: RoutingKeyAnnouncementPosted,
+		Body:       body,
+		CreatedAt:  c.CreatedAt,
+	})
+}
@@ -0,0 +1,65 @@
+package event
+
+import (
+	"context"
+	"slices"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+// CoverRejectedReason is the revision reason when moderation in
+// media-service rejects an image used as a cover.
+const CoverRejectedReason = "cover_rejected"
+
+// HandleCoverRejected detaches rejected uploads (media.upload.rejected)
+// from every event using them as a cover, whatever its status. The event
+// stays as it is otherwise; a revision records the removal under
+// SystemActor. Replays are no-ops. Returns how many events were changed.
+func (s *Service) HandleCoverRejected(ctx context.Context, uploadIDs []string) (int, error) {
+	if len(uploadIDs) == 0 {
+		return 0, nil
+	}
+
+	ids, err := s.repo.ListIDsByCoverImages(ctx, uploadIDs)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.clock.Now().UTC()
+	changed := 0
+	for _, id := range ids {
+		touched := false
+
+		err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+			ev, err := r.GetByIDForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			kept := slices.DeleteFunc(slices.Clone(ev.CoverImageIDs), func(c string) bool {
+				return slices.Contains(uploadIDs, c)
+			})
+			if len(kept) == len(ev.CoverImageIDs) {
+				return nil
+			}
+
+			touched = true
+			before := *ev
+			ev.CoverImageIDs = kept
+			ev.UpdatedAt = now
+			if err := r.Update(ctx, ev); err != nil {
+				return err
+			}
+			return recordRevisionTx(ctx, r, &before, ev, domain.RevisionUpdate, domain.SystemActor, domain.SystemActor, CoverRejectedReason, now)
+		})
+		if err != nil {
+			return changed, err
+		}
+
+		if touched {
+			changed++
+			s.invalidateDetails(ctx, id)
+		}
+	}
+	return changed, nil
+}
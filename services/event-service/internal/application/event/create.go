@@ -19,6 +19,8 @@ type CreateCmd struct {
 	EndTime       time.Time
 	Capacity      int
 	CoverImageIDs []string
+	Visibility    domain.Visibility // "" = public
+	Schedule      domain.Schedule
 }
 
 func (s *Service) Create(ctx context.Context, cmd CreateCmd) (*domain.Event, error) {
@@ -30,6 +32,19 @@ func (s *Service) Create(ctx context.Context, cmd CreateCmd) (*domain.Event, err
 	if err != nil {
 		return nil, err
 	}
+	if cmd.Visibility != "" {
+		if err := e.SetVisibility(cmd.Visibility, now); err != nil {
+			return nil, err
+		}
+	}
+	if cmd.Schedule != (domain.Schedule{}) {
+		if err := e.SetSchedule(cmd.Schedule, now); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.claimCovers(ctx, cmd.ActorID, e.CoverImageIDs); err != nil {
+		return nil, err
+	}
 	if err := s.repo.Create(ctx, e); err != nil {
 		return nil, err
 	}
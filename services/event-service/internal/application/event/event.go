@@ -23,20 +23,27 @@ type DomainEventEnvelope[T any] struct {
 	Payload    T         `json:"payload"`
 }
 
-// EventPublishedPayload is the business payload for routing key: event.published
+// EventPublishedPayload is the business payload for routing keys event.published
+// and event.updated (a full snapshot of a published event).
 type EventPublishedPayload struct {
 	EventID       string    `json:"event_id"`
 	OwnerID       string    `json:"owner_id"`
 	Title         string    `json:"title"`
+	Description   string    `json:"description,omitempty"`
 	City          string    `json:"city"`
 	Category      string    `json:"category"`
 	StartTime     time.Time `json:"start_time"`
 	EndTime       time.Time `json:"end_time"`
 	Capacity      int       `json:"capacity"`
 	Status        string    `json:"status"`
+	Visibility    string    `json:"visibility"`
 	Reason        string    `json:"reason,omitempty"`
 	ActorRole     string    `json:"actor_role,omitempty"`
 	CoverImageIDs []string  `json:"cover_image_ids,omitempty"`
+
+	// Registration window; join-service rejects joins outside it. Nil = no bound.
+	RegistrationOpensAt  *time.Time `json:"registration_opens_at,omitempty"`
+	RegistrationClosesAt *time.Time `json:"registration_closes_at,omitempty"`
 }
 
 // EventCanceledPayload is the business payload for routing key: event.canceled
@@ -62,6 +69,59 @@ type EventUnpublishedPayload struct {
 	ActorRole string `json:"actor_role,omitempty"`
 }
 
+// EventCollaboratorsPayload is the business payload for routing keys
+// event.collaborators.updated and event.owner_transferred. It is a full
+// snapshot of the organizer team; consumers replace their copy when
+// UpdatedAt is newer than what they hold.
+type EventCollaboratorsPayload struct {
+	EventID         string                `json:"event_id"`
+	OwnerID         string                `json:"owner_id"`
+	PreviousOwnerID string                `json:"previous_owner_id,omitempty"`
+	Collaborators   []CollaboratorPayload `json:"collaborators"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+type CollaboratorPayload struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+// EventInvitePayload is the business payload for routing keys
+// event.invite.created and event.invite.revoked. Access codes travel only
+// as CodeHash (see domain.HashAccessCode).
+type EventInvitePayload struct {
+	InviteID  string     `json:"invite_id"`
+	EventID   string     `json:"event_id"`
+	Kind      string     `json:"kind"`
+	CodeHash  string     `json:"code_hash,omitempty"`
+	MaxUses   *int       `json:"max_uses,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// EventAnnouncementPayload is the business payload for routing key:
+// event.announcement.posted
+type EventAnnouncementPayload struct {
+	AnnouncementID string    `json:"announcement_id"`
+	EventID        string    `json:"event_id"`
+	EventTitle     string    `json:"event_title"`
+	AuthorID       string    `json:"author_id"`
+	Title          string    `json:"title"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// EventReputationPayload is the business payload for routing key:
+// event.reputation.updated. It is a snapshot of the organizer's rating;
+// consumers keep the newest by updated_at.
+type EventReputationPayload struct {
+	OrganizerID string    `json:"organizer_id"`
+	EventID     string    `json:"event_id"` // the event whose review changed
+	RatingAvg   float64   `json:"rating_avg"`
+	RatingCount int       `json:"rating_count"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 // ---- trace id plumbing ----
 // Minimal and decoupled: if transport layer stores a request id in context,
 // we read it here. If not present, trace_id will be omitted.
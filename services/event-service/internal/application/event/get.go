@@ -53,8 +53,8 @@ func (s *Service) GetForOwner(ctx context.Context, id, actorID, actorRole string
 	if err != nil {
 		return nil, err
 	}
-	if !canEdit(e.OwnerID, actorID, actorRole) {
-		return nil, domain.ErrForbidden("not allowed")
+	if err := authorize(ctx, s.repo, e, actorID, actorRole, anyCollaborator); err != nil {
+		return nil, err
 	}
 	return e, nil
 }
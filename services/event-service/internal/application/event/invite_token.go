@@ -0,0 +1,30 @@
+package event
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"time"
+)
+
+// Invite link tokens have the form <invite_id>.<event_id>.<exp>.<sig>, where
+// exp is a unix timestamp (0 = no expiry) and sig is the unpadded base64url
+// HMAC-SHA256 of "invite:v1:<invite_id>.<event_id>.<exp>". join-service
+// verifies them with the same INVITE_TOKEN_SECRET; keep both sides in sync.
+//
+// Tokens are deterministic so the organizer can list a link again later;
+// revoking the invite is what invalidates it.
+const inviteTokenPrefix = "invite:v1:"
+
+func SignInviteToken(secret []byte, inviteID, eventID string, expiresAt *time.Time) string {
+	var exp int64
+	if expiresAt != nil {
+		exp = expiresAt.Unix()
+	}
+	claims := inviteID + "." + eventID + "." + strconv.FormatInt(exp, 10)
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(inviteTokenPrefix + claims))
+	return claims + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
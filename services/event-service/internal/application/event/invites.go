@@ -0,0 +1,270 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	RoutingKeyInviteCreated = "event.invite.created"
+	RoutingKeyInviteRevoked = "event.invite.revoked"
+
+	maxInviteUses = 10000
+)
+
+type CreateInviteCmd struct {
+	ActorID   string
+	ActorRole string
+	EventID   string
+
+	Kind      string // link | code
+	Label     string
+	Code      string // kind=code: optional, generated when empty
+	MaxUses   *int   // nil = unlimited
+	ExpiresAt *time.Time
+}
+
+// IssuedInvite is an invite as the organizer sees it; Token is the signed
+// link token for kind=link.
+type IssuedInvite struct {
+	domain.Invite
+	Token string
+}
+
+// InviteList is every invite of an event plus the counts shown to organizers.
+type InviteList struct {
+	Invites []IssuedInvite
+	Total   int
+	Active  int
+	Uses    int
+}
+
+// CreateInvite issues an invite link or access code for an invite-only
+// event. Managed by the owner and co-hosts.
+func (s *Service) CreateInvite(ctx context.Context, cmd CreateInviteCmd) (*IssuedInvite, error) {
+	kind := domain.InviteKind(strings.ToLower(strings.TrimSpace(cmd.Kind)))
+	if kind != domain.InviteLink && kind != domain.InviteCode {
+		return nil, domain.ErrValidationMeta("invalid kind", map[string]string{
+			"kind": "must be one of link, code",
+		})
+	}
+	label := strings.TrimSpace(cmd.Label)
+	if len(label) > 80 {
+		return nil, domain.ErrValidationMeta("invalid label", map[string]string{
+			"label": "must be <= 80 chars",
+		})
+	}
+	if cmd.MaxUses != nil && (*cmd.MaxUses <= 0 || *cmd.MaxUses > maxInviteUses) {
+		return nil, domain.ErrValidationMeta("invalid max_uses", map[string]string{
+			"max_uses": "must be between 1 and 10000",
+		})
+	}
+
+	now := s.clock.Now().UTC()
+	if cmd.ExpiresAt != nil && !cmd.ExpiresAt.After(now) {
+		return nil, domain.ErrValidationMeta("invalid expires_at", map[string]string{
+			"expires_at": "must be in the future",
+		})
+	}
+
+	var out *IssuedInvite
+	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		ev, err := r.GetByIDForUpdate(ctx, cmd.EventID)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, r, ev, cmd.ActorID, cmd.ActorRole, domain.CollaboratorRole.CanManage); err != nil {
+			return err
+		}
+		if ev.Status == domain.StatusCanceled {
+			return domain.ErrInvalidState("canceled event cannot be changed")
+		}
+		if ev.IsEnded(now) {
+			return domain.ErrInvalidState("ended event cannot be changed")
+		}
+		if ev.Visibility != domain.VisibilityInviteOnly {
+			return domain.ErrInvalidState("invites are only used by invite-only events")
+		}
+
+		inv := domain.Invite{
+			ID:        uuid.NewString(),
+			EventID:   ev.ID,
+			Kind:      kind,
+			Label:     label,
+			MaxUses:   cmd.MaxUses,
+			CreatedBy: cmd.ActorID,
+			CreatedAt: now,
+		}
+		if cmd.ExpiresAt != nil {
+			t := cmd.ExpiresAt.UTC()
+			inv.ExpiresAt = &t
+		}
+		if kind == domain.InviteCode {
+			code, err := accessCode(cmd.Code)
+			if err != nil {
+				return err
+			}
+			inv.Code = code
+			inv.CodeHash = domain.HashAccessCode(ev.ID, code)
+		}
+
+		existing, err := r.ListInvites(ctx, ev.ID)
+		if err != nil {
+			return err
+		}
+		active := 0
+		for _, it := range existing {
+			if !it.Active(now) {
+				continue
+			}
+			active++
+			if inv.CodeHash != "" && it.CodeHash == inv.CodeHash {
+				return domain.ErrValidationMeta("invalid code", map[string]string{
+					"code": "already in use for this event",
+				})
+			}
+		}
+		if active >= domain.MaxActiveInvites {
+			return domain.ErrValidationMeta("too many invites", map[string]string{
+				"kind": "revoke unused invites first",
+			})
+		}
+
+		if err := r.InsertInvite(ctx, inv); err != nil {
+			return err
+		}
+		if err := insertInviteOutbox(ctx, r, RoutingKeyInviteCreated, inv, now); err != nil {
+			return err
+		}
+		out = s.issued(inv)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RevokeInvite stops an invite from being redeemed. Attendees who already
+// joined with it keep their seat.
+func (s *Service) RevokeInvite(ctx context.Context, eventID, inviteID, actorID, actorRole string) error {
+	if _, err := uuid.Parse(inviteID); err != nil {
+		return domain.ErrNotFound("invite not found")
+	}
+	return s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		ev, err := r.GetByIDForUpdate(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, r, ev, actorID, actorRole, domain.CollaboratorRole.CanManage); err != nil {
+			return err
+		}
+
+		now := s.clock.Now().UTC()
+		revoked, err := r.RevokeInvite(ctx, ev.ID, inviteID, now)
+		if err != nil {
+			return err
+		}
+		if !revoked {
+			return domain.ErrNotFound("invite not found")
+		}
+		return insertInviteOutbox(ctx, r, RoutingKeyInviteRevoked, domain.Invite{
+			ID:        inviteID,
+			EventID:   ev.ID,
+			RevokedAt: &now,
+		}, now)
+	})
+}
+
+// ListInvites returns the event's invites with their link tokens and
+// redemption counts.
+func (s *Service) ListInvites(ctx context.Context, eventID, actorID, actorRole string) (*InviteList, error) {
+	ev, err := s.repo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorize(ctx, s.repo, ev, actorID, actorRole, domain.CollaboratorRole.CanManage); err != nil {
+		return nil, err
+	}
+
+	invites, err := s.repo.ListInvites(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	out := &InviteList{Invites: make([]IssuedInvite, 0, len(invites)), Total: len(invites)}
+	for _, inv := range invites {
+		if inv.Active(now) {
+			out.Active++
+		}
+		out.Uses += inv.UseCount
+		out.Invites = append(out.Invites, *s.issued(inv))
+	}
+	return out, nil
+}
+
+// RecordInviteUse counts a join that join-service admitted with an invite.
+// The count is informational; join-service enforces max_uses itself.
+func (s *Service) RecordInviteUse(ctx context.Context, eventID uuid.UUID, inviteID string) error {
+	return s.repo.IncrementInviteUse(ctx, eventID.String(), inviteID)
+}
+
+func (s *Service) issued(inv domain.Invite) *IssuedInvite {
+	out := &IssuedInvite{Invite: inv}
+	if inv.Kind == domain.InviteLink {
+		out.Token = SignInviteToken(s.inviteSecret, inv.ID, inv.EventID, inv.ExpiresAt)
+	}
+	return out
+}
+
+// accessCode normalizes an organizer-chosen code or generates one.
+func accessCode(raw string) (string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return domain.NewAccessCode()
+	}
+	code, ok := domain.NormalizeAccessCode(raw)
+	if !ok {
+		return "", domain.ErrValidationMeta("invalid code", map[string]string{
+			"code": "must be 4-32 letters or digits",
+		})
+	}
+	return code, nil
+}
+
+func insertInviteOutbox(ctx context.Context, r TxEventRepo, routingKey string, inv domain.Invite, now time.Time) error {
+	messageID := uuid.NewString()
+	env := DomainEventEnvelope[EventInvitePayload]{
+		Version:    EventVersion,
+		Producer:   EventProducer,
+		MessageID:  messageID,
+		TraceID:    TraceIDFromContext(ctx),
+		OccurredAt: now,
+		Payload: EventInvitePayload{
+			InviteID:  inv.ID,
+			EventID:   inv.EventID,
+			Kind:      string(inv.Kind),
+			CodeHash:  inv.CodeHash,
+			MaxUses:   inv.MaxUses,
+			ExpiresAt: inv.ExpiresAt,
+			RevokedAt: inv.RevokedAt,
+		},
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return r.InsertOutbox(ctx, OutboxMessage{
+		MessageID:  messageID,
+		RoutingKey: routingKey,
+		Body:       body,
+		CreatedAt:  now,
+	})
+}
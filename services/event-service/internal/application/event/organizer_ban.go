@@ -0,0 +1,75 @@
+package event
+
+import (
+	"context"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+// OrganizerBannedReason is the unpublish reason for events whose owner's
+// account was banned in auth-service.
+const OrganizerBannedReason = "organizer_banned"
+
+// HandleOrganizerBanned takes a banned organizer's upcoming events out of
+// circulation (auth.user.banned): published events are unpublished with
+// OrganizerBannedReason and pending publish_at schedules are dropped.
+// Nothing is restored on unban; the organizer republishes by hand.
+// Replays are no-ops. Returns how many events were changed.
+func (s *Service) HandleOrganizerBanned(ctx context.Context, ownerID string) (int, error) {
+	return s.takeDownUpcoming(ctx, ownerID, OrganizerBannedReason)
+}
+
+// takeDownUpcoming unpublishes the owner's upcoming published events with
+// reason and drops pending publish_at schedules.
+func (s *Service) takeDownUpcoming(ctx context.Context, ownerID, reason string) (int, error) {
+	now := s.clock.Now().UTC()
+
+	ids, err := s.repo.ListUpcomingOwnedIDs(ctx, ownerID, now)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, id := range ids {
+		unpublished, touched := false, false
+
+		err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+			ev, err := r.GetByIDForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+			// Re-check under the lock: ownership may have been transferred.
+			if ev.OwnerID != ownerID || !ev.StartTime.After(now) {
+				return nil
+			}
+
+			switch {
+			case ev.Status == domain.StatusPublished:
+				unpublished, touched = true, true
+				return unpublishTx(ctx, r, ev, now, reason, domain.SystemActor, domain.SystemActor)
+
+			case ev.Status == domain.StatusDraft && ev.PublishAt != nil:
+				touched = true
+				before := *ev
+				ev.PublishAt = nil
+				ev.UpdatedAt = now
+				if err := r.Update(ctx, ev); err != nil {
+					return err
+				}
+				return recordRevisionTx(ctx, r, &before, ev, domain.RevisionSchedule, domain.SystemActor, domain.SystemActor, reason, now)
+			}
+			return nil
+		})
+		if err != nil {
+			return changed, err
+		}
+
+		if touched {
+			changed++
+		}
+		if unpublished {
+			s.invalidateDetails(ctx, id)
+		}
+	}
+	return changed, nil
+}
@@ -2,6 +2,7 @@ package event
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
 	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
@@ -35,6 +36,10 @@ type EventRepo interface {
 		afterID string,
 	) ([]*domain.Event, []float64, error)
 
+	// Change feed for read-model reconciliation, keyset on (updated_at, id).
+	// Without a cursor it returns rows with updated_at >= afterUpdated.
+	ListChangedSince(ctx context.Context, hasCursor bool, afterUpdated time.Time, afterID string, limit int) ([]EventChange, error)
+
 	// Participant count management
 	IncrementParticipantCount(ctx context.Context, eventID uuid.UUID) error
 	DecrementParticipantCount(ctx context.Context, eventID uuid.UUID) error
@@ -42,6 +47,55 @@ type EventRepo interface {
 	// City autocomplete suggestions
 	GetCitySuggestions(ctx context.Context, query string, limit int) ([]string, error)
 
+	// Organizer team. GetCollaboratorRole returns "" when the user is not a collaborator.
+	GetCollaboratorRole(ctx context.Context, eventID, userID string) (domain.CollaboratorRole, error)
+	ListCollaborators(ctx context.Context, eventID string) ([]domain.Collaborator, error)
+
+	// Invites of invite-only events, newest first.
+	ListInvites(ctx context.Context, eventID string) ([]domain.Invite, error)
+	// IncrementInviteUse counts a redemption reported by join-service.
+	IncrementInviteUse(ctx context.Context, eventID, inviteID string) error
+
+	// ListDueTransitions returns publish_at / unpublish_at transitions due at
+	// or before now, oldest first.
+	ListDueTransitions(ctx context.Context, now time.Time, limit int) ([]ScheduledTransition, error)
+	// ListUpcomingOwnedIDs returns the owner's events starting after now that
+	// are published or waiting on a publish_at.
+	ListUpcomingOwnedIDs(ctx context.Context, ownerID string, now time.Time) ([]string, error)
+	// ListIDsByCoverImages returns the events, in any status, that use one
+	// of uploadIDs as a cover.
+	ListIDsByCoverImages(ctx context.Context, uploadIDs []string) ([]string, error)
+
+	// Discussion. ListComments pages top-level posts newest first (keyset on
+	// created_at, id); ListReplies pages a thread oldest first.
+	ListComments(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Comment, error)
+	ListReplies(ctx context.Context, parentID string, hasCursor bool, afterCreated time.Time, afterID string, limit int) ([]domain.Comment, error)
+	ListPinnedComments(ctx context.Context, eventID string) ([]domain.Comment, error)
+	GetComment(ctx context.Context, eventID, commentID string) (*domain.Comment, error)
+
+	// Reviews. ListReviews pages visible reviews newest first (keyset on
+	// created_at, id); summaries only count visible reviews.
+	ListReviews(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Review, error)
+	GetReview(ctx context.Context, eventID, reviewID string) (*domain.Review, error)
+	EventRatingSummary(ctx context.Context, eventID string) (domain.RatingSummary, error)
+	OrganizerRatingSummary(ctx context.Context, organizerID string) (domain.RatingSummary, error)
+
+	// Moderation queue. ListModerationCases pages cases in one status, most
+	// recently reported first (keyset on last_reported_at, id); an empty
+	// target lists every target type.
+	ListModerationCases(ctx context.Context, status domain.CaseStatus, target domain.ReportTarget, hasCursor bool, beforeReported time.Time, beforeID string, limit int) ([]domain.ModerationCase, error)
+	GetModerationCase(ctx context.Context, caseID string) (*domain.ModerationCase, error)
+	// ListCaseReports returns a case's reports, newest first.
+	ListCaseReports(ctx context.Context, caseID string, limit int) ([]domain.Report, error)
+
+	// ListRevisions pages an event's change history newest first (keyset on
+	// created_at, id).
+	ListRevisions(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Revision, error)
+
+	// ExportUserData returns everything stored about the user as a JSON
+	// document (user.data.exported).
+	ExportUserData(ctx context.Context, userID string) (json.RawMessage, error)
+
 	// WithTx runs fn in a DB transaction.
 	// The TxEventRepo must be used for all reads/writes inside the callback.
 	WithTx(ctx context.Context, fn func(r TxEventRepo) error) error
@@ -51,10 +105,79 @@ type TxEventRepo interface {
 	GetByIDForUpdate(ctx context.Context, id string) (*domain.Event, error)
 	Update(ctx context.Context, e *domain.Event) error
 
+	GetCollaboratorRole(ctx context.Context, eventID, userID string) (domain.CollaboratorRole, error)
+	ListCollaborators(ctx context.Context, eventID string) ([]domain.Collaborator, error)
+	UpsertCollaborator(ctx context.Context, c domain.Collaborator) error
+	// DeleteCollaborator reports whether a row was removed.
+	DeleteCollaborator(ctx context.Context, eventID, userID string) (bool, error)
+	// SetOwner moves ownership; Update does not touch owner_id.
+	SetOwner(ctx context.Context, eventID, ownerID string, at time.Time) error
+
+	ListInvites(ctx context.Context, eventID string) ([]domain.Invite, error)
+	InsertInvite(ctx context.Context, inv domain.Invite) error
+	// RevokeInvite reports whether a live invite was revoked.
+	RevokeInvite(ctx context.Context, eventID, inviteID string, at time.Time) (bool, error)
+
+	GetCommentForUpdate(ctx context.Context, eventID, commentID string) (*domain.Comment, error)
+	InsertComment(ctx context.Context, c domain.Comment) error
+	// UpdateComment writes pin, reply count and soft-delete state.
+	UpdateComment(ctx context.Context, c domain.Comment) error
+	CountPinnedComments(ctx context.Context, eventID string) (int, error)
+
+	// LockOrganizerRatings serializes review writes per organizer until the
+	// transaction ends.
+	LockOrganizerRatings(ctx context.Context, organizerID string) error
+	GetReviewForUpdate(ctx context.Context, eventID, reviewID string) (*domain.Review, error)
+	// GetReviewByAuthorForUpdate returns nil when the author has not reviewed the event.
+	GetReviewByAuthorForUpdate(ctx context.Context, eventID, authorID string) (*domain.Review, error)
+	InsertReview(ctx context.Context, rv domain.Review) error
+	// UpdateReview writes rating, body and hidden state.
+	UpdateReview(ctx context.Context, rv domain.Review) error
+	OrganizerRatingSummary(ctx context.Context, organizerID string) (domain.RatingSummary, error)
+
+	// EnsureModerationCase inserts c unless its target already has an open
+	// or triaged case. Closed cases are history; they are never reused.
+	EnsureModerationCase(ctx context.Context, c domain.ModerationCase) error
+	GetModerationCaseForUpdate(ctx context.Context, caseID string) (*domain.ModerationCase, error)
+	// GetActiveModerationCaseForUpdate returns the target's open or triaged case.
+	GetActiveModerationCaseForUpdate(ctx context.Context, target domain.ReportTarget, targetID string) (*domain.ModerationCase, error)
+	// UpdateModerationCase writes counts, status and resolution.
+	UpdateModerationCase(ctx context.Context, c domain.ModerationCase) error
+	// InsertReport records rp and reports true, or loads the reporter's
+	// earlier report of the same case into rp and reports false.
+	InsertReport(ctx context.Context, rp *domain.Report) (bool, error)
+
+	// InsertRevision appends to the event's change history.
+	InsertRevision(ctx context.Context, rv domain.Revision) error
+
+	// AnonymizeUser replaces every reference to userID with pseudonym and
+	// drops the user's collaborator rows.
+	AnonymizeUser(ctx context.Context, userID, pseudonym string) error
+
 	// InsertOutbox persists the message for eventual publish (Outbox pattern).
 	InsertOutbox(ctx context.Context, msg OutboxMessage) error
 }
 
+// AttendanceChecker looks up a user's join status in join-service.
+// It returns "" when the user never joined the event.
+type AttendanceChecker interface {
+	ParticipationStatus(ctx context.Context, eventID, userID string) (string, error)
+}
+
+// MediaChecker claims uploads in media-service before an event references
+// them. It fails with a validation error naming the first id that is
+// missing, owned by someone else, of another purpose or not READY; on
+// success media-service keeps the uploads from being cleaned up.
+type MediaChecker interface {
+	ClaimUploads(ctx context.Context, ownerID, purpose string, ids []string) error
+}
+
+// AccountBanner bans accounts in auth-service on behalf of a moderator.
+// auth-service applies its own role rules; repeating a ban succeeds.
+type AccountBanner interface {
+	BanUser(ctx context.Context, actorID, actorRole, userID string) error
+}
+
 // EventPublisher is used by the outbox worker (infrastructure layer).
 // It MUST set AMQP MessageId = msg.MessageID and publish msg.Body as-is.
 type EventPublisher interface {
@@ -10,7 +10,7 @@ import (
 	zlog "github.com/rs/zerolog/log"
 )
 
-func (s *Service) Publish(ctx context.Context, eventID, actorID, actorRole string) (*domain.Event, error) {
+func (s *Service) Publish(ctx context.Context, eventID, actorID, actorRole string, ifMatch int64) (*domain.Event, error) {
 	var out *domain.Event
 
 	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
@@ -19,8 +19,11 @@ func (s *Service) Publish(ctx context.Context, eventID, actorID, actorRole strin
 			return err
 		}
 
-		if !canManage(actorID, actorRole, ev.OwnerID) {
-			return domain.ErrForbidden("not allowed")
+		if err := authorize(ctx, r, ev, actorID, actorRole, domain.CollaboratorRole.CanManage); err != nil {
+			return err
+		}
+		if err := checkVersion(ev, ifMatch); err != nil {
+			return err
 		}
 
 		switch ev.Status {
@@ -39,47 +42,7 @@ func (s *Service) Publish(ctx context.Context, eventID, actorID, actorRole strin
 			})
 		}
 
-		ev.Status = domain.StatusPublished
-		ev.PublishedAt = &now
-		ev.UpdatedAt = now
-
-		if err := r.Update(ctx, ev); err != nil {
-			return err
-		}
-
-		// --- Outbox (durable, at-least-once) ---
-		messageID := uuid.NewString()
-		env := DomainEventEnvelope[EventPublishedPayload]{
-			Version:    EventVersion,
-			Producer:   EventProducer,
-			MessageID:  messageID,
-			TraceID:    TraceIDFromContext(ctx),
-			OccurredAt: now,
-			Payload: EventPublishedPayload{
-				EventID:       ev.ID,
-				OwnerID:       ev.OwnerID,
-				Title:         ev.Title,
-				City:          ev.City,
-				Category:      ev.Category,
-				StartTime:     ev.StartTime,
-				EndTime:       ev.EndTime,
-				Capacity:      ev.Capacity,
-				Status:        string(ev.Status),
-				CoverImageIDs: ev.CoverImageIDs,
-			},
-		}
-
-		body, err := json.Marshal(env)
-		if err != nil {
-			return err
-		}
-
-		if err := r.InsertOutbox(ctx, OutboxMessage{
-			MessageID:  messageID,
-			RoutingKey: "event.published",
-			Body:       body,
-			CreatedAt:  now,
-		}); err != nil {
+		if err := publishTx(ctx, r, ev, now, "", actorID, actorRole); err != nil {
 			return err
 		}
 
@@ -100,3 +63,71 @@ func (s *Service) Publish(ctx context.Context, eventID, actorID, actorRole strin
 
 	return out, nil
 }
+
+// publishTx moves ev to published and writes event.published and the
+// revision in the same transaction. Publishing consumes any pending
+// publish_at.
+func publishTx(ctx context.Context, r TxEventRepo, ev *domain.Event, now time.Time, reason, actorID, actorRole string) error {
+	before := *ev
+	ev.Status = domain.StatusPublished
+	ev.PublishedAt = &now
+	ev.PublishAt = nil
+	ev.UpdatedAt = now
+
+	if err := r.Update(ctx, ev); err != nil {
+		return err
+	}
+	if err := recordRevisionTx(ctx, r, &before, ev, domain.RevisionPublish, actorID, actorRole, reason, now); err != nil {
+		return err
+	}
+
+	payload := publishedPayload(ev)
+	payload.Reason = reason
+	payload.ActorRole = actorRole
+	return insertPublishedOutbox(ctx, r, "event.published", payload, now)
+}
+
+// publishedPayload is the snapshot consumers index from event.published and
+// event.updated.
+func publishedPayload(ev *domain.Event) EventPublishedPayload {
+	return EventPublishedPayload{
+		EventID:              ev.ID,
+		OwnerID:              ev.OwnerID,
+		Title:                ev.Title,
+		Description:          ev.Description,
+		City:                 ev.City,
+		Category:             ev.Category,
+		StartTime:            ev.StartTime,
+		EndTime:              ev.EndTime,
+		Capacity:             ev.Capacity,
+		Status:               string(ev.Status),
+		Visibility:           string(ev.Visibility),
+		RegistrationOpensAt:  ev.RegistrationOpensAt,
+		RegistrationClosesAt: ev.RegistrationClosesAt,
+		CoverImageIDs:        ev.CoverImageIDs,
+	}
+}
+
+func insertPublishedOutbox(ctx context.Context, r TxEventRepo, routingKey string, payload EventPublishedPayload, now time.Time) error {
+	messageID := uuid.NewString()
+	env := DomainEventEnvelope[EventPublishedPayload]{
+		Version:    EventVersion,
+		Producer:   EventProducer,
+		MessageID:  messageID,
+		TraceID:    TraceIDFromContext(ctx),
+		OccurredAt: now,
+		Payload:    payload,
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return r.InsertOutbox(ctx, OutboxMessage{
+		MessageID:  messageID,
+		RoutingKey: routingKey,
+		Body:       body,
+		CreatedAt:  now,
+	})
+}
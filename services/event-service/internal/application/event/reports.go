@@ -0,0 +1,446 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+// autoHideReason is recorded on content hidden by the report threshold.
+const autoHideReason = "hidden pending review after user reports"
+
+// maxCaseReports caps the reports returned with a case.
+const maxCaseReports = 100
+
+type ReportCmd struct {
+	ActorID    string
+	TargetType domain.ReportTarget
+	TargetID   string
+	EventID    string // comments only
+	Reason     domain.ReportReason
+	Details    string
+}
+
+// ReportReceipt is what a reporter gets back. Duplicate is set when the
+// reporter had already reported the target; Report is then the original.
+type ReportReceipt struct {
+	Report    domain.Report
+	Duplicate bool
+}
+
+type ResolveCaseCmd struct {
+	ActorID   string
+	ActorRole string
+	CaseID    string
+	Status    domain.CaseStatus
+	Action    domain.ModerationAction
+	Note      string
+}
+
+// CasePage is one keyset page of the moderation queue.
+type CasePage struct {
+	Items      []domain.ModerationCase
+	NextCursor string
+	HasMore    bool
+}
+
+// CaseDetail is a case with its most recent reports.
+type CaseDetail struct {
+	Case    domain.ModerationCase
+	Reports []domain.Report
+}
+
+// Report records a user's report of an event, comment or user and adds it
+// to the target's open moderation case, opening one if the target has none
+// (a closed case is never reused). Each reporter counts once per case.
+// When a case reaches the auto-hide threshold the event is unpublished or
+// the comment removed until a moderator reviews it.
+func (s *Service) Report(ctx context.Context, cmd ReportCmd) (*ReportReceipt, error) {
+	if strings.TrimSpace(cmd.ActorID) == "" {
+		return nil, domain.ErrForbidden("not allowed")
+	}
+	details, err := domain.NormalizeReport(cmd.TargetType, cmd.TargetID, cmd.EventID, cmd.Reason, cmd.Details)
+	if err != nil {
+		return nil, err
+	}
+
+	eventID, err := s.checkReportTarget(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now().UTC()
+	var (
+		out         *ReportReceipt
+		hiddenEvent string
+	)
+	err = s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		c, err := activeCaseForUpdate(ctx, r, cmd.TargetType, cmd.TargetID, eventID, now)
+		if err != nil {
+			return err
+		}
+
+		rp := domain.Report{
+			ID:         uuid.NewString(),
+			CaseID:     c.ID,
+			ReporterID: cmd.ActorID,
+			Reason:     cmd.Reason,
+			Details:    details,
+			CreatedAt:  now,
+		}
+		inserted, err := r.InsertReport(ctx, &rp)
+		if err != nil {
+			return err
+		}
+		out = &ReportReceipt{Report: rp, Duplicate: !inserted}
+		if !inserted {
+			return nil
+		}
+
+		c.AddReport(cmd.Reason, now)
+		if c.ShouldAutoHide(s.reportAutoHideThreshold) {
+			hidden, err := autoHideTx(ctx, r, c, now)
+			if err != nil {
+				return err
+			}
+			if hidden && c.TargetType == domain.ReportTargetEvent {
+				hiddenEvent = c.EventID
+			}
+			c.AutoHiddenAt = &now
+		}
+		return r.UpdateModerationCase(ctx, *c)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateDetails(ctx, hiddenEvent)
+	return out, nil
+}
+
+// ListModerationQueue pages cases in one status (open by default), most
+// recently reported first. Moderators and admins only.
+func (s *Service) ListModerationQueue(ctx context.Context, actorRole string, status domain.CaseStatus, target domain.ReportTarget, cursor string, limit int) (*CasePage, error) {
+	if !isModerator(actorRole) && !isAdmin(actorRole) {
+		return nil, domain.ErrForbidden("not allowed")
+	}
+	if status == "" {
+		status = domain.CaseOpen
+	}
+	meta := map[string]string{}
+	if !status.Valid() {
+		meta["status"] = "must be open, triaged, actioned or dismissed"
+	}
+	if target != "" && !target.Valid() {
+		meta["target_type"] = "must be event, comment or user"
+	}
+	if len(meta) > 0 {
+		return nil, domain.ErrValidationMeta("invalid filter", meta)
+	}
+
+	beforeReported, beforeID, hasCursor, err := parseTimeCursorOrEmpty(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	limit = commentPageSize(limit)
+	items, err := s.repo.ListModerationCases(ctx, status, target, hasCursor, beforeReported, beforeID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &CasePage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+		last := page.Items[limit-1]
+		page.NextCursor = formatTimeCursor(last.LastReportedAt.UTC(), last.ID)
+	}
+	return page, nil
+}
+
+// GetModerationCase returns a case with its most recent reports.
+func (s *Service) GetModerationCase(ctx context.Context, actorRole, caseID string) (*CaseDetail, error) {
+	if !isModerator(actorRole) && !isAdmin(actorRole) {
+		return nil, domain.ErrForbidden("not allowed")
+	}
+	c, err := s.repo.GetModerationCase(ctx, caseID)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := s.repo.ListCaseReports(ctx, c.ID, maxCaseReports)
+	if err != nil {
+		return nil, err
+	}
+	return &CaseDetail{Case: *c, Reports: reports}, nil
+}
+
+// TriageCase claims an open case for the acting moderator.
+func (s *Service) TriageCase(ctx context.Context, actorID, actorRole, caseID string) (*domain.ModerationCase, error) {
+	if !isModerator(actorRole) && !isAdmin(actorRole) {
+		return nil, domain.ErrForbidden("not allowed")
+	}
+
+	var out *domain.ModerationCase
+	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		c, err := r.GetModerationCaseForUpdate(ctx, caseID)
+		if err != nil {
+			return err
+		}
+		if err := c.Triage(actorID, s.clock.Now()); err != nil {
+			return err
+		}
+		if err := r.UpdateModerationCase(ctx, *c); err != nil {
+			return err
+		}
+		out = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ResolveCase closes a case. Actioning a case carries out its action on the
+// target in the same transaction, so the action is always linked to the
+// reports. ban_user bans the account in auth-service first; the ban is
+// idempotent, so a resolve that fails afterwards can simply be retried.
+// Dismissing a case restores content the report threshold had hidden.
+func (s *Service) ResolveCase(ctx context.Context, cmd ResolveCaseCmd) (*domain.ModerationCase, error) {
+	if !isModerator(cmd.ActorRole) && !isAdmin(cmd.ActorRole) {
+		return nil, domain.ErrForbidden("not allowed")
+	}
+	if cmd.Status == domain.CaseActioned && cmd.Action == domain.ActionBanUser {
+		if err := s.banReportedUser(ctx, cmd); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		out          *domain.ModerationCase
+		changedEvent string
+	)
+	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		c, err := r.GetModerationCaseForUpdate(ctx, cmd.CaseID)
+		if err != nil {
+			return err
+		}
+		now := s.clock.Now().UTC()
+		if err := c.Resolve(cmd.ActorID, cmd.Status, cmd.Action, cmd.Note, now); err != nil {
+			return err
+		}
+
+		changed := false
+		if c.Status == domain.CaseActioned {
+			changed, err = applyModerationActionTx(ctx, r, c, cmd.ActorID, cmd.ActorRole, now)
+		} else if c.AutoHiddenAt != nil {
+			changed, err = restoreAutoHiddenTx(ctx, r, c, cmd.ActorID, cmd.ActorRole, now)
+		}
+		if err != nil {
+			return err
+		}
+		if changed && c.TargetType == domain.ReportTargetEvent {
+			changedEvent = c.EventID
+		}
+
+		if err := r.UpdateModerationCase(ctx, *c); err != nil {
+			return err
+		}
+		out = c
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateDetails(ctx, changedEvent)
+	return out, nil
+}
+
+// banReportedUser validates a ban_user resolution and carries out the ban
+// before the case is locked, so a slow auth-service holds no row lock.
+func (s *Service) banReportedUser(ctx context.Context, cmd ResolveCaseCmd) error {
+	if s.accounts == nil {
+		return domain.ErrInvalidState("account bans are not available")
+	}
+	c, err := s.repo.GetModerationCase(ctx, cmd.CaseID)
+	if err != nil {
+		return err
+	}
+	// Validate on a copy; the transaction resolves the locked row.
+	probe := *c
+	if err := probe.Resolve(cmd.ActorID, cmd.Status, cmd.Action, cmd.Note, s.clock.Now()); err != nil {
+		return err
+	}
+	return s.accounts.BanUser(ctx, cmd.ActorID, cmd.ActorRole, c.TargetID)
+}
+
+// activeCaseForUpdate locks the target's open or triaged case, opening one
+// when there is none. A case closed between the insert and the lock is
+// skipped by the locking read, so the lookup is retried once.
+func activeCaseForUpdate(ctx context.Context, r TxEventRepo, target domain.ReportTarget, targetID, eventID string, now time.Time) (*domain.ModerationCase, error) {
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if err = r.EnsureModerationCase(ctx, domain.ModerationCase{
+			ID:         uuid.NewString(),
+			TargetType: target,
+			TargetID:   targetID,
+			EventID:    eventID,
+			CreatedAt:  now,
+		}); err != nil {
+			return nil, err
+		}
+		var c *domain.ModerationCase
+		c, err = r.GetActiveModerationCaseForUpdate(ctx, target, targetID)
+		if err == nil {
+			return c, nil
+		}
+		if !isNotFound(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func isNotFound(err error) bool {
+	var appErr *domain.AppError
+	return errors.As(err, &appErr) && appErr.Code == domain.CodeNotFound
+}
+
+// checkReportTarget makes sure the target exists and is not the reporter's
+// own, and returns the event it belongs to.
+func (s *Service) checkReportTarget(ctx context.Context, cmd ReportCmd) (string, error) {
+	switch cmd.TargetType {
+	case domain.ReportTargetEvent:
+		ev, err := s.repo.GetByID(ctx, cmd.TargetID)
+		if err != nil {
+			return "", err
+		}
+		if ev.Status != domain.StatusPublished {
+			return "", domain.ErrNotFound("event not found")
+		}
+		if ev.OwnerID == cmd.ActorID {
+			return "", domain.ErrInvalidState("cannot report your own event")
+		}
+		return ev.ID, nil
+
+	case domain.ReportTargetComment:
+		c, err := s.repo.GetComment(ctx, cmd.EventID, cmd.TargetID)
+		if err != nil {
+			return "", err
+		}
+		if c.IsDeleted() {
+			return "", domain.ErrNotFound("comment not found")
+		}
+		if c.AuthorID == cmd.ActorID {
+			return "", domain.ErrInvalidState("cannot report your own comment")
+		}
+		return c.EventID, nil
+
+	default:
+		if cmd.TargetID == cmd.ActorID {
+			return "", domain.ErrInvalidState("cannot report yourself")
+		}
+		return "", nil
+	}
+}
+
+// autoHideTx hides the case's target pending review. It reports whether
+// anything changed; content already hidden by other means is left alone.
+func autoHideTx(ctx context.Context, r TxEventRepo, c *domain.ModerationCase, now time.Time) (bool, error) {
+	switch c.TargetType {
+	case domain.ReportTargetEvent:
+		ev, err := r.GetByIDForUpdate(ctx, c.EventID)
+		if err != nil {
+			return false, err
+		}
+		if ev.Status != domain.StatusPublished {
+			return false, nil
+		}
+		return true, unpublishTx(ctx, r, ev, now, autoHideReason, domain.SystemActor, domain.SystemActor)
+
+	case domain.ReportTargetComment:
+		cm, err := r.GetCommentForUpdate(ctx, c.EventID, c.TargetID)
+		if err != nil {
+			return false, err
+		}
+		if cm.IsDeleted() {
+			return false, nil
+		}
+		return true, removeCommentTx(ctx, r, cm, domain.SystemActor, autoHideReason, now)
+	}
+	return false, nil
+}
+
+// applyModerationActionTx carries out an actioned case's action.
+func applyModerationActionTx(ctx context.Context, r TxEventRepo, c *domain.ModerationCase, actorID, actorRole string, now time.Time) (bool, error) {
+	switch c.Action {
+	case domain.ActionUnpublishEvent, domain.ActionCancelEvent:
+		ev, err := r.GetByIDForUpdate(ctx, c.EventID)
+		if err != nil {
+			return false, err
+		}
+		if ev.Status == domain.StatusCanceled {
+			return false, domain.ErrInvalidState("event already canceled")
+		}
+		if c.Action == domain.ActionCancelEvent {
+			return true, cancelTx(ctx, r, ev, now, c.ResolutionNote, actorID, actorRole)
+		}
+		// Already unpublished by the report threshold: nothing left to do.
+		if ev.Status != domain.StatusPublished {
+			return false, nil
+		}
+		return true, unpublishTx(ctx, r, ev, now, c.ResolutionNote, actorID, actorRole)
+
+	case domain.ActionRemoveComment:
+		cm, err := r.GetCommentForUpdate(ctx, c.EventID, c.TargetID)
+		if err != nil {
+			return false, err
+		}
+		if cm.IsDeleted() {
+			if cm.DeletedBy != domain.SystemActor {
+				return false, nil
+			}
+			// Keep the hidden post hidden, now as the moderator's removal.
+			cm.DeletedBy = actorID
+			cm.RemovalReason = c.ResolutionNote
+			cm.UpdatedAt = now
+			return false, r.UpdateComment(ctx, *cm)
+		}
+		return false, removeCommentTx(ctx, r, cm, actorID, c.ResolutionNote, now)
+	}
+	return false, nil
+}
+
+// restoreAutoHiddenTx undoes autoHideTx when a case is dismissed. Content
+// changed since (an organizer unpublished, an author deleted) stays as is,
+// and events that have already started are not republished.
+func restoreAutoHiddenTx(ctx context.Context, r TxEventRepo, c *domain.ModerationCase, actorID, actorRole string, now time.Time) (bool, error) {
+	switch c.TargetType {
+	case domain.ReportTargetEvent:
+		ev, err := r.GetByIDForUpdate(ctx, c.EventID)
+		if err != nil {
+			return false, err
+		}
+		if ev.Status != domain.StatusDraft || ev.UpdatedAt.After(*c.AutoHiddenAt) || !ev.StartTime.After(now) {
+			return false, nil
+		}
+		return true, publishTx(ctx, r, ev, now, "restored after review", actorID, actorRole)
+
+	case domain.ReportTargetComment:
+		cm, err := r.GetCommentForUpdate(ctx, c.EventID, c.TargetID)
+		if err != nil {
+			return false, err
+		}
+		if !cm.IsDeleted() || cm.DeletedBy != domain.SystemActor {
+			return false, nil
+		}
+		return false, restoreCommentTx(ctx, r, cm, now)
+	}
+	return false, nil
+}
@@ -0,0 +1,261 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/google/uuid"
+)
+
+const RoutingKeyReputationUpdated = "event.reputation.updated"
+
+type SubmitReviewCmd struct {
+	ActorID string
+	EventID string
+	Rating  int
+	Body    string
+}
+
+type SetReviewHiddenCmd struct {
+	ActorID   string
+	ActorRole string
+	EventID   string
+	ReviewID  string
+	Hidden    bool
+	Reason    string // required when hiding
+}
+
+// ReviewPage is one keyset page of an event's visible reviews.
+type ReviewPage struct {
+	Summary    domain.RatingSummary
+	Items      []domain.Review
+	NextCursor string
+	HasMore    bool
+}
+
+// ListReviews pages the visible reviews of a published event, newest first.
+func (s *Service) ListReviews(ctx context.Context, eventID, cursor string, limit int) (*ReviewPage, error) {
+	beforeCreated, beforeID, hasCursor, err := parseTimeCursorOrEmpty(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetPublic(ctx, eventID); err != nil {
+		return nil, err
+	}
+
+	limit = commentPageSize(limit)
+	items, err := s.repo.ListReviews(ctx, eventID, hasCursor, beforeCreated, beforeID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := s.repo.EventRatingSummary(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ReviewPage{Summary: summary, Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+		last := page.Items[limit-1]
+		page.NextCursor = formatTimeCursor(last.CreatedAt.UTC(), last.ID)
+	}
+	return page, nil
+}
+
+// GetOrganizerReputation aggregates the visible reviews of every event the
+// organizer ran.
+func (s *Service) GetOrganizerReputation(ctx context.Context, organizerID string) (domain.RatingSummary, error) {
+	return s.repo.OrganizerRatingSummary(ctx, organizerID)
+}
+
+// SubmitReview creates or replaces the actor's review of an event. Only
+// attendees whose join is active in join-service may review, and only within
+// domain.ReviewWindow after the event ends. The organizer's new reputation is
+// written with event.reputation.updated in the same transaction.
+func (s *Service) SubmitReview(ctx context.Context, cmd SubmitReviewCmd) (*domain.Review, error) {
+	if strings.TrimSpace(cmd.ActorID) == "" {
+		return nil, domain.ErrForbidden("not allowed")
+	}
+	rating, body, err := domain.NormalizeReview(cmd.Rating, cmd.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	ev, err := s.repo.GetByID(ctx, cmd.EventID)
+	if err != nil {
+		return nil, err
+	}
+	now := s.clock.Now().UTC()
+	if err := domain.CheckReviewWindow(ev, now); err != nil {
+		return nil, err
+	}
+	if ev.OwnerID == cmd.ActorID {
+		return nil, domain.ErrForbidden("organizers cannot review their own events")
+	}
+	role, err := s.repo.GetCollaboratorRole(ctx, ev.ID, cmd.ActorID)
+	if err != nil {
+		return nil, err
+	}
+	if role.Valid() {
+		return nil, domain.ErrForbidden("organizers cannot review their own events")
+	}
+	if err := s.checkAttended(ctx, ev.ID, cmd.ActorID); err != nil {
+		return nil, err
+	}
+
+	var out *domain.Review
+	err = s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		if err := r.LockOrganizerRatings(ctx, ev.OwnerID); err != nil {
+			return err
+		}
+		rv, err := r.GetReviewByAuthorForUpdate(ctx, ev.ID, cmd.ActorID)
+		if err != nil {
+			return err
+		}
+
+		if rv == nil {
+			rv = &domain.Review{
+				ID:          uuid.NewString(),
+				EventID:     ev.ID,
+				OrganizerID: ev.OwnerID,
+				AuthorID:    cmd.ActorID,
+				Rating:      rating,
+				Body:        body,
+				CreatedAt:   now,
+				UpdatedAt:   now,
+			}
+			if err := r.InsertReview(ctx, *rv); err != nil {
+				return err
+			}
+		} else {
+			if rv.IsHidden() {
+				return domain.ErrInvalidState("review was hidden by a moderator")
+			}
+			// The review stays with the organizer it was first written for.
+			if rv.OrganizerID != ev.OwnerID {
+				if err := r.LockOrganizerRatings(ctx, rv.OrganizerID); err != nil {
+					return err
+				}
+			}
+			rv.Rating = rating
+			rv.Body = body
+			rv.UpdatedAt = now
+			if err := r.UpdateReview(ctx, *rv); err != nil {
+				return err
+			}
+		}
+
+		if err := insertReputationOutbox(ctx, r, rv.OrganizerID, ev.ID, now); err != nil {
+			return err
+		}
+		out = rv
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SetReviewHidden hides or restores a review. Moderators and admins only;
+// organizers cannot hide reviews of their own events.
+func (s *Service) SetReviewHidden(ctx context.Context, cmd SetReviewHiddenCmd) (*domain.Review, error) {
+	if !isModerator(cmd.ActorRole) && !isAdmin(cmd.ActorRole) {
+		return nil, domain.ErrForbidden("not allowed")
+	}
+
+	// organizer_id never changes, so it can be read before taking the
+	// advisory lock; the lock is always taken before the row lock.
+	cur, err := s.repo.GetReview(ctx, cmd.EventID, cmd.ReviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out *domain.Review
+	err = s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		if err := r.LockOrganizerRatings(ctx, cur.OrganizerID); err != nil {
+			return err
+		}
+		rv, err := r.GetReviewForUpdate(ctx, cmd.EventID, cmd.ReviewID)
+		if err != nil {
+			return err
+		}
+
+		now := s.clock.Now().UTC()
+		if cmd.Hidden {
+			err = rv.Hide(cmd.ActorID, cmd.Reason, now)
+		} else {
+			err = rv.Unhide(now)
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.UpdateReview(ctx, *rv); err != nil {
+			return err
+		}
+		if err := insertReputationOutbox(ctx, r, rv.OrganizerID, rv.EventID, now); err != nil {
+			return err
+		}
+		out = rv
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// checkAttended asks join-service whether the user's join is active.
+func (s *Service) checkAttended(ctx context.Context, eventID, userID string) error {
+	if s.attendance == nil {
+		return errors.New("attendance checker not configured")
+	}
+	status, err := s.attendance.ParticipationStatus(ctx, eventID, userID)
+	if err != nil {
+		return err
+	}
+	if status != "active" {
+		return domain.ErrForbidden("only attendees can review this event")
+	}
+	return nil
+}
+
+func insertReputationOutbox(ctx context.Context, r TxEventRepo, organizerID, eventID string, now time.Time) error {
+	summary, err := r.OrganizerRatingSummary(ctx, organizerID)
+	if err != nil {
+		return err
+	}
+
+	messageID := uuid.NewString()
+	env := DomainEventEnvelope[EventReputationPayload]{
+		Version:    EventVersion,
+		Producer:   EventProducer,
+		MessageID:  messageID,
+		TraceID:    TraceIDFromContext(ctx),
+		OccurredAt: now,
+		Payload: EventReputationPayload{
+			OrganizerID: organizerID,
+			EventID:     eventID,
+			RatingAvg:   summary.Average,
+			RatingCount: summary.Count,
+			UpdatedAt:   now,
+		},
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return r.InsertOutbox(ctx, OutboxMessage{
+		MessageID:  messageID,
+		RoutingKey: RoutingKeyReputationUpdated,
+		Body:       body,
+		CreatedAt:  now,
+	})
+}
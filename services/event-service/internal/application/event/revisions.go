@@ -0,0 +1,122 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+// RoutingKeyEventChanged announces a material change (time, city,
+// capacity) to a published event; join-service fans it out to attendees.
+const RoutingKeyEventChanged = "event.changed"
+
+// EventChangedPayload is the business payload for routing key:
+// event.changed. Changes only holds material fields (domain.MaterialFields);
+// EventVersion identifies the revision for de-duplication.
+type EventChangedPayload struct {
+	EventID      string                        `json:"event_id"`
+	EventTitle   string                        `json:"event_title"`
+	EventVersion int64                         `json:"event_version"`
+	Changes      map[string]domain.FieldChange `json:"changes"`
+	ActorRole    string                        `json:"actor_role,omitempty"`
+	ChangedAt    time.Time                     `json:"changed_at"`
+}
+
+type RevisionPage struct {
+	Items      []domain.Revision
+	NextCursor string
+	HasMore    bool
+}
+
+// ListRevisions pages an event's change history, newest first. It is open
+// to the organizer team and moderators.
+func (s *Service) ListRevisions(ctx context.Context, eventID, actorID, actorRole, cursor string, limit int) (*RevisionPage, error) {
+	beforeCreated, beforeID, hasCursor, err := parseTimeCursorOrEmpty(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.GetForOwner(ctx, eventID, actorID, actorRole); err != nil {
+		return nil, err
+	}
+
+	limit = commentPageSize(limit)
+	items, err := s.repo.ListRevisions(ctx, eventID, hasCursor, beforeCreated, beforeID, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &RevisionPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasMore = true
+		last := page.Items[limit-1]
+		page.NextCursor = formatTimeCursor(last.CreatedAt.UTC(), last.ID)
+	}
+	return page, nil
+}
+
+// recordRevisionTx logs the difference between before and ev, which must
+// already be written (ev.Version is the new version). Writes that changed
+// nothing visible are not logged.
+func recordRevisionTx(ctx context.Context, r TxEventRepo, before, ev *domain.Event, action domain.RevisionAction, actorID, actorRole, reason string, now time.Time) error {
+	changes := domain.DiffEvents(before, ev)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	rv := domain.Revision{
+		ID:        uuid.NewString(),
+		EventID:   ev.ID,
+		Version:   ev.Version,
+		Action:    action,
+		ActorID:   actorID,
+		ActorRole: actorRole,
+		Changes:   changes,
+		Reason:    reason,
+		CreatedAt: now,
+	}
+	if err := r.InsertRevision(ctx, rv); err != nil {
+		return err
+	}
+
+	material := domain.MaterialChanges(changes)
+	if action != domain.RevisionUpdate || ev.Status != domain.StatusPublished || len(material) == 0 {
+		return nil
+	}
+	return insertChangedOutbox(ctx, r, ev, rv.Version, material, actorRole, now)
+}
+
+func insertChangedOutbox(ctx context.Context, r TxEventRepo, ev *domain.Event, version int64, changes map[string]domain.FieldChange, actorRole string, now time.Time) error {
+	messageID := uuid.NewString()
+	env := DomainEventEnvelope[EventChangedPayload]{
+		Version:    EventVersion,
+		Producer:   EventProducer,
+		MessageID:  messageID,
+		TraceID:    TraceIDFromContext(ctx),
+		OccurredAt: now,
+		Payload: EventChangedPayload{
+			EventID:      ev.ID,
+			EventTitle:   ev.Title,
+			EventVersion: version,
+			Changes:      changes,
+			ActorRole:    actorRole,
+			ChangedAt:    now,
+		},
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return r.InsertOutbox(ctx, OutboxMessage{
+		MessageID:  messageID,
+		RoutingKey: RoutingKeyEventChanged,
+		Body:       body,
+		CreatedAt:  now,
+	})
+}
@@ -0,0 +1,159 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	zlog "github.com/rs/zerolog/log"
+)
+
+const (
+	TransitionPublish   = "publish"
+	TransitionUnpublish = "unpublish"
+
+	// ScheduledReason and ScheduledActorRole mark outbox messages written by
+	// the scheduler rather than a person.
+	ScheduledReason    = "scheduled"
+	ScheduledActorRole = "system"
+
+	scheduledBatch = 100
+)
+
+// ScheduledTransition is a publish_at / unpublish_at that has come due
+type ScheduledTransition struct {
+	EventID string
+	Action  string
+	DueAt   time.Time
+}
+
+// UpdateSchedule replaces the event's schedule. While the event is published,
+// a changed registration window is re-announced as event.updated so
+// join-service enforces the new dates.
+func (s *Service) UpdateSchedule(ctx context.Context, eventID, actorID, actorRole string, sched domain.Schedule) (*domain.Event, error) {
+	var out *domain.Event
+
+	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		ev, err := r.GetByIDForUpdate(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if err := authorize(ctx, r, ev, actorID, actorRole, domain.CollaboratorRole.CanManage); err != nil {
+			return err
+		}
+
+		now := s.clock.Now().UTC()
+		before := *ev
+		if err := ev.SetSchedule(sched, now); err != nil {
+			return err
+		}
+		if err := r.Update(ctx, ev); err != nil {
+			return err
+		}
+		if err := recordRevisionTx(ctx, r, &before, ev, domain.RevisionSchedule, actorID, actorRole, "", now); err != nil {
+			return err
+		}
+
+		if ev.Status == domain.StatusPublished &&
+			(!timePtrEqual(before.RegistrationOpensAt, ev.RegistrationOpensAt) ||
+				!timePtrEqual(before.RegistrationClosesAt, ev.RegistrationClosesAt)) {
+			if err := insertPublishedOutbox(ctx, r, "event.updated", publishedPayload(ev), now); err != nil {
+				return err
+			}
+		}
+
+		out = ev
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateDetails(ctx, out.ID)
+	return out, nil
+}
+
+// RunScheduledTransitions applies every publish_at / unpublish_at due now.
+// Each event gets its own transaction, so one failure does not hold back
+// the rest; it is retried on the next run.
+func (s *Service) RunScheduledTransitions(ctx context.Context) (int, error) {
+	now := s.clock.Now().UTC()
+	due, err := s.repo.ListDueTransitions(ctx, now, scheduledBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, t := range due {
+		ok, err := s.applyScheduled(ctx, t, now)
+		if err != nil {
+			zlog.Warn().Err(err).Str("event_id", t.EventID).Str("action", t.Action).Msg("scheduled transition failed")
+			continue
+		}
+		if ok {
+			applied++
+		}
+	}
+	return applied, nil
+}
+
+// applyScheduled re-checks the transition under the row lock: the organizer
+// may have published, unpublished or rescheduled since it was listed.
+func (s *Service) applyScheduled(ctx context.Context, t ScheduledTransition, now time.Time) (bool, error) {
+	applied := false
+
+	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		ev, err := r.GetByIDForUpdate(ctx, t.EventID)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case t.Action == TransitionPublish && ev.PublishDue(now):
+			// Same rule as a manual Publish: a missed window is dropped,
+			// not published late.
+			if !ev.StartTime.IsZero() && ev.StartTime.Before(now.Add(-5*time.Minute)) {
+				zlog.Warn().Str("event_id", ev.ID).Msg("scheduled publish skipped: event already started")
+				before := *ev
+				ev.PublishAt = nil
+				ev.UpdatedAt = now
+				if err := r.Update(ctx, ev); err != nil {
+					return err
+				}
+				return recordRevisionTx(ctx, r, &before, ev, domain.RevisionSchedule, domain.SystemActor, ScheduledActorRole, "event already started", now)
+			}
+			applied = true
+			return publishTx(ctx, r, ev, now, ScheduledReason, domain.SystemActor, ScheduledActorRole)
+
+		case t.Action == TransitionUnpublish && ev.UnpublishDue(now):
+			applied = true
+			return unpublishTx(ctx, r, ev, now, ScheduledReason, domain.SystemActor, ScheduledActorRole)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if applied {
+		s.invalidateDetails(ctx, t.EventID)
+	}
+	return applied, nil
+}
+
+func (s *Service) invalidateDetails(ctx context.Context, eventID string) {
+	if s.cache == nil || eventID == "" {
+		return
+	}
+	key := cacheKeyEventDetails(eventID)
+	if err := s.cache.Delete(ctx, key); err != nil {
+		zlog.Warn().Err(err).Str("key", key).Msg("cache invalidate failed")
+	}
+}
+
+func timePtrEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
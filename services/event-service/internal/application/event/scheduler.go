@@ -0,0 +1,64 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	zlog "github.com/rs/zerolog/log"
+)
+
+// LeaderLock elects the one replica that runs scheduled transitions.
+// ok is false when another replica holds it; otherwise unlock must be called.
+type LeaderLock interface {
+	TryLock(ctx context.Context) (unlock func(), ok bool, err error)
+}
+
+// Scheduler periodically runs RunScheduledTransitions on whichever replica
+// wins the leader lock for that tick. Transitions re-check their state under
+// a row lock, so a tick overlapping a manual publish is harmless.
+type Scheduler struct {
+	svc      *Service
+	lock     LeaderLock
+	interval time.Duration
+}
+
+func NewScheduler(svc *Service, lock LeaderLock, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	return &Scheduler{svc: svc, lock: lock, interval: interval}
+}
+
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := s.Tick(ctx); err != nil {
+					zlog.Warn().Err(err).Msg("scheduler tick failed")
+				}
+			}
+		}
+	}()
+}
+
+// Tick runs one pass if this replica is the leader and reports how many
+// transitions it applied.
+func (s *Scheduler) Tick(ctx context.Context) (int, error) {
+	unlock, ok, err := s.lock.TryLock(ctx)
+	if err != nil || !ok {
+		return 0, err
+	}
+	defer unlock()
+
+	n, err := s.svc.RunScheduledTransitions(ctx)
+	if n > 0 {
+		zlog.Info().Int("applied", n).Msg("scheduled transitions applied")
+	}
+	return n, err
+}
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"strings"
 	"time"
 
@@ -17,6 +18,22 @@ type Service struct {
 	// Config for TTLs
 	ttlDetails time.Duration
 	ttlList    time.Duration
+
+	// Signs invite links; shared with join-service.
+	inviteSecret []byte
+
+	// Verifies reviewers attended; reviews are refused without it.
+	attendance AttendanceChecker
+
+	// Validates cover image references; nil skips the check.
+	media MediaChecker
+
+	// Carries out ban_user moderation actions; they are refused without it.
+	accounts AccountBanner
+
+	// Distinct reporters after which reported content is hidden pending
+	// review; 0 disables auto-hide.
+	reportAutoHideThreshold int
 }
 
 func New(
@@ -42,6 +59,22 @@ func New(
 	}
 }
 
+// SetInviteSecret sets the INVITE_TOKEN_SECRET used to sign invite links.
+func (s *Service) SetInviteSecret(secret string) { s.inviteSecret = []byte(secret) }
+
+// SetAttendanceChecker sets the join-service client used to verify reviewers.
+func (s *Service) SetAttendanceChecker(a AttendanceChecker) { s.attendance = a }
+
+// SetMediaChecker sets the media-service client used to validate covers.
+func (s *Service) SetMediaChecker(m MediaChecker) { s.media = m }
+
+// SetAccountBanner sets the auth-service client that carries out ban_user.
+func (s *Service) SetAccountBanner(a AccountBanner) { s.accounts = a }
+
+// SetReportAutoHideThreshold sets how many distinct reporters hide an event
+// or comment pending review; 0 disables auto-hide.
+func (s *Service) SetReportAutoHideThreshold(n int) { s.reportAutoHideThreshold = n }
+
 func isUser(role string) bool      { return role == "user" }
 func isModerator(role string) bool { return role == "moderator" }
 func isAdmin(role string) bool     { return role == "admin" }
@@ -63,7 +96,42 @@ func canManage(actorID, actorRole, ownerID string) bool {
 	}
 	return strings.TrimSpace(actorID) != "" && actorID == ownerID
 }
-func canEdit(ownerID, actorID, actorRole string) bool {
-	// keep legacy name used across handlers
-	return canManage(actorID, actorRole, ownerID)
+
+// collaboratorRoles is satisfied by both EventRepo and TxEventRepo, so the
+// check can run inside or outside a transaction.
+type collaboratorRoles interface {
+	GetCollaboratorRole(ctx context.Context, eventID, userID string) (domain.CollaboratorRole, error)
+}
+
+// authorize extends canManage to the event's organizer team: allow decides
+// which collaborator roles pass.
+func authorize(ctx context.Context, r collaboratorRoles, ev *domain.Event, actorID, actorRole string, allow func(domain.CollaboratorRole) bool) error {
+	if canManage(actorID, actorRole, ev.OwnerID) {
+		return nil
+	}
+	if strings.TrimSpace(actorID) == "" {
+		return domain.ErrForbidden("not allowed")
+	}
+	role, err := r.GetCollaboratorRole(ctx, ev.ID, actorID)
+	if err != nil {
+		return err
+	}
+	if !role.Valid() || !allow(role) {
+		return domain.ErrForbidden("not allowed")
+	}
+	return nil
+}
+
+func anyCollaborator(domain.CollaboratorRole) bool { return true }
+
+// PurposeEventCover is the media-service upload purpose for covers.
+const PurposeEventCover = "event_cover"
+
+// claimCovers validates cover uploads with media-service. The uploader is
+// whoever attaches the image, so ids must belong to actorID.
+func (s *Service) claimCovers(ctx context.Context, actorID string, ids []string) error {
+	if s.media == nil || len(ids) == 0 {
+		return nil
+	}
+	return s.media.ClaimUploads(ctx, actorID, PurposeEventCover, ids)
 }
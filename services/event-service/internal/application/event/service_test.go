@@ -2,6 +2,9 @@ package event
 
 import (
 	"context"
+	"encoding/json"
+	"slices"
+	"sort"
 	"testing"
 	"time"
 
@@ -39,10 +42,28 @@ func (m *mockCache) Delete(ctx context.Context, keys ...string) error {
 
 // memRepo 实现了 EventRepo 和 TxEventRepo (为了简化测试)
 type memRepo struct {
-	byID map[string]*domain.Event
+	byID      map[string]*domain.Event
+	collabs   map[string][]domain.Collaborator
+	invites   map[string][]domain.Invite
+	comments  map[string]domain.Comment
+	reviews   map[string]domain.Review
+	cases     map[string]domain.ModerationCase
+	reports   []domain.Report
+	revisions []domain.Revision
+	outbox    []OutboxMessage
+	inTx      bool
 }
 
-func newMemRepo() *memRepo { return &memRepo{byID: map[string]*domain.Event{}} }
+func newMemRepo() *memRepo {
+	return &memRepo{
+		byID:     map[string]*domain.Event{},
+		collabs:  map[string][]domain.Collaborator{},
+		invites:  map[string][]domain.Invite{},
+		comments: map[string]domain.Comment{},
+		reviews:  map[string]domain.Review{},
+		cases:    map[string]domain.ModerationCase{},
+	}
+}
 
 func (m *memRepo) Create(ctx context.Context, e *domain.Event) error {
 	m.byID[e.ID] = e
@@ -62,16 +83,428 @@ func (m *memRepo) GetByIDForUpdate(ctx context.Context, id string) (*domain.Even
 }
 
 func (m *memRepo) Update(ctx context.Context, e *domain.Event) error {
+	e.Version++
 	m.byID[e.ID] = e
 	return nil
 }
 
 func (m *memRepo) InsertOutbox(ctx context.Context, msg OutboxMessage) error {
+	m.outbox = append(m.outbox, msg)
+	return nil
+}
+
+func (m *memRepo) GetCollaboratorRole(ctx context.Context, eventID, userID string) (domain.CollaboratorRole, error) {
+	for _, c := range m.collabs[eventID] {
+		if c.UserID == userID {
+			return c.Role, nil
+		}
+	}
+	return "", nil
+}
+
+func (m *memRepo) ListCollaborators(ctx context.Context, eventID string) ([]domain.Collaborator, error) {
+	return append([]domain.Collaborator{}, m.collabs[eventID]...), nil
+}
+
+func (m *memRepo) UpsertCollaborator(ctx context.Context, c domain.Collaborator) error {
+	for i, it := range m.collabs[c.EventID] {
+		if it.UserID == c.UserID {
+			m.collabs[c.EventID][i] = c
+			return nil
+		}
+	}
+	m.collabs[c.EventID] = append(m.collabs[c.EventID], c)
+	return nil
+}
+
+func (m *memRepo) DeleteCollaborator(ctx context.Context, eventID, userID string) (bool, error) {
+	for i, it := range m.collabs[eventID] {
+		if it.UserID == userID {
+			m.collabs[eventID] = append(m.collabs[eventID][:i], m.collabs[eventID][i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memRepo) SetOwner(ctx context.Context, eventID, ownerID string, at time.Time) error {
+	m.byID[eventID].OwnerID = ownerID
+	m.byID[eventID].UpdatedAt = at
+	return nil
+}
+
+func (m *memRepo) ListInvites(ctx context.Context, eventID string) ([]domain.Invite, error) {
+	return append([]domain.Invite{}, m.invites[eventID]...), nil
+}
+
+func (m *memRepo) InsertInvite(ctx context.Context, inv domain.Invite) error {
+	m.invites[inv.EventID] = append(m.invites[inv.EventID], inv)
+	return nil
+}
+
+func (m *memRepo) RevokeInvite(ctx context.Context, eventID, inviteID string, at time.Time) (bool, error) {
+	for i, it := range m.invites[eventID] {
+		if it.ID == inviteID && it.RevokedAt == nil {
+			m.invites[eventID][i].RevokedAt = &at
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memRepo) IncrementInviteUse(ctx context.Context, eventID, inviteID string) error {
+	for i, it := range m.invites[eventID] {
+		if it.ID == inviteID {
+			m.invites[eventID][i].UseCount++
+			return nil
+		}
+	}
+	return domain.ErrNotFound("invite not found")
+}
+
+func (m *memRepo) filterComments(keep func(c domain.Comment) bool, newestFirst bool) []domain.Comment {
+	out := []domain.Comment{}
+	for _, c := range m.comments {
+		if keep(c) {
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if newestFirst {
+			a, b = b, a
+		}
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		return a.ID < b.ID
+	})
+	return out
+}
+
+func (m *memRepo) ListComments(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Comment, error) {
+	out := m.filterComments(func(c domain.Comment) bool {
+		if c.EventID != eventID || c.IsReply() || (c.IsDeleted() && c.ReplyCount == 0) {
+			return false
+		}
+		return !hasCursor || c.CreatedAt.Before(beforeCreated) || (c.CreatedAt.Equal(beforeCreated) && c.ID < beforeID)
+	}, true)
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *memRepo) ListReplies(ctx context.Context, parentID string, hasCursor bool, afterCreated time.Time, afterID string, limit int) ([]domain.Comment, error) {
+	out := m.filterComments(func(c domain.Comment) bool {
+		if c.ParentID == nil || *c.ParentID != parentID {
+			return false
+		}
+		return !hasCursor || c.CreatedAt.After(afterCreated) || (c.CreatedAt.Equal(afterCreated) && c.ID > afterID)
+	}, false)
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *memRepo) ListPinnedComments(ctx context.Context, eventID string) ([]domain.Comment, error) {
+	return m.filterComments(func(c domain.Comment) bool { return c.EventID == eventID && c.Pinned }, true), nil
+}
+
+func (m *memRepo) GetComment(ctx context.Context, eventID, commentID string) (*domain.Comment, error) {
+	c, ok := m.comments[commentID]
+	if !ok || c.EventID != eventID {
+		return nil, domain.ErrNotFound("comment not found")
+	}
+	return &c, nil
+}
+
+func (m *memRepo) GetCommentForUpdate(ctx context.Context, eventID, commentID string) (*domain.Comment, error) {
+	return m.GetComment(ctx, eventID, commentID)
+}
+
+func (m *memRepo) InsertComment(ctx context.Context, c domain.Comment) error {
+	m.comments[c.ID] = c
+	return nil
+}
+
+func (m *memRepo) UpdateComment(ctx context.Context, c domain.Comment) error {
+	m.comments[c.ID] = c
+	return nil
+}
+
+func (m *memRepo) CountPinnedComments(ctx context.Context, eventID string) (int, error) {
+	pinned, _ := m.ListPinnedComments(ctx, eventID)
+	return len(pinned), nil
+}
+
+func (m *memRepo) ListReviews(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Review, error) {
+	out := []domain.Review{}
+	for _, rv := range m.reviews {
+		if rv.EventID != eventID || rv.IsHidden() {
+			continue
+		}
+		if hasCursor && !(rv.CreatedAt.Before(beforeCreated) || (rv.CreatedAt.Equal(beforeCreated) && rv.ID < beforeID)) {
+			continue
+		}
+		out = append(out, rv)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].CreatedAt.Equal(out[j].CreatedAt) {
+			return out[i].CreatedAt.After(out[j].CreatedAt)
+		}
+		return out[i].ID > out[j].ID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *memRepo) GetReview(ctx context.Context, eventID, reviewID string) (*domain.Review, error) {
+	rv, ok := m.reviews[reviewID]
+	if !ok || rv.EventID != eventID {
+		return nil, domain.ErrNotFound("review not found")
+	}
+	return &rv, nil
+}
+
+func (m *memRepo) ratingSummary(keep func(rv domain.Review) bool) domain.RatingSummary {
+	var s domain.RatingSummary
+	sum := 0
+	for _, rv := range m.reviews {
+		if !rv.IsHidden() && keep(rv) {
+			s.Count++
+			sum += rv.Rating
+		}
+	}
+	if s.Count > 0 {
+		s.Average = float64(sum) / float64(s.Count)
+	}
+	return s
+}
+
+func (m *memRepo) EventRatingSummary(ctx context.Context, eventID string) (domain.RatingSummary, error) {
+	return m.ratingSummary(func(rv domain.Review) bool { return rv.EventID == eventID }), nil
+}
+
+func (m *memRepo) OrganizerRatingSummary(ctx context.Context, organizerID string) (domain.RatingSummary, error) {
+	return m.ratingSummary(func(rv domain.Review) bool { return rv.OrganizerID == organizerID }), nil
+}
+
+func (m *memRepo) LockOrganizerRatings(ctx context.Context, organizerID string) error { return nil }
+
+func (m *memRepo) GetReviewForUpdate(ctx context.Context, eventID, reviewID string) (*domain.Review, error) {
+	return m.GetReview(ctx, eventID, reviewID)
+}
+
+func (m *memRepo) GetReviewByAuthorForUpdate(ctx context.Context, eventID, authorID string) (*domain.Review, error) {
+	for _, rv := range m.reviews {
+		if rv.EventID == eventID && rv.AuthorID == authorID {
+			return &rv, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memRepo) InsertReview(ctx context.Context, rv domain.Review) error {
+	m.reviews[rv.ID] = rv
+	return nil
+}
+
+func (m *memRepo) UpdateReview(ctx context.Context, rv domain.Review) error {
+	m.reviews[rv.ID] = rv
+	return nil
+}
+
+func (m *memRepo) ListModerationCases(ctx context.Context, status domain.CaseStatus, target domain.ReportTarget, hasCursor bool, beforeReported time.Time, beforeID string, limit int) ([]domain.ModerationCase, error) {
+	var out []domain.ModerationCase
+	for _, c := range m.cases {
+		if c.Status != status || (target != "" && c.TargetType != target) {
+			continue
+		}
+		if hasCursor && !(c.LastReportedAt.Before(beforeReported) || c.LastReportedAt.Equal(beforeReported) && c.ID < beforeID) {
+			continue
+		}
+		out = append(out, c)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].LastReportedAt.Equal(out[j].LastReportedAt) {
+			return out[i].LastReportedAt.After(out[j].LastReportedAt)
+		}
+		return out[i].ID > out[j].ID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (m *memRepo) GetModerationCase(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	c, ok := m.cases[caseID]
+	if !ok {
+		return nil, domain.ErrNotFound("moderation case not found")
+	}
+	return &c, nil
+}
+
+func (m *memRepo) ListCaseReports(ctx context.Context, caseID string, limit int) ([]domain.Report, error) {
+	var out []domain.Report
+	for i := len(m.reports) - 1; i >= 0 && len(out) < limit; i-- {
+		if m.reports[i].CaseID == caseID {
+			out = append(out, m.reports[i])
+		}
+	}
+	return out, nil
+}
+
+func (m *memRepo) InsertRevision(ctx context.Context, rv domain.Revision) error {
+	m.revisions = append(m.revisions, rv)
+	return nil
+}
+
+// ListRevisions relies on insertion order; the test clock does not move.
+func (m *memRepo) ListRevisions(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Revision, error) {
+	out := []domain.Revision{}
+	skipping := hasCursor
+	for i := len(m.revisions) - 1; i >= 0 && len(out) < limit; i-- {
+		rv := m.revisions[i]
+		if rv.EventID != eventID {
+			continue
+		}
+		if skipping {
+			skipping = rv.ID != beforeID
+			continue
+		}
+		out = append(out, rv)
+	}
+	return out, nil
+}
+
+func (m *memRepo) ExportUserData(ctx context.Context, userID string) (json.RawMessage, error) {
+	ids := []string{}
+	for id, e := range m.byID {
+		if e.OwnerID == userID {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return json.Marshal(map[string]any{"events": ids})
+}
+
+func (m *memRepo) AnonymizeUser(ctx context.Context, userID, pseudonym string) error {
+	for _, e := range m.byID {
+		if e.OwnerID == userID {
+			e.OwnerID = pseudonym
+		}
+	}
+	for id, c := range m.comments {
+		if c.AuthorID == userID {
+			c.AuthorID = pseudonym
+			m.comments[id] = c
+		}
+	}
+	for id, rv := range m.reviews {
+		if rv.AuthorID == userID {
+			rv.AuthorID = pseudonym
+		}
+		if rv.OrganizerID == userID {
+			rv.OrganizerID = pseudonym
+		}
+		m.reviews[id] = rv
+	}
+	return nil
+}
+
+func (m *memRepo) EnsureModerationCase(ctx context.Context, c domain.ModerationCase) error {
+	for _, cur := range m.cases {
+		if cur.TargetType == c.TargetType && cur.TargetID == c.TargetID && !cur.IsClosed() {
+			return nil
+		}
+	}
+	c.Status = domain.CaseOpen
+	c.LastReportedAt = c.CreatedAt
+	c.UpdatedAt = c.CreatedAt
+	m.cases[c.ID] = c
+	return nil
+}
+
+func (m *memRepo) GetModerationCaseForUpdate(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	return m.GetModerationCase(ctx, caseID)
+}
+
+func (m *memRepo) GetActiveModerationCaseForUpdate(ctx context.Context, target domain.ReportTarget, targetID string) (*domain.ModerationCase, error) {
+	for _, c := range m.cases {
+		if c.TargetType == target && c.TargetID == targetID && !c.IsClosed() {
+			return &c, nil
+		}
+	}
+	return nil, domain.ErrNotFound("moderation case not found")
+}
+
+func (m *memRepo) UpdateModerationCase(ctx context.Context, c domain.ModerationCase) error {
+	m.cases[c.ID] = c
 	return nil
 }
 
+func (m *memRepo) InsertReport(ctx context.Context, rp *domain.Report) (bool, error) {
+	for _, cur := range m.reports {
+		if cur.CaseID == rp.CaseID && cur.ReporterID == rp.ReporterID {
+			*rp = cur
+			return false, nil
+		}
+	}
+	m.reports = append(m.reports, *rp)
+	return true, nil
+}
+
 // 模拟事务逻辑
+func (m *memRepo) ListDueTransitions(ctx context.Context, now time.Time, limit int) ([]ScheduledTransition, error) {
+	var out []ScheduledTransition
+	for _, e := range m.byID {
+		if e.PublishDue(now) {
+			out = append(out, ScheduledTransition{EventID: e.ID, Action: TransitionPublish, DueAt: *e.PublishAt})
+		}
+		if e.UnpublishDue(now) {
+			out = append(out, ScheduledTransition{EventID: e.ID, Action: TransitionUnpublish, DueAt: *e.UnpublishAt})
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].DueAt.Before(out[j].DueAt) })
+	return out, nil
+}
+
+func (m *memRepo) ListUpcomingOwnedIDs(ctx context.Context, ownerID string, now time.Time) ([]string, error) {
+	var out []string
+	for _, e := range m.byID {
+		if e.OwnerID != ownerID || !e.StartTime.After(now) {
+			continue
+		}
+		if e.Status == domain.StatusPublished || (e.Status == domain.StatusDraft && e.PublishAt != nil) {
+			out = append(out, e.ID)
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+func (m *memRepo) ListIDsByCoverImages(ctx context.Context, uploadIDs []string) ([]string, error) {
+	var out []string
+	for _, e := range m.byID {
+		for _, c := range e.CoverImageIDs {
+			if slices.Contains(uploadIDs, c) {
+				out = append(out, e.ID)
+				break
+			}
+		}
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
 func (m *memRepo) WithTx(ctx context.Context, fn func(r TxEventRepo) error) error {
+	m.inTx = true
+	defer func() { m.inTx = false }()
 	return fn(m)
 }
 
@@ -100,6 +533,26 @@ func (m *memRepo) GetCitySuggestions(ctx context.Context, query string, limit in
 	return []string{}, nil
 }
 
+func (m *memRepo) ListChangedSince(ctx context.Context, hasCursor bool, afterUpdated time.Time, afterID string, limit int) ([]EventChange, error) {
+	var out []EventChange
+	for _, e := range m.byID {
+		if e.UpdatedAt.Before(afterUpdated) || (hasCursor && e.UpdatedAt.Equal(afterUpdated) && e.ID <= afterID) {
+			continue
+		}
+		out = append(out, EventChange{ID: e.ID, Status: e.Status, UpdatedAt: e.UpdatedAt})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].UpdatedAt.Equal(out[j].UpdatedAt) {
+			return out[i].UpdatedAt.Before(out[j].UpdatedAt)
+		}
+		return out[i].ID < out[j].ID
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
 func (m *memRepo) GetByIDs(ctx context.Context, ids []string) ([]*domain.Event, error) {
 	var result []*domain.Event
 	for _, id := range ids {
@@ -135,7 +588,7 @@ func TestService_Cancel_Success(t *testing.T) {
 	}
 
 	t.Run("owner_can_cancel", func(t *testing.T) {
-		ev, err := svc.Cancel(context.Background(), eventID, ownerID, "user", "")
+		ev, err := svc.Cancel(context.Background(), eventID, ownerID, "user", "", 0)
 		assert.NoError(t, err)
 		assert.Equal(t, domain.StatusCanceled, ev.Status)
 		assert.NotNil(t, ev.CanceledAt)
@@ -143,7 +596,7 @@ func TestService_Cancel_Success(t *testing.T) {
 
 	t.Run("admin_can_cancel_any_event", func(t *testing.T) {
 		repo.byID[eventID].Status = domain.StatusPublished
-		ev, err := svc.Cancel(context.Background(), eventID, "admin_user", "admin", "")
+		ev, err := svc.Cancel(context.Background(), eventID, "admin_user", "admin", "", 0)
 		assert.NoError(t, err)
 		assert.Equal(t, domain.StatusCanceled, ev.Status)
 	})
@@ -165,7 +618,7 @@ func TestService_Publish_Validation(t *testing.T) {
 			EndTime:   now.Add(1 * time.Hour),
 		}
 
-		_, err := svc.Publish(context.Background(), eventID, "owner", "user")
+		_, err := svc.Publish(context.Background(), eventID, "owner", "user", 0)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "cannot publish event in the past")
 	})
@@ -262,6 +715,126 @@ func TestService_Update_CacheInvalidation(t *testing.T) {
 	})
 }
 
+func TestService_IfMatch(t *testing.T) {
+	now := mustTime(t, "2025-12-25T10:00:00Z")
+	repo := newMemRepo()
+	svc := New(repo, fakeClock{t: now}, newMockCache(), 0, 0)
+	ctx := context.Background()
+
+	eventID := "evt_versioned"
+	repo.byID[eventID] = &domain.Event{
+		ID:        eventID,
+		OwnerID:   "owner",
+		Status:    domain.StatusDraft,
+		Title:     "Title",
+		City:      "Sydney",
+		Category:  "music",
+		StartTime: now.Add(time.Hour),
+		EndTime:   now.Add(2 * time.Hour),
+		Version:   1,
+	}
+
+	title := "Renamed"
+	ev, err := svc.Update(ctx, UpdateCmd{EventID: eventID, ActorID: "owner", ActorRole: "user", Title: &title, IfMatch: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), ev.Version)
+
+	// A second writer still holding version 1 is rejected.
+	title = "Stale"
+	_, err = svc.Update(ctx, UpdateCmd{EventID: eventID, ActorID: "owner", ActorRole: "user", Title: &title, IfMatch: 1})
+	assert.ErrorContains(t, err, string(domain.CodePreconditionFailed))
+	assert.Equal(t, "Renamed", repo.byID[eventID].Title)
+
+	_, err = svc.Publish(ctx, eventID, "owner", "user", 1)
+	assert.ErrorContains(t, err, string(domain.CodePreconditionFailed))
+	_, err = svc.Cancel(ctx, eventID, "owner", "user", "", 1)
+	assert.ErrorContains(t, err, string(domain.CodePreconditionFailed))
+
+	ev, err = svc.Publish(ctx, eventID, "owner", "user", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusPublished, ev.Status)
+}
+
+func TestService_Revisions(t *testing.T) {
+	now := mustTime(t, "2025-12-25T10:00:00Z")
+	repo := newMemRepo()
+	svc := New(repo, fakeClock{t: now}, newMockCache(), 0, 0)
+	ctx := context.Background()
+
+	eventID := "evt_history"
+	repo.byID[eventID] = &domain.Event{
+		ID:        eventID,
+		OwnerID:   "owner",
+		Status:    domain.StatusDraft,
+		Title:     "Meetup",
+		City:      "Sydney",
+		Category:  "tech",
+		Capacity:  10,
+		StartTime: now.Add(24 * time.Hour),
+		EndTime:   now.Add(26 * time.Hour),
+		Version:   1,
+	}
+
+	// Draft edits are logged but nobody is notified.
+	title := "Go Meetup"
+	_, err := svc.Update(ctx, UpdateCmd{EventID: eventID, ActorID: "owner", ActorRole: "user", Title: &title})
+	assert.NoError(t, err)
+	assert.Empty(t, repo.outbox)
+
+	_, err = svc.Publish(ctx, eventID, "owner", "user", 0)
+	assert.NoError(t, err)
+
+	// A no-op update writes no revision.
+	_, err = svc.Update(ctx, UpdateCmd{EventID: eventID, ActorID: "owner", ActorRole: "user", Title: &title})
+	assert.NoError(t, err)
+
+	capacity, city, newTitle := 20, "Melbourne", "Go Meetup Melbourne"
+	_, err = svc.Update(ctx, UpdateCmd{EventID: eventID, ActorID: "owner", ActorRole: "user", Capacity: &capacity, City: &city, Title: &newTitle})
+	assert.NoError(t, err)
+
+	_, err = svc.Cancel(ctx, eventID, "mod", "moderator", "venue closed", 0)
+	assert.NoError(t, err)
+
+	assert.Len(t, repo.revisions, 4)
+	last := repo.revisions[2]
+	assert.Equal(t, domain.RevisionUpdate, last.Action)
+	assert.Equal(t, "owner", last.ActorID)
+	assert.Equal(t, domain.FieldChange{From: 10, To: 20}, last.Changes["capacity"])
+	assert.Contains(t, last.Changes, "title")
+	cancel := repo.revisions[3]
+	assert.Equal(t, domain.RevisionCancel, cancel.Action)
+	assert.Equal(t, "venue closed", cancel.Reason)
+	assert.Equal(t, domain.FieldChange{From: domain.StatusPublished, To: domain.StatusCanceled}, cancel.Changes["status"])
+
+	// Only the material part of the published-event update is announced.
+	var changed []OutboxMessage
+	for _, m := range repo.outbox {
+		if m.RoutingKey == RoutingKeyEventChanged {
+			changed = append(changed, m)
+		}
+	}
+	if assert.Len(t, changed, 1) {
+		var env DomainEventEnvelope[EventChangedPayload]
+		assert.NoError(t, json.Unmarshal(changed[0].Body, &env))
+		assert.Equal(t, last.Version, env.Payload.EventVersion)
+		assert.Contains(t, env.Payload.Changes, "capacity")
+		assert.Contains(t, env.Payload.Changes, "city")
+		assert.NotContains(t, env.Payload.Changes, "title")
+	}
+
+	page, err := svc.ListRevisions(ctx, eventID, "owner", "user", "", 2)
+	assert.NoError(t, err)
+	assert.True(t, page.HasMore)
+	assert.Equal(t, domain.RevisionCancel, page.Items[0].Action)
+	page, err = svc.ListRevisions(ctx, eventID, "mod", "moderator", page.NextCursor, 2)
+	assert.NoError(t, err)
+	assert.False(t, page.HasMore)
+	assert.Equal(t, domain.RevisionUpdate, page.Items[1].Action)
+
+	_, err = svc.ListRevisions(ctx, eventID, "stranger", "user", "", 0)
+	assert.Error(t, err)
+}
+
 func TestService_GetPublic_CacheFlow(t *testing.T) {
 	now := mustTime(t, "2025-12-25T10:00:00Z")
 	repo := newMemRepo()
@@ -341,7 +914,7 @@ func TestService_Cancel_StateValidation(t *testing.T) {
 	}
 
 	t.Run("cannot_cancel_already_canceled", func(t *testing.T) {
-		_, err := svc.Cancel(context.Background(), eventID, "owner", "user", "")
+		_, err := svc.Cancel(context.Background(), eventID, "owner", "user", "", 0)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "already canceled")
 	})
@@ -385,3 +958,907 @@ func TestService_Unpublish(t *testing.T) {
 		assert.Contains(t, err.Error(), "must be published")
 	})
 }
+
+func TestService_ListChangedSince_Paging(t *testing.T) {
+	now := mustTime(t, "2025-12-25T10:00:00Z")
+	repo := newMemRepo()
+	svc := New(repo, fakeClock{t: now}, nil, 0, 0)
+
+	repo.byID["a"] = &domain.Event{ID: "a", Status: domain.StatusPublished, UpdatedAt: now.Add(-2 * time.Hour)}
+	repo.byID["b"] = &domain.Event{ID: "b", Status: domain.StatusCanceled, UpdatedAt: now.Add(-time.Hour)}
+	repo.byID["c"] = &domain.Event{ID: "c", Status: domain.StatusDraft, UpdatedAt: now.Add(-time.Hour)}
+	repo.byID["old"] = &domain.Event{ID: "old", Status: domain.StatusPublished, UpdatedAt: now.Add(-48 * time.Hour)}
+
+	since := now.Add(-24 * time.Hour)
+
+	page1, err := svc.ListChangedSince(context.Background(), since, "", 2)
+	assert.NoError(t, err)
+	assert.Len(t, page1.Items, 2)
+	assert.Equal(t, "a", page1.Items[0].ID)
+	assert.Equal(t, "b", page1.Items[1].ID)
+	assert.NotEmpty(t, page1.NextCursor)
+
+	page2, err := svc.ListChangedSince(context.Background(), since, page1.NextCursor, 2)
+	assert.NoError(t, err)
+	assert.Len(t, page2.Items, 1)
+	assert.Equal(t, "c", page2.Items[0].ID)
+	assert.Equal(t, domain.StatusDraft, page2.Items[0].Status)
+	assert.Empty(t, page2.NextCursor, "short page ends the feed")
+
+	_, err = svc.ListChangedSince(context.Background(), since, "garbage", 2)
+	assert.Error(t, err)
+}
+
+func TestService_Collaborators_Permissions(t *testing.T) {
+	now := mustTime(t, "2025-12-25T10:00:00Z")
+	repo := newMemRepo()
+	svc := New(repo, fakeClock{t: now}, newMockCache(), 0, 0)
+	ctx := context.Background()
+
+	eventID := "evt_team"
+	repo.byID[eventID] = &domain.Event{
+		ID:        eventID,
+		OwnerID:   "owner",
+		Status:    domain.StatusDraft,
+		StartTime: now.Add(24 * time.Hour),
+		EndTime:   now.Add(26 * time.Hour),
+	}
+
+	t.Run("only_owner_manages_team", func(t *testing.T) {
+		_, err := svc.SetCollaborator(ctx, SetCollaboratorCmd{ActorID: "stranger", ActorRole: "user", EventID: eventID, UserID: "editor", Role: "editor"})
+		assert.Error(t, err)
+
+		_, err = svc.SetCollaborator(ctx, SetCollaboratorCmd{ActorID: "owner", ActorRole: "user", EventID: eventID, UserID: "owner", Role: "co_host"})
+		assert.Error(t, err, "owner cannot be a collaborator")
+
+		_, err = svc.SetCollaborator(ctx, SetCollaboratorCmd{ActorID: "owner", ActorRole: "user", EventID: eventID, UserID: "editor", Role: "superuser"})
+		assert.Error(t, err, "unknown role")
+
+		for user, role := range map[string]string{"editor": "editor", "staff": "checkin_staff", "cohost": "co_host"} {
+			_, err := svc.SetCollaborator(ctx, SetCollaboratorCmd{ActorID: "owner", ActorRole: "user", EventID: eventID, UserID: user, Role: role})
+			assert.NoError(t, err)
+		}
+		assert.Len(t, repo.outbox, 3)
+		assert.Equal(t, RoutingKeyCollaboratorsUpdated, repo.outbox[2].RoutingKey)
+	})
+
+	t.Run("editor_can_update_but_not_publish", func(t *testing.T) {
+		title := "Renamed"
+		_, err := svc.Update(ctx, UpdateCmd{ActorID: "editor", ActorRole: "user", EventID: eventID, Title: &title})
+		assert.NoError(t, err)
+
+		_, err = svc.Publish(ctx, eventID, "editor", "user", 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("checkin_staff_can_view_but_not_edit", func(t *testing.T) {
+		_, err := svc.GetForOwner(ctx, eventID, "staff", "user")
+		assert.NoError(t, err)
+
+		title := "Nope"
+		_, err = svc.Update(ctx, UpdateCmd{ActorID: "staff", ActorRole: "user", EventID: eventID, Title: &title})
+		assert.Error(t, err)
+	})
+
+	t.Run("co_host_can_publish", func(t *testing.T) {
+		ev, err := svc.Publish(ctx, eventID, "cohost", "user", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusPublished, ev.Status)
+	})
+
+	t.Run("collaborator_can_leave", func(t *testing.T) {
+		assert.NoError(t, svc.RemoveCollaborator(ctx, eventID, "staff", "staff", "user"))
+		err := svc.RemoveCollaborator(ctx, eventID, "staff", "owner", "user")
+		assert.Error(t, err, "already removed")
+	})
+}
+
+func TestService_TransferOwnership(t *testing.T) {
+	now := mustTime(t, "2025-12-25T10:00:00Z")
+	repo := newMemRepo()
+	svc := New(repo, fakeClock{t: now}, newMockCache(), 0, 0)
+	ctx := context.Background()
+
+	eventID := "evt_transfer"
+	repo.byID[eventID] = &domain.Event{ID: eventID, OwnerID: "owner", Status: domain.StatusPublished}
+	repo.collabs[eventID] = []domain.Collaborator{{EventID: eventID, UserID: "cohost", Role: domain.RoleCoHost}}
+
+	_, err := svc.TransferOwnership(ctx, eventID, "cohost", "cohost", "user")
+	assert.Error(t, err, "co-hosts cannot transfer ownership")
+
+	ev, err := svc.TransferOwnership(ctx, eventID, "cohost", "owner", "user")
+	assert.NoError(t, err)
+	assert.Equal(t, "cohost", ev.OwnerID)
+
+	role, _ := repo.GetCollaboratorRole(ctx, eventID, "owner")
+	assert.Equal(t, domain.RoleCoHost, role, "previous owner stays as co-host")
+	role, _ = repo.GetCollaboratorRole(ctx, eventID, "cohost")
+	assert.Equal(t, domain.CollaboratorRole(""), role, "new owner is no longer a collaborator")
+
+	last := repo.outbox[len(repo.outbox)-1]
+	assert.Equal(t, RoutingKeyOwnerTransferred, last.RoutingKey)
+	assert.Contains(t, string(last.Body), `"previous_owner_id":"owner"`)
+}
+
+func TestService_Visibility_And_Invites(t *testing.T) {
+	now := mustTime(t, "2025-12-25T10:00:00Z")
+	repo := newMemRepo()
+	svc := New(repo, fakeClock{t: now}, newMockCache(), 0, 0)
+	svc.SetInviteSecret("test-secret")
+	ctx := context.Background()
+
+	ev, err := svc.Create(ctx, CreateCmd{
+		ActorID: "owner", ActorRole: "user",
+		Title: "Secret supper", Description: "d", City: "Sydney", Category: "Food",
+		StartTime: now.Add(24 * time.Hour), EndTime: now.Add(26 * time.Hour),
+		Visibility: domain.VisibilityInviteOnly,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, domain.VisibilityInviteOnly, ev.Visibility)
+
+	t.Run("only_managers_issue_invites", func(t *testing.T) {
+		_, err := svc.CreateInvite(ctx, CreateInviteCmd{ActorID: "stranger", ActorRole: "user", EventID: ev.ID, Kind: "link"})
+		assert.Error(t, err)
+
+		_, err = svc.CreateInvite(ctx, CreateInviteCmd{ActorID: "owner", ActorRole: "user", EventID: ev.ID, Kind: "carrier-pigeon"})
+		assert.Error(t, err)
+	})
+
+	var link, code *IssuedInvite
+	t.Run("link_and_code", func(t *testing.T) {
+		maxUses := 2
+		link, err = svc.CreateInvite(ctx, CreateInviteCmd{ActorID: "owner", ActorRole: "user", EventID: ev.ID, Kind: "link", MaxUses: &maxUses})
+		assert.NoError(t, err)
+		assert.Equal(t, SignInviteToken([]byte("test-secret"), link.ID, ev.ID, nil), link.Token)
+		assert.Empty(t, link.CodeHash)
+
+		code, err = svc.CreateInvite(ctx, CreateInviteCmd{ActorID: "owner", ActorRole: "user", EventID: ev.ID, Kind: "code", Code: "vip-2025"})
+		assert.NoError(t, err)
+		assert.Equal(t, "VIP2025", code.Code)
+		assert.Equal(t, domain.HashAccessCode(ev.ID, "VIP2025"), code.CodeHash)
+		assert.Empty(t, code.Token)
+
+		_, err = svc.CreateInvite(ctx, CreateInviteCmd{ActorID: "owner", ActorRole: "user", EventID: ev.ID, Kind: "code", Code: "VIP 2025"})
+		assert.Error(t, err, "duplicate live code")
+
+		assert.Len(t, repo.outbox, 2)
+		assert.Equal(t, RoutingKeyInviteCreated, repo.outbox[1].RoutingKey)
+		assert.NotContains(t, string(repo.outbox[1].Body), "VIP2025", "plaintext code stays in event-service")
+	})
+
+	t.Run("revoke_and_count", func(t *testing.T) {
+		evID, _ := uuid.Parse(ev.ID)
+		assert.NoError(t, svc.RecordInviteUse(ctx, evID, link.ID))
+		assert.NoError(t, svc.RevokeInvite(ctx, ev.ID, code.ID, "owner", "user"))
+		assert.Error(t, svc.RevokeInvite(ctx, ev.ID, code.ID, "owner", "user"), "already revoked")
+		assert.Equal(t, RoutingKeyInviteRevoked, repo.outbox[len(repo.outbox)-1].RoutingKey)
+
+		list, err := svc.ListInvites(ctx, ev.ID, "owner", "user")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, list.Total)
+		assert.Equal(t, 1, list.Active)
+		assert.Equal(t, 1, list.Uses)
+	})
+
+	t.Run("visibility_locked_while_published", func(t *testing.T) {
+		_, err := svc.Publish(ctx, ev.ID, "owner", "user", 0)
+		assert.NoError(t, err)
+		assert.Contains(t, string(repo.outbox[len(repo.outbox)-1].Body), `"visibility":"invite_only"`)
+
+		public := domain.VisibilityPublic
+		_, err = svc.Update(ctx, UpdateCmd{ActorID: "owner", ActorRole: "user", EventID: ev.ID, Visibility: &public})
+		assert.Error(t, err)
+	})
+}
+
+type fakeLeaderLock struct{ held bool }
+
+func (l *fakeLeaderLock) TryLock(ctx context.Context) (func(), bool, error) {
+	if l.held {
+		return nil, false, nil
+	}
+	return func() {}, true, nil
+}
+
+func TestService_ScheduledTransitions(t *testing.T) {
+	now := mustTime(t, "2025-12-25T10:00:00Z")
+	repo := newMemRepo()
+	ctx := context.Background()
+	at := func(d time.Duration) *time.Time { v := now.Add(d); return &v }
+
+	svc := New(repo, fakeClock{t: now}, newMockCache(), 0, 0)
+
+	_, err := svc.Create(ctx, CreateCmd{
+		ActorID: "owner", ActorRole: "user",
+		Title: "Launch", Description: "d", City: "Sydney", Category: "Tech",
+		StartTime: now.Add(24 * time.Hour), EndTime: now.Add(26 * time.Hour),
+		Schedule: domain.Schedule{PublishAt: at(25 * time.Hour)},
+	})
+	assert.Error(t, err, "publish_at after start_time")
+
+	ev, err := svc.Create(ctx, CreateCmd{
+		ActorID: "owner", ActorRole: "user",
+		Title: "Launch", Description: "d", City: "Sydney", Category: "Tech",
+		StartTime: now.Add(24 * time.Hour), EndTime: now.Add(26 * time.Hour),
+		Schedule: domain.Schedule{
+			PublishAt:            at(time.Hour),
+			UnpublishAt:          at(20 * time.Hour),
+			RegistrationClosesAt: at(23 * time.Hour),
+		},
+	})
+	assert.NoError(t, err)
+
+	t.Run("not_due_yet", func(t *testing.T) {
+		n, err := svc.RunScheduledTransitions(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, n)
+		assert.Equal(t, domain.StatusDraft, repo.byID[ev.ID].Status)
+	})
+
+	t.Run("follower_does_nothing", func(t *testing.T) {
+		later := New(repo, fakeClock{t: now.Add(2 * time.Hour)}, newMockCache(), 0, 0)
+		n, err := NewScheduler(later, &fakeLeaderLock{held: true}, time.Minute).Tick(ctx)
+		assert.NoError(t, err)
+		assert.Zero(t, n)
+	})
+
+	t.Run("publish_when_due", func(t *testing.T) {
+		later := New(repo, fakeClock{t: now.Add(2 * time.Hour)}, newMockCache(), 0, 0)
+		n, err := NewScheduler(later, &fakeLeaderLock{}, time.Minute).Tick(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		got := repo.byID[ev.ID]
+		assert.Equal(t, domain.StatusPublished, got.Status)
+		assert.Nil(t, got.PublishAt)
+		assert.Len(t, repo.outbox, 1)
+		assert.Equal(t, "event.published", repo.outbox[0].RoutingKey)
+		assert.Contains(t, string(repo.outbox[0].Body), `"registration_closes_at":"2025-12-26T09:00:00Z"`)
+		assert.Contains(t, string(repo.outbox[0].Body), `"reason":"scheduled"`)
+
+		n, _ = later.RunScheduledTransitions(ctx)
+		assert.Zero(t, n, "already applied")
+	})
+
+	t.Run("window_change_while_published", func(t *testing.T) {
+		later := New(repo, fakeClock{t: now.Add(2 * time.Hour)}, newMockCache(), 0, 0)
+		_, err := later.UpdateSchedule(ctx, ev.ID, "stranger", "user", domain.Schedule{})
+		assert.Error(t, err)
+
+		_, err = later.UpdateSchedule(ctx, ev.ID, "owner", "user", domain.Schedule{PublishAt: at(3 * time.Hour)})
+		assert.Error(t, err, "publish_at on a published event")
+
+		got, err := later.UpdateSchedule(ctx, ev.ID, "owner", "user", domain.Schedule{
+			UnpublishAt:          at(20 * time.Hour),
+			RegistrationClosesAt: at(12 * time.Hour),
+		})
+		assert.NoError(t, err)
+		assert.True(t, got.RegistrationClosesAt.Equal(now.Add(12*time.Hour)))
+		assert.Len(t, repo.outbox, 2)
+		assert.Equal(t, "event.updated", repo.outbox[1].RoutingKey)
+	})
+
+	t.Run("unpublish_when_due", func(t *testing.T) {
+		later := New(repo, fakeClock{t: now.Add(21 * time.Hour)}, newMockCache(), 0, 0)
+		n, err := later.RunScheduledTransitions(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+
+		got := repo.byID[ev.ID]
+		assert.Equal(t, domain.StatusDraft, got.Status)
+		assert.Nil(t, got.UnpublishAt)
+		assert.Equal(t, "event.unpublished", repo.outbox[len(repo.outbox)-1].RoutingKey)
+	})
+}
+
+// tickClock advances one second per call so posts get distinct timestamps.
+type tickClock struct{ t time.Time }
+
+func (c *tickClock) Now() time.Time {
+	c.t = c.t.Add(time.Second)
+	return c.t
+}
+
+func TestService_Discussion(t *testing.T) {
+	clock := &tickClock{t: mustTime(t, "2025-12-25T10:00:00Z")}
+	repo := newMemRepo()
+	svc := New(repo, clock, nil, 0, 0)
+	ctx := context.Background()
+
+	ev, err := svc.Create(ctx, CreateCmd{
+		ActorID: "owner", ActorRole: "user",
+		Title: "Meetup", Description: "d", City: "Sydney", Category: "Tech",
+		StartTime: clock.t.Add(24 * time.Hour), EndTime: clock.t.Add(26 * time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.UpsertCollaborator(ctx, domain.Collaborator{EventID: ev.ID, UserID: "cohost", Role: domain.RoleCoHost}))
+	assert.NoError(t, repo.UpsertCollaborator(ctx, domain.Collaborator{EventID: ev.ID, UserID: "staff", Role: domain.RoleCheckinStaff}))
+
+	t.Run("draft_events_have_no_discussion", func(t *testing.T) {
+		_, err := svc.PostComment(ctx, PostCommentCmd{ActorID: "alice", EventID: ev.ID, Body: "hi"})
+		assert.Error(t, err)
+		_, err = svc.ListComments(ctx, ev.ID, "", 10)
+		assert.Error(t, err)
+	})
+
+	_, err = svc.Publish(ctx, ev.ID, "owner", "user", 0)
+	assert.NoError(t, err)
+
+	var question *domain.Comment
+	t.Run("threads_are_one_level_deep", func(t *testing.T) {
+		question, err = svc.PostComment(ctx, PostCommentCmd{ActorID: "alice", EventID: ev.ID, Body: "  is there parking?  "})
+		assert.NoError(t, err)
+		assert.Equal(t, "is there parking?", question.Body)
+
+		answer, err := svc.PostComment(ctx, PostCommentCmd{ActorID: "cohost", EventID: ev.ID, ParentID: question.ID, Body: "yes, level 2"})
+		assert.NoError(t, err)
+		followUp, err := svc.PostComment(ctx, PostCommentCmd{ActorID: "alice", EventID: ev.ID, ParentID: answer.ID, Body: "thanks"})
+		assert.NoError(t, err)
+		assert.Equal(t, question.ID, *followUp.ParentID, "a reply to a reply joins the root thread")
+
+		_, err = svc.PostComment(ctx, PostCommentCmd{ActorID: "alice", EventID: ev.ID, Body: "   "})
+		assert.Error(t, err)
+
+		replies, err := svc.ListReplies(ctx, ev.ID, question.ID, "", 10)
+		assert.NoError(t, err)
+		assert.Len(t, replies.Items, 2)
+		assert.Equal(t, answer.ID, replies.Items[0].ID, "replies are oldest first")
+
+		root, _ := repo.GetComment(ctx, ev.ID, question.ID)
+		assert.Equal(t, 2, root.ReplyCount)
+	})
+
+	t.Run("announcements", func(t *testing.T) {
+		_, err := svc.PostAnnouncement(ctx, PostAnnouncementCmd{ActorID: "staff", ActorRole: "user", EventID: ev.ID, Title: "t", Body: "b"})
+		assert.Error(t, err, "check-in staff cannot announce")
+		_, err = svc.PostAnnouncement(ctx, PostAnnouncementCmd{ActorID: "cohost", ActorRole: "user", EventID: ev.ID, Body: "b"})
+		assert.Error(t, err, "title is required")
+
+		before := len(repo.outbox)
+		a, err := svc.PostAnnouncement(ctx, PostAnnouncementCmd{ActorID: "cohost", ActorRole: "user", EventID: ev.ID, Title: "Room change", Body: "We moved to room 4B", Pin: true})
+		assert.NoError(t, err)
+		assert.True(t, a.Pinned)
+		assert.Len(t, repo.outbox, before+1)
+		assert.Equal(t, RoutingKeyAnnouncementPosted, repo.outbox[before].RoutingKey)
+		assert.Contains(t, string(repo.outbox[before].Body), `"announcement_id":"`+a.ID+`"`)
+		assert.Contains(t, string(repo.outbox[before].Body), `"event_title":"Meetup"`)
+	})
+
+	t.Run("pin_limit", func(t *testing.T) {
+		_, err := svc.SetCommentPinned(ctx, ev.ID, question.ID, "alice", "user", true)
+		assert.Error(t, err, "authors cannot pin")
+
+		_, err = svc.SetCommentPinned(ctx, ev.ID, question.ID, "owner", "user", true)
+		assert.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			_, err = svc.PostAnnouncement(ctx, PostAnnouncementCmd{ActorID: "owner", ActorRole: "user", EventID: ev.ID, Title: "t", Body: "b", Pin: i == 0})
+			assert.NoError(t, err)
+		}
+		_, err = svc.PostAnnouncement(ctx, PostAnnouncementCmd{ActorID: "owner", ActorRole: "user", EventID: ev.ID, Title: "t", Body: "b", Pin: true})
+		assert.Error(t, err, "at most three pinned posts")
+	})
+
+	t.Run("keyset_pages", func(t *testing.T) {
+		page, err := svc.ListComments(ctx, ev.ID, "", 2)
+		assert.NoError(t, err)
+		assert.Len(t, page.Pinned, 3)
+		assert.Len(t, page.Items, 2)
+		assert.True(t, page.HasMore)
+
+		var all []domain.Comment
+		all = append(all, page.Items...)
+		for page.HasMore {
+			page, err = svc.ListComments(ctx, ev.ID, page.NextCursor, 2)
+			assert.NoError(t, err)
+			assert.Empty(t, page.Pinned)
+			all = append(all, page.Items...)
+		}
+		assert.Len(t, all, 4)
+		assert.Equal(t, question.ID, all[3].ID, "newest first")
+	})
+
+	t.Run("soft_delete_and_removal", func(t *testing.T) {
+		own, err := svc.PostComment(ctx, PostCommentCmd{ActorID: "bob", EventID: ev.ID, Body: "oops"})
+		assert.NoError(t, err)
+		deleted, err := svc.DeleteComment(ctx, DeleteCommentCmd{ActorID: "bob", ActorRole: "user", EventID: ev.ID, CommentID: own.ID, Reason: "ignored"})
+		assert.NoError(t, err)
+		assert.False(t, deleted.Removed())
+		assert.Empty(t, deleted.RemovalReason)
+
+		spam, err := svc.PostComment(ctx, PostCommentCmd{ActorID: "bob", EventID: ev.ID, Body: "buy now"})
+		assert.NoError(t, err)
+		_, err = svc.DeleteComment(ctx, DeleteCommentCmd{ActorID: "alice", ActorRole: "user", EventID: ev.ID, CommentID: spam.ID})
+		assert.Error(t, err, "strangers cannot remove")
+		removed, err := svc.DeleteComment(ctx, DeleteCommentCmd{ActorID: "mod", ActorRole: "moderator", EventID: ev.ID, CommentID: spam.ID, Reason: "spam"})
+		assert.NoError(t, err)
+		assert.True(t, removed.Removed())
+		assert.Equal(t, "spam", removed.RemovalReason)
+
+		// The pinned question keeps its thread as a tombstone and frees its pin.
+		_, err = svc.DeleteComment(ctx, DeleteCommentCmd{ActorID: "admin", ActorRole: "admin", EventID: ev.ID, CommentID: question.ID})
+		assert.NoError(t, err)
+		_, err = svc.PostComment(ctx, PostCommentCmd{ActorID: "carol", EventID: ev.ID, ParentID: question.ID, Body: "late"})
+		assert.Error(t, err)
+
+		page, err := svc.ListComments(ctx, ev.ID, "", 20)
+		assert.NoError(t, err)
+		assert.Len(t, page.Pinned, 2)
+		ids := map[string]bool{}
+		for _, c := range page.Items {
+			ids[c.ID] = true
+		}
+		assert.True(t, ids[question.ID], "deleted root with replies stays")
+		assert.False(t, ids[own.ID], "deleted root without replies is hidden")
+	})
+}
+
+// fakeMedia records claims and rejects ids listed in bad. With repo set it
+// also notes claims made inside a transaction.
+type fakeMedia struct {
+	bad       map[string]bool
+	claimed   [][]string
+	repo      *memRepo
+	claimInTx bool
+}
+
+func (f *fakeMedia) ClaimUploads(ctx context.Context, ownerID, purpose string, ids []string) error {
+	if f.repo != nil && f.repo.inTx {
+		f.claimInTx = true
+	}
+	for _, id := range ids {
+		if f.bad[id] {
+			return domain.ErrValidationMeta("cover image cannot be used", map[string]string{"cover_image_id": id, "reason": "not_owner"})
+		}
+	}
+	f.claimed = append(f.claimed, ids)
+	return nil
+}
+
+func TestService_CoverImagesClaimedFromMedia(t *testing.T) {
+	clock := &tickClock{t: mustTime(t, "2025-12-25T10:00:00Z")}
+	repo := newMemRepo()
+	svc := New(repo, clock, nil, 0, 0)
+	a, b, stolen := uuid.NewString(), uuid.NewString(), uuid.NewString()
+	media := &fakeMedia{bad: map[string]bool{stolen: true}, repo: repo}
+	svc.SetMediaChecker(media)
+	ctx := context.Background()
+
+	cmd := CreateCmd{
+		ActorID: "owner", ActorRole: "user",
+		Title: "Meetup", Description: "d", City: "Sydney", Category: "Tech",
+		StartTime: clock.t.Add(24 * time.Hour), EndTime: clock.t.Add(26 * time.Hour),
+	}
+
+	cmd.CoverImageIDs = []string{stolen}
+	_, err := svc.Create(ctx, cmd)
+	var appErr *domain.AppError
+	assert.ErrorAs(t, err, &appErr, "foreign upload is rejected")
+	assert.Equal(t, domain.CodeValidation, appErr.Code)
+
+	cmd.CoverImageIDs = []string{a}
+	ev, err := svc.Create(ctx, cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{a}}, media.claimed)
+
+	covers := []string{a, b}
+	_, err = svc.Update(ctx, UpdateCmd{ActorID: "owner", ActorRole: "user", EventID: ev.ID, CoverImageIDs: &covers})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{b}, media.claimed[1], "only the added cover is claimed")
+
+	covers = []string{b, stolen}
+	_, err = svc.Update(ctx, UpdateCmd{ActorID: "owner", ActorRole: "user", EventID: ev.ID, CoverImageIDs: &covers})
+	assert.Error(t, err, "an added foreign upload fails the whole update")
+	assert.Len(t, media.claimed, 2)
+
+	covers = []string{b, uuid.NewString()}
+	_, err = svc.Update(ctx, UpdateCmd{ActorID: "owner", ActorRole: "user", EventID: ev.ID, CoverImageIDs: &covers, IfMatch: ev.Version + 100})
+	assert.Error(t, err, "stale If-Match")
+	assert.Len(t, media.claimed, 2, "a rejected update claims nothing")
+	assert.False(t, media.claimInTx, "covers are claimed outside the transaction")
+}
+
+// fakeAttendance maps user id to join-service status.
+type fakeAttendance map[string]string
+
+func (f fakeAttendance) ParticipationStatus(ctx context.Context, eventID, userID string) (string, error) {
+	return f[userID], nil
+}
+
+func TestService_Reviews(t *testing.T) {
+	clock := &tickClock{t: mustTime(t, "2025-12-25T10:00:00Z")}
+	repo := newMemRepo()
+	svc := New(repo, clock, nil, 0, 0)
+	svc.SetAttendanceChecker(fakeAttendance{"alice": "active", "bob": "active", "carol": "canceled"})
+	ctx := context.Background()
+
+	ev, err := svc.Create(ctx, CreateCmd{
+		ActorID: "owner", ActorRole: "user",
+		Title: "Meetup", Description: "d", City: "Sydney", Category: "Tech",
+		StartTime: clock.t.Add(24 * time.Hour), EndTime: clock.t.Add(26 * time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, repo.UpsertCollaborator(ctx, domain.Collaborator{EventID: ev.ID, UserID: "cohost", Role: domain.RoleCoHost}))
+	_, err = svc.Publish(ctx, ev.ID, "owner", "user", 0)
+	assert.NoError(t, err)
+
+	t.Run("not_before_the_event_ends", func(t *testing.T) {
+		_, err := svc.SubmitReview(ctx, SubmitReviewCmd{ActorID: "alice", EventID: ev.ID, Rating: 5})
+		assert.Error(t, err)
+	})
+
+	clock.t = ev.EndTime.Add(time.Hour)
+
+	var aliceReview *domain.Review
+	t.Run("attendees_only", func(t *testing.T) {
+		_, err := svc.SubmitReview(ctx, SubmitReviewCmd{ActorID: "carol", EventID: ev.ID, Rating: 1})
+		assert.Error(t, err, "canceled joins cannot review")
+		_, err = svc.SubmitReview(ctx, SubmitReviewCmd{ActorID: "mallory", EventID: ev.ID, Rating: 1})
+		assert.Error(t, err, "never joined")
+		_, err = svc.SubmitReview(ctx, SubmitReviewCmd{ActorID: "cohost", EventID: ev.ID, Rating: 5})
+		assert.Error(t, err, "organizer team cannot review")
+		_, err = svc.SubmitReview(ctx, SubmitReviewCmd{ActorID: "alice", EventID: ev.ID, Rating: 6})
+		assert.Error(t, err)
+
+		aliceReview, err = svc.SubmitReview(ctx, SubmitReviewCmd{ActorID: "alice", EventID: ev.ID, Rating: 4, Body: " great "})
+		assert.NoError(t, err)
+		assert.Equal(t, "owner", aliceReview.OrganizerID)
+		assert.Equal(t, "great", aliceReview.Body)
+	})
+
+	t.Run("resubmitting_replaces_the_review", func(t *testing.T) {
+		again, err := svc.SubmitReview(ctx, SubmitReviewCmd{ActorID: "alice", EventID: ev.ID, Rating: 5})
+		assert.NoError(t, err)
+		assert.Equal(t, aliceReview.ID, again.ID)
+
+		_, err = svc.SubmitReview(ctx, SubmitReviewCmd{ActorID: "bob", EventID: ev.ID, Rating: 2})
+		assert.NoError(t, err)
+
+		rep, err := svc.GetOrganizerReputation(ctx, "owner")
+		assert.NoError(t, err)
+		assert.Equal(t, domain.RatingSummary{Average: 3.5, Count: 2}, rep)
+
+		last := repo.outbox[len(repo.outbox)-1]
+		assert.Equal(t, RoutingKeyReputationUpdated, last.RoutingKey)
+		assert.Contains(t, string(last.Body), `"rating_count":2`)
+	})
+
+	t.Run("moderators_hide_reviews", func(t *testing.T) {
+		_, err := svc.SetReviewHidden(ctx, SetReviewHiddenCmd{ActorID: "owner", ActorRole: "user", EventID: ev.ID, ReviewID: aliceReview.ID, Hidden: true, Reason: "x"})
+		assert.Error(t, err, "organizers cannot hide reviews")
+		_, err = svc.SetReviewHidden(ctx, SetReviewHiddenCmd{ActorID: "mod", ActorRole: "moderator", EventID: ev.ID, ReviewID: aliceReview.ID, Hidden: true})
+		assert.Error(t, err, "a reason is required")
+
+		hidden, err := svc.SetReviewHidden(ctx, SetReviewHiddenCmd{ActorID: "mod", ActorRole: "moderator", EventID: ev.ID, ReviewID: aliceReview.ID, Hidden: true, Reason: "abusive"})
+		assert.NoError(t, err)
+		assert.True(t, hidden.IsHidden())
+
+		page, err := svc.ListReviews(ctx, ev.ID, "", 10)
+		assert.NoError(t, err)
+		assert.Len(t, page.Items, 1)
+		assert.Equal(t, domain.RatingSummary{Average: 2, Count: 1}, page.Summary)
+
+		_, err = svc.SubmitReview(ctx, SubmitReviewCmd{ActorID: "alice", EventID: ev.ID, Rating: 5})
+		assert.Error(t, err, "hidden reviews cannot be overwritten")
+
+		_, err = svc.SetReviewHidden(ctx, SetReviewHiddenCmd{ActorID: "mod", ActorRole: "admin", EventID: ev.ID, ReviewID: aliceReview.ID})
+		assert.NoError(t, err)
+		rep, _ := svc.GetOrganizerReputation(ctx, "owner")
+		assert.Equal(t, 2, rep.Count)
+	})
+
+	t.Run("window_closes", func(t *testing.T) {
+		clock.t = ev.EndTime.Add(domain.ReviewWindow + time.Minute)
+		_, err := svc.SubmitReview(ctx, SubmitReviewCmd{ActorID: "bob", EventID: ev.ID, Rating: 3})
+		assert.Error(t, err)
+	})
+}
+
+func TestService_ReportsAndModerationQueue(t *testing.T) {
+	clock := &tickClock{t: mustTime(t, "2025-12-25T10:00:00Z")}
+	repo := newMemRepo()
+	svc := New(repo, clock, nil, 0, 0)
+	svc.SetReportAutoHideThreshold(2)
+	ctx := context.Background()
+
+	ev, err := svc.Create(ctx, CreateCmd{
+		ActorID: "owner", ActorRole: "user",
+		Title: "Meetup", Description: "d", City: "Sydney", Category: "Tech",
+		StartTime: clock.t.Add(24 * time.Hour), EndTime: clock.t.Add(26 * time.Hour),
+	})
+	assert.NoError(t, err)
+	_, err = svc.Publish(ctx, ev.ID, "owner", "user", 0)
+	assert.NoError(t, err)
+	spam, err := svc.PostComment(ctx, PostCommentCmd{ActorID: "spammer", EventID: ev.ID, Body: "buy now"})
+	assert.NoError(t, err)
+
+	t.Run("reports_are_deduplicated_per_reporter", func(t *testing.T) {
+		_, err := svc.Report(ctx, ReportCmd{ActorID: "owner", TargetType: domain.ReportTargetEvent, TargetID: ev.ID, Reason: domain.ReasonSpam})
+		assert.Error(t, err, "cannot report your own event")
+		_, err = svc.Report(ctx, ReportCmd{ActorID: "alice", TargetType: domain.ReportTargetEvent, TargetID: ev.ID, Reason: "rude"})
+		assert.Error(t, err, "unknown reason")
+
+		first, err := svc.Report(ctx, ReportCmd{ActorID: "alice", TargetType: domain.ReportTargetEvent, TargetID: ev.ID, Reason: domain.ReasonScam})
+		assert.NoError(t, err)
+		assert.False(t, first.Duplicate)
+
+		again, err := svc.Report(ctx, ReportCmd{ActorID: "alice", TargetType: domain.ReportTargetEvent, TargetID: ev.ID, Reason: domain.ReasonSpam})
+		assert.NoError(t, err)
+		assert.True(t, again.Duplicate)
+		assert.Equal(t, first.Report.ID, again.Report.ID)
+		assert.Equal(t, domain.ReasonScam, again.Report.Reason)
+
+		got, _ := repo.GetByID(ctx, ev.ID)
+		assert.Equal(t, domain.StatusPublished, got.Status, "one reporter is below the threshold")
+	})
+
+	t.Run("threshold_hides_comment_and_dismiss_restores_it", func(t *testing.T) {
+		for _, who := range []string{"alice", "bob"} {
+			_, err := svc.Report(ctx, ReportCmd{ActorID: who, TargetType: domain.ReportTargetComment, TargetID: spam.ID, EventID: ev.ID, Reason: domain.ReasonSpam})
+			assert.NoError(t, err)
+		}
+		c, _ := repo.GetComment(ctx, ev.ID, spam.ID)
+		assert.True(t, c.IsDeleted())
+		assert.Equal(t, domain.SystemActor, c.DeletedBy)
+
+		page, err := svc.ListModerationQueue(ctx, "moderator", "", domain.ReportTargetComment, "", 10)
+		assert.NoError(t, err)
+		if assert.Len(t, page.Items, 1) {
+			cs := page.Items[0]
+			assert.Equal(t, 2, cs.ReportCount)
+			assert.Equal(t, 2, cs.ReasonCounts[domain.ReasonSpam])
+			assert.NotNil(t, cs.AutoHiddenAt)
+
+			_, err = svc.ResolveCase(ctx, ResolveCaseCmd{ActorID: "mod", ActorRole: "moderator", CaseID: cs.ID, Status: domain.CaseDismissed})
+			assert.NoError(t, err)
+		}
+		c, _ = repo.GetComment(ctx, ev.ID, spam.ID)
+		assert.False(t, c.IsDeleted())
+	})
+
+	t.Run("queue_is_moderators_only", func(t *testing.T) {
+		_, err := svc.ListModerationQueue(ctx, "user", "", "", "", 10)
+		assert.Error(t, err)
+	})
+
+	t.Run("actioning_links_the_moderator_action", func(t *testing.T) {
+		_, err := svc.Report(ctx, ReportCmd{ActorID: "bob", TargetType: domain.ReportTargetEvent, TargetID: ev.ID, Reason: domain.ReasonScam})
+		assert.NoError(t, err)
+		got, _ := repo.GetByID(ctx, ev.ID)
+		assert.Equal(t, domain.StatusDraft, got.Status, "second reporter reaches the threshold")
+
+		cs, err := repo.GetActiveModerationCaseForUpdate(ctx, domain.ReportTargetEvent, ev.ID)
+		assert.NoError(t, err)
+
+		triaged, err := svc.TriageCase(ctx, "mod", "moderator", cs.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.CaseTriaged, triaged.Status)
+
+		_, err = svc.ResolveCase(ctx, ResolveCaseCmd{ActorID: "mod", ActorRole: "moderator", CaseID: cs.ID, Status: domain.CaseActioned, Action: domain.ActionRemoveComment})
+		assert.Error(t, err, "action does not fit the target")
+
+		done, err := svc.ResolveCase(ctx, ResolveCaseCmd{ActorID: "mod", ActorRole: "moderator", CaseID: cs.ID, Status: domain.CaseActioned, Action: domain.ActionCancelEvent, Note: "fraudulent"})
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ActionCancelEvent, done.Action)
+		got, _ = repo.GetByID(ctx, ev.ID)
+		assert.Equal(t, domain.StatusCanceled, got.Status)
+
+		last := repo.outbox[len(repo.outbox)-1]
+		assert.Equal(t, "event.canceled", last.RoutingKey)
+		assert.Contains(t, string(last.Body), "fraudulent")
+
+		_, err = svc.ResolveCase(ctx, ResolveCaseCmd{ActorID: "mod", ActorRole: "moderator", CaseID: cs.ID, Status: domain.CaseDismissed})
+		assert.Error(t, err, "already resolved")
+	})
+
+	t.Run("report_after_dismissal_opens_a_new_case", func(t *testing.T) {
+		// The comment case was dismissed above; alice and bob report again.
+		first, err := svc.Report(ctx, ReportCmd{ActorID: "alice", TargetType: domain.ReportTargetComment, TargetID: spam.ID, EventID: ev.ID, Reason: domain.ReasonHarassment})
+		assert.NoError(t, err)
+		assert.False(t, first.Duplicate, "a closed case does not swallow repeat reporters")
+
+		page, err := svc.ListModerationQueue(ctx, "moderator", "", domain.ReportTargetComment, "", 10)
+		assert.NoError(t, err)
+		if assert.Len(t, page.Items, 1, "the target is back in the open queue") {
+			assert.Equal(t, first.Report.CaseID, page.Items[0].ID)
+			assert.Equal(t, 1, page.Items[0].ReportCount)
+			assert.Nil(t, page.Items[0].AutoHiddenAt)
+		}
+		dismissed, err := svc.ListModerationQueue(ctx, "moderator", domain.CaseDismissed, domain.ReportTargetComment, "", 10)
+		assert.NoError(t, err)
+		if assert.Len(t, dismissed.Items, 1) {
+			assert.NotEqual(t, first.Report.CaseID, dismissed.Items[0].ID)
+			assert.Equal(t, 2, dismissed.Items[0].ReportCount, "history is kept")
+		}
+
+		_, err = svc.Report(ctx, ReportCmd{ActorID: "bob", TargetType: domain.ReportTargetComment, TargetID: spam.ID, EventID: ev.ID, Reason: domain.ReasonSpam})
+		assert.NoError(t, err)
+		c, _ := repo.GetComment(ctx, ev.ID, spam.ID)
+		assert.True(t, c.IsDeleted(), "auto-hide applies to the new case")
+		assert.Equal(t, domain.SystemActor, c.DeletedBy)
+	})
+
+	t.Run("users_are_queued_not_hidden", func(t *testing.T) {
+		_, err := svc.Report(ctx, ReportCmd{ActorID: "alice", TargetType: domain.ReportTargetUser, TargetID: "alice", Reason: domain.ReasonHarassment})
+		assert.Error(t, err, "cannot report yourself")
+		for _, who := range []string{"alice", "bob", "carol"} {
+			_, err := svc.Report(ctx, ReportCmd{ActorID: who, TargetType: domain.ReportTargetUser, TargetID: "spammer", Reason: domain.ReasonHarassment})
+			assert.NoError(t, err)
+		}
+		cs, err := repo.GetActiveModerationCaseForUpdate(ctx, domain.ReportTargetUser, "spammer")
+		assert.NoError(t, err)
+		assert.Nil(t, cs.AutoHiddenAt)
+		assert.Equal(t, 3, cs.ReportCount)
+	})
+
+	t.Run("ban_user_bans_in_auth_service", func(t *testing.T) {
+		cs, err := repo.GetActiveModerationCaseForUpdate(ctx, domain.ReportTargetUser, "spammer")
+		assert.NoError(t, err)
+
+		ban := ResolveCaseCmd{ActorID: "mod", ActorRole: "moderator", CaseID: cs.ID, Status: domain.CaseActioned, Action: domain.ActionBanUser}
+		_, err = svc.ResolveCase(ctx, ban)
+		assert.Error(t, err, "refused without an account banner")
+
+		banner := &fakeBanner{err: domain.ErrForbidden("cannot moderate admin")}
+		svc.SetAccountBanner(banner)
+		_, err = svc.ResolveCase(ctx, ban)
+		assert.Error(t, err)
+		open, _ := repo.GetModerationCase(ctx, cs.ID)
+		assert.Equal(t, domain.CaseOpen, open.Status, "a failed ban leaves the case open")
+
+		banner.err = nil
+		done, err := svc.ResolveCase(ctx, ban)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.ActionBanUser, done.Action)
+		assert.Equal(t, []string{"spammer", "spammer"}, banner.banned)
+	})
+}
+
+type fakeBanner struct {
+	err    error
+	banned []string
+}
+
+func (f *fakeBanner) BanUser(ctx context.Context, actorID, actorRole, userID string) error {
+	f.banned = append(f.banned, userID)
+	return f.err
+}
+
+func TestService_HandleOrganizerBanned(t *testing.T) {
+	clock := &tickClock{t: mustTime(t, "2025-12-25T10:00:00Z")}
+	repo := newMemRepo()
+	svc := New(repo, clock, nil, 0, 0)
+	ctx := context.Background()
+
+	create := func(owner string, start time.Time) *domain.Event {
+		ev, err := svc.Create(ctx, CreateCmd{
+			ActorID: owner, ActorRole: "user",
+			Title: "Meetup", Description: "d", City: "Sydney", Category: "Tech",
+			StartTime: start, EndTime: start.Add(2 * time.Hour),
+		})
+		assert.NoError(t, err)
+		return ev
+	}
+
+	live := create("mallory", clock.t.Add(48*time.Hour))
+	_, err := svc.Publish(ctx, live.ID, "mallory", "user", 0)
+	assert.NoError(t, err)
+
+	scheduled := create("mallory", clock.t.Add(72*time.Hour))
+	publishAt := clock.t.Add(24 * time.Hour)
+	_, err = svc.UpdateSchedule(ctx, scheduled.ID, "mallory", "user", domain.Schedule{PublishAt: &publishAt})
+	assert.NoError(t, err)
+
+	other := create("alice", clock.t.Add(48*time.Hour))
+	_, err = svc.Publish(ctx, other.ID, "alice", "user", 0)
+	assert.NoError(t, err)
+
+	n, err := svc.HandleOrganizerBanned(ctx, "mallory")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	got, _ := repo.GetByID(ctx, live.ID)
+	assert.Equal(t, domain.StatusDraft, got.Status)
+	last := repo.outbox[len(repo.outbox)-1]
+	assert.Equal(t, "event.unpublished", last.RoutingKey)
+	assert.Contains(t, string(last.Body), OrganizerBannedReason)
+
+	got, _ = repo.GetByID(ctx, scheduled.ID)
+	assert.Nil(t, got.PublishAt, "a scheduled publish must not bring the event back")
+
+	got, _ = repo.GetByID(ctx, other.ID)
+	assert.Equal(t, domain.StatusPublished, got.Status)
+
+	// Replayed message: nothing left to take down.
+	n, err = svc.HandleOrganizerBanned(ctx, "mallory")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestService_HandleCoverRejected(t *testing.T) {
+	clock := &tickClock{t: mustTime(t, "2025-12-25T10:00:00Z")}
+	repo := newMemRepo()
+	svc := New(repo, clock, nil, 0, 0)
+	ctx := context.Background()
+
+	bad, good := uuid.NewString(), uuid.NewString()
+	start := clock.t.Add(48 * time.Hour)
+	create := func(covers ...string) *domain.Event {
+		ev, err := svc.Create(ctx, CreateCmd{
+			ActorID: "owner", ActorRole: "user",
+			Title: "Meetup", Description: "d", City: "Sydney", Category: "Tech",
+			StartTime: start, EndTime: start.Add(2 * time.Hour),
+			CoverImageIDs: covers,
+		})
+		assert.NoError(t, err)
+		return ev
+	}
+	both := create(bad, good)
+	untouched := create(good)
+
+	n, err := svc.HandleCoverRejected(ctx, []string{bad})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	got, _ := repo.GetByID(ctx, both.ID)
+	assert.Equal(t, []string{good}, got.CoverImageIDs)
+	rv := repo.revisions[len(repo.revisions)-1]
+	assert.Equal(t, both.ID, rv.EventID)
+	assert.Equal(t, domain.SystemActor, rv.ActorID)
+	assert.Equal(t, CoverRejectedReason, rv.Reason)
+	assert.Contains(t, rv.Changes, "cover_image_ids")
+
+	got, _ = repo.GetByID(ctx, untouched.ID)
+	assert.Equal(t, []string{good}, got.CoverImageIDs)
+
+	// Replayed message: nothing left to detach.
+	n, err = svc.HandleCoverRejected(ctx, []string{bad})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestService_HandleUserDeletionAndExport(t *testing.T) {
+	clock := &tickClock{t: mustTime(t, "2025-12-25T10:00:00Z")}
+	repo := newMemRepo()
+	svc := New(repo, clock, nil, 0, 0)
+	ctx := context.Background()
+
+	start := clock.t.Add(48 * time.Hour)
+	ev, err := svc.Create(ctx, CreateCmd{
+		ActorID: "dave", ActorRole: "user",
+		Title: "Meetup", Description: "d", City: "Sydney", Category: "Tech",
+		StartTime: start, EndTime: start.Add(2 * time.Hour),
+	})
+	assert.NoError(t, err)
+	_, err = svc.Publish(ctx, ev.ID, "dave", "user", 0)
+	assert.NoError(t, err)
+
+	assert.NoError(t, svc.HandleUserExport(ctx, "req-1", "dave"))
+	last := repo.outbox[len(repo.outbox)-1]
+	assert.Equal(t, RoutingKeyUserDataExported, last.RoutingKey)
+	var exported DomainEventEnvelope[UserDataReplyPayload]
+	assert.NoError(t, json.Unmarshal(last.Body, &exported))
+	assert.Equal(t, "req-1", exported.Payload.RequestID)
+	assert.Equal(t, DataRequestService, exported.Payload.Service)
+	assert.Contains(t, string(exported.Payload.Data), ev.ID)
+
+	assert.NoError(t, svc.HandleUserDeletion(ctx, "req-2", "dave"))
+	got, _ := repo.GetByID(ctx, ev.ID)
+	assert.Equal(t, domain.StatusDraft, got.Status)
+	assert.NotEqual(t, "dave", got.OwnerID)
+
+	unpublished := repo.outbox[len(repo.outbox)-2]
+	assert.Equal(t, "event.unpublished", unpublished.RoutingKey)
+	assert.Contains(t, string(unpublished.Body), OrganizerDeletedReason)
+
+	last = repo.outbox[len(repo.outbox)-1]
+	assert.Equal(t, RoutingKeyUserDataDeleted, last.RoutingKey)
+	var deleted DomainEventEnvelope[UserDataReplyPayload]
+	assert.NoError(t, json.Unmarshal(last.Body, &deleted))
+	assert.Equal(t, "req-2", deleted.Payload.RequestID)
+	assert.Equal(t, "dave", deleted.Payload.UserID)
+	assert.Empty(t, deleted.Payload.Data)
+
+	// Replays still acknowledge.
+	assert.NoError(t, svc.HandleUserDeletion(ctx, "req-2", "dave"))
+	assert.Equal(t, RoutingKeyUserDataDeleted, repo.outbox[len(repo.outbox)-1].RoutingKey)
+}
@@ -3,6 +3,7 @@ package event
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
 	"github.com/google/uuid"
@@ -18,8 +19,8 @@ func (s *Service) Unpublish(ctx context.Context, eventID, actorID, actorRole, re
 			return err
 		}
 
-		if !canManage(actorID, actorRole, ev.OwnerID) {
-			return domain.ErrForbidden("not allowed")
+		if err := authorize(ctx, r, ev, actorID, actorRole, domain.CollaboratorRole.CanManage); err != nil {
+			return err
 		}
 
 		// Validation: Must be published to unpublish
@@ -28,63 +29,7 @@ func (s *Service) Unpublish(ctx context.Context, eventID, actorID, actorRole, re
 		}
 
 		now := s.clock.Now().UTC()
-
-		ev.Status = domain.StatusDraft
-		ev.UpdatedAt = now
-		// Do not set CanceledAt (Unpublish != Cancel)
-
-		if err := r.Update(ctx, ev); err != nil {
-			return err
-		}
-
-		// --- Outbox ---
-		messageID := uuid.NewString()
-		// Re-use CanceledPayload or define UnpublishedPayload?
-		// Usually Unpublished is similar to Canceled (removed from feed).
-		// For MVP, generic Envelope is fine.
-		// Or "EventUnpublishedPayload".
-		// I'll stick to generic payload map or struct if available.
-		// cancel.go used `EventCanceledPayload`.
-		// I should check `ports.go` for defined payloads.
-		// Assuming I can use a generic map for now or `EventUnpublished` if defined.
-		// To be safe, I'll check ports.go first to avoid compilation error.
-		// But for now I'll use a local struct or map.
-
-		// I'll assume simple map for payload to match cleanliness or reuse struct.
-		// `cancel.go` used `EventCanceledPayload`.
-		// I will assume `EventUnpublishedPayload` does NOT exist yet.
-		// I will use `map[string]any`.
-
-		payload := EventUnpublishedPayload{
-			EventID:   ev.ID,
-			OwnerID:   ev.OwnerID,
-			Status:    string(ev.Status),
-			Reason:    reason,
-			ActorRole: actorRole,
-		}
-
-		env := DomainEventEnvelope[EventUnpublishedPayload]{
-			Version:    EventVersion,
-			Producer:   EventProducer,
-			MessageID:  messageID,
-			TraceID:    TraceIDFromContext(ctx),
-			OccurredAt: now,
-			Payload:    payload,
-		}
-		// Wait, Envelope definition in cancel.go seems to infer Type from payload? or Routing Key?
-		// cancel.go: `RoutingKey: "event.canceled"`.
-
-		body, err := json.Marshal(env)
-		if err != nil {
-			return err
-		}
-
-		if err := r.InsertOutbox(ctx, OutboxMessage{
-			MessageID:  messageID,
-			RoutingKey: "event.unpublished",
-			Body:       body,
-			CreatedAt:  now,
-		}); err != nil {
+		if err := unpublishTx(ctx, r, ev, now, reason, actorID, actorRole); err != nil {
 			return err
 		}
 
@@ -105,3 +50,49 @@ func (s *Service) Unpublish(ctx context.Context, eventID, actorID, actorRole, re
 
 	return out, nil
 }
+
+// unpublishTx moves ev back to draft and writes event.unpublished and the
+// revision in the same transaction. Unpublishing consumes any pending
+// unpublish_at.
+func unpublishTx(ctx context.Context, r TxEventRepo, ev *domain.Event, now time.Time, reason, actorID, actorRole string) error {
+	before := *ev
+	ev.Status = domain.StatusDraft
+	ev.UnpublishAt = nil
+	ev.UpdatedAt = now
+	// Do not set CanceledAt (Unpublish != Cancel)
+
+	if err := r.Update(ctx, ev); err != nil {
+		return err
+	}
+	if err := recordRevisionTx(ctx, r, &before, ev, domain.RevisionUnpublish, actorID, actorRole, reason, now); err != nil {
+		return err
+	}
+
+	messageID := uuid.NewString()
+	env := DomainEventEnvelope[EventUnpublishedPayload]{
+		Version:    EventVersion,
+		Producer:   EventProducer,
+		MessageID:  messageID,
+		TraceID:    TraceIDFromContext(ctx),
+		OccurredAt: now,
+		Payload: EventUnpublishedPayload{
+			EventID:   ev.ID,
+			OwnerID:   ev.OwnerID,
+			Status:    string(ev.Status),
+			Reason:    reason,
+			ActorRole: actorRole,
+		},
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return r.InsertOutbox(ctx, OutboxMessage{
+		MessageID:  messageID,
+		RoutingKey: "event.unpublished",
+		Body:       body,
+		CreatedAt:  now,
+	})
+}
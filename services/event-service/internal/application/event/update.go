@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
-	zlog "github.com/rs/zerolog/log"
 )
 
 type UpdateCmd struct {
@@ -21,36 +20,106 @@ type UpdateCmd struct {
 	EndTime       *time.Time
 	Capacity      *int
 	CoverImageIDs *[]string
+	Visibility    *domain.Visibility
+
+	// IfMatch is the version the caller last saw; 0 skips the check.
+	IfMatch int64
+}
+
+// checkVersion rejects a write based on a stale read of ev. ifMatch 0
+// means unconditional (If-Match: *, scheduler and consumers).
+func checkVersion(ev *domain.Event, ifMatch int64) error {
+	if ifMatch != 0 && ev.Version != ifMatch {
+		return domain.ErrPreconditionFailed("event has been modified")
+	}
+	return nil
 }
 
+// Update applies cmd and records the revision in one transaction. Material
+// changes to a published event are announced as event.changed. Added
+// covers are claimed before the transaction (see claimAddedCovers).
 func (s *Service) Update(ctx context.Context, cmd UpdateCmd) (*domain.Event, error) {
-	ev, err := s.repo.GetByID(ctx, cmd.EventID)
-	if err != nil {
+	if err := s.claimAddedCovers(ctx, cmd); err != nil {
 		return nil, err
 	}
 
-	if !canManage(cmd.ActorID, cmd.ActorRole, ev.OwnerID) {
-		return nil, domain.ErrForbidden("not allowed")
-	}
-	if ev.Status == domain.StatusCanceled {
-		return nil, domain.ErrInvalidState("canceled event cannot be updated")
-	}
+	var out *domain.Event
+	err := s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		ev, err := r.GetByIDForUpdate(ctx, cmd.EventID)
+		if err != nil {
+			return err
+		}
+
+		if err := authorize(ctx, r, ev, cmd.ActorID, cmd.ActorRole, domain.CollaboratorRole.CanEdit); err != nil {
+			return err
+		}
+		if err := checkVersion(ev, cmd.IfMatch); err != nil {
+			return err
+		}
+		if ev.Status == domain.StatusCanceled {
+			return domain.ErrInvalidState("canceled event cannot be updated")
+		}
+
+		now := s.clock.Now()
+		before := *ev
+		if cmd.Visibility != nil {
+			if err := ev.SetVisibility(*cmd.Visibility, now); err != nil {
+				return err
+			}
+		}
+		if err := ev.ApplyUpdate(cmd.Title, cmd.Description, cmd.City, cmd.Category, cmd.StartTime, cmd.EndTime, cmd.Capacity, cmd.CoverImageIDs, now); err != nil {
+			return err
+		}
+
+		if err := r.Update(ctx, ev); err != nil {
+			return err
+		}
+		if err := recordRevisionTx(ctx, r, &before, ev, domain.RevisionUpdate, cmd.ActorID, cmd.ActorRole, "", now.UTC()); err != nil {
+			return err
+		}
 
-	if err := ev.ApplyUpdate(cmd.Title, cmd.Description, cmd.City, cmd.Category, cmd.StartTime, cmd.EndTime, cmd.Capacity, cmd.CoverImageIDs, s.clock.Now()); err != nil {
+		out = ev
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	if err := s.repo.Update(ctx, ev); err != nil {
-		return nil, err
+	s.invalidateDetails(ctx, out.ID)
+	return out, nil
+}
+
+// claimAddedCovers claims the covers cmd attaches from media-service outside
+// Update's transaction, so a slow media-service never holds the row lock or
+// a pooled connection. The update is first checked against a snapshot, so
+// one that would be rejected claims nothing. Only newly attached covers
+// need claiming; kept ones already were. If a concurrent edit changes the
+// covers in between, every cover the transaction then attaches was still
+// claimed, either here or when it was first attached; a claim is never
+// undone and repeating one is harmless.
+func (s *Service) claimAddedCovers(ctx context.Context, cmd UpdateCmd) error {
+	if s.media == nil || cmd.CoverImageIDs == nil {
+		return nil
+	}
+	ev, err := s.repo.GetByID(ctx, cmd.EventID)
+	if err != nil {
+		return err
+	}
+	if err := authorize(ctx, s.repo, ev, cmd.ActorID, cmd.ActorRole, domain.CollaboratorRole.CanEdit); err != nil {
+		return err
+	}
+	if err := checkVersion(ev, cmd.IfMatch); err != nil {
+		return err
 	}
 
-	// --- Cache Invalidation ---
-	if s.cache != nil {
-		key := cacheKeyEventDetails(ev.ID)
-		if err := s.cache.Delete(ctx, key); err != nil {
-			zlog.Warn().Err(err).Str("key", key).Msg("cache invalidate failed")
+	next := *ev
+	if cmd.Visibility != nil {
+		if err := next.SetVisibility(*cmd.Visibility, s.clock.Now()); err != nil {
+			return err
 		}
 	}
-
-	return ev, nil
+	if err := next.ApplyUpdate(cmd.Title, cmd.Description, cmd.City, cmd.Category, cmd.StartTime, cmd.EndTime, cmd.Capacity, cmd.CoverImageIDs, s.clock.Now()); err != nil {
+		return err
+	}
+	return s.claimCovers(ctx, cmd.ActorID, domain.AddedCoverIDs(ev.CoverImageIDs, next.CoverImageIDs))
 }
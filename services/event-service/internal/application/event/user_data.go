@@ -0,0 +1,112 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+const (
+	RoutingKeyUserDataDeleted  = "user.data.deleted"
+	RoutingKeyUserDataExported = "user.data.exported"
+
+	// OrganizerDeletedReason is the unpublish reason for events whose owner
+	// deleted their account.
+	OrganizerDeletedReason = "organizer_deleted"
+
+	// DataRequestService identifies event-service in replies to auth-service.
+	DataRequestService = "event-service"
+)
+
+// UserDataReplyPayload is the business payload for routing keys
+// user.data.deleted and user.data.exported. Data is only set on exports.
+type UserDataReplyPayload struct {
+	RequestID string          `json:"request_id"`
+	UserID    string          `json:"user_id"`
+	Service   string          `json:"service"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// HandleUserDeletion handles auth.user.deletion_requested: the user's
+// upcoming events are unpublished, then every reference to the user is
+// replaced by a random pseudonym and the acknowledgement is written to the
+// outbox in the same transaction. Content (events, comments, reviews) is
+// kept so threads and ratings stay intact, but can no longer be traced
+// back to the account. Replays re-acknowledge without further changes.
+func (s *Service) HandleUserDeletion(ctx context.Context, requestID, userID string) error {
+	requestID, userID = strings.TrimSpace(requestID), strings.TrimSpace(userID)
+	if requestID == "" || userID == "" {
+		return domain.ErrValidationMeta("invalid data request", map[string]string{
+			"request_id": "required",
+			"user_id":    "required",
+		})
+	}
+
+	if _, err := s.takeDownUpcoming(ctx, userID, OrganizerDeletedReason); err != nil {
+		return err
+	}
+
+	now := s.clock.Now().UTC()
+	return s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		if err := r.AnonymizeUser(ctx, userID, uuid.NewString()); err != nil {
+			return err
+		}
+		return insertUserDataReplyOutbox(ctx, r, RoutingKeyUserDataDeleted, requestID, userID, nil, now)
+	})
+}
+
+// HandleUserExport handles auth.user.export_requested by replying with
+// everything event-service stores about the user.
+func (s *Service) HandleUserExport(ctx context.Context, requestID, userID string) error {
+	requestID, userID = strings.TrimSpace(requestID), strings.TrimSpace(userID)
+	if requestID == "" || userID == "" {
+		return domain.ErrValidationMeta("invalid data request", map[string]string{
+			"request_id": "required",
+			"user_id":    "required",
+		})
+	}
+
+	data, err := s.repo.ExportUserData(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	now := s.clock.Now().UTC()
+	return s.repo.WithTx(ctx, func(r TxEventRepo) error {
+		return insertUserDataReplyOutbox(ctx, r, RoutingKeyUserDataExported, requestID, userID, data, now)
+	})
+}
+
+func insertUserDataReplyOutbox(ctx context.Context, r TxEventRepo, routingKey, requestID, userID string, data json.RawMessage, now time.Time) error {
+	messageID := uuid.NewString()
+	env := DomainEventEnvelope[UserDataReplyPayload]{
+		Version:    EventVersion,
+		Producer:   EventProducer,
+		MessageID:  messageID,
+		TraceID:    TraceIDFromContext(ctx),
+		OccurredAt: now,
+		Payload: UserDataReplyPayload{
+			RequestID: requestID,
+			UserID:    userID,
+			Service:   DataRequestService,
+			Data:      data,
+		},
+	}
+
+	body, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return r.InsertOutbox(ctx, OutboxMessage{
+		MessageID:  messageID,
+		RoutingKey: routingKey,
+		Body:       body,
+		CreatedAt:  now,
+	})
+}
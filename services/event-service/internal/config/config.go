@@ -19,6 +19,28 @@ type Config struct {
 	JWTSecret string
 	JWTIssuer string
 
+	// Shared secret for service-to-service routes (X-Internal-Secret)
+	InternalSecret string
+
+	// Signs invite links for invite-only events; join-service verifies them
+	InviteTokenSecret string
+
+	// join-service base URL; reviews check attendance there (X-Internal-Secret)
+	JoinServiceURL string
+
+	// media-service base URL; cover image ids are claimed there (X-Internal-Secret)
+	MediaServiceURL string
+
+	// auth-service base URL; ban_user moderation actions ban there (X-Internal-Secret)
+	AuthServiceURL string
+
+	// Distinct reporters after which an event or comment is hidden pending
+	// moderator review; 0 disables auto-hide
+	ReportAutoHideThreshold int
+
+	// How often the leader replica runs due publish_at / unpublish_at
+	SchedulerInterval time.Duration
+
 	// RabbitMQ
 	RabbitURL      string
 	RabbitExchange string
@@ -53,6 +75,15 @@ func Load() (*Config, error) {
 
 	cfg.JWTSecret = getEnv("JWT_SECRET", "")
 	cfg.JWTIssuer = getEnv("JWT_ISSUER", "")
+	cfg.InternalSecret = getEnv("INTERNAL_SECRET_KEY", "dev-secret-key")
+	cfg.InviteTokenSecret = getEnv("INVITE_TOKEN_SECRET", "dev-invite-secret")
+	cfg.JoinServiceURL = getEnv("JOIN_SERVICE_URL", "http://localhost:8083")
+	cfg.MediaServiceURL = getEnv("MEDIA_SERVICE_URL", "http://localhost:8085")
+	cfg.AuthServiceURL = getEnv("AUTH_SERVICE_URL", "http://localhost:8080")
+
+	cfg.ReportAutoHideThreshold = getIntEnv("REPORT_AUTO_HIDE_THRESHOLD", 5)
+
+	cfg.SchedulerInterval = getDuration("SCHEDULER_INTERVAL", 30*time.Second)
 
 	cfg.RabbitURL = getEnv("RABBIT_URL", "")
 	cfg.RabbitExchange = getEnv("RABBIT_EXCHANGE", "city.events")
@@ -86,6 +117,13 @@ func Load() (*Config, error) {
 		return nil, fmt.Errorf("missing RABBIT_URL (required when APP_ENV != dev)")
 	}
 
+	if cfg.AppEnv == "prod" && cfg.InternalSecret == "dev-secret-key" {
+		return nil, fmt.Errorf("INTERNAL_SECRET_KEY must be set in prod")
+	}
+	if cfg.AppEnv == "prod" && cfg.InviteTokenSecret == "dev-invite-secret" {
+		return nil, fmt.Errorf("INVITE_TOKEN_SECRET must be set in prod")
+	}
+
 	return cfg, nil
 }
 
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// CollaboratorRole is what a non-owner member of an event's organizer team
+// may do. The owner is not a collaborator row; see Event.OwnerID.
+type CollaboratorRole string
+
+const (
+	RoleCoHost       CollaboratorRole = "co_host"
+	RoleCheckinStaff CollaboratorRole = "checkin_staff"
+	RoleEditor       CollaboratorRole = "editor"
+)
+
+// MaxCollaborators caps the size of an organizer team.
+const MaxCollaborators = 20
+
+func ParseCollaboratorRole(s string) (CollaboratorRole, bool) {
+	r := CollaboratorRole(strings.ToLower(strings.TrimSpace(s)))
+	return r, r.Valid()
+}
+
+func (r CollaboratorRole) Valid() bool {
+	return r == RoleCoHost || r == RoleCheckinStaff || r == RoleEditor
+}
+
+// CanEdit reports whether the role may change event details.
+func (r CollaboratorRole) CanEdit() bool { return r == RoleCoHost || r == RoleEditor }
+
+// CanManage reports whether the role may publish, unpublish or cancel.
+func (r CollaboratorRole) CanManage() bool { return r == RoleCoHost }
+
+type Collaborator struct {
+	EventID   string           `json:"event_id"`
+	UserID    string           `json:"user_id"`
+	Role      CollaboratorRole `json:"role"`
+	AddedBy   string           `json:"added_by"`
+	CreatedAt time.Time        `json:"created_at"`
+	UpdatedAt time.Time        `json:"updated_at"`
+}
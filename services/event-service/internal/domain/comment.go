@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CommentKind separates attendee discussion from organizer broadcasts.
+type CommentKind string
+
+const (
+	CommentKindComment      CommentKind = "comment"
+	CommentKindAnnouncement CommentKind = "announcement"
+)
+
+const (
+	MaxCommentBody        = 2000
+	MaxAnnouncementTitle  = 120
+	MaxPinnedComments     = 3
+	MaxCommentRemovalNote = 200
+)
+
+// Comment is a post in an event's discussion. Threads are one level deep:
+// ParentID always points at a top-level post.
+type Comment struct {
+	ID         string
+	EventID    string
+	ParentID   *string
+	AuthorID   string
+	Kind       CommentKind
+	Title      string // announcements only
+	Body       string
+	Pinned     bool
+	ReplyCount int // live replies; top-level posts only
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+
+	// Soft delete. DeletedBy is the author for a self-delete, otherwise the
+	// organizer or moderator who removed it.
+	DeletedAt     *time.Time
+	DeletedBy     string
+	RemovalReason string
+}
+
+func (c *Comment) IsDeleted() bool { return c.DeletedAt != nil }
+
+// IsReply reports whether the comment belongs to another post's thread.
+func (c *Comment) IsReply() bool { return c.ParentID != nil }
+
+// Removed reports whether someone other than the author deleted it.
+func (c *Comment) Removed() bool { return c.IsDeleted() && c.DeletedBy != c.AuthorID }
+
+// NormalizeCommentText trims the post text and checks its lengths.
+// title is only validated for announcements.
+func NormalizeCommentText(kind CommentKind, title, body string) (string, string, error) {
+	title = strings.TrimSpace(title)
+	body = strings.TrimSpace(body)
+
+	meta := map[string]string{}
+	switch n := utf8.RuneCountInString(body); {
+	case n == 0:
+		meta["body"] = "required"
+	case n > MaxCommentBody:
+		meta["body"] = "must be <= 2000 chars"
+	}
+	if kind == CommentKindAnnouncement {
+		switch n := utf8.RuneCountInString(title); {
+		case n == 0:
+			meta["title"] = "required"
+		case n > MaxAnnouncementTitle:
+			meta["title"] = "must be <= 120 chars"
+		}
+	} else {
+		title = ""
+	}
+	if len(meta) > 0 {
+		return "", "", ErrValidationMeta("invalid comment", meta)
+	}
+	return title, body, nil
+}
+
+// SoftDelete marks the comment deleted by actorID. Pinned posts are
+// unpinned so they stop taking a pin slot.
+func (c *Comment) SoftDelete(actorID, reason string, now time.Time) error {
+	if c.IsDeleted() {
+		return ErrInvalidState("comment already deleted")
+	}
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > MaxCommentRemovalNote {
+		return ErrValidationMeta("invalid reason", map[string]string{
+			"reason": "must be <= 200 chars",
+		})
+	}
+	t := now.UTC()
+	c.DeletedAt = &t
+	c.DeletedBy = actorID
+	c.RemovalReason = reason
+	c.Pinned = false
+	c.UpdatedAt = t
+	return nil
+}
+
+// Restore undoes a removal, e.g. when a moderator dismisses the reports
+// that hid the post.
+func (c *Comment) Restore(now time.Time) error {
+	if !c.IsDeleted() {
+		return ErrInvalidState("comment is not deleted")
+	}
+	c.DeletedAt = nil
+	c.DeletedBy = ""
+	c.RemovalReason = ""
+	c.UpdatedAt = now.UTC()
+	return nil
+}
@@ -9,6 +9,9 @@ const (
 	CodeNotFound     ErrCode = "not_found"
 	CodeForbidden    ErrCode = "forbidden"
 	CodeInvalidState ErrCode = "invalid_state"
+
+	CodePreconditionFailed   ErrCode = "precondition_failed"
+	CodePreconditionRequired ErrCode = "precondition_required"
 )
 
 type AppError struct {
@@ -31,3 +34,11 @@ func ErrValidationMeta(msg string, meta map[string]string) error {
 func ErrNotFound(msg string) error     { return &AppError{Code: CodeNotFound, Message: msg} }
 func ErrForbidden(msg string) error    { return &AppError{Code: CodeForbidden, Message: msg} }
 func ErrInvalidState(msg string) error { return &AppError{Code: CodeInvalidState, Message: msg} }
+
+// ErrPreconditionFailed reports that If-Match no longer matches the event.
+func ErrPreconditionFailed(msg string) error {
+	return &AppError{Code: CodePreconditionFailed, Message: msg}
+}
+func ErrPreconditionRequired(msg string) error {
+	return &AppError{Code: CodePreconditionRequired, Message: msg}
+}
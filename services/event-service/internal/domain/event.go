@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"slices"
 	"strings"
 	"time"
 
@@ -20,11 +21,21 @@ type Event struct {
 	ActiveParticipants int       `json:"active_participants"`
 
 	Status      EventStatus `json:"status"`
+	Visibility  Visibility  `json:"visibility"`
 	PublishedAt *time.Time  `json:"published_at,omitempty"`
 	CanceledAt  *time.Time  `json:"canceled_at,omitempty"`
 
+	// Scheduled transitions and registration window (see Schedule)
+	PublishAt            *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt          *time.Time `json:"unpublish_at,omitempty"`
+	RegistrationOpensAt  *time.Time `json:"registration_opens_at,omitempty"`
+	RegistrationClosesAt *time.Time `json:"registration_closes_at,omitempty"`
+
 	CoverImageIDs []string `json:"cover_image_ids,omitempty"` // max 2, references to media_uploads.id
 
+	// Version is bumped on every edit; it is the event's ETag.
+	Version int64 `json:"version"`
+
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -57,8 +68,8 @@ func NewDraft(ownerID, title, description, city, category string, start, end tim
 	if capacity < 0 {
 		return nil, ErrValidation("capacity must be >= 0 (0 means unlimited)")
 	}
-	if len(coverIDs) > 2 {
-		return nil, ErrValidation("maximum 2 cover images allowed")
+	if err := validateCoverIDs(coverIDs); err != nil {
+		return nil, err
 	}
 
 	return &Event{
@@ -72,7 +83,9 @@ func NewDraft(ownerID, title, description, city, category string, start, end tim
 		EndTime:       end.UTC(),
 		Capacity:      capacity,
 		Status:        StatusDraft,
+		Visibility:    VisibilityPublic,
 		CoverImageIDs: coverIDs,
+		Version:       1,
 		CreatedAt:     now.UTC(),
 		UpdatedAt:     now.UTC(),
 	}, nil
@@ -172,6 +185,11 @@ func (e *Event) ApplyUpdate(title, description, city, category *string, start, e
 	if (start != nil || end != nil) && !e.EndTime.After(e.StartTime) {
 		return ErrValidation("end_time must be after start_time")
 	}
+	if start != nil || end != nil {
+		if err := checkScheduleBounds(e.Schedule(), e.StartTime, e.EndTime); err != nil {
+			return err
+		}
+	}
 	if capacity != nil {
 		if *capacity < 0 {
 			return ErrValidation("capacity must be >= 0 (0 means unlimited)")
@@ -179,11 +197,41 @@ func (e *Event) ApplyUpdate(title, description, city, category *string, start, e
 		e.Capacity = *capacity
 	}
 	if coverIDs != nil {
-		if len(*coverIDs) > 2 {
-			return ErrValidation("maximum 2 cover images allowed")
+		if err := validateCoverIDs(*coverIDs); err != nil {
+			return err
 		}
 		e.CoverImageIDs = *coverIDs
 	}
 	e.UpdatedAt = now.UTC()
 	return nil
 }
+
+// validateCoverIDs checks the shape of cover references; whether the
+// uploads exist and may be used is media-service's call (MediaChecker).
+func validateCoverIDs(ids []string) error {
+	if len(ids) > 2 {
+		return ErrValidation("maximum 2 cover images allowed")
+	}
+	for i, id := range ids {
+		if _, err := uuid.Parse(id); err != nil {
+			return ErrValidationMeta("cover image id must be a UUID", map[string]string{"cover_image_id": id})
+		}
+		for _, prev := range ids[:i] {
+			if prev == id {
+				return ErrValidationMeta("duplicate cover image id", map[string]string{"cover_image_id": id})
+			}
+		}
+	}
+	return nil
+}
+
+// AddedCoverIDs returns the ids in next that are not in prev.
+func AddedCoverIDs(prev, next []string) []string {
+	var added []string
+	for _, id := range next {
+		if !slices.Contains(prev, id) {
+			added = append(added, id)
+		}
+	}
+	return added
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -111,16 +112,17 @@ func TestEvent_CoverImages(t *testing.T) {
 	now := mustTime(t, "2025-12-25T10:00:00Z")
 	start := now.Add(1 * time.Hour)
 	end := now.Add(2 * time.Hour)
+	img1, img2, img3 := uuid.NewString(), uuid.NewString(), uuid.NewString()
 
 	t.Run("allow_max_two_images", func(t *testing.T) {
-		images := []string{"img1", "img2"}
+		images := []string{img1, img2}
 		e, err := NewDraft("u1", "t", "d", "c", "cat", start, end, 0, images, now)
 		assert.NoError(t, err)
 		assert.Equal(t, images, e.CoverImageIDs)
 	})
 
 	t.Run("reject_more_than_two_images", func(t *testing.T) {
-		images := []string{"img1", "img2", "img3"}
+		images := []string{img1, img2, img3}
 		_, err := NewDraft("u1", "t", "d", "c", "cat", start, end, 0, images, now)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "maximum 2 cover images allowed")
@@ -128,7 +130,7 @@ func TestEvent_CoverImages(t *testing.T) {
 
 	t.Run("update_images_success", func(t *testing.T) {
 		e, _ := NewDraft("u1", "t", "d", "c", "cat", start, end, 0, nil, now)
-		newImages := []string{"new1"}
+		newImages := []string{img3}
 		err := e.ApplyUpdate(nil, nil, nil, nil, nil, nil, nil, &newImages, now)
 		assert.NoError(t, err)
 		assert.Equal(t, newImages, e.CoverImageIDs)
@@ -136,9 +138,64 @@ func TestEvent_CoverImages(t *testing.T) {
 
 	t.Run("update_reject_too_many_images", func(t *testing.T) {
 		e, _ := NewDraft("u1", "t", "d", "c", "cat", start, end, 0, nil, now)
-		newImages := []string{"new1", "new2", "new3"}
+		newImages := []string{img1, img2, img3}
 		err := e.ApplyUpdate(nil, nil, nil, nil, nil, nil, nil, &newImages, now)
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "maximum 2 cover images allowed")
 	})
+
+	t.Run("reject_non_uuid_id", func(t *testing.T) {
+		_, err := NewDraft("u1", "t", "d", "c", "cat", start, end, 0, []string{"img1"}, now)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must be a UUID")
+	})
+
+	t.Run("reject_duplicate_id", func(t *testing.T) {
+		e, _ := NewDraft("u1", "t", "d", "c", "cat", start, end, 0, nil, now)
+		dup := []string{img1, img1}
+		err := e.ApplyUpdate(nil, nil, nil, nil, nil, nil, nil, &dup, now)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "duplicate")
+	})
+
+	t.Run("added_cover_ids", func(t *testing.T) {
+		assert.Equal(t, []string{img3}, AddedCoverIDs([]string{img1, img2}, []string{img2, img3}))
+		assert.Empty(t, AddedCoverIDs([]string{img1, img2}, []string{img2}))
+	})
+}
+
+func TestEvent_Schedule(t *testing.T) {
+	now := mustTime(t, "2025-12-25T10:00:00Z")
+	at := func(d time.Duration) *time.Time { v := now.Add(d); return &v }
+	newEvent := func() *Event {
+		e, _ := NewDraft("u1", "t", "d", "c", "cat", now.Add(24*time.Hour), now.Add(26*time.Hour), 0, nil, now)
+		return e
+	}
+
+	t.Run("window_bounds", func(t *testing.T) {
+		e := newEvent()
+		err := e.SetSchedule(Schedule{RegistrationOpensAt: at(5 * time.Hour), RegistrationClosesAt: at(4 * time.Hour)}, now)
+		assert.Error(t, err)
+		assert.Contains(t, err.(*AppError).Meta, "registration_closes_at")
+
+		assert.NoError(t, e.SetSchedule(Schedule{RegistrationOpensAt: at(2 * time.Hour), RegistrationClosesAt: at(20 * time.Hour)}, now))
+		assert.False(t, e.RegistrationOpen(now))
+		assert.True(t, e.RegistrationOpen(now.Add(3*time.Hour)))
+		assert.False(t, e.RegistrationOpen(now.Add(20*time.Hour)))
+	})
+
+	t.Run("transitions_must_be_future", func(t *testing.T) {
+		e := newEvent()
+		assert.Error(t, e.SetSchedule(Schedule{PublishAt: at(-time.Minute)}, now))
+		assert.Error(t, e.SetSchedule(Schedule{PublishAt: at(2 * time.Hour), UnpublishAt: at(time.Hour)}, now))
+		assert.NoError(t, e.SetSchedule(Schedule{PublishAt: at(time.Hour)}, now))
+		assert.True(t, e.PublishDue(now.Add(time.Hour)))
+	})
+
+	t.Run("moving_start_revalidates", func(t *testing.T) {
+		e := newEvent()
+		assert.NoError(t, e.SetSchedule(Schedule{PublishAt: at(10 * time.Hour)}, now))
+		start, end := now.Add(5*time.Hour), now.Add(6*time.Hour)
+		assert.Error(t, e.ApplyUpdate(nil, nil, nil, nil, &start, &end, nil, nil, now))
+	})
 }
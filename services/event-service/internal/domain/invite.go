@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// InviteKind is how an invite is redeemed: a shareable signed link or a
+// short access code typed in by the attendee.
+type InviteKind string
+
+const (
+	InviteLink InviteKind = "link"
+	InviteCode InviteKind = "code"
+)
+
+// MaxActiveInvites caps live invites per event.
+const MaxActiveInvites = 50
+
+type Invite struct {
+	ID        string     `json:"id"`
+	EventID   string     `json:"event_id"`
+	Kind      InviteKind `json:"kind"`
+	Label     string     `json:"label"`
+	Code      string     `json:"code,omitempty"` // kind=code only; join-service only sees the hash
+	CodeHash  string     `json:"-"`
+	MaxUses   *int       `json:"max_uses,omitempty"`
+	UseCount  int        `json:"use_count"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Active reports whether the invite can still be redeemed.
+func (i *Invite) Active(now time.Time) bool {
+	if i.RevokedAt != nil {
+		return false
+	}
+	if i.ExpiresAt != nil && !now.Before(*i.ExpiresAt) {
+		return false
+	}
+	return i.MaxUses == nil || i.UseCount < *i.MaxUses
+}
+
+// accessCodeAlphabet avoids look-alike characters (0/O, 1/I/L).
+const accessCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// NewAccessCode returns a random 8-character access code.
+func NewAccessCode() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	for i := range b {
+		b[i] = accessCodeAlphabet[int(b[i])%len(accessCodeAlphabet)]
+	}
+	return string(b), nil
+}
+
+// NormalizeAccessCode uppercases and strips spaces and dashes so codes can
+// be typed loosely. ok is false if the result is not 4-32 letters/digits.
+func NormalizeAccessCode(code string) (string, bool) {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		switch {
+		case r == ' ' || r == '-':
+			continue
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			sb.WriteRune(r)
+		default:
+			return "", false
+		}
+	}
+	out := sb.String()
+	return out, len(out) >= 4 && len(out) <= 32
+}
+
+// HashAccessCode is the form of an access code shared with join-service.
+// It is salted with the event id so equal codes on different events differ.
+func HashAccessCode(eventID, normalizedCode string) string {
+	sum := sha256.Sum256([]byte(eventID + ":" + normalizedCode))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ReportTarget is what a user can report.
+type ReportTarget string
+
+const (
+	ReportTargetEvent   ReportTarget = "event"
+	ReportTargetComment ReportTarget = "comment"
+	ReportTargetUser    ReportTarget = "user"
+)
+
+func (t ReportTarget) Valid() bool {
+	return t == ReportTargetEvent || t == ReportTargetComment || t == ReportTargetUser
+}
+
+// ReportReason is the category a reporter picks.
+type ReportReason string
+
+const (
+	ReasonSpam          ReportReason = "spam"
+	ReasonHarassment    ReportReason = "harassment"
+	ReasonInappropriate ReportReason = "inappropriate"
+	ReasonScam          ReportReason = "scam"
+	ReasonMisleading    ReportReason = "misleading"
+	ReasonOther         ReportReason = "other"
+)
+
+func (r ReportReason) Valid() bool {
+	switch r {
+	case ReasonSpam, ReasonHarassment, ReasonInappropriate, ReasonScam, ReasonMisleading, ReasonOther:
+		return true
+	}
+	return false
+}
+
+// CaseStatus is where a moderation case is in the queue.
+type CaseStatus string
+
+const (
+	CaseOpen      CaseStatus = "open"
+	CaseTriaged   CaseStatus = "triaged"
+	CaseActioned  CaseStatus = "actioned"
+	CaseDismissed CaseStatus = "dismissed"
+)
+
+func (s CaseStatus) Valid() bool {
+	return s == CaseOpen || s == CaseTriaged || s == CaseActioned || s == CaseDismissed
+}
+
+// ModerationAction is what a moderator did when actioning a case.
+type ModerationAction string
+
+const (
+	ActionUnpublishEvent ModerationAction = "unpublish_event"
+	ActionCancelEvent    ModerationAction = "cancel_event"
+	ActionRemoveComment  ModerationAction = "remove_comment"
+	// ActionBanUser bans the account in auth-service before the case closes.
+	ActionBanUser ModerationAction = "ban_user"
+)
+
+// AppliesTo reports whether the action makes sense for the target type.
+func (a ModerationAction) AppliesTo(t ReportTarget) bool {
+	switch a {
+	case ActionUnpublishEvent, ActionCancelEvent:
+		return t == ReportTargetEvent
+	case ActionRemoveComment:
+		return t == ReportTargetComment
+	case ActionBanUser:
+		return t == ReportTargetUser
+	}
+	return false
+}
+
+// SystemActor is recorded as the actor of automatic moderation.
+const SystemActor = "system"
+
+const (
+	MaxReportDetails  = 500
+	MaxResolutionNote = MaxCommentRemovalNote
+)
+
+// Report is one user's report of a target. Reports are deduplicated per
+// reporter within a ModerationCase.
+type Report struct {
+	ID         string
+	CaseID     string
+	ReporterID string
+	Reason     ReportReason
+	Details    string
+	CreatedAt  time.Time
+}
+
+// ModerationCase aggregates the reports of one target until a moderator
+// closes it; a later report opens a new case. EventID is the event the
+// target belongs to (empty for users).
+type ModerationCase struct {
+	ID           string
+	TargetType   ReportTarget
+	TargetID     string
+	EventID      string
+	Status       CaseStatus
+	ReportCount  int // distinct reporters
+	ReasonCounts map[ReportReason]int
+
+	CreatedAt      time.Time
+	LastReportedAt time.Time
+	UpdatedAt      time.Time
+
+	// Set when the target was hidden after reaching the report threshold.
+	AutoHiddenAt *time.Time
+
+	TriagedBy string
+	TriagedAt *time.Time
+
+	Action         ModerationAction // actioned cases only
+	ResolutionNote string
+	ResolvedBy     string
+	ResolvedAt     *time.Time
+}
+
+// IsClosed reports whether a moderator has actioned or dismissed the case.
+// Closed cases take no further reports.
+func (c *ModerationCase) IsClosed() bool {
+	return c.Status == CaseActioned || c.Status == CaseDismissed
+}
+
+// NormalizeReport checks a report before it is recorded. eventID is
+// required for comment targets, which are addressed within their event.
+func NormalizeReport(target ReportTarget, targetID, eventID string, reason ReportReason, details string) (string, error) {
+	details = strings.TrimSpace(details)
+
+	meta := map[string]string{}
+	if !target.Valid() {
+		meta["target_type"] = "must be event, comment or user"
+	}
+	if strings.TrimSpace(targetID) == "" {
+		meta["target_id"] = "required"
+	}
+	if target == ReportTargetComment && strings.TrimSpace(eventID) == "" {
+		meta["event_id"] = "required for comments"
+	}
+	if !reason.Valid() {
+		meta["reason"] = "must be spam, harassment, inappropriate, scam, misleading or other"
+	}
+	if utf8.RuneCountInString(details) > MaxReportDetails {
+		meta["details"] = "must be <= 500 chars"
+	}
+	if len(meta) > 0 {
+		return "", ErrValidationMeta("invalid report", meta)
+	}
+	return details, nil
+}
+
+// AddReport counts a new distinct report.
+func (c *ModerationCase) AddReport(reason ReportReason, now time.Time) {
+	if c.ReasonCounts == nil {
+		c.ReasonCounts = map[ReportReason]int{}
+	}
+	c.ReportCount++
+	c.ReasonCounts[reason]++
+	c.LastReportedAt = now.UTC()
+	c.UpdatedAt = now.UTC()
+}
+
+// ShouldAutoHide reports whether the target should be hidden pending
+// review. Users are never auto-hidden; threshold <= 0 disables it.
+func (c *ModerationCase) ShouldAutoHide(threshold int) bool {
+	return threshold > 0 &&
+		c.TargetType != ReportTargetUser &&
+		!c.IsClosed() &&
+		c.AutoHiddenAt == nil &&
+		c.ReportCount >= threshold
+}
+
+// Triage claims an open case for review.
+func (c *ModerationCase) Triage(actorID string, now time.Time) error {
+	if c.Status != CaseOpen {
+		return ErrInvalidState("only open cases can be triaged")
+	}
+	t := now.UTC()
+	c.Status = CaseTriaged
+	c.TriagedBy = actorID
+	c.TriagedAt = &t
+	c.UpdatedAt = t
+	return nil
+}
+
+// Resolve closes the case. Actioned cases name the action taken; dismissed
+// cases take none.
+func (c *ModerationCase) Resolve(actorID string, status CaseStatus, action ModerationAction, note string, now time.Time) error {
+	if c.IsClosed() {
+		return ErrInvalidState("case already resolved")
+	}
+	note = strings.TrimSpace(note)
+
+	meta := map[string]string{}
+	switch status {
+	case CaseActioned:
+		if !action.AppliesTo(c.TargetType) {
+			meta["action"] = "not valid for a " + string(c.TargetType)
+		}
+	case CaseDismissed:
+		if action != "" {
+			meta["action"] = "must be empty when dismissing"
+		}
+	default:
+		meta["status"] = "must be actioned or dismissed"
+	}
+	if utf8.RuneCountInString(note) > MaxResolutionNote {
+		meta["note"] = "must be <= 200 chars"
+	}
+	if len(meta) > 0 {
+		return ErrValidationMeta("invalid resolution", meta)
+	}
+
+	t := now.UTC()
+	c.Status = status
+	c.Action = action
+	c.ResolutionNote = note
+	c.ResolvedBy = actorID
+	c.ResolvedAt = &t
+	c.UpdatedAt = t
+	return nil
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MinRating           = 1
+	MaxRating           = 5
+	MaxReviewBody       = 2000
+	MaxReviewHideReason = 200
+
+	// ReviewWindow is how long after EndTime attendees may rate an event.
+	ReviewWindow = 14 * 24 * time.Hour
+)
+
+// Review is an attendee's rating of an event that has ended. OrganizerID is
+// the owner when the review was first written, so reputation stays with the
+// organizer who ran the event even if ownership moves later.
+type Review struct {
+	ID          string
+	EventID     string
+	OrganizerID string
+	AuthorID    string
+	Rating      int
+	Body        string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	// Set by a moderator; hidden reviews leave the public list and the
+	// aggregates but the author cannot overwrite them.
+	HiddenAt     *time.Time
+	HiddenBy     string
+	HiddenReason string
+}
+
+func (r *Review) IsHidden() bool { return r.HiddenAt != nil }
+
+// RatingSummary aggregates visible reviews. Average is 0 when Count is 0.
+type RatingSummary struct {
+	Average float64
+	Count   int
+}
+
+// NormalizeReview checks the rating range and trims the optional body.
+func NormalizeReview(rating int, body string) (int, string, error) {
+	body = strings.TrimSpace(body)
+
+	meta := map[string]string{}
+	if rating < MinRating || rating > MaxRating {
+		meta["rating"] = "must be between 1 and 5"
+	}
+	if utf8.RuneCountInString(body) > MaxReviewBody {
+		meta["body"] = "must be <= 2000 chars"
+	}
+	if len(meta) > 0 {
+		return 0, "", ErrValidationMeta("invalid review", meta)
+	}
+	return rating, body, nil
+}
+
+// CheckReviewWindow reports whether e can be reviewed at now: it must be a
+// published event that has ended no more than ReviewWindow ago.
+func CheckReviewWindow(e *Event, now time.Time) error {
+	if e.Status != StatusPublished {
+		return ErrInvalidState("only published events can be reviewed")
+	}
+	if !e.IsEnded(now) {
+		return ErrInvalidState("event has not ended yet")
+	}
+	if now.After(e.EndTime.Add(ReviewWindow)) {
+		return ErrInvalidState("review window has closed")
+	}
+	return nil
+}
+
+// Hide takes the review out of public view.
+func (r *Review) Hide(actorID, reason string, now time.Time) error {
+	if r.IsHidden() {
+		return ErrInvalidState("review already hidden")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" || utf8.RuneCountInString(reason) > MaxReviewHideReason {
+		return ErrValidationMeta("invalid reason", map[string]string{
+			"reason": "required, <= 200 chars",
+		})
+	}
+	t := now.UTC()
+	r.HiddenAt = &t
+	r.HiddenBy = actorID
+	r.HiddenReason = reason
+	r.UpdatedAt = t
+	return nil
+}
+
+func (r *Review) Unhide(now time.Time) error {
+	if !r.IsHidden() {
+		return ErrInvalidState("review is not hidden")
+	}
+	r.HiddenAt = nil
+	r.HiddenBy = ""
+	r.HiddenReason = ""
+	r.UpdatedAt = now.UTC()
+	return nil
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+type RevisionAction string
+
+const (
+	RevisionUpdate    RevisionAction = "update"
+	RevisionSchedule  RevisionAction = "schedule"
+	RevisionPublish   RevisionAction = "publish"
+	RevisionUnpublish RevisionAction = "unpublish"
+	RevisionCancel    RevisionAction = "cancel"
+)
+
+// FieldChange is one field's value before and after a write.
+type FieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// Revision is one entry of an event's change history, written in the same
+// transaction as the change. Version is the event version it produced.
+type Revision struct {
+	ID        string
+	EventID   string
+	Version   int64
+	Action    RevisionAction
+	ActorID   string
+	ActorRole string
+	Changes   map[string]FieldChange
+	Reason    string
+	CreatedAt time.Time
+}
+
+// MaterialFields are the fields attendees are notified about when they
+// change on a published event.
+var MaterialFields = []string{"start_time", "end_time", "city", "capacity"}
+
+// MaterialChanges returns the subset of changes attendees care about.
+func MaterialChanges(changes map[string]FieldChange) map[string]FieldChange {
+	out := map[string]FieldChange{}
+	for _, f := range MaterialFields {
+		if c, ok := changes[f]; ok {
+			out[f] = c
+		}
+	}
+	return out
+}
+
+// DiffEvents returns the user-visible fields that differ between before and
+// after, keyed by their JSON name. Bookkeeping (version, timestamps of the
+// write itself, participant count) is not part of the diff.
+func DiffEvents(before, after *Event) map[string]FieldChange {
+	out := map[string]FieldChange{}
+	add := func(field string, from, to any) { out[field] = FieldChange{From: from, To: to} }
+
+	if before.Title != after.Title {
+		add("title", before.Title, after.Title)
+	}
+	if before.Description != after.Description {
+		add("description", before.Description, after.Description)
+	}
+	if before.City != after.City {
+		add("city", before.City, after.City)
+	}
+	if before.Category != after.Category {
+		add("category", before.Category, after.Category)
+	}
+	if !before.StartTime.Equal(after.StartTime) {
+		add("start_time", before.StartTime, after.StartTime)
+	}
+	if !before.EndTime.Equal(after.EndTime) {
+		add("end_time", before.EndTime, after.EndTime)
+	}
+	if before.Capacity != after.Capacity {
+		add("capacity", before.Capacity, after.Capacity)
+	}
+	if !slices.Equal(before.CoverImageIDs, after.CoverImageIDs) {
+		add("cover_image_ids", before.CoverImageIDs, after.CoverImageIDs)
+	}
+	if before.Status != after.Status {
+		add("status", before.Status, after.Status)
+	}
+	if before.Visibility != after.Visibility {
+		add("visibility", before.Visibility, after.Visibility)
+	}
+
+	times := []struct {
+		field    string
+		from, to *time.Time
+	}{
+		{"publish_at", before.PublishAt, after.PublishAt},
+		{"unpublish_at", before.UnpublishAt, after.UnpublishAt},
+		{"registration_opens_at", before.RegistrationOpensAt, after.RegistrationOpensAt},
+		{"registration_closes_at", before.RegistrationClosesAt, after.RegistrationClosesAt},
+	}
+	for _, t := range times {
+		if (t.from == nil) != (t.to == nil) || (t.from != nil && !t.from.Equal(*t.to)) {
+			add(t.field, t.from, t.to)
+		}
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+package domain
+
+import "time"
+
+// Schedule holds an event's timed transitions and registration window.
+// Every field is optional; nil means "not scheduled" / "no bound".
+type Schedule struct {
+	PublishAt            *time.Time
+	UnpublishAt          *time.Time
+	RegistrationOpensAt  *time.Time
+	RegistrationClosesAt *time.Time
+}
+
+func (e *Event) Schedule() Schedule {
+	return Schedule{
+		PublishAt:            e.PublishAt,
+		UnpublishAt:          e.UnpublishAt,
+		RegistrationOpensAt:  e.RegistrationOpensAt,
+		RegistrationClosesAt: e.RegistrationClosesAt,
+	}
+}
+
+// SetSchedule replaces the schedule. publish_at only applies to drafts, and
+// both transitions must lie in the future.
+func (e *Event) SetSchedule(s Schedule, now time.Time) error {
+	if e.Status == StatusCanceled {
+		return ErrInvalidState("canceled event cannot be scheduled")
+	}
+	if e.IsEnded(now) {
+		return ErrInvalidState("ended event cannot be scheduled")
+	}
+
+	s = Schedule{
+		PublishAt:            utcPtr(s.PublishAt),
+		UnpublishAt:          utcPtr(s.UnpublishAt),
+		RegistrationOpensAt:  utcPtr(s.RegistrationOpensAt),
+		RegistrationClosesAt: utcPtr(s.RegistrationClosesAt),
+	}
+
+	meta := map[string]string{}
+	if s.PublishAt != nil {
+		switch {
+		case e.Status == StatusPublished:
+			meta["publish_at"] = "event is already published"
+		case !s.PublishAt.After(now):
+			meta["publish_at"] = "must be in the future"
+		}
+	}
+	if s.UnpublishAt != nil && !s.UnpublishAt.After(now) {
+		meta["unpublish_at"] = "must be in the future"
+	}
+	if len(meta) > 0 {
+		return ErrValidationMeta("invalid schedule", meta)
+	}
+
+	if err := checkScheduleBounds(s, e.StartTime, e.EndTime); err != nil {
+		return err
+	}
+
+	e.PublishAt = s.PublishAt
+	e.UnpublishAt = s.UnpublishAt
+	e.RegistrationOpensAt = s.RegistrationOpensAt
+	e.RegistrationClosesAt = s.RegistrationClosesAt
+	e.UpdatedAt = now.UTC()
+	return nil
+}
+
+// checkScheduleBounds validates the schedule against the event's own times.
+// ApplyUpdate re-runs it when start_time or end_time move.
+func checkScheduleBounds(s Schedule, start, end time.Time) error {
+	meta := map[string]string{}
+	if s.PublishAt != nil && !s.PublishAt.Before(start) {
+		meta["publish_at"] = "must be before start_time"
+	}
+	if s.UnpublishAt != nil {
+		if !s.UnpublishAt.Before(end) {
+			meta["unpublish_at"] = "must be before end_time"
+		} else if s.PublishAt != nil && !s.UnpublishAt.After(*s.PublishAt) {
+			meta["unpublish_at"] = "must be after publish_at"
+		}
+	}
+	if s.RegistrationOpensAt != nil && !s.RegistrationOpensAt.Before(end) {
+		meta["registration_opens_at"] = "must be before end_time"
+	}
+	if s.RegistrationClosesAt != nil {
+		if s.RegistrationClosesAt.After(end) {
+			meta["registration_closes_at"] = "must not be after end_time"
+		} else if s.RegistrationOpensAt != nil && !s.RegistrationClosesAt.After(*s.RegistrationOpensAt) {
+			meta["registration_closes_at"] = "must be after registration_opens_at"
+		}
+	}
+	if len(meta) > 0 {
+		return ErrValidationMeta("invalid schedule", meta)
+	}
+	return nil
+}
+
+// PublishDue reports whether a scheduled publish should run at now.
+func (e *Event) PublishDue(now time.Time) bool {
+	return e.Status == StatusDraft && e.PublishAt != nil && !e.PublishAt.After(now)
+}
+
+// UnpublishDue reports whether a scheduled unpublish should run at now.
+func (e *Event) UnpublishDue(now time.Time) bool {
+	return e.Status == StatusPublished && e.UnpublishAt != nil && !e.UnpublishAt.After(now)
+}
+
+// RegistrationOpen reports whether now falls inside the registration window.
+func (e *Event) RegistrationOpen(now time.Time) bool {
+	if e.RegistrationOpensAt != nil && now.Before(*e.RegistrationOpensAt) {
+		return false
+	}
+	return e.RegistrationClosesAt == nil || now.Before(*e.RegistrationClosesAt)
+}
+
+func utcPtr(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	v := t.UTC()
+	return &v
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// Visibility controls who can find and join a published event.
+type Visibility string
+
+const (
+	// VisibilityPublic events are listed, indexed by the feed and joinable by anyone.
+	VisibilityPublic Visibility = "public"
+	// VisibilityUnlisted events are reachable by link only.
+	VisibilityUnlisted Visibility = "unlisted"
+	// VisibilityInviteOnly events are reachable by link and joinable only
+	// with an invite token or access code.
+	VisibilityInviteOnly Visibility = "invite_only"
+)
+
+// ParseVisibility accepts "" as public.
+func ParseVisibility(s string) (Visibility, bool) {
+	v := Visibility(strings.ToLower(strings.TrimSpace(s)))
+	if v == "" {
+		return VisibilityPublic, true
+	}
+	return v, v.Valid()
+}
+
+func (v Visibility) Valid() bool {
+	return v == VisibilityPublic || v == VisibilityUnlisted || v == VisibilityInviteOnly
+}
+
+// Listed reports whether the event appears in public lists and the feed.
+func (v Visibility) Listed() bool { return v == VisibilityPublic || v == "" }
+
+// SetVisibility changes who can see the event. A published event keeps its
+// visibility; consumers learn it from event.published, so the organizer has
+// to unpublish first.
+func (e *Event) SetVisibility(v Visibility, now time.Time) error {
+	if !v.Valid() {
+		return ErrValidationMeta("invalid visibility", map[string]string{
+			"visibility": "must be one of public, unlisted, invite_only",
+		})
+	}
+	if v == e.Visibility {
+		return nil
+	}
+	if e.Status == StatusCanceled {
+		return ErrInvalidState("canceled event cannot be updated")
+	}
+	if e.Status == StatusPublished {
+		return ErrInvalidState("unpublish the event to change its visibility")
+	}
+	e.Visibility = v
+	e.UpdatedAt = now.UTC()
+	return nil
+}
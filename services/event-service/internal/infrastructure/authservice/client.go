@@ -0,0 +1,80 @@
+package authservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+// Client bans accounts through auth-service's internal routes. It
+// implements event.AccountBanner.
+type Client struct {
+	baseURL        string
+	internalSecret string
+	http           *http.Client
+}
+
+func NewClient(baseURL, internalSecret string) *Client {
+	return &Client{
+		baseURL:        baseURL,
+		internalSecret: internalSecret,
+		http:           &http.Client{Timeout: 3 * time.Second},
+	}
+}
+
+type banRequest struct {
+	ActorID   string `json:"actor_id"`
+	ActorRole string `json:"actor_role"`
+}
+
+type errorBody struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// BanUser bans userID on behalf of the acting moderator. auth-service
+// applies its own rules (no self-bans, moderators cannot ban admins);
+// banning an already banned account succeeds.
+func (c *Client) BanUser(ctx context.Context, actorID, actorRole, userID string) error {
+	body, err := json.Marshal(banRequest{ActorID: actorID, ActorRole: actorRole})
+	if err != nil {
+		return err
+	}
+	path := "/internal/users/" + url.PathEscape(userID) + "/ban"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Internal-Secret", c.internalSecret)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var eb errorBody
+	_ = json.NewDecoder(resp.Body).Decode(&eb)
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		return domain.ErrNotFound("user not found")
+	case http.StatusForbidden:
+		return domain.ErrForbidden(eb.Error.Message)
+	case http.StatusBadRequest:
+		return domain.ErrValidation(eb.Error.Message)
+	}
+	return fmt.Errorf("auth-service POST %s: status %d", path, resp.StatusCode)
+}
@@ -25,7 +25,8 @@ INSERT INTO event_outbox (
 const selectEventForUpdateSQL = `
 SELECT id, owner_id, title, description, city, category,
        start_time, end_time, capacity, active_participants, status,
-       published_at, canceled_at, created_at, updated_at, cover_image_ids
+       published_at, canceled_at, created_at, updated_at, cover_image_ids, visibility,
+       publish_at, unpublish_at, registration_opens_at, registration_closes_at, version
 FROM events WHERE id = $1
 FOR UPDATE
 `
@@ -35,29 +36,24 @@ func (r *txRepo) GetByIDForUpdate(ctx context.Context, id string) (*domain.Event
 
 	var e domain.Event
 	var status string
-	var coverIDsJSON string
+	var coverIDsJSON, visibility string
 	err := row.Scan(
 		&e.ID, &e.OwnerID, &e.Title, &e.Description, &e.City, &e.Category,
 		&e.StartTime, &e.EndTime, &e.Capacity, &e.ActiveParticipants, &status,
-		&e.PublishedAt, &e.CanceledAt, &e.CreatedAt, &e.UpdatedAt, &coverIDsJSON,
+		&e.PublishedAt, &e.CanceledAt, &e.CreatedAt, &e.UpdatedAt, &coverIDsJSON, &visibility,
+		&e.PublishAt, &e.UnpublishAt, &e.RegistrationOpensAt, &e.RegistrationClosesAt, &e.Version,
 	)
 	if err != nil {
 		return nil, err
 	}
 	e.Status = domain.EventStatus(status)
+	e.Visibility = domain.Visibility(visibility)
 	_ = json.Unmarshal([]byte(coverIDsJSON), &e.CoverImageIDs)
 	return &e, nil
 }
 
 func (r *txRepo) Update(ctx context.Context, e *domain.Event) error {
-	coverIDsJSON, _ := json.Marshal(e.CoverImageIDs)
-	_, err := r.tx.ExecContext(ctx, updateEventSQL,
-		e.ID,
-		e.Title, e.Description, e.City, domain.NormalizeCity(e.City), e.Category,
-		e.StartTime, e.EndTime, e.Capacity, string(e.Status),
-		e.PublishedAt, e.CanceledAt, e.UpdatedAt, string(coverIDsJSON),
-	)
-	return err
+	return updateEvent(ctx, r.tx, e)
 }
 
 func (r *txRepo) InsertOutbox(ctx context.Context, msg event.OutboxMessage) error {
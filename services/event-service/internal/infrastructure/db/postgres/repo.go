@@ -22,7 +22,8 @@ func (r *Repo) Create(ctx context.Context, e *domain.Event) error {
 	_, err := r.db.ExecContext(ctx, insertEventSQL,
 		e.ID, e.OwnerID, e.Title, e.Description, e.City, domain.NormalizeCity(e.City), e.Category,
 		e.StartTime, e.EndTime, e.Capacity, string(e.Status),
-		e.PublishedAt, e.CanceledAt, e.CreatedAt, e.UpdatedAt, string(coverIDsJSON),
+		e.PublishedAt, e.CanceledAt, e.CreatedAt, e.UpdatedAt, string(coverIDsJSON), string(e.Visibility),
+		e.PublishAt, e.UnpublishAt, e.RegistrationOpensAt, e.RegistrationClosesAt, e.Version,
 	)
 	return err
 }
@@ -32,11 +33,12 @@ func (r *Repo) GetByID(ctx context.Context, id string) (*domain.Event, error) {
 
 	var e domain.Event
 	var status string
-	var coverIDsJSON string
+	var coverIDsJSON, visibility string
 	err := row.Scan(
 		&e.ID, &e.OwnerID, &e.Title, &e.Description, &e.City, &e.Category,
 		&e.StartTime, &e.EndTime, &e.Capacity, &e.ActiveParticipants, &status,
-		&e.PublishedAt, &e.CanceledAt, &e.CreatedAt, &e.UpdatedAt, &coverIDsJSON,
+		&e.PublishedAt, &e.CanceledAt, &e.CreatedAt, &e.UpdatedAt, &coverIDsJSON, &visibility,
+		&e.PublishAt, &e.UnpublishAt, &e.RegistrationOpensAt, &e.RegistrationClosesAt, &e.Version,
 	)
 	if err == sql.ErrNoRows {
 		return nil, domain.ErrNotFound("event not found")
@@ -45,6 +47,7 @@ func (r *Repo) GetByID(ctx context.Context, id string) (*domain.Event, error) {
 		return nil, err
 	}
 	e.Status = domain.EventStatus(status)
+	e.Visibility = domain.Visibility(visibility)
 	_ = json.Unmarshal([]byte(coverIDsJSON), &e.CoverImageIDs)
 	if !e.Status.Valid() {
 		return nil, domain.ErrInvalidState("invalid status in db")
@@ -53,13 +56,24 @@ func (r *Repo) GetByID(ctx context.Context, id string) (*domain.Event, error) {
 }
 
 func (r *Repo) Update(ctx context.Context, e *domain.Event) error {
+	return updateEvent(ctx, r.db, e)
+}
+
+// updateEvent writes e if it is still at e.Version and bumps e.Version.
+// A concurrent edit in between fails with a precondition error.
+func updateEvent(ctx context.Context, q queryer, e *domain.Event) error {
 	coverIDsJSON, _ := json.Marshal(e.CoverImageIDs)
-	_, err := r.db.ExecContext(ctx, updateEventSQL,
+	err := q.QueryRowContext(ctx, updateEventSQL,
 		e.ID,
 		e.Title, e.Description, e.City, domain.NormalizeCity(e.City), e.Category,
 		e.StartTime, e.EndTime, e.Capacity, string(e.Status),
-		e.PublishedAt, e.CanceledAt, e.UpdatedAt, string(coverIDsJSON),
-	)
+		e.PublishedAt, e.CanceledAt, e.UpdatedAt, string(coverIDsJSON), string(e.Visibility),
+		e.PublishAt, e.UnpublishAt, e.RegistrationOpensAt, e.RegistrationClosesAt,
+		e.Version,
+	).Scan(&e.Version)
+	if err == sql.ErrNoRows {
+		return domain.ErrPreconditionFailed("event was modified concurrently")
+	}
 	return err
 }
 
@@ -84,7 +98,8 @@ func (r *Repo) GetByIDs(ctx context.Context, ids []string) ([]*domain.Event, err
 	query := `
 SELECT id, owner_id, title, description, city, category,
        start_time, end_time, capacity, active_participants, status,
-       published_at, canceled_at, created_at, updated_at, cover_image_ids
+       published_at, canceled_at, created_at, updated_at, cover_image_ids, visibility,
+       publish_at, unpublish_at, registration_opens_at, registration_closes_at
 FROM events
 WHERE id IN (` + strings.Join(placeholders, ", ") + `) AND status = 'published'`
 
@@ -98,15 +113,17 @@ WHERE id IN (` + strings.Join(placeholders, ", ") + `) AND status = 'published'`
 	for rows.Next() {
 		var e domain.Event
 		var status string
-		var coverIDsJSON string
+		var coverIDsJSON, visibility string
 		if err := rows.Scan(
 			&e.ID, &e.OwnerID, &e.Title, &e.Description, &e.City, &e.Category,
 			&e.StartTime, &e.EndTime, &e.Capacity, &e.ActiveParticipants, &status,
-			&e.PublishedAt, &e.CanceledAt, &e.CreatedAt, &e.UpdatedAt, &coverIDsJSON,
+			&e.PublishedAt, &e.CanceledAt, &e.CreatedAt, &e.UpdatedAt, &coverIDsJSON, &visibility,
+			&e.PublishAt, &e.UnpublishAt, &e.RegistrationOpensAt, &e.RegistrationClosesAt,
 		); err != nil {
 			return nil, err
 		}
 		e.Status = domain.EventStatus(status)
+		e.Visibility = domain.Visibility(visibility)
 		_ = json.Unmarshal([]byte(coverIDsJSON), &e.CoverImageIDs)
 		out = append(out, &e)
 	}
@@ -153,7 +170,8 @@ func (r *Repo) ListByOwner(ctx context.Context, ownerID string, status string, p
 	listSQL := `
 SELECT id, owner_id, title, description, city, category,
        start_time, end_time, capacity, active_participants, status,
-       published_at, canceled_at, created_at, updated_at, cover_image_ids
+       published_at, canceled_at, created_at, updated_at, cover_image_ids, visibility,
+       publish_at, unpublish_at, registration_opens_at, registration_closes_at, version
 FROM events
 ` + whereSQL + `
 ORDER BY created_at DESC
@@ -171,15 +189,17 @@ LIMIT $` + fmt.Sprintf("%d", argN) + ` OFFSET $` + fmt.Sprintf("%d", argN+1)
 	for rows.Next() {
 		var e domain.Event
 		var s string
-		var coverIDsJSON string
+		var coverIDsJSON, visibility string
 		if err := rows.Scan(
 			&e.ID, &e.OwnerID, &e.Title, &e.Description, &e.City, &e.Category,
 			&e.StartTime, &e.EndTime, &e.Capacity, &e.ActiveParticipants, &s,
-			&e.PublishedAt, &e.CanceledAt, &e.CreatedAt, &e.UpdatedAt, &coverIDsJSON,
+			&e.PublishedAt, &e.CanceledAt, &e.CreatedAt, &e.UpdatedAt, &coverIDsJSON, &visibility,
+			&e.PublishAt, &e.UnpublishAt, &e.RegistrationOpensAt, &e.RegistrationClosesAt, &e.Version,
 		); err != nil {
 			return nil, 0, err
 		}
 		e.Status = domain.EventStatus(s)
+		e.Visibility = domain.Visibility(visibility)
 		_ = json.Unmarshal([]byte(coverIDsJSON), &e.CoverImageIDs)
 		out = append(out, &e)
 	}
@@ -204,7 +224,7 @@ func (r *Repo) ListPublic(ctx context.Context, f event.ListFilter) ([]*domain.Ev
 	category := strings.TrimSpace(f.Category)
 	query := strings.TrimSpace(f.Query)
 
-	where := []string{"status = 'published'"}
+	where := []string{"status = 'published'", "visibility = 'public'"}
 	args := []any{}
 	argN := 1
 
@@ -278,6 +298,7 @@ LIMIT $` + fmt.Sprintf("%d", argN) + ` OFFSET $` + fmt.Sprintf("%d", argN+1)
 			return nil, 0, err
 		}
 		e.Status = domain.EventStatus(status)
+		e.Visibility = domain.VisibilityPublic
 		_ = json.Unmarshal([]byte(coverIDsJSON), &e.CoverImageIDs)
 		out = append(out, &e)
 	}
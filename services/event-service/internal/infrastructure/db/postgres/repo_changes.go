@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/application/event"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+func (r *Repo) ListChangedSince(
+	ctx context.Context,
+	hasCursor bool,
+	afterUpdated time.Time,
+	afterID string,
+	limit int,
+) ([]event.EventChange, error) {
+	q := `
+SELECT id, status, updated_at
+FROM events
+WHERE updated_at >= $1
+ORDER BY updated_at ASC, id ASC
+LIMIT $2`
+	args := []any{afterUpdated.UTC(), limit}
+
+	if hasCursor {
+		q = `
+SELECT id, status, updated_at
+FROM events
+WHERE (updated_at, id) > ($1, $3)
+ORDER BY updated_at ASC, id ASC
+LIMIT $2`
+		args = append(args, afterID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]event.EventChange, 0, limit)
+	for rows.Next() {
+		var c event.EventChange
+		var status string
+		if err := rows.Scan(&c.ID, &status, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		c.Status = domain.EventStatus(status)
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+const getCollaboratorRoleSQL = `
+SELECT role FROM event_collaborators WHERE event_id = $1 AND user_id = $2
+`
+
+const listCollaboratorsSQL = `
+SELECT event_id, user_id, role, added_by, created_at, updated_at
+FROM event_collaborators
+WHERE event_id = $1
+ORDER BY created_at ASC, user_id ASC
+`
+
+const upsertCollaboratorSQL = `
+INSERT INTO event_collaborators (event_id, user_id, role, added_by, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (event_id, user_id) DO UPDATE SET
+  role = EXCLUDED.role,
+  added_by = EXCLUDED.added_by,
+  updated_at = EXCLUDED.updated_at
+`
+
+const deleteCollaboratorSQL = `
+DELETE FROM event_collaborators WHERE event_id = $1 AND user_id = $2
+`
+
+const setOwnerSQL = `
+UPDATE events SET owner_id = $2, updated_at = $3, version = version + 1 WHERE id = $1
+`
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func getCollaboratorRole(ctx context.Context, q queryer, eventID, userID string) (domain.CollaboratorRole, error) {
+	var role string
+	err := q.QueryRowContext(ctx, getCollaboratorRoleSQL, eventID, userID).Scan(&role)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return domain.CollaboratorRole(role), nil
+}
+
+func listCollaborators(ctx context.Context, q queryer, eventID string) ([]domain.Collaborator, error) {
+	rows, err := q.QueryContext(ctx, listCollaboratorsSQL, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Collaborator{}
+	for rows.Next() {
+		var c domain.Collaborator
+		var role string
+		if err := rows.Scan(&c.EventID, &c.UserID, &role, &c.AddedBy, &c.CreatedAt, &c.UpdatedAt); err != nil {
+			return nil, err
+		}
+		c.Role = domain.CollaboratorRole(role)
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repo) GetCollaboratorRole(ctx context.Context, eventID, userID string) (domain.CollaboratorRole, error) {
+	return getCollaboratorRole(ctx, r.db, eventID, userID)
+}
+
+func (r *Repo) ListCollaborators(ctx context.Context, eventID string) ([]domain.Collaborator, error) {
+	return listCollaborators(ctx, r.db, eventID)
+}
+
+func (r *txRepo) GetCollaboratorRole(ctx context.Context, eventID, userID string) (domain.CollaboratorRole, error) {
+	return getCollaboratorRole(ctx, r.tx, eventID, userID)
+}
+
+func (r *txRepo) ListCollaborators(ctx context.Context, eventID string) ([]domain.Collaborator, error) {
+	return listCollaborators(ctx, r.tx, eventID)
+}
+
+func (r *txRepo) UpsertCollaborator(ctx context.Context, c domain.Collaborator) error {
+	_, err := r.tx.ExecContext(ctx, upsertCollaboratorSQL,
+		c.EventID, c.UserID, string(c.Role), c.AddedBy, c.CreatedAt, c.UpdatedAt,
+	)
+	return err
+}
+
+func (r *txRepo) DeleteCollaborator(ctx context.Context, eventID, userID string) (bool, error) {
+	res, err := r.tx.ExecContext(ctx, deleteCollaboratorSQL, eventID, userID)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *txRepo) SetOwner(ctx context.Context, eventID, ownerID string, at time.Time) error {
+	_, err := r.tx.ExecContext(ctx, setOwnerSQL, eventID, ownerID, at)
+	return err
+}
@@ -0,0 +1,183 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+const commentColumns = `
+id, event_id, parent_id, author_id, kind, title, body, pinned, reply_count,
+created_at, updated_at, deleted_at, COALESCE(deleted_by, ''), removal_reason
+`
+
+// Deleted top-level posts stay in the list only while they still have live
+// replies, so a thread is never orphaned.
+const listCommentsSQL = `
+SELECT ` + commentColumns + `
+FROM event_comments
+WHERE event_id = $1 AND parent_id IS NULL
+  AND (deleted_at IS NULL OR reply_count > 0)
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+const listCommentsAfterSQL = `
+SELECT ` + commentColumns + `
+FROM event_comments
+WHERE event_id = $1 AND parent_id IS NULL
+  AND (deleted_at IS NULL OR reply_count > 0)
+  AND (created_at, id) < ($3, $4)
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+const listRepliesSQL = `
+SELECT ` + commentColumns + `
+FROM event_comments
+WHERE parent_id = $1
+ORDER BY created_at ASC, id ASC
+LIMIT $2
+`
+
+const listRepliesAfterSQL = `
+SELECT ` + commentColumns + `
+FROM event_comments
+WHERE parent_id = $1
+  AND (created_at, id) > ($3, $4)
+ORDER BY created_at ASC, id ASC
+LIMIT $2
+`
+
+const listPinnedCommentsSQL = `
+SELECT ` + commentColumns + `
+FROM event_comments
+WHERE event_id = $1 AND pinned
+ORDER BY created_at DESC, id DESC
+`
+
+const getCommentSQL = `
+SELECT ` + commentColumns + `
+FROM event_comments
+WHERE event_id = $1 AND id = $2
+`
+
+const insertCommentSQL = `
+INSERT INTO event_comments (
+  id, event_id, parent_id, author_id, kind, title, body, pinned, created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+
+const updateCommentSQL = `
+UPDATE event_comments SET
+  pinned = $2,
+  reply_count = $3,
+  updated_at = $4,
+  deleted_at = $5,
+  deleted_by = NULLIF($6, ''),
+  removal_reason = $7
+WHERE id = $1
+`
+
+const countPinnedCommentsSQL = `
+SELECT COUNT(*) FROM event_comments WHERE event_id = $1 AND pinned
+`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanComment(s rowScanner) (domain.Comment, error) {
+	var c domain.Comment
+	var parentID sql.NullString
+	var kind string
+	err := s.Scan(
+		&c.ID, &c.EventID, &parentID, &c.AuthorID, &kind, &c.Title, &c.Body, &c.Pinned, &c.ReplyCount,
+		&c.CreatedAt, &c.UpdatedAt, &c.DeletedAt, &c.DeletedBy, &c.RemovalReason,
+	)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	c.Kind = domain.CommentKind(kind)
+	if parentID.Valid {
+		c.ParentID = &parentID.String
+	}
+	return c, nil
+}
+
+func queryComments(ctx context.Context, q queryer, query string, args ...any) ([]domain.Comment, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Comment{}
+	for rows.Next() {
+		c, err := scanComment(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+func getComment(ctx context.Context, q queryer, query, eventID, commentID string) (*domain.Comment, error) {
+	c, err := scanComment(q.QueryRowContext(ctx, query, eventID, commentID))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound("comment not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *Repo) ListComments(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Comment, error) {
+	if !hasCursor {
+		return queryComments(ctx, r.db, listCommentsSQL, eventID, limit)
+	}
+	return queryComments(ctx, r.db, listCommentsAfterSQL, eventID, limit, beforeCreated.UTC(), beforeID)
+}
+
+func (r *Repo) ListReplies(ctx context.Context, parentID string, hasCursor bool, afterCreated time.Time, afterID string, limit int) ([]domain.Comment, error) {
+	if !hasCursor {
+		return queryComments(ctx, r.db, listRepliesSQL, parentID, limit)
+	}
+	return queryComments(ctx, r.db, listRepliesAfterSQL, parentID, limit, afterCreated.UTC(), afterID)
+}
+
+func (r *Repo) ListPinnedComments(ctx context.Context, eventID string) ([]domain.Comment, error) {
+	return queryComments(ctx, r.db, listPinnedCommentsSQL, eventID)
+}
+
+func (r *Repo) GetComment(ctx context.Context, eventID, commentID string) (*domain.Comment, error) {
+	return getComment(ctx, r.db, getCommentSQL, eventID, commentID)
+}
+
+func (r *txRepo) GetCommentForUpdate(ctx context.Context, eventID, commentID string) (*domain.Comment, error) {
+	return getComment(ctx, r.tx, getCommentSQL+" FOR UPDATE", eventID, commentID)
+}
+
+func (r *txRepo) InsertComment(ctx context.Context, c domain.Comment) error {
+	_, err := r.tx.ExecContext(ctx, insertCommentSQL,
+		c.ID, c.EventID, c.ParentID, c.AuthorID, string(c.Kind), c.Title, c.Body, c.Pinned, c.CreatedAt, c.UpdatedAt,
+	)
+	return err
+}
+
+func (r *txRepo) UpdateComment(ctx context.Context, c domain.Comment) error {
+	_, err := r.tx.ExecContext(ctx, updateCommentSQL,
+		c.ID, c.Pinned, c.ReplyCount, c.UpdatedAt, c.DeletedAt, c.DeletedBy, c.RemovalReason,
+	)
+	return err
+}
+
+func (r *txRepo) CountPinnedComments(ctx context.Context, eventID string) (int, error) {
+	var n int
+	err := r.tx.QueryRowContext(ctx, countPinnedCommentsSQL, eventID).Scan(&n)
+	return n, err
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+const listInvitesSQL = `
+SELECT id, event_id, kind, COALESCE(code, ''), COALESCE(code_hash, ''), label,
+       max_uses, use_count, expires_at, created_by, created_at, revoked_at
+FROM event_invites
+WHERE event_id = $1
+ORDER BY created_at DESC, id ASC
+`
+
+const insertInviteSQL = `
+INSERT INTO event_invites (
+  id, event_id, kind, code, code_hash, label, max_uses, expires_at, created_by, created_at
+) VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, $7, $8, $9, $10)
+`
+
+const revokeInviteSQL = `
+UPDATE event_invites SET revoked_at = $3
+WHERE event_id = $1 AND id = $2 AND revoked_at IS NULL
+`
+
+const incrementInviteUseSQL = `
+UPDATE event_invites SET use_count = use_count + 1
+WHERE event_id = $1 AND id = $2
+`
+
+func listInvites(ctx context.Context, q queryer, eventID string) ([]domain.Invite, error) {
+	rows, err := q.QueryContext(ctx, listInvitesSQL, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Invite{}
+	for rows.Next() {
+		var inv domain.Invite
+		var kind string
+		var maxUses sql.NullInt64
+		if err := rows.Scan(
+			&inv.ID, &inv.EventID, &kind, &inv.Code, &inv.CodeHash, &inv.Label,
+			&maxUses, &inv.UseCount, &inv.ExpiresAt, &inv.CreatedBy, &inv.CreatedAt, &inv.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		inv.Kind = domain.InviteKind(kind)
+		if maxUses.Valid {
+			n := int(maxUses.Int64)
+			inv.MaxUses = &n
+		}
+		out = append(out, inv)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repo) ListInvites(ctx context.Context, eventID string) ([]domain.Invite, error) {
+	return listInvites(ctx, r.db, eventID)
+}
+
+func (r *Repo) IncrementInviteUse(ctx context.Context, eventID, inviteID string) error {
+	res, err := r.db.ExecContext(ctx, incrementInviteUseSQL, eventID, inviteID)
+	if err != nil {
+		return fmt.Errorf("failed to increment invite use: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if n == 0 {
+		return domain.ErrNotFound("invite not found")
+	}
+	return nil
+}
+
+func (r *txRepo) ListInvites(ctx context.Context, eventID string) ([]domain.Invite, error) {
+	return listInvites(ctx, r.tx, eventID)
+}
+
+func (r *txRepo) InsertInvite(ctx context.Context, inv domain.Invite) error {
+	_, err := r.tx.ExecContext(ctx, insertInviteSQL,
+		inv.ID, inv.EventID, string(inv.Kind), inv.Code, inv.CodeHash, inv.Label,
+		inv.MaxUses, inv.ExpiresAt, inv.CreatedBy, inv.CreatedAt,
+	)
+	return err
+}
+
+func (r *txRepo) RevokeInvite(ctx context.Context, eventID, inviteID string, at time.Time) (bool, error) {
+	res, err := r.tx.ExecContext(ctx, revokeInviteSQL, eventID, inviteID, at)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
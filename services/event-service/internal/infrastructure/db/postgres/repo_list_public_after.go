@@ -31,7 +31,7 @@ func (r *Repo) ListPublicAfter(
 	category := strings.TrimSpace(f.Category)
 	query := strings.TrimSpace(f.Query)
 
-	where := []string{"status = 'published'"}
+	where := []string{"status = 'published'", "visibility = 'public'"}
 	args := []any{}
 	argN := 1
 
@@ -166,6 +166,7 @@ LIMIT $` + fmt.Sprintf("%d", limitParam)
 				return nil, 0, "", err
 			}
 			rr.ev.Status = domain.EventStatus(status)
+			rr.ev.Visibility = domain.VisibilityPublic
 			out = append(out, &rr.ev)
 			lastRank = rr.rank
 		}
@@ -210,6 +211,7 @@ LIMIT $` + fmt.Sprintf("%d", limitParam)
 			return nil, 0, "", err
 		}
 		e.Status = domain.EventStatus(status)
+		e.Visibility = domain.VisibilityPublic
 		out = append(out, &e)
 	}
 	if err := rows.Err(); err != nil {
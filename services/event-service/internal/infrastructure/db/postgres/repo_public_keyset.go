@@ -117,6 +117,7 @@ LIMIT $` + fmt.Sprintf("%d", argN)
 			return nil, nil, err
 		}
 		e.Status = domain.EventStatus(status)
+		e.Visibility = domain.VisibilityPublic
 		items = append(items, &e)
 		ranks = append(ranks, rank)
 	}
@@ -128,7 +129,7 @@ LIMIT $` + fmt.Sprintf("%d", argN)
 }
 
 func buildPublicBaseWhere(f event.ListFilter) ([]string, []any, int, int) {
-	where := []string{"status = 'published'"}
+	where := []string{"status = 'published'", "visibility = 'public'"}
 	args := []any{}
 	argN := 1
 	qPos := 0
@@ -194,6 +195,7 @@ func scanEvents(rows *sql.Rows) ([]*domain.Event, error) {
 			return nil, err
 		}
 		e.Status = domain.EventStatus(status)
+		e.Visibility = domain.VisibilityPublic
 		out = append(out, &e)
 	}
 	if err := rows.Err(); err != nil {
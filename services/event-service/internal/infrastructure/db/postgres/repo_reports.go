@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+const caseColumns = `
+id, target_type, target_id, COALESCE(event_id::text, ''), status, report_count, reason_counts,
+created_at, last_reported_at, updated_at, auto_hidden_at,
+COALESCE(triaged_by, ''), triaged_at, action, resolution_note, COALESCE(resolved_by, ''), resolved_at
+`
+
+// An empty target_type ($2) lists every target type.
+const listCasesSQL = `
+SELECT ` + caseColumns + `
+FROM moderation_cases
+WHERE status = $1 AND ($2 = '' OR target_type = $2)
+ORDER BY last_reported_at DESC, id DESC
+LIMIT $3
+`
+
+const listCasesAfterSQL = `
+SELECT ` + caseColumns + `
+FROM moderation_cases
+WHERE status = $1 AND ($2 = '' OR target_type = $2)
+  AND (last_reported_at, id) < ($4, $5)
+ORDER BY last_reported_at DESC, id DESC
+LIMIT $3
+`
+
+const getCaseSQL = `
+SELECT ` + caseColumns + `
+FROM moderation_cases
+WHERE id = $1
+`
+
+const getActiveCaseByTargetSQL = `
+SELECT ` + caseColumns + `
+FROM moderation_cases
+WHERE target_type = $1 AND target_id = $2 AND status IN ('open', 'triaged')
+`
+
+// A report on a target without an open or triaged case creates one; later
+// reports find it until a moderator closes it.
+const ensureCaseSQL = `
+INSERT INTO moderation_cases (
+  id, target_type, target_id, event_id, status, created_at, last_reported_at, updated_at
+) VALUES ($1, $2, $3, NULLIF($4, '')::uuid, 'open', $5, $5, $5)
+ON CONFLICT (target_type, target_id) WHERE status IN ('open', 'triaged') DO NOTHING
+`
+
+const updateCaseSQL = `
+UPDATE moderation_cases SET
+  status = $2,
+  report_count = $3,
+  reason_counts = $4,
+  last_reported_at = $5,
+  updated_at = $6,
+  auto_hidden_at = $7,
+  triaged_by = NULLIF($8, ''),
+  triaged_at = $9,
+  action = $10,
+  resolution_note = $11,
+  resolved_by = NULLIF($12, ''),
+  resolved_at = $13
+WHERE id = $1
+`
+
+// A repeat report by the same reporter on the same case keeps the original row; xmax = 0
+// tells a fresh insert apart from the no-op update.
+const insertReportSQL = `
+INSERT INTO moderation_reports (id, case_id, reporter_id, reason, details, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (case_id, reporter_id) DO UPDATE SET case_id = EXCLUDED.case_id
+RETURNING id, reason, details, created_at, (xmax = 0)
+`
+
+const listCaseReportsSQL = `
+SELECT id, case_id, reporter_id, reason, details, created_at
+FROM moderation_reports
+WHERE case_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+func scanCase(s rowScanner) (domain.ModerationCase, error) {
+	var (
+		c      domain.ModerationCase
+		counts []byte
+	)
+	err := s.Scan(
+		&c.ID, &c.TargetType, &c.TargetID, &c.EventID, &c.Status, &c.ReportCount, &counts,
+		&c.CreatedAt, &c.LastReportedAt, &c.UpdatedAt, &c.AutoHiddenAt,
+		&c.TriagedBy, &c.TriagedAt, &c.Action, &c.ResolutionNote, &c.ResolvedBy, &c.ResolvedAt,
+	)
+	if err != nil {
+		return c, err
+	}
+	if err := json.Unmarshal(counts, &c.ReasonCounts); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+func getCase(ctx context.Context, q queryer, query string, args ...any) (*domain.ModerationCase, error) {
+	c, err := scanCase(q.QueryRowContext(ctx, query, args...))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound("moderation case not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *Repo) ListModerationCases(ctx context.Context, status domain.CaseStatus, target domain.ReportTarget, hasCursor bool, beforeReported time.Time, beforeID string, limit int) ([]domain.ModerationCase, error) {
+	query, args := listCasesSQL, []any{status, target, limit}
+	if hasCursor {
+		query, args = listCasesAfterSQL, append(args, beforeReported.UTC(), beforeID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.ModerationCase{}
+	for rows.Next() {
+		c, err := scanCase(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repo) GetModerationCase(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	return getCase(ctx, r.db, getCaseSQL, caseID)
+}
+
+func (r *Repo) ListCaseReports(ctx context.Context, caseID string, limit int) ([]domain.Report, error) {
+	rows, err := r.db.QueryContext(ctx, listCaseReportsSQL, caseID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Report{}
+	for rows.Next() {
+		var rp domain.Report
+		if err := rows.Scan(&rp.ID, &rp.CaseID, &rp.ReporterID, &rp.Reason, &rp.Details, &rp.CreatedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, rp)
+	}
+	return out, rows.Err()
+}
+
+func (r *txRepo) EnsureModerationCase(ctx context.Context, c domain.ModerationCase) error {
+	_, err := r.tx.ExecContext(ctx, ensureCaseSQL, c.ID, c.TargetType, c.TargetID, c.EventID, c.CreatedAt)
+	return err
+}
+
+func (r *txRepo) GetModerationCaseForUpdate(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	return getCase(ctx, r.tx, getCaseSQL+" FOR UPDATE", caseID)
+}
+
+func (r *txRepo) GetActiveModerationCaseForUpdate(ctx context.Context, target domain.ReportTarget, targetID string) (*domain.ModerationCase, error) {
+	return getCase(ctx, r.tx, getActiveCaseByTargetSQL+" FOR UPDATE", target, targetID)
+}
+
+func (r *txRepo) UpdateModerationCase(ctx context.Context, c domain.ModerationCase) error {
+	counts, err := json.Marshal(c.ReasonCounts)
+	if err != nil {
+		return err
+	}
+	if c.ReasonCounts == nil {
+		counts = []byte("{}")
+	}
+	_, err = r.tx.ExecContext(ctx, updateCaseSQL,
+		c.ID, c.Status, c.ReportCount, counts, c.LastReportedAt, c.UpdatedAt, c.AutoHiddenAt,
+		c.TriagedBy, c.TriagedAt, c.Action, c.ResolutionNote, c.ResolvedBy, c.ResolvedAt,
+	)
+	return err
+}
+
+func (r *txRepo) InsertReport(ctx context.Context, rp *domain.Report) (bool, error) {
+	var inserted bool
+	err := r.tx.QueryRowContext(ctx, insertReportSQL,
+		rp.ID, rp.CaseID, rp.ReporterID, rp.Reason, rp.Details, rp.CreatedAt,
+	).Scan(&rp.ID, &rp.Reason, &rp.Details, &rp.CreatedAt, &inserted)
+	return inserted, err
+}
@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+const reviewColumns = `
+id, event_id, organizer_id, author_id, rating, body, created_at, updated_at,
+hidden_at, COALESCE(hidden_by, ''), hidden_reason
+`
+
+const listReviewsSQL = `
+SELECT ` + reviewColumns + `
+FROM event_reviews
+WHERE event_id = $1 AND hidden_at IS NULL
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+const listReviewsAfterSQL = `
+SELECT ` + reviewColumns + `
+FROM event_reviews
+WHERE event_id = $1 AND hidden_at IS NULL
+  AND (created_at, id) < ($3, $4)
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+const getReviewSQL = `
+SELECT ` + reviewColumns + `
+FROM event_reviews
+WHERE event_id = $1 AND id = $2
+`
+
+const getReviewByAuthorSQL = `
+SELECT ` + reviewColumns + `
+FROM event_reviews
+WHERE event_id = $1 AND author_id = $2
+`
+
+const insertReviewSQL = `
+INSERT INTO event_reviews (
+  id, event_id, organizer_id, author_id, rating, body, created_at, updated_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+const updateReviewSQL = `
+UPDATE event_reviews SET
+  rating = $2,
+  body = $3,
+  updated_at = $4,
+  hidden_at = $5,
+  hidden_by = NULLIF($6, ''),
+  hidden_reason = $7
+WHERE id = $1
+`
+
+const eventRatingSummarySQL = `
+SELECT COUNT(*), COALESCE(AVG(rating), 0)::float8
+FROM event_reviews
+WHERE event_id = $1 AND hidden_at IS NULL
+`
+
+const organizerRatingSummarySQL = `
+SELECT COUNT(*), COALESCE(AVG(rating), 0)::float8
+FROM event_reviews
+WHERE organizer_id = $1 AND hidden_at IS NULL
+`
+
+// Serializes reputation recomputation per organizer so the snapshots sent
+// on event.reputation.updated are written in order.
+const lockOrganizerRatingsSQL = `SELECT pg_advisory_xact_lock(hashtext('event_reviews:' || $1))`
+
+func scanReview(s rowScanner) (domain.Review, error) {
+	var rv domain.Review
+	err := s.Scan(
+		&rv.ID, &rv.EventID, &rv.OrganizerID, &rv.AuthorID, &rv.Rating, &rv.Body, &rv.CreatedAt, &rv.UpdatedAt,
+		&rv.HiddenAt, &rv.HiddenBy, &rv.HiddenReason,
+	)
+	return rv, err
+}
+
+func getReview(ctx context.Context, q queryer, query string, args ...any) (*domain.Review, error) {
+	rv, err := scanReview(q.QueryRowContext(ctx, query, args...))
+	if err != nil {
+		return nil, err
+	}
+	return &rv, nil
+}
+
+func ratingSummary(ctx context.Context, q queryer, query, id string) (domain.RatingSummary, error) {
+	var s domain.RatingSummary
+	err := q.QueryRowContext(ctx, query, id).Scan(&s.Count, &s.Average)
+	return s, err
+}
+
+func (r *Repo) ListReviews(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Review, error) {
+	query, args := listReviewsSQL, []any{eventID, limit}
+	if hasCursor {
+		query, args = listReviewsAfterSQL, append(args, beforeCreated.UTC(), beforeID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Review{}
+	for rows.Next() {
+		rv, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, rv)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repo) GetReview(ctx context.Context, eventID, reviewID string) (*domain.Review, error) {
+	rv, err := getReview(ctx, r.db, getReviewSQL, eventID, reviewID)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound("review not found")
+	}
+	return rv, err
+}
+
+func (r *Repo) EventRatingSummary(ctx context.Context, eventID string) (domain.RatingSummary, error) {
+	return ratingSummary(ctx, r.db, eventRatingSummarySQL, eventID)
+}
+
+func (r *Repo) OrganizerRatingSummary(ctx context.Context, organizerID string) (domain.RatingSummary, error) {
+	return ratingSummary(ctx, r.db, organizerRatingSummarySQL, organizerID)
+}
+
+func (r *txRepo) LockOrganizerRatings(ctx context.Context, organizerID string) error {
+	_, err := r.tx.ExecContext(ctx, lockOrganizerRatingsSQL, organizerID)
+	return err
+}
+
+func (r *txRepo) GetReviewForUpdate(ctx context.Context, eventID, reviewID string) (*domain.Review, error) {
+	rv, err := getReview(ctx, r.tx, getReviewSQL+" FOR UPDATE", eventID, reviewID)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound("review not found")
+	}
+	return rv, err
+}
+
+func (r *txRepo) GetReviewByAuthorForUpdate(ctx context.Context, eventID, authorID string) (*domain.Review, error) {
+	rv, err := getReview(ctx, r.tx, getReviewByAuthorSQL+" FOR UPDATE", eventID, authorID)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return rv, err
+}
+
+func (r *txRepo) InsertReview(ctx context.Context, rv domain.Review) error {
+	_, err := r.tx.ExecContext(ctx, insertReviewSQL,
+		rv.ID, rv.EventID, rv.OrganizerID, rv.AuthorID, rv.Rating, rv.Body, rv.CreatedAt, rv.UpdatedAt,
+	)
+	return err
+}
+
+func (r *txRepo) UpdateReview(ctx context.Context, rv domain.Review) error {
+	_, err := r.tx.ExecContext(ctx, updateReviewSQL,
+		rv.ID, rv.Rating, rv.Body, rv.UpdatedAt, rv.HiddenAt, rv.HiddenBy, rv.HiddenReason,
+	)
+	return err
+}
+
+func (r *txRepo) OrganizerRatingSummary(ctx context.Context, organizerID string) (domain.RatingSummary, error) {
+	return ratingSummary(ctx, r.tx, organizerRatingSummarySQL, organizerID)
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+const revisionColumns = `
+id, event_id, version, action, actor_id, actor_role, changes, reason, created_at
+`
+
+const listRevisionsSQL = `
+SELECT ` + revisionColumns + `
+FROM event_revisions
+WHERE event_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+const listRevisionsAfterSQL = `
+SELECT ` + revisionColumns + `
+FROM event_revisions
+WHERE event_id = $1
+  AND (created_at, id) < ($3, $4)
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+const insertRevisionSQL = `
+INSERT INTO event_revisions (
+  id, event_id, version, action, actor_id, actor_role, changes, reason, created_at
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+
+func (r *Repo) ListRevisions(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Revision, error) {
+	query, args := listRevisionsSQL, []any{eventID, limit}
+	if hasCursor {
+		query, args = listRevisionsAfterSQL, append(args, beforeCreated.UTC(), beforeID)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []domain.Revision{}
+	for rows.Next() {
+		var (
+			rv      domain.Revision
+			changes []byte
+		)
+		if err := rows.Scan(
+			&rv.ID, &rv.EventID, &rv.Version, &rv.Action, &rv.ActorID, &rv.ActorRole, &changes, &rv.Reason, &rv.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &rv.Changes); err != nil {
+			return nil, err
+		}
+		out = append(out, rv)
+	}
+	return out, rows.Err()
+}
+
+func (r *txRepo) InsertRevision(ctx context.Context, rv domain.Revision) error {
+	changes, err := json.Marshal(rv.Changes)
+	if err != nil {
+		return err
+	}
+	_, err = r.tx.ExecContext(ctx, insertRevisionSQL,
+		rv.ID, rv.EventID, rv.Version, rv.Action, rv.ActorID, rv.ActorRole, changes, rv.Reason, rv.CreatedAt,
+	)
+	return err
+}
@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/application/event"
+)
+
+// schedulerLockKey is the advisory lock held by the replica running the
+// scheduler tick.
+const schedulerLockKey = 0x65766e74 // "evnt"
+
+// ListDueTransitions returns scheduled publishes and unpublishes due at or
+// before now, oldest first.
+func (r *Repo) ListDueTransitions(ctx context.Context, now time.Time, limit int) ([]event.ScheduledTransition, error) {
+	rows, err := r.db.QueryContext(ctx, `
+SELECT id, action, due_at FROM (
+  SELECT id, 'publish' AS action, publish_at AS due_at
+  FROM events
+  WHERE status = 'draft' AND publish_at IS NOT NULL AND publish_at <= $1
+  UNION ALL
+  SELECT id, 'unpublish' AS action, unpublish_at AS due_at
+  FROM events
+  WHERE status = 'published' AND unpublish_at IS NOT NULL AND unpublish_at <= $1
+) due
+ORDER BY due_at ASC, id ASC
+LIMIT $2`, now.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []event.ScheduledTransition
+	for rows.Next() {
+		var t event.ScheduledTransition
+		if err := rows.Scan(&t.EventID, &t.Action, &t.DueAt); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// ListUpcomingOwnedIDs returns the owner's events that have not started and
+// are published or scheduled to publish.
+func (r *Repo) ListUpcomingOwnedIDs(ctx context.Context, ownerID string, now time.Time) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `
+SELECT id FROM events
+WHERE owner_id = $1 AND start_time > $2
+  AND (status = 'published' OR (status = 'draft' AND publish_at IS NOT NULL))
+ORDER BY id`, ownerID, now.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
+
+// ListIDsByCoverImages returns the events, in any status, that use one of
+// uploadIDs as a cover.
+func (r *Repo) ListIDsByCoverImages(ctx context.Context, uploadIDs []string) ([]string, error) {
+	idsJSON, err := json.Marshal(uploadIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.db.QueryContext(ctx, `
+SELECT id FROM events
+WHERE cover_image_ids ?| ARRAY(SELECT jsonb_array_elements_text($1::jsonb))
+ORDER BY id`, string(idsJSON))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
+
+// SchedulerLock is a Postgres advisory lock used as event.LeaderLock.
+type SchedulerLock struct {
+	db *sql.DB
+}
+
+func NewSchedulerLock(db *sql.DB) *SchedulerLock { return &SchedulerLock{db: db} }
+
+// TryLock takes the lock on a dedicated connection, since advisory locks
+// belong to the session that took them.
+func (l *SchedulerLock) TryLock(ctx context.Context) (unlock func(), ok bool, err error) {
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, schedulerLockKey).Scan(&ok); err != nil {
+		_ = conn.Close()
+		return nil, false, err
+	}
+	if !ok {
+		_ = conn.Close()
+		return nil, false, nil
+	}
+
+	return func() {
+		_, _ = conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, schedulerLockKey)
+		_ = conn.Close()
+	}, true, nil
+}
@@ -36,7 +36,7 @@ func TestRepo_ListPublicTimeKeyset(t *testing.T) {
 
 		// 修复：使用 ILIKE 和 cleaned arguments
 		// mock.ExpectQuery 使用的是正则匹配
-		mock.ExpectQuery(`SELECT (.+) FROM events WHERE status = 'published' AND city ILIKE \$1 ORDER BY start_time ASC, id ASC LIMIT \$2`).
+		mock.ExpectQuery(`SELECT (.+) FROM events WHERE status = 'published' AND visibility = 'public' AND city ILIKE \$1 ORDER BY start_time ASC, id ASC LIMIT \$2`).
 			WithArgs("Sydney%", 10).
 			WillReturnRows(rows)
 
@@ -53,7 +53,7 @@ func TestRepo_ListPublicTimeKeyset(t *testing.T) {
 			"published_at", "canceled_at", "created_at", "updated_at",
 		}).AddRow(newEventRow("e1")...)
 
-		mock.ExpectQuery(`WHERE status = 'published' AND end_time > NOW\(\) ORDER BY start_time ASC, id ASC LIMIT \$1`).
+		mock.ExpectQuery(`WHERE status = 'published' AND visibility = 'public' AND end_time > NOW\(\) ORDER BY start_time ASC, id ASC LIMIT \$1`).
 			WithArgs(10).
 			WillReturnRows(rows)
 
@@ -74,7 +74,7 @@ func TestRepo_ListPublicTimeKeyset(t *testing.T) {
 		}).AddRow(newEventRow("e2")...)
 
 		// 修复：Keyset 谓词正则
-		mock.ExpectQuery(`WHERE status = 'published' AND \(start_time, id\) > \(\$1, \$2\) ORDER BY start_time ASC, id ASC LIMIT \$3`).
+		mock.ExpectQuery(`WHERE status = 'published' AND visibility = 'public' AND \(start_time, id\) > \(\$1, \$2\) ORDER BY start_time ASC, id ASC LIMIT \$3`).
 			WithArgs(lastTime, lastID, 10).
 			WillReturnRows(rows)
 
@@ -92,7 +92,7 @@ func TestRepo_ListPublicTimeKeyset(t *testing.T) {
 		}).AddRow(newEventRow("e1")...)
 
 		// Time keyset using search query (enabled by my recent fix)
-		mock.ExpectQuery(`WHERE status = 'published' AND search_vector @@ to_tsquery\('simple', \$1\) ORDER BY start_time ASC, id ASC LIMIT \$2`).
+		mock.ExpectQuery(`WHERE status = 'published' AND visibility = 'public' AND search_vector @@ to_tsquery\('simple', \$1\) ORDER BY start_time ASC, id ASC LIMIT \$2`).
 			WithArgs("part:*", 10).
 			WillReturnRows(rows)
 
@@ -109,7 +109,7 @@ func TestRepo_ListPublicTimeKeyset(t *testing.T) {
 			"published_at", "canceled_at", "created_at", "updated_at",
 		}).AddRow(newEventRow("e1")...)
 
-		mock.ExpectQuery(`WHERE status = 'published' AND city ILIKE \$1 ORDER BY start_time ASC, id ASC LIMIT \$2`).
+		mock.ExpectQuery(`WHERE status = 'published' AND visibility = 'public' AND city ILIKE \$1 ORDER BY start_time ASC, id ASC LIMIT \$2`).
 			WithArgs("Syd%", 10).
 			WillReturnRows(rows)
 
@@ -138,7 +138,7 @@ func TestRepo_ListPublicRelevanceKeyset(t *testing.T) {
 		}).AddRow(append(newEventRow("e1"), 0.95)...)
 
 		// 匹配 to_tsquery 部分 (注意到 fmtTsQuery 会把 "Go" 变成 "Go:*")
-		mock.ExpectQuery(`SELECT (.+) ts_rank_cd\(search_vector, to_tsquery\('simple', \$1\)\) AS rank FROM events WHERE status = 'published' AND search_vector @@ to_tsquery\('simple', \$1\) ORDER BY rank DESC, start_time ASC, id ASC LIMIT \$2`).
+		mock.ExpectQuery(`SELECT (.+) ts_rank_cd\(search_vector, to_tsquery\('simple', \$1\)\) AS rank FROM events WHERE status = 'published' AND visibility = 'public' AND search_vector @@ to_tsquery\('simple', \$1\) ORDER BY rank DESC, start_time ASC, id ASC LIMIT \$2`).
 			WithArgs("Go:*", 5).
 			WillReturnRows(rows)
 
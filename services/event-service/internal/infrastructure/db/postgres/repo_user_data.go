@@ -0,0 +1,124 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// anonymizeUserSQL rewrites every user reference from $1 to the pseudonym
+// $2. Collaborator grants are dropped rather than rewritten. Each table is
+// touched by one statement: data-modifying CTEs must not update the same
+// row twice.
+const anonymizeUserSQL = `
+WITH
+  collabs AS (DELETE FROM event_collaborators WHERE user_id = $1),
+  added AS (UPDATE event_collaborators SET added_by = $2 WHERE added_by = $1 AND user_id <> $1),
+  owned AS (UPDATE events SET owner_id = $2 WHERE owner_id = $1),
+  invites AS (UPDATE event_invites SET created_by = $2 WHERE created_by = $1),
+  comments AS (
+    UPDATE event_comments
+    SET author_id = CASE WHEN author_id = $1 THEN $2 ELSE author_id END,
+        deleted_by = CASE WHEN deleted_by = $1 THEN $2 ELSE deleted_by END
+    WHERE author_id = $1 OR deleted_by = $1
+  ),
+  reviews AS (
+    UPDATE event_reviews
+    SET author_id = CASE WHEN author_id = $1 THEN $2 ELSE author_id END,
+        organizer_id = CASE WHEN organizer_id = $1 THEN $2 ELSE organizer_id END,
+        hidden_by = CASE WHEN hidden_by = $1 THEN $2 ELSE hidden_by END
+    WHERE author_id = $1 OR organizer_id = $1 OR hidden_by = $1
+  ),
+  cases AS (
+    UPDATE moderation_cases
+    SET target_id = CASE WHEN target_type = 'user' AND target_id = $1 THEN $2 ELSE target_id END,
+        triaged_by = CASE WHEN triaged_by = $1 THEN $2 ELSE triaged_by END,
+        resolved_by = CASE WHEN resolved_by = $1 THEN $2 ELSE resolved_by END
+    WHERE (target_type = 'user' AND target_id = $1) OR triaged_by = $1 OR resolved_by = $1
+  ),
+  reports AS (UPDATE moderation_reports SET reporter_id = $2 WHERE reporter_id = $1),
+  revisions AS (UPDATE event_revisions SET actor_id = $2 WHERE actor_id = $1)
+SELECT 1;
+`
+
+// exportUserDataSQL builds event-service's section of a data export.
+// Moderator actions on other users' content are not included.
+const exportUserDataSQL = `
+SELECT jsonb_build_object(
+  'events', COALESCE((
+    SELECT jsonb_agg(jsonb_build_object(
+      'id', e.id,
+      'title', e.title,
+      'description', e.description,
+      'city', e.city,
+      'category', e.category,
+      'start_time', e.start_time,
+      'end_time', e.end_time,
+      'status', e.status,
+      'created_at', e.created_at
+    ) ORDER BY e.created_at)
+    FROM events e
+    WHERE e.owner_id = $1
+  ), '[]'::jsonb),
+  'collaborations', COALESCE((
+    SELECT jsonb_agg(jsonb_build_object(
+      'event_id', c.event_id,
+      'role', c.role,
+      'created_at', c.created_at
+    ) ORDER BY c.created_at)
+    FROM event_collaborators c
+    WHERE c.user_id = $1
+  ), '[]'::jsonb),
+  'comments', COALESCE((
+    SELECT jsonb_agg(jsonb_build_object(
+      'id', c.id,
+      'event_id', c.event_id,
+      'parent_id', c.parent_id,
+      'kind', c.kind,
+      'title', c.title,
+      'body', c.body,
+      'created_at', c.created_at,
+      'deleted_at', c.deleted_at
+    ) ORDER BY c.created_at)
+    FROM event_comments c
+    WHERE c.author_id = $1
+  ), '[]'::jsonb),
+  'reviews', COALESCE((
+    SELECT jsonb_agg(jsonb_build_object(
+      'id', r.id,
+      'event_id', r.event_id,
+      'rating', r.rating,
+      'body', r.body,
+      'created_at', r.created_at,
+      'updated_at', r.updated_at
+    ) ORDER BY r.created_at)
+    FROM event_reviews r
+    WHERE r.author_id = $1
+  ), '[]'::jsonb),
+  'reports', COALESCE((
+    SELECT jsonb_agg(jsonb_build_object(
+      'id', rp.id,
+      'target_type', mc.target_type,
+      'target_id', mc.target_id,
+      'reason', rp.reason,
+      'details', rp.details,
+      'created_at', rp.created_at
+    ) ORDER BY rp.created_at)
+    FROM moderation_reports rp
+    JOIN moderation_cases mc ON mc.id = rp.case_id
+    WHERE rp.reporter_id = $1
+  ), '[]'::jsonb)
+);
+`
+
+func (r *Repo) ExportUserData(ctx context.Context, userID string) (json.RawMessage, error) {
+	var out []byte
+	if err := r.db.QueryRowContext(ctx, exportUserDataSQL, userID).Scan(&out); err != nil {
+		return nil, err
+	}
+	return json.RawMessage(out), nil
+}
+
+func (r *txRepo) AnonymizeUser(ctx context.Context, userID, pseudonym string) error {
+	_, err := r.tx.ExecContext(ctx, anonymizeUserSQL, userID, pseudonym)
+	return err
+}
@@ -0,0 +1,61 @@
+package joinservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Client reads participation from join-service's internal routes. It
+// implements event.AttendanceChecker.
+type Client struct {
+	baseURL        string
+	internalSecret string
+	http           *http.Client
+}
+
+func NewClient(baseURL, internalSecret string) *Client {
+	return &Client{
+		baseURL:        baseURL,
+		internalSecret: internalSecret,
+		http:           &http.Client{Timeout: 3 * time.Second},
+	}
+}
+
+// ParticipationStatus returns the user's join status for the event, or ""
+// when the user never joined.
+func (c *Client) ParticipationStatus(ctx context.Context, eventID, userID string) (string, error) {
+	path := "/internal/v1/events/" + url.PathEscape(eventID) + "/participants/" + url.PathEscape(userID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Internal-Secret", c.internalSecret)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", nil
+	default:
+		return "", fmt.Errorf("join-service GET %s: status %d", path, resp.StatusCode)
+	}
+
+	var env struct {
+		Data struct {
+			Status string `json:"status"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&env); err != nil {
+		return "", err
+	}
+	return env.Data.Status, nil
+}
@@ -0,0 +1,88 @@
+package mediaservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+)
+
+const claimPath = "/media/v1/internal/uploads/claim"
+
+// Client claims uploads through media-service's internal routes. It
+// implements event.MediaChecker.
+type Client struct {
+	baseURL        string
+	internalSecret string
+	http           *http.Client
+}
+
+func NewClient(baseURL, internalSecret string) *Client {
+	return &Client{
+		baseURL:        baseURL,
+		internalSecret: internalSecret,
+		http:           &http.Client{Timeout: 3 * time.Second},
+	}
+}
+
+type claimRequest struct {
+	OwnerID string   `json:"owner_id"`
+	Purpose string   `json:"purpose"`
+	IDs     []string `json:"ids"`
+}
+
+type claimResponse struct {
+	OK    bool `json:"ok"`
+	Items []struct {
+		ID     string `json:"id"`
+		OK     bool   `json:"ok"`
+		Reason string `json:"reason"`
+	} `json:"items"`
+}
+
+// ClaimUploads checks that ids are READY uploads of ownerID with the given
+// purpose and marks them in use. A rejected id becomes a validation error
+// carrying the id and media-service's reason.
+func (c *Client) ClaimUploads(ctx context.Context, ownerID, purpose string, ids []string) error {
+	body, err := json.Marshal(claimRequest{OwnerID: ownerID, Purpose: purpose, IDs: ids})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+claimPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Internal-Secret", c.internalSecret)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("media-service POST %s: status %d", claimPath, resp.StatusCode)
+	}
+
+	var out claimResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return err
+	}
+	if out.OK {
+		return nil
+	}
+	for _, it := range out.Items {
+		if !it.OK {
+			return domain.ErrValidationMeta("cover image cannot be used", map[string]string{
+				"cover_image_id": it.ID,
+				"reason":         it.Reason,
+			})
+		}
+	}
+	return fmt.Errorf("media-service POST %s: rejected without reason", claimPath)
+}
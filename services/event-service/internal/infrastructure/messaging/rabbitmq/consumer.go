@@ -19,11 +19,46 @@ type JoinEventMessage struct {
 	EventID   string    `json:"event_id"`
 	UserID    string    `json:"user_id"`
 	Status    string    `json:"status"`
+	InviteID  string    `json:"invite_id,omitempty"` // set when admitted with an invite
 	CreatedAt time.Time `json:"created_at"`
 	TraceID   string    `json:"trace_id"`
 }
 
-// Consumer listens to join.* events and updates event participation counts
+// UserBannedMessage is the payload of auth.user.banned (auth-service outbox).
+type UserBannedMessage struct {
+	UserID   string    `json:"user_id"`
+	BannedAt time.Time `json:"banned_at"`
+}
+
+// DataRequestedMessage is the payload of auth.user.deletion_requested and
+// auth.user.export_requested (auth-service outbox).
+type DataRequestedMessage struct {
+	RequestID   string    `json:"request_id"`
+	UserID      string    `json:"user_id"`
+	RequestedAt time.Time `json:"requested_at"`
+}
+
+// UploadRejectedMessage is the payload of media.upload.rejected
+// (media-service, published directly rather than through an outbox).
+type UploadRejectedMessage struct {
+	UploadIDs []string `json:"upload_ids"`
+	OwnerID   string   `json:"owner_id"`
+	Purpose   string   `json:"purpose"`
+	Reason    string   `json:"reason"`
+}
+
+// auth.user.unbanned is not consumed: unbanning restores nothing.
+const (
+	rkUserBanned        = "auth.user.banned"
+	rkDeletionRequested = "auth.user.deletion_requested"
+	rkExportRequested   = "auth.user.export_requested"
+	rkUploadRejected    = "media.upload.rejected"
+)
+
+// Consumer listens to join.* events and updates event participation counts.
+// It also takes down the upcoming events of banned organizers, answers
+// account deletion / data export requests and detaches cover images
+// rejected by moderation.
 type Consumer struct {
 	conn     *amqp.Connection
 	channel  *amqp.Channel
@@ -102,7 +137,7 @@ func NewConsumer(rabbitURL, exchange string, service *event.Service) (*Consumer,
 	}
 
 	// Bind Main Queue to Main Exchange
-	routingKeys := []string{"join.created", "join.canceled"}
+	routingKeys := []string{"join.created", "join.canceled", rkUserBanned, rkDeletionRequested, rkExportRequested, rkUploadRejected}
 	for _, key := range routingKeys {
 		err = ch.QueueBind(q.Name, key, exchange, false, nil)
 		if err != nil {
@@ -159,6 +194,18 @@ func (c *Consumer) handleMessage(msg amqp.Delivery) {
 		routingKey = val
 	}
 
+	switch routingKey {
+	case rkUserBanned:
+		c.handleUserBanned(msg, routingKey)
+		return
+	case rkDeletionRequested, rkExportRequested:
+		c.handleDataRequest(msg, routingKey)
+		return
+	case rkUploadRejected:
+		c.handleUploadRejected(msg, routingKey)
+		return
+	}
+
 	log.Debug().
 		Str("routing_key", routingKey).
 		Str("message_id", msg.MessageId).
@@ -185,6 +232,12 @@ func (c *Consumer) handleMessage(msg amqp.Delivery) {
 	switch routingKey {
 	case "join.created":
 		err = c.service.IncrementParticipantCount(ctx, eventID)
+		if err == nil && joinMsg.InviteID != "" {
+			// Best-effort: a retry would double count the participant.
+			if ierr := c.service.RecordInviteUse(ctx, eventID, joinMsg.InviteID); ierr != nil {
+				log.Warn().Err(ierr).Str("invite_id", joinMsg.InviteID).Msg("failed to record invite use")
+			}
+		}
 	case "join.canceled":
 		err = c.service.DecrementParticipantCount(ctx, eventID)
 	default:
@@ -211,61 +264,154 @@ func (c *Consumer) handleMessage(msg amqp.Delivery) {
 			return
 		}
 
-		// 2. Retry Logic
-		retryCount := 0
-		if val, ok := msg.Headers["x-retry-count"].(int32); ok {
-			retryCount = int(val)
+		c.retryOrDeadLetter(msg, routingKey, err)
+		return
+	}
+
+	log.Info().
+		Str("event_id", joinMsg.EventID).
+		Str("routing_key", routingKey).
+		Msg("participant count updated")
+	msg.Ack(false)
+}
+
+// retryOrDeadLetter republishes a failed message to the retry queue, or
+// sends it to the DLQ once it has been retried 3 times.
+func (c *Consumer) retryOrDeadLetter(msg amqp.Delivery, routingKey string, err error) {
+	retryCount := 0
+	if val, ok := msg.Headers["x-retry-count"].(int32); ok {
+		retryCount = int(val)
+	}
+
+	if retryCount < 3 {
+		log.Warn().
+			Err(err).
+			Int("retry_count", retryCount).
+			Msg("processing failed, scheduling retry")
+
+		// Publish to Retry Queue
+		headers := make(amqp.Table)
+		for k, v := range msg.Headers {
+			headers[k] = v
 		}
+		headers["x-retry-count"] = int32(retryCount + 1)
+		headers["x-original-routing-key"] = routingKey
 
-		if retryCount < 3 {
-			log.Warn().
-				Err(err).
-				Int("retry_count", retryCount).
-				Msg("processing failed, scheduling retry")
-
-			// Publish to Retry Queue
-			headers := make(amqp.Table)
-			for k, v := range msg.Headers {
-				headers[k] = v
-			}
-			headers["x-retry-count"] = int32(retryCount + 1)
-			headers["x-original-routing-key"] = routingKey
-
-			pubErr := c.channel.Publish(
-				"",                                // Default Exchange
-				"event-service.join-events.retry", // Routing Key = Retry Queue Name
-				false,
-				false,
-				amqp.Publishing{
-					ContentType: msg.ContentType,
-					Body:        msg.Body,
-					Headers:     headers,
-					MessageId:   msg.MessageId,
-				},
-			)
-
-			if pubErr != nil {
-				log.Error().Err(pubErr).Msg("failed to publish to retry queue")
-				msg.Nack(false, false) // Failed to retry -> DLQ
-			} else {
-				msg.Ack(false) // Handled via retry
-			}
-			return
+		pubErr := c.channel.Publish(
+			"",                                // Default Exchange
+			"event-service.join-events.retry", // Routing Key = Retry Queue Name
+			false,
+			false,
+			amqp.Publishing{
+				ContentType: msg.ContentType,
+				Body:        msg.Body,
+				Headers:     headers,
+				MessageId:   msg.MessageId,
+			},
+		)
+
+		if pubErr != nil {
+			log.Error().Err(pubErr).Msg("failed to publish to retry queue")
+			msg.Nack(false, false) // Failed to retry -> DLQ
+		} else {
+			msg.Ack(false) // Handled via retry
 		}
+		return
+	}
 
-		// 3. Max Retries Reached -> DLQ
-		log.Error().
-			Err(err).
-			Str("event_id", joinMsg.EventID).
-			Msg("max retries reached, sending to DLQ")
-		msg.Nack(false, false) // Requeue=false + DLX configured = DLQ
+	// Max Retries Reached -> DLQ
+	log.Error().
+		Err(err).
+		Str("routing_key", routingKey).
+		Str("message_id", msg.MessageId).
+		Msg("max retries reached, sending to DLQ")
+	msg.Nack(false, false) // Requeue=false + DLX configured = DLQ
+}
+
+// handleUserBanned unpublishes the banned user's upcoming events. Replays
+// are harmless: events already taken down are skipped.
+func (c *Consumer) handleUserBanned(msg amqp.Delivery, routingKey string) {
+	var env event.DomainEventEnvelope[UserBannedMessage]
+	if err := json.Unmarshal(msg.Body, &env); err != nil || env.Payload.UserID == "" {
+		log.Error().Err(err).Str("message_id", msg.MessageId).Msg("invalid auth.user.banned message")
+		msg.Nack(false, false) // Poison message -> DLQ
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	n, err := c.service.HandleOrganizerBanned(ctx, env.Payload.UserID)
+	if err != nil {
+		c.retryOrDeadLetter(msg, routingKey, err)
 		return
 	}
 
 	log.Info().
-		Str("event_id", joinMsg.EventID).
+		Str("user_id", env.Payload.UserID).
+		Int("events", n).
+		Msg("took down events of banned organizer")
+	msg.Ack(false)
+}
+
+// handleDataRequest anonymizes or exports the user's data and queues the
+// reply to auth-service. Replays send the reply again.
+func (c *Consumer) handleDataRequest(msg amqp.Delivery, routingKey string) {
+	var env event.DomainEventEnvelope[DataRequestedMessage]
+	if err := json.Unmarshal(msg.Body, &env); err != nil || env.Payload.RequestID == "" || env.Payload.UserID == "" {
+		log.Error().Err(err).Str("message_id", msg.MessageId).Str("routing_key", routingKey).Msg("invalid data request message")
+		msg.Nack(false, false) // Poison message -> DLQ
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	var err error
+	if routingKey == rkDeletionRequested {
+		err = c.service.HandleUserDeletion(ctx, env.Payload.RequestID, env.Payload.UserID)
+	} else {
+		err = c.service.HandleUserExport(ctx, env.Payload.RequestID, env.Payload.UserID)
+	}
+	if err != nil {
+		c.retryOrDeadLetter(msg, routingKey, err)
+		return
+	}
+
+	log.Info().
+		Str("request_id", env.Payload.RequestID).
 		Str("routing_key", routingKey).
-		Msg("participant count updated")
+		Msg("data request handled")
+	msg.Ack(false)
+}
+
+// handleUploadRejected removes rejected uploads from event covers. Avatars
+// are auth-service's concern and are acked untouched.
+func (c *Consumer) handleUploadRejected(msg amqp.Delivery, routingKey string) {
+	var m UploadRejectedMessage
+	if err := json.Unmarshal(msg.Body, &m); err != nil || len(m.UploadIDs) == 0 {
+		log.Error().Err(err).Str("message_id", msg.MessageId).Msg("invalid media.upload.rejected message")
+		msg.Nack(false, false) // Poison message -> DLQ
+		return
+	}
+	if m.Purpose != "event_cover" {
+		msg.Ack(false)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	n, err := c.service.HandleCoverRejected(ctx, m.UploadIDs)
+	if err != nil {
+		c.retryOrDeadLetter(msg, routingKey, err)
+		return
+	}
+
+	log.Info().
+		Strs("upload_ids", m.UploadIDs).
+		Int("events", n).
+		Msg("detached rejected cover images")
 	msg.Ack(false)
 }
 
@@ -80,6 +80,42 @@ func (m *mockFailingRepo) GetByIDForUpdate(ctx context.Context, id string) (*dom
 func (m *mockFailingRepo) InsertOutbox(ctx context.Context, msg event.OutboxMessage) error {
 	return nil
 }
+func (m *mockFailingRepo) GetCollaboratorRole(ctx context.Context, eventID, userID string) (domain.CollaboratorRole, error) {
+	return "", nil
+}
+func (m *mockFailingRepo) ListCollaborators(ctx context.Context, eventID string) ([]domain.Collaborator, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) UpsertCollaborator(ctx context.Context, c domain.Collaborator) error {
+	return nil
+}
+func (m *mockFailingRepo) DeleteCollaborator(ctx context.Context, eventID, userID string) (bool, error) {
+	return false, nil
+}
+func (m *mockFailingRepo) SetOwner(ctx context.Context, eventID, ownerID string, at time.Time) error {
+	return nil
+}
+func (m *mockFailingRepo) ListInvites(ctx context.Context, eventID string) ([]domain.Invite, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) IncrementInviteUse(ctx context.Context, eventID, inviteID string) error {
+	return nil
+}
+func (m *mockFailingRepo) ListDueTransitions(ctx context.Context, now time.Time, limit int) ([]event.ScheduledTransition, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) ListUpcomingOwnedIDs(ctx context.Context, ownerID string, now time.Time) ([]string, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) ListIDsByCoverImages(ctx context.Context, uploadIDs []string) ([]string, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) InsertInvite(ctx context.Context, inv domain.Invite) error {
+	return nil
+}
+func (m *mockFailingRepo) RevokeInvite(ctx context.Context, eventID, inviteID string, at time.Time) (bool, error) {
+	return false, nil
+}
 
 // The target methods for consumer
 func (m *mockFailingRepo) IncrementParticipantCount(ctx context.Context, eventID uuid.UUID) error {
@@ -91,6 +127,10 @@ func (m *mockFailingRepo) DecrementParticipantCount(ctx context.Context, eventID
 func (m *mockFailingRepo) GetCitySuggestions(ctx context.Context, query string, limit int) ([]string, error) {
 	return []string{}, nil
 }
+
+func (m *mockFailingRepo) ListChangedSince(ctx context.Context, hasCursor bool, afterUpdated time.Time, afterID string, limit int) ([]event.EventChange, error) {
+	return []event.EventChange{}, nil
+}
 func (m *mockFailingRepo) GetByIDs(ctx context.Context, ids []string) ([]*domain.Event, error) {
 	return []*domain.Event{}, nil
 }
@@ -231,3 +271,98 @@ func TestConsumer_DLQ_Retry(t *testing.T) {
 		return string(d.Body) == string(body)
 	}, 5*time.Second, 100*time.Millisecond, "Message should appear in DLQ")
 }
+
+func (m *mockFailingRepo) ListComments(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Comment, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) ListReplies(ctx context.Context, parentID string, hasCursor bool, afterCreated time.Time, afterID string, limit int) ([]domain.Comment, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) ListPinnedComments(ctx context.Context, eventID string) ([]domain.Comment, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) GetComment(ctx context.Context, eventID, commentID string) (*domain.Comment, error) {
+	return nil, domain.ErrNotFound("comment not found")
+}
+
+func (m *mockFailingRepo) GetCommentForUpdate(ctx context.Context, eventID, commentID string) (*domain.Comment, error) {
+	return nil, domain.ErrNotFound("comment not found")
+}
+func (m *mockFailingRepo) InsertComment(ctx context.Context, c domain.Comment) error {
+	return nil
+}
+func (m *mockFailingRepo) UpdateComment(ctx context.Context, c domain.Comment) error {
+	return nil
+}
+func (m *mockFailingRepo) CountPinnedComments(ctx context.Context, eventID string) (int, error) {
+	return 0, nil
+}
+
+func (m *mockFailingRepo) ListReviews(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Review, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) GetReview(ctx context.Context, eventID, reviewID string) (*domain.Review, error) {
+	return nil, domain.ErrNotFound("review not found")
+}
+func (m *mockFailingRepo) EventRatingSummary(ctx context.Context, eventID string) (domain.RatingSummary, error) {
+	return domain.RatingSummary{}, nil
+}
+func (m *mockFailingRepo) OrganizerRatingSummary(ctx context.Context, organizerID string) (domain.RatingSummary, error) {
+	return domain.RatingSummary{}, nil
+}
+
+func (m *mockFailingRepo) LockOrganizerRatings(ctx context.Context, organizerID string) error {
+	return nil
+}
+func (m *mockFailingRepo) GetReviewForUpdate(ctx context.Context, eventID, reviewID string) (*domain.Review, error) {
+	return nil, domain.ErrNotFound("review not found")
+}
+func (m *mockFailingRepo) GetReviewByAuthorForUpdate(ctx context.Context, eventID, authorID string) (*domain.Review, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) InsertReview(ctx context.Context, rv domain.Review) error {
+	return nil
+}
+func (m *mockFailingRepo) UpdateReview(ctx context.Context, rv domain.Review) error {
+	return nil
+}
+
+func (m *mockFailingRepo) ListModerationCases(ctx context.Context, status domain.CaseStatus, target domain.ReportTarget, hasCursor bool, beforeReported time.Time, beforeID string, limit int) ([]domain.ModerationCase, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) GetModerationCase(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	return nil, domain.ErrNotFound("moderation case not found")
+}
+func (m *mockFailingRepo) ListCaseReports(ctx context.Context, caseID string, limit int) ([]domain.Report, error) {
+	return nil, nil
+}
+
+func (m *mockFailingRepo) ListRevisions(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Revision, error) {
+	return nil, nil
+}
+func (m *mockFailingRepo) InsertRevision(ctx context.Context, rv domain.Revision) error {
+	return nil
+}
+
+func (m *mockFailingRepo) ExportUserData(ctx context.Context, userID string) (json.RawMessage, error) {
+	return json.RawMessage(`{}`), nil
+}
+func (m *mockFailingRepo) AnonymizeUser(ctx context.Context, userID, pseudonym string) error {
+	return nil
+}
+
+func (m *mockFailingRepo) EnsureModerationCase(ctx context.Context, c domain.ModerationCase) error {
+	return nil
+}
+func (m *mockFailingRepo) GetModerationCaseForUpdate(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	return nil, domain.ErrNotFound("moderation case not found")
+}
+func (m *mockFailingRepo) GetActiveModerationCaseForUpdate(ctx context.Context, target domain.ReportTarget, targetID string) (*domain.ModerationCase, error) {
+	return nil, domain.ErrNotFound("moderation case not found")
+}
+func (m *mockFailingRepo) UpdateModerationCase(ctx context.Context, c domain.ModerationCase) error {
+	return nil
+}
+func (m *mockFailingRepo) InsertReport(ctx context.Context, rp *domain.Report) (bool, error) {
+	return true, nil
+}
@@ -11,6 +11,12 @@ type CreateEventReq struct {
 	EndTime       time.Time `json:"end_time"`
 	Capacity      int       `json:"capacity"`
 	CoverImageIDs []string  `json:"cover_image_ids"`
+	Visibility    string    `json:"visibility,omitempty"` // public (default) | unlisted | invite_only
+
+	PublishAt            *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt          *time.Time `json:"unpublish_at,omitempty"`
+	RegistrationOpensAt  *time.Time `json:"registration_opens_at,omitempty"`
+	RegistrationClosesAt *time.Time `json:"registration_closes_at,omitempty"`
 }
 
 type UpdateEventReq struct {
@@ -22,6 +28,15 @@ type UpdateEventReq struct {
 	EndTime       *time.Time `json:"end_time,omitempty"`
 	Capacity      *int       `json:"capacity,omitempty"`
 	CoverImageIDs *[]string  `json:"cover_image_ids,omitempty"`
+	Visibility    *string    `json:"visibility,omitempty"`
+}
+
+// ScheduleReq replaces the whole schedule; an omitted or null field clears it.
+type ScheduleReq struct {
+	PublishAt            *time.Time `json:"publish_at"`
+	UnpublishAt          *time.Time `json:"unpublish_at"`
+	RegistrationOpensAt  *time.Time `json:"registration_opens_at"`
+	RegistrationClosesAt *time.Time `json:"registration_closes_at"`
 }
 
 type CancelEventReq struct {
@@ -31,3 +46,73 @@ type CancelEventReq struct {
 type UnpublishEventReq struct {
 	Reason string `json:"reason"`
 }
+
+type SetCollaboratorReq struct {
+	Role string `json:"role"`
+}
+
+type TransferOwnershipReq struct {
+	NewOwnerID string `json:"new_owner_id"`
+}
+
+// CreateInviteReq issues an invite link (kind=link) or access code (kind=code).
+type CreateInviteReq struct {
+	Kind      string     `json:"kind"`
+	Label     string     `json:"label,omitempty"`
+	Code      string     `json:"code,omitempty"` // kind=code; generated when empty
+	MaxUses   *int       `json:"max_uses,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CreateCommentReq posts a comment, or a reply when ParentID is set.
+type CreateCommentReq struct {
+	ParentID string `json:"parent_id,omitempty"`
+	Body     string `json:"body"`
+}
+
+type CreateAnnouncementReq struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	Pin   bool   `json:"pin,omitempty"`
+}
+
+// EventChangeResp is one row of the internal change feed.
+type EventChangeResp struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ChangesResp struct {
+	Items      []EventChangeResp `json:"items"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// SubmitReviewReq creates or replaces the caller's review.
+type SubmitReviewReq struct {
+	Rating int    `json:"rating"`
+	Body   string `json:"body,omitempty"`
+}
+
+// HideReviewReq is a moderator hiding a review.
+type HideReviewReq struct {
+	Reason string `json:"reason"`
+}
+
+// CreateReportReq reports an event, comment or user. event_id is required
+// for comments.
+type CreateReportReq struct {
+	TargetType string `json:"target_type"`
+	TargetID   string `json:"target_id"`
+	EventID    string `json:"event_id,omitempty"`
+	Reason     string `json:"reason"`
+	Details    string `json:"details,omitempty"`
+}
+
+// ResolveCaseReq closes a moderation case. action is required when
+// status is "actioned".
+type ResolveCaseReq struct {
+	Status string `json:"status"`
+	Action string `json:"action,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
@@ -13,7 +13,13 @@ func ToEventResp(e *domain.Event, now time.Time) EventResp {
 	// - must be published
 	// - not ended
 	// - not canceled
-	joinable := (e.Status == domain.StatusPublished) && !ended && (e.Status != domain.StatusCanceled)
+	// - inside the registration window, if any
+	joinable := (e.Status == domain.StatusPublished) && !ended && (e.Status != domain.StatusCanceled) && e.RegistrationOpen(now)
+
+	visibility := e.Visibility
+	if visibility == "" {
+		visibility = domain.VisibilityPublic
+	}
 
 	return EventResp{
 		ID:                 e.ID,
@@ -27,11 +33,18 @@ func ToEventResp(e *domain.Event, now time.Time) EventResp {
 		Capacity:           e.Capacity,
 		ActiveParticipants: e.ActiveParticipants,
 		Status:             string(e.Status),
+		Visibility:         string(visibility),
 
 		PublishedAt: e.PublishedAt,
 		CanceledAt:  e.CanceledAt,
 		CreatedAt:   e.CreatedAt,
 		UpdatedAt:   e.UpdatedAt,
+		Version:     e.Version,
+
+		PublishAt:            e.PublishAt,
+		UnpublishAt:          e.UnpublishAt,
+		RegistrationOpensAt:  e.RegistrationOpensAt,
+		RegistrationClosesAt: e.RegistrationClosesAt,
 
 		Ended:    ended,
 		Joinable: joinable,
@@ -47,3 +60,153 @@ func firstItem(ids []string) string {
 	}
 	return ""
 }
+
+func ToCollaboratorResp(c domain.Collaborator) CollaboratorResp {
+	return CollaboratorResp{
+		UserID:    c.UserID,
+		Role:      string(c.Role),
+		AddedBy:   c.AddedBy,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+// ToInviteResp maps an invite; token is the signed link for kind=link.
+func ToInviteResp(inv domain.Invite, token string, now time.Time) InviteResp {
+	return InviteResp{
+		ID:        inv.ID,
+		Kind:      string(inv.Kind),
+		Label:     inv.Label,
+		Token:     token,
+		Code:      inv.Code,
+		MaxUses:   inv.MaxUses,
+		UseCount:  inv.UseCount,
+		Active:    inv.Active(now),
+		ExpiresAt: inv.ExpiresAt,
+		CreatedBy: inv.CreatedBy,
+		CreatedAt: inv.CreatedAt,
+		RevokedAt: inv.RevokedAt,
+	}
+}
+
+// ToCommentResp maps a post, blanking the text of deleted ones.
+func ToCommentResp(c domain.Comment) CommentResp {
+	out := CommentResp{
+		ID:         c.ID,
+		EventID:    c.EventID,
+		ParentID:   c.ParentID,
+		AuthorID:   c.AuthorID,
+		Kind:       string(c.Kind),
+		Title:      c.Title,
+		Body:       c.Body,
+		Pinned:     c.Pinned,
+		ReplyCount: c.ReplyCount,
+		CreatedAt:  c.CreatedAt,
+		UpdatedAt:  c.UpdatedAt,
+	}
+	if c.IsDeleted() {
+		out.Title = ""
+		out.Body = ""
+		out.Deleted = true
+		out.Removed = c.Removed()
+		out.DeletedAt = c.DeletedAt
+	}
+	return out
+}
+
+func ToCommentResps(cs []domain.Comment) []CommentResp {
+	out := make([]CommentResp, 0, len(cs))
+	for _, c := range cs {
+		out = append(out, ToCommentResp(c))
+	}
+	return out
+}
+
+func ToRatingSummaryResp(s domain.RatingSummary) RatingSummaryResp {
+	return RatingSummaryResp{Average: s.Average, Count: s.Count}
+}
+
+func ToReviewResp(rv domain.Review) ReviewResp {
+	return ReviewResp{
+		ID:           rv.ID,
+		EventID:      rv.EventID,
+		OrganizerID:  rv.OrganizerID,
+		AuthorID:     rv.AuthorID,
+		Rating:       rv.Rating,
+		Body:         rv.Body,
+		CreatedAt:    rv.CreatedAt,
+		UpdatedAt:    rv.UpdatedAt,
+		Hidden:       rv.IsHidden(),
+		HiddenAt:     rv.HiddenAt,
+		HiddenReason: rv.HiddenReason,
+	}
+}
+
+func ToReviewResps(rvs []domain.Review) []ReviewResp {
+	out := make([]ReviewResp, 0, len(rvs))
+	for _, rv := range rvs {
+		out = append(out, ToReviewResp(rv))
+	}
+	return out
+}
+
+func ToReportResp(rp domain.Report) ReportResp {
+	return ReportResp{
+		ID:         rp.ID,
+		Reason:     string(rp.Reason),
+		Details:    rp.Details,
+		ReporterID: rp.ReporterID,
+		CreatedAt:  rp.CreatedAt,
+	}
+}
+
+func ToModerationCaseResp(c domain.ModerationCase) ModerationCaseResp {
+	counts := make(map[string]int, len(c.ReasonCounts))
+	for reason, n := range c.ReasonCounts {
+		counts[string(reason)] = n
+	}
+	return ModerationCaseResp{
+		ID:             c.ID,
+		TargetType:     string(c.TargetType),
+		TargetID:       c.TargetID,
+		EventID:        c.EventID,
+		Status:         string(c.Status),
+		ReportCount:    c.ReportCount,
+		ReasonCounts:   counts,
+		CreatedAt:      c.CreatedAt,
+		LastReportedAt: c.LastReportedAt,
+		UpdatedAt:      c.UpdatedAt,
+		AutoHiddenAt:   c.AutoHiddenAt,
+		TriagedBy:      c.TriagedBy,
+		TriagedAt:      c.TriagedAt,
+		Action:         string(c.Action),
+		ResolutionNote: c.ResolutionNote,
+		ResolvedBy:     c.ResolvedBy,
+		ResolvedAt:     c.ResolvedAt,
+	}
+}
+
+func ToModerationCaseResps(cs []domain.ModerationCase) []ModerationCaseResp {
+	out := make([]ModerationCaseResp, 0, len(cs))
+	for _, c := range cs {
+		out = append(out, ToModerationCaseResp(c))
+	}
+	return out
+}
+
+func ToRevisionResp(rv domain.Revision) RevisionResp {
+	changes := make(map[string]FieldChangeResp, len(rv.Changes))
+	for field, c := range rv.Changes {
+		changes[field] = FieldChangeResp{From: c.From, To: c.To}
+	}
+	return RevisionResp{
+		ID:        rv.ID,
+		Version:   rv.Version,
+		Action:    string(rv.Action),
+		ActorID:   rv.ActorID,
+		ActorRole: rv.ActorRole,
+		Changes:   changes,
+		Reason:    rv.Reason,
+		CreatedAt: rv.CreatedAt,
+	}
+}
@@ -20,7 +20,8 @@ type EventResp struct {
 	Capacity           int `json:"capacity"`
 	ActiveParticipants int `json:"active_participants"`
 
-	Status string `json:"status"`
+	Status     string `json:"status"`
+	Visibility string `json:"visibility"`
 
 	PublishedAt *time.Time `json:"published_at,omitempty"`
 	CanceledAt  *time.Time `json:"canceled_at,omitempty"`
@@ -28,6 +29,14 @@ type EventResp struct {
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 
+	// Version is also sent as the ETag; list responses may omit it.
+	Version int64 `json:"version,omitempty"`
+
+	PublishAt            *time.Time `json:"publish_at,omitempty"`
+	UnpublishAt          *time.Time `json:"unpublish_at,omitempty"`
+	RegistrationOpensAt  *time.Time `json:"registration_opens_at,omitempty"`
+	RegistrationClosesAt *time.Time `json:"registration_closes_at,omitempty"`
+
 	// Derived
 	Ended    bool `json:"ended"`
 	Joinable bool `json:"joinable"`
@@ -43,3 +52,152 @@ type PageResp[T any] struct {
 	Total      int    `json:"total"`
 	NextCursor string `json:"next_cursor,omitempty"`
 }
+
+type CollaboratorResp struct {
+	UserID    string    `json:"user_id"`
+	Role      string    `json:"role"`
+	AddedBy   string    `json:"added_by"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type InviteResp struct {
+	ID        string     `json:"id"`
+	Kind      string     `json:"kind"`
+	Label     string     `json:"label"`
+	Token     string     `json:"token,omitempty"` // kind=link
+	Code      string     `json:"code,omitempty"`  // kind=code
+	MaxUses   *int       `json:"max_uses,omitempty"`
+	UseCount  int        `json:"use_count"`
+	Active    bool       `json:"active"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+type InviteListResp struct {
+	Items  []InviteResp `json:"items"`
+	Total  int          `json:"total"`
+	Active int          `json:"active"`
+	Uses   int          `json:"uses"`
+}
+
+// CommentResp is a discussion post. Deleted posts are returned as
+// tombstones: title and body are blanked and Removed tells a moderator
+// removal from a self-delete.
+type CommentResp struct {
+	ID         string     `json:"id"`
+	EventID    string     `json:"event_id"`
+	ParentID   *string    `json:"parent_id,omitempty"`
+	AuthorID   string     `json:"author_id"`
+	Kind       string     `json:"kind"`
+	Title      string     `json:"title,omitempty"`
+	Body       string     `json:"body"`
+	Pinned     bool       `json:"pinned"`
+	ReplyCount int        `json:"reply_count"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	Deleted    bool       `json:"deleted"`
+	Removed    bool       `json:"removed,omitempty"`
+	DeletedAt  *time.Time `json:"deleted_at,omitempty"`
+}
+
+type CommentPageResp struct {
+	Pinned     []CommentResp `json:"pinned,omitempty"`
+	Items      []CommentResp `json:"items"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+	HasMore    bool          `json:"has_more"`
+}
+
+type RatingSummaryResp struct {
+	Average float64 `json:"average"`
+	Count   int     `json:"count"`
+}
+
+// ReviewResp is a review. Hidden fields are only set on moderator responses;
+// hidden reviews never appear in the public list.
+type ReviewResp struct {
+	ID           string     `json:"id"`
+	EventID      string     `json:"event_id"`
+	OrganizerID  string     `json:"organizer_id"`
+	AuthorID     string     `json:"author_id"`
+	Rating       int        `json:"rating"`
+	Body         string     `json:"body,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	Hidden       bool       `json:"hidden,omitempty"`
+	HiddenAt     *time.Time `json:"hidden_at,omitempty"`
+	HiddenReason string     `json:"hidden_reason,omitempty"`
+}
+
+type ReviewPageResp struct {
+	Summary    RatingSummaryResp `json:"summary"`
+	Items      []ReviewResp      `json:"items"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+	HasMore    bool              `json:"has_more"`
+}
+
+// RevisionResp is one entry of an event's change history. Changes maps a
+// field name to its from/to values.
+type RevisionResp struct {
+	ID        string                     `json:"id"`
+	Version   int64                      `json:"version"`
+	Action    string                     `json:"action"`
+	ActorID   string                     `json:"actor_id"`
+	ActorRole string                     `json:"actor_role,omitempty"`
+	Changes   map[string]FieldChangeResp `json:"changes"`
+	Reason    string                     `json:"reason,omitempty"`
+	CreatedAt time.Time                  `json:"created_at"`
+}
+
+type FieldChangeResp struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+type RevisionPageResp struct {
+	Items      []RevisionResp `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	HasMore    bool           `json:"has_more"`
+}
+
+// ReportResp is returned to the reporter; duplicate is set when they had
+// already reported the target.
+type ReportResp struct {
+	ID         string    `json:"id"`
+	TargetType string    `json:"target_type,omitempty"`
+	TargetID   string    `json:"target_id,omitempty"`
+	Reason     string    `json:"reason"`
+	Details    string    `json:"details,omitempty"`
+	ReporterID string    `json:"reporter_id,omitempty"` // moderator views only
+	CreatedAt  time.Time `json:"created_at"`
+	Duplicate  bool      `json:"duplicate,omitempty"`
+}
+
+type ModerationCaseResp struct {
+	ID             string         `json:"id"`
+	TargetType     string         `json:"target_type"`
+	TargetID       string         `json:"target_id"`
+	EventID        string         `json:"event_id,omitempty"`
+	Status         string         `json:"status"`
+	ReportCount    int            `json:"report_count"`
+	ReasonCounts   map[string]int `json:"reason_counts"`
+	CreatedAt      time.Time      `json:"created_at"`
+	LastReportedAt time.Time      `json:"last_reported_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	AutoHiddenAt   *time.Time     `json:"auto_hidden_at,omitempty"`
+	TriagedBy      string         `json:"triaged_by,omitempty"`
+	TriagedAt      *time.Time     `json:"triaged_at,omitempty"`
+	Action         string         `json:"action,omitempty"`
+	ResolutionNote string         `json:"resolution_note,omitempty"`
+	ResolvedBy     string         `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time     `json:"resolved_at,omitempty"`
+	Reports        []ReportResp   `json:"reports,omitempty"` // case detail only
+}
+
+type ModerationQueueResp struct {
+	Items      []ModerationCaseResp `json:"items"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+	HasMore    bool                 `json:"has_more"`
+}
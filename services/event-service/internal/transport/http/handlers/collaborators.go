@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/application/event"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/dto"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/middleware"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/response"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/validate"
+)
+
+// -------------------------
+// Organizer team
+// -------------------------
+
+// ListCollaborators GET /event/v1/events/{event_id}/collaborators
+func (h *EventsHandler) ListCollaborators(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	if !validate.IsUUID(id) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+		}))
+		return
+	}
+
+	items, err := h.svc.ListCollaborators(r.Context(), id, middleware.UserID(r), middleware.Role(r))
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	out := make([]dto.CollaboratorResp, 0, len(items))
+	for _, c := range items {
+		out = append(out, dto.ToCollaboratorResp(c))
+	}
+	response.Data(w, http.StatusOK, out)
+}
+
+// SetCollaborator PUT /event/v1/events/{event_id}/collaborators/{user_id}
+// Body: {"role": "co_host|checkin_staff|editor"}
+func (h *EventsHandler) SetCollaborator(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	userID := chi.URLParam(r, "user_id")
+	if !validate.IsUUID(id) || !validate.IsUUID(userID) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+			"user_id":  "must be uuid",
+		}))
+		return
+	}
+
+	var req dto.SetCollaboratorReq
+	if err := validate.DecodeJSON(r, &req); err != nil {
+		response.Err(w, r, domain.ErrValidationMeta("invalid json body", map[string]string{
+			"body": "malformed JSON or invalid fields",
+		}))
+		return
+	}
+
+	c, err := h.svc.SetCollaborator(r.Context(), event.SetCollaboratorCmd{
+		ActorID:   middleware.UserID(r),
+		ActorRole: middleware.Role(r),
+		EventID:   id,
+		UserID:    userID,
+		Role:      req.Role,
+	})
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	response.Data(w, http.StatusOK, dto.ToCollaboratorResp(*c))
+}
+
+// RemoveCollaborator DELETE /event/v1/events/{event_id}/collaborators/{user_id}
+func (h *EventsHandler) RemoveCollaborator(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	userID := chi.URLParam(r, "user_id")
+	if !validate.IsUUID(id) || !validate.IsUUID(userID) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+			"user_id":  "must be uuid",
+		}))
+		return
+	}
+
+	if err := h.svc.RemoveCollaborator(r.Context(), id, userID, middleware.UserID(r), middleware.Role(r)); err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// TransferOwnership POST /event/v1/events/{event_id}/transfer-ownership
+// Body: {"new_owner_id": "uuid"}
+func (h *EventsHandler) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	if !validate.IsUUID(id) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+		}))
+		return
+	}
+
+	var req dto.TransferOwnershipReq
+	if err := validate.DecodeJSON(r, &req); err != nil || !validate.IsUUID(req.NewOwnerID) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid json body", map[string]string{
+			"new_owner_id": "must be uuid",
+		}))
+		return
+	}
+
+	ev, err := h.svc.TransferOwnership(r.Context(), id, req.NewOwnerID, middleware.UserID(r), middleware.Role(r))
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	now := h.clock.Now().UTC()
+	response.Data(w, http.StatusOK, dto.ToEventResp(ev, now))
+}
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/application/event"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/dto"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/middleware"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/response"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/validate"
+)
+
+// -------------------------
+// Discussion (comments and announcements)
+// -------------------------
+
+// ListComments GET /event/v1/events/{event_id}/comments?cursor=&limit=
+// The first page (no cursor) also carries the pinned posts.
+func (h *EventsHandler) ListComments(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	if !validate.IsUUID(id) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+		}))
+		return
+	}
+
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	page, err := h.svc.ListComments(r.Context(), id, strings.TrimSpace(q.Get("cursor")), limit)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusOK, toCommentPageResp(page))
+}
+
+// ListReplies GET /event/v1/events/{event_id}/comments/{comment_id}/replies?cursor=&limit=
+func (h *EventsHandler) ListReplies(w http.ResponseWriter, r *http.Request) {
+	id, commentID, ok := commentPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	page, err := h.svc.ListReplies(r.Context(), id, commentID, strings.TrimSpace(q.Get("cursor")), limit)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusOK, toCommentPageResp(page))
+}
+
+// CreateComment POST /event/v1/events/{event_id}/comments
+// Body: {"body": "...", "parent_id": "optional uuid"}
+func (h *EventsHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	if !validate.IsUUID(id) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+		}))
+		return
+	}
+
+	var req dto.CreateCommentReq
+	if err := validate.DecodeJSON(r, &req); err != nil {
+		response.Err(w, r, domain.ErrValidationMeta("invalid json body", map[string]string{
+			"body": "malformed JSON or invalid fields",
+		}))
+		return
+	}
+	parentID := strings.TrimSpace(req.ParentID)
+	if parentID != "" && !validate.IsUUID(parentID) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid parent_id", map[string]string{
+			"parent_id": "must be uuid",
+		}))
+		return
+	}
+
+	c, err := h.svc.PostComment(r.Context(), event.PostCommentCmd{
+		ActorID:  middleware.UserID(r),
+		EventID:  id,
+		ParentID: parentID,
+		Body:     req.Body,
+	})
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusCreated, dto.ToCommentResp(*c))
+}
+
+// CreateAnnouncement POST /event/v1/events/{event_id}/announcements
+// Body: {"title": "...", "body": "...", "pin": true}
+func (h *EventsHandler) CreateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	if !validate.IsUUID(id) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+		}))
+		return
+	}
+
+	var req dto.CreateAnnouncementReq
+	if err := validate.DecodeJSON(r, &req); err != nil {
+		response.Err(w, r, domain.ErrValidationMeta("invalid json body", map[string]string{
+			"body": "malformed JSON or invalid fields",
+		}))
+		return
+	}
+
+	c, err := h.svc.PostAnnouncement(r.Context(), event.PostAnnouncementCmd{
+		ActorID:   middleware.UserID(r),
+		ActorRole: middleware.Role(r),
+		EventID:   id,
+		Title:     req.Title,
+		Body:      req.Body,
+		Pin:       req.Pin,
+	})
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusCreated, dto.ToCommentResp(*c))
+}
+
+// PinComment PUT /event/v1/events/{event_id}/comments/{comment_id}/pin
+func (h *EventsHandler) PinComment(w http.ResponseWriter, r *http.Request) {
+	h.setCommentPinned(w, r, true)
+}
+
+// UnpinComment DELETE /event/v1/events/{event_id}/comments/{comment_id}/pin
+func (h *EventsHandler) UnpinComment(w http.ResponseWriter, r *http.Request) {
+	h.setCommentPinned(w, r, false)
+}
+
+func (h *EventsHandler) setCommentPinned(w http.ResponseWriter, r *http.Request, pinned bool) {
+	id, commentID, ok := commentPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	c, err := h.svc.SetCommentPinned(r.Context(), id, commentID, middleware.UserID(r), middleware.Role(r), pinned)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusOK, dto.ToCommentResp(*c))
+}
+
+// DeleteComment DELETE /event/v1/events/{event_id}/comments/{comment_id}?reason=
+// reason is recorded when an organizer or moderator removes someone else's post.
+func (h *EventsHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	id, commentID, ok := commentPathParams(w, r)
+	if !ok {
+		return
+	}
+
+	_, err := h.svc.DeleteComment(r.Context(), event.DeleteCommentCmd{
+		ActorID:   middleware.UserID(r),
+		ActorRole: middleware.Role(r),
+		EventID:   id,
+		CommentID: commentID,
+		Reason:    r.URL.Query().Get("reason"),
+	})
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func commentPathParams(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	id := chi.URLParam(r, "event_id")
+	commentID := chi.URLParam(r, "comment_id")
+	if !validate.IsUUID(id) || !validate.IsUUID(commentID) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id":   "must be uuid",
+			"comment_id": "must be uuid",
+		}))
+		return "", "", false
+	}
+	return id, commentID, true
+}
+
+func toCommentPageResp(page *event.CommentPage) dto.CommentPageResp {
+	out := dto.CommentPageResp{
+		Items:      dto.ToCommentResps(page.Items),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+	if len(page.Pinned) > 0 {
+		out.Pinned = dto.ToCommentResps(page.Pinned)
+	}
+	return out
+}
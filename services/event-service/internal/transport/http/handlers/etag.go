@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	chimw "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/dto"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/middleware"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/response"
+)
+
+// etag renders an event version as a strong entity tag.
+func etag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ifMatchVersion reads the version a write is conditional on. "*" matches
+// any version and yields 0; a missing header is a 428.
+func ifMatchVersion(r *http.Request) (int64, error) {
+	v := strings.TrimSpace(r.Header.Get("If-Match"))
+	if v == "" {
+		return 0, domain.ErrPreconditionRequired("If-Match header is required")
+	}
+	if v == "*" {
+		return 0, nil
+	}
+
+	v = strings.Trim(strings.TrimPrefix(v, "W/"), `"`)
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n <= 0 {
+		return 0, domain.ErrValidationMeta("invalid header", map[string]string{
+			"If-Match": "must be an ETag returned by a previous read",
+		})
+	}
+	return n, nil
+}
+
+// writeEvent sends ev with its ETag.
+func (h *EventsHandler) writeEvent(w http.ResponseWriter, status int, ev *domain.Event) {
+	if ev.Version > 0 {
+		w.Header().Set("ETag", etag(ev.Version))
+	}
+	response.Data(w, status, dto.ToEventResp(ev, h.clock.Now().UTC()))
+}
+
+// writeWriteErr maps a failed conditional write. A stale If-Match gets a
+// 412 carrying the current event so the client can merge and retry.
+func (h *EventsHandler) writeWriteErr(w http.ResponseWriter, r *http.Request, eventID string, err error) {
+	var ae *domain.AppError
+	if !errors.As(err, &ae) || ae.Code != domain.CodePreconditionFailed {
+		response.Err(w, r, err)
+		return
+	}
+
+	cur, gerr := h.svc.GetForOwner(r.Context(), eventID, middleware.UserID(r), middleware.Role(r))
+	if gerr != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(cur.Version))
+	response.JSON(w, http.StatusPreconditionFailed, response.ErrorWithData{
+		Error: response.ErrorPayload{
+			Code:      "precondition_failed",
+			Message:   ae.Message,
+			RequestID: chimw.GetReqID(r.Context()),
+		},
+		Data: dto.ToEventResp(cur, h.clock.Now().UTC()),
+	})
+}
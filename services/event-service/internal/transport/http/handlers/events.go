@@ -127,8 +127,7 @@ func (h *EventsHandler) GetPublic(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	now := h.clock.Now().UTC()
-	response.Data(w, http.StatusOK, dto.ToEventResp(ev, now))
+	h.writeEvent(w, http.StatusOK, ev)
 }
 
 // -------------------------
@@ -155,6 +154,13 @@ func (h *EventsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		EndTime:       req.EndTime,
 		Capacity:      req.Capacity,
 		CoverImageIDs: req.CoverImageIDs,
+		Visibility:    domain.Visibility(req.Visibility),
+		Schedule: domain.Schedule{
+			PublishAt:            req.PublishAt,
+			UnpublishAt:          req.UnpublishAt,
+			RegistrationOpensAt:  req.RegistrationOpensAt,
+			RegistrationClosesAt: req.RegistrationClosesAt,
+		},
 	}
 
 	ev, err := h.svc.Create(r.Context(), cmd)
@@ -163,8 +169,7 @@ func (h *EventsHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	now := h.clock.Now().UTC()
-	response.Data(w, http.StatusCreated, dto.ToEventResp(ev, now))
+	h.writeEvent(w, http.StatusCreated, ev)
 }
 
 func (h *EventsHandler) Update(w http.ResponseWriter, r *http.Request) {
@@ -176,6 +181,12 @@ func (h *EventsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ifMatch, err := ifMatchVersion(r)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
 	var req dto.UpdateEventReq
 	if err := validate.DecodeJSON(r, &req); err != nil {
 		response.Err(w, r, domain.ErrValidationMeta("invalid json body", map[string]string{
@@ -196,16 +207,20 @@ func (h *EventsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		EndTime:       req.EndTime,
 		Capacity:      req.Capacity,
 		CoverImageIDs: req.CoverImageIDs,
+		IfMatch:       ifMatch,
+	}
+	if req.Visibility != nil {
+		v := domain.Visibility(*req.Visibility)
+		cmd.Visibility = &v
 	}
 
 	ev, err := h.svc.Update(r.Context(), cmd)
 	if err != nil {
-		response.Err(w, r, err)
+		h.writeWriteErr(w, r, id, err)
 		return
 	}
 
-	now := h.clock.Now().UTC()
-	response.Data(w, http.StatusOK, dto.ToEventResp(ev, now))
+	h.writeEvent(w, http.StatusOK, ev)
 }
 
 func (h *EventsHandler) Publish(w http.ResponseWriter, r *http.Request) {
@@ -217,14 +232,52 @@ func (h *EventsHandler) Publish(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	ev, err := h.svc.Publish(r.Context(), id, middleware.UserID(r), middleware.Role(r))
+	ifMatch, err := ifMatchVersion(r)
 	if err != nil {
 		response.Err(w, r, err)
 		return
 	}
 
-	now := h.clock.Now().UTC()
-	response.Data(w, http.StatusOK, dto.ToEventResp(ev, now))
+	ev, err := h.svc.Publish(r.Context(), id, middleware.UserID(r), middleware.Role(r), ifMatch)
+	if err != nil {
+		h.writeWriteErr(w, r, id, err)
+		return
+	}
+
+	h.writeEvent(w, http.StatusOK, ev)
+}
+
+// SetSchedule replaces the event's publish_at / unpublish_at and
+// registration window.
+func (h *EventsHandler) SetSchedule(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	if !validate.IsUUID(id) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+		}))
+		return
+	}
+
+	var req dto.ScheduleReq
+	if err := validate.DecodeJSON(r, &req); err != nil {
+		response.Err(w, r, domain.ErrValidationMeta("invalid json body", map[string]string{
+			"body": "malformed JSON or invalid fields",
+		}))
+		return
+	}
+
+	ev, err := h.svc.UpdateSchedule(r.Context(), id, middleware.UserID(r), middleware.Role(r), domain.Schedule{
+		PublishAt:            req.PublishAt,
+		UnpublishAt:          req.UnpublishAt,
+		RegistrationOpensAt:  req.RegistrationOpensAt,
+		RegistrationClosesAt: req.RegistrationClosesAt,
+	})
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	h.writeEvent(w, http.StatusOK, ev)
 }
 
 func (h *EventsHandler) Cancel(w http.ResponseWriter, r *http.Request) {
@@ -236,20 +289,25 @@ func (h *EventsHandler) Cancel(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ifMatch, err := ifMatchVersion(r)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
 	var req dto.CancelEventReq
 	if err := validate.DecodeJSON(r, &req); err != nil {
 		// Fallback for requests without body (e.g. legacy or internal calls that don't need reason)
 		req.Reason = ""
 	}
 
-	ev, err := h.svc.Cancel(r.Context(), id, middleware.UserID(r), middleware.Role(r), req.Reason)
+	ev, err := h.svc.Cancel(r.Context(), id, middleware.UserID(r), middleware.Role(r), req.Reason, ifMatch)
 	if err != nil {
-		response.Err(w, r, err)
+		h.writeWriteErr(w, r, id, err)
 		return
 	}
 
-	now := h.clock.Now().UTC()
-	response.Data(w, http.StatusOK, dto.ToEventResp(ev, now))
+	h.writeEvent(w, http.StatusOK, ev)
 }
 
 func (h *EventsHandler) ListMine(w http.ResponseWriter, r *http.Request) {
@@ -302,8 +360,7 @@ func (h *EventsHandler) GetMine(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	now := h.clock.Now().UTC()
-	response.Data(w, http.StatusOK, dto.ToEventResp(ev, now))
+	h.writeEvent(w, http.StatusOK, ev)
 }
 
 // -------------------------
@@ -370,8 +427,7 @@ func (h *EventsHandler) Unpublish(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	now := h.clock.Now().UTC()
-	response.Data(w, http.StatusOK, dto.ToEventResp(ev, now))
+	h.writeEvent(w, http.StatusOK, ev)
 }
 
 // GetBatch returns multiple events by their IDs.
@@ -423,3 +479,38 @@ func (h *EventsHandler) GetBatch(w http.ResponseWriter, r *http.Request) {
 
 	response.Data(w, http.StatusOK, result)
 }
+
+// ListChanges is the internal change feed used by read models (feed-service)
+// to reconcile their projections. Returns every status; non-published rows
+// are tombstones for the consumer.
+// GET /event/v1/internal/events/changes?since=RFC3339&cursor=...&limit=
+func (h *EventsHandler) ListChanges(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var since time.Time
+	if v := strings.TrimSpace(q.Get("since")); v != "" {
+		t, err := parseRFC3339OrNano(v)
+		if err != nil {
+			response.Err(w, r, domain.ErrValidationMeta("invalid query param", map[string]string{
+				"since": "must be RFC3339 timestamp",
+			}))
+			return
+		}
+		since = t.UTC()
+	}
+
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	res, err := h.svc.ListChangedSince(r.Context(), since, strings.TrimSpace(q.Get("cursor")), limit)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	out := make([]dto.EventChangeResp, 0, len(res.Items))
+	for _, c := range res.Items {
+		out = append(out, dto.EventChangeResp{ID: c.ID, Status: string(c.Status), UpdatedAt: c.UpdatedAt})
+	}
+
+	response.Data(w, http.StatusOK, dto.ChangesResp{Items: out, NextCursor: res.NextCursor})
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -43,6 +44,66 @@ func TestEventsHandler_GetPublic(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, rr.Code)
 		assert.Contains(t, rr.Body.String(), "validation_error")
 	})
+
+	t.Run("sets_etag_from_version", func(t *testing.T) {
+		id := uuid.NewString()
+		req := httptest.NewRequest("GET", "/events/"+id, nil)
+
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("event_id", id)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+		rr := httptest.NewRecorder()
+		h.GetPublic(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `"3"`, rr.Header().Get("ETag"))
+	})
+}
+
+func TestEventsHandler_IfMatch(t *testing.T) {
+	now := time.Now().UTC()
+	svc := event.New(&mockRepo{}, mockClock{t: now}, nil, 0, 0)
+	h := NewEventsHandler(svc, mockClock{t: now})
+
+	cases := []struct {
+		name    string
+		ifMatch string
+		code    int
+		errCode string
+	}{
+		{"missing", "", http.StatusPreconditionRequired, "precondition_required"},
+		{"malformed", `"abc"`, http.StatusBadRequest, "validation_error"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			id := uuid.NewString()
+			req := httptest.NewRequest("POST", "/events/"+id+"/publish", nil)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("event_id", id)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			h.Publish(rr, req)
+
+			assert.Equal(t, tc.code, rr.Code)
+			assert.Contains(t, rr.Body.String(), tc.errCode)
+		})
+	}
+}
+
+func TestIfMatchVersion(t *testing.T) {
+	for header, want := range map[string]int64{`"7"`: 7, `W/"7"`: 7, "*": 0} {
+		req := httptest.NewRequest("PATCH", "/", nil)
+		req.Header.Set("If-Match", header)
+		got, err := ifMatchVersion(req)
+		assert.NoError(t, err, header)
+		assert.Equal(t, want, got, header)
+	}
 }
 
 // Full mock repo satisfying the event.EventRepo interface
@@ -58,6 +119,7 @@ func (m *mockRepo) GetByID(ctx context.Context, id string) (*domain.Event, error
 		Status:    domain.StatusPublished,
 		StartTime: time.Now().Add(time.Hour),
 		EndTime:   time.Now().Add(2 * time.Hour),
+		Version:   3,
 	}, nil
 }
 func (m *mockRepo) Update(ctx context.Context, e *domain.Event) error { return nil }
@@ -83,9 +145,35 @@ func (m *mockRepo) DecrementParticipantCount(ctx context.Context, eventID uuid.U
 func (m *mockRepo) GetCitySuggestions(ctx context.Context, query string, limit int) ([]string, error) {
 	return []string{}, nil
 }
+
+func (m *mockRepo) ListChangedSince(ctx context.Context, hasCursor bool, afterUpdated time.Time, afterID string, limit int) ([]event.EventChange, error) {
+	return []event.EventChange{}, nil
+}
 func (m *mockRepo) GetByIDs(ctx context.Context, ids []string) ([]*domain.Event, error) {
 	return []*domain.Event{}, nil
 }
+func (m *mockRepo) GetCollaboratorRole(ctx context.Context, eventID, userID string) (domain.CollaboratorRole, error) {
+	return "", nil
+}
+func (m *mockRepo) ListCollaborators(ctx context.Context, eventID string) ([]domain.Collaborator, error) {
+	return []domain.Collaborator{}, nil
+}
+func (m *mockRepo) ListInvites(ctx context.Context, eventID string) ([]domain.Invite, error) {
+	return []domain.Invite{}, nil
+}
+func (m *mockRepo) IncrementInviteUse(ctx context.Context, eventID, inviteID string) error {
+	return nil
+}
+
+func (m *mockRepo) ListDueTransitions(ctx context.Context, now time.Time, limit int) ([]event.ScheduledTransition, error) {
+	return nil, nil
+}
+func (m *mockRepo) ListUpcomingOwnedIDs(ctx context.Context, ownerID string, now time.Time) ([]string, error) {
+	return nil, nil
+}
+func (m *mockRepo) ListIDsByCoverImages(ctx context.Context, uploadIDs []string) ([]string, error) {
+	return nil, nil
+}
 
 // Satisfy Transaction requirements
 func (m *mockRepo) WithTx(ctx context.Context, fn func(r event.TxEventRepo) error) error {
@@ -99,3 +187,117 @@ func (m *mockTxRepo) GetByIDForUpdate(ctx context.Context, id string) (*domain.E
 }
 func (m *mockTxRepo) Update(ctx context.Context, e *domain.Event) error               { return nil }
 func (m *mockTxRepo) InsertOutbox(ctx context.Context, msg event.OutboxMessage) error { return nil }
+func (m *mockTxRepo) GetCollaboratorRole(ctx context.Context, eventID, userID string) (domain.CollaboratorRole, error) {
+	return "", nil
+}
+func (m *mockTxRepo) ListCollaborators(ctx context.Context, eventID string) ([]domain.Collaborator, error) {
+	return []domain.Collaborator{}, nil
+}
+func (m *mockTxRepo) UpsertCollaborator(ctx context.Context, c domain.Collaborator) error { return nil }
+func (m *mockTxRepo) DeleteCollaborator(ctx context.Context, eventID, userID string) (bool, error) {
+	return true, nil
+}
+func (m *mockTxRepo) SetOwner(ctx context.Context, eventID, ownerID string, at time.Time) error {
+	return nil
+}
+func (m *mockTxRepo) ListInvites(ctx context.Context, eventID string) ([]domain.Invite, error) {
+	return []domain.Invite{}, nil
+}
+func (m *mockTxRepo) InsertInvite(ctx context.Context, inv domain.Invite) error { return nil }
+func (m *mockTxRepo) RevokeInvite(ctx context.Context, eventID, inviteID string, at time.Time) (bool, error) {
+	return true, nil
+}
+
+func (m *mockRepo) ListComments(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Comment, error) {
+	return nil, nil
+}
+func (m *mockRepo) ListReplies(ctx context.Context, parentID string, hasCursor bool, afterCreated time.Time, afterID string, limit int) ([]domain.Comment, error) {
+	return nil, nil
+}
+func (m *mockRepo) ListPinnedComments(ctx context.Context, eventID string) ([]domain.Comment, error) {
+	return nil, nil
+}
+func (m *mockRepo) GetComment(ctx context.Context, eventID, commentID string) (*domain.Comment, error) {
+	return nil, domain.ErrNotFound("comment not found")
+}
+
+func (m *mockTxRepo) GetCommentForUpdate(ctx context.Context, eventID, commentID string) (*domain.Comment, error) {
+	return nil, domain.ErrNotFound("comment not found")
+}
+func (m *mockTxRepo) InsertComment(ctx context.Context, c domain.Comment) error {
+	return nil
+}
+func (m *mockTxRepo) UpdateComment(ctx context.Context, c domain.Comment) error {
+	return nil
+}
+func (m *mockTxRepo) CountPinnedComments(ctx context.Context, eventID string) (int, error) {
+	return 0, nil
+}
+
+func (m *mockRepo) ListReviews(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Review, error) {
+	return nil, nil
+}
+func (m *mockRepo) GetReview(ctx context.Context, eventID, reviewID string) (*domain.Review, error) {
+	return nil, domain.ErrNotFound("review not found")
+}
+func (m *mockRepo) EventRatingSummary(ctx context.Context, eventID string) (domain.RatingSummary, error) {
+	return domain.RatingSummary{}, nil
+}
+func (m *mockRepo) OrganizerRatingSummary(ctx context.Context, organizerID string) (domain.RatingSummary, error) {
+	return domain.RatingSummary{}, nil
+}
+
+func (m *mockTxRepo) OrganizerRatingSummary(ctx context.Context, organizerID string) (domain.RatingSummary, error) {
+	return domain.RatingSummary{}, nil
+}
+func (m *mockTxRepo) LockOrganizerRatings(ctx context.Context, organizerID string) error {
+	return nil
+}
+func (m *mockTxRepo) GetReviewForUpdate(ctx context.Context, eventID, reviewID string) (*domain.Review, error) {
+	return nil, domain.ErrNotFound("review not found")
+}
+func (m *mockTxRepo) GetReviewByAuthorForUpdate(ctx context.Context, eventID, authorID string) (*domain.Review, error) {
+	return nil, nil
+}
+func (m *mockTxRepo) InsertReview(ctx context.Context, rv domain.Review) error {
+	return nil
+}
+func (m *mockTxRepo) UpdateReview(ctx context.Context, rv domain.Review) error {
+	return nil
+}
+
+func (m *mockRepo) ListModerationCases(ctx context.Context, status domain.CaseStatus, target domain.ReportTarget, hasCursor bool, beforeReported time.Time, beforeID string, limit int) ([]domain.ModerationCase, error) {
+	return nil, nil
+}
+func (m *mockRepo) GetModerationCase(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	return nil, domain.ErrNotFound("moderation case not found")
+}
+func (m *mockRepo) ListCaseReports(ctx context.Context, caseID string, limit int) ([]domain.Report, error) {
+	return nil, nil
+}
+
+func (m *mockRepo) ListRevisions(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Revision, error) {
+	return nil, nil
+}
+func (m *mockTxRepo) InsertRevision(ctx context.Context, rv domain.Revision) error { return nil }
+
+func (m *mockRepo) ExportUserData(ctx context.Context, userID string) (json.RawMessage, error) {
+	return json.RawMessage(`{}`), nil
+}
+func (m *mockTxRepo) AnonymizeUser(ctx context.Context, userID, pseudonym string) error { return nil }
+
+func (m *mockTxRepo) EnsureModerationCase(ctx context.Context, c domain.ModerationCase) error {
+	return nil
+}
+func (m *mockTxRepo) GetModerationCaseForUpdate(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	return nil, domain.ErrNotFound("moderation case not found")
+}
+func (m *mockTxRepo) GetActiveModerationCaseForUpdate(ctx context.Context, target domain.ReportTarget, targetID string) (*domain.ModerationCase, error) {
+	return nil, domain.ErrNotFound("moderation case not found")
+}
+func (m *mockTxRepo) UpdateModerationCase(ctx context.Context, c domain.ModerationCase) error {
+	return nil
+}
+func (m *mockTxRepo) InsertReport(ctx context.Context, rp *domain.Report) (bool, error) {
+	return true, nil
+}
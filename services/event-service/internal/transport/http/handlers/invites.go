@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/application/event"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/dto"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/middleware"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/response"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/validate"
+)
+
+// -------------------------
+// Invites (invite-only events)
+// -------------------------
+
+// ListInvites GET /event/v1/events/{event_id}/invites
+func (h *EventsHandler) ListInvites(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	if !validate.IsUUID(id) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+		}))
+		return
+	}
+
+	list, err := h.svc.ListInvites(r.Context(), id, middleware.UserID(r), middleware.Role(r))
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	now := h.clock.Now().UTC()
+	out := dto.InviteListResp{
+		Items:  make([]dto.InviteResp, 0, len(list.Invites)),
+		Total:  list.Total,
+		Active: list.Active,
+		Uses:   list.Uses,
+	}
+	for _, inv := range list.Invites {
+		out.Items = append(out.Items, dto.ToInviteResp(inv.Invite, inv.Token, now))
+	}
+	response.Data(w, http.StatusOK, out)
+}
+
+// CreateInvite POST /event/v1/events/{event_id}/invites
+// Body: {"kind": "link|code", "label": "", "code": "", "max_uses": 10, "expires_at": "..."}
+func (h *EventsHandler) CreateInvite(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	if !validate.IsUUID(id) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+		}))
+		return
+	}
+
+	var req dto.CreateInviteReq
+	if err := validate.DecodeJSON(r, &req); err != nil {
+		response.Err(w, r, domain.ErrValidationMeta("invalid json body", map[string]string{
+			"body": "malformed JSON or invalid fields",
+		}))
+		return
+	}
+
+	inv, err := h.svc.CreateInvite(r.Context(), event.CreateInviteCmd{
+		ActorID:   middleware.UserID(r),
+		ActorRole: middleware.Role(r),
+		EventID:   id,
+		Kind:      req.Kind,
+		Label:     req.Label,
+		Code:      req.Code,
+		MaxUses:   req.MaxUses,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	now := h.clock.Now().UTC()
+	response.Data(w, http.StatusCreated, dto.ToInviteResp(inv.Invite, inv.Token, now))
+}
+
+// RevokeInvite DELETE /event/v1/events/{event_id}/invites/{invite_id}
+func (h *EventsHandler) RevokeInvite(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	inviteID := chi.URLParam(r, "invite_id")
+	if !validate.IsUUID(id) || !validate.IsUUID(inviteID) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id":  "must be uuid",
+			"invite_id": "must be uuid",
+		}))
+		return
+	}
+
+	if err := h.svc.RevokeInvite(r.Context(), id, inviteID, middleware.UserID(r), middleware.Role(r)); err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/application/event"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/dto"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/middleware"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/response"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/validate"
+)
+
+// -------------------------
+// Reports and moderation queue
+// -------------------------
+
+// CreateReport POST /event/v1/reports
+// Body: {"target_type": "event|comment|user", "target_id", "event_id", "reason", "details"}
+// Returns 201 for a new report and 200 when the caller already reported the target.
+func (h *EventsHandler) CreateReport(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateReportReq
+	if err := validate.DecodeJSON(r, &req); err != nil {
+		response.Err(w, r, domain.ErrValidationMeta("invalid json body", map[string]string{
+			"body": "malformed JSON or invalid fields",
+		}))
+		return
+	}
+
+	targetID := strings.TrimSpace(req.TargetID)
+	eventID := strings.TrimSpace(req.EventID)
+	meta := map[string]string{}
+	if !validate.IsUUID(targetID) {
+		meta["target_id"] = "must be uuid"
+	}
+	if eventID != "" && !validate.IsUUID(eventID) {
+		meta["event_id"] = "must be uuid"
+	}
+	if len(meta) > 0 {
+		response.Err(w, r, domain.ErrValidationMeta("invalid report", meta))
+		return
+	}
+
+	target := domain.ReportTarget(strings.ToLower(strings.TrimSpace(req.TargetType)))
+	receipt, err := h.svc.Report(r.Context(), event.ReportCmd{
+		ActorID:    middleware.UserID(r),
+		TargetType: target,
+		TargetID:   targetID,
+		EventID:    eventID,
+		Reason:     domain.ReportReason(strings.ToLower(strings.TrimSpace(req.Reason))),
+		Details:    req.Details,
+	})
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	out := dto.ToReportResp(receipt.Report)
+	out.TargetType = string(target)
+	out.TargetID = targetID
+	out.ReporterID = ""
+	out.Duplicate = receipt.Duplicate
+
+	status := http.StatusCreated
+	if receipt.Duplicate {
+		status = http.StatusOK
+	}
+	response.Data(w, status, out)
+}
+
+// ListModerationQueue GET /event/v1/mod/reports?status=&target_type=&cursor=&limit=
+// status defaults to open.
+func (h *EventsHandler) ListModerationQueue(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	page, err := h.svc.ListModerationQueue(r.Context(), middleware.Role(r),
+		domain.CaseStatus(strings.TrimSpace(q.Get("status"))),
+		domain.ReportTarget(strings.TrimSpace(q.Get("target_type"))),
+		strings.TrimSpace(q.Get("cursor")), limit,
+	)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusOK, dto.ModerationQueueResp{
+		Items:      dto.ToModerationCaseResps(page.Items),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	})
+}
+
+// GetModerationCase GET /event/v1/mod/reports/{case_id}
+// The case with its most recent reports.
+func (h *EventsHandler) GetModerationCase(w http.ResponseWriter, r *http.Request) {
+	caseID, ok := casePathParam(w, r)
+	if !ok {
+		return
+	}
+
+	d, err := h.svc.GetModerationCase(r.Context(), middleware.Role(r), caseID)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	out := dto.ToModerationCaseResp(d.Case)
+	out.Reports = make([]dto.ReportResp, 0, len(d.Reports))
+	for _, rp := range d.Reports {
+		out.Reports = append(out.Reports, dto.ToReportResp(rp))
+	}
+	response.Data(w, http.StatusOK, out)
+}
+
+// TriageCase POST /event/v1/mod/reports/{case_id}/triage
+func (h *EventsHandler) TriageCase(w http.ResponseWriter, r *http.Request) {
+	caseID, ok := casePathParam(w, r)
+	if !ok {
+		return
+	}
+
+	c, err := h.svc.TriageCase(r.Context(), middleware.UserID(r), middleware.Role(r), caseID)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusOK, dto.ToModerationCaseResp(*c))
+}
+
+// ResolveCase POST /event/v1/mod/reports/{case_id}/resolve
+// Body: {"status": "actioned|dismissed", "action", "note"}
+func (h *EventsHandler) ResolveCase(w http.ResponseWriter, r *http.Request) {
+	caseID, ok := casePathParam(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.ResolveCaseReq
+	if err := validate.DecodeJSON(r, &req); err != nil {
+		response.Err(w, r, domain.ErrValidationMeta("invalid json body", map[string]string{
+			"body": "malformed JSON or invalid fields",
+		}))
+		return
+	}
+
+	c, err := h.svc.ResolveCase(r.Context(), event.ResolveCaseCmd{
+		ActorID:   middleware.UserID(r),
+		ActorRole: middleware.Role(r),
+		CaseID:    caseID,
+		Status:    domain.CaseStatus(strings.ToLower(strings.TrimSpace(req.Status))),
+		Action:    domain.ModerationAction(strings.ToLower(strings.TrimSpace(req.Action))),
+		Note:      req.Note,
+	})
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusOK, dto.ToModerationCaseResp(*c))
+}
+
+func casePathParam(w http.ResponseWriter, r *http.Request) (string, bool) {
+	caseID := chi.URLParam(r, "case_id")
+	if !validate.IsUUID(caseID) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"case_id": "must be uuid",
+		}))
+		return "", false
+	}
+	return caseID, true
+}
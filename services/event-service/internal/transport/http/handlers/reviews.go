@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/application/event"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/dto"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/middleware"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/response"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/validate"
+)
+
+// -------------------------
+// Reviews and organizer reputation
+// -------------------------
+
+// ListReviews GET /event/v1/events/{event_id}/reviews?cursor=&limit=
+func (h *EventsHandler) ListReviews(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	if !validate.IsUUID(id) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+		}))
+		return
+	}
+
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	page, err := h.svc.ListReviews(r.Context(), id, strings.TrimSpace(q.Get("cursor")), limit)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusOK, dto.ReviewPageResp{
+		Summary:    dto.ToRatingSummaryResp(page.Summary),
+		Items:      dto.ToReviewResps(page.Items),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	})
+}
+
+// GetOrganizerReputation GET /event/v1/organizers/{user_id}/reputation
+func (h *EventsHandler) GetOrganizerReputation(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "user_id")
+	if !validate.IsUUID(userID) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"user_id": "must be uuid",
+		}))
+		return
+	}
+
+	rep, err := h.svc.GetOrganizerReputation(r.Context(), userID)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusOK, dto.ToRatingSummaryResp(rep))
+}
+
+// SubmitReview PUT /event/v1/events/{event_id}/review
+// Body: {"rating": 1-5, "body": "optional"}
+func (h *EventsHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	if !validate.IsUUID(id) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+		}))
+		return
+	}
+
+	var req dto.SubmitReviewReq
+	if err := validate.DecodeJSON(r, &req); err != nil {
+		response.Err(w, r, domain.ErrValidationMeta("invalid json body", map[string]string{
+			"body": "malformed JSON or invalid fields",
+		}))
+		return
+	}
+
+	rv, err := h.svc.SubmitReview(r.Context(), event.SubmitReviewCmd{
+		ActorID: middleware.UserID(r),
+		EventID: id,
+		Rating:  req.Rating,
+		Body:    req.Body,
+	})
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusOK, dto.ToReviewResp(*rv))
+}
+
+// HideReview PUT /event/v1/events/{event_id}/reviews/{review_id}/hide
+// Body: {"reason": "..."}; moderators and admins only.
+func (h *EventsHandler) HideReview(w http.ResponseWriter, r *http.Request) {
+	var req dto.HideReviewReq
+	if err := validate.DecodeJSON(r, &req); err != nil {
+		response.Err(w, r, domain.ErrValidationMeta("invalid json body", map[string]string{
+			"body": "malformed JSON or invalid fields",
+		}))
+		return
+	}
+	h.setReviewHidden(w, r, true, req.Reason)
+}
+
+// UnhideReview DELETE /event/v1/events/{event_id}/reviews/{review_id}/hide
+func (h *EventsHandler) UnhideReview(w http.ResponseWriter, r *http.Request) {
+	h.setReviewHidden(w, r, false, "")
+}
+
+func (h *EventsHandler) setReviewHidden(w http.ResponseWriter, r *http.Request, hidden bool, reason string) {
+	id := chi.URLParam(r, "event_id")
+	reviewID := chi.URLParam(r, "review_id")
+	if !validate.IsUUID(id) || !validate.IsUUID(reviewID) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id":  "must be uuid",
+			"review_id": "must be uuid",
+		}))
+		return
+	}
+
+	rv, err := h.svc.SetReviewHidden(r.Context(), event.SetReviewHiddenCmd{
+		ActorID:   middleware.UserID(r),
+		ActorRole: middleware.Role(r),
+		EventID:   id,
+		ReviewID:  reviewID,
+		Hidden:    hidden,
+		Reason:    reason,
+	})
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+	response.Data(w, http.StatusOK, dto.ToReviewResp(*rv))
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/dto"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/middleware"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/response"
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/validate"
+)
+
+// ListRevisions GET /event/v1/events/{event_id}/revisions?cursor=&limit=
+// Organizer team and moderators only.
+func (h *EventsHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "event_id")
+	if !validate.IsUUID(id) {
+		response.Err(w, r, domain.ErrValidationMeta("invalid path param", map[string]string{
+			"event_id": "must be uuid",
+		}))
+		return
+	}
+
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+
+	page, err := h.svc.ListRevisions(r.Context(), id, middleware.UserID(r), middleware.Role(r), strings.TrimSpace(q.Get("cursor")), limit)
+	if err != nil {
+		response.Err(w, r, err)
+		return
+	}
+
+	out := dto.RevisionPageResp{
+		Items:      make([]dto.RevisionResp, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+		HasMore:    page.HasMore,
+	}
+	for _, rv := range page.Items {
+		out.Items = append(out.Items, dto.ToRevisionResp(rv))
+	}
+	response.Data(w, http.StatusOK, out)
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	"github.com/baechuer/real-time-ressys/services/event-service/internal/transport/http/response"
+)
+
+// InternalAuth restricts a route to other services holding the shared
+// X-Internal-Secret (e.g. feed-service reconciliation).
+func InternalAuth(secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if secret == "" {
+				response.Fail(w, http.StatusInternalServerError, "internal_error", "internal auth misconfigured", nil, response.RequestIDFromRequest(r))
+				return
+			}
+
+			got := r.Header.Get("X-Internal-Secret")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(secret)) != 1 {
+				response.Fail(w, http.StatusUnauthorized, "unauthorized", "unauthorized", nil, response.RequestIDFromRequest(r))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -25,6 +25,13 @@ type ErrorPayload struct {
 	RequestID string            `json:"request_id,omitempty"`
 }
 
+// ErrorWithData is an error that carries the resource's current state, e.g.
+// a 412 so the client can merge without another read.
+type ErrorWithData struct {
+	Error ErrorPayload `json:"error"`
+	Data  any          `json:"data"`
+}
+
 // JSON writes raw JSON with Content-Type.
 func JSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
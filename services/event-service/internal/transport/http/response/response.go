@@ -31,6 +31,12 @@ func Err(w http.ResponseWriter, r *http.Request, err error) {
 		case domain.CodeInvalidState:
 			status = http.StatusConflict
 			code = "invalid_state"
+		case domain.CodePreconditionFailed:
+			status = http.StatusPreconditionFailed
+			code = "precondition_failed"
+		case domain.CodePreconditionRequired:
+			status = http.StatusPreconditionRequired
+			code = "precondition_required"
 		default:
 			status = http.StatusBadRequest
 			code = "validation_error"
@@ -59,15 +59,44 @@ func New(
 		r.Get("/events", h.ListPublic)
 		r.Post("/events/batch", h.GetBatch) // Batch lookup for N+1 fix
 		r.Get("/events/{event_id}", h.GetPublic)
+		r.Get("/events/{event_id}/comments", h.ListComments)
+		r.Get("/events/{event_id}/comments/{comment_id}/replies", h.ListReplies)
+		r.Get("/events/{event_id}/reviews", h.ListReviews)
+		r.Get("/organizers/{user_id}/reputation", h.GetOrganizerReputation)
 		r.Get("/meta/cities", h.GetCitySuggestions)
 
+		// Internal: service-to-service only
+		r.With(authmw.InternalAuth(cfg.InternalSecret)).Get("/internal/events/changes", h.ListChanges)
+
 		r.Group(func(r chi.Router) {
 			r.Use(auth.Require)
 			r.Post("/events", h.Create)
 			r.Patch("/events/{event_id}", h.Update)
 			r.Post("/events/{event_id}/publish", h.Publish)
 			r.Post("/events/{event_id}/unpublish", h.Unpublish)
+			r.Put("/events/{event_id}/schedule", h.SetSchedule)
 			r.Post("/events/{event_id}/cancel", h.Cancel)
+			r.Get("/events/{event_id}/revisions", h.ListRevisions)
+			r.Get("/events/{event_id}/collaborators", h.ListCollaborators)
+			r.Put("/events/{event_id}/collaborators/{user_id}", h.SetCollaborator)
+			r.Delete("/events/{event_id}/collaborators/{user_id}", h.RemoveCollaborator)
+			r.Post("/events/{event_id}/transfer-ownership", h.TransferOwnership)
+			r.Get("/events/{event_id}/invites", h.ListInvites)
+			r.Post("/events/{event_id}/invites", h.CreateInvite)
+			r.Delete("/events/{event_id}/invites/{invite_id}", h.RevokeInvite)
+			r.Post("/events/{event_id}/comments", h.CreateComment)
+			r.Delete("/events/{event_id}/comments/{comment_id}", h.DeleteComment)
+			r.Put("/events/{event_id}/comments/{comment_id}/pin", h.PinComment)
+			r.Delete("/events/{event_id}/comments/{comment_id}/pin", h.UnpinComment)
+			r.Post("/events/{event_id}/announcements", h.CreateAnnouncement)
+			r.Put("/events/{event_id}/review", h.SubmitReview)
+			r.Put("/events/{event_id}/reviews/{review_id}/hide", h.HideReview)
+			r.Delete("/events/{event_id}/reviews/{review_id}/hide", h.UnhideReview)
+			r.Post("/reports", h.CreateReport)
+			r.Get("/mod/reports", h.ListModerationQueue)
+			r.Get("/mod/reports/{case_id}", h.GetModerationCase)
+			r.Post("/mod/reports/{case_id}/triage", h.TriageCase)
+			r.Post("/mod/reports/{case_id}/resolve", h.ResolveCase)
 			r.Get("/organizer/events", h.ListMine)
 			r.Get("/organizer/events/{event_id}", h.GetMine)
 		})
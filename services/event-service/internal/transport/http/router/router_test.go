@@ -2,6 +2,7 @@ package router
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -51,9 +52,35 @@ func (s *stubRepo) DecrementParticipantCount(ctx context.Context, eventID uuid.U
 func (s *stubRepo) GetCitySuggestions(ctx context.Context, query string, limit int) ([]string, error) {
 	return []string{}, nil
 }
+
+func (s *stubRepo) ListChangedSince(ctx context.Context, hasCursor bool, afterUpdated time.Time, afterID string, limit int) ([]event.EventChange, error) {
+	return []event.EventChange{}, nil
+}
 func (s *stubRepo) GetByIDs(ctx context.Context, ids []string) ([]*domain.Event, error) {
 	return []*domain.Event{}, nil
 }
+func (s *stubRepo) GetCollaboratorRole(ctx context.Context, eventID, userID string) (domain.CollaboratorRole, error) {
+	return "", nil
+}
+func (s *stubRepo) ListCollaborators(ctx context.Context, eventID string) ([]domain.Collaborator, error) {
+	return []domain.Collaborator{}, nil
+}
+func (s *stubRepo) ListInvites(ctx context.Context, eventID string) ([]domain.Invite, error) {
+	return []domain.Invite{}, nil
+}
+func (s *stubRepo) IncrementInviteUse(ctx context.Context, eventID, inviteID string) error {
+	return nil
+}
+
+func (s *stubRepo) ListDueTransitions(ctx context.Context, now time.Time, limit int) ([]event.ScheduledTransition, error) {
+	return nil, nil
+}
+func (s *stubRepo) ListUpcomingOwnedIDs(ctx context.Context, ownerID string, now time.Time) ([]string, error) {
+	return nil, nil
+}
+func (s *stubRepo) ListIDsByCoverImages(ctx context.Context, uploadIDs []string) ([]string, error) {
+	return nil, nil
+}
 
 // FIX: Added WithTx to satisfy the EventRepo interface
 func (s *stubRepo) WithTx(ctx context.Context, fn func(r event.TxEventRepo) error) error {
@@ -68,6 +95,26 @@ func (s *stubTxRepo) GetByIDForUpdate(ctx context.Context, id string) (*domain.E
 }
 func (s *stubTxRepo) Update(ctx context.Context, e *domain.Event) error               { return nil }
 func (s *stubTxRepo) InsertOutbox(ctx context.Context, msg event.OutboxMessage) error { return nil }
+func (s *stubTxRepo) GetCollaboratorRole(ctx context.Context, eventID, userID string) (domain.CollaboratorRole, error) {
+	return "", nil
+}
+func (s *stubTxRepo) ListCollaborators(ctx context.Context, eventID string) ([]domain.Collaborator, error) {
+	return []domain.Collaborator{}, nil
+}
+func (s *stubTxRepo) UpsertCollaborator(ctx context.Context, c domain.Collaborator) error { return nil }
+func (s *stubTxRepo) DeleteCollaborator(ctx context.Context, eventID, userID string) (bool, error) {
+	return false, nil
+}
+func (s *stubTxRepo) SetOwner(ctx context.Context, eventID, ownerID string, at time.Time) error {
+	return nil
+}
+func (s *stubTxRepo) ListInvites(ctx context.Context, eventID string) ([]domain.Invite, error) {
+	return []domain.Invite{}, nil
+}
+func (s *stubTxRepo) InsertInvite(ctx context.Context, inv domain.Invite) error { return nil }
+func (s *stubTxRepo) RevokeInvite(ctx context.Context, eventID, inviteID string, at time.Time) (bool, error) {
+	return false, nil
+}
 
 func TestRouter_Routing(t *testing.T) {
 	authMw := middleware.NewAuth("secret", "issuer", nil)
@@ -104,3 +151,97 @@ func TestRouter_Routing(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, rr.Code)
 	})
 }
+
+func (s *stubRepo) ListComments(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Comment, error) {
+	return nil, nil
+}
+func (s *stubRepo) ListReplies(ctx context.Context, parentID string, hasCursor bool, afterCreated time.Time, afterID string, limit int) ([]domain.Comment, error) {
+	return nil, nil
+}
+func (s *stubRepo) ListPinnedComments(ctx context.Context, eventID string) ([]domain.Comment, error) {
+	return nil, nil
+}
+func (s *stubRepo) GetComment(ctx context.Context, eventID, commentID string) (*domain.Comment, error) {
+	return nil, domain.ErrNotFound("comment not found")
+}
+
+func (s *stubTxRepo) GetCommentForUpdate(ctx context.Context, eventID, commentID string) (*domain.Comment, error) {
+	return nil, domain.ErrNotFound("comment not found")
+}
+func (s *stubTxRepo) InsertComment(ctx context.Context, c domain.Comment) error {
+	return nil
+}
+func (s *stubTxRepo) UpdateComment(ctx context.Context, c domain.Comment) error {
+	return nil
+}
+func (s *stubTxRepo) CountPinnedComments(ctx context.Context, eventID string) (int, error) {
+	return 0, nil
+}
+
+func (s *stubRepo) ListReviews(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Review, error) {
+	return nil, nil
+}
+func (s *stubRepo) GetReview(ctx context.Context, eventID, reviewID string) (*domain.Review, error) {
+	return nil, domain.ErrNotFound("review not found")
+}
+func (s *stubRepo) EventRatingSummary(ctx context.Context, eventID string) (domain.RatingSummary, error) {
+	return domain.RatingSummary{}, nil
+}
+func (s *stubRepo) OrganizerRatingSummary(ctx context.Context, organizerID string) (domain.RatingSummary, error) {
+	return domain.RatingSummary{}, nil
+}
+
+func (s *stubTxRepo) OrganizerRatingSummary(ctx context.Context, organizerID string) (domain.RatingSummary, error) {
+	return domain.RatingSummary{}, nil
+}
+func (s *stubTxRepo) LockOrganizerRatings(ctx context.Context, organizerID string) error {
+	return nil
+}
+func (s *stubTxRepo) GetReviewForUpdate(ctx context.Context, eventID, reviewID string) (*domain.Review, error) {
+	return nil, domain.ErrNotFound("review not found")
+}
+func (s *stubTxRepo) GetReviewByAuthorForUpdate(ctx context.Context, eventID, authorID string) (*domain.Review, error) {
+	return nil, nil
+}
+func (s *stubTxRepo) InsertReview(ctx context.Context, rv domain.Review) error {
+	return nil
+}
+func (s *stubTxRepo) UpdateReview(ctx context.Context, rv domain.Review) error {
+	return nil
+}
+
+func (s *stubRepo) ListModerationCases(ctx context.Context, status domain.CaseStatus, target domain.ReportTarget, hasCursor bool, beforeReported time.Time, beforeID string, limit int) ([]domain.ModerationCase, error) {
+	return nil, nil
+}
+func (s *stubRepo) GetModerationCase(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	return nil, domain.ErrNotFound("moderation case not found")
+}
+func (s *stubRepo) ListCaseReports(ctx context.Context, caseID string, limit int) ([]domain.Report, error) {
+	return nil, nil
+}
+
+func (s *stubRepo) ListRevisions(ctx context.Context, eventID string, hasCursor bool, beforeCreated time.Time, beforeID string, limit int) ([]domain.Revision, error) {
+	return nil, nil
+}
+func (s *stubTxRepo) InsertRevision(ctx context.Context, rv domain.Revision) error { return nil }
+
+func (s *stubRepo) ExportUserData(ctx context.Context, userID string) (json.RawMessage, error) {
+	return json.RawMessage(`{}`), nil
+}
+func (s *stubTxRepo) AnonymizeUser(ctx context.Context, userID, pseudonym string) error { return nil }
+
+func (s *stubTxRepo) EnsureModerationCase(ctx context.Context, c domain.ModerationCase) error {
+	return nil
+}
+func (s *stubTxRepo) GetModerationCaseForUpdate(ctx context.Context, caseID string) (*domain.ModerationCase, error) {
+	return nil, domain.ErrNotFound("moderation case not found")
+}
+func (s *stubTxRepo) GetActiveModerationCaseForUpdate(ctx context.Context, target domain.ReportTarget, targetID string) (*domain.ModerationCase, error) {
+	return nil, domain.ErrNotFound("moderation case not found")
+}
+func (s *stubTxRepo) UpdateModerationCase(ctx context.Context, c domain.ModerationCase) error {
+	return nil
+}
+func (s *stubTxRepo) InsertReport(ctx context.Context, rp *domain.Report) (bool, error) {
+	return true, nil
+}
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_events_updated_at_id;
@@ -0,0 +1,2 @@
+-- Keyset index for the internal change feed (read-model reconciliation)
+CREATE INDEX IF NOT EXISTS idx_events_updated_at_id ON events (updated_at ASC, id ASC);
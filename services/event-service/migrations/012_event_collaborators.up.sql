@@ -0,0 +1,14 @@
+-- Organizer teams: users other than the owner who help run an event.
+-- role: co_host (full management, attendee moderation) | checkin_staff (attendee lists) | editor (details only)
+CREATE TABLE IF NOT EXISTS event_collaborators (
+  event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL,
+  role TEXT NOT NULL CHECK (role IN ('co_host', 'checkin_staff', 'editor')),
+  added_by TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (event_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_collaborators_user
+  ON event_collaborators (user_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_invites;
+ALTER TABLE events DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,31 @@
+-- visibility: public (listed) | unlisted (link only) | invite_only (link only, joining needs an invite)
+ALTER TABLE events
+  ADD COLUMN IF NOT EXISTS visibility TEXT NOT NULL DEFAULT 'public'
+  CHECK (visibility IN ('public', 'unlisted', 'invite_only'));
+
+-- Invite links and access codes for invite-only events.
+-- kind=link is redeemed with a signed token, kind=code with the code itself;
+-- join-service validates both against its own copy of this table.
+CREATE TABLE IF NOT EXISTS event_invites (
+  id UUID PRIMARY KEY,
+  event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+  kind TEXT NOT NULL CHECK (kind IN ('link', 'code')),
+  code TEXT,
+  code_hash TEXT,
+  label TEXT NOT NULL DEFAULT '',
+  max_uses INT CHECK (max_uses IS NULL OR max_uses > 0),
+  use_count INT NOT NULL DEFAULT 0,
+  expires_at TIMESTAMPTZ,
+  created_by TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  revoked_at TIMESTAMPTZ,
+  CHECK ((kind = 'code') = (code_hash IS NOT NULL))
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_invites_event
+  ON event_invites (event_id, created_at DESC);
+
+-- An access code is unique among the live invites of an event.
+CREATE UNIQUE INDEX IF NOT EXISTS uq_event_invites_code
+  ON event_invites (event_id, code_hash)
+  WHERE code_hash IS NOT NULL AND revoked_at IS NULL;
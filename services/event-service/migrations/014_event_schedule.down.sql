@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_events_unpublish_due;
+DROP INDEX IF EXISTS idx_events_publish_due;
+ALTER TABLE events
+  DROP COLUMN IF EXISTS registration_closes_at,
+  DROP COLUMN IF EXISTS registration_opens_at,
+  DROP COLUMN IF EXISTS unpublish_at,
+  DROP COLUMN IF EXISTS publish_at;
@@ -0,0 +1,15 @@
+-- Scheduled transitions, run by the leader-elected scheduler
+ALTER TABLE events
+  ADD COLUMN IF NOT EXISTS publish_at TIMESTAMPTZ,
+  ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMPTZ,
+  -- Registration window, enforced by join-service from event.published
+  ADD COLUMN IF NOT EXISTS registration_opens_at TIMESTAMPTZ,
+  ADD COLUMN IF NOT EXISTS registration_closes_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_events_publish_due
+  ON events (publish_at)
+  WHERE status = 'draft' AND publish_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_unpublish_due
+  ON events (unpublish_at)
+  WHERE status = 'published' AND unpublish_at IS NOT NULL;
@@ -0,0 +1,34 @@
+-- Event discussion: attendee comments and organizer announcements.
+-- Threads are one level deep (parent_id points at a top-level post).
+-- Rows are soft-deleted so threads keep their shape and removals stay auditable.
+CREATE TABLE IF NOT EXISTS event_comments (
+  id UUID PRIMARY KEY,
+  event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+  parent_id UUID REFERENCES event_comments(id) ON DELETE CASCADE,
+  author_id TEXT NOT NULL,
+  kind TEXT NOT NULL CHECK (kind IN ('comment', 'announcement')),
+  title TEXT NOT NULL DEFAULT '',
+  body TEXT NOT NULL,
+  pinned BOOLEAN NOT NULL DEFAULT FALSE,
+  reply_count INT NOT NULL DEFAULT 0,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  deleted_at TIMESTAMPTZ,
+  deleted_by TEXT,
+  removal_reason TEXT NOT NULL DEFAULT '',
+  CHECK (parent_id IS NULL OR (kind = 'comment' AND NOT pinned))
+);
+
+-- Top-level posts, newest first (keyset on created_at, id).
+CREATE INDEX IF NOT EXISTS idx_event_comments_top
+  ON event_comments (event_id, created_at DESC, id DESC)
+  WHERE parent_id IS NULL;
+
+-- Replies of a thread, oldest first.
+CREATE INDEX IF NOT EXISTS idx_event_comments_replies
+  ON event_comments (parent_id, created_at ASC, id ASC)
+  WHERE parent_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_event_comments_pinned
+  ON event_comments (event_id, created_at DESC)
+  WHERE pinned;
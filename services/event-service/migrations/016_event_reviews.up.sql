@@ -0,0 +1,29 @@
+-- Post-event ratings. One review per attendee and event; attendance is
+-- checked against join-service when the review is written.
+-- organizer_id is copied from events.owner_id so reputation can be
+-- aggregated per organizer without joining events.
+CREATE TABLE IF NOT EXISTS event_reviews (
+  id UUID PRIMARY KEY,
+  event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+  organizer_id TEXT NOT NULL,
+  author_id TEXT NOT NULL,
+  rating SMALLINT NOT NULL CHECK (rating BETWEEN 1 AND 5),
+  body TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  hidden_at TIMESTAMPTZ,
+  hidden_by TEXT,
+  hidden_reason TEXT NOT NULL DEFAULT '',
+  UNIQUE (event_id, author_id)
+);
+
+-- Public list, newest first (keyset on created_at, id).
+CREATE INDEX IF NOT EXISTS idx_event_reviews_event
+  ON event_reviews (event_id, created_at DESC, id DESC)
+  WHERE hidden_at IS NULL;
+
+-- Organizer reputation.
+CREATE INDEX IF NOT EXISTS idx_event_reviews_organizer
+  ON event_reviews (organizer_id)
+  INCLUDE (rating)
+  WHERE hidden_at IS NULL;
@@ -0,0 +1,42 @@
+-- User reports of events, comments and users, aggregated into one
+-- moderation case per target. target_id is TEXT because users live in
+-- auth-service; event_id is set for event and comment targets.
+CREATE TABLE IF NOT EXISTS moderation_cases (
+  id UUID PRIMARY KEY,
+  target_type TEXT NOT NULL CHECK (target_type IN ('event', 'comment', 'user')),
+  target_id TEXT NOT NULL,
+  event_id UUID REFERENCES events(id) ON DELETE SET NULL,
+  status TEXT NOT NULL DEFAULT 'open'
+    CHECK (status IN ('open', 'triaged', 'actioned', 'dismissed')),
+  report_count INT NOT NULL DEFAULT 0,
+  reason_counts JSONB NOT NULL DEFAULT '{}',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_reported_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  auto_hidden_at TIMESTAMPTZ,
+  triaged_by TEXT,
+  triaged_at TIMESTAMPTZ,
+  action TEXT NOT NULL DEFAULT '',
+  resolution_note TEXT NOT NULL DEFAULT '',
+  resolved_by TEXT,
+  resolved_at TIMESTAMPTZ,
+  UNIQUE (target_type, target_id)
+);
+
+-- Queue by status, most recently reported first (keyset on last_reported_at, id).
+CREATE INDEX IF NOT EXISTS idx_moderation_cases_queue
+  ON moderation_cases (status, last_reported_at DESC, id DESC);
+
+-- One report per reporter and target.
+CREATE TABLE IF NOT EXISTS moderation_reports (
+  id UUID PRIMARY KEY,
+  case_id UUID NOT NULL REFERENCES moderation_cases(id) ON DELETE CASCADE,
+  reporter_id TEXT NOT NULL,
+  reason TEXT NOT NULL,
+  details TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  UNIQUE (case_id, reporter_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_reports_case
+  ON moderation_reports (case_id, created_at DESC);
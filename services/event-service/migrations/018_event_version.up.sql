@@ -0,0 +1,3 @@
+-- Optimistic concurrency: bumped on every edit, exposed as the ETag
+ALTER TABLE events
+  ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
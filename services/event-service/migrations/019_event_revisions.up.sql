@@ -0,0 +1,19 @@
+-- Change history of events: one row per update, schedule change, publish,
+-- unpublish or cancel, written in the same transaction as the change.
+-- changes maps field name to {"from": ..., "to": ...}.
+CREATE TABLE IF NOT EXISTS event_revisions (
+  id UUID PRIMARY KEY,
+  event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+  version BIGINT NOT NULL,
+  action TEXT NOT NULL
+    CHECK (action IN ('update', 'schedule', 'publish', 'unpublish', 'cancel')),
+  actor_id TEXT NOT NULL,
+  actor_role TEXT NOT NULL DEFAULT '',
+  changes JSONB NOT NULL DEFAULT '{}',
+  reason TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- History newest first (keyset on created_at, id).
+CREATE INDEX IF NOT EXISTS idx_event_revisions_event
+  ON event_revisions (event_id, created_at DESC, id DESC);
@@ -0,0 +1,2 @@
+-- Finds the events using an upload as a cover when media-service rejects it.
+CREATE INDEX IF NOT EXISTS idx_events_cover_image_ids ON events USING GIN (cover_image_ids);
@@ -0,0 +1,2 @@
+-- Snapshots already relayed cannot be recalled; nothing to undo.
+SELECT 1;
@@ -0,0 +1,31 @@
+-- One-shot resync of organizer teams to consumers (join-service event_acl).
+-- Events published before event.collaborators.updated existed have no team
+-- snapshot downstream, so every organizer/staff route there treats them as
+-- unknown. Enqueue a full snapshot for each published or canceled event;
+-- consumers keep whichever snapshot has the newest updated_at.
+INSERT INTO event_outbox (message_id, routing_key, body, created_at, status, next_retry_at)
+SELECT
+  m.message_id,
+  'event.collaborators.updated',
+  jsonb_build_object(
+    'version', 1,
+    'producer', 'event-service',
+    'message_id', m.message_id,
+    'occurred_at', NOW(),
+    'payload', jsonb_build_object(
+      'event_id', e.id,
+      'owner_id', e.owner_id,
+      'collaborators', COALESCE((
+        SELECT jsonb_agg(jsonb_build_object('user_id', c.user_id, 'role', c.role) ORDER BY c.user_id)
+        FROM event_collaborators c
+        WHERE c.event_id = e.id
+      ), '[]'::jsonb),
+      'updated_at', NOW()
+    )
+  ),
+  NOW(),
+  'pending',
+  NOW()
+FROM events e
+CROSS JOIN LATERAL (SELECT gen_random_uuid() AS message_id) m
+WHERE e.status IN ('published', 'canceled');
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS uq_moderation_cases_active_target;
+
+-- Keep only the latest case per target so the old constraint holds again.
+DELETE FROM moderation_cases c
+USING moderation_cases newer
+WHERE newer.target_type = c.target_type
+  AND newer.target_id = c.target_id
+  AND (newer.created_at, newer.id) > (c.created_at, c.id);
+
+ALTER TABLE moderation_cases
+  ADD CONSTRAINT moderation_cases_target_type_target_id_key UNIQUE (target_type, target_id);
@@ -0,0 +1,10 @@
+-- A target has at most one open or triaged case. Once a case is actioned
+-- or dismissed it is history: the next report opens a fresh case, so the
+-- target returns to the queue, auto-hide applies again and earlier
+-- reporters count again.
+ALTER TABLE moderation_cases
+  DROP CONSTRAINT IF EXISTS moderation_cases_target_type_target_id_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_moderation_cases_active_target
+  ON moderation_cases (target_type, target_id)
+  WHERE status IN ('open', 'triaged');
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"os"
@@ -12,8 +13,10 @@ import (
 	"github.com/baechuer/real-time-ressys/services/feed-service/internal/api"
 	"github.com/baechuer/real-time-ressys/services/feed-service/internal/api/handlers"
 	"github.com/baechuer/real-time-ressys/services/feed-service/internal/config"
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/infrastructure/eventservice"
 	"github.com/baechuer/real-time-ressys/services/feed-service/internal/infrastructure/postgres"
 	"github.com/baechuer/real-time-ressys/services/feed-service/internal/infrastructure/rabbitmq"
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/reconcile"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -31,21 +34,39 @@ func main() {
 	defer pool.Close()
 
 	// Repositories
-	trackRepo := postgres.NewTrackRepo(pool)
+	profileRepo := postgres.NewProfileRepo(pool, cfg.ProfileHalfLife, cfg.ProfileMaxTags)
+	trackRepo := postgres.NewTrackRepo(pool, profileRepo)
 	trendingRepo := postgres.NewTrendingRepo(pool)
-	profileRepo := postgres.NewProfileRepo(pool)
+	identityRepo := postgres.NewIdentityRepo(pool, profileRepo)
+	similarityRepo := postgres.NewSimilarityRepo(pool)
+	indexRepo := postgres.NewIndexRepo(pool)
+
+	reconciler := reconcile.NewReconciler(eventservice.NewClient(cfg.EventServiceURL, cfg.InternalSecretKey), indexRepo)
+
+	// CLI: `feed-service backfill` re-syncs all of event_index and exits
+	if len(os.Args) > 1 && os.Args[1] == "backfill" {
+		runBackfill(reconciler)
+		return
+	}
 
 	// Handlers
 	trackHandler := handlers.NewTrackHandler(trackRepo)
 	feedHandler := handlers.NewFeedHandler(trendingRepo, profileRepo)
+	identityHandler := handlers.NewIdentityHandler(identityRepo, cfg.AnonCookieSecret)
+	similarHandler := handlers.NewSimilarHandler(similarityRepo)
 
 	// Router
-	router := api.NewRouter(cfg, trackHandler, feedHandler)
+	router := api.NewRouter(cfg, trackHandler, feedHandler, identityHandler, similarHandler)
 
 	// Start workers
 	go runOutboxWorker(trackRepo)
 	go runAggregationWorker(trendingRepo)
-	go runProfileRebuildWorker(profileRepo)
+	go runSimilarityWorker(similarityRepo)
+	go runProfileCompactionWorker(profileRepo)
+	go runReconcileWorker(reconciler, cfg.ReconcileInterval)
+	if cfg.ProfileRebuildNightly {
+		go runProfileRebuildWorker(profileRepo)
+	}
 
 	// Start RabbitMQ consumer
 	// Start RabbitMQ consumer
@@ -127,7 +148,55 @@ func runAggregation(repo *postgres.TrendingRepo) {
 	}
 }
 
-// runProfileRebuildWorker rebuilds user profiles daily
+// runSimilarityWorker refreshes the "similar events" model periodically
+func runSimilarityWorker(repo *postgres.SimilarityRepo) {
+	ticker := time.NewTicker(15 * time.Minute)
+	defer ticker.Stop()
+
+	// Run immediately on startup
+	runSimilarity(repo)
+
+	for range ticker.C {
+		runSimilarity(repo)
+	}
+}
+
+func runSimilarity(repo *postgres.SimilarityRepo) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	if err := repo.RebuildSimilarity(ctx); err != nil {
+		log.Printf("similarity worker error: %v", err)
+	} else {
+		log.Println("event similarity rebuild complete")
+	}
+}
+
+// runProfileCompactionWorker decays and prunes user profiles weekly.
+// Profiles themselves are updated incrementally by the outbox worker.
+func runProfileCompactionWorker(repo *postgres.ProfileRepo) {
+	ticker := time.NewTicker(7 * 24 * time.Hour)
+	defer ticker.Stop()
+
+	// Run immediately on startup
+	runProfileCompaction(repo)
+
+	for range ticker.C {
+		runProfileCompaction(repo)
+	}
+}
+
+func runProfileCompaction(repo *postgres.ProfileRepo) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	removed, err := repo.Compact(ctx)
+	if err != nil {
+		log.Printf("profile compaction error: %v", err)
+	} else {
+		log.Printf("user profile compaction complete, removed %d faded or over-cap tags", removed)
+	}
+}
+
+// runProfileRebuildWorker rebuilds user profiles daily (repair path, opt-in)
 func runProfileRebuildWorker(repo *postgres.ProfileRepo) {
 	// Run at 3 AM daily
 	for {
@@ -144,3 +213,42 @@ func runProfileRebuildWorker(repo *postgres.ProfileRepo) {
 		cancel()
 	}
 }
+
+// runReconcileWorker catches event_index up with event-service changes that
+// never arrived as messages (downtime, purged queue, fresh deploy)
+func runReconcileWorker(r *reconcile.Reconciler, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	// Run immediately on startup
+	runReconcile(r, false)
+
+	for range ticker.C {
+		runReconcile(r, false)
+	}
+}
+
+func runReconcile(r *reconcile.Reconciler, full bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	res, err := r.Run(ctx, full)
+	if errors.Is(err, reconcile.ErrBusy) {
+		log.Println("event_index reconciliation skipped: another replica is running it")
+		return err
+	}
+	if err != nil {
+		log.Printf("event_index reconciliation error: %v", err)
+		return err
+	}
+	log.Printf("event_index reconciliation complete (full=%t): scanned=%d missing=%d stale=%d tombstoned=%d orphaned=%d",
+		res.Full, res.Scanned, res.Missing, res.Stale, res.Tombstoned, res.Orphaned)
+	return nil
+}
+
+// runBackfill performs a full reconciliation for the backfill subcommand
+func runBackfill(r *reconcile.Reconciler) {
+	if err := runReconcile(r, true); err != nil {
+		os.Exit(1)
+	}
+}
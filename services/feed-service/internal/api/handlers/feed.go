@@ -17,7 +17,7 @@ import (
 
 // TrendingRepo defines the interface for trending data access
 type TrendingRepo interface {
-	GetTrending(ctx context.Context, city string, category string, queryStr string, limit int, afterScore float64, afterStartTime time.Time, afterID string) ([]postgres.TrendingEvent, error)
+	GetTrending(ctx context.Context, city string, category string, queryStr string, limit int, asOf time.Time, afterScore float64, afterStartTime time.Time, afterID string) ([]postgres.TrendingEvent, error)
 	GetLatest(ctx context.Context, city string, category string, queryStr string, limit int, afterStartTime time.Time, afterID string) ([]postgres.TrendingEvent, error)
 }
 
@@ -59,13 +59,19 @@ func (h *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Parse cursor
+	// Parse cursor. asOf pins the time-dependent part of the score to the
+	// first page so later pages compare against identical values.
 	var afterScore float64
 	var afterStartTime time.Time
 	var afterID string
+	asOf := time.Now()
 
 	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
-		afterScore, afterStartTime, afterID = h.decodeCursor(cursor)
+		var cursorAsOf time.Time
+		afterScore, afterStartTime, afterID, cursorAsOf = h.decodeCursor(cursor)
+		if !cursorAsOf.IsZero() {
+			asOf = cursorAsOf
+		}
 	}
 
 	var events []postgres.TrendingEvent
@@ -82,9 +88,9 @@ func (h *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 
 	switch feedType {
 	case "personalized":
-		events, err = h.getPersonalized(r, city, category, queryStr, limit, afterScore, afterStartTime, afterID)
+		events, err = h.getPersonalized(r, city, category, queryStr, limit, asOf, afterScore, afterStartTime, afterID)
 	case "trending":
-		events, err = h.getTrending(r.Context(), city, category, queryStr, limit, afterScore, afterStartTime, afterID)
+		events, err = h.getTrending(r.Context(), city, category, queryStr, limit, asOf, afterScore, afterStartTime, afterID)
 	case "latest":
 		events, err = h.getLatest(r.Context(), city, category, queryStr, limit, afterStartTime, afterID)
 	default:
@@ -107,7 +113,7 @@ func (h *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 		if len(events) == limit {
 			hasMore = true
 			last := events[len(events)-1]
-			nextCursor = h.encodeCursor(last.TrendScore, last.StartTime, last.EventID)
+			nextCursor = h.encodeCursor(last.TrendScore, last.StartTime, last.EventID, asOf)
 		}
 	}
 
@@ -121,10 +127,10 @@ func (h *FeedHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
 }
 
 // getTrending returns trending events with keyset pagination
-func (h *FeedHandler) getTrending(ctx context.Context, city string, category string, queryStr string, limit int, afterScore float64, afterStartTime time.Time, afterID string) ([]postgres.TrendingEvent, error) {
+func (h *FeedHandler) getTrending(ctx context.Context, city string, category string, queryStr string, limit int, asOf time.Time, afterScore float64, afterStartTime time.Time, afterID string) ([]postgres.TrendingEvent, error) {
 	ctx, cancel := context.WithTimeout(ctx, 40*time.Millisecond)
 	defer cancel()
-	return h.trendingRepo.GetTrending(ctx, city, category, queryStr, limit, afterScore, afterStartTime, afterID)
+	return h.trendingRepo.GetTrending(ctx, city, category, queryStr, limit, asOf, afterScore, afterStartTime, afterID)
 }
 
 // getLatest returns newest events ordered by start_time DESC
@@ -135,7 +141,7 @@ func (h *FeedHandler) getLatest(ctx context.Context, city string, category strin
 }
 
 // getPersonalized returns personalized feed with fallback to trending
-func (h *FeedHandler) getPersonalized(r *http.Request, city string, category string, queryStr string, limit int, afterScore float64, afterStartTime time.Time, afterID string) ([]postgres.TrendingEvent, error) {
+func (h *FeedHandler) getPersonalized(r *http.Request, city string, category string, queryStr string, limit int, asOf time.Time, afterScore float64, afterStartTime time.Time, afterID string) ([]postgres.TrendingEvent, error) {
 	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
 	defer cancel()
 
@@ -155,11 +161,11 @@ func (h *FeedHandler) getPersonalized(r *http.Request, city string, category str
 	if candidateLimit > 200 {
 		candidateLimit = 200
 	}
-	candidates, err := h.trendingRepo.GetTrending(trendingCtx, city, category, queryStr, candidateLimit, afterScore, afterStartTime, afterID)
+	candidates, err := h.trendingRepo.GetTrending(trendingCtx, city, category, queryStr, candidateLimit, asOf, afterScore, afterStartTime, afterID)
 	trendingCancel()
 	if err != nil || len(candidates) == 0 {
 		// Fallback to trending (simple pagination)
-		return h.getTrending(r.Context(), city, category, queryStr, limit, afterScore, afterStartTime, afterID)
+		return h.getTrending(r.Context(), city, category, queryStr, limit, asOf, afterScore, afterStartTime, afterID)
 	}
 
 	// Get user prefs (20ms budget)
@@ -183,28 +189,29 @@ func (h *FeedHandler) getPersonalized(r *http.Request, city string, category str
 }
 
 // encodeCursor creates a base64 string from pagination fields
-func (h *FeedHandler) encodeCursor(score float64, startTime time.Time, id string) string {
-	// Format: scoreBits(hex)|unixNano|id
+func (h *FeedHandler) encodeCursor(score float64, startTime time.Time, id string, asOf time.Time) string {
+	// Format: scoreBits(hex)|unixNano|id|asOfUnixNano
 	// Use Float64bits to ensure exact bitwise roundtrip of the float
 	scoreBits := math.Float64bits(score)
-	s := fmt.Sprintf("%x|%d|%s", scoreBits, startTime.UnixNano(), id)
+	s := fmt.Sprintf("%x|%d|%s|%d", scoreBits, startTime.UnixNano(), id, asOf.UnixNano())
 	return base64.URLEncoding.EncodeToString([]byte(s))
 }
 
-// decodeCursor parses base64 string back to pagination fields
-func (h *FeedHandler) decodeCursor(cursor string) (float64, time.Time, string) {
+// decodeCursor parses base64 string back to pagination fields. Legacy
+// three-part cursors decode with a zero asOf.
+func (h *FeedHandler) decodeCursor(cursor string) (float64, time.Time, string, time.Time) {
 	// Try URLEncoding first (new standard)
 	b, err := base64.URLEncoding.DecodeString(cursor)
 	if err != nil {
 		// Fallback to StdEncoding (legacy/url-safe-std)
 		b, err = base64.StdEncoding.DecodeString(cursor)
 		if err != nil {
-			return 0, time.Time{}, ""
+			return 0, time.Time{}, "", time.Time{}
 		}
 	}
 	parts := strings.Split(string(b), "|")
-	if len(parts) != 3 {
-		return 0, time.Time{}, ""
+	if len(parts) != 3 && len(parts) != 4 {
+		return 0, time.Time{}, "", time.Time{}
 	}
 
 	var score float64
@@ -221,7 +228,14 @@ func (h *FeedHandler) decodeCursor(cursor string) (float64, time.Time, string) {
 
 	ts, _ := strconv.ParseInt(parts[1], 10, 64)
 
-	return score, time.Unix(0, ts), parts[2]
+	var asOf time.Time
+	if len(parts) == 4 {
+		if n, err := strconv.ParseInt(parts[3], 10, 64); err == nil {
+			asOf = time.Unix(0, n)
+		}
+	}
+
+	return score, time.Unix(0, ts), parts[2], asOf
 }
 
 // rerank adjusts trending scores based on user preferences
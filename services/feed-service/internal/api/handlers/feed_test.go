@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -13,11 +14,15 @@ import (
 
 // Mock trending repo
 type mockTrendingRepo struct {
-	events []postgres.TrendingEvent
-	err    error
+	events    []postgres.TrendingEvent
+	err       error
+	lastQuery string
+	lastAsOf  time.Time
 }
 
-func (m *mockTrendingRepo) GetTrending(ctx context.Context, city string, category string, queryStr string, limit int, afterScore float64, afterStartTime time.Time, afterID string) ([]postgres.TrendingEvent, error) {
+func (m *mockTrendingRepo) GetTrending(ctx context.Context, city string, category string, queryStr string, limit int, asOf time.Time, afterScore float64, afterStartTime time.Time, afterID string) ([]postgres.TrendingEvent, error) {
+	m.lastQuery = queryStr
+	m.lastAsOf = asOf
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -128,3 +133,58 @@ func TestFeedHandler_PersonalizedWithUser(t *testing.T) {
 		t.Errorf("expected 'personalized', got '%v'", resp["feed_type"])
 	}
 }
+
+func TestFeedHandler_CursorPinsAsOf(t *testing.T) {
+	trendingRepo := &mockTrendingRepo{
+		events: []postgres.TrendingEvent{
+			{EventID: "1", Title: "Jazz night", TrendScore: 1.25, StartTime: time.Now().Add(time.Hour)},
+		},
+	}
+	h := NewFeedHandler(trendingRepo, &mockProfileRepo{})
+
+	req := httptest.NewRequest("GET", "/api/feed?type=trending&q=jaz&limit=1", nil)
+	rr := httptest.NewRecorder()
+	h.GetFeed(rr, req)
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if trendingRepo.lastQuery != "jaz" {
+		t.Errorf("expected q passed to repo, got %q", trendingRepo.lastQuery)
+	}
+	firstAsOf := trendingRepo.lastAsOf
+
+	cursor, _ := resp["next_cursor"].(string)
+	if cursor == "" {
+		t.Fatal("expected next_cursor on a full page")
+	}
+
+	score, _, id, asOf := h.decodeCursor(cursor)
+	if score != 1.25 || id != "1" {
+		t.Errorf("cursor roundtrip mismatch: score=%v id=%s", score, id)
+	}
+	if !asOf.Equal(firstAsOf) {
+		t.Errorf("expected cursor asOf %v, got %v", firstAsOf, asOf)
+	}
+
+	// Page 2 scores against the first page's asOf
+	req = httptest.NewRequest("GET", "/api/feed?type=trending&q=jaz&limit=1&cursor="+cursor, nil)
+	h.GetFeed(httptest.NewRecorder(), req)
+	if !trendingRepo.lastAsOf.Equal(firstAsOf) {
+		t.Errorf("expected page 2 asOf %v, got %v", firstAsOf, trendingRepo.lastAsOf)
+	}
+}
+
+func TestFeedHandler_DecodeLegacyCursor(t *testing.T) {
+	h := NewFeedHandler(&mockTrendingRepo{}, &mockProfileRepo{})
+
+	legacy := base64.URLEncoding.EncodeToString([]byte("2.5|1700000000000000000|abc"))
+	score, ts, id, asOf := h.decodeCursor(legacy)
+	if score != 2.5 || id != "abc" || ts.UnixNano() != 1700000000000000000 {
+		t.Errorf("unexpected legacy decode: %v %v %s", score, ts, id)
+	}
+	if !asOf.IsZero() {
+		t.Errorf("expected zero asOf for legacy cursor, got %v", asOf)
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/middleware"
+	"github.com/google/uuid"
+)
+
+// IdentityRepo defines the persistence interface for actor merges
+type IdentityRepo interface {
+	MergeActor(ctx context.Context, anonKey, userKey string) (*domain.MergeResult, error)
+}
+
+// IdentityHandler handles /identity requests
+type IdentityHandler struct {
+	repo       IdentityRepo
+	anonSecret string
+}
+
+func NewIdentityHandler(repo IdentityRepo, anonSecret string) *IdentityHandler {
+	return &IdentityHandler{repo: repo, anonSecret: anonSecret}
+}
+
+// Merge handles POST /identity/merge - folds anonymous activity into a user.
+// Called by the BFF after login/registration with the user's signed anon cookie.
+func (h *IdentityHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(r.Header.Get("X-User-ID"))
+	if err != nil {
+		http.Error(w, "invalid user id", http.StatusBadRequest)
+		return
+	}
+
+	var req domain.MergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	anonID, ok := middleware.VerifyAnonCookie(h.anonSecret, req.AnonCookie)
+	if !ok {
+		http.Error(w, "invalid anon cookie", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+
+	res, err := h.repo.MergeActor(ctx, "a:"+anonID, "u:"+userID.String())
+	if errors.Is(err, domain.ErrAnonAlreadyMerged) {
+		http.Error(w, "anon id belongs to another user", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		log.Printf("identity merge error: %v", err)
+		http.Error(w, "failed to merge identity", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/middleware"
+)
+
+type mockIdentityRepo struct {
+	anonKey string
+	userKey string
+	err     error
+}
+
+func (m *mockIdentityRepo) MergeActor(ctx context.Context, anonKey, userKey string) (*domain.MergeResult, error) {
+	m.anonKey, m.userKey = anonKey, userKey
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &domain.MergeResult{AnonKey: anonKey, UserKey: userKey, EventsMoved: 3}, nil
+}
+
+// issueAnonCookie runs the AnonID middleware once and returns the signed cookie value
+func issueAnonCookie(t *testing.T, secret string) string {
+	t.Helper()
+	h := middleware.AnonID(secret, time.Hour, false)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest("GET", "/", nil))
+	for _, c := range rr.Result().Cookies() {
+		if c.Name == "anon_id" {
+			return c.Value
+		}
+	}
+	t.Fatal("anon_id cookie not issued")
+	return ""
+}
+
+func mergeRequest(userID, cookie string) *http.Request {
+	body, _ := json.Marshal(domain.MergeRequest{AnonCookie: cookie})
+	req := httptest.NewRequest("POST", "/api/feed/identity/merge", strings.NewReader(string(body)))
+	req.Header.Set("X-User-ID", userID)
+	return req
+}
+
+func TestIdentityHandler_Merge(t *testing.T) {
+	const secret = "test-secret"
+	const userID = "7b0d3c52-2a5e-4b1f-9d3e-0c7a1f2b3c4d"
+	cookie := issueAnonCookie(t, secret)
+	anonID, _ := middleware.VerifyAnonCookie(secret, cookie)
+
+	repo := &mockIdentityRepo{}
+	h := NewIdentityHandler(repo, secret)
+
+	rr := httptest.NewRecorder()
+	h.Merge(rr, mergeRequest(userID, cookie))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if repo.anonKey != "a:"+anonID {
+		t.Errorf("expected anon key a:%s, got %s", anonID, repo.anonKey)
+	}
+	if repo.userKey != "u:"+userID {
+		t.Errorf("expected user key u:%s, got %s", userID, repo.userKey)
+	}
+}
+
+func TestIdentityHandler_Merge_InvalidCookie(t *testing.T) {
+	repo := &mockIdentityRepo{}
+	h := NewIdentityHandler(repo, "test-secret")
+
+	cookie := issueAnonCookie(t, "other-secret")
+	rr := httptest.NewRecorder()
+	h.Merge(rr, mergeRequest("7b0d3c52-2a5e-4b1f-9d3e-0c7a1f2b3c4d", cookie))
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+	if repo.anonKey != "" {
+		t.Error("repo should not be called with a forged cookie")
+	}
+}
+
+func TestIdentityHandler_Merge_AlreadyMerged(t *testing.T) {
+	const secret = "test-secret"
+	repo := &mockIdentityRepo{err: domain.ErrAnonAlreadyMerged}
+	h := NewIdentityHandler(repo, secret)
+
+	rr := httptest.NewRecorder()
+	h.Merge(rr, mergeRequest("7b0d3c52-2a5e-4b1f-9d3e-0c7a1f2b3c4d", issueAnonCookie(t, secret)))
+
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", rr.Code)
+	}
+}
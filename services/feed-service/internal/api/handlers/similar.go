@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/infrastructure/postgres"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// SimilarityRepo defines the interface for item-to-item recommendations
+type SimilarityRepo interface {
+	GetSimilar(ctx context.Context, eventID string, limit int) ([]postgres.SimilarEvent, error)
+}
+
+// SimilarHandler handles /events/{id}/similar requests
+type SimilarHandler struct {
+	repo    SimilarityRepo
+	timeout time.Duration
+}
+
+func NewSimilarHandler(repo SimilarityRepo) *SimilarHandler {
+	return &SimilarHandler{repo: repo, timeout: 80 * time.Millisecond}
+}
+
+// GetSimilar handles GET /api/feed/events/{id}/similar?limit=
+func (h *SimilarHandler) GetSimilar(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "invalid event id", http.StatusBadRequest)
+		return
+	}
+
+	limit := 6
+	if l := r.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 && parsed <= 20 {
+			limit = parsed
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+
+	events, err := h.repo.GetSimilar(ctx, eventID.String(), limit)
+	if err != nil {
+		http.Error(w, "failed to get similar events", http.StatusInternalServerError)
+		return
+	}
+	if events == nil {
+		events = []postgres.SimilarEvent{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"event_id": eventID.String(),
+		"items":    events,
+	})
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/infrastructure/postgres"
+	"github.com/go-chi/chi/v5"
+)
+
+type mockSimilarityRepo struct {
+	events []postgres.SimilarEvent
+	limit  int
+}
+
+func (m *mockSimilarityRepo) GetSimilar(ctx context.Context, eventID string, limit int) ([]postgres.SimilarEvent, error) {
+	m.limit = limit
+	return m.events, nil
+}
+
+func similarRequest(id, query string) *http.Request {
+	req := httptest.NewRequest("GET", "/api/feed/events/"+id+"/similar"+query, nil)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestSimilarHandler_GetSimilar(t *testing.T) {
+	repo := &mockSimilarityRepo{
+		events: []postgres.SimilarEvent{
+			{EventID: "2", Title: "Jazz Night", Score: 0.8, CoJoined: 3},
+		},
+	}
+	h := NewSimilarHandler(repo)
+
+	rr := httptest.NewRecorder()
+	h.GetSimilar(rr, similarRequest("7b0d3c52-2a5e-4b1f-9d3e-0c7a1f2b3c4d", "?limit=4"))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if repo.limit != 4 {
+		t.Errorf("expected limit 4, got %d", repo.limit)
+	}
+
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	items, ok := resp["items"].([]interface{})
+	if !ok || len(items) != 1 {
+		t.Fatalf("expected 1 item, got %v", resp["items"])
+	}
+}
+
+func TestSimilarHandler_EmptyIsArray(t *testing.T) {
+	h := NewSimilarHandler(&mockSimilarityRepo{})
+
+	rr := httptest.NewRecorder()
+	h.GetSimilar(rr, similarRequest("7b0d3c52-2a5e-4b1f-9d3e-0c7a1f2b3c4d", ""))
+
+	var resp map[string]interface{}
+	json.Unmarshal(rr.Body.Bytes(), &resp)
+	if _, ok := resp["items"].([]interface{}); !ok {
+		t.Errorf("expected empty items array, got %v", resp["items"])
+	}
+}
+
+func TestSimilarHandler_InvalidID(t *testing.T) {
+	h := NewSimilarHandler(&mockSimilarityRepo{})
+
+	rr := httptest.NewRecorder()
+	h.GetSimilar(rr, similarRequest("not-a-uuid", ""))
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
@@ -9,9 +9,10 @@ import (
 	"github.com/baechuer/real-time-ressys/services/feed-service/internal/middleware"
 	"github.com/go-chi/chi/v5"
 	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
-func NewRouter(cfg *config.Config, trackHandler *handlers.TrackHandler, feedHandler *handlers.FeedHandler) http.Handler {
+func NewRouter(cfg *config.Config, trackHandler *handlers.TrackHandler, feedHandler *handlers.FeedHandler, identityHandler *handlers.IdentityHandler, similarHandler *handlers.SimilarHandler) http.Handler {
 	r := chi.NewRouter()
 
 	// Base middleware
@@ -29,6 +30,9 @@ func NewRouter(cfg *config.Config, trackHandler *handlers.TrackHandler, feedHand
 	actorLimiter := middleware.NewRateLimiter(cfg.RateLimitPerActor, time.Minute)
 	ipLimiter := middleware.NewRateLimiter(cfg.RateLimitPerIP, time.Minute)
 
+	// Operational endpoints
+	r.Handle("/metrics", promhttp.Handler())
+
 	// Routes
 	r.Route("/api/feed", func(r chi.Router) {
 		// Public routes
@@ -42,6 +46,12 @@ func NewRouter(cfg *config.Config, trackHandler *handlers.TrackHandler, feedHand
 
 		// Feed endpoint
 		r.Get("/", feedHandler.GetFeed)
+
+		// Item-to-item recommendations for the event detail page
+		r.Get("/events/{id}/similar", similarHandler.GetSimilar)
+
+		// Internal: service-to-service only
+		r.With(middleware.InternalAuth(cfg.InternalSecretKey)).Post("/identity/merge", identityHandler.Merge)
 	})
 
 	return r
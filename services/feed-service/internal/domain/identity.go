@@ -0,0 +1,21 @@
+package domain
+
+import "errors"
+
+// ErrAnonAlreadyMerged is returned when an anonymous actor was already merged
+// into a different user.
+var ErrAnonAlreadyMerged = errors.New("anonymous actor already merged into another user")
+
+// MergeResult summarizes an anonymous → user actor merge
+type MergeResult struct {
+	AnonKey       string `json:"anon_key"`
+	UserKey       string `json:"user_key"`
+	EventsMoved   int64  `json:"events_moved"`
+	EventsDropped int64  `json:"events_dropped"` // duplicates of rows the user already had
+	TagsMerged    int64  `json:"tags_merged"`
+}
+
+// MergeRequest is the API request for /identity/merge
+type MergeRequest struct {
+	AnonCookie string `json:"anon_cookie"` // signed anon_id cookie value
+}
@@ -0,0 +1,57 @@
+package domain
+
+import "time"
+
+// IndexedEvent is the event-service projection stored in event_index
+type IndexedEvent struct {
+	EventID       string
+	OwnerID       string
+	Title         string
+	Description   string
+	City          string
+	Category      string
+	StartTime     time.Time
+	Status        string
+	CoverImageIDs []string
+}
+
+// EventTags derives event_index.tags. Events carry no free-form tags yet, so
+// the category is the only one.
+func EventTags(category string) []string {
+	return []string{category}
+}
+
+// EventTombstone marks an event that is no longer published upstream
+type EventTombstone struct {
+	EventID string
+	Status  string // draft, canceled, ...
+}
+
+// ReconcileResult summarizes one event_index reconciliation run
+type ReconcileResult struct {
+	Full       bool
+	Scanned    int   // change-feed rows read from event-service
+	Missing    int64 // published upstream, absent locally
+	Stale      int64 // present locally with different fields
+	Tombstoned int64 // local status behind a non-published upstream status
+	Orphaned   int64 // published locally, unknown upstream (full runs only)
+	Watermark  time.Time
+}
+
+// Drift is the number of event_index rows that disagreed with event-service
+func (r ReconcileResult) Drift() int64 {
+	return r.Missing + r.Stale + r.Tombstoned + r.Orphaned
+}
+
+// StatusUnlisted is stored for published events that are unlisted or
+// invite-only, so queries on status = 'published' never surface them.
+const StatusUnlisted = "unlisted"
+
+// IndexStatus maps an event-service status and visibility onto
+// event_index.status. An empty visibility predates the field and is public.
+func IndexStatus(status, visibility string) string {
+	if status == "published" && visibility != "" && visibility != "public" {
+		return StatusUnlisted
+	}
+	return status
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"math"
+	"time"
+)
+
+// ProfileSignalWeight returns how much a single track event contributes to
+// the tag affinity of the actor. Joins are the strongest signal.
+func ProfileSignalWeight(eventType string) float64 {
+	if eventType == "join" {
+		return 1.0
+	}
+	return 0.3
+}
+
+// DecayFactor returns the exponential decay multiplier for a weight of the
+// given age: 1.0 for a fresh signal, 0.5 after one half-life, and so on.
+func DecayFactor(age, halfLife time.Duration) float64 {
+	if age <= 0 || halfLife <= 0 {
+		return 1.0
+	}
+	return math.Exp(-math.Ln2 * age.Seconds() / halfLife.Seconds())
+}
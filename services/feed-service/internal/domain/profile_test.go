@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestProfileSignalWeight(t *testing.T) {
+	if w := ProfileSignalWeight("join"); w != 1.0 {
+		t.Errorf("expected join weight 1.0, got %v", w)
+	}
+	if w := ProfileSignalWeight("view"); w != 0.3 {
+		t.Errorf("expected view weight 0.3, got %v", w)
+	}
+}
+
+func TestDecayFactor(t *testing.T) {
+	halfLife := 14 * 24 * time.Hour
+
+	if f := DecayFactor(0, halfLife); f != 1.0 {
+		t.Errorf("expected no decay for fresh signal, got %v", f)
+	}
+	if f := DecayFactor(halfLife, halfLife); math.Abs(f-0.5) > 1e-9 {
+		t.Errorf("expected 0.5 after one half-life, got %v", f)
+	}
+	if f := DecayFactor(2*halfLife, halfLife); math.Abs(f-0.25) > 1e-9 {
+		t.Errorf("expected 0.25 after two half-lives, got %v", f)
+	}
+	if f := DecayFactor(time.Hour, 0); f != 1.0 {
+		t.Errorf("expected decay disabled for zero half-life, got %v", f)
+	}
+}
@@ -0,0 +1,140 @@
+package eventservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/domain"
+)
+
+// MaxBatch is the largest id list POST /event/v1/events/batch accepts
+const MaxBatch = 50
+
+// Client reads events from event-service for reconciliation
+type Client struct {
+	baseURL        string
+	internalSecret string
+	http           *http.Client
+}
+
+func NewClient(baseURL, internalSecret string) *Client {
+	return &Client{
+		baseURL:        baseURL,
+		internalSecret: internalSecret,
+		http:           &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Change is one row of event-service's change feed
+type Change struct {
+	ID        string    `json:"id"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ChangesPage struct {
+	Items      []Change `json:"items"`
+	NextCursor string   `json:"next_cursor"`
+}
+
+type eventResp struct {
+	ID            string    `json:"id"`
+	OwnerID       string    `json:"owner_id"`
+	Title         string    `json:"title"`
+	Description   string    `json:"description"`
+	City          string    `json:"city"`
+	Category      string    `json:"category"`
+	StartTime     time.Time `json:"start_time"`
+	Status        string    `json:"status"`
+	Visibility    string    `json:"visibility"`
+	CoverImageIDs []string  `json:"cover_image_ids"`
+}
+
+// Changes fetches one page of events updated at or after since. A non-empty
+// cursor continues a previous page and overrides since.
+func (c *Client) Changes(ctx context.Context, since time.Time, cursor string, limit int) (*ChangesPage, error) {
+	q := url.Values{}
+	if !since.IsZero() {
+		q.Set("since", since.UTC().Format(time.RFC3339Nano))
+	}
+	if cursor != "" {
+		q.Set("cursor", cursor)
+	}
+	q.Set("limit", strconv.Itoa(limit))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/event/v1/internal/events/changes?"+q.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Internal-Secret", c.internalSecret)
+
+	var page ChangesPage
+	if err := c.do(req, &page); err != nil {
+		return nil, err
+	}
+	return &page, nil
+}
+
+// GetBatch returns the published events among ids, keyed by id. Ids that are
+// not (or no longer) published are absent from the result; unlisted and
+// invite-only events come back with status "unlisted".
+func (c *Client) GetBatch(ctx context.Context, ids []string) (map[string]domain.IndexedEvent, error) {
+	if len(ids) > MaxBatch {
+		return nil, fmt.Errorf("batch of %d exceeds max %d", len(ids), MaxBatch)
+	}
+
+	body, err := json.Marshal(map[string][]string{"event_ids": ids})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/event/v1/events/batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var resp map[string]eventResp
+	if err := c.do(req, &resp); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]domain.IndexedEvent, len(resp))
+	for id, e := range resp {
+		out[id] = domain.IndexedEvent{
+			EventID:       e.ID,
+			OwnerID:       e.OwnerID,
+			Title:         e.Title,
+			Description:   e.Description,
+			City:          e.City,
+			Category:      e.Category,
+			StartTime:     e.StartTime,
+			Status:        domain.IndexStatus(e.Status, e.Visibility),
+			CoverImageIDs: e.CoverImageIDs,
+		}
+	}
+	return out, nil
+}
+
+// do executes req and decodes event-service's {"data": ...} envelope into out
+func (c *Client) do(req *http.Request, out interface{}) error {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("event-service %s %s: status %d", req.Method, req.URL.Path, resp.StatusCode)
+	}
+
+	env := struct {
+		Data interface{} `json:"data"`
+	}{Data: out}
+	return json.NewDecoder(resp.Body).Decode(&env)
+}
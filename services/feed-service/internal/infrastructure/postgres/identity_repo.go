@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// mergeWindowDays bounds how much anonymous history is re-attributed.
+// Matches the window RebuildProfile reads from user_events.
+const mergeWindowDays = 30
+
+// IdentityRepo re-attributes anonymous activity to a signed-in user
+type IdentityRepo struct {
+	pool     *pgxpool.Pool
+	profiles *ProfileRepo
+}
+
+func NewIdentityRepo(pool *pgxpool.Pool, profiles *ProfileRepo) *IdentityRepo {
+	return &IdentityRepo{pool: pool, profiles: profiles}
+}
+
+// MergeActor moves recent user_events, pending outbox rows and the tag profile
+// of anonKey to userKey in one transaction. It is safe to call repeatedly for
+// the same pair; an anonKey already merged into another user is rejected.
+func (r *IdentityRepo) MergeActor(ctx context.Context, anonKey, userKey string) (*domain.MergeResult, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock order matches ProcessOutbox: profile config first. Waits for a
+	// running rebuild to swap rather than merging into the stale table.
+	table, _, err := r.profiles.lockActiveTable(ctx, tx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. Claim the anon key. Row lock serializes concurrent merges of it.
+	var owner string
+	err = tx.QueryRow(ctx, `
+		INSERT INTO actor_merges (anon_key, user_key)
+		VALUES ($1, $2)
+		ON CONFLICT (anon_key) DO UPDATE SET last_merged_at = NOW()
+		RETURNING user_key
+	`, anonKey, userKey).Scan(&owner)
+	if err != nil {
+		return nil, err
+	}
+	if owner != userKey {
+		return nil, domain.ErrAnonAlreadyMerged
+	}
+
+	res := &domain.MergeResult{AnonKey: anonKey, UserKey: userKey}
+
+	// 2. Re-attribute events the user does not already have. Same
+	// (event_id, event_type, bucket_date) under both keys would violate
+	// ux_user_events_dedup, so those anon rows are dropped instead.
+	tag, err := tx.Exec(ctx, `
+		UPDATE user_events ue SET actor_key = $2
+		WHERE ue.actor_key = $1
+			AND ue.bucket_date > CURRENT_DATE - $3::int
+			AND NOT EXISTS (
+				SELECT 1 FROM user_events u
+				WHERE u.actor_key = $2
+					AND u.event_id = ue.event_id
+					AND u.event_type = ue.event_type
+					AND u.bucket_date = ue.bucket_date
+			)
+	`, anonKey, userKey, mergeWindowDays)
+	if err != nil {
+		return nil, err
+	}
+	res.EventsMoved = tag.RowsAffected()
+
+	tag, err = tx.Exec(ctx, `
+		DELETE FROM user_events WHERE actor_key = $1 AND bucket_date > CURRENT_DATE - $2::int
+	`, anonKey, mergeWindowDays)
+	if err != nil {
+		return nil, err
+	}
+	res.EventsDropped = tag.RowsAffected()
+
+	// 3. Events still in the outbox will be attributed to the user when moved
+	_, err = tx.Exec(ctx, `
+		UPDATE track_outbox SET actor_key = $2 WHERE actor_key = $1 AND processed_at IS NULL
+	`, anonKey, userKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. Fold the tag profile
+	res.TagsMerged, err = r.profiles.mergeActor(ctx, tx, table, anonKey, userKey)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE actor_merges
+		SET events_moved = events_moved + $2, tags_merged = tags_merged + $3
+		WHERE anon_key = $1
+	`, anonKey, res.EventsMoved, res.TagsMerged)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/domain"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// reconcileLockKey is the advisory lock held by the replica running a
+// reconciliation, so only one of them pages through event-service at a time.
+const reconcileLockKey = 0x66656564 // "feed"
+
+// IndexRepo reconciles event_index with event-service
+type IndexRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewIndexRepo(pool *pgxpool.Pool) *IndexRepo {
+	return &IndexRepo{pool: pool}
+}
+
+// TryLock takes the reconciliation advisory lock on a dedicated connection.
+// ok is false when another replica holds it; otherwise unlock must be called.
+func (r *IndexRepo) TryLock(ctx context.Context) (unlock func(), ok bool, err error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, reconcileLockKey).Scan(&ok); err != nil {
+		conn.Release()
+		return nil, false, err
+	}
+	if !ok {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	return func() {
+		_, _ = conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, reconcileLockKey)
+		conn.Release()
+	}, true, nil
+}
+
+// GetWatermark returns the last fully applied change-feed position.
+// Zero means the index was never reconciled.
+func (r *IndexRepo) GetWatermark(ctx context.Context) (time.Time, error) {
+	var wm *time.Time
+	err := r.pool.QueryRow(ctx, `SELECT watermark FROM event_index_sync_state WHERE id = 1`).Scan(&wm)
+	if err != nil || wm == nil {
+		return time.Time{}, err
+	}
+	return *wm, nil
+}
+
+// SaveRun records a completed run
+func (r *IndexRepo) SaveRun(ctx context.Context, res domain.ReconcileResult) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE event_index_sync_state SET
+			watermark = GREATEST(watermark, $1),
+			last_run_at = NOW(),
+			last_full_at = CASE WHEN $2 THEN NOW() ELSE last_full_at END,
+			last_drift = $3
+		WHERE id = 1
+	`, res.Watermark, res.Full, res.Drift())
+	return err
+}
+
+// UpsertEvents writes published events and reports how many were missing and
+// how many differed from the local copy. synced_at is bumped on every row so
+// a full run can find rows it did not see.
+func (r *IndexRepo) UpsertEvents(ctx context.Context, events []domain.IndexedEvent) (missing, stale int64, err error) {
+	if len(events) == 0 {
+		return 0, 0, nil
+	}
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	now := time.Now()
+	for _, e := range events {
+		// prev is evaluated against the snapshot before the upsert
+		var inserted, changed bool
+		err := tx.QueryRow(ctx, `
+			WITH prev AS (
+				SELECT title, owner_id, city, tags, start_time, status, cover_image_ids, description
+				FROM event_index WHERE event_id = $1
+			)
+			INSERT INTO event_index (event_id, title, owner_id, city, tags, start_time, status, cover_image_ids, synced_at, description)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			ON CONFLICT (event_id) DO UPDATE SET
+				title = EXCLUDED.title,
+				description = EXCLUDED.description,
+				owner_id = EXCLUDED.owner_id,
+				city = EXCLUDED.city,
+				tags = EXCLUDED.tags,
+				start_time = EXCLUDED.start_time,
+				status = EXCLUDED.status,
+				cover_image_ids = EXCLUDED.cover_image_ids,
+				synced_at = EXCLUDED.synced_at
+			RETURNING
+				NOT EXISTS (SELECT 1 FROM prev),
+				EXISTS (
+					SELECT 1 FROM prev p
+					WHERE (p.title, p.owner_id, p.city, p.tags, p.start_time, p.status, p.cover_image_ids, p.description)
+						IS DISTINCT FROM ($2::text, $3::text, $4::text, $5::text[], $6::timestamptz, $7::text, $8::text[], $10::text)
+				)
+		`, e.EventID, e.Title, e.OwnerID, e.City, domain.EventTags(e.Category), e.StartTime, e.Status, e.CoverImageIDs, now, e.Description).Scan(&inserted, &changed)
+		if err != nil {
+			return 0, 0, err
+		}
+		if inserted {
+			missing++
+		} else if changed {
+			stale++
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, err
+	}
+	return missing, stale, nil
+}
+
+// Tombstone copies the upstream status onto local rows that are no longer
+// published. Events never indexed are left alone.
+func (r *IndexRepo) Tombstone(ctx context.Context, tombstones []domain.EventTombstone) (int64, error) {
+	if len(tombstones) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, 0, len(tombstones))
+	statuses := make([]string, 0, len(tombstones))
+	for _, t := range tombstones {
+		ids = append(ids, t.EventID)
+		statuses = append(statuses, t.Status)
+	}
+
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE event_index e SET status = t.status, synced_at = NOW()
+		FROM unnest($1::uuid[], $2::text[]) AS t(event_id, status)
+		WHERE e.event_id = t.event_id AND e.status IS DISTINCT FROM t.status
+	`, ids, statuses)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// SweepOrphans tombstones published rows a full run did not confirm, i.e.
+// events event-service no longer knows about.
+func (r *IndexRepo) SweepOrphans(ctx context.Context, runStart time.Time) (int64, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE event_index SET status = 'deleted', synced_at = NOW()
+		WHERE status = 'published' AND synced_at < $1
+	`, runStart)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
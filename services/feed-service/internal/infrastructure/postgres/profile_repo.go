@@ -2,24 +2,201 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/domain"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+const (
+	defaultProfileHalfLife = 14 * 24 * time.Hour
+	defaultProfileMaxTags  = 50
+
+	// Weights that decayed below this are dropped during compaction.
+	profileMinWeight = 0.01
+)
+
 // ProfileRepo handles user tag profile with VIEW swap
 type ProfileRepo struct {
-	pool *pgxpool.Pool
+	pool     *pgxpool.Pool
+	halfLife time.Duration
+	maxTags  int
 }
 
-func NewProfileRepo(pool *pgxpool.Pool) *ProfileRepo {
-	return &ProfileRepo{pool: pool}
+func NewProfileRepo(pool *pgxpool.Pool, halfLife time.Duration, maxTags int) *ProfileRepo {
+	if halfLife <= 0 {
+		halfLife = defaultProfileHalfLife
+	}
+	if maxTags <= 0 {
+		maxTags = defaultProfileMaxTags
+	}
+	return &ProfileRepo{pool: pool, halfLife: halfLife, maxTags: maxTags}
 }
 
-// RebuildProfile rebuilds user_tag_profile using VIEW swap pattern
+// decayedWeightSQL returns the weight of a profile row decayed to NOW().
+// Weights are stored as of updated_at; param holds the half-life in seconds.
+func decayedWeightSQL(param string) string {
+	return `weight * exp(-ln(2) * GREATEST(EXTRACT(EPOCH FROM (NOW() - updated_at)), 0) / ` + param + `)`
+}
+
+// lockActiveTable returns the backing table incremental updates should write
+// to. It takes a shared lock on the config row so a concurrent swap waits for
+// the caller's transaction. Unless wait is set, ok is false while a full
+// rebuild or compaction holds the row; callers should retry on their next tick.
+func (r *ProfileRepo) lockActiveTable(ctx context.Context, tx pgx.Tx, wait bool) (string, bool, error) {
+	query := `SELECT active_table FROM user_tag_profile_config WHERE id = 1 FOR SHARE`
+	if !wait {
+		query += ` SKIP LOCKED`
+	}
+
+	var active string
+	err := tx.QueryRow(ctx, query).Scan(&active)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, err
+	}
+	return "user_tag_profile_" + active, true, nil
+}
+
+// applyEvents folds newly recorded track events into the active profile table.
+// Existing weights are decayed to now before the new signal is added.
+func (r *ProfileRepo) applyEvents(ctx context.Context, tx pgx.Tx, table string, events []domain.TrackEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	actorKeys := make([]string, 0, len(events))
+	eventIDs := make([]string, 0, len(events))
+	weights := make([]float64, 0, len(events))
+	for _, e := range events {
+		actorKeys = append(actorKeys, e.ActorKey)
+		eventIDs = append(eventIDs, e.EventID.String())
+		weights = append(weights, domain.ProfileSignalWeight(e.EventType)*domain.DecayFactor(now.Sub(e.OccurredAt), r.halfLife))
+	}
+
+	_, err := tx.Exec(ctx, `
+		INSERT INTO `+table+` AS p (actor_key, tag, weight, updated_at)
+		SELECT d.actor_key, t.tag, SUM(d.weight), NOW()
+		FROM unnest($1::text[], $2::uuid[], $3::float8[]) AS d(actor_key, event_id, weight)
+		JOIN event_index e ON e.event_id = d.event_id
+		CROSS JOIN LATERAL unnest(e.tags) AS t(tag)
+		WHERE t.tag IS NOT NULL AND t.tag <> ''
+		GROUP BY d.actor_key, t.tag
+		ON CONFLICT (actor_key, tag) DO UPDATE SET
+			weight = p.weight * exp(-ln(2) * GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - p.updated_at)), 0) / $4) + EXCLUDED.weight,
+			updated_at = EXCLUDED.updated_at
+	`, actorKeys, eventIDs, weights, r.halfLife.Seconds())
+	if err != nil {
+		return err
+	}
+
+	_, err = r.capTags(ctx, tx, table, actorKeys)
+	return err
+}
+
+// mergeActor folds every tag of fromKey into toKey and removes fromKey's rows.
+// Both sides are decayed to now before adding, so merging twice is a no-op.
+func (r *ProfileRepo) mergeActor(ctx context.Context, tx pgx.Tx, table, fromKey, toKey string) (int64, error) {
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO `+table+` AS p (actor_key, tag, weight, updated_at)
+		SELECT $2, tag, `+decayedWeightSQL("$3")+`, NOW()
+		FROM `+table+`
+		WHERE actor_key = $1
+		ON CONFLICT (actor_key, tag) DO UPDATE SET
+			weight = p.weight * exp(-ln(2) * GREATEST(EXTRACT(EPOCH FROM (EXCLUDED.updated_at - p.updated_at)), 0) / $3) + EXCLUDED.weight,
+			updated_at = EXCLUDED.updated_at
+	`, fromKey, toKey, r.halfLife.Seconds())
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE actor_key = $1`, fromKey); err != nil {
+		return 0, err
+	}
+
+	if _, err := r.capTags(ctx, tx, table, []string{toKey}); err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// capTags keeps only the strongest maxTags tags per actor. A nil actorKeys
+// applies the cap to every actor in the table. Returns the number of rows removed.
+func (r *ProfileRepo) capTags(ctx context.Context, tx pgx.Tx, table string, actorKeys []string) (int64, error) {
+	query := `
+		DELETE FROM ` + table + ` p
+		USING (
+			SELECT actor_key, tag,
+				row_number() OVER (PARTITION BY actor_key ORDER BY ` + decayedWeightSQL("$2") + ` DESC, tag) AS rn
+			FROM ` + table + `
+			WHERE $3::text[] IS NULL OR actor_key = ANY($3)
+		) ranked
+		WHERE p.actor_key = ranked.actor_key AND p.tag = ranked.tag AND ranked.rn > $1
+	`
+	tag, err := tx.Exec(ctx, query, r.maxTags, r.halfLife.Seconds(), actorKeys)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// Compact materializes decayed weights, drops tags that faded below the
+// minimum weight and re-applies the per-actor tag cap. Returns the total
+// number of rows removed by both steps. Runs weekly.
+func (r *ProfileRepo) Compact(ctx context.Context) (int64, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// Exclusive lock: outbox processing skips its profile step meanwhile.
+	var active string
+	err = tx.QueryRow(ctx, `SELECT active_table FROM user_tag_profile_config WHERE id = 1 FOR UPDATE`).Scan(&active)
+	if err != nil {
+		return 0, err
+	}
+	table := "user_tag_profile_" + active
+
+	_, err = tx.Exec(ctx, `UPDATE `+table+` SET weight = `+decayedWeightSQL("$1")+`, updated_at = NOW()`, r.halfLife.Seconds())
+	if err != nil {
+		return 0, err
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE weight < $1`, profileMinWeight)
+	if err != nil {
+		return 0, err
+	}
+
+	capped, err := r.capTags(ctx, tx, table, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected() + capped, nil
+}
+
+// RebuildProfile rebuilds user_tag_profile from user_events using VIEW swap pattern.
+// Profiles are maintained incrementally; this is the repair path.
 func (r *ProfileRepo) RebuildProfile(ctx context.Context) error {
-	// 1. Get current active table
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// 1. Get current active table. Holding the row lock for the whole rebuild
+	// pauses incremental updates, so no events slip between rebuild and swap.
 	var activeTable string
-	err := r.pool.QueryRow(ctx, `SELECT active_table FROM user_tag_profile_config WHERE id = 1`).Scan(&activeTable)
+	err = tx.QueryRow(ctx, `SELECT active_table FROM user_tag_profile_config WHERE id = 1 FOR UPDATE`).Scan(&activeTable)
 	if err != nil {
 		return err
 	}
@@ -32,34 +209,37 @@ func (r *ProfileRepo) RebuildProfile(ctx context.Context) error {
 	nextTableName := "user_tag_profile_" + nextTable
 
 	// 3. Truncate and rebuild next table
-	_, err = r.pool.Exec(ctx, `TRUNCATE `+nextTableName)
+	_, err = tx.Exec(ctx, `TRUNCATE `+nextTableName)
 	if err != nil {
 		return err
 	}
 
-	_, err = r.pool.Exec(ctx, `
+	_, err = tx.Exec(ctx, `
 		INSERT INTO `+nextTableName+` (actor_key, tag, weight, updated_at)
-		SELECT 
+		SELECT
 			ue.actor_key,
-			unnest(e.tags) as tag,
-			SUM(CASE WHEN ue.event_type = 'join' THEN 1.0 ELSE 0.3 END) as weight,
+			t.tag,
+			SUM(
+				CASE WHEN ue.event_type = 'join' THEN $1::float8 ELSE $2::float8 END
+				* exp(-ln(2) * GREATEST(EXTRACT(EPOCH FROM (NOW() - ue.occurred_at)), 0) / $3)
+			) as weight,
 			NOW()
 		FROM user_events ue
 		JOIN event_index e ON ue.event_id = e.event_id
+		CROSS JOIN LATERAL unnest(e.tags) AS t(tag)
 		WHERE ue.bucket_date > CURRENT_DATE - 30
-		GROUP BY ue.actor_key, tag
-	`)
+			AND t.tag IS NOT NULL AND t.tag <> ''
+		GROUP BY ue.actor_key, t.tag
+	`, domain.ProfileSignalWeight("join"), domain.ProfileSignalWeight("view"), r.halfLife.Seconds())
 	if err != nil {
 		return err
 	}
 
-	// 4. Atomic swap: update view and config in transaction
-	tx, err := r.pool.Begin(ctx)
-	if err != nil {
+	if _, err := r.capTags(ctx, tx, nextTableName, nil); err != nil {
 		return err
 	}
-	defer tx.Rollback(ctx)
 
+	// 4. Atomic swap: update view and config in the same transaction
 	_, err = tx.Exec(ctx, `DROP VIEW IF EXISTS user_tag_profile`)
 	if err != nil {
 		return err
@@ -78,9 +258,11 @@ func (r *ProfileRepo) RebuildProfile(ctx context.Context) error {
 	return tx.Commit(ctx)
 }
 
-// GetUserProfile returns tag weights for a user
+// GetUserProfile returns decayed tag weights for a user
 func (r *ProfileRepo) GetUserProfile(ctx context.Context, actorKey string) (map[string]float64, error) {
-	rows, err := r.pool.Query(ctx, `SELECT tag, weight FROM user_tag_profile WHERE actor_key = $1`, actorKey)
+	rows, err := r.pool.Query(ctx, `
+		SELECT tag, `+decayedWeightSQL("$2")+` FROM user_tag_profile WHERE actor_key = $1
+	`, actorKey, r.halfLife.Seconds())
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Blend of signals in the similarity score
+const (
+	similarityCoWeight   = 0.6 // cosine of co-interaction vectors
+	similarityTagWeight  = 0.3 // Jaccard of tags
+	similarityCityWeight = 0.1 // same city
+	similarityTopK       = 20  // neighbours kept per event
+)
+
+// SimilarityRepo handles item-to-item recommendations
+type SimilarityRepo struct {
+	pool *pgxpool.Pool
+}
+
+func NewSimilarityRepo(pool *pgxpool.Pool) *SimilarityRepo {
+	return &SimilarityRepo{pool: pool}
+}
+
+type SimilarEvent struct {
+	EventID       string    `json:"id"`
+	Title         string    `json:"title"`
+	City          string    `json:"city"`
+	Tags          []string  `json:"tags"`
+	StartTime     time.Time `json:"start_time"`
+	CoverImageIDs []string  `json:"cover_image_ids"`
+	Score         float64   `json:"score"`
+	CoJoined      int       `json:"co_joined"` // "people who joined this also joined"
+}
+
+// RebuildSimilarity recomputes the top-K neighbours of every upcoming published
+// event. Co-interaction comes from 30 days of joins/views; content similarity
+// from shared tags within the same city.
+func (r *SimilarityRepo) RebuildSimilarity(ctx context.Context) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Readers keep seeing the previous model until commit
+	if _, err := tx.Exec(ctx, `DELETE FROM event_similarity`); err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		WITH candidates AS (
+			SELECT event_id, city, tags
+			FROM event_index
+			WHERE status = 'published' AND start_time > NOW()
+		),
+		signals AS (
+			SELECT
+				ue.actor_key,
+				ue.event_id,
+				MAX(CASE WHEN ue.event_type = 'join' THEN 1.0 ELSE 0.3 END) AS w,
+				bool_or(ue.event_type = 'join') AS joined
+			FROM user_events ue
+			JOIN candidates c ON c.event_id = ue.event_id
+			WHERE ue.bucket_date > CURRENT_DATE - 30
+				AND ue.event_type IN ('join', 'view')
+			GROUP BY ue.actor_key, ue.event_id
+		),
+		norms AS (
+			SELECT event_id, sqrt(SUM(w * w)) AS norm FROM signals GROUP BY event_id
+		),
+		co AS (
+			SELECT
+				a.event_id,
+				b.event_id AS similar_event_id,
+				($1::float8 * SUM(a.w * b.w) / (na.norm * nb.norm))::float8 AS score,
+				COUNT(*) FILTER (WHERE a.joined AND b.joined) AS co_joined
+			FROM signals a
+			JOIN signals b ON b.actor_key = a.actor_key AND b.event_id <> a.event_id
+			JOIN norms na ON na.event_id = a.event_id
+			JOIN norms nb ON nb.event_id = b.event_id
+			GROUP BY a.event_id, b.event_id, na.norm, nb.norm
+		),
+		content AS (
+			SELECT
+				s.event_id,
+				c.event_id AS similar_event_id,
+				$2::float8 * cardinality(ARRAY(SELECT unnest(s.tags) INTERSECT SELECT unnest(c.tags)))::float8
+					/ GREATEST(cardinality(ARRAY(SELECT unnest(s.tags) UNION SELECT unnest(c.tags))), 1)
+					+ $3::float8 AS score,
+				0 AS co_joined
+			FROM candidates s
+			JOIN candidates c ON c.event_id <> s.event_id AND c.city = s.city AND c.tags && s.tags
+		),
+		ranked AS (
+			SELECT
+				p.event_id,
+				p.similar_event_id,
+				SUM(p.score) AS score,
+				SUM(p.co_joined) AS co_joined,
+				row_number() OVER (PARTITION BY p.event_id ORDER BY SUM(p.score) DESC, p.similar_event_id) AS rn
+			FROM (
+				SELECT event_id, similar_event_id, score, co_joined FROM co
+				UNION ALL
+				SELECT event_id, similar_event_id, score, co_joined FROM content
+			) p
+			GROUP BY p.event_id, p.similar_event_id
+		)
+		INSERT INTO event_similarity (event_id, similar_event_id, score, co_joined, updated_at)
+		SELECT event_id, similar_event_id, score, co_joined, NOW()
+		FROM ranked
+		WHERE rn <= $4
+	`, similarityCoWeight, similarityTagWeight, similarityCityWeight, similarityTopK)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// GetSimilar returns the nearest upcoming events to eventID. Events not yet
+// covered by the last rebuild fall back to live tag/city matching.
+func (r *SimilarityRepo) GetSimilar(ctx context.Context, eventID string, limit int) ([]SimilarEvent, error) {
+	events, err := r.querySimilar(ctx, `
+		SELECT e.event_id, e.title, e.city, e.tags, e.start_time, e.cover_image_ids, s.score, s.co_joined
+		FROM event_similarity s
+		JOIN event_index e ON e.event_id = s.similar_event_id
+		WHERE s.event_id = $1 AND e.status = 'published' AND e.start_time > NOW()
+		ORDER BY s.score DESC, e.event_id
+		LIMIT $2
+	`, eventID, limit)
+	if err != nil || len(events) > 0 {
+		return events, err
+	}
+
+	return r.querySimilar(ctx, `
+		SELECT
+			e.event_id, e.title, e.city, e.tags, e.start_time, e.cover_image_ids,
+			$3::float8 * cardinality(ARRAY(SELECT unnest(src.tags) INTERSECT SELECT unnest(e.tags)))::float8
+				/ GREATEST(cardinality(ARRAY(SELECT unnest(src.tags) UNION SELECT unnest(e.tags))), 1)
+				+ CASE WHEN e.city = src.city THEN $4::float8 ELSE 0 END AS score,
+			0
+		FROM event_index src
+		JOIN event_index e ON e.event_id <> src.event_id AND (e.tags && src.tags OR e.city = src.city)
+		WHERE src.event_id = $1 AND e.status = 'published' AND e.start_time > NOW()
+		ORDER BY score DESC, e.start_time ASC, e.event_id
+		LIMIT $2
+	`, eventID, limit, similarityTagWeight, similarityCityWeight)
+}
+
+func (r *SimilarityRepo) querySimilar(ctx context.Context, query string, args ...interface{}) ([]SimilarEvent, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []SimilarEvent
+	for rows.Next() {
+		var e SimilarEvent
+		if err := rows.Scan(&e.EventID, &e.Title, &e.City, &e.Tags, &e.StartTime, &e.CoverImageIDs, &e.Score, &e.CoJoined); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -10,11 +10,14 @@ import (
 )
 
 type TrackRepo struct {
-	pool *pgxpool.Pool
+	pool     *pgxpool.Pool
+	profiles *ProfileRepo
 }
 
-func NewTrackRepo(pool *pgxpool.Pool) *TrackRepo {
-	return &TrackRepo{pool: pool}
+// NewTrackRepo creates a TrackRepo. When profiles is non-nil, ProcessOutbox
+// also folds moved events into the user tag profiles.
+func NewTrackRepo(pool *pgxpool.Pool, profiles *ProfileRepo) *TrackRepo {
+	return &TrackRepo{pool: pool, profiles: profiles}
 }
 
 // InsertOutbox inserts a track event into the outbox table
@@ -26,7 +29,8 @@ func (r *TrackRepo) InsertOutbox(ctx context.Context, e domain.TrackEvent) error
 	return err
 }
 
-// ProcessOutbox moves events from outbox to user_events
+// ProcessOutbox moves events from outbox to user_events and updates the
+// actors' tag profiles in the same transaction
 func (r *TrackRepo) ProcessOutbox(ctx context.Context, batchSize int) (int, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
@@ -34,6 +38,20 @@ func (r *TrackRepo) ProcessOutbox(ctx context.Context, batchSize int) (int, erro
 	}
 	defer tx.Rollback(ctx)
 
+	// Resolve the active profile table first. While a rebuild or compaction
+	// holds it, leave the outbox untouched; those events are applied after.
+	var profileTable string
+	if r.profiles != nil {
+		table, ok, err := r.profiles.lockActiveTable(ctx, tx, false)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			return 0, nil
+		}
+		profileTable = table
+	}
+
 	// Select unprocessed items
 	rows, err := tx.Query(ctx, `
 		SELECT id, actor_key, event_type, event_id, feed_type, position, request_id, bucket_date, created_at
@@ -74,15 +92,27 @@ func (r *TrackRepo) ProcessOutbox(ctx context.Context, batchSize int) (int, erro
 		`, e.ActorKey, e.EventType, e.EventID, e.FeedType, e.Position, e.RequestID, e.BucketDate, e.OccurredAt)
 	}
 
+	// Only rows that survived dedup count towards the profile
+	var inserted []domain.TrackEvent
 	br := tx.SendBatch(ctx, batch)
-	for range events {
-		if _, err := br.Exec(); err != nil {
+	for _, e := range events {
+		tag, err := br.Exec()
+		if err != nil {
 			br.Close()
 			return 0, err
 		}
+		if tag.RowsAffected() > 0 {
+			inserted = append(inserted, e)
+		}
 	}
 	br.Close()
 
+	if r.profiles != nil {
+		if err := r.profiles.applyEvents(ctx, tx, profileTable, inserted); err != nil {
+			return 0, err
+		}
+	}
+
 	// Mark as processed
 	_, err = tx.Exec(ctx, `
 		UPDATE track_outbox SET processed_at = $1 WHERE id = ANY($2)
@@ -98,12 +128,13 @@ func (r *TrackRepo) ProcessOutbox(ctx context.Context, batchSize int) (int, erro
 	return len(events), nil
 }
 
-func (r *TrackRepo) IndexEvent(ctx context.Context, eventID, ownerID, title, city, category string, startTime time.Time, status string, coverImageIDs []string) error {
+func (r *TrackRepo) IndexEvent(ctx context.Context, e domain.IndexedEvent) error {
 	query := `
-		INSERT INTO event_index (event_id, title, owner_id, city, tags, start_time, status, cover_image_ids, synced_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		INSERT INTO event_index (event_id, title, owner_id, city, tags, start_time, status, cover_image_ids, synced_at, description)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 		ON CONFLICT (event_id) DO UPDATE SET
 			title = EXCLUDED.title,
+			description = EXCLUDED.description,
 			city = EXCLUDED.city,
 			tags = EXCLUDED.tags,
 			start_time = EXCLUDED.start_time,
@@ -111,9 +142,23 @@ func (r *TrackRepo) IndexEvent(ctx context.Context, eventID, ownerID, title, cit
 			cover_image_ids = EXCLUDED.cover_image_ids,
 			synced_at = EXCLUDED.synced_at;
 	`
-	// Simple tag extraction for now: just category
-	tags := []string{category}
+	tags := domain.EventTags(e.Category)
 
-	_, err := r.pool.Exec(ctx, query, eventID, title, ownerID, city, tags, startTime, status, coverImageIDs, time.Now())
+	_, err := r.pool.Exec(ctx, query, e.EventID, e.Title, e.OwnerID, e.City, tags, e.StartTime, e.Status, e.CoverImageIDs, time.Now(), e.Description)
+	return err
+}
+
+// UpsertOrganizerRating stores an organizer's reputation snapshot unless a
+// newer one is already there (messages may arrive out of order).
+func (r *TrackRepo) UpsertOrganizerRating(ctx context.Context, ownerID string, avg float64, count int, updatedAt time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO organizer_ratings (owner_id, rating_avg, rating_count, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (owner_id) DO UPDATE SET
+			rating_avg = EXCLUDED.rating_avg,
+			rating_count = EXCLUDED.rating_count,
+			updated_at = EXCLUDED.updated_at
+		WHERE organizer_ratings.updated_at < EXCLUDED.updated_at
+	`, ownerID, avg, count, updatedAt)
 	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -44,62 +45,126 @@ func (r *TrendingRepo) RunAggregation(ctx context.Context) error {
 	return err
 }
 
-// GetTrending returns trending events with online score calculation
-func (r *TrendingRepo) GetTrending(ctx context.Context, city string, category string, queryStr string, limit int, afterScore float64, afterStartTime time.Time, afterID string) ([]TrendingEvent, error) {
-	fmt.Printf("GetTrending: limit=%d cursor=(score=%f, time=%v, id=%s)\n", limit, afterScore, afterStartTime, afterID)
-	query := `
-		SELECT 
-			e.event_id, e.title, e.city, e.tags, e.start_time, e.cover_image_ids,
-			(4.0 * COALESCE(ts.join_users_24h, 0) +
+// Search tuning. Relevance is ts_rank_cd normalized to [0, 1) plus trigram
+// word similarity against title/city for typo tolerance.
+const (
+	searchTrigramWeight    = 0.5
+	searchTrigramThreshold = 0.4 // pg_trgm.word_similarity_threshold for <%
+)
+
+// trendScoreSQL is the online trend score. The start-time boost is measured
+// from $1 (the cursor's as-of time) instead of NOW() so a score computed for
+// page 1 is reproduced exactly when page 2 is requested.
+const trendScoreSQL = `(4.0 * COALESCE(ts.join_users_24h, 0) +
 			 2.0 * COALESCE(ts.join_users_7d, 0) +
 			 0.5 * COALESCE(ts.view_users_24h, 0) +
-			 3.0 / (1 + EXTRACT(EPOCH FROM (e.start_time - NOW())) / 86400)
-			) AS trend_score
-		FROM event_index e
-		LEFT JOIN event_trend_stats ts ON e.event_id = ts.event_id
-		WHERE e.status = 'published' AND e.start_time > NOW()
-	`
-	args := []interface{}{}
-	argNum := 1
+			 3.0 / (1 + EXTRACT(EPOCH FROM (e.start_time - $1::timestamptz)) / 86400))`
+
+// ratingBoostSQL ranks by organizer reputation. The average is shrunk
+// towards a neutral 3 stars with the weight of 5 reviews, so a single rating
+// barely moves it; the result spans [-3, +3] and is 0 for unrated organizers.
+const ratingBoostSQL = `1.5 * ((COALESCE(orr.rating_avg, 0) * COALESCE(orr.rating_count, 0) + 5 * 3.0) /
+			 (COALESCE(orr.rating_count, 0) + 5) - 3.0)`
+
+// rankScoreSQL is the trend score plus the reputation boost
+const rankScoreSQL = `(` + trendScoreSQL + ` + ` + ratingBoostSQL + `)`
+
+// searchMatchSQL matches q (bound at param) on the weighted tsvector or, for
+// typos, on trigram word similarity. Both are index-backed.
+func searchMatchSQL(param string) string {
+	return `(e.search_vector @@ websearch_to_tsquery('simple', ` + param + `) OR ` + param + ` <% e.title OR ` + param + ` <% e.city)`
+}
+
+// searchRelevanceSQL scores how well an event matches q
+func searchRelevanceSQL(param string) string {
+	return `(ts_rank_cd(e.search_vector, websearch_to_tsquery('simple', ` + param + `), 32) +
+			 ` + fmt.Sprintf("%v", searchTrigramWeight) + ` * GREATEST(word_similarity(` + param + `, e.title), word_similarity(` + param + `, e.city)))`
+}
+
+// GetTrending returns trending events with online score calculation. With a
+// query, only matching events are returned and the score becomes
+// relevance * (1 + ln(1 + rank score)): text relevance dominates, trend and
+// reputation break ties between similarly relevant events.
+func (r *TrendingRepo) GetTrending(ctx context.Context, city string, category string, queryStr string, limit int, asOf time.Time, afterScore float64, afterStartTime time.Time, afterID string) ([]TrendingEvent, error) {
+	args := []interface{}{asOf}
+	argNum := 2
+
+	score := rankScoreSQL
+	where := ""
 
 	if city != "" {
-		query += fmt.Sprintf(" AND e.city = $%d", argNum)
+		where += fmt.Sprintf(" AND e.city = $%d", argNum)
 		args = append(args, city)
 		argNum++
 	}
 
 	if category != "" {
-		query += fmt.Sprintf(" AND $%d = ANY(e.tags)", argNum)
+		where += fmt.Sprintf(" AND $%d = ANY(e.tags)", argNum)
 		args = append(args, category)
 		argNum++
 	}
 
 	if queryStr != "" {
-		query += fmt.Sprintf(" AND (e.title ILIKE $%d OR e.city ILIKE $%d)", argNum, argNum)
-		args = append(args, "%"+queryStr+"%")
+		q := fmt.Sprintf("$%d::text", argNum)
+		where += " AND " + searchMatchSQL(q)
+		score = searchRelevanceSQL(q) + " * (1 + ln(1 + GREATEST(" + rankScoreSQL + ", 0)))"
+		args = append(args, queryStr)
 		argNum++
 	}
 
+	query := `
+		SELECT event_id, title, city, tags, start_time, cover_image_ids, trend_score
+		FROM (
+			SELECT
+				e.event_id, e.title, e.city, e.tags, e.start_time, e.cover_image_ids,
+				` + score + `::float8 AS trend_score
+			FROM event_index e
+			LEFT JOIN event_trend_stats ts ON e.event_id = ts.event_id
+			LEFT JOIN organizer_ratings orr ON e.owner_id = orr.owner_id
+			WHERE e.status = 'published' AND e.start_time > NOW()` + where + `
+		) s
+	`
+
 	if afterID != "" {
-		query += fmt.Sprintf(` AND (
-			(4.0 * COALESCE(ts.join_users_24h, 0) + 2.0 * COALESCE(ts.join_users_7d, 0) + 0.5 * COALESCE(ts.view_users_24h, 0) + 3.0 / (1 + EXTRACT(EPOCH FROM (e.start_time - NOW())) / 86400)) < $%d
-			OR (
-				(4.0 * COALESCE(ts.join_users_24h, 0) + 2.0 * COALESCE(ts.join_users_7d, 0) + 0.5 * COALESCE(ts.view_users_24h, 0) + 3.0 / (1 + EXTRACT(EPOCH FROM (e.start_time - NOW())) / 86400)) = $%d
-				AND (e.start_time > $%d OR (e.start_time = $%d AND e.event_id < $%d))
-			)
-		)`, argNum, argNum+1, argNum+2, argNum+3, argNum+4)
-		args = append(args, afterScore, afterScore, afterStartTime, afterStartTime, afterID)
-		argNum += 5
+		query += fmt.Sprintf(` WHERE (
+			trend_score < $%d
+			OR (trend_score = $%d AND (start_time > $%d OR (start_time = $%d AND event_id < $%d)))
+		)`, argNum, argNum, argNum+1, argNum+1, argNum+2)
+		args = append(args, afterScore, afterStartTime, afterID)
+		argNum += 3
 	}
 
-	query += fmt.Sprintf(" ORDER BY trend_score DESC, e.start_time ASC, e.event_id DESC LIMIT $%d", argNum)
+	query += fmt.Sprintf(" ORDER BY trend_score DESC, start_time ASC, event_id DESC LIMIT $%d", argNum)
 	args = append(args, limit)
 
-	fmt.Printf("Query Params: %v\n", args)
+	return r.queryEvents(ctx, queryStr != "", query, args...)
+}
+
+// queryEvents runs a feed query. Search queries run in a transaction so the
+// trigram threshold can be lowered for them only.
+func (r *TrendingRepo) queryEvents(ctx context.Context, search bool, query string, args ...interface{}) ([]TrendingEvent, error) {
+	var rows pgx.Rows
+	var err error
+
+	if search {
+		tx, err := r.pool.Begin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback(ctx)
 
-	rows, err := r.pool.Query(ctx, query, args...)
-	if err != nil {
-		return nil, err
+		if _, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL pg_trgm.word_similarity_threshold = %v", searchTrigramThreshold)); err != nil {
+			return nil, err
+		}
+		rows, err = tx.Query(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		rows, err = r.pool.Query(ctx, query, args...)
+		if err != nil {
+			return nil, err
+		}
 	}
 	defer rows.Close()
 
@@ -124,7 +189,8 @@ type TrendingEvent struct {
 	CoverImageIDs []string  `json:"cover_image_ids"`
 }
 
-// GetLatest returns newest events ordered by creation time (created_at DESC)
+// GetLatest returns newest events ordered by start_time DESC. A query only
+// filters; ordering stays chronological.
 func (r *TrendingRepo) GetLatest(ctx context.Context, city string, category string, queryStr string, limit int, afterStartTime time.Time, afterID string) ([]TrendingEvent, error) {
 	query := `
 		SELECT 
@@ -149,12 +215,12 @@ func (r *TrendingRepo) GetLatest(ctx context.Context, city string, category stri
 	}
 
 	if queryStr != "" {
-		query += fmt.Sprintf(" AND (e.title ILIKE $%d OR e.city ILIKE $%d)", argNum, argNum)
-		args = append(args, "%"+queryStr+"%")
+		query += " AND " + searchMatchSQL(fmt.Sprintf("$%d::text", argNum))
+		args = append(args, queryStr)
 		argNum++
 	}
 
-	// Keyset pagination: created_at DESC, event_id DESC
+	// Keyset pagination: start_time DESC, event_id DESC
 	if afterID != "" {
 		query += fmt.Sprintf(` AND (e.start_time < $%d OR (e.start_time = $%d AND e.event_id < $%d))`, argNum, argNum+1, argNum+2)
 		args = append(args, afterStartTime, afterStartTime, afterID)
@@ -164,19 +230,5 @@ func (r *TrendingRepo) GetLatest(ctx context.Context, city string, category stri
 	query += fmt.Sprintf(" ORDER BY e.start_time DESC, e.event_id DESC LIMIT $%d", argNum)
 	args = append(args, limit)
 
-	rows, err := r.pool.Query(ctx, query, args...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var events []TrendingEvent
-	for rows.Next() {
-		var e TrendingEvent
-		if err := rows.Scan(&e.EventID, &e.Title, &e.City, &e.Tags, &e.StartTime, &e.CoverImageIDs, &e.TrendScore); err != nil {
-			return nil, err
-		}
-		events = append(events, e)
-	}
-	return events, rows.Err()
+	return r.queryEvents(ctx, queryStr != "", query, args...)
 }
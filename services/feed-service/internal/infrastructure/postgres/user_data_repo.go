@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// DeleteUserData removes a user's activity, tag profile, anon merges and
+// organizer rating. event_index keeps the event rows; the reconciler picks
+// up the pseudonymized owner from event-service.
+func (r *TrackRepo) DeleteUserData(ctx context.Context, userID string) error {
+	actorKey := "u:" + userID
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Wait out a running rebuild or compaction so it cannot copy the
+	// user's rows into the table it is about to swap in.
+	if r.profiles != nil {
+		if _, _, err := r.profiles.lockActiveTable(ctx, tx, true); err != nil {
+			return err
+		}
+	}
+
+	stmts := []struct {
+		sql string
+		arg string
+	}{
+		{`DELETE FROM track_outbox WHERE actor_key = $1`, actorKey},
+		{`DELETE FROM user_events WHERE actor_key = $1`, actorKey},
+		{`DELETE FROM user_tag_profile_a WHERE actor_key = $1`, actorKey},
+		{`DELETE FROM user_tag_profile_b WHERE actor_key = $1`, actorKey},
+		{`DELETE FROM actor_merges WHERE user_key = $1`, actorKey},
+		{`DELETE FROM organizer_ratings WHERE owner_id = $1`, userID},
+	}
+	for _, st := range stmts {
+		if _, err := tx.Exec(ctx, st.sql, st.arg); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// ExportUserData returns the user's tracked activity and tag profile.
+func (r *TrackRepo) ExportUserData(ctx context.Context, userID string) (json.RawMessage, error) {
+	var out []byte
+	err := r.pool.QueryRow(ctx, `
+		SELECT jsonb_build_object(
+			'activity', COALESCE((
+				SELECT jsonb_agg(jsonb_build_object(
+					'event_id', ue.event_id,
+					'event_type', ue.event_type,
+					'feed_type', ue.feed_type,
+					'occurred_at', ue.occurred_at
+				) ORDER BY ue.occurred_at)
+				FROM user_events ue
+				WHERE ue.actor_key = $1
+			), '[]'::jsonb),
+			'tag_profile', COALESCE((
+				SELECT jsonb_object_agg(p.tag, p.weight)
+				FROM user_tag_profile p
+				WHERE p.actor_key = $1
+			), '{}'::jsonb)
+		)
+	`, "u:"+userID).Scan(&out)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(out), nil
+}
@@ -3,9 +3,11 @@ package rabbitmq
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
 	"time"
 
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/domain"
 	"github.com/baechuer/real-time-ressys/services/feed-service/internal/infrastructure/postgres"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -14,13 +16,49 @@ type EventPublishedPayload struct {
 	EventID       string    `json:"event_id"`
 	OwnerID       string    `json:"owner_id"`
 	Title         string    `json:"title"`
+	Description   string    `json:"description"`
 	City          string    `json:"city"` // e.g. "Sydney"
 	Category      string    `json:"category"`
 	StartTime     time.Time `json:"start_time"`
 	Status        string    `json:"status"`
+	Visibility    string    `json:"visibility"`
 	CoverImageIDs []string  `json:"cover_image_ids"`
 }
 
+// ReputationPayload is event.reputation.updated: a snapshot of an
+// organizer's rating, newest by updated_at wins
+type ReputationPayload struct {
+	OrganizerID string    `json:"organizer_id"`
+	RatingAvg   float64   `json:"rating_avg"`
+	RatingCount int       `json:"rating_count"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// DataRequestedPayload is auth.user.deletion_requested /
+// auth.user.export_requested from auth-service.
+type DataRequestedPayload struct {
+	RequestID string `json:"request_id"`
+	UserID    string `json:"user_id"`
+}
+
+// DataRequestReply is the user.data.deleted / user.data.exported reply to
+// auth-service. Data is only set on exports.
+type DataRequestReply struct {
+	RequestID string          `json:"request_id"`
+	UserID    string          `json:"user_id"`
+	Service   string          `json:"service"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+const (
+	exchangeName = "city.events"
+
+	rkDeletionRequested = "auth.user.deletion_requested"
+	rkExportRequested   = "auth.user.export_requested"
+	rkUserDataDeleted   = "user.data.deleted"
+	rkUserDataExported  = "user.data.exported"
+)
+
 type DomainEventEnvelope struct {
 	MessageID  string          `json:"message_id"`
 	Payload    json.RawMessage `json:"payload"`
@@ -70,7 +108,7 @@ func (c *Consumer) connectAndConsume(ctx context.Context) error {
 
 	// Declare exchange (must match event-service)
 	err = ch.ExchangeDeclare(
-		"cityevents", // name - must match event-service's exchange
+		exchangeName, // name - must match event-service's exchange
 		"topic",      // type
 		true,         // durable
 		false,        // auto-deleted
@@ -94,16 +132,12 @@ func (c *Consumer) connectAndConsume(ctx context.Context) error {
 		return err
 	}
 
-	// Bind to event.published
-	err = ch.QueueBind(
-		q.Name,
-		"event.published",
-		"cityevents",
-		false,
-		nil,
-	)
-	if err != nil {
-		return err
+	// Bind to event.published, organizer reputation updates and
+	// auth-service's account deletion / data export requests
+	for _, key := range []string{"event.published", "event.reputation.updated", rkDeletionRequested, rkExportRequested} {
+		if err := ch.QueueBind(q.Name, key, exchangeName, false, nil); err != nil {
+			return err
+		}
 	}
 
 	msgs, err := ch.Consume(
@@ -130,7 +164,7 @@ func (c *Consumer) connectAndConsume(ctx context.Context) error {
 				return amqp.ErrClosed
 			}
 
-			if err := c.handleMessage(ctx, d.Body); err != nil {
+			if err := c.handleMessage(ctx, ch, d.RoutingKey, d.Body); err != nil {
 				log.Printf("failed to handle message: %v", err)
 				// Negative Ack with requeue=false (dead letter)
 				_ = d.Nack(false, false)
@@ -141,7 +175,14 @@ func (c *Consumer) connectAndConsume(ctx context.Context) error {
 	}
 }
 
-func (c *Consumer) handleMessage(ctx context.Context, body []byte) error {
+func (c *Consumer) handleMessage(ctx context.Context, ch *amqp.Channel, routingKey string, body []byte) error {
+	switch routingKey {
+	case "event.reputation.updated":
+		return c.handleReputation(ctx, body)
+	case rkDeletionRequested, rkExportRequested:
+		return c.handleDataRequest(ctx, ch, routingKey, body)
+	}
+
 	// event-service sends "Payload" as object, not raw bytes in some versions,
 	// but the struct above defined Payload as RawMessage.
 	// Let's verify event-service payload structure.
@@ -168,5 +209,69 @@ func (c *Consumer) handleMessage(ctx context.Context, body []byte) error {
 	log.Printf("received event published: %s (%s)", env.Payload.EventID, env.Payload.City)
 
 	// TODO: We need a Postgres method to UPSERT this into event_index
-	return c.repo.IndexEvent(ctx, env.Payload.EventID, env.Payload.OwnerID, env.Payload.Title, env.Payload.City, env.Payload.Category, env.Payload.StartTime, env.Payload.Status, env.Payload.CoverImageIDs)
+	return c.repo.IndexEvent(ctx, domain.IndexedEvent{
+		EventID:       env.Payload.EventID,
+		OwnerID:       env.Payload.OwnerID,
+		Title:         env.Payload.Title,
+		Description:   env.Payload.Description,
+		City:          env.Payload.City,
+		Category:      env.Payload.Category,
+		StartTime:     env.Payload.StartTime,
+		Status:        domain.IndexStatus(env.Payload.Status, env.Payload.Visibility),
+		CoverImageIDs: env.Payload.CoverImageIDs,
+	})
+}
+
+func (c *Consumer) handleReputation(ctx context.Context, body []byte) error {
+	var env struct {
+		Payload ReputationPayload `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &env); err != nil {
+		return err
+	}
+
+	p := env.Payload
+	return c.repo.UpsertOrganizerRating(ctx, p.OrganizerID, p.RatingAvg, p.RatingCount, p.UpdatedAt)
+}
+
+// handleDataRequest deletes or exports the user's feed data and replies to
+// auth-service. Replays reply again.
+func (c *Consumer) handleDataRequest(ctx context.Context, ch *amqp.Channel, routingKey string, body []byte) error {
+	var env struct {
+		Payload DataRequestedPayload `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &env); err != nil {
+		return err
+	}
+	p := env.Payload
+	if p.RequestID == "" || p.UserID == "" {
+		return fmt.Errorf("%s: missing request_id or user_id", routingKey)
+	}
+
+	reply := DataRequestReply{RequestID: p.RequestID, UserID: p.UserID, Service: "feed-service"}
+	replyKey := rkUserDataDeleted
+	if routingKey == rkDeletionRequested {
+		if err := c.repo.DeleteUserData(ctx, p.UserID); err != nil {
+			return err
+		}
+	} else {
+		data, err := c.repo.ExportUserData(ctx, p.UserID)
+		if err != nil {
+			return err
+		}
+		reply.Data = data
+		replyKey = rkUserDataExported
+	}
+
+	out, err := json.Marshal(reply)
+	if err != nil {
+		return err
+	}
+	log.Printf("handled %s for request %s", routingKey, p.RequestID)
+	return ch.PublishWithContext(ctx, exchangeName, replyKey, false, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now().UTC(),
+		Body:         out,
+	})
 }
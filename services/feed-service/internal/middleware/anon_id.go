@@ -58,6 +58,12 @@ func AnonIDFromContext(ctx context.Context) (string, bool) {
 	return v, ok && v != ""
 }
 
+// VerifyAnonCookie validates a signed anon_id cookie value and returns the
+// anon_id. Used by endpoints that receive the cookie out of band.
+func VerifyAnonCookie(secret, cookie string) (string, bool) {
+	return verifyAnonCookie(secret, cookie)
+}
+
 // signAnonCookie creates a signed cookie value
 func signAnonCookie(secret, anonID string, exp int64) string {
 	payload := fmt.Sprintf("%s.%d", anonID, exp)
@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// InternalAuth restricts a route to other services holding the shared
+// X-Internal-Secret (e.g. the BFF).
+func InternalAuth(secret string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if secret == "" {
+				http.Error(w, "internal auth misconfigured", http.StatusInternalServerError)
+				return
+			}
+
+			got := r.Header.Get("X-Internal-Secret")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(secret)) != 1 {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package reconcile
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// indexDrift is the number of event_index rows the last run had to fix
+	indexDrift = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "feed_service",
+			Name:      "event_index_drift",
+			Help:      "event_index rows that disagreed with event-service in the last reconciliation run",
+		},
+		[]string{"kind"}, // missing, stale, tombstoned, orphaned
+	)
+
+	runsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "feed_service",
+			Name:      "event_index_reconcile_runs_total",
+			Help:      "Reconciliation runs by mode and outcome",
+		},
+		[]string{"mode", "result"},
+	)
+
+	lastSuccess = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "feed_service",
+			Name:      "event_index_reconcile_last_success_timestamp_seconds",
+			Help:      "Unix time of the last successful reconciliation run",
+		},
+	)
+)
@@ -0,0 +1,176 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/infrastructure/eventservice"
+)
+
+const (
+	defaultPageSize = 200
+
+	// Re-read this much before the watermark: an event-service transaction
+	// can commit after a later updated_at was already paged past.
+	watermarkOverlap = 5 * time.Minute
+)
+
+// ErrBusy is returned when another replica is already reconciling
+var ErrBusy = errors.New("reconciliation already running")
+
+// Source is event-service's change feed and batch lookup
+type Source interface {
+	Changes(ctx context.Context, since time.Time, cursor string, limit int) (*eventservice.ChangesPage, error)
+	GetBatch(ctx context.Context, ids []string) (map[string]domain.IndexedEvent, error)
+}
+
+// Store is the local event_index
+type Store interface {
+	TryLock(ctx context.Context) (unlock func(), ok bool, err error)
+	GetWatermark(ctx context.Context) (time.Time, error)
+	SaveRun(ctx context.Context, res domain.ReconcileResult) error
+	UpsertEvents(ctx context.Context, events []domain.IndexedEvent) (missing, stale int64, err error)
+	Tombstone(ctx context.Context, tombstones []domain.EventTombstone) (int64, error)
+	SweepOrphans(ctx context.Context, runStart time.Time) (int64, error)
+}
+
+// Reconciler brings event_index in line with event-service. Incremental runs
+// page the change feed from the stored watermark; full runs page everything
+// and then tombstone published rows event-service did not return.
+type Reconciler struct {
+	source   Source
+	store    Store
+	pageSize int
+	now      func() time.Time
+}
+
+func NewReconciler(source Source, store Store) *Reconciler {
+	return &Reconciler{source: source, store: store, pageSize: defaultPageSize, now: time.Now}
+}
+
+// Run performs one reconciliation. A never-reconciled index always gets a
+// full run.
+func (r *Reconciler) Run(ctx context.Context, full bool) (res domain.ReconcileResult, err error) {
+	unlock, ok, err := r.store.TryLock(ctx)
+	if err != nil {
+		return res, err
+	}
+	if !ok {
+		return res, ErrBusy
+	}
+	defer unlock()
+
+	watermark, err := r.store.GetWatermark(ctx)
+	if err != nil {
+		return res, err
+	}
+	if watermark.IsZero() {
+		full = true
+	}
+
+	mode := "incremental"
+	if full {
+		mode = "full"
+	}
+	defer func() {
+		if err != nil {
+			runsTotal.WithLabelValues(mode, "error").Inc()
+			return
+		}
+		runsTotal.WithLabelValues(mode, "ok").Inc()
+		lastSuccess.SetToCurrentTime()
+		indexDrift.WithLabelValues("missing").Set(float64(res.Missing))
+		indexDrift.WithLabelValues("stale").Set(float64(res.Stale))
+		indexDrift.WithLabelValues("tombstoned").Set(float64(res.Tombstoned))
+		indexDrift.WithLabelValues("orphaned").Set(float64(res.Orphaned))
+	}()
+
+	res = domain.ReconcileResult{Full: full, Watermark: watermark}
+	var since time.Time
+	if !full {
+		since = watermark.Add(-watermarkOverlap)
+	}
+	runStart := r.now()
+
+	cursor := ""
+	for {
+		page, err := r.source.Changes(ctx, since, cursor, r.pageSize)
+		if err != nil {
+			return res, err
+		}
+		if err := r.apply(ctx, page.Items, &res); err != nil {
+			return res, err
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	if full {
+		res.Orphaned, err = r.store.SweepOrphans(ctx, runStart)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	if err := r.store.SaveRun(ctx, res); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// apply upserts the published events of one change-feed page and tombstones
+// the rest
+func (r *Reconciler) apply(ctx context.Context, changes []eventservice.Change, res *domain.ReconcileResult) error {
+	var published []string
+	var tombstones []domain.EventTombstone
+	for _, c := range changes {
+		res.Scanned++
+		if c.UpdatedAt.After(res.Watermark) {
+			res.Watermark = c.UpdatedAt
+		}
+		if c.Status == "published" {
+			published = append(published, c.ID)
+		} else {
+			tombstones = append(tombstones, domain.EventTombstone{EventID: c.ID, Status: c.Status})
+		}
+	}
+
+	for start := 0; start < len(published); start += eventservice.MaxBatch {
+		end := start + eventservice.MaxBatch
+		if end > len(published) {
+			end = len(published)
+		}
+
+		found, err := r.source.GetBatch(ctx, published[start:end])
+		if err != nil {
+			return err
+		}
+
+		// Ids missing from the batch changed state after the page was read;
+		// their new updated_at puts them later in the feed.
+		events := make([]domain.IndexedEvent, 0, len(found))
+		for _, id := range published[start:end] {
+			if e, ok := found[id]; ok {
+				events = append(events, e)
+			}
+		}
+
+		missing, stale, err := r.store.UpsertEvents(ctx, events)
+		if err != nil {
+			return err
+		}
+		res.Missing += missing
+		res.Stale += stale
+	}
+
+	n, err := r.store.Tombstone(ctx, tombstones)
+	if err != nil {
+		return err
+	}
+	res.Tombstoned += n
+	return nil
+}
@@ -0,0 +1,150 @@
+package reconcile
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/domain"
+	"github.com/baechuer/real-time-ressys/services/feed-service/internal/infrastructure/eventservice"
+)
+
+type fakeSource struct {
+	pages    []eventservice.ChangesPage
+	events   map[string]domain.IndexedEvent
+	sinces   []time.Time
+	batchLen []int
+}
+
+func (f *fakeSource) Changes(ctx context.Context, since time.Time, cursor string, limit int) (*eventservice.ChangesPage, error) {
+	f.sinces = append(f.sinces, since)
+	i := 0
+	if cursor != "" {
+		i, _ = strconv.Atoi(cursor)
+	}
+	page := f.pages[i]
+	return &page, nil
+}
+
+func (f *fakeSource) GetBatch(ctx context.Context, ids []string) (map[string]domain.IndexedEvent, error) {
+	f.batchLen = append(f.batchLen, len(ids))
+	out := map[string]domain.IndexedEvent{}
+	for _, id := range ids {
+		if e, ok := f.events[id]; ok {
+			out[id] = e
+		}
+	}
+	return out, nil
+}
+
+type fakeStore struct {
+	locked     bool
+	watermark  time.Time
+	upserted   []domain.IndexedEvent
+	tombstoned []domain.EventTombstone
+	swept      bool
+	saved      *domain.ReconcileResult
+}
+
+func (f *fakeStore) TryLock(ctx context.Context) (func(), bool, error) {
+	if f.locked {
+		return nil, false, nil
+	}
+	return func() {}, true, nil
+}
+func (f *fakeStore) GetWatermark(ctx context.Context) (time.Time, error) { return f.watermark, nil }
+func (f *fakeStore) SaveRun(ctx context.Context, res domain.ReconcileResult) error {
+	f.saved = &res
+	return nil
+}
+func (f *fakeStore) UpsertEvents(ctx context.Context, events []domain.IndexedEvent) (int64, int64, error) {
+	f.upserted = append(f.upserted, events...)
+	return int64(len(events)), 0, nil
+}
+func (f *fakeStore) Tombstone(ctx context.Context, t []domain.EventTombstone) (int64, error) {
+	f.tombstoned = append(f.tombstoned, t...)
+	return int64(len(t)), nil
+}
+func (f *fakeStore) SweepOrphans(ctx context.Context, runStart time.Time) (int64, error) {
+	f.swept = true
+	return 2, nil
+}
+
+func TestRun_IncrementalFromWatermark(t *testing.T) {
+	wm := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	src := &fakeSource{
+		pages: []eventservice.ChangesPage{
+			{Items: []eventservice.Change{
+				{ID: "e1", Status: "published", UpdatedAt: wm.Add(time.Minute)},
+				{ID: "e2", Status: "canceled", UpdatedAt: wm.Add(2 * time.Minute)},
+			}, NextCursor: "1"},
+			{Items: []eventservice.Change{
+				{ID: "e3", Status: "published", UpdatedAt: wm.Add(3 * time.Minute)}, // unpublished mid-run
+			}},
+		},
+		events: map[string]domain.IndexedEvent{"e1": {EventID: "e1", Status: "published"}},
+	}
+	store := &fakeStore{watermark: wm}
+
+	res, err := NewReconciler(src, store).Run(context.Background(), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if res.Full || store.swept {
+		t.Error("incremental run must not sweep orphans")
+	}
+	if !src.sinces[0].Equal(wm.Add(-watermarkOverlap)) {
+		t.Errorf("expected since = watermark - overlap, got %v", src.sinces[0])
+	}
+	if len(store.upserted) != 1 || store.upserted[0].EventID != "e1" {
+		t.Errorf("expected only e1 upserted, got %+v", store.upserted)
+	}
+	if len(store.tombstoned) != 1 || store.tombstoned[0].Status != "canceled" {
+		t.Errorf("expected e2 tombstoned as canceled, got %+v", store.tombstoned)
+	}
+	if res.Scanned != 3 || res.Drift() != 2 {
+		t.Errorf("expected scanned=3 drift=2, got %d/%d", res.Scanned, res.Drift())
+	}
+	if store.saved == nil || !store.saved.Watermark.Equal(wm.Add(3*time.Minute)) {
+		t.Errorf("expected watermark advanced to last change, got %+v", store.saved)
+	}
+}
+
+func TestRun_FreshIndexForcesFull(t *testing.T) {
+	var items []eventservice.Change
+	events := map[string]domain.IndexedEvent{}
+	for i := 0; i < 120; i++ {
+		id := "e" + strconv.Itoa(i)
+		items = append(items, eventservice.Change{ID: id, Status: "published", UpdatedAt: time.Now()})
+		events[id] = domain.IndexedEvent{EventID: id, Status: "published"}
+	}
+	src := &fakeSource{pages: []eventservice.ChangesPage{{Items: items}}, events: events}
+	store := &fakeStore{}
+
+	res, err := NewReconciler(src, store).Run(context.Background(), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !res.Full || !store.swept || res.Orphaned != 2 {
+		t.Errorf("expected full run with orphan sweep, got %+v", res)
+	}
+	if !src.sinces[0].IsZero() {
+		t.Errorf("full run must start from the beginning, got since=%v", src.sinces[0])
+	}
+	if len(src.batchLen) != 3 || src.batchLen[0] != eventservice.MaxBatch || src.batchLen[2] != 20 {
+		t.Errorf("expected batches of 50/50/20, got %v", src.batchLen)
+	}
+	if len(store.upserted) != 120 {
+		t.Errorf("expected 120 upserts, got %d", len(store.upserted))
+	}
+}
+
+func TestRun_Busy(t *testing.T) {
+	_, err := NewReconciler(&fakeSource{}, &fakeStore{locked: true}).Run(context.Background(), true)
+	if err != ErrBusy {
+		t.Errorf("expected ErrBusy, got %v", err)
+	}
+}
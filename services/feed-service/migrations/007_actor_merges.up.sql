@@ -0,0 +1,13 @@
+-- actor_merges: anonymous actors folded into a user after login/registration
+-- One anon key can only ever belong to one user; re-merging is a no-op.
+
+CREATE TABLE actor_merges (
+    anon_key TEXT PRIMARY KEY,   -- "a:<anon_id>"
+    user_key TEXT NOT NULL,      -- "u:<user_id>"
+    events_moved INT NOT NULL DEFAULT 0,
+    tags_merged INT NOT NULL DEFAULT 0,
+    first_merged_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_merged_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX ix_actor_merges_user ON actor_merges(user_key);
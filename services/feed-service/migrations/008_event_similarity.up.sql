@@ -0,0 +1,13 @@
+-- event_similarity: precomputed item-to-item recommendations
+-- Rebuilt periodically from user_events co-occurrence + event_index tags/city
+
+CREATE TABLE event_similarity (
+    event_id UUID NOT NULL,
+    similar_event_id UUID NOT NULL,
+    score FLOAT8 NOT NULL,
+    co_joined INT NOT NULL DEFAULT 0,  -- actors who joined both events
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    PRIMARY KEY (event_id, similar_event_id)
+);
+
+CREATE INDEX ix_event_similarity_score ON event_similarity(event_id, score DESC);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS ix_event_index_synced_at;
+DROP TABLE IF EXISTS event_index_sync_state;
@@ -0,0 +1,15 @@
+-- event_index_sync_state: progress of the event-service reconciliation job
+-- Single row; watermark is the last change-feed updated_at fully applied
+
+CREATE TABLE event_index_sync_state (
+    id INT PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    watermark TIMESTAMPTZ,
+    last_run_at TIMESTAMPTZ,
+    last_full_at TIMESTAMPTZ,
+    last_drift INT NOT NULL DEFAULT 0
+);
+
+INSERT INTO event_index_sync_state (id) VALUES (1);
+
+-- Orphan sweep after a full backfill
+CREATE INDEX ix_event_index_synced_at ON event_index(synced_at) WHERE status = 'published';
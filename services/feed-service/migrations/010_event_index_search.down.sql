@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS ix_event_index_city_trgm;
+DROP INDEX IF EXISTS ix_event_index_title_trgm;
+DROP INDEX IF EXISTS ix_event_index_search;
+ALTER TABLE event_index DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE event_index DROP COLUMN IF EXISTS description;
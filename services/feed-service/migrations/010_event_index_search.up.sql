@@ -0,0 +1,21 @@
+-- Full-text + typo-tolerant search over event_index
+-- Weighted like event-service's events.search_vector; city is searchable too
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE event_index ADD COLUMN description TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE event_index
+  ADD COLUMN search_vector tsvector
+  GENERATED ALWAYS AS (
+    setweight(to_tsvector('simple', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(city, '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(description, '')), 'C')
+  ) STORED;
+
+CREATE INDEX ix_event_index_search ON event_index USING GIN (search_vector) WHERE status = 'published';
+CREATE INDEX ix_event_index_title_trgm ON event_index USING GIN (title gin_trgm_ops) WHERE status = 'published';
+CREATE INDEX ix_event_index_city_trgm ON event_index USING GIN (city gin_trgm_ops) WHERE status = 'published';
+
+-- Descriptions were never indexed: force the next reconciliation to be a full run
+UPDATE event_index_sync_state SET watermark = NULL WHERE id = 1;
@@ -0,0 +1,10 @@
+-- organizer_ratings: organizer reputation from event-service reviews
+-- One row per organizer; event.reputation.updated snapshots, newest wins.
+-- Joined on event_index.owner_id as a ranking feature of upcoming events.
+
+CREATE TABLE organizer_ratings (
+    owner_id TEXT PRIMARY KEY,
+    rating_avg DOUBLE PRECISION NOT NULL DEFAULT 0,
+    rating_count INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL
+);
@@ -85,6 +85,7 @@ func main() {
 
 	// ---- Application service ----
 	svc := service.NewJoinService(repo, cache)
+	svc.SetInviteSecret(cfg.InviteTokenSecret)
 	h := rest.NewHandler(svc)
 
 	// ---- JWT verifier ----
@@ -98,6 +99,8 @@ func main() {
 		JWTIssuer: cfg.JWTIssuer,
 		RLLimit:   cfg.RLLimit,
 		RLWindow:  cfg.RLWindow,
+
+		InternalSecret: cfg.InternalSecret,
 	})
 
 	// ---- MQ consumer (inbound snapshots from event-service) ----
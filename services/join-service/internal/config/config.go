@@ -22,6 +22,12 @@ type Config struct {
 	JWTSecret string
 	JWTIssuer string
 
+	// Verifies invite links issued by event-service (must match its INVITE_TOKEN_SECRET)
+	InviteTokenSecret string
+
+	// Shared secret for service-to-service routes (X-Internal-Secret)
+	InternalSecret string
+
 	// Redis
 	RedisAddr string
 	RedisPass string
@@ -99,6 +105,9 @@ func Load() (*Config, error) {
 	// --- Logging
 	cfg.LogLevel = getEnv("LOG_LEVEL", "info")
 
+	cfg.InviteTokenSecret = getEnv("INVITE_TOKEN_SECRET", "dev-invite-secret")
+	cfg.InternalSecret = getEnv("INTERNAL_SECRET_KEY", "dev-secret-key")
+
 	// --- Optional toggles
 	cfg.OutboxEnabled = getBool("OUTBOX_ENABLED", true)
 
@@ -113,6 +122,12 @@ func Load() (*Config, error) {
 	if cfg.AppEnv != "dev" && cfg.RabbitURL == "" {
 		return nil, fmt.Errorf("missing RABBITMQ_URL (required when APP_ENV != dev)")
 	}
+	if cfg.AppEnv == "prod" && cfg.InviteTokenSecret == "dev-invite-secret" {
+		return nil, fmt.Errorf("INVITE_TOKEN_SECRET must be set in prod")
+	}
+	if cfg.AppEnv == "prod" && cfg.InternalSecret == "dev-secret-key" {
+		return nil, fmt.Errorf("INTERNAL_SECRET_KEY must be set in prod")
+	}
 
 	return cfg, nil
 }
@@ -1,7 +1,10 @@
 // PATH: services/join-service/internal/contracts/event/envelope.go
 package event
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // DomainEventEnvelope is the canonical envelope consumed across services.
 // NOTE: message_id is optional for backward compatibility.
@@ -17,9 +20,17 @@ type DomainEventEnvelope[T any] struct {
 // EventPublishedPayload / EventUpdatedPayload
 // Keep fields tolerant: extra fields from producer are ignored by json.Unmarshal.
 type EventPublishedPayload struct {
-	EventID  string `json:"event_id"`
-	Capacity *int   `json:"capacity,omitempty"` // pointer so we can detect missing
-	Status   string `json:"status,omitempty"`   // e.g. published/canceled
+	EventID    string `json:"event_id"`
+	OwnerID    string `json:"owner_id,omitempty"`
+	Capacity   *int   `json:"capacity,omitempty"`   // pointer so we can detect missing
+	Status     string `json:"status,omitempty"`     // e.g. published/canceled
+	Visibility string `json:"visibility,omitempty"` // public/unlisted/invite_only; empty from older producers
+
+	// Registration window; nil = no bound
+	RegistrationOpensAt  *time.Time `json:"registration_opens_at,omitempty"`
+	RegistrationClosesAt *time.Time `json:"registration_closes_at,omitempty"`
+
+	StartTime *time.Time `json:"start_time,omitempty"`
 }
 
 type EventUpdatedPayload = EventPublishedPayload
@@ -32,3 +43,68 @@ type EventCanceledPayload struct {
 	Status  string `json:"status,omitempty"` // optional
 	Reason  string `json:"reason,omitempty"` // optional
 }
+
+// EventCollaboratorsPayload is a full organizer team snapshot
+// (event.collaborators.updated / event.owner_transferred).
+type EventCollaboratorsPayload struct {
+	EventID         string                `json:"event_id"`
+	OwnerID         string                `json:"owner_id"`
+	PreviousOwnerID string                `json:"previous_owner_id,omitempty"`
+	Collaborators   []CollaboratorPayload `json:"collaborators"`
+	UpdatedAt       time.Time             `json:"updated_at"`
+}
+
+type CollaboratorPayload struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+// EventInvitePayload (event.invite.created / event.invite.revoked).
+// Access codes arrive only as code_hash.
+type EventInvitePayload struct {
+	InviteID  string     `json:"invite_id"`
+	EventID   string     `json:"event_id"`
+	Kind      string     `json:"kind,omitempty"`
+	CodeHash  string     `json:"code_hash,omitempty"`
+	MaxUses   *int       `json:"max_uses,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// EventAnnouncementPayload (event.announcement.posted).
+type EventAnnouncementPayload struct {
+	AnnouncementID string    `json:"announcement_id"`
+	EventID        string    `json:"event_id"`
+	EventTitle     string    `json:"event_title,omitempty"`
+	AuthorID       string    `json:"author_id,omitempty"`
+	Title          string    `json:"title"`
+	Body           string    `json:"body"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// EventChangedPayload (event.changed): material fields (time, city,
+// capacity) of a published event changed. Changes maps field name to
+// {"from","to"} and is relayed to email-service untouched.
+type EventChangedPayload struct {
+	EventID      string          `json:"event_id"`
+	EventTitle   string          `json:"event_title,omitempty"`
+	EventVersion int64           `json:"event_version"`
+	Changes      json.RawMessage `json:"changes"`
+	ChangedAt    time.Time       `json:"changed_at"`
+}
+
+// UserBannedPayload (auth.user.banned, produced by auth-service).
+type UserBannedPayload struct {
+	UserID   string    `json:"user_id"`
+	ActorID  string    `json:"actor_id,omitempty"`
+	BannedAt time.Time `json:"banned_at"`
+}
+
+// DataRequestedPayload (auth.user.deletion_requested /
+// auth.user.export_requested, produced by auth-service). The reply,
+// user.data.deleted or user.data.exported, echoes request_id.
+type DataRequestedPayload struct {
+	RequestID   string    `json:"request_id"`
+	UserID      string    `json:"user_id"`
+	RequestedAt time.Time `json:"requested_at"`
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"time"
 
@@ -18,6 +19,39 @@ const (
 	StatusRejected   JoinStatus = "rejected"
 )
 
+// CollaboratorRole mirrors event-service's organizer team roles.
+type CollaboratorRole string
+
+const (
+	RoleCoHost       CollaboratorRole = "co_host"
+	RoleCheckinStaff CollaboratorRole = "checkin_staff"
+	RoleEditor       CollaboratorRole = "editor"
+)
+
+// Collaborator is one entry of an organizer team snapshot.
+type Collaborator struct {
+	UserID uuid.UUID
+	Role   CollaboratorRole
+}
+
+// Announcement is an organizer broadcast relayed to active participants.
+type Announcement struct {
+	ID         uuid.UUID
+	EventID    uuid.UUID
+	EventTitle string
+	Title      string
+	Body       string
+}
+
+// EventChange is a material change to an event (event.changed) relayed to
+// its participants. Version is the event version that introduced it.
+type EventChange struct {
+	EventID    uuid.UUID
+	EventTitle string
+	Version    int64
+	Changes    json.RawMessage
+}
+
 var (
 	ErrEventNotFound = errors.New("event not found") // for shared-db lookup or snapshot missing
 	ErrEventClosed   = errors.New("event is closed")
@@ -31,6 +65,14 @@ var (
 	ErrEventNotKnown = errors.New("unknown event") // capacity row missing (your current join path)
 	ErrNotJoined     = errors.New("event not joined")
 
+	// Invite-only events
+	ErrInviteRequired = errors.New("an invite is required to join this event")
+	ErrInviteInvalid  = errors.New("invite is invalid, expired or used up")
+
+	// Registration window (event.published)
+	ErrRegistrationNotOpen = errors.New("registration has not opened yet")
+	ErrRegistrationClosed  = errors.New("registration is closed")
+
 	// Idempotency
 	ErrIdempotencyKeyMismatch = errors.New("idempotency key mismatch")
 )
@@ -73,14 +115,17 @@ type EventStats struct {
 
 // JoinRepository handles DB transactions, locking, outbox, and read endpoints.
 type JoinRepository interface {
-	JoinEvent(ctx context.Context, traceID, idempotencyKey string, eventID, userID uuid.UUID) (JoinStatus, error)
+	// JoinEvent checks access against invite_only events before taking the capacity lock.
+	JoinEvent(ctx context.Context, traceID, idempotencyKey string, eventID, userID uuid.UUID, access JoinAccess) (JoinStatus, error)
 	CancelJoin(ctx context.Context, traceID, idempotencyKey string, eventID, userID uuid.UUID) error
 
 	// Single Check
 	GetByEventAndUser(ctx context.Context, eventID, userID uuid.UUID) (JoinRecord, error)
 
-	// ACL on shared DB
+	// ACL, from the event-service organizer team snapshot
 	GetEventOwnerID(ctx context.Context, eventID uuid.UUID) (uuid.UUID, error)
+	// GetEventCollaboratorRole returns "" when the user is not on the team.
+	GetEventCollaboratorRole(ctx context.Context, eventID, userID uuid.UUID) (CollaboratorRole, error)
 
 	// Reads
 	ListMyJoins(ctx context.Context, userID uuid.UUID, statuses []JoinStatus, from, to *time.Time, limit int, cursor *KeysetCursor) ([]JoinRecord, *KeysetCursor, error)
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event visibility, mirrored from event.published.
+const (
+	VisibilityPublic     = "public"
+	VisibilityUnlisted   = "unlisted"
+	VisibilityInviteOnly = "invite_only"
+)
+
+// JoinAccess is the invite presented with a join. Only invite_only events
+// look at it; at most one of the fields is set.
+type JoinAccess struct {
+	InviteID uuid.UUID // from a verified invite link token
+	CodeHash string    // HashAccessCode of a typed access code
+}
+
+// Invite is join-service's copy of an event-service invite.
+type Invite struct {
+	ID        uuid.UUID
+	EventID   uuid.UUID
+	Kind      string // link | code
+	CodeHash  string
+	MaxUses   *int
+	ExpiresAt *time.Time
+}
+
+func (a JoinAccess) Empty() bool { return a.InviteID == uuid.Nil && a.CodeHash == "" }
+
+// The invite token format and code hash mirror event-service
+// (application/event/invite_token.go, domain/invite.go); keep them in sync.
+const inviteTokenPrefix = "invite:v1:"
+
+// VerifyInviteToken checks an invite link token for eventID and returns its
+// invite id. Tokens are <invite_id>.<event_id>.<exp>.<sig>.
+func VerifyInviteToken(secret []byte, token string, eventID uuid.UUID, now time.Time) (uuid.UUID, error) {
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 4 {
+		return uuid.Nil, ErrInviteInvalid
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(inviteTokenPrefix + strings.Join(parts[:3], ".")))
+	sig, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil || !hmac.Equal(sig, mac.Sum(nil)) {
+		return uuid.Nil, ErrInviteInvalid
+	}
+
+	inviteID, err := uuid.Parse(parts[0])
+	if err != nil {
+		return uuid.Nil, ErrInviteInvalid
+	}
+	if parts[1] != eventID.String() {
+		return uuid.Nil, ErrInviteInvalid
+	}
+	exp, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil || (exp != 0 && !now.Before(time.Unix(exp, 0))) {
+		return uuid.Nil, ErrInviteInvalid
+	}
+	return inviteID, nil
+}
+
+// HashAccessCode normalizes a typed access code (case, spaces and dashes are
+// ignored) and hashes it the way event-service does. ok is false when the
+// code cannot be valid.
+func HashAccessCode(eventID uuid.UUID, code string) (hash string, ok bool) {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		switch {
+		case r == ' ' || r == '-':
+			continue
+		case (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'):
+			sb.WriteRune(r)
+		default:
+			return "", false
+		}
+	}
+	norm := sb.String()
+	if len(norm) < 4 || len(norm) > 32 {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(eventID.String() + ":" + norm))
+	return hex.EncodeToString(sum[:]), true
+}
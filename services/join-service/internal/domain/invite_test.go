@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/join-service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// Fixtures produced by event-service's SignInviteToken / HashAccessCode.
+var (
+	fixtureEventID  = uuid.MustParse("0b5e8f3a-9c2d-4e7b-8f1a-3c6d9e2b5a71")
+	fixtureInviteID = uuid.MustParse("6f1c2a9e-4b7d-4c1e-9a3f-2d8e5b7c1a40")
+	fixtureToken    = "6f1c2a9e-4b7d-4c1e-9a3f-2d8e5b7c1a40.0b5e8f3a-9c2d-4e7b-8f1a-3c6d9e2b5a71.1893456000.vGxLhTlGPQvOP_nM0LvkIAHoakzdZc6YgWlhdeSCmdA"
+	fixtureCodeHash = "121e1b0e0e9ad63e0d50e48ce8ec07fe26d321ff76cf01b5b81a48f96f74ea5f"
+)
+
+func TestVerifyInviteToken(t *testing.T) {
+	secret := []byte("test-secret")
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	id, err := domain.VerifyInviteToken(secret, fixtureToken, fixtureEventID, now)
+	assert.NoError(t, err)
+	assert.Equal(t, fixtureInviteID, id)
+
+	tests := []struct {
+		name    string
+		secret  []byte
+		token   string
+		eventID uuid.UUID
+		now     time.Time
+	}{
+		{"wrong secret", []byte("other"), fixtureToken, fixtureEventID, now},
+		{"other event", secret, fixtureToken, uuid.New(), now},
+		{"expired", secret, fixtureToken, fixtureEventID, time.Unix(1893456000, 0)},
+		{"tampered", secret, fixtureToken[:len(fixtureToken)-1] + "x", fixtureEventID, now},
+		{"malformed", secret, "not-a-token", fixtureEventID, now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := domain.VerifyInviteToken(tt.secret, tt.token, tt.eventID, tt.now)
+			assert.ErrorIs(t, err, domain.ErrInviteInvalid)
+		})
+	}
+}
+
+func TestHashAccessCode(t *testing.T) {
+	for _, code := range []string{"VIP2025", "vip-2025", " vip 2025 "} {
+		hash, ok := domain.HashAccessCode(fixtureEventID, code)
+		assert.True(t, ok, code)
+		assert.Equal(t, fixtureCodeHash, hash, code)
+	}
+
+	_, ok := domain.HashAccessCode(fixtureEventID, "ab")
+	assert.False(t, ok, "too short")
+	_, ok = domain.HashAccessCode(fixtureEventID, "vip_2025!")
+	assert.False(t, ok, "invalid characters")
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/join-service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// SetEventStartTx records start_time from event.published / event.updated.
+func (r *Repository) SetEventStartTx(ctx context.Context, tx pgx.Tx, eventID uuid.UUID, startsAt time.Time) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE event_capacity
+		SET starts_at = $2, updated_at = NOW()
+		WHERE event_id = $1
+	`, eventID, startsAt.UTC())
+	return err
+}
+
+// ReleaseUserJoinsTx cancels a banned user's active and waitlisted joins on
+// events that have not started, promoting the next waitlister into each
+// freed seat. Past joins are kept as attendance history.
+// Called from the consumer inside ProcessOnce(...); returns how many joins
+// were released.
+func (r *Repository) ReleaseUserJoinsTx(ctx context.Context, tx pgx.Tx, traceID string, userID uuid.UUID, reason string) (int, error) {
+	traceID = strings.TrimSpace(traceID)
+
+	rows, err := tx.Query(ctx, `
+		SELECT j.event_id
+		FROM joins j
+		JOIN event_capacity c ON c.event_id = j.event_id
+		WHERE j.user_id = $1
+		  AND j.status IN ('active', 'waitlisted')
+		  AND (c.starts_at IS NULL OR c.starts_at > NOW())
+		ORDER BY j.event_id
+	`, userID)
+	if err != nil {
+		return 0, err
+	}
+	var eventIDs []uuid.UUID
+	for rows.Next() {
+		var eid uuid.UUID
+		if err := rows.Scan(&eid); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		eventIDs = append(eventIDs, eid)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	released := 0
+	for _, eid := range eventIDs {
+		ok, err := releaseJoinTx(ctx, tx, traceID, eid, userID, reason)
+		if err != nil {
+			return released, err
+		}
+		if ok {
+			released++
+		}
+	}
+	return released, nil
+}
+
+// releaseJoinTx cancels one join on the user's behalf. Locks capacity then
+// the join row, in the same order as CancelJoin.
+func releaseJoinTx(ctx context.Context, tx pgx.Tx, traceID string, eventID, userID uuid.UUID, reason string) (bool, error) {
+	var capacity, waitlistCount int
+	err := tx.QueryRow(ctx, `
+		SELECT capacity, waitlist_count
+		FROM event_capacity
+		WHERE event_id = $1
+		FOR UPDATE
+	`, eventID).Scan(&capacity, &waitlistCount)
+	if err != nil {
+		return false, err
+	}
+
+	var oldStatus string
+	err = tx.QueryRow(ctx, `
+		SELECT status
+		FROM joins
+		WHERE event_id = $1 AND user_id = $2
+		FOR UPDATE
+	`, eventID, userID).Scan(&oldStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	if oldStatus != string(domain.StatusActive) && oldStatus != string(domain.StatusWaitlisted) {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE joins
+		SET status = 'canceled',
+		    canceled_at = NOW(),
+		    canceled_by = NULL,
+		    canceled_reason = $3,
+		    updated_at = NOW()
+		WHERE event_id = $1 AND user_id = $2
+	`, eventID, userID, reason); err != nil {
+		return false, err
+	}
+
+	if err := releaseSeatTx(ctx, tx, traceID, eventID, oldStatus, capacity, waitlistCount); err != nil {
+		return false, err
+	}
+
+	payload, _ := json.Marshal(map[string]any{
+		"event_id":    eventID,
+		"user_id":     userID,
+		"prev_status": oldStatus,
+		"reason":      reason,
+	})
+	if _, err := tx.Exec(ctx,
+		`INSERT INTO outbox (message_id, trace_id, routing_key, payload, occurred_at, status)
+		 VALUES ($1, $2, $3, $4, NOW(), 'pending')`,
+		uuid.New(), traceID, "join.canceled", payload,
+	); err != nil {
+		return false, err
+	}
+	return true, nil
+}
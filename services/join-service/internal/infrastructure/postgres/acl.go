@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/baechuer/real-time-ressys/services/join-service/internal/domain"
 	"github.com/google/uuid"
@@ -11,7 +12,7 @@ import (
 
 func (r *Repository) GetEventOwnerID(ctx context.Context, eventID uuid.UUID) (uuid.UUID, error) {
 	var owner uuid.UUID
-	err := r.pool.QueryRow(ctx, `SELECT owner_id FROM events WHERE id = $1`, eventID).Scan(&owner)
+	err := r.pool.QueryRow(ctx, `SELECT owner_id FROM event_acl WHERE event_id = $1`, eventID).Scan(&owner)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return uuid.UUID{}, domain.ErrEventNotFound
@@ -20,3 +21,60 @@ func (r *Repository) GetEventOwnerID(ctx context.Context, eventID uuid.UUID) (uu
 	}
 	return owner, nil
 }
+
+func (r *Repository) GetEventCollaboratorRole(ctx context.Context, eventID, userID uuid.UUID) (domain.CollaboratorRole, error) {
+	var role string
+	err := r.pool.QueryRow(ctx, `
+		SELECT role FROM event_collaborators WHERE event_id = $1 AND user_id = $2
+	`, eventID, userID).Scan(&role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+	return domain.CollaboratorRole(role), nil
+}
+
+// EnsureEventOwnerTx records the owner from event.published for events that
+// have no team snapshot yet. The row is stamped -infinity so any snapshot
+// replaces it; a snapshot, once present, is authoritative.
+func (r *Repository) EnsureEventOwnerTx(ctx context.Context, tx pgx.Tx, eventID, ownerID uuid.UUID) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO event_acl (event_id, owner_id, synced_at)
+		VALUES ($1, $2, '-infinity')
+		ON CONFLICT (event_id) DO NOTHING
+	`, eventID, ownerID)
+	return err
+}
+
+// ReplaceEventStaffTx applies an organizer team snapshot unless a newer one
+// was already applied (deliveries may be reordered).
+func (r *Repository) ReplaceEventStaffTx(ctx context.Context, tx pgx.Tx, eventID, ownerID uuid.UUID, team []domain.Collaborator, at time.Time) error {
+	tag, err := tx.Exec(ctx, `
+		INSERT INTO event_acl (event_id, owner_id, synced_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (event_id) DO UPDATE
+		SET owner_id = EXCLUDED.owner_id,
+		    synced_at = EXCLUDED.synced_at
+		WHERE event_acl.synced_at <= EXCLUDED.synced_at
+	`, eventID, ownerID, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return nil // stale snapshot
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM event_collaborators WHERE event_id = $1`, eventID); err != nil {
+		return err
+	}
+	for _, c := range team {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO event_collaborators (event_id, user_id, role) VALUES ($1, $2, $3)
+		`, eventID, c.UserID, string(c.Role)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
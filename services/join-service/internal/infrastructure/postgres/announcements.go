@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/join-service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// FanOutAnnouncementTx turns event.announcement.posted into one
+// email.event_announcement outbox row per active participant, inside the
+// consumer's ProcessOnce transaction. Waitlisted users are not notified.
+func (r *Repository) FanOutAnnouncementTx(ctx context.Context, tx pgx.Tx, traceID string, a domain.Announcement) error {
+	traceID = strings.TrimSpace(traceID)
+
+	users, err := joinedUsersTx(ctx, tx, a.EventID, domain.StatusActive)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, uid := range users {
+		payload, _ := json.Marshal(map[string]any{
+			"announcement_id": a.ID.String(),
+			"event_id":        a.EventID.String(),
+			"event_title":     a.EventTitle,
+			"user_id":         uid.String(),
+			"title":           a.Title,
+			"body":            a.Body,
+			"occurred_at":     now.Format(time.RFC3339Nano),
+			"trace_id":        traceID,
+			"producer":        "join-service",
+		})
+
+		if err := insertEmailOutboxTx(ctx, tx, traceID, "email.event_announcement", payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// FanOutEventChangeTx turns event.changed into one email.event_changed
+// outbox row per participant. Waitlisted users are included: a new time or
+// place matters to them too if they get promoted.
+func (r *Repository) FanOutEventChangeTx(ctx context.Context, tx pgx.Tx, traceID string, c domain.EventChange) error {
+	traceID = strings.TrimSpace(traceID)
+
+	users, err := joinedUsersTx(ctx, tx, c.EventID, domain.StatusActive, domain.StatusWaitlisted)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, uid := range users {
+		payload, _ := json.Marshal(map[string]any{
+			"event_id":      c.EventID.String(),
+			"event_title":   c.EventTitle,
+			"event_version": c.Version,
+			"user_id":       uid.String(),
+			"changes":       c.Changes,
+			"occurred_at":   now.Format(time.RFC3339Nano),
+			"trace_id":      traceID,
+			"producer":      "join-service",
+		})
+
+		if err := insertEmailOutboxTx(ctx, tx, traceID, "email.event_changed", payload); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func joinedUsersTx(ctx context.Context, tx pgx.Tx, eventID uuid.UUID, statuses ...domain.JoinStatus) ([]uuid.UUID, error) {
+	st := make([]string, len(statuses))
+	for i, s := range statuses {
+		st[i] = string(s)
+	}
+
+	rows, err := tx.Query(ctx, `
+		SELECT user_id
+		FROM joins
+		WHERE event_id = $1 AND status = ANY($2)`, eventID, st)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []uuid.UUID
+	for rows.Next() {
+		var uid uuid.UUID
+		if err := rows.Scan(&uid); err != nil {
+			return nil, err
+		}
+		users = append(users, uid)
+	}
+	return users, rows.Err()
+}
+
+func insertEmailOutboxTx(ctx context.Context, tx pgx.Tx, traceID, routingKey string, payload []byte) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO outbox (message_id, trace_id, routing_key, payload, occurred_at, status)
+		VALUES ($1, $2, $3, $4, NOW(), 'pending')`,
+		uuid.New(), traceID, routingKey, payload)
+	return err
+}
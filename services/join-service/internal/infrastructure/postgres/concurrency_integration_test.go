@@ -99,7 +99,7 @@ func TestConcurrentJoin_DoesNotOversellCapacity(t *testing.T) {
 		userID := uuid.New()
 		go func(uid uuid.UUID) {
 			defer wg.Done()
-			st, err := repo.JoinEvent(ctx, "trace-concurrent", "", eventID, uid)
+			st, err := repo.JoinEvent(ctx, "trace-concurrent", "", eventID, uid, domain.JoinAccess{})
 			ch <- res{status: st, err: err}
 		}(userID)
 	}
@@ -182,7 +182,7 @@ func TestConcurrentJoin_SameUser_OneRowOnly(t *testing.T) {
 	for i := 0; i < n; i++ {
 		go func() {
 			defer wg.Done()
-			_, err := repo.JoinEvent(ctx, "trace-same-user", "", eventID, userID)
+			_, err := repo.JoinEvent(ctx, "trace-same-user", "", eventID, userID, domain.JoinAccess{})
 			// 允许：nil（幂等返回成功） or ErrAlreadyJoined（你 domain 里有这个）
 			if err != nil && !errors.Is(err, domain.ErrAlreadyJoined) {
 				errs <- err
@@ -231,14 +231,14 @@ func TestConcurrentCancel_PromotesWaitlist_NoDuplicates_NoNegativeCounts(t *test
 	user3 := uuid.New()
 
 	// 1) Join 1
-	status, err := repo.JoinEvent(context.Background(), "t1", "", eventID, user1)
+	status, err := repo.JoinEvent(context.Background(), "t1", "", eventID, user1, domain.JoinAccess{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
 	require.Equal(t, domain.StatusActive, status)
 
 	// 2) Join 2 (Waitlist)
-	status, err = repo.JoinEvent(context.Background(), "t2", "", eventID, user2)
+	status, err = repo.JoinEvent(context.Background(), "t2", "", eventID, user2, domain.JoinAccess{})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -247,12 +247,12 @@ func TestConcurrentCancel_PromotesWaitlist_NoDuplicates_NoNegativeCounts(t *test
 	// 3) Fill waitlist until full. WaitlistMax(1) is 20.
 	// We already have user2 in waitlist (1/20).
 	for i := 0; i < 19; i++ {
-		_, err = repo.JoinEvent(context.Background(), "t-fill", "", eventID, uuid.New())
+		_, err = repo.JoinEvent(context.Background(), "t-fill", "", eventID, uuid.New(), domain.JoinAccess{})
 		require.NoError(t, err)
 	}
 
 	// 4) Join 3 (Full) - This should now actually fail.
-	status, err = repo.JoinEvent(context.Background(), "t3", "", eventID, user3)
+	status, err = repo.JoinEvent(context.Background(), "t3", "", eventID, user3, domain.JoinAccess{})
 	if !errors.Is(err, domain.ErrEventFull) {
 		t.Fatalf("expected full, got %v", err)
 	}
@@ -288,7 +288,7 @@ func TestConcurrentCancel_PromotesWaitlist_NoDuplicates_NoNegativeCounts(t *test
 		go func() {
 			defer wg.Done()
 			uid := uuid.New()
-			_, err := repo.JoinEvent(ctx, "trace-join-after-cancel", "", eventID, uid)
+			_, err := repo.JoinEvent(ctx, "trace-join-after-cancel", "", eventID, uid, domain.JoinAccess{})
 			// 允许 full（极端情况下 waitlist 被顶满）
 			if err != nil && !errors.Is(err, domain.ErrEventFull) {
 				errs <- err
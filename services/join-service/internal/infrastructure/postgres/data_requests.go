@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// dataRequestService names join-service in replies to auth-service.
+	dataRequestService = "join-service"
+
+	deletedReleaseReason = "account_deleted"
+)
+
+// DeleteUserDataTx handles auth.user.deletion_requested: seats on events
+// that have not started are released first (so counts and waitlists stay
+// right), then the user's joins, event bans, idempotency keys and team
+// memberships are deleted and their id is cleared where they acted as an
+// organizer. Events they own keep their team snapshot under a random
+// pseudonym, like event-service does, so that snapshot can still be
+// updated. The user.data.deleted reply goes out through the outbox in the
+// same tx.
+func (r *Repository) DeleteUserDataTx(ctx context.Context, tx pgx.Tx, traceID string, requestID, userID uuid.UUID) error {
+	if _, err := r.ReleaseUserJoinsTx(ctx, tx, traceID, userID, deletedReleaseReason); err != nil {
+		return err
+	}
+
+	for _, q := range []string{
+		`DELETE FROM joins WHERE user_id = $1`,
+		`DELETE FROM event_bans WHERE user_id = $1`,
+		`DELETE FROM idempotency_keys WHERE user_id = $1`,
+		`DELETE FROM event_collaborators WHERE user_id = $1`,
+		`UPDATE joins SET canceled_by = NULL WHERE canceled_by = $1`,
+		`UPDATE joins SET rejected_by = NULL WHERE rejected_by = $1`,
+	} {
+		if _, err := tx.Exec(ctx, q, userID); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec(ctx, `UPDATE event_acl SET owner_id = $2 WHERE owner_id = $1`, userID, uuid.New()); err != nil {
+		return err
+	}
+
+	return insertDataRequestReplyTx(ctx, tx, traceID, "user.data.deleted", requestID, userID, nil)
+}
+
+// ExportUserDataTx handles auth.user.export_requested: the user's joins and
+// event bans are sent back to auth-service as user.data.exported.
+func (r *Repository) ExportUserDataTx(ctx context.Context, tx pgx.Tx, traceID string, requestID, userID uuid.UUID) error {
+	type joinRow struct {
+		EventID        uuid.UUID  `json:"event_id"`
+		Status         string     `json:"status"`
+		CreatedAt      time.Time  `json:"created_at"`
+		ActivatedAt    *time.Time `json:"activated_at,omitempty"`
+		CanceledAt     *time.Time `json:"canceled_at,omitempty"`
+		CanceledReason *string    `json:"canceled_reason,omitempty"`
+	}
+	type banRow struct {
+		EventID   uuid.UUID  `json:"event_id"`
+		Reason    *string    `json:"reason,omitempty"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty"`
+		CreatedAt time.Time  `json:"created_at"`
+	}
+	part := struct {
+		Joins     []joinRow `json:"joins"`
+		EventBans []banRow  `json:"event_bans"`
+	}{Joins: []joinRow{}, EventBans: []banRow{}}
+
+	rows, err := tx.Query(ctx, `
+		SELECT event_id, status::text, created_at, activated_at, canceled_at, canceled_reason
+		FROM joins
+		WHERE user_id = $1
+		ORDER BY created_at, id
+	`, userID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var j joinRow
+		if err := rows.Scan(&j.EventID, &j.Status, &j.CreatedAt, &j.ActivatedAt, &j.CanceledAt, &j.CanceledReason); err != nil {
+			rows.Close()
+			return err
+		}
+		part.Joins = append(part.Joins, j)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = tx.Query(ctx, `
+		SELECT event_id, reason, expires_at, created_at
+		FROM event_bans
+		WHERE user_id = $1
+		ORDER BY created_at
+	`, userID)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var b banRow
+		if err := rows.Scan(&b.EventID, &b.Reason, &b.ExpiresAt, &b.CreatedAt); err != nil {
+			rows.Close()
+			return err
+		}
+		part.EventBans = append(part.EventBans, b)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(part)
+	if err != nil {
+		return err
+	}
+	return insertDataRequestReplyTx(ctx, tx, traceID, "user.data.exported", requestID, userID, data)
+}
+
+func insertDataRequestReplyTx(ctx context.Context, tx pgx.Tx, traceID, routingKey string, requestID, userID uuid.UUID, data json.RawMessage) error {
+	body := map[string]any{
+		"request_id": requestID,
+		"user_id":    userID,
+		"service":    dataRequestService,
+	}
+	if data != nil {
+		body["data"] = data
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx,
+		`INSERT INTO outbox (message_id, trace_id, routing_key, payload, occurred_at, status)
+		 VALUES ($1, $2, $3, $4, NOW(), 'pending')`,
+		uuid.New(), strings.TrimSpace(traceID), routingKey, payload,
+	)
+	return err
+}
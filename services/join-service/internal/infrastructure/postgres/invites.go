@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/baechuer/real-time-ressys/services/join-service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// redeemInviteTx gates joins on invite_only events. It locks and consumes one
+// use of the presented invite and returns its id; for other events it is a
+// no-op. Runs before the capacity lock (see the deadlock policy), so a
+// failed join rolls the use back with the rest of the tx.
+func redeemInviteTx(ctx context.Context, tx pgx.Tx, eventID uuid.UUID, access domain.JoinAccess) (uuid.UUID, error) {
+	var visibility string
+	err := tx.QueryRow(ctx, `SELECT visibility FROM event_capacity WHERE event_id = $1`, eventID).Scan(&visibility)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, nil // the capacity lock reports ErrEventNotKnown
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if visibility != domain.VisibilityInviteOnly {
+		return uuid.Nil, nil
+	}
+	if access.Empty() {
+		return uuid.Nil, domain.ErrInviteRequired
+	}
+
+	var inviteID uuid.UUID
+	var maxUses *int
+	var uses int
+	var expiresAt, revokedAt *time.Time
+	if access.InviteID != uuid.Nil {
+		err = tx.QueryRow(ctx, `
+			SELECT id, max_uses, uses, expires_at, revoked_at
+			FROM event_invites
+			WHERE id = $1 AND event_id = $2 AND kind = 'link'
+			FOR UPDATE
+		`, access.InviteID, eventID).Scan(&inviteID, &maxUses, &uses, &expiresAt, &revokedAt)
+	} else {
+		err = tx.QueryRow(ctx, `
+			SELECT id, max_uses, uses, expires_at, revoked_at
+			FROM event_invites
+			WHERE event_id = $1 AND code_hash = $2 AND kind = 'code' AND revoked_at IS NULL
+			FOR UPDATE
+		`, eventID, access.CodeHash).Scan(&inviteID, &maxUses, &uses, &expiresAt, &revokedAt)
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, domain.ErrInviteInvalid
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	now := time.Now()
+	if revokedAt != nil || (expiresAt != nil && !now.Before(*expiresAt)) || (maxUses != nil && uses >= *maxUses) {
+		return uuid.Nil, domain.ErrInviteInvalid
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE event_invites SET uses = uses + 1 WHERE id = $1`, inviteID); err != nil {
+		return uuid.Nil, err
+	}
+	return inviteID, nil
+}
+
+// SetEventVisibilityTx records the visibility carried by event.published.
+func (r *Repository) SetEventVisibilityTx(ctx context.Context, tx pgx.Tx, eventID uuid.UUID, visibility string) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE event_capacity SET visibility = $2, updated_at = NOW() WHERE event_id = $1
+	`, eventID, visibility)
+	return err
+}
+
+// UpsertInviteTx applies event.invite.created. A revoke that arrived first
+// is kept.
+func (r *Repository) UpsertInviteTx(ctx context.Context, tx pgx.Tx, inv domain.Invite) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO event_invites (id, event_id, kind, code_hash, max_uses, expires_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6)
+		ON CONFLICT (id) DO UPDATE
+		SET kind = EXCLUDED.kind,
+		    code_hash = EXCLUDED.code_hash,
+		    max_uses = EXCLUDED.max_uses,
+		    expires_at = EXCLUDED.expires_at
+	`, inv.ID, inv.EventID, inv.Kind, inv.CodeHash, inv.MaxUses, inv.ExpiresAt)
+	return err
+}
+
+// RevokeInviteTx applies event.invite.revoked, creating a tombstone if the
+// create has not arrived yet.
+func (r *Repository) RevokeInviteTx(ctx context.Context, tx pgx.Tx, inviteID, eventID uuid.UUID, at time.Time) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO event_invites (id, event_id, revoked_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE
+		SET revoked_at = COALESCE(event_invites.revoked_at, EXCLUDED.revoked_at)
+	`, inviteID, eventID, at)
+	return err
+}
@@ -75,50 +75,8 @@ func (r *Repository) Kick(ctx context.Context, traceID string, eventID, targetUs
 	}
 
 	// counters + promotion
-	if oldStatus == string(domain.StatusActive) {
-		_, _ = tx.Exec(ctx, `UPDATE event_capacity SET active_count = active_count - 1, updated_at = NOW() WHERE event_id = $1`, eventID)
-
-		if waitlistCount > 0 && capacity != 0 && capacity >= 0 {
-			var promoUserID uuid.UUID
-			err = tx.QueryRow(ctx, `
-				SELECT user_id
-				FROM joins
-				WHERE event_id = $1 AND status = 'waitlisted'
-				ORDER BY created_at ASC, id ASC
-				LIMIT 1
-				FOR UPDATE SKIP LOCKED
-			`, eventID).Scan(&promoUserID)
-
-			if err == nil {
-				_, _ = tx.Exec(ctx,
-					`UPDATE joins SET status='active', activated_at=NOW(), updated_at=NOW()
-					 WHERE event_id=$1 AND user_id=$2`,
-					eventID, promoUserID,
-				)
-				_, _ = tx.Exec(ctx, `
-					UPDATE event_capacity
-					SET active_count = active_count + 1,
-					    waitlist_count = waitlist_count - 1,
-					    updated_at = NOW()
-					WHERE event_id = $1
-				`, eventID)
-
-				payload, _ := json.Marshal(map[string]any{
-					"event_id": eventID,
-					"user_id":  promoUserID,
-					"reason":   "slot_freed",
-				})
-				_, _ = tx.Exec(ctx,
-					`INSERT INTO outbox (message_id, trace_id, routing_key, payload, occurred_at, status)
-					 VALUES ($1,$2,$3,$4,NOW(),'pending')`,
-					uuid.New(), traceID, "join.promoted", payload,
-				)
-			} else if !errors.Is(err, pgx.ErrNoRows) {
-				return err
-			}
-		}
-	} else if oldStatus == string(domain.StatusWaitlisted) {
-		_, _ = tx.Exec(ctx, `UPDATE event_capacity SET waitlist_count = waitlist_count - 1, updated_at = NOW() WHERE event_id = $1`, eventID)
+	if err := releaseSeatTx(ctx, tx, traceID, eventID, oldStatus, capacity, waitlistCount); err != nil {
+		return err
 	}
 
 	payload, _ := json.Marshal(map[string]any{
@@ -185,31 +143,13 @@ func (r *Repository) Ban(ctx context.Context, traceID string, eventID, targetUse
 			WHERE event_id=$1 AND user_id=$2
 		`, eventID, targetUserID, actorID, "banned:"+reason)
 
-		// counters/promotion: simplest is call a small helper; but keep short:
-		// lock capacity row and adjust counts
+		// lock capacity row, then adjust counts and promote
 		var capacity, waitlistCount int
 		if err2 := tx.QueryRow(ctx, `
 			SELECT capacity, waitlist_count FROM event_capacity WHERE event_id=$1 FOR UPDATE
 		`, eventID).Scan(&capacity, &waitlistCount); err2 == nil {
-
-			if oldStatus == string(domain.StatusActive) {
-				_, _ = tx.Exec(ctx, `UPDATE event_capacity SET active_count=active_count-1, updated_at=NOW() WHERE event_id=$1`, eventID)
-				if waitlistCount > 0 && capacity != 0 && capacity >= 0 {
-					var promoUserID uuid.UUID
-					err3 := tx.QueryRow(ctx, `
-						SELECT user_id FROM joins
-						WHERE event_id=$1 AND status='waitlisted'
-						ORDER BY created_at ASC, id ASC
-						LIMIT 1
-						FOR UPDATE SKIP LOCKED
-					`, eventID).Scan(&promoUserID)
-					if err3 == nil {
-						_, _ = tx.Exec(ctx, `UPDATE joins SET status='active', activated_at=NOW(), updated_at=NOW() WHERE event_id=$1 AND user_id=$2`, eventID, promoUserID)
-						_, _ = tx.Exec(ctx, `UPDATE event_capacity SET active_count=active_count+1, waitlist_count=waitlist_count-1, updated_at=NOW() WHERE event_id=$1`, eventID)
-					}
-				}
-			} else if oldStatus == string(domain.StatusWaitlisted) {
-				_, _ = tx.Exec(ctx, `UPDATE event_capacity SET waitlist_count=waitlist_count-1, updated_at=NOW() WHERE event_id=$1`, eventID)
+			if err := releaseSeatTx(ctx, tx, traceID, eventID, oldStatus, capacity, waitlistCount); err != nil {
+				return err
 			}
 		}
 	}
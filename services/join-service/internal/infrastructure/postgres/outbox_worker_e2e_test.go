@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/baechuer/real-time-ressys/services/join-service/internal/domain"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -140,7 +141,7 @@ func TestOutboxWorker_E2E_PublishesAndMarksSent(t *testing.T) {
 	require.NoError(t, repo.InitCapacity(ctx, eventID, 1))
 
 	// 触发 outbox：JoinEvent 会插入 join.created
-	_, err := repo.JoinEvent(ctx, traceID, "", eventID, userID)
+	_, err := repo.JoinEvent(ctx, traceID, "", eventID, userID, domain.JoinAccess{})
 	require.NoError(t, err)
 
 	workerCtx, workerCancel := context.WithCancel(ctx)
@@ -186,7 +187,7 @@ func TestOutboxWorker_MandatoryNoRoute_IncrementsAttemptAndSchedulesRetry(t *tes
 	traceID := "trace-outbox-noroute"
 
 	require.NoError(t, repo.InitCapacity(ctx, eventID, 1))
-	_, err = repo.JoinEvent(ctx, traceID, "", eventID, userID)
+	_, err = repo.JoinEvent(ctx, traceID, "", eventID, userID, domain.JoinAccess{})
 	require.NoError(t, err)
 
 	workerCtx, workerCancel := context.WithCancel(ctx)
@@ -230,7 +231,7 @@ func TestOutboxWorker_Idempotent_NoDoubleSendAfterSent(t *testing.T) {
 	traceID := "trace-outbox-idem"
 
 	require.NoError(t, repo.InitCapacity(ctx, eventID, 1))
-	_, err := repo.JoinEvent(ctx, traceID, "", eventID, userID)
+	_, err := repo.JoinEvent(ctx, traceID, "", eventID, userID, domain.JoinAccess{})
 	require.NoError(t, err)
 
 	msgs, err := ch.Consume(q.Name, "", true, true, false, false, nil)
@@ -0,0 +1,20 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// SetRegistrationWindowTx records the window carried by event.published /
+// event.updated. Each message is a full snapshot, so nil clears a bound.
+func (r *Repository) SetRegistrationWindowTx(ctx context.Context, tx pgx.Tx, eventID uuid.UUID, opensAt, closesAt *time.Time) error {
+	_, err := tx.Exec(ctx, `
+		UPDATE event_capacity
+		SET registration_opens_at = $2, registration_closes_at = $3, updated_at = NOW()
+		WHERE event_id = $1
+	`, eventID, opensAt, closesAt)
+	return err
+}
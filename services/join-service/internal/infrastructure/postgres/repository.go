@@ -24,13 +24,14 @@ func New(pool *pgxpool.Pool) *Repository {
 // -------------------------
 // Deadlock policy:
 // Always lock in this order (for the same event_id):
+//   0) event_invites row (FOR UPDATE), JoinEvent on invite_only events only
 //   1) event_capacity row (FOR UPDATE)
 //   2) joins row for (event_id,user_id) if needed (FOR UPDATE)
 //   3) optional waitlist row (FOR UPDATE SKIP LOCKED)
 // This prevents cycles between JoinEvent/CancelJoin/Consumer(event.canceled).
 // -------------------------
 
-func (r *Repository) JoinEvent(ctx context.Context, traceID, idempotencyKey string, eventID, userID uuid.UUID) (domain.JoinStatus, error) {
+func (r *Repository) JoinEvent(ctx context.Context, traceID, idempotencyKey string, eventID, userID uuid.UUID, access domain.JoinAccess) (domain.JoinStatus, error) {
 	traceID = strings.TrimSpace(traceID)
 	idempotencyKey = strings.TrimSpace(idempotencyKey)
 
@@ -67,14 +68,23 @@ func (r *Repository) JoinEvent(ctx context.Context, traceID, idempotencyKey stri
 		}
 	}
 
+	// 0.5) Invite gate, before the capacity lock
+	inviteID, err := redeemInviteTx(ctx, tx, eventID, access)
+	if err != nil {
+		return "", err
+	}
+
 	// 1) Lock capacity FIRST (global lock for this event_id)
 	var capacity, activeCount, waitlistCount int
+	var notOpenYet, closed bool
 	err = tx.QueryRow(ctx, `
-		SELECT capacity, active_count, waitlist_count
+		SELECT capacity, active_count, waitlist_count,
+		       COALESCE(registration_opens_at > NOW(), FALSE),
+		       COALESCE(registration_closes_at <= NOW(), FALSE)
 		FROM event_capacity
 		WHERE event_id = $1
 		FOR UPDATE
-	`, eventID).Scan(&capacity, &activeCount, &waitlistCount)
+	`, eventID).Scan(&capacity, &activeCount, &waitlistCount, &notOpenYet, &closed)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return "", domain.ErrEventNotKnown
@@ -85,6 +95,13 @@ func (r *Repository) JoinEvent(ctx context.Context, traceID, idempotencyKey stri
 	if capacity < 0 {
 		return "", domain.ErrEventClosed
 	}
+	// 1.5) Registration window from event.published
+	if notOpenYet {
+		return "", domain.ErrRegistrationNotOpen
+	}
+	if closed {
+		return "", domain.ErrRegistrationClosed
+	}
 
 	// 2) Ban check (same tx)
 	var banned bool
@@ -172,11 +189,15 @@ func (r *Repository) JoinEvent(ctx context.Context, traceID, idempotencyKey stri
 	}
 
 	// 6) Outbox (join.created)
-	payload, _ := json.Marshal(map[string]any{
+	msg := map[string]any{
 		"event_id": eventID,
 		"user_id":  userID,
 		"status":   newStatus,
-	})
+	}
+	if inviteID != uuid.Nil {
+		msg["invite_id"] = inviteID
+	}
+	payload, _ := json.Marshal(msg)
 	_, _ = tx.Exec(ctx,
 		`INSERT INTO outbox (message_id, trace_id, routing_key, payload, occurred_at, status) VALUES ($1, $2, $3, $4, NOW(), 'pending')`,
 		uuid.New(), traceID, "join.created", payload,
@@ -279,51 +300,8 @@ func (r *Repository) CancelJoin(ctx context.Context, traceID, idempotencyKey str
 	}
 
 	// 4) Counters + auto-promotion if freed slot
-	if oldStatus == string(domain.StatusActive) {
-		_, _ = tx.Exec(ctx, `UPDATE event_capacity SET active_count = active_count - 1, updated_at = NOW() WHERE event_id = $1`, eventID)
-
-		if waitlistCount > 0 && capacity != 0 && capacity >= 0 {
-			var promoUserID uuid.UUID
-			err = tx.QueryRow(ctx, `
-				SELECT user_id
-				FROM joins
-				WHERE event_id = $1 AND status = 'waitlisted'
-				ORDER BY created_at ASC, id ASC
-				LIMIT 1
-				FOR UPDATE SKIP LOCKED
-			`, eventID).Scan(&promoUserID)
-
-			if err == nil {
-				_, _ = tx.Exec(ctx,
-					`UPDATE joins
-					 SET status = 'active', activated_at = NOW(), updated_at = NOW()
-					 WHERE event_id = $1 AND user_id = $2`,
-					eventID, promoUserID,
-				)
-				_, _ = tx.Exec(ctx, `
-					UPDATE event_capacity
-					SET active_count = active_count + 1,
-					    waitlist_count = waitlist_count - 1,
-					    updated_at = NOW()
-					WHERE event_id = $1
-				`, eventID)
-
-				payload, _ := json.Marshal(map[string]any{
-					"event_id": eventID,
-					"user_id":  promoUserID,
-					"reason":   "slot_freed",
-				})
-				_, _ = tx.Exec(ctx,
-					`INSERT INTO outbox (message_id, trace_id, routing_key, payload, occurred_at, status)
-					 VALUES ($1, $2, $3, $4, NOW(), 'pending')`,
-					uuid.New(), traceID, "join.promoted", payload,
-				)
-			} else if !errors.Is(err, pgx.ErrNoRows) {
-				return err
-			}
-		}
-	} else if oldStatus == string(domain.StatusWaitlisted) {
-		_, _ = tx.Exec(ctx, `UPDATE event_capacity SET waitlist_count = waitlist_count - 1, updated_at = NOW() WHERE event_id = $1`, eventID)
+	if err := releaseSeatTx(ctx, tx, traceID, eventID, oldStatus, capacity, waitlistCount); err != nil {
+		return err
 	}
 
 	// 5) Outbox
@@ -7,6 +7,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/baechuer/real-time-ressys/services/join-service/internal/domain"
 	"github.com/baechuer/real-time-ressys/services/join-service/internal/infrastructure/postgres"
@@ -53,7 +54,7 @@ func TestJoinFlow_CapacityLimits(t *testing.T) {
 
 	// 2. User A joins: Should be 'active' as it's the first person.
 	u1 := uuid.New()
-	status, err := repo.JoinEvent(ctx, "trace-1", "", eventID, u1)
+	status, err := repo.JoinEvent(ctx, "trace-1", "", eventID, u1, domain.JoinAccess{})
 	assert.NoError(t, err)
 	assert.Equal(t, domain.StatusActive, status)
 
@@ -64,7 +65,7 @@ func TestJoinFlow_CapacityLimits(t *testing.T) {
 
 	// 3. User B joins: Capacity is full, so they must be 'waitlisted'.
 	u2 := uuid.New()
-	status, err = repo.JoinEvent(ctx, "trace-2", "", eventID, u2)
+	status, err = repo.JoinEvent(ctx, "trace-2", "", eventID, u2, domain.JoinAccess{})
 	assert.NoError(t, err)
 	assert.Equal(t, domain.StatusWaitlisted, status)
 
@@ -85,8 +86,8 @@ func TestCancelJoin_PromotesWaitlist(t *testing.T) {
 	repo.InitCapacity(ctx, eventID, 1)
 
 	// U1 gets the active slot, U2 goes to waitlist.
-	repo.JoinEvent(ctx, "t1", "", eventID, u1)
-	repo.JoinEvent(ctx, "t2", "", eventID, u2)
+	repo.JoinEvent(ctx, "t1", "", eventID, u1, domain.JoinAccess{})
+	repo.JoinEvent(ctx, "t2", "", eventID, u2, domain.JoinAccess{})
 
 	// U1 cancels their participation.
 	err := repo.CancelJoin(ctx, "t3", "", eventID, u1)
@@ -118,16 +119,16 @@ func TestJoin_Idempotency(t *testing.T) {
 	repo.InitCapacity(ctx, eventID, 1) // Start with capacity 1
 
 	// 1. Join A
-	status, err := repo.JoinEvent(context.Background(), "trace1", "", eventID, userA)
+	status, err := repo.JoinEvent(context.Background(), "trace1", "", eventID, userA, domain.JoinAccess{})
 	require.NoError(t, err)
 	require.Equal(t, domain.StatusActive, status)
 
 	// 2. Join A again -> AlreadyJoined
-	_, err = repo.JoinEvent(context.Background(), "trace2", "", eventID, userA)
+	_, err = repo.JoinEvent(context.Background(), "trace2", "", eventID, userA, domain.JoinAccess{})
 	require.ErrorIs(t, err, domain.ErrAlreadyJoined)
 
 	// 3. User B joins -> Waitlisted
-	status, err = repo.JoinEvent(context.Background(), "trace3", "", eventID, userB)
+	status, err = repo.JoinEvent(context.Background(), "trace3", "", eventID, userB, domain.JoinAccess{})
 	require.NoError(t, err)
 	require.Equal(t, domain.StatusWaitlisted, status)
 
@@ -137,7 +138,7 @@ func TestJoin_Idempotency(t *testing.T) {
 
 	// 5. Check B promoted (by listing participants or join event)
 	// Using JoinEvent for "get status" via err check is hacky but confirms status
-	status, err = repo.JoinEvent(context.Background(), "trace5", "", eventID, userB)
+	status, err = repo.JoinEvent(context.Background(), "trace5", "", eventID, userB, domain.JoinAccess{})
 	// Should be AlreadyJoined (logic) but actually we can check DB or List
 	require.ErrorIs(t, err, domain.ErrAlreadyJoined)
 	// We can't easily check current status via JoinEvent return value when it errors,
@@ -155,12 +156,12 @@ func TestJoin_Idempotency(t *testing.T) {
 	require.NoError(t, err)
 
 	// 7. Join C -> Active
-	status, err = repo.JoinEvent(context.Background(), "trace6", "", eventID, userC)
+	status, err = repo.JoinEvent(context.Background(), "trace6", "", eventID, userC, domain.JoinAccess{})
 	require.NoError(t, err)
 	require.Equal(t, domain.StatusActive, status)
 
 	// 8. Join D -> Active
-	status, err = repo.JoinEvent(context.Background(), "trace7", "", eventID, userD)
+	status, err = repo.JoinEvent(context.Background(), "trace7", "", eventID, userD, domain.JoinAccess{})
 	require.NoError(t, err)
 	require.Equal(t, domain.StatusActive, status)
 }
@@ -177,7 +178,7 @@ func TestHandleEventCanceled(t *testing.T) {
 
 	u1 := uuid.New()
 	// User must be successfully joined as 'active' before testing the cancel flow.
-	status, err := repo.JoinEvent(ctx, "trace-setup", "", eventID, u1)
+	status, err := repo.JoinEvent(ctx, "trace-setup", "", eventID, u1, domain.JoinAccess{})
 	require.NoError(t, err)
 	require.Equal(t, domain.StatusActive, status)
 
@@ -201,6 +202,36 @@ func TestHandleEventCanceled(t *testing.T) {
 	assert.Equal(t, "expired", finalStatus)
 }
 
+// TestJoin_RegistrationWindow verifies the window from event.published is
+// enforced under the capacity lock.
+func TestJoin_RegistrationWindow(t *testing.T) {
+	repo, pool := setupRepo(t)
+	ctx := context.Background()
+	eventID := uuid.New()
+	require.NoError(t, repo.InitCapacity(ctx, eventID, 10))
+
+	setWindow := func(opens, closes *time.Time) {
+		tx, err := pool.Begin(ctx)
+		require.NoError(t, err)
+		require.NoError(t, repo.SetRegistrationWindowTx(ctx, tx, eventID, opens, closes))
+		require.NoError(t, tx.Commit(ctx))
+	}
+	future, past := time.Now().Add(time.Hour), time.Now().Add(-time.Hour)
+
+	setWindow(&future, nil)
+	_, err := repo.JoinEvent(ctx, "t-early", "", eventID, uuid.New(), domain.JoinAccess{})
+	assert.ErrorIs(t, err, domain.ErrRegistrationNotOpen)
+
+	setWindow(&past, &past)
+	_, err = repo.JoinEvent(ctx, "t-late", "", eventID, uuid.New(), domain.JoinAccess{})
+	assert.ErrorIs(t, err, domain.ErrRegistrationClosed)
+
+	setWindow(&past, &future)
+	status, err := repo.JoinEvent(ctx, "t-open", "", eventID, uuid.New(), domain.JoinAccess{})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusActive, status)
+}
+
 // TestProcessedMessages_Deduplication verifies the idempotency fence for incoming messages[cite: 56].
 func TestProcessedMessages_Deduplication(t *testing.T) {
 	repo, _ := setupRepo(t)
@@ -219,3 +250,66 @@ func TestProcessedMessages_Deduplication(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, ok)
 }
+
+// TestFanOutAnnouncement verifies only active participants get an announcement email.
+func TestFanOutAnnouncement(t *testing.T) {
+	repo, pool := setupRepo(t)
+	ctx := context.Background()
+	eventID := uuid.New()
+
+	require.NoError(t, repo.InitCapacity(ctx, eventID, 1))
+	active, waitlisted := uuid.New(), uuid.New()
+	status, err := repo.JoinEvent(ctx, "trace-setup", "", eventID, active, domain.JoinAccess{})
+	require.NoError(t, err)
+	require.Equal(t, domain.StatusActive, status)
+	status, err = repo.JoinEvent(ctx, "trace-setup", "", eventID, waitlisted, domain.JoinAccess{})
+	require.NoError(t, err)
+	require.Equal(t, domain.StatusWaitlisted, status)
+
+	tx, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback(ctx) }()
+	require.NoError(t, repo.FanOutAnnouncementTx(ctx, tx, "trace-ann", domain.Announcement{
+		ID: uuid.New(), EventID: eventID, EventTitle: "Meetup", Title: "Room change", Body: "Room 4B",
+	}))
+	require.NoError(t, tx.Commit(ctx))
+
+	var n int
+	var userID string
+	err = pool.QueryRow(ctx,
+		"SELECT count(*), max(payload->>'user_id') FROM outbox WHERE routing_key = 'email.event_announcement' AND trace_id = 'trace-ann'",
+	).Scan(&n, &userID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n, "waitlisted users are not notified")
+	assert.Equal(t, active.String(), userID)
+}
+
+func TestFanOutEventChange(t *testing.T) {
+	repo, pool := setupRepo(t)
+	ctx := context.Background()
+	eventID := uuid.New()
+
+	require.NoError(t, repo.InitCapacity(ctx, eventID, 1))
+	for range 2 {
+		_, err := repo.JoinEvent(ctx, "trace-setup", "", eventID, uuid.New(), domain.JoinAccess{})
+		require.NoError(t, err)
+	}
+
+	tx, err := pool.Begin(ctx)
+	require.NoError(t, err)
+	defer func() { _ = tx.Rollback(ctx) }()
+	require.NoError(t, repo.FanOutEventChangeTx(ctx, tx, "trace-chg", domain.EventChange{
+		EventID: eventID, EventTitle: "Meetup", Version: 3,
+		Changes: []byte(`{"city":{"from":"Sydney","to":"Melbourne"}}`),
+	}))
+	require.NoError(t, tx.Commit(ctx))
+
+	var n int
+	var city string
+	err = pool.QueryRow(ctx,
+		"SELECT count(*), max(payload->'changes'->'city'->>'to') FROM outbox WHERE routing_key = 'email.event_changed' AND trace_id = 'trace-chg'",
+	).Scan(&n, &city)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n, "active and waitlisted users are notified")
+	assert.Equal(t, "Melbourne", city)
+}
@@ -0,0 +1,79 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/baechuer/real-time-ressys/services/join-service/internal/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// releaseSeatTx updates the counters after a join left oldStatus. A freed
+// active seat goes to the oldest waitlister (join.promoted is queued for
+// them); unlimited (0) and closed (-1) events have no waitlist to promote.
+// The caller holds the event_capacity row lock and read capacity and
+// waitlistCount under it.
+func releaseSeatTx(ctx context.Context, tx pgx.Tx, traceID string, eventID uuid.UUID, oldStatus string, capacity, waitlistCount int) error {
+	switch oldStatus {
+	case string(domain.StatusWaitlisted):
+		_, err := tx.Exec(ctx, `UPDATE event_capacity SET waitlist_count = waitlist_count - 1, updated_at = NOW() WHERE event_id = $1`, eventID)
+		return err
+	case string(domain.StatusActive):
+	default:
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE event_capacity SET active_count = active_count - 1, updated_at = NOW() WHERE event_id = $1`, eventID); err != nil {
+		return err
+	}
+	if waitlistCount <= 0 || capacity <= 0 {
+		return nil
+	}
+
+	var promoUserID uuid.UUID
+	err := tx.QueryRow(ctx, `
+		SELECT user_id
+		FROM joins
+		WHERE event_id = $1 AND status = 'waitlisted'
+		ORDER BY created_at ASC, id ASC
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED
+	`, eventID).Scan(&promoUserID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE joins
+		SET status = 'active', activated_at = NOW(), updated_at = NOW()
+		WHERE event_id = $1 AND user_id = $2
+	`, eventID, promoUserID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		UPDATE event_capacity
+		SET active_count = active_count + 1,
+		    waitlist_count = waitlist_count - 1,
+		    updated_at = NOW()
+		WHERE event_id = $1
+	`, eventID); err != nil {
+		return err
+	}
+
+	payload, _ := json.Marshal(map[string]any{
+		"event_id": eventID,
+		"user_id":  promoUserID,
+		"reason":   "slot_freed",
+	})
+	_, err = tx.Exec(ctx,
+		`INSERT INTO outbox (message_id, trace_id, routing_key, payload, occurred_at, status)
+		 VALUES ($1, $2, $3, $4, NOW(), 'pending')`,
+		uuid.New(), traceID, "join.promoted", payload,
+	)
+	return err
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"strings"
+	"time"
 
 	"github.com/baechuer/real-time-ressys/services/join-service/internal/contracts/event"
 	"github.com/baechuer/real-time-ressys/services/join-service/internal/domain"
@@ -22,6 +23,23 @@ const (
 	rkEventPublished = "event.published"
 	rkEventUpdated   = "event.updated"
 	rkEventCanceled  = "event.canceled"
+
+	rkCollaboratorsUpdated = "event.collaborators.updated"
+	rkOwnerTransferred     = "event.owner_transferred"
+
+	rkInviteCreated = "event.invite.created"
+	rkInviteRevoked = "event.invite.revoked"
+
+	rkAnnouncementPosted = "event.announcement.posted"
+	rkEventChanged       = "event.changed"
+
+	// auth.user.unbanned is not consumed: unbanning restores nothing.
+	rkUserBanned = "auth.user.banned"
+
+	bannedReleaseReason = "account_banned"
+
+	rkDeletionRequested = "auth.user.deletion_requested"
+	rkExportRequested   = "auth.user.export_requested"
 )
 
 type Consumer struct {
@@ -98,7 +116,7 @@ func (c *Consumer) Start(ctx context.Context) error {
 		return err
 	}
 
-	for _, rk := range []string{rkEventPublished, rkEventUpdated, rkEventCanceled} {
+	for _, rk := range []string{rkEventPublished, rkEventUpdated, rkEventCanceled, rkCollaboratorsUpdated, rkOwnerTransferred, rkInviteCreated, rkInviteRevoked, rkAnnouncementPosted, rkEventChanged, rkUserBanned, rkDeletionRequested, rkExportRequested} {
 		if err := ch.QueueBind(q.Name, rk, c.exchange, false, nil); err != nil {
 			_ = ch.Close()
 			_ = conn.Close()
@@ -293,7 +311,50 @@ func applySnapshotTx(
 			log.Warn().Err(err).Msg("invalid event_id; dropping")
 			return nil
 		}
-		return r.InitCapacityTx(ctx, tx, eid, *p.Capacity)
+		if err := r.InitCapacityTx(ctx, tx, eid, *p.Capacity); err != nil {
+			return err
+		}
+
+		type visibilityHandler interface {
+			SetEventVisibilityTx(ctx context.Context, tx pgx.Tx, eventID uuid.UUID, visibility string) error
+		}
+		if h, ok := any(r).(visibilityHandler); ok && p.Visibility != "" {
+			if err := h.SetEventVisibilityTx(ctx, tx, eid, p.Visibility); err != nil {
+				return err
+			}
+		}
+
+		type registrationHandler interface {
+			SetRegistrationWindowTx(ctx context.Context, tx pgx.Tx, eventID uuid.UUID, opensAt, closesAt *time.Time) error
+		}
+		if h, ok := any(r).(registrationHandler); ok {
+			if err := h.SetRegistrationWindowTx(ctx, tx, eid, p.RegistrationOpensAt, p.RegistrationClosesAt); err != nil {
+				return err
+			}
+		}
+
+		type startHandler interface {
+			SetEventStartTx(ctx context.Context, tx pgx.Tx, eventID uuid.UUID, startsAt time.Time) error
+		}
+		if h, ok := any(r).(startHandler); ok && p.StartTime != nil {
+			if err := h.SetEventStartTx(ctx, tx, eid, *p.StartTime); err != nil {
+				return err
+			}
+		}
+
+		// Seed the ACL owner until event-service sends a team snapshot
+		type ownerHandler interface {
+			EnsureEventOwnerTx(ctx context.Context, tx pgx.Tx, eventID, ownerID uuid.UUID) error
+		}
+		if h, ok := any(r).(ownerHandler); ok && strings.TrimSpace(p.OwnerID) != "" {
+			owner, err := uuid.Parse(p.OwnerID)
+			if err != nil {
+				log.Warn().Err(err).Msg("invalid owner_id; skipping acl")
+				return nil
+			}
+			return h.EnsureEventOwnerTx(ctx, tx, eid, owner)
+		}
+		return nil
 
 	case rkEventCanceled:
 		var p event.EventCanceledPayload
@@ -336,6 +397,216 @@ func applySnapshotTx(
 		// Fallback: at least close snapshot
 		return r.InitCapacityTx(ctx, tx, eid, -1)
 
+	case rkCollaboratorsUpdated, rkOwnerTransferred:
+		var p event.EventCollaboratorsPayload
+		if err := json.Unmarshal(raw, &p); err != nil {
+			log.Warn().Err(err).Msg("invalid payload json; dropping")
+			return nil
+		}
+		eid, err := uuid.Parse(strings.TrimSpace(p.EventID))
+		if err != nil {
+			log.Warn().Err(err).Msg("invalid event_id; dropping")
+			return nil
+		}
+		owner, err := uuid.Parse(strings.TrimSpace(p.OwnerID))
+		if err != nil || p.UpdatedAt.IsZero() {
+			log.Warn().Msg("missing owner_id or updated_at; dropping")
+			return nil
+		}
+
+		team := make([]domain.Collaborator, 0, len(p.Collaborators))
+		for _, c := range p.Collaborators {
+			uid, err := uuid.Parse(strings.TrimSpace(c.UserID))
+			if err != nil {
+				log.Warn().Str("user_id", c.UserID).Msg("invalid collaborator user_id; skipping")
+				continue
+			}
+			team = append(team, domain.Collaborator{UserID: uid, Role: domain.CollaboratorRole(c.Role)})
+		}
+
+		type staffHandler interface {
+			ReplaceEventStaffTx(ctx context.Context, tx pgx.Tx, eventID, ownerID uuid.UUID, team []domain.Collaborator, at time.Time) error
+		}
+		if h, ok := any(r).(staffHandler); ok {
+			return h.ReplaceEventStaffTx(ctx, tx, eid, owner, team, p.UpdatedAt)
+		}
+		log.Warn().Msg("repo does not support organizer teams; ignoring")
+		return nil
+
+	case rkInviteCreated, rkInviteRevoked:
+		var p event.EventInvitePayload
+		if err := json.Unmarshal(raw, &p); err != nil {
+			log.Warn().Err(err).Msg("invalid payload json; dropping")
+			return nil
+		}
+		inviteID, err := uuid.Parse(strings.TrimSpace(p.InviteID))
+		if err != nil {
+			log.Warn().Err(err).Msg("invalid invite_id; dropping")
+			return nil
+		}
+		eid, err := uuid.Parse(strings.TrimSpace(p.EventID))
+		if err != nil {
+			log.Warn().Err(err).Msg("invalid event_id; dropping")
+			return nil
+		}
+
+		type inviteHandler interface {
+			UpsertInviteTx(ctx context.Context, tx pgx.Tx, inv domain.Invite) error
+			RevokeInviteTx(ctx context.Context, tx pgx.Tx, inviteID, eventID uuid.UUID, at time.Time) error
+		}
+		h, ok := any(r).(inviteHandler)
+		if !ok {
+			log.Warn().Msg("repo does not support invites; ignoring")
+			return nil
+		}
+
+		if routingKey == rkInviteRevoked {
+			at := time.Now().UTC()
+			if p.RevokedAt != nil {
+				at = *p.RevokedAt
+			}
+			return h.RevokeInviteTx(ctx, tx, inviteID, eid, at)
+		}
+		if p.Kind != "link" && p.Kind != "code" {
+			log.Warn().Str("kind", p.Kind).Msg("unknown invite kind; dropping")
+			return nil
+		}
+		return h.UpsertInviteTx(ctx, tx, domain.Invite{
+			ID:        inviteID,
+			EventID:   eid,
+			Kind:      p.Kind,
+			CodeHash:  p.CodeHash,
+			MaxUses:   p.MaxUses,
+			ExpiresAt: p.ExpiresAt,
+		})
+
+	case rkAnnouncementPosted:
+		var p event.EventAnnouncementPayload
+		if err := json.Unmarshal(raw, &p); err != nil {
+			log.Warn().Err(err).Msg("invalid payload json; dropping")
+			return nil
+		}
+		aid, err := uuid.Parse(strings.TrimSpace(p.AnnouncementID))
+		if err != nil {
+			log.Warn().Err(err).Msg("invalid announcement_id; dropping")
+			return nil
+		}
+		eid, err := uuid.Parse(strings.TrimSpace(p.EventID))
+		if err != nil {
+			log.Warn().Err(err).Msg("invalid event_id; dropping")
+			return nil
+		}
+
+		type announcementHandler interface {
+			FanOutAnnouncementTx(ctx context.Context, tx pgx.Tx, traceID string, a domain.Announcement) error
+		}
+		if h, ok := any(r).(announcementHandler); ok {
+			return h.FanOutAnnouncementTx(ctx, tx, traceID, domain.Announcement{
+				ID:         aid,
+				EventID:    eid,
+				EventTitle: p.EventTitle,
+				Title:      p.Title,
+				Body:       p.Body,
+			})
+		}
+		log.Warn().Msg("repo does not support announcements; ignoring")
+		return nil
+
+	case rkEventChanged:
+		var p event.EventChangedPayload
+		if err := json.Unmarshal(raw, &p); err != nil {
+			log.Warn().Err(err).Msg("invalid payload json; dropping")
+			return nil
+		}
+		eid, err := uuid.Parse(strings.TrimSpace(p.EventID))
+		if err != nil {
+			log.Warn().Err(err).Msg("invalid event_id; dropping")
+			return nil
+		}
+		if len(p.Changes) == 0 || string(p.Changes) == "null" {
+			log.Warn().Msg("missing changes; dropping")
+			return nil
+		}
+
+		type eventChangeHandler interface {
+			FanOutEventChangeTx(ctx context.Context, tx pgx.Tx, traceID string, c domain.EventChange) error
+		}
+		if h, ok := any(r).(eventChangeHandler); ok {
+			return h.FanOutEventChangeTx(ctx, tx, traceID, domain.EventChange{
+				EventID:    eid,
+				EventTitle: p.EventTitle,
+				Version:    p.EventVersion,
+				Changes:    p.Changes,
+			})
+		}
+		log.Warn().Msg("repo does not support event changes; ignoring")
+		return nil
+
+	case rkUserBanned:
+		var p event.UserBannedPayload
+		if err := json.Unmarshal(raw, &p); err != nil {
+			log.Warn().Err(err).Msg("invalid payload json; dropping")
+			return nil
+		}
+		uid, err := uuid.Parse(strings.TrimSpace(p.UserID))
+		if err != nil {
+			log.Warn().Err(err).Msg("invalid user_id; dropping")
+			return nil
+		}
+
+		type bannedHandler interface {
+			ReleaseUserJoinsTx(ctx context.Context, tx pgx.Tx, traceID string, userID uuid.UUID, reason string) (int, error)
+		}
+		if h, ok := any(r).(bannedHandler); ok {
+			n, err := h.ReleaseUserJoinsTx(ctx, tx, traceID, uid, bannedReleaseReason)
+			if err != nil {
+				return err
+			}
+			log.Info().Str("user_id", uid.String()).Int("released", n).Msg("released joins of banned user")
+			return nil
+		}
+		log.Warn().Msg("repo does not support account bans; ignoring")
+		return nil
+
+	case rkDeletionRequested, rkExportRequested:
+		var p event.DataRequestedPayload
+		if err := json.Unmarshal(raw, &p); err != nil {
+			log.Warn().Err(err).Msg("invalid payload json; dropping")
+			return nil
+		}
+		rid, err := uuid.Parse(strings.TrimSpace(p.RequestID))
+		if err != nil {
+			log.Warn().Err(err).Msg("invalid request_id; dropping")
+			return nil
+		}
+		uid, err := uuid.Parse(strings.TrimSpace(p.UserID))
+		if err != nil {
+			log.Warn().Err(err).Msg("invalid user_id; dropping")
+			return nil
+		}
+
+		type dataRequestHandler interface {
+			DeleteUserDataTx(ctx context.Context, tx pgx.Tx, traceID string, requestID, userID uuid.UUID) error
+			ExportUserDataTx(ctx context.Context, tx pgx.Tx, traceID string, requestID, userID uuid.UUID) error
+		}
+		h, ok := any(r).(dataRequestHandler)
+		if !ok {
+			log.Warn().Msg("repo does not support data requests; ignoring")
+			return nil
+		}
+		if routingKey == rkDeletionRequested {
+			if err := h.DeleteUserDataTx(ctx, tx, traceID, rid, uid); err != nil {
+				return err
+			}
+			log.Info().Str("request_id", rid.String()).Str("user_id", uid.String()).Msg("deleted user data")
+			return nil
+		}
+		if err := h.ExportUserDataTx(ctx, tx, traceID, rid, uid); err != nil {
+			return err
+		}
+		log.Info().Str("request_id", rid.String()).Str("user_id", uid.String()).Msg("exported user data")
+		return nil
+
 	default:
 		log.Warn().Msg("unknown routing key; ignoring")
 		return nil
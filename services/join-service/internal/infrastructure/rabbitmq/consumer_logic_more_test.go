@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/baechuer/real-time-ressys/services/join-service/internal/contracts/event"
+	"github.com/baechuer/real-time-ressys/services/join-service/internal/domain"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
@@ -70,3 +72,331 @@ func TestApplySnapshotTx_Canceled_PrefersCanceledHandlerWhenImplemented(t *testi
 	assert.NoError(t, err)
 	repo.AssertExpectations(t)
 }
+
+type StaffRepo struct {
+	mock.Mock
+}
+
+func (m *StaffRepo) InitCapacityTx(ctx context.Context, tx pgx.Tx, eid uuid.UUID, cap int) error {
+	return m.Called(ctx, tx, eid, cap).Error(0)
+}
+func (m *StaffRepo) EnsureEventOwnerTx(ctx context.Context, tx pgx.Tx, eid, owner uuid.UUID) error {
+	return m.Called(ctx, tx, eid, owner).Error(0)
+}
+func (m *StaffRepo) ReplaceEventStaffTx(ctx context.Context, tx pgx.Tx, eid, owner uuid.UUID, team []domain.Collaborator, at time.Time) error {
+	return m.Called(ctx, tx, eid, owner, team, at).Error(0)
+}
+
+func TestApplySnapshotTx_PublishedSeedsOwner(t *testing.T) {
+	repo := new(StaffRepo)
+	ctx := context.Background()
+	eid, owner := uuid.New(), uuid.New()
+	capacity := 10
+
+	b, _ := json.Marshal(event.EventPublishedPayload{EventID: eid.String(), OwnerID: owner.String(), Capacity: &capacity})
+	repo.On("InitCapacityTx", ctx, mock.Anything, eid, 10).Return(nil).Once()
+	repo.On("EnsureEventOwnerTx", ctx, mock.Anything, eid, owner).Return(nil).Once()
+
+	err := applySnapshotTx(ctx, repo, nil, "event.published", b, "trace-owner", loggerStub())
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestApplySnapshotTx_CollaboratorsSnapshot(t *testing.T) {
+	repo := new(StaffRepo)
+	ctx := context.Background()
+	eid, owner, cohost := uuid.New(), uuid.New(), uuid.New()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	b, _ := json.Marshal(event.EventCollaboratorsPayload{
+		EventID: eid.String(),
+		OwnerID: owner.String(),
+		Collaborators: []event.CollaboratorPayload{
+			{UserID: cohost.String(), Role: "co_host"},
+			{UserID: "not-a-uuid", Role: "editor"},
+		},
+		UpdatedAt: at,
+	})
+	want := []domain.Collaborator{{UserID: cohost, Role: domain.RoleCoHost}}
+	repo.On("ReplaceEventStaffTx", ctx, mock.Anything, eid, owner, want, at).Return(nil).Once()
+
+	err := applySnapshotTx(ctx, repo, nil, "event.owner_transferred", b, "trace-team", loggerStub())
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+type InviteRepo struct {
+	mock.Mock
+}
+
+func (m *InviteRepo) InitCapacityTx(ctx context.Context, tx pgx.Tx, eid uuid.UUID, cap int) error {
+	return m.Called(ctx, tx, eid, cap).Error(0)
+}
+func (m *InviteRepo) SetEventVisibilityTx(ctx context.Context, tx pgx.Tx, eid uuid.UUID, visibility string) error {
+	return m.Called(ctx, tx, eid, visibility).Error(0)
+}
+func (m *InviteRepo) UpsertInviteTx(ctx context.Context, tx pgx.Tx, inv domain.Invite) error {
+	return m.Called(ctx, tx, inv).Error(0)
+}
+func (m *InviteRepo) RevokeInviteTx(ctx context.Context, tx pgx.Tx, inviteID, eid uuid.UUID, at time.Time) error {
+	return m.Called(ctx, tx, inviteID, eid, at).Error(0)
+}
+
+func TestApplySnapshotTx_PublishedSetsVisibility(t *testing.T) {
+	repo := new(InviteRepo)
+	ctx := context.Background()
+	eid := uuid.New()
+	capacity := 5
+
+	b, _ := json.Marshal(event.EventPublishedPayload{EventID: eid.String(), Capacity: &capacity, Visibility: "invite_only"})
+	repo.On("InitCapacityTx", ctx, mock.Anything, eid, 5).Return(nil).Once()
+	repo.On("SetEventVisibilityTx", ctx, mock.Anything, eid, "invite_only").Return(nil).Once()
+
+	err := applySnapshotTx(ctx, repo, nil, "event.published", b, "trace-vis", loggerStub())
+	assert.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestApplySnapshotTx_Invites(t *testing.T) {
+	ctx := context.Background()
+	eid, iid := uuid.New(), uuid.New()
+	maxUses := 3
+
+	t.Run("created", func(t *testing.T) {
+		repo := new(InviteRepo)
+		b, _ := json.Marshal(event.EventInvitePayload{InviteID: iid.String(), EventID: eid.String(), Kind: "code", CodeHash: "abc", MaxUses: &maxUses})
+		want := domain.Invite{ID: iid, EventID: eid, Kind: "code", CodeHash: "abc", MaxUses: &maxUses}
+		repo.On("UpsertInviteTx", ctx, mock.Anything, want).Return(nil).Once()
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "event.invite.created", b, "trace-inv", loggerStub()))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		repo := new(InviteRepo)
+		at := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+		b, _ := json.Marshal(event.EventInvitePayload{InviteID: iid.String(), EventID: eid.String(), RevokedAt: &at})
+		repo.On("RevokeInviteTx", ctx, mock.Anything, iid, eid, at).Return(nil).Once()
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "event.invite.revoked", b, "trace-inv", loggerStub()))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown kind dropped", func(t *testing.T) {
+		repo := new(InviteRepo)
+		b, _ := json.Marshal(event.EventInvitePayload{InviteID: iid.String(), EventID: eid.String(), Kind: "qr"})
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "event.invite.created", b, "trace-inv", loggerStub()))
+		repo.AssertNotCalled(t, "UpsertInviteTx", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+type WindowRepo struct {
+	mock.Mock
+}
+
+func (m *WindowRepo) InitCapacityTx(ctx context.Context, tx pgx.Tx, eid uuid.UUID, cap int) error {
+	return m.Called(ctx, tx, eid, cap).Error(0)
+}
+func (m *WindowRepo) SetRegistrationWindowTx(ctx context.Context, tx pgx.Tx, eid uuid.UUID, opensAt, closesAt *time.Time) error {
+	return m.Called(ctx, tx, eid, opensAt, closesAt).Error(0)
+}
+
+func TestApplySnapshotTx_RegistrationWindow(t *testing.T) {
+	ctx := context.Background()
+	eid := uuid.New()
+	capacity := 20
+	closes := time.Date(2026, 5, 1, 18, 0, 0, 0, time.UTC)
+
+	t.Run("published carries the window", func(t *testing.T) {
+		repo := new(WindowRepo)
+		b, _ := json.Marshal(event.EventPublishedPayload{EventID: eid.String(), Capacity: &capacity, RegistrationClosesAt: &closes})
+		repo.On("InitCapacityTx", ctx, mock.Anything, eid, 20).Return(nil).Once()
+		repo.On("SetRegistrationWindowTx", ctx, mock.Anything, eid, (*time.Time)(nil), &closes).Return(nil).Once()
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "event.published", b, "trace-win", loggerStub()))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("updated without a window clears it", func(t *testing.T) {
+		repo := new(WindowRepo)
+		b, _ := json.Marshal(event.EventUpdatedPayload{EventID: eid.String(), Capacity: &capacity})
+		repo.On("InitCapacityTx", ctx, mock.Anything, eid, 20).Return(nil).Once()
+		repo.On("SetRegistrationWindowTx", ctx, mock.Anything, eid, (*time.Time)(nil), (*time.Time)(nil)).Return(nil).Once()
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "event.updated", b, "trace-win", loggerStub()))
+		repo.AssertExpectations(t)
+	})
+}
+
+type AnnouncementRepo struct {
+	mock.Mock
+}
+
+func (m *AnnouncementRepo) InitCapacityTx(ctx context.Context, tx pgx.Tx, eid uuid.UUID, cap int) error {
+	return m.Called(ctx, tx, eid, cap).Error(0)
+}
+func (m *AnnouncementRepo) FanOutAnnouncementTx(ctx context.Context, tx pgx.Tx, traceID string, a domain.Announcement) error {
+	return m.Called(ctx, tx, traceID, a).Error(0)
+}
+
+func TestApplySnapshotTx_Announcement(t *testing.T) {
+	ctx := context.Background()
+	eid := uuid.New()
+	aid := uuid.New()
+
+	t.Run("fans out", func(t *testing.T) {
+		repo := new(AnnouncementRepo)
+		b, _ := json.Marshal(event.EventAnnouncementPayload{
+			AnnouncementID: aid.String(), EventID: eid.String(), EventTitle: "Meetup",
+			Title: "Room change", Body: "We moved to room 4B",
+		})
+		repo.On("FanOutAnnouncementTx", ctx, mock.Anything, "trace-ann", domain.Announcement{
+			ID: aid, EventID: eid, EventTitle: "Meetup", Title: "Room change", Body: "We moved to room 4B",
+		}).Return(nil).Once()
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "event.announcement.posted", b, "trace-ann", loggerStub()))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("bad announcement_id is dropped", func(t *testing.T) {
+		repo := new(AnnouncementRepo)
+		b, _ := json.Marshal(event.EventAnnouncementPayload{AnnouncementID: "nope", EventID: eid.String()})
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "event.announcement.posted", b, "trace-ann", loggerStub()))
+		repo.AssertNotCalled(t, "FanOutAnnouncementTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+type ChangedRepo struct {
+	mock.Mock
+}
+
+func (m *ChangedRepo) InitCapacityTx(ctx context.Context, tx pgx.Tx, eid uuid.UUID, cap int) error {
+	return m.Called(ctx, tx, eid, cap).Error(0)
+}
+func (m *ChangedRepo) FanOutEventChangeTx(ctx context.Context, tx pgx.Tx, traceID string, c domain.EventChange) error {
+	return m.Called(ctx, tx, traceID, c).Error(0)
+}
+
+func TestApplySnapshotTx_EventChanged(t *testing.T) {
+	ctx := context.Background()
+	eid := uuid.New()
+	changes := json.RawMessage(`{"city":{"from":"Sydney","to":"Melbourne"}}`)
+
+	t.Run("fans out", func(t *testing.T) {
+		repo := new(ChangedRepo)
+		b, _ := json.Marshal(event.EventChangedPayload{
+			EventID: eid.String(), EventTitle: "Meetup", EventVersion: 4, Changes: changes,
+		})
+		repo.On("FanOutEventChangeTx", ctx, mock.Anything, "trace-chg", domain.EventChange{
+			EventID: eid, EventTitle: "Meetup", Version: 4, Changes: changes,
+		}).Return(nil).Once()
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "event.changed", b, "trace-chg", loggerStub()))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("missing changes are dropped", func(t *testing.T) {
+		repo := new(ChangedRepo)
+		b, _ := json.Marshal(event.EventChangedPayload{EventID: eid.String(), EventVersion: 4})
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "event.changed", b, "trace-chg", loggerStub()))
+		repo.AssertNotCalled(t, "FanOutEventChangeTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+type BannedRepo struct {
+	mock.Mock
+}
+
+func (m *BannedRepo) InitCapacityTx(ctx context.Context, tx pgx.Tx, eid uuid.UUID, cap int) error {
+	return m.Called(ctx, tx, eid, cap).Error(0)
+}
+func (m *BannedRepo) SetEventStartTx(ctx context.Context, tx pgx.Tx, eid uuid.UUID, startsAt time.Time) error {
+	return m.Called(ctx, tx, eid, startsAt).Error(0)
+}
+func (m *BannedRepo) ReleaseUserJoinsTx(ctx context.Context, tx pgx.Tx, traceID string, uid uuid.UUID, reason string) (int, error) {
+	args := m.Called(ctx, tx, traceID, uid, reason)
+	return args.Int(0), args.Error(1)
+}
+
+func TestApplySnapshotTx_UserBanned(t *testing.T) {
+	ctx := context.Background()
+	uid := uuid.New()
+
+	t.Run("releases joins", func(t *testing.T) {
+		repo := new(BannedRepo)
+		b, _ := json.Marshal(event.UserBannedPayload{UserID: uid.String(), BannedAt: time.Now().UTC()})
+		repo.On("ReleaseUserJoinsTx", ctx, mock.Anything, "trace-ban", uid, "account_banned").Return(2, nil).Once()
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "auth.user.banned", b, "trace-ban", loggerStub()))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("bad user_id is dropped", func(t *testing.T) {
+		repo := new(BannedRepo)
+		b, _ := json.Marshal(event.UserBannedPayload{UserID: "nope"})
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "auth.user.banned", b, "trace-ban", loggerStub()))
+		repo.AssertNotCalled(t, "ReleaseUserJoinsTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("published records the start time", func(t *testing.T) {
+		repo := new(BannedRepo)
+		eid := uuid.New()
+		capacity := 10
+		start := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+		b, _ := json.Marshal(event.EventPublishedPayload{EventID: eid.String(), Capacity: &capacity, StartTime: &start})
+		repo.On("InitCapacityTx", ctx, mock.Anything, eid, 10).Return(nil).Once()
+		repo.On("SetEventStartTx", ctx, mock.Anything, eid, start).Return(nil).Once()
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "event.published", b, "trace-ban", loggerStub()))
+		repo.AssertExpectations(t)
+	})
+}
+
+type DataRequestRepo struct {
+	mock.Mock
+}
+
+func (m *DataRequestRepo) InitCapacityTx(ctx context.Context, tx pgx.Tx, eid uuid.UUID, cap int) error {
+	return m.Called(ctx, tx, eid, cap).Error(0)
+}
+func (m *DataRequestRepo) DeleteUserDataTx(ctx context.Context, tx pgx.Tx, traceID string, rid, uid uuid.UUID) error {
+	return m.Called(ctx, tx, traceID, rid, uid).Error(0)
+}
+func (m *DataRequestRepo) ExportUserDataTx(ctx context.Context, tx pgx.Tx, traceID string, rid, uid uuid.UUID) error {
+	return m.Called(ctx, tx, traceID, rid, uid).Error(0)
+}
+
+func TestApplySnapshotTx_DataRequests(t *testing.T) {
+	ctx := context.Background()
+	rid, uid := uuid.New(), uuid.New()
+	b, _ := json.Marshal(event.DataRequestedPayload{RequestID: rid.String(), UserID: uid.String(), RequestedAt: time.Now().UTC()})
+
+	t.Run("deletion", func(t *testing.T) {
+		repo := new(DataRequestRepo)
+		repo.On("DeleteUserDataTx", ctx, mock.Anything, "trace-dr", rid, uid).Return(nil).Once()
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "auth.user.deletion_requested", b, "trace-dr", loggerStub()))
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "ExportUserDataTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		repo := new(DataRequestRepo)
+		repo.On("ExportUserDataTx", ctx, mock.Anything, "trace-dr", rid, uid).Return(nil).Once()
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "auth.user.export_requested", b, "trace-dr", loggerStub()))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("bad request_id is dropped", func(t *testing.T) {
+		repo := new(DataRequestRepo)
+		bad, _ := json.Marshal(event.DataRequestedPayload{RequestID: "nope", UserID: uid.String()})
+
+		assert.NoError(t, applySnapshotTx(ctx, repo, nil, "auth.user.deletion_requested", bad, "trace-dr", loggerStub()))
+		repo.AssertNotCalled(t, "DeleteUserDataTx", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -13,18 +13,32 @@ import (
 type JoinService struct {
 	repo  domain.JoinRepository
 	cache domain.CacheRepository
+
+	inviteSecret []byte
 }
 
 func NewJoinService(repo domain.JoinRepository, cache domain.CacheRepository) *JoinService {
 	return &JoinService{repo: repo, cache: cache}
 }
 
+// SetInviteSecret sets the INVITE_TOKEN_SECRET shared with event-service.
+func (s *JoinService) SetInviteSecret(secret string) { s.inviteSecret = []byte(secret) }
+
+// JoinInvite is what the attendee presents for an invite-only event: an
+// invite link token or an access code. Both are ignored for other events.
+type JoinInvite struct {
+	Token string
+	Code  string
+}
+
 func isPrivileged(role string) bool {
 	r := strings.ToLower(strings.TrimSpace(role))
 	return r == "admin" || r == "moderator"
 }
 
-func (s *JoinService) requireOrganizerOrAdmin(ctx context.Context, eventID uuid.UUID, requesterID uuid.UUID, role string) error {
+// requireEventStaff passes admins/moderators, the event owner, and team
+// members holding one of the allowed roles.
+func (s *JoinService) requireEventStaff(ctx context.Context, eventID uuid.UUID, requesterID uuid.UUID, role string, allowed ...domain.CollaboratorRole) error {
 	if isPrivileged(role) {
 		return nil
 	}
@@ -32,13 +46,28 @@ func (s *JoinService) requireOrganizerOrAdmin(ctx context.Context, eventID uuid.
 	if err != nil {
 		return err
 	}
-	if owner != requesterID {
-		return domain.ErrForbidden
+	if owner == requesterID {
+		return nil
 	}
-	return nil
+	cr, err := s.repo.GetEventCollaboratorRole(ctx, eventID, requesterID)
+	if err != nil {
+		return err
+	}
+	for _, a := range allowed {
+		if cr == a {
+			return nil
+		}
+	}
+	return domain.ErrForbidden
 }
 
-func (s *JoinService) Join(ctx context.Context, traceID, idempotencyKey string, eventID, userID uuid.UUID) (string, error) {
+// Attendee lists are for co-hosts and check-in staff; moderation is co-host only.
+var (
+	attendeeViewers = []domain.CollaboratorRole{domain.RoleCoHost, domain.RoleCheckinStaff}
+	moderators      = []domain.CollaboratorRole{domain.RoleCoHost}
+)
+
+func (s *JoinService) Join(ctx context.Context, traceID, idempotencyKey string, eventID, userID uuid.UUID, invite JoinInvite) (string, error) {
 	// Organizer cannot join own event
 	owner, err := s.repo.GetEventOwnerID(ctx, eventID)
 	if err == nil && owner == userID {
@@ -56,7 +85,26 @@ func (s *JoinService) Join(ctx context.Context, traceID, idempotencyKey string,
 			// ignore redis errors
 		}
 	}
-	status, err := s.repo.JoinEvent(ctx, traceID, idempotencyKey, eventID, userID)
+
+	// Signature and code format are checked here; whether the event needs
+	// an invite, and whether this one still has uses left, is up to the repo.
+	var access domain.JoinAccess
+	switch {
+	case strings.TrimSpace(invite.Token) != "":
+		id, err := domain.VerifyInviteToken(s.inviteSecret, invite.Token, eventID, time.Now())
+		if err != nil {
+			return "", err
+		}
+		access.InviteID = id
+	case strings.TrimSpace(invite.Code) != "":
+		hash, ok := domain.HashAccessCode(eventID, invite.Code)
+		if !ok {
+			return "", domain.ErrInviteInvalid
+		}
+		access.CodeHash = hash
+	}
+
+	status, err := s.repo.JoinEvent(ctx, traceID, idempotencyKey, eventID, userID, access)
 	if err != nil {
 		return "", err
 	}
@@ -78,21 +126,21 @@ func (s *JoinService) ListMyJoins(ctx context.Context, userID uuid.UUID, statuse
 }
 
 func (s *JoinService) ListParticipants(ctx context.Context, eventID uuid.UUID, requesterID uuid.UUID, role string, limit int, cursor *domain.KeysetCursor) ([]domain.JoinRecord, *domain.KeysetCursor, error) {
-	if err := s.requireOrganizerOrAdmin(ctx, eventID, requesterID, role); err != nil {
+	if err := s.requireEventStaff(ctx, eventID, requesterID, role, attendeeViewers...); err != nil {
 		return nil, nil, err
 	}
 	return s.repo.ListParticipants(ctx, eventID, limit, cursor)
 }
 
 func (s *JoinService) ListWaitlist(ctx context.Context, eventID uuid.UUID, requesterID uuid.UUID, role string, limit int, cursor *domain.KeysetCursor) ([]domain.JoinRecord, *domain.KeysetCursor, error) {
-	if err := s.requireOrganizerOrAdmin(ctx, eventID, requesterID, role); err != nil {
+	if err := s.requireEventStaff(ctx, eventID, requesterID, role, attendeeViewers...); err != nil {
 		return nil, nil, err
 	}
 	return s.repo.ListWaitlist(ctx, eventID, limit, cursor)
 }
 
 func (s *JoinService) GetStats(ctx context.Context, eventID uuid.UUID, requesterID uuid.UUID, role string) (domain.EventStats, error) {
-	if err := s.requireOrganizerOrAdmin(ctx, eventID, requesterID, role); err != nil {
+	if err := s.requireEventStaff(ctx, eventID, requesterID, role, attendeeViewers...); err != nil {
 		return domain.EventStats{}, err
 	}
 	return s.repo.GetStats(ctx, eventID)
@@ -100,21 +148,21 @@ func (s *JoinService) GetStats(ctx context.Context, eventID uuid.UUID, requester
 
 // Moderation
 func (s *JoinService) Kick(ctx context.Context, traceID string, eventID, targetUserID, actorID uuid.UUID, role string, reason string) error {
-	if err := s.requireOrganizerOrAdmin(ctx, eventID, actorID, role); err != nil {
+	if err := s.requireEventStaff(ctx, eventID, actorID, role, moderators...); err != nil {
 		return err
 	}
 	return s.repo.Kick(ctx, traceID, eventID, targetUserID, actorID, reason)
 }
 
 func (s *JoinService) Ban(ctx context.Context, traceID string, eventID, targetUserID, actorID uuid.UUID, role string, reason string, expiresAt *time.Time) error {
-	if err := s.requireOrganizerOrAdmin(ctx, eventID, actorID, role); err != nil {
+	if err := s.requireEventStaff(ctx, eventID, actorID, role, moderators...); err != nil {
 		return err
 	}
 	return s.repo.Ban(ctx, traceID, eventID, targetUserID, actorID, reason, expiresAt)
 }
 
 func (s *JoinService) Unban(ctx context.Context, traceID string, eventID, targetUserID, actorID uuid.UUID, role string) error {
-	if err := s.requireOrganizerOrAdmin(ctx, eventID, actorID, role); err != nil {
+	if err := s.requireEventStaff(ctx, eventID, actorID, role, moderators...); err != nil {
 		return err
 	}
 	return s.repo.Unban(ctx, traceID, eventID, targetUserID, actorID)
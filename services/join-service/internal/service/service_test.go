@@ -15,8 +15,8 @@ import (
 
 type MockRepo struct{ mock.Mock }
 
-func (m *MockRepo) JoinEvent(ctx context.Context, tid, idempotencyKey string, eid, uid uuid.UUID) (domain.JoinStatus, error) {
-	args := m.Called(ctx, tid, idempotencyKey, eid, uid)
+func (m *MockRepo) JoinEvent(ctx context.Context, tid, idempotencyKey string, eid, uid uuid.UUID, access domain.JoinAccess) (domain.JoinStatus, error) {
+	args := m.Called(ctx, tid, idempotencyKey, eid, uid, access)
 	return args.Get(0).(domain.JoinStatus), args.Error(1)
 }
 func (m *MockRepo) CancelJoin(ctx context.Context, tid, idempotencyKey string, eid, uid uuid.UUID) error {
@@ -30,6 +30,10 @@ func (m *MockRepo) GetEventOwnerID(ctx context.Context, eid uuid.UUID) (uuid.UUI
 	args := m.Called(ctx, eid)
 	return args.Get(0).(uuid.UUID), args.Error(1)
 }
+func (m *MockRepo) GetEventCollaboratorRole(ctx context.Context, eid, uid uuid.UUID) (domain.CollaboratorRole, error) {
+	args := m.Called(ctx, eid, uid)
+	return args.Get(0).(domain.CollaboratorRole), args.Error(1)
+}
 
 // Reads
 func (m *MockRepo) ListMyJoins(ctx context.Context, u uuid.UUID, s []domain.JoinStatus, f, t *time.Time, l int, c *domain.KeysetCursor) ([]domain.JoinRecord, *domain.KeysetCursor, error) {
@@ -135,9 +139,9 @@ func TestJoinService_Join_Success(t *testing.T) {
 	// Cache miss or error (ignored)
 	cache.On("GetEventCapacity", ctx, eID).Return(0, domain.ErrCacheMiss)
 	// Repo join
-	repo.On("JoinEvent", ctx, traceID, "", eID, uID).Return(domain.StatusActive, nil)
+	repo.On("JoinEvent", ctx, traceID, "", eID, uID, domain.JoinAccess{}).Return(domain.StatusActive, nil)
 
-	status, err := svc.Join(ctx, traceID, "", eID, uID)
+	status, err := svc.Join(ctx, traceID, "", eID, uID, service.JoinInvite{})
 	assert.NoError(t, err)
 	assert.Equal(t, "active", status)
 	repo.AssertExpectations(t)
@@ -181,9 +185,9 @@ func TestJoinService_Join_EventFull(t *testing.T) {
 
 	repo.On("GetEventOwnerID", ctx, eID).Return(uuid.New(), nil)
 	cache.On("GetEventCapacity", ctx, eID).Return(0, domain.ErrCacheMiss)
-	repo.On("JoinEvent", ctx, "trace", "", eID, uID).Return(domain.JoinStatus(""), domain.ErrEventFull)
+	repo.On("JoinEvent", ctx, "trace", "", eID, uID, domain.JoinAccess{}).Return(domain.JoinStatus(""), domain.ErrEventFull)
 
-	_, err := svc.Join(ctx, "trace", "", eID, uID)
+	_, err := svc.Join(ctx, "trace", "", eID, uID, service.JoinInvite{})
 	assert.ErrorIs(t, err, domain.ErrEventFull)
 }
 
@@ -197,9 +201,9 @@ func TestJoinService_Join_AlreadyJoined(t *testing.T) {
 
 	repo.On("GetEventOwnerID", ctx, eID).Return(uuid.New(), nil)
 	cache.On("GetEventCapacity", ctx, eID).Return(0, domain.ErrCacheMiss)
-	repo.On("JoinEvent", ctx, "trace", "", eID, uID).Return(domain.StatusActive, domain.ErrAlreadyJoined)
+	repo.On("JoinEvent", ctx, "trace", "", eID, uID, domain.JoinAccess{}).Return(domain.StatusActive, domain.ErrAlreadyJoined)
 
-	_, err := svc.Join(ctx, "trace", "", eID, uID)
+	_, err := svc.Join(ctx, "trace", "", eID, uID, service.JoinInvite{})
 	assert.ErrorIs(t, err, domain.ErrAlreadyJoined)
 }
 
@@ -213,9 +217,54 @@ func TestJoinService_Join_ForbiddenForOwner(t *testing.T) {
 
 	repo.On("GetEventOwnerID", ctx, eID).Return(uID, nil)
 
-	_, err := svc.Join(ctx, "trace", "", eID, uID)
+	_, err := svc.Join(ctx, "trace", "", eID, uID, service.JoinInvite{})
 	assert.ErrorIs(t, err, domain.ErrForbidden)
-	repo.AssertNotCalled(t, "JoinEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "JoinEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestJoinService_Join_Invites(t *testing.T) {
+	ctx := context.Background()
+	eID := uuid.MustParse("0b5e8f3a-9c2d-4e7b-8f1a-3c6d9e2b5a71")
+	uID := uuid.New()
+	// Non-expiring link for eID signed by event-service with "test-secret"
+	token := "6f1c2a9e-4b7d-4c1e-9a3f-2d8e5b7c1a40.0b5e8f3a-9c2d-4e7b-8f1a-3c6d9e2b5a71.0.rovZf3sZzyxBJzF53a3VGqP0U4zrotVhZJFpFOj8xiU"
+
+	setup := func() (*MockRepo, *service.JoinService) {
+		repo := new(MockRepo)
+		svc := service.NewJoinService(repo, nil)
+		svc.SetInviteSecret("test-secret")
+		repo.On("GetEventOwnerID", ctx, eID).Return(uuid.New(), nil)
+		return repo, svc
+	}
+
+	t.Run("link token passes the invite id", func(t *testing.T) {
+		repo, svc := setup()
+		access := domain.JoinAccess{InviteID: uuid.MustParse("6f1c2a9e-4b7d-4c1e-9a3f-2d8e5b7c1a40")}
+		repo.On("JoinEvent", ctx, "trace", "", eID, uID, access).Return(domain.StatusActive, nil)
+
+		_, err := svc.Join(ctx, "trace", "", eID, uID, service.JoinInvite{Token: token})
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("access code passes the hash", func(t *testing.T) {
+		repo, svc := setup()
+		hash, _ := domain.HashAccessCode(eID, "VIP2025")
+		repo.On("JoinEvent", ctx, "trace", "", eID, uID, domain.JoinAccess{CodeHash: hash}).Return(domain.StatusActive, nil)
+
+		_, err := svc.Join(ctx, "trace", "", eID, uID, service.JoinInvite{Code: "vip-2025"})
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("bad token never reaches the repo", func(t *testing.T) {
+		repo, svc := setup()
+		svc.SetInviteSecret("rotated")
+
+		_, err := svc.Join(ctx, "trace", "", eID, uID, service.JoinInvite{Token: token})
+		assert.ErrorIs(t, err, domain.ErrInviteInvalid)
+		repo.AssertNotCalled(t, "JoinEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestJoinService_GuardedReads_And_Moderation(t *testing.T) {
@@ -232,6 +281,7 @@ func TestJoinService_GuardedReads_And_Moderation(t *testing.T) {
 		svc := service.NewJoinService(repo, nil)
 
 		repo.On("GetEventOwnerID", ctx, eventID).Return(ownerID, nil).Once()
+		repo.On("GetEventCollaboratorRole", ctx, eventID, otherID).Return(domain.CollaboratorRole(""), nil).Once()
 
 		_, _, err := svc.ListParticipants(ctx, eventID, otherID, "organizer", 10, cursor)
 		assert.ErrorIs(t, err, domain.ErrForbidden)
@@ -283,12 +333,46 @@ func TestJoinService_GuardedReads_And_Moderation(t *testing.T) {
 		svc := service.NewJoinService(repo, nil)
 
 		repo.On("GetEventOwnerID", ctx, eventID).Return(ownerID, nil).Once()
+		repo.On("GetEventCollaboratorRole", ctx, eventID, otherID).Return(domain.CollaboratorRole(""), nil).Once()
 
 		err := svc.Kick(ctx, traceID, eventID, uuid.New(), otherID, "organizer", "reason")
 		assert.ErrorIs(t, err, domain.ErrForbidden)
 		repo.AssertNotCalled(t, "Kick", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
+	t.Run("Kick: co-host allowed, check-in staff forbidden", func(t *testing.T) {
+		repo := new(MockRepo)
+		svc := service.NewJoinService(repo, nil)
+
+		cohostID := uuid.New()
+		target := uuid.New()
+		repo.On("GetEventOwnerID", ctx, eventID).Return(ownerID, nil).Twice()
+		repo.On("GetEventCollaboratorRole", ctx, eventID, cohostID).Return(domain.RoleCoHost, nil).Once()
+		repo.On("GetEventCollaboratorRole", ctx, eventID, otherID).Return(domain.RoleCheckinStaff, nil).Once()
+		repo.On("Kick", ctx, traceID, eventID, target, cohostID, "reason").Return(nil).Once()
+
+		assert.NoError(t, svc.Kick(ctx, traceID, eventID, target, cohostID, "user", "reason"))
+		assert.ErrorIs(t, svc.Kick(ctx, traceID, eventID, target, otherID, "user", "reason"), domain.ErrForbidden)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ListParticipants: check-in staff ok, editor forbidden", func(t *testing.T) {
+		repo := new(MockRepo)
+		svc := service.NewJoinService(repo, nil)
+
+		staffID := uuid.New()
+		repo.On("GetEventOwnerID", ctx, eventID).Return(ownerID, nil).Twice()
+		repo.On("GetEventCollaboratorRole", ctx, eventID, staffID).Return(domain.RoleCheckinStaff, nil).Once()
+		repo.On("GetEventCollaboratorRole", ctx, eventID, otherID).Return(domain.RoleEditor, nil).Once()
+		repo.On("ListParticipants", ctx, eventID, 10, cursor).Return([]domain.JoinRecord{}, (*domain.KeysetCursor)(nil), nil).Once()
+
+		_, _, err := svc.ListParticipants(ctx, eventID, staffID, "user", 10, cursor)
+		assert.NoError(t, err)
+		_, _, err = svc.ListParticipants(ctx, eventID, otherID, "user", 10, cursor)
+		assert.ErrorIs(t, err, domain.ErrForbidden)
+		repo.AssertExpectations(t)
+	})
+
 	t.Run("Ban/Unban: admin bypasses owner check", func(t *testing.T) {
 		repo := new(MockRepo)
 		svc := service.NewJoinService(repo, nil)
@@ -26,7 +26,9 @@ func NewHandler(svc *service.JoinService) *Handler {
 
 func (h *Handler) Join(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		EventID string `json:"event_id"`
+		EventID     string `json:"event_id"`
+		InviteToken string `json:"invite_token,omitempty"` // invite-only events
+		AccessCode  string `json:"access_code,omitempty"`
 	}
 	if err := render.DecodeJSON(r.Body, &req); err != nil {
 		fail(w, r, http.StatusBadRequest, "request.invalid", "invalid body", nil)
@@ -62,7 +64,10 @@ func (h *Handler) Join(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	status, err := h.svc.Join(r.Context(), traceID, idempotencyKey, eventID, auth.UserID)
+	status, err := h.svc.Join(r.Context(), traceID, idempotencyKey, eventID, auth.UserID, service.JoinInvite{
+		Token: req.InviteToken,
+		Code:  req.AccessCode,
+	})
 	if err != nil {
 		handleErr(w, r, err)
 		return
@@ -131,12 +136,25 @@ func handleErr(w http.ResponseWriter, r *http.Request, err error) {
 		fail(w, r, http.StatusGone, "event.closed", err.Error(), nil)
 		return
 
+	case errors.Is(err, domain.ErrRegistrationNotOpen):
+		fail(w, r, http.StatusConflict, "join.registration_not_open", err.Error(), nil)
+		return
+	case errors.Is(err, domain.ErrRegistrationClosed):
+		fail(w, r, http.StatusGone, "join.registration_closed", err.Error(), nil)
+		return
+
 	case errors.Is(err, domain.ErrEventNotFound):
 		fail(w, r, http.StatusNotFound, "event.not_found", err.Error(), nil)
 		return
 	case errors.Is(err, domain.ErrBanned):
 		fail(w, r, http.StatusForbidden, "join.banned", err.Error(), nil)
 		return
+	case errors.Is(err, domain.ErrInviteRequired):
+		fail(w, r, http.StatusForbidden, "join.invite_required", err.Error(), nil)
+		return
+	case errors.Is(err, domain.ErrInviteInvalid):
+		fail(w, r, http.StatusForbidden, "join.invite_invalid", err.Error(), nil)
+		return
 	case errors.Is(err, domain.ErrForbidden):
 		fail(w, r, http.StatusForbidden, "auth.forbidden", err.Error(), nil)
 		return
@@ -442,6 +460,35 @@ func (h *Handler) GetMyParticipation(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// InternalParticipation returns any user's join status for an event. Only
+// reachable with X-Internal-Secret; event-service uses it to check that a
+// reviewer attended.
+func (h *Handler) InternalParticipation(w http.ResponseWriter, r *http.Request) {
+	eventID, err := uuid.Parse(chi.URLParam(r, "eventID"))
+	if err != nil {
+		fail(w, r, http.StatusBadRequest, "request.invalid", "invalid eventID", nil)
+		return
+	}
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		fail(w, r, http.StatusBadRequest, "request.invalid", "invalid userID", nil)
+		return
+	}
+
+	rec, err := h.svc.GetMyParticipation(r.Context(), userID, eventID)
+	if err != nil {
+		handleErr(w, r, err)
+		return
+	}
+
+	response.Data(w, http.StatusOK, map[string]any{
+		"event_id":  rec.EventID,
+		"user_id":   rec.UserID,
+		"status":    rec.Status,
+		"joined_at": rec.CreatedAt,
+	})
+}
+
 func parseLimit(s string) int {
 	s = strings.TrimSpace(s)
 	if s == "" {
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"crypto/subtle"
 	"net"
 	"net/http"
 	"strings"
@@ -75,6 +76,25 @@ func AuthMiddleware(verifier security.AccessTokenVerifier, opt AuthOptions) func
 	}
 }
 
+// InternalAuth restricts a route to other services holding the shared
+// X-Internal-Secret (e.g. event-service verifying attendance for reviews).
+func InternalAuth(secret string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if secret == "" {
+				http.Error(w, "internal auth misconfigured", http.StatusInternalServerError)
+				return
+			}
+			got := r.Header.Get("X-Internal-Secret")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(secret)) != 1 {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 func RateLimitMiddleware(cache domain.CacheRepository, limit int, window time.Duration) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -20,6 +20,9 @@ type RouterDeps struct {
 	JWTIssuer string
 	RLLimit   int
 	RLWindow  time.Duration
+
+	// InternalSecret guards /internal routes (X-Internal-Secret)
+	InternalSecret string
 }
 
 func NewRouter(d RouterDeps) http.Handler {
@@ -55,6 +58,9 @@ func NewRouter(d RouterDeps) http.Handler {
 	// Also expose at /join/v1/health for BFF readiness checks
 	r.Get("/join/v1/health", healthzHandler)
 
+	// Service-to-service
+	r.With(InternalAuth(d.InternalSecret)).Get("/internal/v1/events/{eventID}/participants/{userID}", d.Handler.InternalParticipation)
+
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Use(AuthMiddleware(d.Verifier, AuthOptions{ExpectedIssuer: d.JWTIssuer}))
 
@@ -59,6 +59,7 @@ func (c *fakeCache) Ping(ctx context.Context) error {
 
 type fakeRepo struct {
 	joinFn           func(ctx context.Context, traceID string, eventID, userID uuid.UUID) (domain.JoinStatus, error)
+	lastAccess       domain.JoinAccess
 	cancelFn         func(ctx context.Context, traceID string, eventID, userID uuid.UUID) error
 	listMyFn         func(ctx context.Context, userID uuid.UUID, statuses []domain.JoinStatus, from, to *time.Time, limit int, cursor *domain.KeysetCursor) ([]domain.JoinRecord, *domain.KeysetCursor, error)
 	listParticipants func(ctx context.Context, eventID uuid.UUID, limit int, cursor *domain.KeysetCursor) ([]domain.JoinRecord, *domain.KeysetCursor, error)
@@ -69,6 +70,7 @@ type fakeRepo struct {
 	banFn      func(ctx context.Context, traceID string, eventID, targetUserID, actorID uuid.UUID, reason string, expiresAt *time.Time) error
 	unbanFn    func(ctx context.Context, traceID string, eventID, targetUserID, actorID uuid.UUID) error
 	ownerFn    func(ctx context.Context, eventID uuid.UUID) (uuid.UUID, error)
+	roleFn     func(ctx context.Context, eventID, userID uuid.UUID) (domain.CollaboratorRole, error)
 	notImplErr error
 }
 
@@ -102,7 +104,8 @@ func (r *fakeRepo) notImpl() error {
 
 // --- domain.JoinRepository ---
 
-func (r *fakeRepo) JoinEvent(ctx context.Context, traceID, idempotencyKey string, eventID, userID uuid.UUID) (domain.JoinStatus, error) {
+func (r *fakeRepo) JoinEvent(ctx context.Context, traceID, idempotencyKey string, eventID, userID uuid.UUID, access domain.JoinAccess) (domain.JoinStatus, error) {
+	r.lastAccess = access
 	if r.joinFn == nil {
 		return "", r.notImpl()
 	}
@@ -194,6 +197,13 @@ func (r *fakeRepo) GetEventOwnerID(ctx context.Context, eventID uuid.UUID) (uuid
 	return r.ownerFn(ctx, eventID)
 }
 
+func (r *fakeRepo) GetEventCollaboratorRole(ctx context.Context, eventID, userID uuid.UUID) (domain.CollaboratorRole, error) {
+	if r.roleFn == nil {
+		return "", nil // not on the team
+	}
+	return r.roleFn(ctx, eventID, userID)
+}
+
 func newTestRouter(repo domain.JoinRepository, cache domain.CacheRepository, claims security.TokenClaims) http.Handler {
 	svc := service.NewJoinService(repo, cache)
 	h := NewHandler(svc)
@@ -430,6 +440,37 @@ func TestRouter_Reads_OrganizerGuard_ForbiddenForNonOwner(t *testing.T) {
 	require.Equal(t, "auth.forbidden", errBody.Error.Code)
 }
 
+func TestRouter_Reads_OrganizerGuard_AllowsCheckinStaff(t *testing.T) {
+	cache := newFakeCache()
+	ev := uuid.New()
+	uid := uuid.New()
+
+	repo := &fakeRepo{
+		ownerFn: func(ctx context.Context, eventID uuid.UUID) (uuid.UUID, error) {
+			return uuid.New(), nil
+		},
+		roleFn: func(ctx context.Context, eventID, userID uuid.UUID) (domain.CollaboratorRole, error) {
+			return domain.RoleCheckinStaff, nil
+		},
+		listParticipants: func(ctx context.Context, eventID uuid.UUID, limit int, cursor *domain.KeysetCursor) ([]domain.JoinRecord, *domain.KeysetCursor, error) {
+			return []domain.JoinRecord{}, nil, nil
+		},
+	}
+
+	r := newTestRouter(repo, cache, security.TokenClaims{
+		UserID: uid.String(),
+		Role:   "user",
+		Issuer: "auth-service",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events/"+ev.String()+"/participants", nil)
+	req.Header.Set("Authorization", "Bearer ok")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
 func TestRouter_RateLimit_429(t *testing.T) {
 	cache := newFakeCache()
 	cache.allow = false
@@ -474,3 +515,39 @@ func TestRouter_SecurityHeaders_PresentOnOK(t *testing.T) {
 	require.Equal(t, "DENY", rr.Header().Get("X-Frame-Options"))
 	require.Contains(t, rr.Header().Get("Content-Security-Policy"), "default-src")
 }
+
+func TestRouter_InternalParticipation_RequiresSecret(t *testing.T) {
+	cache := newFakeCache()
+	svc := service.NewJoinService(&fakeRepo{}, cache)
+	r := NewRouter(RouterDeps{
+		Cache:          cache,
+		Handler:        NewHandler(svc),
+		Verifier:       fakeVerifier{err: errors.New("no user token on internal routes")},
+		InternalSecret: "s3cret",
+	})
+
+	eventID, userID := uuid.New(), uuid.New()
+	path := "/internal/v1/events/" + eventID.String() + "/participants/" + userID.String()
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("X-Internal-Secret", "wrong")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	req = httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("X-Internal-Secret", "s3cret")
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	data := decodeData(t, rr).Data.(map[string]any)
+	require.Equal(t, "active", data["status"])
+	require.Equal(t, userID.String(), data["user_id"])
+
+	req = httptest.NewRequest(http.MethodGet, "/internal/v1/events/"+uuid.Nil.String()+"/participants/"+userID.String(), nil)
+	req.Header.Set("X-Internal-Secret", "s3cret")
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNotFound, rr.Code)
+	require.Equal(t, "join.not_found", decodeError(t, rr).Error.Code)
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_collaborators;
+DROP TABLE IF EXISTS event_acl;
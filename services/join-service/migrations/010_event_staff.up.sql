@@ -0,0 +1,16 @@
+-- 010_event_staff.sql
+-- Local copy of each event's organizer team, fed by event-service
+-- (event.published owner_id, event.collaborators.updated, event.owner_transferred).
+-- synced_at is the snapshot's updated_at; older snapshots are ignored.
+CREATE TABLE IF NOT EXISTS event_acl (
+  event_id UUID PRIMARY KEY,
+  owner_id UUID NOT NULL,
+  synced_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS event_collaborators (
+  event_id UUID NOT NULL REFERENCES event_acl(event_id) ON DELETE CASCADE,
+  user_id  UUID NOT NULL,
+  role     TEXT NOT NULL,
+  PRIMARY KEY (event_id, user_id)
+);
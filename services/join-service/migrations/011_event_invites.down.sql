@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_invites;
+ALTER TABLE event_capacity DROP COLUMN IF EXISTS visibility;
@@ -0,0 +1,24 @@
+-- 011_event_invites.sql
+-- Visibility from event.published; invite_only events require an invite to join.
+ALTER TABLE event_capacity
+  ADD COLUMN IF NOT EXISTS visibility TEXT NOT NULL DEFAULT 'public';
+
+-- Local copy of event-service invites (event.invite.created / event.invite.revoked).
+-- Either message may arrive first, so kind is nullable until the create lands.
+-- uses is authoritative for max_uses and only ever grows: cancelling a join
+-- does not give the use back.
+CREATE TABLE IF NOT EXISTS event_invites (
+  id UUID PRIMARY KEY,
+  event_id UUID NOT NULL,
+  kind TEXT,                 -- link | code
+  code_hash TEXT,            -- sha256(event_id:CODE), kind=code only
+  max_uses INTEGER,          -- NULL = unlimited
+  uses INTEGER NOT NULL DEFAULT 0,
+  expires_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_invites_code
+  ON event_invites (event_id, code_hash)
+  WHERE code_hash IS NOT NULL AND revoked_at IS NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE event_capacity
+  DROP COLUMN IF EXISTS registration_closes_at,
+  DROP COLUMN IF EXISTS registration_opens_at;
@@ -0,0 +1,5 @@
+-- 012_registration_window.sql
+-- Registration window from event.published / event.updated; NULL = no bound.
+ALTER TABLE event_capacity
+  ADD COLUMN IF NOT EXISTS registration_opens_at TIMESTAMPTZ,
+  ADD COLUMN IF NOT EXISTS registration_closes_at TIMESTAMPTZ;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_joins_user_open;
+ALTER TABLE event_capacity DROP COLUMN IF EXISTS starts_at;
@@ -0,0 +1,9 @@
+-- 013_event_start.sql
+-- Event start time from event.published / event.updated. Account bans only
+-- release seats of events that have not started; NULL (snapshots from
+-- before this migration) counts as not started.
+ALTER TABLE event_capacity
+  ADD COLUMN IF NOT EXISTS starts_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_joins_user_open
+  ON joins (user_id, event_id) WHERE status IN ('active', 'waitlisted');
@@ -57,7 +57,10 @@ func main() {
 
 	// Initialize repository and handler
 	uploadRepo := repository.NewUploadRepository(pool)
-	uploadHandler := handler.NewUploadHandler(uploadRepo, s3Client, publisher, cfg, log)
+	uploadHandler := handler.NewUploadHandler(uploadRepo, uploadRepo, s3Client, publisher, cfg, log)
+	refHandler := handler.NewReferenceHandler(uploadRepo, s3Client, log)
+	quotaHandler := handler.NewQuotaHandler(uploadRepo, cfg, log)
+	moderationHandler := handler.NewModerationHandler(uploadRepo, s3Client, publisher, log)
 
 	// Initialize and run cleaner
 	cleaner := cleanup.NewCleaner(uploadRepo, s3Client, log)
@@ -89,7 +92,25 @@ func main() {
 	r.Route("/media/v1", func(r chi.Router) {
 		r.Post("/request-upload", uploadHandler.RequestUpload)
 		r.Post("/complete", uploadHandler.CompleteUpload)
+		r.Post("/recrop", uploadHandler.Recrop)
 		r.Get("/status/{id}", uploadHandler.GetStatus)
+		r.Get("/image/{id}", uploadHandler.GetImage)
+
+		// Service-to-service: cover/avatar reference checks, and the
+		// admin usage/quota and moderation endpoints proxied by the BFF
+		r.Route("/internal", func(r chi.Router) {
+			r.Use(handler.InternalAuth(cfg.InternalSecret))
+			r.Get("/uploads/{id}", refHandler.GetUpload)
+			r.Post("/uploads/claim", refHandler.Claim)
+			r.Post("/uploads/placeholders", refHandler.Placeholders)
+			r.Post("/uploads/image-urls", refHandler.ImageURLs)
+			r.Get("/users/{id}/usage", quotaHandler.GetUsage)
+			r.Put("/users/{id}/quota", quotaHandler.SetQuota)
+			r.Delete("/users/{id}/quota", quotaHandler.ResetQuota)
+			r.Get("/moderation/queue", moderationHandler.Queue)
+			r.Post("/moderation/{id}/approve", moderationHandler.Approve)
+			r.Post("/moderation/{id}/reject", moderationHandler.Reject)
+		})
 	})
 
 	// Start server
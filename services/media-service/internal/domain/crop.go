@@ -0,0 +1,78 @@
+package domain
+
+import "errors"
+
+// MinCropPixels is the smallest crop rectangle side, in source pixels,
+// accepted once the image's dimensions are known.
+const MinCropPixels = 64
+
+// cropEpsilon absorbs float rounding in client-computed fractions.
+const cropEpsilon = 1e-9
+
+// FocalPoint is the point to keep in view, as fractions of the oriented
+// image: (0, 0) is the top left corner, (1, 1) the bottom right.
+type FocalPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// CropRect is the region to keep, as fractions of the oriented image.
+type CropRect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// Crop tells media-worker what matters in an image. It steers crop-mode
+// sizes (avatars, the aspect-ratio covers); width-only sizes always show
+// the whole image. Exactly one field is set.
+type Crop struct {
+	Focus *FocalPoint `json:"focus,omitempty"`
+	Rect  *CropRect   `json:"rect,omitempty"`
+}
+
+// Validate checks that c lies within the image bounds. A nil crop is
+// valid and means a center crop.
+func (c *Crop) Validate() error {
+	if c == nil {
+		return nil
+	}
+	switch {
+	case c.Focus != nil && c.Rect != nil:
+		return errors.New("crop takes either focus or rect, not both")
+	case c.Focus != nil:
+		if !unit(c.Focus.X) || !unit(c.Focus.Y) {
+			return errors.New("crop focus must lie within the image (0 to 1)")
+		}
+	case c.Rect != nil:
+		r := c.Rect
+		if !unit(r.X) || !unit(r.Y) || r.Width <= 0 || r.Height <= 0 ||
+			r.X+r.Width > 1+cropEpsilon || r.Y+r.Height > 1+cropEpsilon {
+			return errors.New("crop rect must lie within the image (0 to 1)")
+		}
+	default:
+		return errors.New("crop needs focus or rect")
+	}
+	return nil
+}
+
+// ValidateFor also checks a rect against the image's pixel dimensions:
+// each side must cover MinCropPixels, or the whole side of a smaller image.
+func (c *Crop) ValidateFor(width, height int) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if c == nil || c.Rect == nil || width == 0 || height == 0 {
+		return nil
+	}
+	if c.Rect.Width*float64(width) < float64(min(MinCropPixels, width))-cropEpsilon ||
+		c.Rect.Height*float64(height) < float64(min(MinCropPixels, height))-cropEpsilon {
+		return errors.New("crop rect is too small")
+	}
+	return nil
+}
+
+func unit(v float64) bool {
+	return v >= 0 && v <= 1
+}
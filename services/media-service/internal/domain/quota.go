@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Quota caps one user's uploads. A zero limit means unlimited.
+type Quota struct {
+	UploadsPerDay    int   `json:"uploads_per_day"`
+	StoredBytesLimit int64 `json:"stored_bytes_limit"`
+}
+
+// QuotaOverride replaces the configured defaults for one user; nil fields
+// keep the default.
+type QuotaOverride struct {
+	UserID           uuid.UUID `json:"user_id"`
+	UploadsPerDay    *int      `json:"uploads_per_day"`
+	StoredBytesLimit *int64    `json:"stored_bytes_limit"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Usage is what counts against a Quota.
+type Usage struct {
+	UploadsLast24h int   `json:"uploads_last_24h"`
+	StoredBytes    int64 `json:"stored_bytes"` // raw bytes of non-failed uploads; dedup hits are free
+}
+
+// With returns q with o's fields applied.
+func (q Quota) With(o *QuotaOverride) Quota {
+	if o == nil {
+		return q
+	}
+	if o.UploadsPerDay != nil {
+		q.UploadsPerDay = *o.UploadsPerDay
+	}
+	if o.StoredBytesLimit != nil {
+		q.StoredBytesLimit = *o.StoredBytesLimit
+	}
+	return q
+}
+
+// DailyLimitReached reports whether u may not request another upload.
+func (q Quota) DailyLimitReached(u Usage) bool {
+	return q.UploadsPerDay > 0 && u.UploadsLast24h >= q.UploadsPerDay
+}
+
+// ExceedsStorage reports whether storing extra more bytes goes over the limit.
+func (q Quota) ExceedsStorage(u Usage, extra int64) bool {
+	return q.StoredBytesLimit > 0 && u.StoredBytes+extra > q.StoredBytesLimit
+}
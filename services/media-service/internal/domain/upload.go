@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,6 +17,16 @@ const (
 	StatusProcessing UploadStatus = "PROCESSING"
 	StatusReady      UploadStatus = "READY"
 	StatusFailed     UploadStatus = "FAILED"
+	// StatusQuarantined holds an upload the classifier flagged until a
+	// moderator approves or rejects it. No variants are public meanwhile.
+	StatusQuarantined UploadStatus = "QUARANTINED"
+)
+
+// Moderation states. Empty means the upload was never flagged.
+const (
+	ModerationFlagged  = "FLAGGED"
+	ModerationApproved = "APPROVED"
+	ModerationRejected = "REJECTED"
 )
 
 // UploadPurpose indicates what the image is for.
@@ -36,6 +48,27 @@ type Upload struct {
 	ErrorMessage string            `json:"error_message,omitempty"`
 	CreatedAt    time.Time         `json:"created_at"`
 	UpdatedAt    time.Time         `json:"updated_at"`
+	InUseAt      *time.Time        `json:"in_use_at,omitempty"` // first referenced by an event or profile
+	Crop         *Crop             `json:"crop,omitempty"`
+	Placeholder
+	Moderation
+}
+
+// Moderation is the review trail of a flagged upload.
+type Moderation struct {
+	ModerationState  string     `json:"moderation_state,omitempty"`
+	ModerationReason string     `json:"moderation_reason,omitempty"` // classifier reason, then the moderator's
+	ModeratedBy      *uuid.UUID `json:"moderated_by,omitempty"`
+	ModeratedAt      *time.Time `json:"moderated_at,omitempty"`
+}
+
+// Placeholder lets clients reserve space and paint a blurred preview before
+// any variant loads. Zero until the upload is READY.
+type Placeholder struct {
+	Width         int    `json:"width,omitempty"`
+	Height        int    `json:"height,omitempty"`
+	BlurHash      string `json:"blurhash,omitempty"`
+	DominantColor string `json:"dominant_color,omitempty"` // "#rrggbb"
 }
 
 // IsTerminal returns true if the upload is in a terminal state.
@@ -43,7 +76,32 @@ func (u *Upload) IsTerminal() bool {
 	return u.Status == StatusReady || u.Status == StatusFailed
 }
 
-// DerivedSizes defines output sizes for each purpose.
+// Reasons an upload cannot be referenced.
+const (
+	RefNotFound     = "not_found"
+	RefNotOwner     = "not_owner"
+	RefWrongPurpose = "wrong_purpose"
+	RefNotReady     = "not_ready"
+)
+
+// RefProblem reports why ownerID cannot use u as purpose (an avatar, an
+// event cover), or "" when it can. u is nil when the upload does not exist.
+func RefProblem(u *Upload, ownerID uuid.UUID, purpose UploadPurpose) string {
+	switch {
+	case u == nil:
+		return RefNotFound
+	case u.OwnerID != ownerID:
+		return RefNotOwner
+	case u.Purpose != purpose:
+		return RefWrongPurpose
+	case u.Status != StatusReady:
+		return RefNotReady
+	}
+	return ""
+}
+
+// DerivedSizes defines output sizes for each purpose. Crop-mode sizes
+// follow the upload's Crop.
 var DerivedSizes = map[UploadPurpose][]ImageSize{
 	PurposeAvatar: {
 		{Name: "256", Width: 256, Height: 256, Crop: true},
@@ -52,6 +110,9 @@ var DerivedSizes = map[UploadPurpose][]ImageSize{
 	PurposeEventCover: {
 		{Name: "800", Width: 800, Height: 0, Crop: false}, // max width, preserve aspect
 		{Name: "1600", Width: 1600, Height: 0, Crop: false},
+		{Name: "16x9-800", Width: 800, Height: 450, Crop: true}, // feed cards
+		{Name: "16x9-1600", Width: 1600, Height: 900, Crop: true},
+		{Name: "1x1-400", Width: 400, Height: 400, Crop: true}, // thumbnails
 	},
 }
 
@@ -60,5 +121,67 @@ type ImageSize struct {
 	Name   string // e.g., "256", "512", "800"
 	Width  int
 	Height int  // 0 = preserve aspect ratio
-	Crop   bool // true = center crop to exact dimensions
+	Crop   bool // true = crop to exact dimensions around the upload's Crop
+}
+
+// ImageFormat is a derived image encoding. DerivedKeys holds JPEG under the
+// bare size ("800") and other formats as "<size>.<format>" ("800.webp").
+type ImageFormat struct {
+	Name        string
+	ContentType string
+}
+
+// ImageFormats lists derived formats, preferred first. JPEG comes last and
+// is served whatever the client accepts.
+var ImageFormats = []ImageFormat{
+	{Name: "avif", ContentType: "image/avif"},
+	{Name: "webp", ContentType: "image/webp"},
+	{Name: "jpeg", ContentType: "image/jpeg"},
+}
+
+// DefaultSize is the size served when a client does not ask for one.
+func (u *Upload) DefaultSize() string {
+	if sizes := DerivedSizes[u.Purpose]; len(sizes) > 0 {
+		return sizes[0].Name
+	}
+	return ""
+}
+
+// VariantKey picks the object key of the best variant of size for an
+// Accept header. AVIF and WebP must be listed explicitly: "image/*" is sent
+// by browsers that cannot decode them.
+func (u *Upload) VariantKey(size, accept string) (key string, format ImageFormat, ok bool) {
+	for _, f := range ImageFormats {
+		name := size
+		if f.Name != "jpeg" {
+			if !acceptsExplicitly(accept, f.ContentType) {
+				continue
+			}
+			name = size + "." + f.Name
+		}
+		if key, ok := u.DerivedKeys[name]; ok {
+			return key, f, true
+		}
+	}
+	return "", ImageFormat{}, false
+}
+
+// acceptsExplicitly reports whether accept lists contentType with q > 0.
+func acceptsExplicitly(accept, contentType string) bool {
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		if !strings.EqualFold(strings.TrimSpace(fields[0]), contentType) {
+			continue
+		}
+		for _, p := range fields[1:] {
+			k, v, _ := strings.Cut(strings.TrimSpace(p), "=")
+			if strings.EqualFold(k, "q") {
+				if q, err := strconv.ParseFloat(v, 64); err == nil && q <= 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
 }
@@ -2,29 +2,58 @@ package handler
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
 	"github.com/baechuer/cityevents/services/media-service/internal/domain"
+	"github.com/baechuer/cityevents/services/media-service/internal/messaging"
 )
 
 // UploadRepository defines database operations for uploads.
 type UploadRepository interface {
 	Create(ctx context.Context, upload *domain.Upload) error
 	GetByID(ctx context.Context, id uuid.UUID) (*domain.Upload, error)
-	UpdateStatus(ctx context.Context, id uuid.UUID, status domain.UploadStatus) error
+	MarkUploaded(ctx context.Context, id uuid.UUID, size int64, crop *domain.Crop) error
+	SetCrop(ctx context.Context, id uuid.UUID, crop *domain.Crop) (bool, error)
 	UpdateStatusWithError(ctx context.Context, id uuid.UUID, status domain.UploadStatus, errMsg string) error
 }
 
+// ReferenceRepository backs the internal reference checks.
+type ReferenceRepository interface {
+	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Upload, error)
+	MarkInUse(ctx context.Context, ids []uuid.UUID) error
+}
+
+// QuotaRepository reads usage and the per-user quota overrides.
+type QuotaRepository interface {
+	Usage(ctx context.Context, ownerID uuid.UUID, since time.Time) (domain.Usage, error)
+	GetQuotaOverride(ctx context.Context, userID uuid.UUID) (*domain.QuotaOverride, error)
+	SetQuotaOverride(ctx context.Context, o *domain.QuotaOverride) error
+	DeleteQuotaOverride(ctx context.Context, userID uuid.UUID) error
+}
+
+// ModerationRepository backs the moderator review endpoints.
+type ModerationRepository interface {
+	GetByID(ctx context.Context, id uuid.UUID) (*domain.Upload, error)
+	ListQuarantined(ctx context.Context, limit int) ([]*domain.Upload, error)
+	Approve(ctx context.Context, id, moderator uuid.UUID) (bool, error)
+	Reject(ctx context.Context, id, moderator uuid.UUID, reason string) ([]*domain.Upload, error)
+}
+
 // FileStorage defines file operations.
 type FileStorage interface {
 	GeneratePresignedPutURL(ctx context.Context, objectKey string, contentLengthLimit int64) (string, error)
 	ObjectExists(ctx context.Context, objectKey string) (bool, int64, error)
 	DeleteRawObject(ctx context.Context, objectKey string) error
+	DeletePublicObject(ctx context.Context, objectKey string) error
+	GeneratePresignedGetURL(ctx context.Context, objectKey string) (string, error)
 	PublicURL(objectKey string) string
 }
 
 // MessagePublisher defines message publishing operations.
 type MessagePublisher interface {
 	PublishProcessImage(ctx context.Context, uploadID, objectKey, purpose string) error
+	PublishRecropImage(ctx context.Context, uploadID, objectKey, purpose string) error
+	PublishUploadRejected(ctx context.Context, msg messaging.UploadRejectedMessage) error
 }
@@ -0,0 +1,308 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/cityevents/services/media-service/internal/domain"
+)
+
+const (
+	// maxClaimIDs bounds one claim request; an event has at most two covers.
+	maxClaimIDs = 10
+	// maxPlaceholderIDs bounds one placeholder lookup: a feed page of covers.
+	maxPlaceholderIDs = 100
+)
+
+// InternalAuth restricts a route to other services holding the shared
+// X-Internal-Secret.
+func InternalAuth(secret string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if secret == "" {
+				http.Error(w, "internal auth misconfigured", http.StatusInternalServerError)
+				return
+			}
+			got := r.Header.Get("X-Internal-Secret")
+			if subtle.ConstantTimeCompare([]byte(got), []byte(secret)) != 1 {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ObjectURLs turns derived object keys into public URLs.
+type ObjectURLs interface {
+	PublicURL(objectKey string) string
+}
+
+// ReferenceHandler lets event-service and auth-service check uploads before
+// storing them as covers or avatars, and other services look up their
+// placeholders and image URLs.
+type ReferenceHandler struct {
+	repo ReferenceRepository
+	urls ObjectURLs
+	log  zerolog.Logger
+}
+
+// NewReferenceHandler creates a new reference handler.
+func NewReferenceHandler(repo ReferenceRepository, urls ObjectURLs, log zerolog.Logger) *ReferenceHandler {
+	return &ReferenceHandler{repo: repo, urls: urls, log: log}
+}
+
+// UploadInfo is the internal view of an upload.
+type UploadInfo struct {
+	ID      string `json:"id"`
+	OwnerID string `json:"owner_id"`
+	Purpose string `json:"purpose"`
+	Status  string `json:"status"`
+	InUse   bool   `json:"in_use"`
+}
+
+// ClaimRequest asks to reference uploads as purpose on behalf of owner_id.
+type ClaimRequest struct {
+	OwnerID string   `json:"owner_id"`
+	Purpose string   `json:"purpose"`
+	IDs     []string `json:"ids"`
+}
+
+// ClaimItem is the verdict for one requested id. Reason is one of
+// not_found, not_owner, wrong_purpose, not_ready.
+type ClaimItem struct {
+	ID     string `json:"id"`
+	OK     bool   `json:"ok"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ClaimResponse lists a verdict per id, in request order.
+type ClaimResponse struct {
+	OK    bool        `json:"ok"`
+	Items []ClaimItem `json:"items"`
+}
+
+// GetUpload returns owner, purpose and status of one upload.
+func (h *ReferenceHandler) GetUpload(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		errorJSON(w, http.StatusBadRequest, "invalid upload ID")
+		return
+	}
+
+	uploads, err := h.repo.GetByIDs(r.Context(), []uuid.UUID{id})
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get upload")
+		errorJSON(w, http.StatusInternalServerError, "failed to get upload")
+		return
+	}
+	if len(uploads) == 0 {
+		errorJSON(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	u := uploads[0]
+	writeJSON(w, http.StatusOK, UploadInfo{
+		ID:      u.ID.String(),
+		OwnerID: u.OwnerID.String(),
+		Purpose: string(u.Purpose),
+		Status:  string(u.Status),
+		InUse:   u.InUseAt != nil,
+	})
+}
+
+// Claim checks that every id is a READY upload of owner_id with the given
+// purpose. Only when all pass are they marked in use, which keeps the
+// cleaner away from them; otherwise nothing is marked and the caller
+// rejects its write. Claiming an upload twice is harmless.
+func (h *ReferenceHandler) Claim(w http.ResponseWriter, r *http.Request) {
+	var req ClaimRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ownerID, err := uuid.Parse(req.OwnerID)
+	if err != nil {
+		errorJSON(w, http.StatusBadRequest, "invalid owner_id")
+		return
+	}
+	purpose := domain.UploadPurpose(req.Purpose)
+	if purpose != domain.PurposeAvatar && purpose != domain.PurposeEventCover {
+		errorJSON(w, http.StatusBadRequest, "invalid purpose")
+		return
+	}
+	if len(req.IDs) == 0 || len(req.IDs) > maxClaimIDs {
+		errorJSON(w, http.StatusBadRequest, "ids must hold 1 to 10 upload IDs")
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.IDs))
+	for _, s := range req.IDs {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			errorJSON(w, http.StatusBadRequest, "invalid upload ID: "+s)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	uploads, err := h.repo.GetByIDs(r.Context(), ids)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get uploads")
+		errorJSON(w, http.StatusInternalServerError, "failed to get uploads")
+		return
+	}
+	byID := make(map[uuid.UUID]*domain.Upload, len(uploads))
+	for _, u := range uploads {
+		byID[u.ID] = u
+	}
+
+	resp := ClaimResponse{OK: true, Items: make([]ClaimItem, 0, len(ids))}
+	for _, id := range ids {
+		reason := domain.RefProblem(byID[id], ownerID, purpose)
+		resp.Items = append(resp.Items, ClaimItem{ID: id.String(), OK: reason == "", Reason: reason})
+		if reason != "" {
+			resp.OK = false
+		}
+	}
+
+	if resp.OK {
+		if err := h.repo.MarkInUse(r.Context(), ids); err != nil {
+			h.log.Error().Err(err).Msg("failed to mark uploads in use")
+			errorJSON(w, http.StatusInternalServerError, "failed to mark uploads in use")
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// PlaceholdersRequest asks for the placeholders of uploads.
+type PlaceholdersRequest struct {
+	IDs []string `json:"ids"`
+}
+
+// PlaceholdersResponse maps upload id to placeholder. Only READY uploads
+// are included; unknown or malformed ids are skipped.
+type PlaceholdersResponse struct {
+	Items map[string]domain.Placeholder `json:"items"`
+}
+
+// Placeholders returns dimensions, BlurHash and dominant color for a batch
+// of uploads so the BFF can inline them into event cards.
+func (h *ReferenceHandler) Placeholders(w http.ResponseWriter, r *http.Request) {
+	var req PlaceholdersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if len(req.IDs) > maxPlaceholderIDs {
+		errorJSON(w, http.StatusBadRequest, "ids must hold at most 100 upload IDs")
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.IDs))
+	for _, s := range req.IDs {
+		if id, err := uuid.Parse(s); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	resp := PlaceholdersResponse{Items: map[string]domain.Placeholder{}}
+	if len(ids) == 0 {
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	uploads, err := h.repo.GetByIDs(r.Context(), ids)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get uploads")
+		errorJSON(w, http.StatusInternalServerError, "failed to get uploads")
+		return
+	}
+	for _, u := range uploads {
+		if u.Status == domain.StatusReady {
+			resp.Items[u.ID.String()] = u.Placeholder
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ImageURLsRequest asks for the JPEG URL of size for a batch of uploads.
+type ImageURLsRequest struct {
+	IDs  []string `json:"ids"`
+	Size string   `json:"size"` // empty => the purpose's default size
+}
+
+// ImageURLsResponse maps upload id to URL. Only READY uploads that have the
+// size are included; unknown or malformed ids are skipped.
+type ImageURLsResponse struct {
+	Items map[string]string `json:"items"`
+}
+
+// ImageURLs resolves uploads to public JPEG URLs through their
+// derived_keys, for clients that cannot follow the Accept-based redirect
+// (e.g. emails).
+func (h *ReferenceHandler) ImageURLs(w http.ResponseWriter, r *http.Request) {
+	var req ImageURLsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if len(req.IDs) > maxPlaceholderIDs {
+		errorJSON(w, http.StatusBadRequest, "ids must hold at most 100 upload IDs")
+		return
+	}
+
+	ids := make([]uuid.UUID, 0, len(req.IDs))
+	for _, s := range req.IDs {
+		if id, err := uuid.Parse(s); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	resp := ImageURLsResponse{Items: map[string]string{}}
+	if len(ids) == 0 {
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+
+	uploads, err := h.repo.GetByIDs(r.Context(), ids)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get uploads")
+		errorJSON(w, http.StatusInternalServerError, "failed to get uploads")
+		return
+	}
+	for _, u := range uploads {
+		if u.Status != domain.StatusReady {
+			continue
+		}
+		size := req.Size
+		if size == "" {
+			size = u.DefaultSize()
+		}
+		// No Accept header: always the JPEG
+		if key, _, ok := u.VariantKey(size, ""); ok {
+			resp.Items[u.ID.String()] = h.urls.PublicURL(key)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, data interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(data)
+}
+
+func errorJSON(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/baechuer/cityevents/services/media-service/internal/domain"
+)
+
+func claim(t *testing.T, h *ReferenceHandler, body string) ClaimResponse {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/media/v1/internal/uploads/claim", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	h.Claim(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var resp ClaimResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestClaim_MarksInUseWhenAllValid(t *testing.T) {
+	repo := new(MockRepo)
+	owner := uuid.New()
+	a := &domain.Upload{ID: uuid.New(), OwnerID: owner, Purpose: domain.PurposeEventCover, Status: domain.StatusReady}
+	b := &domain.Upload{ID: uuid.New(), OwnerID: owner, Purpose: domain.PurposeEventCover, Status: domain.StatusReady}
+	ids := []uuid.UUID{a.ID, b.ID}
+
+	repo.On("GetByIDs", mock.Anything, ids).Return([]*domain.Upload{b, a}, nil)
+	repo.On("MarkInUse", mock.Anything, ids).Return(nil)
+
+	h := NewReferenceHandler(repo, fakeURLs{}, zerolog.Nop())
+	resp := claim(t, h, `{"owner_id":"`+owner.String()+`","purpose":"event_cover","ids":["`+a.ID.String()+`","`+b.ID.String()+`"]}`)
+
+	assert.True(t, resp.OK)
+	assert.Len(t, resp.Items, 2)
+	assert.Equal(t, a.ID.String(), resp.Items[0].ID)
+	repo.AssertExpectations(t)
+}
+
+func TestClaim_RejectsWithoutMarking(t *testing.T) {
+	owner := uuid.New()
+	ready := &domain.Upload{ID: uuid.New(), OwnerID: owner, Purpose: domain.PurposeEventCover, Status: domain.StatusReady}
+
+	tests := []struct {
+		name   string
+		upload *domain.Upload // nil: missing
+		reason string
+	}{
+		{"missing", nil, domain.RefNotFound},
+		{"other owner", &domain.Upload{ID: uuid.New(), OwnerID: uuid.New(), Purpose: domain.PurposeEventCover, Status: domain.StatusReady}, domain.RefNotOwner},
+		{"avatar as cover", &domain.Upload{ID: uuid.New(), OwnerID: owner, Purpose: domain.PurposeAvatar, Status: domain.StatusReady}, domain.RefWrongPurpose},
+		{"still processing", &domain.Upload{ID: uuid.New(), OwnerID: owner, Purpose: domain.PurposeEventCover, Status: domain.StatusProcessing}, domain.RefNotReady},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockRepo)
+			badID := uuid.New()
+			found := []*domain.Upload{ready}
+			if tt.upload != nil {
+				badID = tt.upload.ID
+				found = append(found, tt.upload)
+			}
+			repo.On("GetByIDs", mock.Anything, mock.Anything).Return(found, nil)
+
+			h := NewReferenceHandler(repo, fakeURLs{}, zerolog.Nop())
+			resp := claim(t, h, `{"owner_id":"`+owner.String()+`","purpose":"event_cover","ids":["`+ready.ID.String()+`","`+badID.String()+`"]}`)
+
+			assert.False(t, resp.OK)
+			assert.True(t, resp.Items[0].OK)
+			assert.Equal(t, tt.reason, resp.Items[1].Reason)
+			repo.AssertNotCalled(t, "MarkInUse", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestInternalAuth_RequiresSecret(t *testing.T) {
+	h := InternalAuth("s3cret")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/media/v1/internal/uploads/x", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	req.Header.Set("X-Internal-Secret", "s3cret")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
+
+func TestPlaceholders_OnlyReady(t *testing.T) {
+	repo := new(MockRepo)
+	ready := &domain.Upload{ID: uuid.New(), Status: domain.StatusReady,
+		Placeholder: domain.Placeholder{Width: 1600, Height: 900, BlurHash: "LEHV6nWB2yk8pyo0adR*.7kCMdnj", DominantColor: "#1478c8"}}
+	pending := &domain.Upload{ID: uuid.New(), Status: domain.StatusProcessing}
+	ids := []uuid.UUID{ready.ID, pending.ID}
+
+	repo.On("GetByIDs", mock.Anything, ids).Return([]*domain.Upload{ready, pending}, nil)
+
+	h := NewReferenceHandler(repo, fakeURLs{}, zerolog.Nop())
+	body := `{"ids":["` + ready.ID.String() + `","` + pending.ID.String() + `","not-a-uuid"]}`
+	w := httptest.NewRecorder()
+	h.Placeholders(w, httptest.NewRequest(http.MethodPost, "/media/v1/internal/uploads/placeholders", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp PlaceholdersResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, map[string]domain.Placeholder{ready.ID.String(): ready.Placeholder}, resp.Items)
+	repo.AssertExpectations(t)
+}
+
+type fakeURLs struct{}
+
+func (fakeURLs) PublicURL(objectKey string) string { return "http://cdn/" + objectKey }
+
+func TestImageURLs_ResolvesDerivedKeys(t *testing.T) {
+	repo := new(MockRepo)
+	ready := &domain.Upload{ID: uuid.New(), Purpose: domain.PurposeEventCover, Status: domain.StatusReady,
+		DerivedKeys: map[string]string{
+			"800":      "derived/event_cover/root_800.jpg",
+			"800.webp": "derived/event_cover/root_800.webp",
+		}}
+	noSize := &domain.Upload{ID: uuid.New(), Purpose: domain.PurposeEventCover, Status: domain.StatusReady,
+		DerivedKeys: map[string]string{"1600": "derived/event_cover/x_1600.jpg"}}
+	pending := &domain.Upload{ID: uuid.New(), Status: domain.StatusProcessing}
+	ids := []uuid.UUID{ready.ID, noSize.ID, pending.ID}
+
+	repo.On("GetByIDs", mock.Anything, ids).Return([]*domain.Upload{ready, noSize, pending}, nil)
+
+	h := NewReferenceHandler(repo, fakeURLs{}, zerolog.Nop())
+	body := `{"size":"800","ids":["` + ready.ID.String() + `","` + noSize.ID.String() + `","` + pending.ID.String() + `"]}`
+	w := httptest.NewRecorder()
+	h.ImageURLs(w, httptest.NewRequest(http.MethodPost, "/media/v1/internal/uploads/image-urls", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var resp ImageURLsResponse
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	assert.Equal(t, map[string]string{ready.ID.String(): "http://cdn/derived/event_cover/root_800.jpg"}, resp.Items)
+	repo.AssertExpectations(t)
+}
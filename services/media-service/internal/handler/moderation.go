@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/cityevents/services/media-service/internal/domain"
+	"github.com/baechuer/cityevents/services/media-service/internal/messaging"
+)
+
+const (
+	defaultQueueLimit = 50
+	maxQueueLimit     = 200
+	// maxRejectReason bounds the reason stored and sent to event-service.
+	maxRejectReason = 500
+)
+
+// ModerationHandler serves the review queue for uploads the classifier
+// flagged, proxied by the BFF for moderators. The acting moderator comes
+// in X-Moderator-ID.
+type ModerationHandler struct {
+	repo      ModerationRepository
+	s3        FileStorage
+	publisher MessagePublisher
+	log       zerolog.Logger
+}
+
+// NewModerationHandler creates a new moderation handler.
+func NewModerationHandler(repo ModerationRepository, s3 FileStorage, publisher MessagePublisher, log zerolog.Logger) *ModerationHandler {
+	return &ModerationHandler{repo: repo, s3: s3, publisher: publisher, log: log}
+}
+
+// QueueItem is one quarantined upload. PreviewURL is a short-lived link to
+// the raw image.
+type QueueItem struct {
+	ID            string    `json:"id"`
+	OwnerID       string    `json:"owner_id"`
+	Purpose       string    `json:"purpose"`
+	Reason        string    `json:"reason"`
+	PreviewURL    string    `json:"preview_url,omitempty"`
+	Width         int       `json:"width,omitempty"`
+	Height        int       `json:"height,omitempty"`
+	DominantColor string    `json:"dominant_color,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	QuarantinedAt time.Time `json:"quarantined_at"`
+}
+
+// QueueResponse lists quarantined uploads, longest waiting first.
+type QueueResponse struct {
+	Items []QueueItem `json:"items"`
+}
+
+// RejectRequest carries the moderator's reason.
+type RejectRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ModerationResponse reports the outcome of a decision.
+type ModerationResponse struct {
+	ID        string   `json:"id"`
+	Status    string   `json:"status"`
+	UploadIDs []string `json:"upload_ids,omitempty"` // every upload a rejection covered
+}
+
+// Queue lists quarantined uploads; ?limit= defaults to 50, max 200.
+func (h *ModerationHandler) Queue(w http.ResponseWriter, r *http.Request) {
+	limit := defaultQueueLimit
+	if s := r.URL.Query().Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 || n > maxQueueLimit {
+			errorJSON(w, http.StatusBadRequest, "limit must be between 1 and 200")
+			return
+		}
+		limit = n
+	}
+
+	uploads, err := h.repo.ListQuarantined(r.Context(), limit)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to list quarantined uploads")
+		errorJSON(w, http.StatusInternalServerError, "failed to list quarantined uploads")
+		return
+	}
+
+	resp := QueueResponse{Items: make([]QueueItem, 0, len(uploads))}
+	for _, u := range uploads {
+		item := QueueItem{
+			ID:            u.ID.String(),
+			OwnerID:       u.OwnerID.String(),
+			Purpose:       string(u.Purpose),
+			Reason:        u.ModerationReason,
+			Width:         u.Width,
+			Height:        u.Height,
+			DominantColor: u.DominantColor,
+			CreatedAt:     u.CreatedAt,
+			QuarantinedAt: u.UpdatedAt,
+		}
+		if u.RawObjectKey != "" {
+			url, err := h.s3.GeneratePresignedGetURL(r.Context(), u.RawObjectKey)
+			if err != nil {
+				h.log.Warn().Err(err).Str("upload_id", u.ID.String()).Msg("failed to presign preview")
+			}
+			item.PreviewURL = url
+		}
+		resp.Items = append(resp.Items, item)
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Approve releases a quarantined upload: it goes back to media-worker,
+// which publishes the variants without classifying again.
+func (h *ModerationHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	id, moderator, ok := h.decisionIDs(w, r)
+	if !ok {
+		return
+	}
+
+	u, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get upload")
+		errorJSON(w, http.StatusInternalServerError, "failed to get upload")
+		return
+	}
+	if u == nil {
+		errorJSON(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	approved, err := h.repo.Approve(r.Context(), id, moderator)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to approve upload")
+		errorJSON(w, http.StatusInternalServerError, "failed to approve upload")
+		return
+	}
+	if !approved {
+		errorJSON(w, http.StatusConflict, "upload is not quarantined")
+		return
+	}
+
+	// The upload is UPLOADED and APPROVED now, so this call can be retried
+	if err := h.publisher.PublishProcessImage(r.Context(), id.String(), u.RawObjectKey, string(u.Purpose)); err != nil {
+		h.log.Error().Err(err).Str("upload_id", id.String()).Msg("failed to requeue approved upload")
+		errorJSON(w, http.StatusInternalServerError, "failed to queue processing, retry")
+		return
+	}
+
+	h.log.Info().Str("upload_id", id.String()).Str("moderator", moderator.String()).Msg("upload approved")
+	writeJSON(w, http.StatusOK, ModerationResponse{ID: id.String(), Status: string(domain.StatusUploaded)})
+}
+
+// Reject fails the upload and its dedup group, deletes their objects and
+// announces media.upload.rejected so event-service detaches the covers.
+// Rejecting a READY upload takes down an image that is already public.
+// Every step is repeatable, so the caller retries on error.
+func (h *ModerationHandler) Reject(w http.ResponseWriter, r *http.Request) {
+	id, moderator, ok := h.decisionIDs(w, r)
+	if !ok {
+		return
+	}
+
+	var req RejectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if req.Reason == "" || len(req.Reason) > maxRejectReason {
+		errorJSON(w, http.StatusBadRequest, "reason must be 1 to 500 characters")
+		return
+	}
+
+	u, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get upload")
+		errorJSON(w, http.StatusInternalServerError, "failed to get upload")
+		return
+	}
+	if u == nil {
+		errorJSON(w, http.StatusNotFound, "upload not found")
+		return
+	}
+
+	rejected, err := h.repo.Reject(r.Context(), id, moderator, req.Reason)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to reject upload")
+		errorJSON(w, http.StatusInternalServerError, "failed to reject upload")
+		return
+	}
+	if len(rejected) == 0 {
+		errorJSON(w, http.StatusConflict, "upload cannot be rejected in status "+string(u.Status))
+		return
+	}
+
+	ids := make([]string, 0, len(rejected))
+	for _, ru := range rejected {
+		ids = append(ids, ru.ID.String())
+		if ru.RawObjectKey != "" {
+			if err := h.s3.DeleteRawObject(r.Context(), ru.RawObjectKey); err != nil {
+				h.log.Warn().Err(err).Str("key", ru.RawObjectKey).Msg("failed to delete raw object")
+			}
+		}
+		// Dedup members point at the root's objects; deleting twice is harmless
+		for _, key := range ru.DerivedKeys {
+			if err := h.s3.DeletePublicObject(r.Context(), key); err != nil {
+				h.log.Warn().Err(err).Str("key", key).Msg("failed to delete derived object")
+			}
+		}
+	}
+
+	msg := messaging.UploadRejectedMessage{
+		UploadIDs: ids,
+		OwnerID:   u.OwnerID.String(),
+		Purpose:   string(u.Purpose),
+		Reason:    req.Reason,
+	}
+	if err := h.publisher.PublishUploadRejected(r.Context(), msg); err != nil {
+		h.log.Error().Err(err).Str("upload_id", id.String()).Msg("failed to publish upload rejected")
+		errorJSON(w, http.StatusInternalServerError, "failed to notify services, retry")
+		return
+	}
+
+	h.log.Info().Strs("upload_ids", ids).Str("moderator", moderator.String()).Msg("upload rejected")
+	writeJSON(w, http.StatusOK, ModerationResponse{ID: id.String(), Status: string(domain.StatusFailed), UploadIDs: ids})
+}
+
+// decisionIDs parses the upload id and the acting moderator, writing a 400
+// when either is missing or malformed.
+func (h *ModerationHandler) decisionIDs(w http.ResponseWriter, r *http.Request) (id, moderator uuid.UUID, ok bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		errorJSON(w, http.StatusBadRequest, "invalid upload ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	moderator, err = uuid.Parse(r.Header.Get("X-Moderator-ID"))
+	if err != nil {
+		errorJSON(w, http.StatusBadRequest, "missing or invalid X-Moderator-ID")
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, moderator, true
+}
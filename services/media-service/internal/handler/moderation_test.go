@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/baechuer/cityevents/services/media-service/internal/domain"
+	"github.com/baechuer/cityevents/services/media-service/internal/messaging"
+)
+
+func moderationRouter(h *ModerationHandler) chi.Router {
+	r := chi.NewRouter()
+	r.Get("/moderation/queue", h.Queue)
+	r.Post("/moderation/{id}/approve", h.Approve)
+	r.Post("/moderation/{id}/reject", h.Reject)
+	return r
+}
+
+func TestModerationHandler_Queue(t *testing.T) {
+	repo := new(MockRepo)
+	s3 := new(MockStorage)
+	h := NewModerationHandler(repo, s3, new(MockPublisher), zerolog.Nop())
+
+	u := &domain.Upload{ID: uuid.New(), OwnerID: uuid.New(), Purpose: domain.PurposeEventCover, Status: domain.StatusQuarantined, RawObjectKey: "raw/a.bin"}
+	u.ModerationReason = "blocklisted"
+	repo.On("ListQuarantined", mock.Anything, 10).Return([]*domain.Upload{u}, nil)
+	s3.On("GeneratePresignedGetURL", mock.Anything, "raw/a.bin").Return("http://s3/raw/a.bin?sig", nil)
+
+	rr := httptest.NewRecorder()
+	moderationRouter(h).ServeHTTP(rr, httptest.NewRequest("GET", "/moderation/queue?limit=10", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp QueueResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Len(t, resp.Items, 1)
+	assert.Equal(t, "blocklisted", resp.Items[0].Reason)
+	assert.Equal(t, "http://s3/raw/a.bin?sig", resp.Items[0].PreviewURL)
+}
+
+func TestModerationHandler_Approve(t *testing.T) {
+	moderator := uuid.New()
+	id := uuid.New()
+	u := &domain.Upload{ID: id, Purpose: domain.PurposeEventCover, Status: domain.StatusQuarantined, RawObjectKey: "raw/a.bin"}
+
+	t.Run("requeues", func(t *testing.T) {
+		repo := new(MockRepo)
+		pub := new(MockPublisher)
+		h := NewModerationHandler(repo, new(MockStorage), pub, zerolog.Nop())
+		repo.On("GetByID", mock.Anything, id).Return(u, nil)
+		repo.On("Approve", mock.Anything, id, moderator).Return(true, nil)
+		pub.On("PublishProcessImage", mock.Anything, id.String(), "raw/a.bin", "event_cover").Return(nil)
+
+		req := httptest.NewRequest("POST", "/moderation/"+id.String()+"/approve", nil)
+		req.Header.Set("X-Moderator-ID", moderator.String())
+		rr := httptest.NewRecorder()
+		moderationRouter(h).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		pub.AssertExpectations(t)
+	})
+
+	t.Run("not quarantined", func(t *testing.T) {
+		repo := new(MockRepo)
+		pub := new(MockPublisher)
+		h := NewModerationHandler(repo, new(MockStorage), pub, zerolog.Nop())
+		repo.On("GetByID", mock.Anything, id).Return(u, nil)
+		repo.On("Approve", mock.Anything, id, moderator).Return(false, nil)
+
+		req := httptest.NewRequest("POST", "/moderation/"+id.String()+"/approve", nil)
+		req.Header.Set("X-Moderator-ID", moderator.String())
+		rr := httptest.NewRecorder()
+		moderationRouter(h).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		pub.AssertNotCalled(t, "PublishProcessImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing moderator", func(t *testing.T) {
+		h := NewModerationHandler(new(MockRepo), new(MockStorage), new(MockPublisher), zerolog.Nop())
+		rr := httptest.NewRecorder()
+		moderationRouter(h).ServeHTTP(rr, httptest.NewRequest("POST", "/moderation/"+id.String()+"/approve", nil))
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestModerationHandler_Reject(t *testing.T) {
+	repo := new(MockRepo)
+	s3 := new(MockStorage)
+	pub := new(MockPublisher)
+	h := NewModerationHandler(repo, s3, pub, zerolog.Nop())
+
+	moderator := uuid.New()
+	owner := uuid.New()
+	root := &domain.Upload{ID: uuid.New(), OwnerID: owner, Purpose: domain.PurposeEventCover, Status: domain.StatusReady,
+		RawObjectKey: "raw/root.bin", DerivedKeys: map[string]string{"800": "derived/event_cover/root_800.jpg"}}
+	dup := &domain.Upload{ID: uuid.New(), OwnerID: owner, Purpose: domain.PurposeEventCover,
+		DerivedKeys: map[string]string{"800": "derived/event_cover/root_800.jpg"}}
+
+	repo.On("GetByID", mock.Anything, root.ID).Return(root, nil)
+	repo.On("Reject", mock.Anything, root.ID, moderator, "graphic content").Return([]*domain.Upload{root, dup}, nil)
+	s3.On("DeleteRawObject", mock.Anything, "raw/root.bin").Return(nil)
+	s3.On("DeletePublicObject", mock.Anything, "derived/event_cover/root_800.jpg").Return(nil)
+	pub.On("PublishUploadRejected", mock.Anything, messaging.UploadRejectedMessage{
+		UploadIDs: []string{root.ID.String(), dup.ID.String()},
+		OwnerID:   owner.String(),
+		Purpose:   "event_cover",
+		Reason:    "graphic content",
+	}).Return(nil)
+
+	req := httptest.NewRequest("POST", "/moderation/"+root.ID.String()+"/reject", strings.NewReader(`{"reason":" graphic content "}`))
+	req.Header.Set("X-Moderator-ID", moderator.String())
+	rr := httptest.NewRecorder()
+	moderationRouter(h).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp ModerationResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, "FAILED", resp.Status)
+	assert.Len(t, resp.UploadIDs, 2)
+	s3.AssertExpectations(t)
+	pub.AssertExpectations(t)
+}
+
+func TestModerationHandler_RejectRequiresReason(t *testing.T) {
+	h := NewModerationHandler(new(MockRepo), new(MockStorage), new(MockPublisher), zerolog.Nop())
+	req := httptest.NewRequest("POST", "/moderation/"+uuid.NewString()+"/reject", strings.NewReader(`{"reason":"  "}`))
+	req.Header.Set("X-Moderator-ID", uuid.NewString())
+	rr := httptest.NewRecorder()
+	moderationRouter(h).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/cityevents/services/media-service/internal/config"
+	"github.com/baechuer/cityevents/services/media-service/internal/domain"
+)
+
+// quotaWindow is the rolling window for the daily upload limit.
+const quotaWindow = 24 * time.Hour
+
+// userQuota resolves the user's effective quota and current usage.
+func userQuota(ctx context.Context, repo QuotaRepository, cfg *config.Config, userID uuid.UUID) (domain.Quota, domain.Usage, error) {
+	override, err := repo.GetQuotaOverride(ctx, userID)
+	if err != nil {
+		return domain.Quota{}, domain.Usage{}, err
+	}
+	usage, err := repo.Usage(ctx, userID, time.Now().Add(-quotaWindow))
+	if err != nil {
+		return domain.Quota{}, domain.Usage{}, err
+	}
+	return defaultQuota(cfg).With(override), usage, nil
+}
+
+func defaultQuota(cfg *config.Config) domain.Quota {
+	return domain.Quota{UploadsPerDay: cfg.UploadsPerDay, StoredBytesLimit: cfg.StorageQuotaBytes}
+}
+
+// QuotaHandler serves the admin usage and quota endpoints, proxied by the
+// BFF.
+type QuotaHandler struct {
+	repo QuotaRepository
+	cfg  *config.Config
+	log  zerolog.Logger
+}
+
+// NewQuotaHandler creates a new quota handler.
+func NewQuotaHandler(repo QuotaRepository, cfg *config.Config, log zerolog.Logger) *QuotaHandler {
+	return &QuotaHandler{repo: repo, cfg: cfg, log: log}
+}
+
+// UsageResponse shows a user's usage against their effective quota.
+type UsageResponse struct {
+	UserID   string                `json:"user_id"`
+	Usage    domain.Usage          `json:"usage"`
+	Quota    domain.Quota          `json:"quota"`
+	Default  domain.Quota          `json:"default"`
+	Override *domain.QuotaOverride `json:"override,omitempty"`
+}
+
+// SetQuotaRequest overrides one or both limits; an omitted field keeps the
+// default, 0 means unlimited.
+type SetQuotaRequest struct {
+	UploadsPerDay    *int   `json:"uploads_per_day"`
+	StoredBytesLimit *int64 `json:"stored_bytes_limit"`
+}
+
+// GetUsage returns the user's usage, effective quota and override.
+func (h *QuotaHandler) GetUsage(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		errorJSON(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	h.writeUsage(w, r, userID)
+}
+
+// SetQuota stores an override for the user.
+func (h *QuotaHandler) SetQuota(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		errorJSON(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+
+	var req SetQuotaRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorJSON(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.UploadsPerDay == nil && req.StoredBytesLimit == nil {
+		errorJSON(w, http.StatusBadRequest, "set uploads_per_day or stored_bytes_limit")
+		return
+	}
+	if (req.UploadsPerDay != nil && *req.UploadsPerDay < 0) || (req.StoredBytesLimit != nil && *req.StoredBytesLimit < 0) {
+		errorJSON(w, http.StatusBadRequest, "limits must not be negative")
+		return
+	}
+
+	o := &domain.QuotaOverride{
+		UserID:           userID,
+		UploadsPerDay:    req.UploadsPerDay,
+		StoredBytesLimit: req.StoredBytesLimit,
+		UpdatedAt:        time.Now(),
+	}
+	if err := h.repo.SetQuotaOverride(r.Context(), o); err != nil {
+		h.log.Error().Err(err).Msg("failed to set quota override")
+		errorJSON(w, http.StatusInternalServerError, "failed to set quota")
+		return
+	}
+	h.log.Info().Str("user_id", userID.String()).Msg("quota override set")
+	h.writeUsage(w, r, userID)
+}
+
+// ResetQuota removes the user's override.
+func (h *QuotaHandler) ResetQuota(w http.ResponseWriter, r *http.Request) {
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		errorJSON(w, http.StatusBadRequest, "invalid user ID")
+		return
+	}
+	if err := h.repo.DeleteQuotaOverride(r.Context(), userID); err != nil {
+		h.log.Error().Err(err).Msg("failed to delete quota override")
+		errorJSON(w, http.StatusInternalServerError, "failed to reset quota")
+		return
+	}
+	h.log.Info().Str("user_id", userID.String()).Msg("quota override removed")
+	h.writeUsage(w, r, userID)
+}
+
+func (h *QuotaHandler) writeUsage(w http.ResponseWriter, r *http.Request, userID uuid.UUID) {
+	ctx := r.Context()
+	override, err := h.repo.GetQuotaOverride(ctx, userID)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get quota override")
+		errorJSON(w, http.StatusInternalServerError, "failed to get usage")
+		return
+	}
+	usage, err := h.repo.Usage(ctx, userID, time.Now().Add(-quotaWindow))
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get usage")
+		errorJSON(w, http.StatusInternalServerError, "failed to get usage")
+		return
+	}
+
+	def := defaultQuota(h.cfg)
+	writeJSON(w, http.StatusOK, UsageResponse{
+		UserID:   userID.String(),
+		Usage:    usage,
+		Quota:    def.With(override),
+		Default:  def,
+		Override: override,
+	})
+}
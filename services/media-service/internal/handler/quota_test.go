@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/baechuer/cityevents/services/media-service/internal/config"
+	"github.com/baechuer/cityevents/services/media-service/internal/domain"
+)
+
+func quotaConfig() *config.Config {
+	return &config.Config{
+		MaxUploadSize:     10 * 1024 * 1024,
+		PresignTTL:        5 * time.Minute,
+		UploadsPerDay:     3,
+		StorageQuotaBytes: 4096,
+	}
+}
+
+func TestRequestUpload_Quota(t *testing.T) {
+	userID := uuid.New()
+	unlimited := 0
+
+	tests := []struct {
+		name     string
+		override *domain.QuotaOverride
+		usage    domain.Usage
+		status   int
+	}{
+		{"daily limit reached", nil, domain.Usage{UploadsLast24h: 3}, http.StatusTooManyRequests},
+		{"storage full", nil, domain.Usage{StoredBytes: 4096}, http.StatusForbidden},
+		{"override lifts daily limit", &domain.QuotaOverride{UploadsPerDay: &unlimited}, domain.Usage{UploadsLast24h: 3}, http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockRepo)
+			s3 := new(MockStorage)
+			h := NewUploadHandler(repo, repo, s3, new(MockPublisher), quotaConfig(), zerolog.Nop())
+
+			if tt.override != nil {
+				repo.On("GetQuotaOverride", mock.Anything, userID).Return(tt.override, nil)
+			} else {
+				repo.On("GetQuotaOverride", mock.Anything, userID).Return(nil, nil)
+			}
+			repo.On("Usage", mock.Anything, userID, mock.Anything).Return(tt.usage, nil)
+			repo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+			s3.On("GeneratePresignedPutURL", mock.Anything, mock.Anything, mock.Anything).Return("http://s3/presigned", nil).Maybe()
+
+			req := httptest.NewRequest("POST", "/request-upload", strings.NewReader(`{"purpose":"avatar"}`))
+			req.Header.Set("X-User-ID", userID.String())
+			rr := httptest.NewRecorder()
+			h.RequestUpload(rr, req)
+
+			assert.Equal(t, tt.status, rr.Code)
+			if tt.status != http.StatusOK {
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCompleteUpload_OverStorageQuota(t *testing.T) {
+	repo := new(MockRepo)
+	s3 := new(MockStorage)
+	pub := new(MockPublisher)
+	h := NewUploadHandler(repo, repo, s3, pub, quotaConfig(), zerolog.Nop())
+
+	userID := uuid.New()
+	uploadID := uuid.New()
+	objectKey := "raw/" + uploadID.String() + ".bin"
+	upload := &domain.Upload{ID: uploadID, OwnerID: userID, Purpose: domain.PurposeAvatar, Status: domain.StatusPending, RawObjectKey: objectKey}
+
+	repo.On("GetByID", mock.Anything, uploadID).Return(upload, nil)
+	s3.On("ObjectExists", mock.Anything, objectKey).Return(true, int64(2048), nil)
+	repo.On("GetQuotaOverride", mock.Anything, userID).Return(nil, nil)
+	repo.On("Usage", mock.Anything, userID, mock.Anything).Return(domain.Usage{StoredBytes: 3000}, nil)
+	s3.On("DeleteRawObject", mock.Anything, objectKey).Return(nil)
+	repo.On("UpdateStatusWithError", mock.Anything, uploadID, domain.StatusFailed, "storage quota exceeded").Return(nil)
+
+	req := httptest.NewRequest("POST", "/complete", strings.NewReader(`{"upload_id":"`+uploadID.String()+`"}`))
+	req.Header.Set("X-User-ID", userID.String())
+	rr := httptest.NewRecorder()
+	h.CompleteUpload(rr, req)
+
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	repo.AssertNotCalled(t, "MarkUploaded", mock.Anything, mock.Anything, mock.Anything)
+	pub.AssertNotCalled(t, "PublishProcessImage", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	repo.AssertExpectations(t)
+	s3.AssertExpectations(t)
+}
+
+func TestQuotaHandler_SetQuota(t *testing.T) {
+	repo := new(MockRepo)
+	h := NewQuotaHandler(repo, quotaConfig(), zerolog.Nop())
+	userID := uuid.New()
+	limit := int64(1 << 30)
+	override := &domain.QuotaOverride{UserID: userID, StoredBytesLimit: &limit}
+
+	repo.On("SetQuotaOverride", mock.Anything, mock.MatchedBy(func(o *domain.QuotaOverride) bool {
+		return o.UserID == userID && o.UploadsPerDay == nil && o.StoredBytesLimit != nil && *o.StoredBytesLimit == limit
+	})).Return(nil)
+	repo.On("GetQuotaOverride", mock.Anything, userID).Return(override, nil)
+	repo.On("Usage", mock.Anything, userID, mock.Anything).Return(domain.Usage{UploadsLast24h: 1, StoredBytes: 100}, nil)
+
+	r := chi.NewRouter()
+	r.Put("/users/{id}/quota", h.SetQuota)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest("PUT", "/users/"+userID.String()+"/quota", strings.NewReader(`{"stored_bytes_limit":1073741824}`)))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var resp UsageResponse
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Equal(t, domain.Quota{UploadsPerDay: 3, StoredBytesLimit: limit}, resp.Quota)
+	assert.Equal(t, domain.Quota{UploadsPerDay: 3, StoredBytesLimit: 4096}, resp.Default)
+	assert.Equal(t, 1, resp.Usage.UploadsLast24h)
+	repo.AssertExpectations(t)
+}
+
+func TestQuotaHandler_SetQuotaRejectsInvalid(t *testing.T) {
+	h := NewQuotaHandler(new(MockRepo), quotaConfig(), zerolog.Nop())
+	r := chi.NewRouter()
+	r.Put("/users/{id}/quota", h.SetQuota)
+
+	for _, body := range []string{`{}`, `{"uploads_per_day":-1}`} {
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, httptest.NewRequest("PUT", "/users/"+uuid.NewString()+"/quota", strings.NewReader(body)))
+		assert.Equal(t, http.StatusBadRequest, rr.Code, body)
+	}
+}
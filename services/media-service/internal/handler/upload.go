@@ -16,6 +16,7 @@ import (
 // UploadHandler handles upload-related HTTP requests.
 type UploadHandler struct {
 	repo      UploadRepository
+	quotas    QuotaRepository
 	s3        FileStorage
 	publisher MessagePublisher
 	cfg       *config.Config
@@ -25,6 +26,7 @@ type UploadHandler struct {
 // NewUploadHandler creates a new upload handler.
 func NewUploadHandler(
 	repo UploadRepository,
+	quotas QuotaRepository,
 	s3 FileStorage,
 	publisher MessagePublisher,
 	cfg *config.Config,
@@ -32,6 +34,7 @@ func NewUploadHandler(
 ) *UploadHandler {
 	return &UploadHandler{
 		repo:      repo,
+		quotas:    quotas,
 		s3:        s3,
 		publisher: publisher,
 		cfg:       cfg,
@@ -80,6 +83,22 @@ func (h *UploadHandler) RequestUpload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	quota, usage, err := userQuota(ctx, h.quotas, h.cfg, ownerID)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get usage")
+		h.errorResponse(w, http.StatusInternalServerError, "failed to create upload")
+		return
+	}
+	if quota.DailyLimitReached(usage) {
+		h.errorResponse(w, http.StatusTooManyRequests, "daily upload limit reached")
+		return
+	}
+	// Refuse early when even a minimal upload no longer fits.
+	if quota.ExceedsStorage(usage, 1) {
+		h.errorResponse(w, http.StatusForbidden, "storage quota exceeded")
+		return
+	}
+
 	// Create upload record
 	uploadID := uuid.New()
 	objectKey := "raw/" + uploadID.String() + ".bin"
@@ -120,8 +139,10 @@ func (h *UploadHandler) RequestUpload(w http.ResponseWriter, r *http.Request) {
 }
 
 // CompleteUploadRequest is the request body for completing an upload.
+// Crop is optional; see domain.Crop.
 type CompleteUploadRequest struct {
-	UploadID string `json:"upload_id"`
+	UploadID string       `json:"upload_id"`
+	Crop     *domain.Crop `json:"crop,omitempty"`
 }
 
 // CompleteUploadResponse is the response after marking upload complete.
@@ -156,6 +177,12 @@ func (h *UploadHandler) CompleteUpload(w http.ResponseWriter, r *http.Request) {
 		h.errorResponse(w, http.StatusBadRequest, "invalid upload ID")
 		return
 	}
+	// The dimensions are unknown until the worker decodes the image, so
+	// only the fractions are checked here.
+	if err := req.Crop.Validate(); err != nil {
+		h.errorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	// Get upload record
 	upload, err := h.repo.GetByID(ctx, uploadID)
@@ -201,8 +228,23 @@ func (h *UploadHandler) CompleteUpload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// The presigned URL only bounds a single file; check the total now
+	// that the size is known.
+	quota, usage, err := userQuota(ctx, h.quotas, h.cfg, ownerID)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get usage")
+		h.errorResponse(w, http.StatusInternalServerError, "failed to verify upload")
+		return
+	}
+	if quota.ExceedsStorage(usage, size) {
+		_ = h.s3.DeleteRawObject(ctx, upload.RawObjectKey)
+		_ = h.repo.UpdateStatusWithError(ctx, uploadID, domain.StatusFailed, "storage quota exceeded")
+		h.errorResponse(w, http.StatusForbidden, "storage quota exceeded")
+		return
+	}
+
 	// Mark as uploaded and publish to queue
-	if err := h.repo.UpdateStatus(ctx, uploadID, domain.StatusUploaded); err != nil {
+	if err := h.repo.MarkUploaded(ctx, uploadID, size, req.Crop); err != nil {
 		h.log.Error().Err(err).Msg("failed to update status")
 		h.errorResponse(w, http.StatusInternalServerError, "failed to update status")
 		return
@@ -217,6 +259,81 @@ func (h *UploadHandler) CompleteUpload(w http.ResponseWriter, r *http.Request) {
 	h.jsonResponse(w, http.StatusOK, CompleteUploadResponse{Status: string(domain.StatusProcessing)})
 }
 
+// RecropRequest changes the crop of a READY upload. A null crop goes back
+// to center crops.
+type RecropRequest struct {
+	UploadID string       `json:"upload_id"`
+	Crop     *domain.Crop `json:"crop"`
+}
+
+// Recrop stores a new crop for a READY upload and queues the crop-mode
+// sizes for regeneration, without a new upload. The upload stays READY and
+// keeps serving the old crop until the worker is done.
+func (h *UploadHandler) Recrop(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ownerID, err := uuid.Parse(r.Header.Get("X-User-ID"))
+	if err != nil {
+		h.errorResponse(w, http.StatusUnauthorized, "missing user ID")
+		return
+	}
+
+	var req RecropRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	uploadID, err := uuid.Parse(req.UploadID)
+	if err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "invalid upload ID")
+		return
+	}
+
+	upload, err := h.repo.GetByID(ctx, uploadID)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get upload")
+		h.errorResponse(w, http.StatusInternalServerError, "failed to get upload")
+		return
+	}
+	if upload == nil {
+		h.errorResponse(w, http.StatusNotFound, "upload not found")
+		return
+	}
+	if upload.OwnerID != ownerID {
+		h.errorResponse(w, http.StatusForbidden, "not authorized")
+		return
+	}
+	if upload.Status != domain.StatusReady {
+		h.errorResponse(w, http.StatusConflict, "upload is not ready")
+		return
+	}
+	if err := req.Crop.ValidateFor(upload.Width, upload.Height); err != nil {
+		h.errorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	ok, err := h.repo.SetCrop(ctx, uploadID, req.Crop)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to set crop")
+		h.errorResponse(w, http.StatusInternalServerError, "failed to set crop")
+		return
+	}
+	if !ok {
+		h.errorResponse(w, http.StatusConflict, "upload is not ready")
+		return
+	}
+
+	// The crop is stored; a lost job leaves the old variants, and sending
+	// the same crop again retries
+	if err := h.publisher.PublishRecropImage(ctx, uploadID.String(), upload.RawObjectKey, string(upload.Purpose)); err != nil {
+		h.log.Error().Err(err).Msg("failed to publish recrop message")
+		h.errorResponse(w, http.StatusInternalServerError, "failed to queue recrop, retry")
+		return
+	}
+
+	h.jsonResponse(w, http.StatusAccepted, map[string]any{"id": uploadID.String(), "status": string(upload.Status), "crop": req.Crop})
+}
+
 // GetStatus returns the current status of an upload.
 func (h *UploadHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -244,12 +361,22 @@ func (h *UploadHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 		Status      string            `json:"status"`
 		DerivedURLs map[string]string `json:"derived_urls,omitempty"`
 		Error       string            `json:"error,omitempty"`
+		Crop        *domain.Crop      `json:"crop,omitempty"`
+		domain.Placeholder
 	}
 
 	resp := StatusResponse{
 		ID:     upload.ID.String(),
 		Status: string(upload.Status),
 		Error:  upload.ErrorMessage,
+		Crop:   upload.Crop,
+	}
+
+	if upload.Status == domain.StatusReady {
+		resp.Placeholder = upload.Placeholder
+	}
+	if upload.Status == domain.StatusQuarantined {
+		resp.Error = "image is awaiting moderation review"
 	}
 
 	if upload.Status == domain.StatusReady && upload.DerivedKeys != nil {
@@ -262,6 +389,45 @@ func (h *UploadHandler) GetStatus(w http.ResponseWriter, r *http.Request) {
 	h.jsonResponse(w, http.StatusOK, resp)
 }
 
+// GetImage redirects to the best stored variant of an image for the
+// client's Accept header (AVIF, then WebP, then JPEG). ?size= picks the
+// size; it defaults to the purpose's smallest.
+func (h *UploadHandler) GetImage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	uploadID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		h.errorResponse(w, http.StatusBadRequest, "invalid upload ID")
+		return
+	}
+
+	upload, err := h.repo.GetByID(ctx, uploadID)
+	if err != nil {
+		h.log.Error().Err(err).Msg("failed to get upload")
+		h.errorResponse(w, http.StatusInternalServerError, "failed to get upload")
+		return
+	}
+	if upload == nil || upload.Status != domain.StatusReady {
+		h.errorResponse(w, http.StatusNotFound, "image not found")
+		return
+	}
+
+	size := r.URL.Query().Get("size")
+	if size == "" {
+		size = upload.DefaultSize()
+	}
+	key, _, ok := upload.VariantKey(size, r.Header.Get("Accept"))
+	if !ok {
+		h.errorResponse(w, http.StatusNotFound, "size not available")
+		return
+	}
+
+	// Caches must key the redirect on Accept.
+	w.Header().Set("Vary", "Accept")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	http.Redirect(w, r, h.s3.PublicURL(key), http.StatusFound)
+}
+
 func (h *UploadHandler) jsonResponse(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
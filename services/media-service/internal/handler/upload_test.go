@@ -8,12 +8,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/baechuer/cityevents/services/media-service/internal/config"
 	"github.com/baechuer/cityevents/services/media-service/internal/domain"
+	"github.com/baechuer/cityevents/services/media-service/internal/messaging"
 )
 
 // Mocks
@@ -32,15 +34,64 @@ func (m *MockRepo) GetByID(ctx context.Context, id uuid.UUID) (*domain.Upload, e
 	}
 	return args.Get(0).(*domain.Upload), args.Error(1)
 }
-func (m *MockRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.UploadStatus) error {
-	args := m.Called(ctx, id, status)
+func (m *MockRepo) MarkUploaded(ctx context.Context, id uuid.UUID, size int64, crop *domain.Crop) error {
+	args := m.Called(ctx, id, size, crop)
 	return args.Error(0)
 }
+
+func (m *MockRepo) SetCrop(ctx context.Context, id uuid.UUID, crop *domain.Crop) (bool, error) {
+	args := m.Called(ctx, id, crop)
+	return args.Bool(0), args.Error(1)
+}
 func (m *MockRepo) UpdateStatusWithError(ctx context.Context, id uuid.UUID, status domain.UploadStatus, errMsg string) error {
 	args := m.Called(ctx, id, status, errMsg)
 	return args.Error(0)
 }
 
+func (m *MockRepo) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Upload, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Upload), args.Error(1)
+}
+func (m *MockRepo) MarkInUse(ctx context.Context, ids []uuid.UUID) error {
+	args := m.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (m *MockRepo) Usage(ctx context.Context, ownerID uuid.UUID, since time.Time) (domain.Usage, error) {
+	args := m.Called(ctx, ownerID, since)
+	return args.Get(0).(domain.Usage), args.Error(1)
+}
+func (m *MockRepo) GetQuotaOverride(ctx context.Context, userID uuid.UUID) (*domain.QuotaOverride, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.QuotaOverride), args.Error(1)
+}
+func (m *MockRepo) SetQuotaOverride(ctx context.Context, o *domain.QuotaOverride) error {
+	args := m.Called(ctx, o)
+	return args.Error(0)
+}
+func (m *MockRepo) ListQuarantined(ctx context.Context, limit int) ([]*domain.Upload, error) {
+	args := m.Called(ctx, limit)
+	return args.Get(0).([]*domain.Upload), args.Error(1)
+}
+func (m *MockRepo) Approve(ctx context.Context, id, moderator uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id, moderator)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockRepo) Reject(ctx context.Context, id, moderator uuid.UUID, reason string) ([]*domain.Upload, error) {
+	args := m.Called(ctx, id, moderator, reason)
+	return args.Get(0).([]*domain.Upload), args.Error(1)
+}
+func (m *MockRepo) DeleteQuotaOverride(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 type MockStorage struct {
 	mock.Mock
 }
@@ -57,6 +108,14 @@ func (m *MockStorage) DeleteRawObject(ctx context.Context, objectKey string) err
 	args := m.Called(ctx, objectKey)
 	return args.Error(0)
 }
+func (m *MockStorage) DeletePublicObject(ctx context.Context, objectKey string) error {
+	args := m.Called(ctx, objectKey)
+	return args.Error(0)
+}
+func (m *MockStorage) GeneratePresignedGetURL(ctx context.Context, objectKey string) (string, error) {
+	args := m.Called(ctx, objectKey)
+	return args.String(0), args.Error(1)
+}
 func (m *MockStorage) PublicURL(objectKey string) string {
 	args := m.Called(objectKey)
 	return args.String(0)
@@ -70,6 +129,14 @@ func (m *MockPublisher) PublishProcessImage(ctx context.Context, uploadID, objec
 	args := m.Called(ctx, uploadID, objectKey, purpose)
 	return args.Error(0)
 }
+func (m *MockPublisher) PublishUploadRejected(ctx context.Context, msg messaging.UploadRejectedMessage) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+func (m *MockPublisher) PublishRecropImage(ctx context.Context, uploadID, objectKey, purpose string) error {
+	args := m.Called(ctx, uploadID, objectKey, purpose)
+	return args.Error(0)
+}
 
 func TestRequestUpload_Success(t *testing.T) {
 	repo := new(MockRepo)
@@ -79,11 +146,13 @@ func TestRequestUpload_Success(t *testing.T) {
 		MaxUploadSize: 10 * 1024 * 1024,
 		PresignTTL:    5 * time.Minute,
 	}
-	h := NewUploadHandler(repo, s3, pub, cfg, zerolog.Nop())
+	h := NewUploadHandler(repo, repo, s3, pub, cfg, zerolog.Nop())
 
 	userID := uuid.New()
 
 	// Expectations
+	repo.On("GetQuotaOverride", mock.Anything, userID).Return(nil, nil)
+	repo.On("Usage", mock.Anything, userID, mock.Anything).Return(domain.Usage{}, nil)
 	repo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Upload")).Return(nil)
 	s3.On("GeneratePresignedPutURL", mock.Anything, mock.MatchedBy(func(key string) bool {
 		return strings.HasPrefix(key, "raw/") && strings.HasSuffix(key, ".bin")
@@ -109,7 +178,7 @@ func TestCompleteUpload_Success(t *testing.T) {
 	cfg := &config.Config{
 		MaxUploadSize: 10 * 1024 * 1024,
 	}
-	h := NewUploadHandler(repo, s3, pub, cfg, zerolog.Nop())
+	h := NewUploadHandler(repo, repo, s3, pub, cfg, zerolog.Nop())
 
 	userID := uuid.New()
 	uploadID := uuid.New()
@@ -125,7 +194,9 @@ func TestCompleteUpload_Success(t *testing.T) {
 
 	repo.On("GetByID", mock.Anything, uploadID).Return(upload, nil)
 	s3.On("ObjectExists", mock.Anything, objectKey).Return(true, int64(1024), nil)
-	repo.On("UpdateStatus", mock.Anything, uploadID, domain.StatusUploaded).Return(nil)
+	repo.On("GetQuotaOverride", mock.Anything, userID).Return(nil, nil)
+	repo.On("Usage", mock.Anything, userID, mock.Anything).Return(domain.Usage{}, nil)
+	repo.On("MarkUploaded", mock.Anything, uploadID, int64(1024), (*domain.Crop)(nil)).Return(nil)
 	pub.On("PublishProcessImage", mock.Anything, uploadID.String(), objectKey, "avatar").Return(nil)
 
 	body := `{"upload_id":"` + uploadID.String() + `"}`
@@ -142,3 +213,167 @@ func TestCompleteUpload_Success(t *testing.T) {
 	s3.AssertExpectations(t)
 	pub.AssertExpectations(t)
 }
+
+func TestGetImage_NegotiatesFormat(t *testing.T) {
+	uploadID := uuid.New()
+	upload := &domain.Upload{
+		ID:      uploadID,
+		Purpose: domain.PurposeEventCover,
+		Status:  domain.StatusReady,
+		DerivedKeys: map[string]string{
+			"800":      "derived/event_cover/x_800.jpg",
+			"800.webp": "derived/event_cover/x_800.webp",
+			"1600":     "derived/event_cover/x_1600.jpg",
+		},
+	}
+
+	cases := []struct {
+		name, query, accept string
+		status              int
+		location            string
+	}{
+		{"webp listed", "", "image/avif,image/webp,*/*;q=0.8", http.StatusFound, "https://cdn/derived/event_cover/x_800.webp"},
+		{"wildcard only gets jpeg", "?size=800", "image/*", http.StatusFound, "https://cdn/derived/event_cover/x_800.jpg"},
+		{"webp refused", "?size=800", "image/webp;q=0, image/jpeg", http.StatusFound, "https://cdn/derived/event_cover/x_800.jpg"},
+		{"no webp for size", "?size=1600", "image/webp", http.StatusFound, "https://cdn/derived/event_cover/x_1600.jpg"},
+		{"unknown size", "?size=42", "image/webp", http.StatusNotFound, ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := new(MockRepo)
+			s3 := new(MockStorage)
+			h := NewUploadHandler(repo, repo, s3, new(MockPublisher), &config.Config{}, zerolog.Nop())
+
+			repo.On("GetByID", mock.Anything, uploadID).Return(upload, nil)
+			for _, key := range upload.DerivedKeys {
+				s3.On("PublicURL", key).Return("https://cdn/" + key).Maybe()
+			}
+
+			r := chi.NewRouter()
+			r.Get("/image/{id}", h.GetImage)
+			req := httptest.NewRequest("GET", "/image/"+uploadID.String()+c.query, nil)
+			req.Header.Set("Accept", c.accept)
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			if rr.Code != c.status {
+				t.Fatalf("expected %d, got %d", c.status, rr.Code)
+			}
+			if c.location != "" {
+				if got := rr.Header().Get("Location"); got != c.location {
+					t.Errorf("expected Location %s, got %s", c.location, got)
+				}
+				if rr.Header().Get("Vary") != "Accept" {
+					t.Errorf("expected Vary: Accept")
+				}
+			}
+		})
+	}
+}
+
+func TestGetImage_NotReady(t *testing.T) {
+	repo := new(MockRepo)
+	h := NewUploadHandler(repo, repo, new(MockStorage), new(MockPublisher), &config.Config{}, zerolog.Nop())
+
+	uploadID := uuid.New()
+	repo.On("GetByID", mock.Anything, uploadID).Return(&domain.Upload{ID: uploadID, Status: domain.StatusProcessing}, nil)
+
+	r := chi.NewRouter()
+	r.Get("/image/{id}", h.GetImage)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest("GET", "/image/"+uploadID.String(), nil))
+
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rr.Code)
+	}
+}
+
+func TestCompleteUpload_InvalidCrop(t *testing.T) {
+	repo := new(MockRepo)
+	h := NewUploadHandler(repo, repo, new(MockStorage), new(MockPublisher), &config.Config{}, zerolog.Nop())
+
+	body := `{"upload_id":"` + uuid.NewString() + `","crop":{"focus":{"x":1.5,"y":0.5}}}`
+	req := httptest.NewRequest("POST", "/complete", strings.NewReader(body))
+	req.Header.Set("X-User-ID", uuid.NewString())
+	rr := httptest.NewRecorder()
+
+	h.CompleteUpload(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+	repo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+}
+
+func TestRecrop(t *testing.T) {
+	userID := uuid.New()
+	uploadID := uuid.New()
+	ready := &domain.Upload{
+		ID:           uploadID,
+		OwnerID:      userID,
+		Purpose:      domain.PurposeEventCover,
+		Status:       domain.StatusReady,
+		RawObjectKey: "raw/a.bin",
+	}
+	ready.Width, ready.Height = 2000, 1000
+	crop := &domain.Crop{Focus: &domain.FocalPoint{X: 0.8, Y: 0.3}}
+
+	send := func(h *UploadHandler, user uuid.UUID, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/recrop", strings.NewReader(body))
+		req.Header.Set("X-User-ID", user.String())
+		rr := httptest.NewRecorder()
+		h.Recrop(rr, req)
+		return rr
+	}
+	body := `{"upload_id":"` + uploadID.String() + `","crop":{"focus":{"x":0.8,"y":0.3}}}`
+
+	t.Run("queues", func(t *testing.T) {
+		repo := new(MockRepo)
+		pub := new(MockPublisher)
+		h := NewUploadHandler(repo, repo, new(MockStorage), pub, &config.Config{}, zerolog.Nop())
+		repo.On("GetByID", mock.Anything, uploadID).Return(ready, nil)
+		repo.On("SetCrop", mock.Anything, uploadID, crop).Return(true, nil)
+		pub.On("PublishRecropImage", mock.Anything, uploadID.String(), "raw/a.bin", "event_cover").Return(nil)
+
+		rr := send(h, userID, body)
+
+		if rr.Code != http.StatusAccepted {
+			t.Errorf("expected 202, got %d", rr.Code)
+		}
+		pub.AssertExpectations(t)
+	})
+
+	t.Run("not owner", func(t *testing.T) {
+		repo := new(MockRepo)
+		h := NewUploadHandler(repo, repo, new(MockStorage), new(MockPublisher), &config.Config{}, zerolog.Nop())
+		repo.On("GetByID", mock.Anything, uploadID).Return(ready, nil)
+
+		if rr := send(h, uuid.New(), body); rr.Code != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", rr.Code)
+		}
+		repo.AssertNotCalled(t, "SetCrop", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		repo := new(MockRepo)
+		h := NewUploadHandler(repo, repo, new(MockStorage), new(MockPublisher), &config.Config{}, zerolog.Nop())
+		processing := *ready
+		processing.Status = domain.StatusProcessing
+		repo.On("GetByID", mock.Anything, uploadID).Return(&processing, nil)
+
+		if rr := send(h, userID, body); rr.Code != http.StatusConflict {
+			t.Errorf("expected 409, got %d", rr.Code)
+		}
+	})
+
+	t.Run("rect too small", func(t *testing.T) {
+		repo := new(MockRepo)
+		h := NewUploadHandler(repo, repo, new(MockStorage), new(MockPublisher), &config.Config{}, zerolog.Nop())
+		repo.On("GetByID", mock.Anything, uploadID).Return(ready, nil)
+
+		small := `{"upload_id":"` + uploadID.String() + `","crop":{"rect":{"x":0.1,"y":0.1,"width":0.01,"height":0.5}}}`
+		if rr := send(h, userID, small); rr.Code != http.StatusBadRequest {
+			t.Errorf("expected 400, got %d", rr.Code)
+		}
+	})
+}
@@ -20,11 +20,22 @@ type Publisher struct {
 	log      zerolog.Logger
 }
 
-// ProcessImageMessage is the message sent to the worker.
+// ProcessImageMessage is the message sent to the worker. Recrop asks it to
+// regenerate only the crop-mode sizes of a READY upload.
 type ProcessImageMessage struct {
 	UploadID  string `json:"upload_id"`
 	ObjectKey string `json:"object_key"`
 	Purpose   string `json:"purpose"`
+	Recrop    bool   `json:"recrop,omitempty"`
+}
+
+// UploadRejectedMessage tells consumers (event-service) that moderation
+// rejected these uploads and any references to them must be dropped.
+type UploadRejectedMessage struct {
+	UploadIDs []string `json:"upload_ids"`
+	OwnerID   string   `json:"owner_id"`
+	Purpose   string   `json:"purpose"`
+	Reason    string   `json:"reason"`
 }
 
 // NewPublisher creates a new RabbitMQ publisher.
@@ -77,12 +88,25 @@ func NewPublisher(cfg *config.Config, log zerolog.Logger) (*Publisher, error) {
 
 // PublishProcessImage publishes a message to process an uploaded image.
 func (p *Publisher) PublishProcessImage(ctx context.Context, uploadID, objectKey, purpose string) error {
-	msg := ProcessImageMessage{
+	return p.publishImageJob(ctx, ProcessImageMessage{
 		UploadID:  uploadID,
 		ObjectKey: objectKey,
 		Purpose:   purpose,
-	}
+	})
+}
 
+// PublishRecropImage asks the worker to redo the crop-mode sizes of a READY
+// upload after its crop changed.
+func (p *Publisher) PublishRecropImage(ctx context.Context, uploadID, objectKey, purpose string) error {
+	return p.publishImageJob(ctx, ProcessImageMessage{
+		UploadID:  uploadID,
+		ObjectKey: objectKey,
+		Purpose:   purpose,
+		Recrop:    true,
+	})
+}
+
+func (p *Publisher) publishImageJob(ctx context.Context, msg ProcessImageMessage) error {
 	body, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message: %w", err)
@@ -103,7 +127,34 @@ func (p *Publisher) PublishProcessImage(ctx context.Context, uploadID, objectKey
 		return fmt.Errorf("failed to publish message: %w", err)
 	}
 
-	p.log.Info().Str("upload_id", uploadID).Msg("published process image message")
+	p.log.Info().Str("upload_id", msg.UploadID).Bool("recrop", msg.Recrop).Msg("published process image message")
+	return nil
+}
+
+// PublishUploadRejected announces a moderation rejection on
+// media.upload.rejected.
+func (p *Publisher) PublishUploadRejected(ctx context.Context, msg UploadRejectedMessage) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	err = p.channel.PublishWithContext(ctx,
+		p.exchange,
+		"media.upload.rejected", // routing key
+		false,                   // mandatory
+		false,                   // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	p.log.Info().Strs("upload_ids", msg.UploadIDs).Msg("published upload rejected message")
 	return nil
 }
 
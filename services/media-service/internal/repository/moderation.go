@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/baechuer/cityevents/services/media-service/internal/domain"
+)
+
+// ListQuarantined returns uploads awaiting review, longest waiting first.
+func (r *UploadRepository) ListQuarantined(ctx context.Context, limit int) ([]*domain.Upload, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, owner_id, purpose, status, raw_object_key, derived_keys, error_message, created_at, updated_at, in_use_at,
+		       COALESCE(width, 0), COALESCE(height, 0), COALESCE(blurhash, ''), COALESCE(dominant_color, ''),
+		       COALESCE(moderation_state, ''), COALESCE(moderation_reason, ''), moderated_by, moderated_at, crop
+		FROM media_uploads WHERE status = $1
+		ORDER BY updated_at
+		LIMIT $2
+	`, domain.StatusQuarantined, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list quarantined uploads: %w", err)
+	}
+	defer rows.Close()
+
+	var uploads []*domain.Upload
+	for rows.Next() {
+		var u domain.Upload
+		var derivedJSON []byte
+
+		if err := rows.Scan(&u.ID, &u.OwnerID, &u.Purpose, &u.Status, &u.RawObjectKey, &derivedJSON, &u.ErrorMessage, &u.CreatedAt, &u.UpdatedAt, &u.InUseAt,
+			&u.Width, &u.Height, &u.BlurHash, &u.DominantColor,
+			&u.ModerationState, &u.ModerationReason, &u.ModeratedBy, &u.ModeratedAt, &u.Crop); err != nil {
+			return nil, fmt.Errorf("failed to scan upload: %w", err)
+		}
+
+		if len(derivedJSON) > 0 {
+			_ = json.Unmarshal(derivedJSON, &u.DerivedKeys)
+		}
+		uploads = append(uploads, &u)
+	}
+
+	return uploads, rows.Err()
+}
+
+// Approve sends a quarantined upload back to UPLOADED so media-worker
+// reprocesses it without classifying again. An upload already approved and
+// still waiting matches too, so a failed republish can be retried.
+func (r *UploadRepository) Approve(ctx context.Context, id, moderator uuid.UUID) (bool, error) {
+	now := time.Now()
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE media_uploads
+		SET status = $2, moderation_state = $3, moderated_by = $4, moderated_at = $5, updated_at = $5
+		WHERE id = $1
+		  AND (status = $6 OR (status = $2 AND moderation_state = $3))
+	`, id, domain.StatusUploaded, domain.ModerationApproved, moderator, now, domain.StatusQuarantined)
+	if err != nil {
+		return false, fmt.Errorf("failed to approve upload: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Reject fails the upload and every upload sharing its derived objects
+// (the dedup group), and releases them to the cleaner. It returns the
+// rejected uploads so their objects can be deleted. Quarantined and READY
+// uploads can be rejected; rejected ones match again so a failed cleanup
+// or notification can be retried.
+func (r *UploadRepository) Reject(ctx context.Context, id, moderator uuid.UUID, reason string) ([]*domain.Upload, error) {
+	now := time.Now()
+	rows, err := r.pool.Query(ctx, `
+		WITH target AS (
+			SELECT COALESCE(dedup_of, id) AS root FROM media_uploads WHERE id = $1
+		)
+		UPDATE media_uploads m
+		SET status = $2, error_message = 'rejected by moderation', moderation_state = $3,
+		    moderation_reason = $4, moderated_by = $5, moderated_at = $6, in_use_at = NULL, updated_at = $6
+		FROM target
+		WHERE (m.id = target.root OR m.dedup_of = target.root)
+		  AND (m.status IN ($7, $8) OR m.moderation_state = $3)
+		RETURNING m.id, m.owner_id, m.purpose, m.raw_object_key, m.derived_keys
+	`, id, domain.StatusFailed, domain.ModerationRejected, reason, moderator, now, domain.StatusQuarantined, domain.StatusReady)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reject upload: %w", err)
+	}
+	defer rows.Close()
+
+	var uploads []*domain.Upload
+	for rows.Next() {
+		var u domain.Upload
+		var derivedJSON []byte
+		if err := rows.Scan(&u.ID, &u.OwnerID, &u.Purpose, &u.RawObjectKey, &derivedJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan upload: %w", err)
+		}
+		if len(derivedJSON) > 0 {
+			_ = json.Unmarshal(derivedJSON, &u.DerivedKeys)
+		}
+		uploads = append(uploads, &u)
+	}
+
+	return uploads, rows.Err()
+}
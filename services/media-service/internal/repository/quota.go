@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/baechuer/cityevents/services/media-service/internal/domain"
+)
+
+// Usage counts the owner's uploads requested after since and the raw bytes
+// they store. Failed uploads and dedup hits (whose raw object is deleted)
+// do not count toward storage.
+func (r *UploadRepository) Usage(ctx context.Context, ownerID uuid.UUID, since time.Time) (domain.Usage, error) {
+	var u domain.Usage
+	err := r.pool.QueryRow(ctx, `
+		SELECT
+			COUNT(*) FILTER (WHERE created_at > $2),
+			COALESCE(SUM(size_bytes) FILTER (WHERE status <> $3 AND dedup_of IS NULL), 0)
+		FROM media_uploads WHERE owner_id = $1
+	`, ownerID, since, domain.StatusFailed).Scan(&u.UploadsLast24h, &u.StoredBytes)
+	if err != nil {
+		return domain.Usage{}, fmt.Errorf("failed to get usage: %w", err)
+	}
+	return u, nil
+}
+
+// MarkUploaded moves a PENDING upload to UPLOADED and records its size
+// and crop.
+func (r *UploadRepository) MarkUploaded(ctx context.Context, id uuid.UUID, size int64, crop *domain.Crop) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE media_uploads SET status = $2, size_bytes = $3, crop = $4, updated_at = $5
+		WHERE id = $1 AND status = $6
+	`, id, domain.StatusUploaded, size, crop, time.Now(), domain.StatusPending)
+	return err
+}
+
+// GetQuotaOverride returns the user's override, or nil if none is set.
+func (r *UploadRepository) GetQuotaOverride(ctx context.Context, userID uuid.UUID) (*domain.QuotaOverride, error) {
+	o := domain.QuotaOverride{UserID: userID}
+	err := r.pool.QueryRow(ctx, `
+		SELECT uploads_per_day, stored_bytes_limit, updated_at
+		FROM media_user_quotas WHERE user_id = $1
+	`, userID).Scan(&o.UploadsPerDay, &o.StoredBytesLimit, &o.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quota override: %w", err)
+	}
+	return &o, nil
+}
+
+// SetQuotaOverride creates or replaces the user's override.
+func (r *UploadRepository) SetQuotaOverride(ctx context.Context, o *domain.QuotaOverride) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO media_user_quotas (user_id, uploads_per_day, stored_bytes_limit, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET uploads_per_day = EXCLUDED.uploads_per_day,
+		    stored_bytes_limit = EXCLUDED.stored_bytes_limit,
+		    updated_at = EXCLUDED.updated_at
+	`, o.UserID, o.UploadsPerDay, o.StoredBytesLimit, o.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to set quota override: %w", err)
+	}
+	return nil
+}
+
+// DeleteQuotaOverride returns the user to the configured defaults.
+func (r *UploadRepository) DeleteQuotaOverride(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, "DELETE FROM media_user_quotas WHERE user_id = $1", userID)
+	return err
+}
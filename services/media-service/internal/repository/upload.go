@@ -46,9 +46,13 @@ func (r *UploadRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.U
 	var derivedJSON []byte
 
 	err := r.pool.QueryRow(ctx, `
-		SELECT id, owner_id, purpose, status, raw_object_key, derived_keys, error_message, created_at, updated_at
+		SELECT id, owner_id, purpose, status, raw_object_key, derived_keys, error_message, created_at, updated_at, in_use_at,
+		       COALESCE(width, 0), COALESCE(height, 0), COALESCE(blurhash, ''), COALESCE(dominant_color, ''),
+		       COALESCE(moderation_state, ''), COALESCE(moderation_reason, ''), moderated_by, moderated_at, crop
 		FROM media_uploads WHERE id = $1
-	`, id).Scan(&u.ID, &u.OwnerID, &u.Purpose, &u.Status, &u.RawObjectKey, &derivedJSON, &u.ErrorMessage, &u.CreatedAt, &u.UpdatedAt)
+	`, id).Scan(&u.ID, &u.OwnerID, &u.Purpose, &u.Status, &u.RawObjectKey, &derivedJSON, &u.ErrorMessage, &u.CreatedAt, &u.UpdatedAt, &u.InUseAt,
+		&u.Width, &u.Height, &u.BlurHash, &u.DominantColor,
+		&u.ModerationState, &u.ModerationReason, &u.ModeratedBy, &u.ModeratedAt, &u.Crop)
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
@@ -80,6 +84,18 @@ func (r *UploadRepository) UpdateStatusWithError(ctx context.Context, id uuid.UU
 	return err
 }
 
+// SetCrop replaces the crop of a READY upload. It reports false when the
+// upload is not READY.
+func (r *UploadRepository) SetCrop(ctx context.Context, id uuid.UUID, crop *domain.Crop) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE media_uploads SET crop = $2, updated_at = $3 WHERE id = $1 AND status = $4
+	`, id, crop, time.Now(), domain.StatusReady)
+	if err != nil {
+		return false, fmt.Errorf("failed to set crop: %w", err)
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
 // UpdateDerivedKeys updates the derived keys after processing.
 func (r *UploadRepository) UpdateDerivedKeys(ctx context.Context, id uuid.UUID, derivedKeys map[string]string) error {
 	derivedJSON, err := json.Marshal(derivedKeys)
@@ -99,12 +115,16 @@ func (r *UploadRepository) GetByOwnerAndPurpose(ctx context.Context, ownerID uui
 	var derivedJSON []byte
 
 	err := r.pool.QueryRow(ctx, `
-		SELECT id, owner_id, purpose, status, raw_object_key, derived_keys, error_message, created_at, updated_at
+		SELECT id, owner_id, purpose, status, raw_object_key, derived_keys, error_message, created_at, updated_at, in_use_at,
+		       COALESCE(width, 0), COALESCE(height, 0), COALESCE(blurhash, ''), COALESCE(dominant_color, ''),
+		       COALESCE(moderation_state, ''), COALESCE(moderation_reason, ''), moderated_by, moderated_at, crop
 		FROM media_uploads 
 		WHERE owner_id = $1 AND purpose = $2 AND status = $3
 		ORDER BY created_at DESC
 		LIMIT 1
-	`, ownerID, purpose, domain.StatusReady).Scan(&u.ID, &u.OwnerID, &u.Purpose, &u.Status, &u.RawObjectKey, &derivedJSON, &u.ErrorMessage, &u.CreatedAt, &u.UpdatedAt)
+	`, ownerID, purpose, domain.StatusReady).Scan(&u.ID, &u.OwnerID, &u.Purpose, &u.Status, &u.RawObjectKey, &derivedJSON, &u.ErrorMessage, &u.CreatedAt, &u.UpdatedAt, &u.InUseAt,
+		&u.Width, &u.Height, &u.BlurHash, &u.DominantColor,
+		&u.ModerationState, &u.ModerationReason, &u.ModeratedBy, &u.ModeratedAt, &u.Crop)
 	if err == pgx.ErrNoRows {
 		return nil, nil
 	}
@@ -126,10 +146,13 @@ func (r *UploadRepository) ListStale(ctx context.Context, pendingAge, failedAge
 	cutoffFailed := time.Now().Add(-failedAge)
 
 	rows, err := r.pool.Query(ctx, `
-		SELECT id, owner_id, purpose, status, raw_object_key, derived_keys, error_message, created_at, updated_at
+		SELECT id, owner_id, purpose, status, raw_object_key, derived_keys, error_message, created_at, updated_at, in_use_at,
+		       COALESCE(width, 0), COALESCE(height, 0), COALESCE(blurhash, ''), COALESCE(dominant_color, ''),
+		       COALESCE(moderation_state, ''), COALESCE(moderation_reason, ''), moderated_by, moderated_at, crop
 		FROM media_uploads 
-		WHERE (status = $1 AND created_at < $2) 
-		   OR (status = $3 AND created_at < $4)
+		WHERE ((status = $1 AND created_at < $2)
+		   OR (status = $3 AND created_at < $4))
+		  AND in_use_at IS NULL
 		LIMIT $5
 	`, domain.StatusPending, cutoffPending, domain.StatusFailed, cutoffFailed, limit)
 	if err != nil {
@@ -142,7 +165,9 @@ func (r *UploadRepository) ListStale(ctx context.Context, pendingAge, failedAge
 		var u domain.Upload
 		var derivedJSON []byte
 
-		if err := rows.Scan(&u.ID, &u.OwnerID, &u.Purpose, &u.Status, &u.RawObjectKey, &derivedJSON, &u.ErrorMessage, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		if err := rows.Scan(&u.ID, &u.OwnerID, &u.Purpose, &u.Status, &u.RawObjectKey, &derivedJSON, &u.ErrorMessage, &u.CreatedAt, &u.UpdatedAt, &u.InUseAt,
+			&u.Width, &u.Height, &u.BlurHash, &u.DominantColor,
+			&u.ModerationState, &u.ModerationReason, &u.ModeratedBy, &u.ModeratedAt, &u.Crop); err != nil {
 			return nil, fmt.Errorf("failed to scan upload: %w", err)
 		}
 
@@ -155,6 +180,48 @@ func (r *UploadRepository) ListStale(ctx context.Context, pendingAge, failedAge
 	return uploads, nil
 }
 
+// GetByIDs retrieves the uploads that exist among ids.
+func (r *UploadRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Upload, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, owner_id, purpose, status, raw_object_key, derived_keys, error_message, created_at, updated_at, in_use_at,
+		       COALESCE(width, 0), COALESCE(height, 0), COALESCE(blurhash, ''), COALESCE(dominant_color, ''),
+		       COALESCE(moderation_state, ''), COALESCE(moderation_reason, ''), moderated_by, moderated_at, crop
+		FROM media_uploads WHERE id = ANY($1)
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get uploads: %w", err)
+	}
+	defer rows.Close()
+
+	var uploads []*domain.Upload
+	for rows.Next() {
+		var u domain.Upload
+		var derivedJSON []byte
+
+		if err := rows.Scan(&u.ID, &u.OwnerID, &u.Purpose, &u.Status, &u.RawObjectKey, &derivedJSON, &u.ErrorMessage, &u.CreatedAt, &u.UpdatedAt, &u.InUseAt,
+			&u.Width, &u.Height, &u.BlurHash, &u.DominantColor,
+			&u.ModerationState, &u.ModerationReason, &u.ModeratedBy, &u.ModeratedAt, &u.Crop); err != nil {
+			return nil, fmt.Errorf("failed to scan upload: %w", err)
+		}
+
+		if len(derivedJSON) > 0 {
+			_ = json.Unmarshal(derivedJSON, &u.DerivedKeys)
+		}
+		uploads = append(uploads, &u)
+	}
+
+	return uploads, rows.Err()
+}
+
+// MarkInUse records that the uploads are referenced; the first reference
+// time is kept.
+func (r *UploadRepository) MarkInUse(ctx context.Context, ids []uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE media_uploads SET in_use_at = COALESCE(in_use_at, $2) WHERE id = ANY($1)
+	`, ids, time.Now())
+	return err
+}
+
 // Delete permanently removes an upload record.
 func (r *UploadRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	_, err := r.pool.Exec(ctx, "DELETE FROM media_uploads WHERE id = $1", id)
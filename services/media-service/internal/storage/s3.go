@@ -154,6 +154,29 @@ func (c *S3Client) DeleteRawObject(ctx context.Context, objectKey string) error
 	return err
 }
 
+// DeletePublicObject deletes an object from the public bucket.
+func (c *S3Client) DeletePublicObject(ctx context.Context, objectKey string) error {
+	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.publicBucket),
+		Key:    aws.String(objectKey),
+	})
+	return err
+}
+
+// GeneratePresignedGetURL creates a short-lived read URL for a raw object,
+// used to show moderators an image that has no public variants. Signed for
+// the external endpoint, like uploads.
+func (c *S3Client) GeneratePresignedGetURL(ctx context.Context, objectKey string) (string, error) {
+	req, err := c.externalPresigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(c.rawBucket),
+		Key:    aws.String(objectKey),
+	}, s3.WithPresignExpires(c.cfg.PresignTTL))
+	if err != nil {
+		return "", fmt.Errorf("failed to presign GET: %w", err)
+	}
+	return req.URL, nil
+}
+
 // EnsureBuckets creates the raw and public buckets if they don't exist.
 // Also sets public read policy on the public bucket.
 func (c *S3Client) EnsureBuckets(ctx context.Context) error {
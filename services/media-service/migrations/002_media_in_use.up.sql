@@ -0,0 +1,7 @@
+-- Set once an event cover or avatar references the upload; the cleaner
+-- never deletes uploads in use.
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS in_use_at TIMESTAMPTZ;
+
+-- References made before this column existed were never recorded: treat
+-- every processed upload as possibly attached.
+UPDATE media_uploads SET in_use_at = NOW() WHERE status = 'READY' AND in_use_at IS NULL;
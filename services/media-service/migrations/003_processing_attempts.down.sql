@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_media_uploads_processing;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS processing_attempts;
@@ -0,0 +1,7 @@
+-- media-worker bumps this each time it claims an upload for processing; the
+-- worker's reaper fails uploads that keep getting stuck instead of
+-- requeueing them forever.
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS processing_attempts INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_media_uploads_processing
+    ON media_uploads (updated_at) WHERE status = 'PROCESSING';
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS media_user_quotas;
+DROP INDEX IF EXISTS idx_media_uploads_owner_created;
+DROP INDEX IF EXISTS idx_media_uploads_content_hash;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS dedup_of;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS content_hash;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS size_bytes;
@@ -0,0 +1,24 @@
+-- Raw object size, recorded on complete; counts toward the owner's quota.
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS size_bytes BIGINT NOT NULL DEFAULT 0;
+
+-- SHA-256 of the raw bytes, set by media-worker. An upload whose bytes match
+-- one of the owner's READY uploads of the same purpose reuses its derived
+-- objects; dedup_of points at the upload that owns them.
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS content_hash TEXT;
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS dedup_of UUID;
+
+CREATE INDEX IF NOT EXISTS idx_media_uploads_content_hash
+    ON media_uploads (owner_id, purpose, content_hash) WHERE status = 'READY';
+
+-- Daily upload counts
+CREATE INDEX IF NOT EXISTS idx_media_uploads_owner_created
+    ON media_uploads (owner_id, created_at);
+
+-- Per-user quota overrides set by admins. NULL keeps the configured
+-- default; 0 means unlimited.
+CREATE TABLE IF NOT EXISTS media_user_quotas (
+    user_id UUID PRIMARY KEY,
+    uploads_per_day INT,
+    stored_bytes_limit BIGINT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,4 @@
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS dominant_color;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS blurhash;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS height;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS width;
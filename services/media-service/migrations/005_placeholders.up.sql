@@ -0,0 +1,6 @@
+-- Set by media-worker when an upload becomes READY: oriented source
+-- dimensions and a placeholder clients can paint before any variant loads.
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS width INT;
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS height INT;
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS blurhash TEXT;
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS dominant_color TEXT;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_media_uploads_quarantined;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS moderated_at;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS moderated_by;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS moderation_reason;
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS moderation_state;
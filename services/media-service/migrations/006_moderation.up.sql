@@ -0,0 +1,9 @@
+-- Set by media-worker when the classifier flags an upload (QUARANTINED)
+-- and by media-service when a moderator approves or rejects it.
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS moderation_state TEXT;
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS moderation_reason TEXT;
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS moderated_by UUID;
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS moderated_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_media_uploads_quarantined
+    ON media_uploads (updated_at) WHERE status = 'QUARANTINED';
@@ -0,0 +1 @@
+ALTER TABLE media_uploads DROP COLUMN IF EXISTS crop;
@@ -0,0 +1,5 @@
+-- Organizer-chosen focal point or crop rectangle, as fractions of the
+-- oriented image: {"focus": {"x", "y"}} or {"rect": {"x", "y", "width",
+-- "height"}}. NULL crops around the center. media-worker applies it to
+-- crop-mode sizes.
+ALTER TABLE media_uploads ADD COLUMN IF NOT EXISTS crop JSONB;
@@ -11,6 +11,8 @@ import (
 
 	"github.com/baechuer/cityevents/services/media-worker/internal/config"
 	"github.com/baechuer/cityevents/services/media-worker/internal/consumer"
+	"github.com/baechuer/cityevents/services/media-worker/internal/moderation"
+	"github.com/baechuer/cityevents/services/media-worker/internal/reaper"
 	"github.com/baechuer/cityevents/services/media-worker/internal/storage"
 )
 
@@ -36,13 +38,36 @@ func main() {
 		log.Fatal().Err(err).Msg("failed to create S3 client")
 	}
 
+	// Moderation stage
+	moderator, err := moderation.New(cfg, log)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create moderation classifier")
+	}
+
 	// Initialize consumer
-	cons, err := consumer.NewConsumer(cfg, pool, s3Client, log)
+	cons, err := consumer.NewConsumer(cfg, pool, s3Client, moderator, log)
 	if err != nil {
 		log.Fatal().Err(err).Msg("failed to create consumer")
 	}
 	defer cons.Close()
 
+	// Older versions consumed a queue without dead-lettering
+	go func() {
+		if err := cons.RetireLegacyQueue(ctx); err != nil {
+			log.Error().Err(err).Msg("failed to retire legacy queue")
+		}
+	}()
+
+	// Older dedup hits still point at their root's objects
+	go func() {
+		if err := cons.RepairDedupCopies(ctx); err != nil {
+			log.Error().Err(err).Msg("failed to repair dedup copies")
+		}
+	}()
+
+	// Requeue uploads whose worker died mid-job
+	go reaper.NewReaper(pool, cons, cfg, log).Run(ctx)
+
 	// Start consuming in goroutine
 	go func() {
 		if err := cons.Run(ctx); err != nil {
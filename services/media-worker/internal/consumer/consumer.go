@@ -4,51 +4,75 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/rs/zerolog"
 
 	"github.com/baechuer/cityevents/services/media-worker/internal/config"
+	"github.com/baechuer/cityevents/services/media-worker/internal/moderation"
 	"github.com/baechuer/cityevents/services/media-worker/internal/sanitizer"
 	"github.com/baechuer/cityevents/services/media-worker/internal/storage"
 )
 
-// DerivedSizes defines output sizes for each purpose.
+// DerivedSizes defines output sizes for each purpose. Crop-mode sizes
+// follow the upload's focal point or crop rectangle; the named aspect-ratio
+// covers feed cards and thumbnails. Mirrored in media-service's
+// domain.DerivedSizes.
 var DerivedSizes = map[string][]sanitizer.ResizeConfig{
 	"avatar": {
-		{Width: 256, Height: 256, Crop: true},
-		{Width: 512, Height: 512, Crop: true},
+		{Name: "256", Width: 256, Height: 256, Crop: true},
+		{Name: "512", Width: 512, Height: 512, Crop: true},
 	},
 	"event_cover": {
-		{Width: 800, Height: 0, Crop: false},
-		{Width: 1600, Height: 0, Crop: false},
+		{Name: "800", Width: 800, Height: 0, Crop: false},
+		{Name: "1600", Width: 1600, Height: 0, Crop: false},
+		{Name: "16x9-800", Width: 800, Height: 450, Crop: true},
+		{Name: "16x9-1600", Width: 1600, Height: 900, Crop: true},
+		{Name: "1x1-400", Width: 400, Height: 400, Crop: true},
 	},
 }
 
-// ProcessImageMessage is the message format from media-service.
+const rkProcessImage = "media.process.image"
+
+// bindKeys are the routing keys the worker queue is bound to.
+var bindKeys = []string{rkProcessImage, rkDeletionRequested, rkExportRequested}
+
+// ProcessImageMessage is the message format from media-service. Recrop
+// jobs regenerate the crop-mode sizes of a READY upload.
 type ProcessImageMessage struct {
 	UploadID  string `json:"upload_id"`
 	ObjectKey string `json:"object_key"`
 	Purpose   string `json:"purpose"`
+	Recrop    bool   `json:"recrop,omitempty"`
 }
 
 // Consumer consumes image processing messages from RabbitMQ.
 type Consumer struct {
-	conn    *amqp.Connection
-	channel *amqp.Channel
-	pool    *pgxpool.Pool
-	s3      *storage.S3Client
-	cfg     *config.Config
-	log     zerolog.Logger
+	conn      *amqp.Connection
+	channel   *amqp.Channel // consume
+	chPublish *amqp.Channel // confirm mode: retries, DLQ, replies
+	confirmCh <-chan amqp.Confirmation
+	returnCh  <-chan amqp.Return
+	pubMu     sync.Mutex
+
+	pool      *pgxpool.Pool
+	s3        *storage.S3Client
+	cfg       *config.Config
+	encoders  []sanitizer.Encoder
+	moderator moderation.Classifier
+	log       zerolog.Logger
 }
 
 // NewConsumer creates a new RabbitMQ consumer.
-func NewConsumer(cfg *config.Config, pool *pgxpool.Pool, s3 *storage.S3Client, log zerolog.Logger) (*Consumer, error) {
+func NewConsumer(cfg *config.Config, pool *pgxpool.Pool, s3 *storage.S3Client, moderator moderation.Classifier, log zerolog.Logger) (*Consumer, error) {
 	conn, err := amqp.Dial(cfg.RabbitURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
@@ -60,6 +84,19 @@ func NewConsumer(cfg *config.Config, pool *pgxpool.Pool, s3 *storage.S3Client, l
 		return nil, fmt.Errorf("failed to open channel: %w", err)
 	}
 
+	fail := func(format string, err error) (*Consumer, error) {
+		conn.Close() // closes its channels too
+		return nil, fmt.Errorf(format, err)
+	}
+
+	chPublish, err := conn.Channel()
+	if err != nil {
+		return fail("failed to open publish channel: %w", err)
+	}
+	if err := chPublish.Confirm(false); err != nil {
+		return fail("failed to enable publisher confirms: %w", err)
+	}
+
 	// Declare exchange
 	err = ch.ExchangeDeclare(
 		cfg.RabbitExchange,
@@ -67,44 +104,86 @@ func NewConsumer(cfg *config.Config, pool *pgxpool.Pool, s3 *storage.S3Client, l
 		true, false, false, false, nil,
 	)
 	if err != nil {
-		ch.Close()
-		conn.Close()
-		return nil, fmt.Errorf("failed to declare exchange: %w", err)
+		return fail("failed to declare exchange: %w", err)
 	}
 
-	// Declare queue
+	// Declare DLQ and retry tiers, then the queue dead-lettering into them
+	if err := declareTopology(ch, cfg.RabbitQueue); err != nil {
+		return fail("failed to declare retry topology: %w", err)
+	}
 	q, err := ch.QueueDeclare(
 		cfg.RabbitQueue,
-		true, false, false, false, nil,
+		true, false, false, false, mainQueueArgs(),
 	)
 	if err != nil {
-		ch.Close()
-		conn.Close()
-		return nil, fmt.Errorf("failed to declare queue: %w", err)
+		return fail("failed to declare queue: %w", err)
 	}
 
-	// Bind queue
-	err = ch.QueueBind(q.Name, "media.process.image", cfg.RabbitExchange, false, nil)
-	if err != nil {
-		ch.Close()
-		conn.Close()
-		return nil, fmt.Errorf("failed to bind queue: %w", err)
+	// Bind queue: image jobs plus auth-service's account deletion / data
+	// export requests
+	for _, key := range bindKeys {
+		if err := ch.QueueBind(q.Name, key, cfg.RabbitExchange, false, nil); err != nil {
+			return fail("failed to bind queue to "+key+": %w", err)
+		}
 	}
 
-	// Set prefetch
-	ch.Qos(1, 0, false)
+	// One unacked message per worker
+	if err := ch.Qos(max(cfg.WorkerConcurrency, 1), 0, false); err != nil {
+		return fail("failed to set prefetch: %w", err)
+	}
 
 	return &Consumer{
-		conn:    conn,
-		channel: ch,
-		pool:    pool,
-		s3:      s3,
-		cfg:     cfg,
-		log:     log,
+		conn:      conn,
+		channel:   ch,
+		chPublish: chPublish,
+		confirmCh: chPublish.NotifyPublish(make(chan amqp.Confirmation, 32)),
+		returnCh:  chPublish.NotifyReturn(make(chan amqp.Return, 32)),
+		pool:      pool,
+		s3:        s3,
+		cfg:       cfg,
+		encoders:  buildEncoders(cfg, log),
+		moderator: moderator,
+		log:       log,
 	}, nil
 }
 
-// Run starts consuming messages.
+// buildEncoders returns JPEG plus the configured formats whose encoder
+// binary is installed.
+func buildEncoders(cfg *config.Config, log zerolog.Logger) []sanitizer.Encoder {
+	encoders := []sanitizer.Encoder{sanitizer.JPEGEncoder{Quality: cfg.JPEGQuality}}
+	for _, name := range cfg.ImageFormats {
+		var enc *sanitizer.CommandEncoder
+		switch name {
+		case sanitizer.FormatJPEG.Name:
+			continue
+		case sanitizer.FormatWebP.Name:
+			enc = sanitizer.NewCWebPEncoder(cfg.CWebPBin, cfg.WebPQuality)
+		case sanitizer.FormatAVIF.Name:
+			enc = sanitizer.NewAVIFEncoder(cfg.AVIFEncBin, cfg.AVIFQuality)
+		default:
+			log.Warn().Str("format", name).Msg("unknown image format; skipping")
+			continue
+		}
+		if !enc.Available() {
+			log.Warn().Str("format", name).Msg("encoder binary not found; skipping format")
+			continue
+		}
+		encoders = append(encoders, enc)
+	}
+	return encoders
+}
+
+// DerivedKeyName is the derived_keys entry for a variant: the bare size for
+// JPEG, which every client can read, and "<size>.<format>" otherwise.
+func DerivedKeyName(size string, f sanitizer.Format) string {
+	if f == sanitizer.FormatJPEG {
+		return size
+	}
+	return size + "." + f.Name
+}
+
+// Run starts WorkerConcurrency workers and blocks until ctx is done or the
+// delivery channel closes. In-flight jobs finish before it returns.
 func (c *Consumer) Run(ctx context.Context) error {
 	msgs, err := c.channel.Consume(
 		c.cfg.RabbitQueue,
@@ -119,27 +198,95 @@ func (c *Consumer) Run(ctx context.Context) error {
 		return fmt.Errorf("failed to start consuming: %w", err)
 	}
 
-	c.log.Info().Str("queue", c.cfg.RabbitQueue).Msg("media-worker started consuming")
+	workers := max(c.cfg.WorkerConcurrency, 1)
+	c.log.Info().Str("queue", c.cfg.RabbitQueue).Int("workers", workers).Msg("media-worker started consuming")
 
-	for {
-		select {
-		case <-ctx.Done():
-			return nil
-		case msg, ok := <-msgs:
-			if !ok {
-				return fmt.Errorf("channel closed")
+	var (
+		wg     sync.WaitGroup
+		closed = make(chan struct{}, workers)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case msg, ok := <-msgs:
+					if !ok {
+						closed <- struct{}{}
+						return
+					}
+					c.handle(ctx, msg)
+				}
 			}
-			c.processMessage(ctx, msg)
+		}()
+	}
+	wg.Wait()
+
+	if len(closed) > 0 && ctx.Err() == nil {
+		return fmt.Errorf("channel closed")
+	}
+	return nil
+}
+
+// handle acks a processed message. A failed one is parked in the next
+// retry tier, or sent to the DLQ when it is permanent or out of attempts;
+// only when that publish fails is it nacked (requeue) to try again.
+func (c *Consumer) handle(ctx context.Context, msg amqp.Delivery) {
+	rk := routingKey(msg)
+
+	var err error
+	switch rk {
+	case rkDeletionRequested, rkExportRequested:
+		err = c.processDataRequest(ctx, msg)
+	default:
+		err = c.processImage(ctx, msg)
+	}
+	if err == nil {
+		msg.Ack(false)
+		return
+	}
+
+	attempt := getAttempt(msg.Headers)
+	log := c.log.With().Str("routing_key", rk).Int("attempt", attempt).Logger()
+
+	if !isPermanent(err) && attempt < c.cfg.MaxAttempts {
+		if pubErr := c.publishRetry(ctx, msg, attempt+1, err); pubErr != nil {
+			log.Error().Err(pubErr).Msg("failed to publish retry; requeueing")
+			msg.Nack(false, true)
+			return
 		}
+		log.Warn().Err(err).Str("queue", retryQueue(attempt+1)).Msg("retriable failure; scheduled retry")
+		msg.Ack(false)
+		return
+	}
+
+	reason := "max_attempts_exceeded"
+	if isPermanent(err) {
+		reason = "non_retriable"
+	}
+	if rk == rkProcessImage {
+		c.failUpload(ctx, msg.Body, err)
 	}
+	if pubErr := c.publishFinal(ctx, msg, reason, err); pubErr != nil {
+		// The queue's dead-letter route still lands it in the DLQ
+		log.Error().Err(pubErr).Msg("failed to publish to DLQ; rejecting")
+		msg.Nack(false, false)
+		return
+	}
+	log.Error().Err(err).Str("reason", reason).Msg("sent to DLQ")
+	msg.Ack(false)
 }
 
-func (c *Consumer) processMessage(ctx context.Context, msg amqp.Delivery) {
+// processImage runs one upload through the sanitizer. Replays are safe:
+// only UPLOADED or PROCESSING uploads are claimed, and variants overwrite
+// the same keys.
+func (c *Consumer) processImage(ctx context.Context, msg amqp.Delivery) error {
 	var m ProcessImageMessage
 	if err := json.Unmarshal(msg.Body, &m); err != nil {
-		c.log.Error().Err(err).Msg("failed to unmarshal message")
-		msg.Nack(false, false) // Don't requeue malformed messages
-		return
+		return permanent(fmt.Errorf("malformed message: %w", err))
 	}
 
 	log := c.log.With().Str("upload_id", m.UploadID).Str("purpose", m.Purpose).Logger()
@@ -147,81 +294,146 @@ func (c *Consumer) processMessage(ctx context.Context, msg amqp.Delivery) {
 
 	uploadID, err := uuid.Parse(m.UploadID)
 	if err != nil {
-		log.Error().Err(err).Msg("invalid upload ID")
-		msg.Nack(false, false)
-		return
+		return permanent(fmt.Errorf("invalid upload ID: %w", err))
 	}
 
-	// Update status to PROCESSING
-	if err := c.updateStatus(ctx, uploadID, "PROCESSING", ""); err != nil {
-		log.Error().Err(err).Msg("failed to update status")
-		msg.Nack(false, true) // Requeue
-		return
+	if m.Recrop {
+		return c.recropImage(ctx, m, uploadID, log)
+	}
+
+	cl, err := c.claimUpload(ctx, uploadID)
+	if err != nil {
+		return fmt.Errorf("claim upload: %w", err)
+	}
+	if cl == nil {
+		log.Info().Msg("upload already processed or gone; skipping")
+		return nil
 	}
 
 	// Get raw image from S3
 	rawData, err := c.fetchRawImage(ctx, m.ObjectKey)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to fetch raw image")
-		c.updateStatus(ctx, uploadID, "FAILED", err.Error())
-		msg.Ack(false)
-		return
+		if storage.IsNotFound(err) {
+			return permanent(err)
+		}
+		return err
 	}
 
 	// Check size
 	if int64(len(rawData)) > c.cfg.MaxUploadSize {
 		log.Warn().Int("size", len(rawData)).Msg("image too large")
-		c.updateStatus(ctx, uploadID, "FAILED", "file too large")
 		c.s3.DeleteRawObject(ctx, m.ObjectKey)
-		msg.Ack(false)
-		return
+		return permanent(errors.New("file too large"))
+	}
+
+	// Identical bytes already processed for this owner: reuse the variants
+	hash := contentHash(rawData)
+	dup, err := c.findDuplicate(ctx, uploadID, hash)
+	if err != nil {
+		return fmt.Errorf("find duplicate: %w", err)
+	}
+	if dup != nil {
+		done, err := c.completeDuplicate(ctx, uploadID, hash, dup)
+		if err != nil {
+			return fmt.Errorf("complete duplicate: %w", err)
+		}
+		if done {
+			// The raw object is no longer needed and no longer counts
+			// toward the owner's quota.
+			if err := c.s3.DeleteRawObject(ctx, m.ObjectKey); err != nil {
+				log.Warn().Err(err).Msg("failed to delete duplicate raw object")
+			}
+			log.Info().Str("dedup_of", dup.rootID.String()).Msg("duplicate content; copied derived images")
+		}
+		return nil
 	}
 
 	// Get sizes for purpose
 	sizes, ok := DerivedSizes[m.Purpose]
 	if !ok {
-		log.Error().Msg("unknown purpose")
-		c.updateStatus(ctx, uploadID, "FAILED", "unknown purpose")
-		msg.Ack(false)
-		return
+		return permanent(errors.New("unknown purpose"))
 	}
 
-	// Process (sanitize, resize)
-	results, err := sanitizer.Process(rawData, sizes, c.cfg.MaxImageWidth, c.cfg.MaxImageHeight)
+	// Process (sanitize, orient, resize, encode)
+	variants, meta, err := sanitizer.Process(rawData, sizes, cl.crop, c.cfg.MaxImageWidth, c.cfg.MaxImageHeight, c.encoders)
 	if err != nil {
-		log.Error().Err(err).Msg("failed to process image")
-		c.updateStatus(ctx, uploadID, "FAILED", err.Error())
 		c.s3.DeleteRawObject(ctx, m.ObjectKey)
-		msg.Ack(false)
-		return
+		return permanent(err)
+	}
+
+	// Hold flagged images for a moderator instead of publishing them
+	verdict, err := c.moderate(ctx, m, hash, cl.approved, variants)
+	if err != nil {
+		return fmt.Errorf("moderate: %w", err)
+	}
+	if verdict.Flagged {
+		done, err := c.quarantineUpload(ctx, uploadID, hash, meta, verdict.Reason)
+		if err != nil {
+			return fmt.Errorf("quarantine: %w", err)
+		}
+		if done {
+			log.Warn().Str("reason", verdict.Reason).Float64("score", verdict.Score).Msg("image quarantined for review")
+		}
+		return nil
 	}
 
 	// Upload derived images
 	derivedKeys := make(map[string]string)
-	for size, data := range results {
-		key := fmt.Sprintf("derived/%s/%s_%s.jpg", m.Purpose, m.UploadID, size)
-		if err := c.s3.PutPublicObject(ctx, key, bytes.NewReader(data), "image/jpeg", int64(len(data))); err != nil {
-			log.Error().Err(err).Str("size", size).Msg("failed to upload derived image")
-			c.updateStatus(ctx, uploadID, "FAILED", err.Error())
-			msg.Nack(false, true) // Requeue
-			return
+	for _, v := range variants {
+		key := fmt.Sprintf("derived/%s/%s_%s.%s", m.Purpose, m.UploadID, v.Size, v.Format.Ext)
+		if err := c.s3.PutPublicObject(ctx, key, bytes.NewReader(v.Data), v.Format.ContentType, int64(len(v.Data))); err != nil {
+			return fmt.Errorf("upload %s: %w", key, err)
 		}
-		derivedKeys[size] = key
-		log.Info().Str("size", size).Str("key", key).Msg("uploaded derived image")
+		derivedKeys[DerivedKeyName(v.Size, v.Format)] = key
+		log.Info().Str("size", v.Size).Str("format", v.Format.Name).Str("key", key).Msg("uploaded derived image")
 	}
 
 	// Update database with derived keys
-	if err := c.updateDerivedKeys(ctx, uploadID, derivedKeys); err != nil {
-		log.Error().Err(err).Msg("failed to update derived keys")
-		msg.Nack(false, true) // Requeue
-		return
+	done, err := c.completeUpload(ctx, uploadID, hash, derivedKeys, meta)
+	if err != nil {
+		return fmt.Errorf("update derived keys: %w", err)
+	}
+	if !done {
+		log.Warn().Msg("upload left PROCESSING while being processed; result dropped")
+		return nil
 	}
 
 	// Delete raw image (optional, for cleanup)
 	// c.s3.DeleteRawObject(ctx, m.ObjectKey)
 
 	log.Info().Msg("image processing complete")
-	msg.Ack(false)
+	return nil
+}
+
+// failUpload marks the message's upload FAILED when giving up on it.
+func (c *Consumer) failUpload(ctx context.Context, body []byte, cause error) {
+	var m ProcessImageMessage
+	if json.Unmarshal(body, &m) != nil {
+		return
+	}
+	id, err := uuid.Parse(m.UploadID)
+	if err != nil {
+		return
+	}
+	if err := c.markFailed(ctx, id, cause.Error()); err != nil {
+		c.log.Error().Err(err).Str("upload_id", m.UploadID).Msg("failed to mark upload FAILED")
+	}
+}
+
+// RequeueImage publishes a fresh processing job; the reaper uses it for
+// uploads whose worker died mid-job.
+func (c *Consumer) RequeueImage(ctx context.Context, uploadID, objectKey, purpose string) error {
+	body, err := json.Marshal(ProcessImageMessage{UploadID: uploadID, ObjectKey: objectKey, Purpose: purpose})
+	if err != nil {
+		return err
+	}
+	return c.publishConfirmed(ctx, c.cfg.RabbitExchange, rkProcessImage, true, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now().UTC(),
+		MessageId:    uuid.NewString(),
+		Body:         body,
+	})
 }
 
 func (c *Consumer) fetchRawImage(ctx context.Context, objectKey string) ([]byte, error) {
@@ -233,24 +445,59 @@ func (c *Consumer) fetchRawImage(ctx context.Context, objectKey string) ([]byte,
 	return io.ReadAll(reader)
 }
 
-func (c *Consumer) updateStatus(ctx context.Context, id uuid.UUID, status, errMsg string) error {
-	if errMsg != "" {
-		_, err := c.pool.Exec(ctx, `
-			UPDATE media_uploads SET status = $2, error_message = $3, updated_at = $4 WHERE id = $1
-		`, id, status, errMsg, time.Now())
-		return err
+// Status transitions only move forward from UPLOADED/PROCESSING, so
+// duplicate deliveries, parallel workers and the reaper cannot undo a
+// READY or FAILED upload.
+
+// claim is what processing needs from a claimed upload.
+type claim struct {
+	approved bool // released by a moderator; skip the classifier
+	crop     *sanitizer.Crop
+}
+
+// claimUpload moves the upload to PROCESSING and counts the attempt. It
+// returns nil when the upload is no longer waiting for processing.
+func (c *Consumer) claimUpload(ctx context.Context, id uuid.UUID) (*claim, error) {
+	var state string
+	var cropJSON []byte
+	err := c.pool.QueryRow(ctx, `
+		UPDATE media_uploads
+		SET status = 'PROCESSING', processing_attempts = processing_attempts + 1, updated_at = $2
+		WHERE id = $1 AND status IN ('UPLOADED', 'PROCESSING')
+		RETURNING COALESCE(moderation_state, ''), crop
+	`, id, time.Now()).Scan(&state, &cropJSON)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
 	}
-	_, err := c.pool.Exec(ctx, `
-		UPDATE media_uploads SET status = $2, updated_at = $3 WHERE id = $1
-	`, id, status, time.Now())
-	return err
+	if err != nil {
+		return nil, err
+	}
+	crop, err := parseCrop(cropJSON)
+	if err != nil {
+		return nil, permanent(err)
+	}
+	return &claim{approved: state == modApproved, crop: crop}, nil
 }
 
-func (c *Consumer) updateDerivedKeys(ctx context.Context, id uuid.UUID, derivedKeys map[string]string) error {
+func (c *Consumer) completeUpload(ctx context.Context, id uuid.UUID, hash string, derivedKeys map[string]string, meta sanitizer.Meta) (bool, error) {
 	keysJSON, _ := json.Marshal(derivedKeys)
+	tag, err := c.pool.Exec(ctx, `
+		UPDATE media_uploads
+		SET derived_keys = $2, content_hash = $3, width = $4, height = $5, blurhash = $6, dominant_color = $7,
+		    status = 'READY', updated_at = $8
+		WHERE id = $1 AND status IN ('UPLOADED', 'PROCESSING')
+	`, id, keysJSON, hash, meta.Width, meta.Height, meta.BlurHash, meta.DominantColor, time.Now())
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+func (c *Consumer) markFailed(ctx context.Context, id uuid.UUID, errMsg string) error {
 	_, err := c.pool.Exec(ctx, `
-		UPDATE media_uploads SET derived_keys = $2, status = 'READY', updated_at = $3 WHERE id = $1
-	`, id, keysJSON, time.Now())
+		UPDATE media_uploads SET status = 'FAILED', error_message = $2, updated_at = $3
+		WHERE id = $1 AND status IN ('UPLOADED', 'PROCESSING')
+	`, id, errMsg, time.Now())
 	return err
 }
 
@@ -259,6 +506,9 @@ func (c *Consumer) Close() {
 	if c.channel != nil {
 		c.channel.Close()
 	}
+	if c.chPublish != nil {
+		c.chPublish.Close()
+	}
 	if c.conn != nil {
 		c.conn.Close()
 	}
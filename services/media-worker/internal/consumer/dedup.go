@@ -0,0 +1,172 @@
+package consumer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/baechuer/cityevents/services/media-worker/internal/sanitizer"
+)
+
+// contentHash is the hex SHA-256 of an upload's raw bytes.
+func contentHash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// duplicate is a READY upload whose derived objects can be copied.
+type duplicate struct {
+	rootID      uuid.UUID // upload that owns the derived objects
+	derivedKeys map[string]string
+	meta        sanitizer.Meta
+}
+
+// findDuplicate looks for a READY upload with the same owner, purpose,
+// content hash and crop as id. Matching is per owner so that deleting one account's
+// media never removes objects another account still points at.
+func (c *Consumer) findDuplicate(ctx context.Context, id uuid.UUID, hash string) (*duplicate, error) {
+	var d duplicate
+	var keysJSON []byte
+	err := c.pool.QueryRow(ctx, `
+		SELECT COALESCE(d.dedup_of, d.id), d.derived_keys,
+		       COALESCE(d.width, 0), COALESCE(d.height, 0), COALESCE(d.blurhash, ''), COALESCE(d.dominant_color, '')
+		FROM media_uploads u
+		JOIN media_uploads d
+		  ON d.owner_id = u.owner_id AND d.purpose = u.purpose
+		 AND d.content_hash = $2 AND d.status = 'READY' AND d.id <> u.id
+		 AND d.crop IS NOT DISTINCT FROM u.crop
+		WHERE u.id = $1
+		ORDER BY d.created_at
+		LIMIT 1
+	`, id, hash).Scan(&d.rootID, &keysJSON, &d.meta.Width, &d.meta.Height, &d.meta.BlurHash, &d.meta.DominantColor)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(keysJSON, &d.derivedKeys); err != nil || len(d.derivedKeys) == 0 {
+		return nil, nil // nothing reusable; process normally
+	}
+	return &d, nil
+}
+
+// completeDuplicate copies dup's derived objects to id's own keys and
+// marks id READY with them and dup's placeholder. Clients build cover URLs
+// from the upload ID, so every upload needs objects under its own keys.
+// The copies are server-side and skip re-encoding.
+func (c *Consumer) completeDuplicate(ctx context.Context, id uuid.UUID, hash string, dup *duplicate) (bool, error) {
+	derivedKeys := make(map[string]string, len(dup.derivedKeys))
+	for name, src := range dup.derivedKeys {
+		dst := ownDerivedKey(src, id)
+		if err := c.s3.CopyPublicObject(ctx, src, dst); err != nil {
+			return false, err
+		}
+		derivedKeys[name] = dst
+	}
+
+	keysJSON, _ := json.Marshal(derivedKeys)
+	tag, err := c.pool.Exec(ctx, `
+		UPDATE media_uploads
+		SET derived_keys = $2, content_hash = $3, dedup_of = $4,
+		    width = $5, height = $6, blurhash = $7, dominant_color = $8,
+		    status = 'READY', updated_at = $9
+		WHERE id = $1 AND status IN ('UPLOADED', 'PROCESSING')
+	`, id, keysJSON, hash, dup.rootID, dup.meta.Width, dup.meta.Height, dup.meta.BlurHash, dup.meta.DominantColor, time.Now())
+	if err != nil {
+		return false, err
+	}
+	if tag.RowsAffected() != 1 {
+		// Someone else finished the upload; drop our copies.
+		for _, key := range derivedKeys {
+			_ = c.s3.DeletePublicObject(ctx, key)
+		}
+		return false, nil
+	}
+	return true, nil
+}
+
+// ownDerivedKey renames a derived key ("derived/<purpose>/<upload id>_<variant>")
+// to the same variant of upload id.
+func ownDerivedKey(key string, id uuid.UUID) string {
+	dir, base := path.Split(key)
+	_, variant, ok := strings.Cut(base, "_")
+	if !ok {
+		return dir + id.String() + "_" + base
+	}
+	return dir + id.String() + "_" + variant
+}
+
+// RepairDedupCopies gives dedup hits completed before they got their own
+// copies (their derived_keys still name the root's objects) objects under
+// their own keys. It runs once at startup and is safe to run on every
+// replica: a row is only updated while its keys are unchanged.
+func (c *Consumer) RepairDedupCopies(ctx context.Context) error {
+	rows, err := c.pool.Query(ctx, `
+		SELECT id, derived_keys
+		FROM media_uploads
+		WHERE dedup_of IS NOT NULL AND status = 'READY'
+		  AND derived_keys IS NOT NULL
+		  AND position(id::text IN derived_keys::text) = 0
+	`)
+	if err != nil {
+		return err
+	}
+	type shared struct {
+		id   uuid.UUID
+		keys []byte
+	}
+	var todo []shared
+	for rows.Next() {
+		var s shared
+		if err := rows.Scan(&s.id, &s.keys); err != nil {
+			rows.Close()
+			return err
+		}
+		todo = append(todo, s)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	repaired := 0
+	for _, s := range todo {
+		var keys map[string]string
+		if err := json.Unmarshal(s.keys, &keys); err != nil {
+			c.log.Warn().Err(err).Str("upload_id", s.id.String()).Msg("bad derived_keys; skipping dedup repair")
+			continue
+		}
+		own := make(map[string]string, len(keys))
+		for name, src := range keys {
+			dst := ownDerivedKey(src, s.id)
+			if err := c.s3.CopyPublicObject(ctx, src, dst); err != nil {
+				return err
+			}
+			own[name] = dst
+		}
+		ownJSON, _ := json.Marshal(own)
+		tag, err := c.pool.Exec(ctx, `
+			UPDATE media_uploads SET derived_keys = $2, updated_at = NOW()
+			WHERE id = $1 AND derived_keys = $3::jsonb
+		`, s.id, ownJSON, s.keys)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 1 {
+			repaired++
+		}
+	}
+	if repaired > 0 {
+		c.log.Info().Int("uploads", repaired).Msg("copied shared dedup objects to their own keys")
+	}
+	return nil
+}
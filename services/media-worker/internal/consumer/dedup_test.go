@@ -0,0 +1,19 @@
+package consumer
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnDerivedKey(t *testing.T) {
+	id := uuid.MustParse("7b0e2f4c-7a4e-4a59-9a43-3f2d3c1b9e10")
+
+	assert.Equal(t,
+		"derived/event_cover/7b0e2f4c-7a4e-4a59-9a43-3f2d3c1b9e10_800.jpg",
+		ownDerivedKey("derived/event_cover/1f6c3a52-0d7e-4f1b-8a4e-2b9c6d5e7f80_800.jpg", id))
+	assert.Equal(t,
+		"derived/event_cover/7b0e2f4c-7a4e-4a59-9a43-3f2d3c1b9e10_16x9-800-c1a2b3c4.webp",
+		ownDerivedKey("derived/event_cover/1f6c3a52-0d7e-4f1b-8a4e-2b9c6d5e7f80_16x9-800-c1a2b3c4.webp", id))
+}
@@ -0,0 +1,81 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// RetireLegacyQueue moves the worker off the queue older versions consumed.
+// That queue has no dead-letter arguments and RabbitMQ refuses to redeclare
+// a queue with different ones, so the worker consumes a new queue instead.
+// The new queue is already bound, so unbinding the old one loses nothing;
+// whatever is left in it is moved over, then it is deleted once empty and
+// unused. While replicas of the old version still consume it the delete is
+// skipped and the next start finishes the job.
+func (c *Consumer) RetireLegacyQueue(ctx context.Context) error {
+	legacy := c.cfg.RabbitLegacyQueue
+	if legacy == "" || legacy == c.cfg.RabbitQueue {
+		return nil
+	}
+
+	// A failed passive declare or delete closes the channel, so use a
+	// separate one.
+	ch, err := c.conn.Channel()
+	if err != nil {
+		return fmt.Errorf("open channel: %w", err)
+	}
+	defer ch.Close()
+
+	if _, err := ch.QueueDeclarePassive(legacy, true, false, false, false, nil); err != nil {
+		if isNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("inspect %s: %w", legacy, err)
+	}
+
+	for _, key := range bindKeys {
+		if err := ch.QueueUnbind(legacy, key, c.cfg.RabbitExchange, nil); err != nil {
+			return fmt.Errorf("unbind %s from %s: %w", legacy, key, err)
+		}
+	}
+
+	moved := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		d, ok, err := ch.Get(legacy, false)
+		if err != nil {
+			return fmt.Errorf("get from %s: %w", legacy, err)
+		}
+		if !ok {
+			break
+		}
+		h := copyHeaders(d.Headers)
+		h[hdrOrigRK] = routingKey(d)
+		if err := c.publishConfirmed(ctx, "", c.cfg.RabbitQueue, true, republishing(d, h)); err != nil {
+			_ = d.Nack(false, true)
+			return fmt.Errorf("move message from %s: %w", legacy, err)
+		}
+		if err := d.Ack(false); err != nil {
+			return fmt.Errorf("ack message from %s: %w", legacy, err)
+		}
+		moved++
+	}
+
+	if _, err := ch.QueueDelete(legacy, true, true, false); err != nil {
+		c.log.Warn().Err(err).Str("queue", legacy).Int("moved", moved).
+			Msg("legacy queue still in use or not empty; retrying on next start")
+		return nil
+	}
+	c.log.Info().Str("queue", legacy).Int("moved", moved).Msg("legacy queue retired")
+	return nil
+}
+
+func isNotFound(err error) bool {
+	var amqpErr *amqp.Error
+	return errors.As(err, &amqpErr) && amqpErr.Code == amqp.NotFound
+}
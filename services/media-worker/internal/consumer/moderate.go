@@ -0,0 +1,72 @@
+package consumer
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/baechuer/cityevents/services/media-worker/internal/moderation"
+	"github.com/baechuer/cityevents/services/media-worker/internal/sanitizer"
+)
+
+// Moderation states in media_uploads.moderation_state. Unset means the
+// upload was never flagged.
+const (
+	modFlagged  = "FLAGGED"
+	modApproved = "APPROVED"
+)
+
+// moderate classifies the JPEG of the largest whole-image size before
+// anything is made public. Purposes outside MODERATION_PURPOSES and uploads a moderator
+// already approved pass without a call.
+func (c *Consumer) moderate(ctx context.Context, m ProcessImageMessage, hash string, approved bool, variants []sanitizer.Variant) (moderation.Verdict, error) {
+	if approved || !slices.Contains(c.cfg.ModerationPurposes, m.Purpose) {
+		return moderation.Verdict{}, nil
+	}
+
+	size := moderationSize(DerivedSizes[m.Purpose])
+	var img []byte
+	for _, v := range variants {
+		if v.Format.Name == "jpeg" && v.Size == size {
+			img = v.Data
+		}
+	}
+	return c.moderator.Classify(ctx, moderation.Input{
+		UploadID:    m.UploadID,
+		Purpose:     m.Purpose,
+		ContentHash: hash,
+		Image:       img,
+	})
+}
+
+// quarantineUpload holds a flagged upload for review. Nothing was
+// published; the raw object stays so approval can reprocess it.
+func (c *Consumer) quarantineUpload(ctx context.Context, id uuid.UUID, hash string, meta sanitizer.Meta, reason string) (bool, error) {
+	tag, err := c.pool.Exec(ctx, `
+		UPDATE media_uploads
+		SET status = 'QUARANTINED', moderation_state = $2, moderation_reason = $3, content_hash = $4,
+		    width = $5, height = $6, blurhash = $7, dominant_color = $8, updated_at = $9
+		WHERE id = $1 AND status IN ('UPLOADED', 'PROCESSING')
+	`, id, modFlagged, reason, hash, meta.Width, meta.Height, meta.BlurHash, meta.DominantColor, time.Now())
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// moderationSize names the widest size showing the whole image, or the
+// widest size when every size crops (avatars).
+func moderationSize(sizes []sanitizer.ResizeConfig) string {
+	var best sanitizer.ResizeConfig
+	for _, s := range sizes {
+		switch {
+		case best.Name == "",
+			best.Crop && !s.Crop,
+			best.Crop == s.Crop && s.Width > best.Width:
+			best = s
+		}
+	}
+	return best.Name
+}
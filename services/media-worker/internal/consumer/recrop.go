@@ -0,0 +1,190 @@
+package consumer
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/cityevents/services/media-worker/internal/sanitizer"
+	"github.com/baechuer/cityevents/services/media-worker/internal/storage"
+)
+
+// parseCrop decodes media_uploads.crop; NULL means a center crop.
+func parseCrop(data []byte) (*sanitizer.Crop, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var crop sanitizer.Crop
+	if err := json.Unmarshal(data, &crop); err != nil {
+		return nil, fmt.Errorf("invalid crop: %w", err)
+	}
+	return &crop, nil
+}
+
+// cropSizes keeps the crop-mode sizes, the only ones a crop changes.
+func cropSizes(sizes []sanitizer.ResizeConfig) []sanitizer.ResizeConfig {
+	var out []sanitizer.ResizeConfig
+	for _, s := range sizes {
+		if s.Crop {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// recropSource is a READY upload as a recrop job finds it.
+type recropSource struct {
+	cropJSON    []byte
+	rawKey      string // the dedup root's for dedup hits, whose own raw object is gone
+	derivedKeys map[string]string
+}
+
+// recropImage regenerates the crop-mode sizes of a READY upload whose owner
+// changed the crop. The new variants get keys tagged with the crop, so CDN
+// caches and uploads sharing the old objects through dedup are unaffected.
+// The upload stays READY throughout. Replays redo the same keys; a job
+// overtaken by a newer crop drops its result.
+func (c *Consumer) recropImage(ctx context.Context, m ProcessImageMessage, uploadID uuid.UUID, log zerolog.Logger) error {
+	src, err := c.loadRecropSource(ctx, uploadID)
+	if err != nil {
+		return fmt.Errorf("load upload: %w", err)
+	}
+	if src == nil {
+		log.Info().Msg("upload not READY; skipping recrop")
+		return nil
+	}
+
+	sizes := cropSizes(DerivedSizes[m.Purpose])
+	if len(sizes) == 0 {
+		return nil
+	}
+	crop, err := parseCrop(src.cropJSON)
+	if err != nil {
+		return permanent(err)
+	}
+
+	rawData, err := c.fetchRawImage(ctx, src.rawKey)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			return permanent(err)
+		}
+		return err
+	}
+
+	// The raw object stays: the upload is READY and may be re-cropped again
+	variants, _, err := sanitizer.Process(rawData, sizes, crop, c.cfg.MaxImageWidth, c.cfg.MaxImageHeight, c.encoders)
+	if err != nil {
+		return permanent(err)
+	}
+
+	tag := cropTag(src.cropJSON)
+	newKeys := make(map[string]string, len(variants))
+	for _, v := range variants {
+		key := fmt.Sprintf("derived/%s/%s_%s-%s.%s", m.Purpose, m.UploadID, v.Size, tag, v.Format.Ext)
+		if err := c.s3.PutPublicObject(ctx, key, bytes.NewReader(v.Data), v.Format.ContentType, int64(len(v.Data))); err != nil {
+			return fmt.Errorf("upload %s: %w", key, err)
+		}
+		newKeys[DerivedKeyName(v.Size, v.Format)] = key
+	}
+
+	done, err := c.applyRecrop(ctx, uploadID, src.cropJSON, newKeys)
+	if err != nil {
+		return fmt.Errorf("update derived keys: %w", err)
+	}
+	if !done {
+		// Re-cropped again or no longer READY; the newer state wins
+		c.deleteUnreferenced(ctx, newKeys, log)
+		log.Info().Msg("crop changed while recropping; result dropped")
+		return nil
+	}
+
+	replaced := make(map[string]string)
+	for name, key := range newKeys {
+		if old := src.derivedKeys[name]; old != "" && old != key {
+			replaced[name] = old
+		}
+	}
+	c.deleteUnreferenced(ctx, replaced, log)
+
+	log.Info().Int("variants", len(newKeys)).Msg("recrop complete")
+	return nil
+}
+
+func (c *Consumer) loadRecropSource(ctx context.Context, id uuid.UUID) (*recropSource, error) {
+	var src recropSource
+	var keysJSON []byte
+	err := c.pool.QueryRow(ctx, `
+		SELECT u.crop, COALESCE(r.raw_object_key, u.raw_object_key), u.derived_keys
+		FROM media_uploads u
+		LEFT JOIN media_uploads r ON r.id = u.dedup_of
+		WHERE u.id = $1 AND u.status = 'READY'
+	`, id).Scan(&src.cropJSON, &src.rawKey, &keysJSON)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(keysJSON) > 0 {
+		_ = json.Unmarshal(keysJSON, &src.derivedKeys)
+	}
+	return &src, nil
+}
+
+// applyRecrop merges the new keys into derived_keys if the upload still has
+// the crop they were made for.
+func (c *Consumer) applyRecrop(ctx context.Context, id uuid.UUID, cropJSON []byte, newKeys map[string]string) (bool, error) {
+	keysJSON, _ := json.Marshal(newKeys)
+	tag, err := c.pool.Exec(ctx, `
+		UPDATE media_uploads
+		SET derived_keys = COALESCE(derived_keys, '{}'::jsonb) || $2::jsonb, updated_at = $4
+		WHERE id = $1 AND status = 'READY' AND crop IS NOT DISTINCT FROM $3::jsonb
+	`, id, keysJSON, nullableJSON(cropJSON), time.Now())
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// deleteUnreferenced removes public objects no upload points at any more.
+// Failures only leave garbage behind, so they are logged.
+func (c *Consumer) deleteUnreferenced(ctx context.Context, keys map[string]string, log zerolog.Logger) {
+	for name, key := range keys {
+		var used bool
+		err := c.pool.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM media_uploads WHERE derived_keys @> jsonb_build_object($1::text, $2::text))
+		`, name, key).Scan(&used)
+		if err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("failed to check derived object references")
+			continue
+		}
+		if used {
+			continue
+		}
+		if err := c.s3.DeletePublicObject(ctx, key); err != nil {
+			log.Warn().Err(err).Str("key", key).Msg("failed to delete replaced derived object")
+		}
+	}
+}
+
+// cropTag is a short, stable tag for a crop, used in object keys.
+func cropTag(cropJSON []byte) string {
+	sum := sha256.Sum256(cropJSON)
+	return "c" + hex.EncodeToString(sum[:4])
+}
+
+func nullableJSON(data []byte) any {
+	if len(data) == 0 {
+		return nil
+	}
+	return string(data)
+}
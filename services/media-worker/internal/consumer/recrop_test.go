@@ -0,0 +1,33 @@
+package consumer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCropSizes(t *testing.T) {
+	var names []string
+	for _, s := range cropSizes(DerivedSizes["event_cover"]) {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"16x9-800", "16x9-1600", "1x1-400"}, names)
+}
+
+func TestModerationSize(t *testing.T) {
+	assert.Equal(t, "1600", moderationSize(DerivedSizes["event_cover"]))
+	assert.Equal(t, "512", moderationSize(DerivedSizes["avatar"]))
+}
+
+func TestParseCrop(t *testing.T) {
+	crop, err := parseCrop(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, crop)
+
+	crop, err = parseCrop([]byte(`{"focus": {"x": 0.25, "y": 0.5}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 0.25, crop.Focus.X)
+	assert.Nil(t, crop.Rect)
+
+	assert.NotEqual(t, cropTag([]byte(`{"focus": {"x": 0.25, "y": 0.5}}`)), cropTag([]byte(`{"focus": {"x": 0.5, "y": 0.5}}`)))
+}
@@ -0,0 +1,221 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// queues
+	qDLQ      = "media-worker.dlq"
+	qRetry10s = "media-worker.retry.10s"
+	qRetry1m  = "media-worker.retry.1m"
+	qRetry10m = "media-worker.retry.10m"
+
+	// headers
+	hdrAttempt     = "x-attempt"
+	hdrOrigRK      = "x-orig-routing-key"
+	hdrError       = "x-error"
+	hdrDLQReason   = "x-dlq-reason"
+	maxErrorHeader = 500
+
+	// publish reliability window
+	publishWait = 250 * time.Millisecond
+)
+
+// permanentError marks a failure retrying cannot fix (bad payload,
+// undecodable image). The message goes straight to the DLQ.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string   { return e.err.Error() }
+func (e *permanentError) Unwrap() error   { return e.err }
+func (e *permanentError) Permanent() bool { return true }
+
+func permanent(err error) error { return &permanentError{err: err} }
+
+func isPermanent(err error) bool {
+	var pe *permanentError
+	return errors.As(err, &pe)
+}
+
+// declareTopology declares the DLQ and the retry tiers. Unlike
+// email-service's tier exchanges, retry queues dead-letter straight into
+// mainQueue through the default exchange: the keys this worker consumes
+// (auth.user.*) are also bound by other services, so re-publishing to
+// city.events would deliver every retry to them again. The business
+// routing key travels in x-orig-routing-key instead.
+func declareTopology(ch *amqp.Channel, mainQueue string) error {
+	if _, err := ch.QueueDeclare(qDLQ, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("dlq queue declare: %w", err)
+	}
+	for _, t := range []struct {
+		name string
+		ttl  time.Duration
+	}{{qRetry10s, 10 * time.Second}, {qRetry1m, time.Minute}, {qRetry10m, 10 * time.Minute}} {
+		if err := declareRetryQueue(ch, t.name, t.ttl, mainQueue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func declareRetryQueue(ch *amqp.Channel, qName string, ttl time.Duration, mainQueue string) error {
+	args := amqp.Table{
+		"x-message-ttl":             int64(ttl / time.Millisecond),
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": mainQueue,
+	}
+	if _, err := ch.QueueDeclare(qName, true, false, false, false, args); err != nil {
+		return fmt.Errorf("retry queue declare (%s): %w", qName, err)
+	}
+	return nil
+}
+
+// mainQueueArgs routes Nack(requeue=false) to the DLQ as a safety net.
+func mainQueueArgs() amqp.Table {
+	return amqp.Table{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": qDLQ,
+	}
+}
+
+// retryQueue picks the delay tier for the given upcoming attempt.
+func retryQueue(nextAttempt int) string {
+	switch {
+	case nextAttempt <= 1:
+		return qRetry10s
+	case nextAttempt == 2:
+		return qRetry1m
+	default:
+		return qRetry10m
+	}
+}
+
+// routingKey is the business routing key; retried messages arrive keyed
+// by queue name.
+func routingKey(d amqp.Delivery) string {
+	if rk, ok := d.Headers[hdrOrigRK].(string); ok && rk != "" {
+		return rk
+	}
+	return d.RoutingKey
+}
+
+// getAttempt returns how many times the message has been retried.
+func getAttempt(h amqp.Table) int {
+	if h == nil {
+		return 0
+	}
+	switch t := h[hdrAttempt].(type) {
+	case int:
+		return t
+	case int32:
+		return int(t)
+	case int64:
+		return int(t)
+	case float64:
+		return int(t)
+	case string:
+		n, _ := strconv.Atoi(t)
+		return n
+	default:
+		return 0
+	}
+}
+
+// publishRetry parks the message in the tier queue for nextAttempt.
+func (c *Consumer) publishRetry(ctx context.Context, d amqp.Delivery, nextAttempt int, cause error) error {
+	h := copyHeaders(d.Headers)
+	h[hdrAttempt] = nextAttempt
+	h[hdrOrigRK] = routingKey(d)
+	h[hdrError] = truncate(cause.Error(), maxErrorHeader)
+	return c.publishConfirmed(ctx, "", retryQueue(nextAttempt), true, republishing(d, h))
+}
+
+// publishFinal moves the message to the DLQ.
+func (c *Consumer) publishFinal(ctx context.Context, d amqp.Delivery, reason string, cause error) error {
+	h := copyHeaders(d.Headers)
+	h[hdrOrigRK] = routingKey(d)
+	h[hdrDLQReason] = reason
+	if cause != nil {
+		h[hdrError] = truncate(cause.Error(), maxErrorHeader)
+	}
+	return c.publishConfirmed(ctx, "", qDLQ, true, republishing(d, h))
+}
+
+func republishing(d amqp.Delivery, h amqp.Table) amqp.Publishing {
+	return amqp.Publishing{
+		ContentType:   d.ContentType,
+		Body:          d.Body,
+		DeliveryMode:  amqp.Persistent,
+		Timestamp:     time.Now(),
+		Headers:       h,
+		CorrelationId: d.CorrelationId,
+		MessageId:     d.MessageId,
+	}
+}
+
+// publishConfirmed publishes on the confirm-mode channel and waits for the
+// broker's ack of that publish (matched by delivery tag, so a late confirm
+// from an earlier timeout is skipped). Workers share the channel, so
+// publishes are serialized. mandatory makes an unroutable retry an error
+// instead of a silent loss.
+func (c *Consumer) publishConfirmed(ctx context.Context, exchange, key string, mandatory bool, pub amqp.Publishing) error {
+	c.pubMu.Lock()
+	defer c.pubMu.Unlock()
+
+	tag := c.chPublish.GetNextPublishSeqNo()
+	if err := c.chPublish.PublishWithContext(ctx, exchange, key, mandatory, false, pub); err != nil {
+		return fmt.Errorf("publish %s: %w", key, err)
+	}
+
+	timer := time.NewTimer(publishWait)
+	defer timer.Stop()
+
+	// A returned message is still acked; wait for that ack so it is not
+	// mistaken for the next publish's.
+	var returned error
+	for {
+		select {
+		case r := <-c.returnCh:
+			returned = fmt.Errorf("publish returned: reply=%d text=%q rk=%q", r.ReplyCode, r.ReplyText, r.RoutingKey)
+		case conf := <-c.confirmCh:
+			if conf.DeliveryTag < tag {
+				continue
+			}
+			if returned != nil {
+				return returned
+			}
+			if !conf.Ack {
+				return fmt.Errorf("publish nacked by broker (rk=%q)", key)
+			}
+			return nil
+		case <-timer.C:
+			if returned != nil {
+				return returned
+			}
+			return errors.New("publish wait timeout (no confirm/return)")
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func copyHeaders(in amqp.Table) amqp.Table {
+	out := amqp.Table{}
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n]
+}
@@ -0,0 +1,48 @@
+package consumer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryQueue_Tiers(t *testing.T) {
+	assert.Equal(t, qRetry10s, retryQueue(1))
+	assert.Equal(t, qRetry1m, retryQueue(2))
+	assert.Equal(t, qRetry10m, retryQueue(3))
+	assert.Equal(t, qRetry10m, retryQueue(9))
+}
+
+func TestGetAttempt(t *testing.T) {
+	assert.Equal(t, 0, getAttempt(nil))
+	assert.Equal(t, 0, getAttempt(amqp.Table{}))
+	assert.Equal(t, 2, getAttempt(amqp.Table{hdrAttempt: int32(2)}))
+	assert.Equal(t, 3, getAttempt(amqp.Table{hdrAttempt: int64(3)}))
+	assert.Equal(t, 4, getAttempt(amqp.Table{hdrAttempt: "4"}))
+}
+
+func TestRoutingKey_PrefersOriginalKey(t *testing.T) {
+	// A retried message comes back from the default exchange keyed by queue name
+	retried := amqp.Delivery{RoutingKey: "media-worker.jobs", Headers: amqp.Table{hdrOrigRK: rkDeletionRequested}}
+	assert.Equal(t, rkDeletionRequested, routingKey(retried))
+
+	fresh := amqp.Delivery{RoutingKey: rkProcessImage}
+	assert.Equal(t, rkProcessImage, routingKey(fresh))
+}
+
+func TestIsPermanent(t *testing.T) {
+	base := errors.New("bad image")
+	assert.True(t, isPermanent(permanent(base)))
+	assert.True(t, isPermanent(fmt.Errorf("wrapped: %w", permanent(base))))
+	assert.False(t, isPermanent(base))
+	assert.ErrorIs(t, permanent(base), base)
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, isNotFound(&amqp.Error{Code: amqp.NotFound, Reason: "NOT_FOUND - no queue 'media-worker.q'"}))
+	assert.False(t, isNotFound(&amqp.Error{Code: amqp.PreconditionFailed}))
+	assert.False(t, isNotFound(errors.New("connection reset")))
+}
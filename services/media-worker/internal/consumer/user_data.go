@@ -0,0 +1,141 @@
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	rkDeletionRequested = "auth.user.deletion_requested"
+	rkExportRequested   = "auth.user.export_requested"
+	rkUserDataDeleted   = "user.data.deleted"
+	rkUserDataExported  = "user.data.exported"
+
+	dataRequestService = "media-worker"
+)
+
+// DataRequestedMessage is the payload of auth-service's
+// auth.user.deletion_requested / auth.user.export_requested envelope.
+type DataRequestedMessage struct {
+	Payload struct {
+		RequestID string `json:"request_id"`
+		UserID    string `json:"user_id"`
+	} `json:"payload"`
+}
+
+// DataRequestReply is the user.data.deleted / user.data.exported reply to
+// auth-service. Data is only set on exports.
+type DataRequestReply struct {
+	RequestID string          `json:"request_id"`
+	UserID    string          `json:"user_id"`
+	Service   string          `json:"service"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+type ownedUpload struct {
+	ID          string            `json:"id"`
+	Purpose     string            `json:"purpose"`
+	Status      string            `json:"status"`
+	DerivedKeys map[string]string `json:"derived_keys"`
+	CreatedAt   time.Time         `json:"created_at"`
+
+	rawKey string
+}
+
+// processDataRequest deletes or exports the user's uploads and replies to
+// auth-service. Replays reply again.
+func (c *Consumer) processDataRequest(ctx context.Context, msg amqp.Delivery) error {
+	var m DataRequestedMessage
+	if err := json.Unmarshal(msg.Body, &m); err != nil || m.Payload.RequestID == "" || m.Payload.UserID == "" {
+		return permanent(fmt.Errorf("invalid data request message: %v", err))
+	}
+
+	rk := routingKey(msg)
+	log := c.log.With().Str("request_id", m.Payload.RequestID).Str("routing_key", rk).Logger()
+
+	uploads, err := c.listOwnedUploads(ctx, m.Payload.UserID)
+	if err != nil {
+		return fmt.Errorf("list uploads: %w", err)
+	}
+
+	reply := DataRequestReply{RequestID: m.Payload.RequestID, UserID: m.Payload.UserID, Service: dataRequestService}
+	replyKey := rkUserDataDeleted
+	if rk == rkDeletionRequested {
+		if err := c.deleteUploads(ctx, m.Payload.UserID, uploads); err != nil {
+			return fmt.Errorf("delete uploads: %w", err)
+		}
+	} else {
+		data, err := json.Marshal(map[string]any{"uploads": uploads})
+		if err != nil {
+			return permanent(fmt.Errorf("marshal export: %w", err))
+		}
+		reply.Data = data
+		replyKey = rkUserDataExported
+	}
+
+	body, _ := json.Marshal(reply)
+	err = c.publishConfirmed(ctx, c.cfg.RabbitExchange, replyKey, false, amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now().UTC(),
+		Body:         body,
+	})
+	if err != nil {
+		return fmt.Errorf("publish data request reply: %w", err)
+	}
+
+	log.Info().Int("uploads", len(uploads)).Msg("data request handled")
+	return nil
+}
+
+func (c *Consumer) listOwnedUploads(ctx context.Context, ownerID string) ([]ownedUpload, error) {
+	rows, err := c.pool.Query(ctx, `
+		SELECT id::text, purpose, status, COALESCE(raw_object_key, ''), COALESCE(derived_keys, '{}'), created_at
+		FROM media_uploads
+		WHERE owner_id = $1
+		ORDER BY created_at
+	`, ownerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := []ownedUpload{}
+	for rows.Next() {
+		var (
+			u       ownedUpload
+			derived []byte
+		)
+		if err := rows.Scan(&u.ID, &u.Purpose, &u.Status, &u.rawKey, &derived, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(derived, &u.DerivedKeys); err != nil {
+			return nil, fmt.Errorf("upload %s: derived_keys: %w", u.ID, err)
+		}
+		out = append(out, u)
+	}
+	return out, rows.Err()
+}
+
+// deleteUploads removes the objects first so a failure leaves the rows
+// behind for the retry to find.
+func (c *Consumer) deleteUploads(ctx context.Context, ownerID string, uploads []ownedUpload) error {
+	for _, u := range uploads {
+		for _, key := range u.DerivedKeys {
+			if err := c.s3.DeletePublicObject(ctx, key); err != nil {
+				return fmt.Errorf("delete %s: %w", key, err)
+			}
+		}
+		if u.rawKey != "" {
+			if err := c.s3.DeleteRawObject(ctx, u.rawKey); err != nil {
+				return fmt.Errorf("delete %s: %w", u.rawKey, err)
+			}
+		}
+	}
+	_, err := c.pool.Exec(ctx, `DELETE FROM media_uploads WHERE owner_id = $1`, ownerID)
+	return err
+}
@@ -0,0 +1,61 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTP posts the image to an external classifier:
+//
+//	POST <url>  Content-Type: image/jpeg, X-Upload-ID, X-Purpose
+//	200 {"flagged": bool, "reason": string, "score": float}
+//
+// Any other status is an error, so the job is retried.
+type HTTP struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTP creates an HTTP classifier.
+func NewHTTP(url string, timeout time.Duration) *HTTP {
+	return &HTTP{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (h *HTTP) Classify(ctx context.Context, in Input) (Verdict, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(in.Image))
+	if err != nil {
+		return Verdict{}, err
+	}
+	req.Header.Set("Content-Type", "image/jpeg")
+	req.Header.Set("X-Upload-ID", in.UploadID)
+	req.Header.Set("X-Purpose", in.Purpose)
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return Verdict{}, fmt.Errorf("classifier: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return Verdict{}, fmt.Errorf("classifier returned %d: %s", resp.StatusCode, bytes.TrimSpace(b))
+	}
+
+	var out struct {
+		Flagged bool    `json:"flagged"`
+		Reason  string  `json:"reason"`
+		Score   float64 `json:"score"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return Verdict{}, fmt.Errorf("classifier: decode response: %w", err)
+	}
+	if out.Flagged && out.Reason == "" {
+		out.Reason = "flagged by classifier"
+	}
+	return Verdict{Flagged: out.Flagged, Reason: out.Reason, Score: out.Score}, nil
+}
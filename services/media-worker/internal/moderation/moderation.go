@@ -0,0 +1,54 @@
+// Package moderation decides whether a processed image may be published.
+package moderation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/cityevents/services/media-worker/internal/config"
+)
+
+// Input is one image to classify. Image is a sanitized JPEG variant, so
+// no source metadata leaves the worker.
+type Input struct {
+	UploadID    string
+	Purpose     string
+	ContentHash string // SHA-256 hex of the raw upload
+	Image       []byte
+}
+
+// Verdict is a classifier's decision. Flagged images are quarantined for a
+// moderator instead of being published.
+type Verdict struct {
+	Flagged bool
+	Reason  string
+	Score   float64 // classifier confidence, 0 when not reported
+}
+
+// Classifier classifies one image. An error means no decision was made;
+// the worker retries the job.
+type Classifier interface {
+	Classify(ctx context.Context, in Input) (Verdict, error)
+}
+
+// Allow passes every image.
+type Allow struct{}
+
+func (Allow) Classify(context.Context, Input) (Verdict, error) { return Verdict{}, nil }
+
+// New builds the classifier selected by MODERATION_PROVIDER.
+func New(cfg *config.Config, log zerolog.Logger) (Classifier, error) {
+	switch cfg.ModerationProvider {
+	case "none":
+		log.Warn().Msg("image moderation disabled")
+		return Allow{}, nil
+	case "rules", "":
+		return NewRules(cfg.ModerationBlocklist, cfg.ModerationReviewAll), nil
+	case "http":
+		return NewHTTP(cfg.ModerationURL, cfg.ModerationTimeout), nil
+	default:
+		return nil, fmt.Errorf("unknown MODERATION_PROVIDER %q", cfg.ModerationProvider)
+	}
+}
@@ -0,0 +1,58 @@
+package moderation
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules(t *testing.T) {
+	ctx := context.Background()
+
+	r := NewRules([]string{"bad"}, false)
+	v, err := r.Classify(ctx, Input{ContentHash: "bad"})
+	assert.NoError(t, err)
+	assert.True(t, v.Flagged)
+
+	v, _ = r.Classify(ctx, Input{ContentHash: "good"})
+	assert.False(t, v.Flagged)
+
+	v, _ = NewRules(nil, true).Classify(ctx, Input{ContentHash: "good"})
+	assert.True(t, v.Flagged, "review-all holds everything")
+}
+
+func TestHTTP(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "image/jpeg", r.Header.Get("Content-Type"))
+		assert.Equal(t, "event_cover", r.Header.Get("X-Purpose"))
+		switch string(body) {
+		case "nsfw":
+			w.Write([]byte(`{"flagged":true,"reason":"adult content","score":0.97}`))
+		case "boom":
+			http.Error(w, "overloaded", http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"flagged":false}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewHTTP(srv.URL, time.Second)
+	ctx := context.Background()
+
+	v, err := c.Classify(ctx, Input{Purpose: "event_cover", Image: []byte("nsfw")})
+	assert.NoError(t, err)
+	assert.Equal(t, Verdict{Flagged: true, Reason: "adult content", Score: 0.97}, v)
+
+	v, err = c.Classify(ctx, Input{Purpose: "event_cover", Image: []byte("cat")})
+	assert.NoError(t, err)
+	assert.False(t, v.Flagged)
+
+	_, err = c.Classify(ctx, Input{Purpose: "event_cover", Image: []byte("boom")})
+	assert.Error(t, err, "no verdict means retry")
+}
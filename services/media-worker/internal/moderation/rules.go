@@ -0,0 +1,29 @@
+package moderation
+
+import "context"
+
+// Rules is the local classifier: it flags images whose content hash is on
+// a blocklist and, with reviewAll, holds every image for a moderator.
+type Rules struct {
+	blocked   map[string]bool
+	reviewAll bool
+}
+
+// NewRules creates a rules classifier.
+func NewRules(blocklist []string, reviewAll bool) *Rules {
+	blocked := make(map[string]bool, len(blocklist))
+	for _, h := range blocklist {
+		blocked[h] = true
+	}
+	return &Rules{blocked: blocked, reviewAll: reviewAll}
+}
+
+func (r *Rules) Classify(_ context.Context, in Input) (Verdict, error) {
+	switch {
+	case r.blocked[in.ContentHash]:
+		return Verdict{Flagged: true, Reason: "blocklisted image", Score: 1}, nil
+	case r.reviewAll:
+		return Verdict{Flagged: true, Reason: "pending review"}, nil
+	}
+	return Verdict{}, nil
+}
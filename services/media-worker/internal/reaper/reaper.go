@@ -0,0 +1,129 @@
+package reaper
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+
+	"github.com/baechuer/cityevents/services/media-worker/internal/config"
+)
+
+// batchSize bounds the uploads handled per tick.
+const batchSize = 100
+
+// Requeuer publishes a new processing job for an upload.
+type Requeuer interface {
+	RequeueImage(ctx context.Context, uploadID, objectKey, purpose string) error
+}
+
+// Reaper rescues uploads left in PROCESSING by a worker that died mid-job
+// (crash, OOM kill, lost connection after the claim).
+type Reaper struct {
+	pool     *pgxpool.Pool
+	requeuer Requeuer
+	cfg      *config.Config
+	log      zerolog.Logger
+}
+
+// NewReaper creates a new Reaper.
+func NewReaper(pool *pgxpool.Pool, requeuer Requeuer, cfg *config.Config, log zerolog.Logger) *Reaper {
+	return &Reaper{
+		pool:     pool,
+		requeuer: requeuer,
+		cfg:      cfg,
+		log:      log,
+	}
+}
+
+// Run starts the reaper loop.
+func (r *Reaper) Run(ctx context.Context) {
+	r.log.Info().Dur("timeout", r.cfg.ProcessingTimeout).Msg("processing reaper started")
+	ticker := time.NewTicker(r.cfg.ReaperInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info().Msg("processing reaper stopped")
+			return
+		case <-ticker.C:
+			if err := r.reap(ctx); err != nil {
+				r.log.Error().Err(err).Msg("failed to reap stuck uploads")
+			}
+		}
+	}
+}
+
+type stuckUpload struct {
+	id, objectKey, purpose string
+	attempts               int
+}
+
+// reap resets uploads stuck past ProcessingTimeout to UPLOADED and
+// requeues them, or fails those already claimed MaxAttempts times: an
+// image that keeps killing the worker must not loop forever. Rows are
+// locked with SKIP LOCKED so replicas split the work; the job is published
+// before the commit, so a failed commit only causes a duplicate job,
+// which the worker ignores.
+func (r *Reaper) reap(ctx context.Context) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT id::text, COALESCE(raw_object_key, ''), purpose, processing_attempts
+		FROM media_uploads
+		WHERE status = 'PROCESSING' AND updated_at < $1
+		ORDER BY updated_at
+		LIMIT $2
+		FOR UPDATE SKIP LOCKED
+	`, time.Now().Add(-r.cfg.ProcessingTimeout), batchSize)
+	if err != nil {
+		return err
+	}
+	stuck, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (stuckUpload, error) {
+		var u stuckUpload
+		err := row.Scan(&u.id, &u.objectKey, &u.purpose, &u.attempts)
+		return u, err
+	})
+	if err != nil {
+		return err
+	}
+	if len(stuck) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	for _, u := range stuck {
+		log := r.log.With().Str("upload_id", u.id).Int("attempts", u.attempts).Logger()
+
+		if u.attempts >= r.cfg.MaxAttempts {
+			if _, err := tx.Exec(ctx, `
+				UPDATE media_uploads SET status = 'FAILED', error_message = 'processing timed out', updated_at = $2
+				WHERE id = $1
+			`, u.id, now); err != nil {
+				return err
+			}
+			log.Warn().Msg("upload kept timing out; marked FAILED")
+			continue
+		}
+
+		if err := r.requeuer.RequeueImage(ctx, u.id, u.objectKey, u.purpose); err != nil {
+			log.Error().Err(err).Msg("failed to requeue stuck upload")
+			continue // stays PROCESSING; next tick tries again
+		}
+		if _, err := tx.Exec(ctx, `
+			UPDATE media_uploads SET status = 'UPLOADED', updated_at = $2 WHERE id = $1
+		`, u.id, now); err != nil {
+			return err
+		}
+		log.Warn().Msg("requeued upload stuck in PROCESSING")
+	}
+
+	return tx.Commit(ctx)
+}
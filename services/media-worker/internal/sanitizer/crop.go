@@ -0,0 +1,78 @@
+package sanitizer
+
+import (
+	"image"
+	"math"
+)
+
+// FocalPoint is the point to keep in view, as fractions of the oriented
+// image: (0, 0) is the top left corner, (1, 1) the bottom right.
+type FocalPoint struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+// CropRect is the region to keep, as fractions of the oriented image.
+type CropRect struct {
+	X      float64 `json:"x"`
+	Y      float64 `json:"y"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// Crop steers crop-mode sizes; width-only sizes always show the whole
+// image. At most one field is set. A nil Crop crops around the center.
+type Crop struct {
+	Focus *FocalPoint `json:"focus,omitempty"`
+	Rect  *CropRect   `json:"rect,omitempty"`
+}
+
+// cropWindow picks the part of a w x h image that is scaled into a
+// crop-mode size with the given aspect ratio (width / height).
+//
+// With a focal point the window is as large as the image allows and
+// centered on the point, shifted inward at the edges. With a rectangle it
+// is the smallest window of that aspect containing the rectangle, centered
+// on it; when that does not fit, the largest one that does.
+func cropWindow(w, h int, aspect float64, crop *Crop) image.Rectangle {
+	// Largest window of the target aspect
+	fitW, fitH := float64(w), float64(w)/aspect
+	if fitH > float64(h) {
+		fitW, fitH = float64(h)*aspect, float64(h)
+	}
+
+	winW, winH := fitW, fitH
+	cx, cy := float64(w)/2, float64(h)/2
+	switch {
+	case crop != nil && crop.Rect != nil:
+		rw, rh := crop.Rect.Width*float64(w), crop.Rect.Height*float64(h)
+		if rw/rh > aspect {
+			winW, winH = rw, rw/aspect
+		} else {
+			winW, winH = rh*aspect, rh
+		}
+		if winW > fitW {
+			winW, winH = fitW, fitH
+		}
+		cx = (crop.Rect.X + crop.Rect.Width/2) * float64(w)
+		cy = (crop.Rect.Y + crop.Rect.Height/2) * float64(h)
+	case crop != nil && crop.Focus != nil:
+		cx, cy = crop.Focus.X*float64(w), crop.Focus.Y*float64(h)
+	}
+
+	ww := max(1, int(math.Round(winW)))
+	wh := max(1, int(math.Round(winH)))
+	x := clampInt(int(math.Round(cx-winW/2)), 0, w-ww)
+	y := clampInt(int(math.Round(cy-winH/2)), 0, h-wh)
+	return image.Rect(x, y, x+ww, y+wh)
+}
+
+func clampInt(v, lo, hi int) int {
+	if v > hi {
+		v = hi
+	}
+	if v < lo {
+		v = lo
+	}
+	return v
+}
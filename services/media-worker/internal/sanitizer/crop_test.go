@@ -0,0 +1,49 @@
+package sanitizer
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCropWindow(t *testing.T) {
+	tests := []struct {
+		name string
+		crop *Crop
+		want image.Rectangle
+	}{
+		{"center", nil, image.Rect(50, 0, 150, 100)},
+		{"focus left", &Crop{Focus: &FocalPoint{X: 0.1, Y: 0.5}}, image.Rect(0, 0, 100, 100)},
+		{"focus right of center", &Crop{Focus: &FocalPoint{X: 0.6, Y: 0.5}}, image.Rect(70, 0, 170, 100)},
+		{"rect grows to aspect", &Crop{Rect: &CropRect{X: 0.7, Y: 0.2, Width: 0.1, Height: 0.4}}, image.Rect(130, 20, 170, 60)},
+		{"rect too large shrinks to fit", &Crop{Rect: &CropRect{X: 0, Y: 0, Width: 1, Height: 0.5}}, image.Rect(50, 0, 150, 100)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 200x100 image into a square size
+			assert.Equal(t, tt.want, cropWindow(200, 100, 1, tt.crop))
+		})
+	}
+}
+
+func TestResize_CropFollowsFocus(t *testing.T) {
+	// Left half red, right half blue
+	img := image.NewRGBA(image.Rect(0, 0, 200, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 200; x++ {
+			c := color.RGBA{255, 0, 0, 255}
+			if x >= 100 {
+				c = color.RGBA{0, 0, 255, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	out := Resize(img, ResizeConfig{Width: 50, Height: 50, Crop: true}, &Crop{Focus: &FocalPoint{X: 0.9, Y: 0.5}})
+	assert.Equal(t, image.Rect(0, 0, 50, 50), out.Bounds())
+	r, _, b, _ := out.At(25, 25).RGBA()
+	assert.Zero(t, r)
+	assert.NotZero(t, b)
+}
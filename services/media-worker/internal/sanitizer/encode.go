@@ -0,0 +1,131 @@
+package sanitizer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Format is an output image format.
+type Format struct {
+	Name        string // "jpeg", "webp", "avif"; used in derived_keys
+	Ext         string // object key extension
+	ContentType string
+}
+
+var (
+	FormatJPEG = Format{Name: "jpeg", Ext: "jpg", ContentType: "image/jpeg"}
+	FormatWebP = Format{Name: "webp", Ext: "webp", ContentType: "image/webp"}
+	FormatAVIF = Format{Name: "avif", Ext: "avif", ContentType: "image/avif"}
+)
+
+// Encoder writes an image in one output format. Encoding from decoded
+// pixels is also what strips the source metadata.
+type Encoder interface {
+	Format() Format
+	Encode(w io.Writer, img image.Image) error
+}
+
+// JPEGEncoder is the pure Go encoder every upload gets; it is the fallback
+// for clients that accept nothing better.
+type JPEGEncoder struct {
+	Quality int
+}
+
+func (e JPEGEncoder) Format() Format { return FormatJPEG }
+
+func (e JPEGEncoder) Encode(w io.Writer, img image.Image) error {
+	return jpeg.Encode(w, img, &jpeg.Options{Quality: e.Quality})
+}
+
+// CommandEncoder shells out to an encoder binary (cwebp, avifenc): Go has no
+// maintained pure Go WebP or AVIF encoder. The image is handed over as a
+// lossless PNG through a temp dir.
+type CommandEncoder struct {
+	format  Format
+	bin     string
+	args    func(in, out string) []string
+	timeout time.Duration
+}
+
+// NewCWebPEncoder encodes lossy WebP with libwebp's cwebp.
+func NewCWebPEncoder(bin string, quality int) *CommandEncoder {
+	return &CommandEncoder{
+		format:  FormatWebP,
+		bin:     bin,
+		timeout: 30 * time.Second,
+		args: func(in, out string) []string {
+			return []string{"-quiet", "-q", strconv.Itoa(quality), "-metadata", "none", in, "-o", out}
+		},
+	}
+}
+
+// NewAVIFEncoder encodes AVIF with libavif's avifenc.
+func NewAVIFEncoder(bin string, quality int) *CommandEncoder {
+	return &CommandEncoder{
+		format:  FormatAVIF,
+		bin:     bin,
+		timeout: 60 * time.Second,
+		args: func(in, out string) []string {
+			return []string{"-q", strconv.Itoa(quality), "-s", "6", in, out}
+		},
+	}
+}
+
+func (e *CommandEncoder) Format() Format { return e.format }
+
+// Available reports whether the encoder binary is on PATH.
+func (e *CommandEncoder) Available() bool {
+	_, err := exec.LookPath(e.bin)
+	return err == nil
+}
+
+func (e *CommandEncoder) Encode(w io.Writer, img image.Image) error {
+	dir, err := os.MkdirTemp("", "media-worker-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "in.png")
+	out := filepath.Join(dir, "out."+e.format.Ext)
+
+	f, err := os.Create(in)
+	if err != nil {
+		return err
+	}
+	enc := png.Encoder{CompressionLevel: png.BestSpeed}
+	if err := enc.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.bin, e.args(in, out)...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %w: %s", e.bin, err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,166 @@
+package sanitizer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+
+	"golang.org/x/image/draw"
+)
+
+const tagOrientation = 0x0112
+
+// ExifOrientation returns the EXIF orientation (1-8) stored in a JPEG, PNG
+// or WebP file, or 1 when there is none. Phones store pixels as the sensor
+// saw them and rely on this tag for display, so it has to be applied before
+// the metadata is thrown away.
+func ExifOrientation(data []byte, mimeType string) int {
+	var tiff []byte
+	switch mimeType {
+	case "image/jpeg":
+		tiff = jpegExif(data)
+	case "image/png":
+		tiff = pngExif(data)
+	case "image/webp":
+		tiff = webpExif(data)
+	}
+	if o := tiffOrientation(tiff); o >= 1 && o <= 8 {
+		return o
+	}
+	return 1
+}
+
+// jpegExif returns the TIFF block of the APP1 Exif segment.
+func jpegExif(data []byte) []byte {
+	i := 2 // skip SOI
+	for i+4 <= len(data) && data[i] == 0xFF {
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 { // SOS, EOI: no more headers
+			return nil
+		}
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		if size < 2 || i+2+size > len(data) {
+			return nil
+		}
+		seg := data[i+4 : i+2+size]
+		if marker == 0xE1 && bytes.HasPrefix(seg, []byte("Exif\x00\x00")) {
+			return seg[6:]
+		}
+		i += 2 + size
+	}
+	return nil
+}
+
+// pngExif returns the eXIf chunk.
+func pngExif(data []byte) []byte {
+	i := 8 // skip signature
+	for i+8 <= len(data) {
+		size := int(binary.BigEndian.Uint32(data[i:]))
+		typ := string(data[i+4 : i+8])
+		if size < 0 || i+12+size > len(data) || typ == "IDAT" {
+			return nil
+		}
+		if typ == "eXIf" {
+			return data[i+8 : i+8+size]
+		}
+		i += 12 + size
+	}
+	return nil
+}
+
+// webpExif returns the EXIF chunk of an extended (VP8X) WebP.
+func webpExif(data []byte) []byte {
+	i := 12 // skip RIFF header
+	for i+8 <= len(data) {
+		typ := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		if size < 0 || i+8+size > len(data) {
+			return nil
+		}
+		if typ == "EXIF" {
+			return bytes.TrimPrefix(data[i+8:i+8+size], []byte("Exif\x00\x00"))
+		}
+		i += 8 + size + size%2
+	}
+	return nil
+}
+
+// tiffOrientation reads the orientation tag from IFD0.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 0
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+	if order.Uint16(tiff[2:]) != 42 {
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	n := int(order.Uint16(tiff[ifd:]))
+	for k := 0; k < n; k++ {
+		e := ifd + 2 + k*12
+		if e+12 > len(tiff) {
+			return 0
+		}
+		if order.Uint16(tiff[e:]) == tagOrientation {
+			return int(order.Uint16(tiff[e+8:]))
+		}
+	}
+	return 0
+}
+
+// ApplyOrientation rotates and/or mirrors img so that it displays upright
+// without the EXIF tag. Orientation 1 (or unknown) returns img unchanged.
+func ApplyOrientation(img image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	src := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(src, src.Bounds(), img, b.Min, draw.Src)
+	w, h := b.Dx(), b.Dy()
+
+	dw, dh := w, h
+	if orientation >= 5 { // 90° variants swap the axes
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirror horizontal
+				sx, sy = w-1-x, y
+			case 3: // rotate 180
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirror vertical
+				sx, sy = x, h-1-y
+			case 5: // transpose
+				sx, sy = y, x
+			case 6: // rotate 90 clockwise
+				sx, sy = y, h-1-x
+			case 7: // transverse
+				sx, sy = w-1-y, h-1-x
+			case 8: // rotate 90 counter-clockwise
+				sx, sy = w-1-y, x
+			}
+			si := src.PixOffset(sx, sy)
+			di := dst.PixOffset(x, y)
+			copy(dst.Pix[di:di+4], src.Pix[si:si+4])
+		}
+	}
+	return dst
+}
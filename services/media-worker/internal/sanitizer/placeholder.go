@@ -0,0 +1,165 @@
+package sanitizer
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"golang.org/x/image/draw"
+)
+
+// Meta describes the source image so clients can reserve space and paint a
+// placeholder before any variant loads.
+type Meta struct {
+	Width         int    // after EXIF orientation
+	Height        int    // after EXIF orientation
+	BlurHash      string // https://blurha.sh
+	DominantColor string // "#rrggbb"
+}
+
+// placeholderSize is the long edge of the thumbnail the placeholder is
+// computed from; BlurHash only keeps a few low frequencies anyway.
+const placeholderSize = 32
+
+// Placeholder computes Meta for an oriented image.
+func Placeholder(img image.Image) Meta {
+	b := img.Bounds()
+	thumb := thumbnail(img, placeholderSize)
+
+	xc, yc := 4, 3
+	if b.Dy() > b.Dx() {
+		xc, yc = 3, 4
+	}
+	return Meta{
+		Width:         b.Dx(),
+		Height:        b.Dy(),
+		BlurHash:      BlurHash(thumb, xc, yc),
+		DominantColor: DominantColor(thumb),
+	}
+}
+
+func thumbnail(img image.Image, longEdge int) *image.RGBA {
+	b := img.Bounds()
+	w, h := longEdge, longEdge
+	if b.Dx() >= b.Dy() {
+		h = max(1, b.Dy()*longEdge/max(1, b.Dx()))
+	} else {
+		w = max(1, b.Dx()*longEdge/max(1, b.Dy()))
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.ApproxBiLinear.Scale(dst, dst.Bounds(), img, b, draw.Src, nil)
+	return dst
+}
+
+// BlurHash encodes img with xc×yc components (each 1..9).
+func BlurHash(img *image.RGBA, xc, yc int) string {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	factors := make([][3]float64, 0, xc*yc)
+	for j := 0; j < yc; j++ {
+		for i := 0; i < xc; i++ {
+			norm := 2.0
+			if i == 0 && j == 0 {
+				norm = 1
+			}
+			var r, g, bl float64
+			for y := 0; y < h; y++ {
+				for x := 0; x < w; x++ {
+					basis := math.Cos(math.Pi*float64(i)*float64(x)/float64(w)) *
+						math.Cos(math.Pi*float64(j)*float64(y)/float64(h))
+					p := img.RGBAAt(x, y)
+					r += basis * srgbToLinear(p.R)
+					g += basis * srgbToLinear(p.G)
+					bl += basis * srgbToLinear(p.B)
+				}
+			}
+			scale := norm / float64(w*h)
+			factors = append(factors, [3]float64{r * scale, g * scale, bl * scale})
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(encode83((xc-1)+(yc-1)*9, 1))
+
+	dc, ac := factors[0], factors[1:]
+	maxValue := 1.0
+	if len(ac) > 0 {
+		actualMax := 0.0
+		for _, f := range ac {
+			actualMax = math.Max(actualMax, math.Max(math.Abs(f[0]), math.Max(math.Abs(f[1]), math.Abs(f[2]))))
+		}
+		quantised := int(math.Max(0, math.Min(82, math.Floor(actualMax*166-0.5))))
+		maxValue = float64(quantised+1) / 166
+		sb.WriteString(encode83(quantised, 1))
+	} else {
+		sb.WriteString(encode83(0, 1))
+	}
+
+	sb.WriteString(encode83(linearToSRGB(dc[0])<<16|linearToSRGB(dc[1])<<8|linearToSRGB(dc[2]), 4))
+	for _, f := range ac {
+		q := func(v float64) int {
+			return int(math.Max(0, math.Min(18, math.Floor(signPow(v/maxValue, 0.5)*9+9.5))))
+		}
+		sb.WriteString(encode83(q(f[0])*19*19+q(f[1])*19+q(f[2]), 2))
+	}
+	return sb.String()
+}
+
+// DominantColor returns the mean color of the most common bucket in a
+// 4-bit-per-channel histogram, which ignores small accents that a plain
+// average would smear in.
+func DominantColor(img *image.RGBA) string {
+	type bucket struct{ n, r, g, b int }
+	var hist [4096]bucket
+	best := 0
+	b := img.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			p := img.RGBAAt(x, y)
+			k := int(p.R>>4)<<8 | int(p.G>>4)<<4 | int(p.B>>4)
+			hist[k].n++
+			hist[k].r += int(p.R)
+			hist[k].g += int(p.G)
+			hist[k].b += int(p.B)
+			if hist[k].n > hist[best].n {
+				best = k
+			}
+		}
+	}
+	top := hist[best]
+	if top.n == 0 {
+		return "#000000"
+	}
+	return fmt.Sprintf("#%02x%02x%02x", top.r/top.n, top.g/top.n, top.b/top.n)
+}
+
+const base83 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+func encode83(value, length int) string {
+	out := make([]byte, length)
+	for i := length - 1; i >= 0; i-- {
+		out[i] = base83[value%83]
+		value /= 83
+	}
+	return string(out)
+}
+
+func srgbToLinear(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= 0.04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) int {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(v, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), exp), v)
+}
@@ -0,0 +1,46 @@
+package sanitizer
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlurHash_SolidColor(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 6))
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 8; x++ {
+			img.Set(x, y, color.RGBA{255, 0, 0, 255})
+		}
+	}
+
+	hash := BlurHash(img, 4, 3)
+	assert.Len(t, hash, 6+2*11)
+	assert.Equal(t, "L", hash[:1], "size flag for 4x3")
+	assert.Equal(t, "TI:j", hash[2:6], "DC is #ff0000")
+}
+
+func TestPlaceholder(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 300; x++ {
+			c := color.RGBA{20, 120, 200, 255}
+			if x < 30 { // a small accent must not win
+				c = color.RGBA{250, 250, 0, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	meta := Placeholder(img)
+	assert.Equal(t, 300, meta.Width)
+	assert.Equal(t, 200, meta.Height)
+	assert.Equal(t, "#1478c8", meta.DominantColor)
+	assert.Len(t, meta.BlurHash, 6+2*11)
+	assert.Equal(t, byte('L'), meta.BlurHash[0], "landscape uses 4x3 components")
+
+	portrait := Placeholder(image.NewRGBA(image.Rect(0, 0, 100, 300)))
+	assert.Equal(t, byte('T'), portrait.BlurHash[0], "portrait uses 3x4 components")
+}
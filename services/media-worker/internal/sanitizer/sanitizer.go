@@ -6,7 +6,6 @@ import (
 	"image"
 	"image/jpeg"
 	"image/png"
-	"io"
 
 	"golang.org/x/image/draw"
 	"golang.org/x/image/webp"
@@ -61,13 +60,15 @@ func DecodeImage(data []byte, mimeType string) (image.Image, error) {
 
 // ResizeConfig defines how to resize an image.
 type ResizeConfig struct {
+	Name   string // variant name; defaults to the width (height when only the height is fixed)
 	Width  int
 	Height int
 	Crop   bool // If true, crop to exact dimensions; if false, preserve aspect ratio
 }
 
-// Resize resizes an image according to the config.
-func Resize(img image.Image, cfg ResizeConfig) image.Image {
+// Resize resizes an image according to the config. crop steers where
+// crop-mode sizes cut; nil means a center crop.
+func Resize(img image.Image, cfg ResizeConfig, crop *Crop) image.Image {
 	bounds := img.Bounds()
 	srcW := bounds.Dx()
 	srcH := bounds.Dy()
@@ -75,30 +76,15 @@ func Resize(img image.Image, cfg ResizeConfig) image.Image {
 	var dstW, dstH int
 
 	if cfg.Crop {
-		// Crop to exact dimensions (center crop)
+		// Crop to exact dimensions around the focal point or crop rectangle
 		dstW = cfg.Width
 		dstH = cfg.Height
 
-		// Calculate crop region
-		aspectSrc := float64(srcW) / float64(srcH)
-		aspectDst := float64(dstW) / float64(dstH)
-
-		var cropRect image.Rectangle
-		if aspectSrc > aspectDst {
-			// Source is wider, crop horizontally
-			newW := int(float64(srcH) * aspectDst)
-			x := (srcW - newW) / 2
-			cropRect = image.Rect(x, 0, x+newW, srcH)
-		} else {
-			// Source is taller, crop vertically
-			newH := int(float64(srcW) / aspectDst)
-			y := (srcH - newH) / 2
-			cropRect = image.Rect(0, y, srcW, y+newH)
-		}
+		cropRect := cropWindow(srcW, srcH, float64(dstW)/float64(dstH), crop)
 
 		// Create cropped image
 		cropped := image.NewRGBA(image.Rect(0, 0, cropRect.Dx(), cropRect.Dy()))
-		draw.Draw(cropped, cropped.Bounds(), img, cropRect.Min, draw.Src)
+		draw.Draw(cropped, cropped.Bounds(), img, bounds.Min.Add(cropRect.Min), draw.Src)
 		img = cropped
 		srcW = cropped.Bounds().Dx()
 		srcH = cropped.Bounds().Dy()
@@ -139,51 +125,61 @@ func Resize(img image.Image, cfg ResizeConfig) image.Image {
 	return dst
 }
 
-// EncodeWebP encodes an image to WebP format.
-// Note: golang.org/x/image/webp only supports decoding, not encoding.
-// For production, you'd use a library like github.com/chai2010/webp
-// For now, we'll encode as high-quality JPEG which is still safe.
-func EncodeWebP(w io.Writer, img image.Image) error {
-	// TODO: Replace with actual WebP encoding library
-	return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+// Variant is one derived image: a size in one format.
+type Variant struct {
+	Size   string // ResizeConfig.Name, or the width (height when only the height is fixed)
+	Format Format
+	Data   []byte
 }
 
-// Process processes an image: decode, validate, resize, and re-encode.
-func Process(data []byte, sizes []ResizeConfig, maxWidth, maxHeight int) (map[string][]byte, error) {
+// Process processes an image: decode, validate, apply EXIF orientation,
+// resize, and re-encode every size with every encoder. Re-encoding from
+// pixels drops all source metadata (EXIF, GPS, ICC). It also returns the
+// oriented image's dimensions and placeholder. crop applies to crop-mode
+// sizes.
+func Process(data []byte, sizes []ResizeConfig, crop *Crop, maxWidth, maxHeight int, encoders []Encoder) ([]Variant, Meta, error) {
 	// Detect type from magic bytes
 	mimeType, err := DetectType(data)
 	if err != nil {
-		return nil, fmt.Errorf("invalid image type: %w", err)
+		return nil, Meta{}, fmt.Errorf("invalid image type: %w", err)
 	}
 
 	// Decode
 	img, err := DecodeImage(data, mimeType)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode image: %w", err)
+		return nil, Meta{}, fmt.Errorf("failed to decode image: %w", err)
 	}
 
 	// Validate dimensions
 	bounds := img.Bounds()
 	if bounds.Dx() > maxWidth || bounds.Dy() > maxHeight {
-		return nil, fmt.Errorf("image too large: %dx%d (max %dx%d)", bounds.Dx(), bounds.Dy(), maxWidth, maxHeight)
+		return nil, Meta{}, fmt.Errorf("image too large: %dx%d (max %dx%d)", bounds.Dx(), bounds.Dy(), maxWidth, maxHeight)
 	}
 
-	// Generate all sizes
-	results := make(map[string][]byte)
+	img = ApplyOrientation(img, ExifOrientation(data, mimeType))
+	meta := Placeholder(img)
+
+	// Generate all sizes in all formats
+	var results []Variant
 	for _, size := range sizes {
-		resized := Resize(img, size)
+		resized := Resize(img, size, crop)
 
-		var buf bytes.Buffer
-		if err := EncodeWebP(&buf, resized); err != nil {
-			return nil, fmt.Errorf("failed to encode size %dx%d: %w", size.Width, size.Height, err)
+		key := size.Name
+		if key == "" {
+			key = fmt.Sprintf("%d", size.Width)
+			if size.Width == 0 {
+				key = fmt.Sprintf("%d", size.Height)
+			}
 		}
 
-		key := fmt.Sprintf("%d", size.Width)
-		if size.Width == 0 {
-			key = fmt.Sprintf("%d", size.Height)
+		for _, enc := range encoders {
+			var buf bytes.Buffer
+			if err := enc.Encode(&buf, resized); err != nil {
+				return nil, Meta{}, fmt.Errorf("failed to encode size %dx%d as %s: %w", size.Width, size.Height, enc.Format().Name, err)
+			}
+			results = append(results, Variant{Size: key, Format: enc.Format(), Data: buf.Bytes()})
 		}
-		results[key] = buf.Bytes()
 	}
 
-	return results, nil
+	return results, meta, nil
 }
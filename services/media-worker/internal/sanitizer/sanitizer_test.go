@@ -2,10 +2,12 @@ package sanitizer
 
 import (
 	"bytes"
+	"encoding/binary"
 	"image"
 	"image/color"
 	"image/jpeg"
 	"image/png"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -54,18 +56,130 @@ func TestProcess_Resize(t *testing.T) {
 		{Width: 50, Height: 50, Crop: true},
 	}
 
-	results, err := Process(pngData, sizes, 1000, 1000)
+	results, _, err := Process(pngData, sizes, nil, 1000, 1000, []Encoder{JPEGEncoder{Quality: 85}})
 	assert.NoError(t, err)
-	assert.NotNil(t, results)
+	assert.Len(t, results, 1)
 
 	// Check output
-	assert.Contains(t, results, "50")
+	res50 := results[0]
+	assert.Equal(t, "50", res50.Size)
+	assert.Equal(t, FormatJPEG, res50.Format)
 
-	// Verify size (Sanitizer currently encodes as JPEG in EncodeWebP stub)
-	res50 := results["50"]
-	// Attempt decode as JPEG
-	img50, err := jpeg.Decode(bytes.NewReader(res50))
+	img50, err := jpeg.Decode(bytes.NewReader(res50.Data))
 	assert.NoError(t, err)
 	assert.Equal(t, 50, img50.Bounds().Dx())
 	assert.Equal(t, 50, img50.Bounds().Dy())
 }
+
+type pngEncoder struct{}
+
+func (pngEncoder) Format() Format { return Format{Name: "png", Ext: "png", ContentType: "image/png"} }
+func (pngEncoder) Encode(w io.Writer, img image.Image) error {
+	return png.Encode(w, img)
+}
+
+func TestProcess_EveryFormatPerSize(t *testing.T) {
+	sizes := []ResizeConfig{{Width: 50}, {Width: 80}}
+
+	results, _, err := Process(createTestImage("png"), sizes, nil, 1000, 1000, []Encoder{JPEGEncoder{Quality: 85}, pngEncoder{}})
+	assert.NoError(t, err)
+	assert.Len(t, results, 4)
+
+	got := map[string]bool{}
+	for _, v := range results {
+		got[v.Size+"."+v.Format.Name] = true
+	}
+	assert.Equal(t, map[string]bool{"50.jpeg": true, "50.png": true, "80.jpeg": true, "80.png": true}, got)
+}
+
+// withExif splices an APP1 Exif segment carrying orientation o into a JPEG.
+func withExif(jpg []byte, o uint16, order binary.ByteOrder) []byte {
+	tiff := make([]byte, 26)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1) // one entry
+	order.PutUint16(tiff[10:], 0x0112)
+	order.PutUint16(tiff[12:], 3) // SHORT
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], o)
+
+	seg := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(seg)+2))
+
+	out := append([]byte{}, jpg[:2]...)
+	out = append(out, app1...)
+	out = append(out, seg...)
+	return append(out, jpg[2:]...)
+}
+
+func TestExifOrientation(t *testing.T) {
+	jpg := createTestImage("jpeg")
+
+	assert.Equal(t, 1, ExifOrientation(jpg, "image/jpeg"), "no exif")
+	assert.Equal(t, 6, ExifOrientation(withExif(jpg, 6, binary.LittleEndian), "image/jpeg"))
+	assert.Equal(t, 8, ExifOrientation(withExif(jpg, 8, binary.BigEndian), "image/jpeg"))
+	assert.Equal(t, 1, ExifOrientation(withExif(jpg, 42, binary.BigEndian), "image/jpeg"), "out of range")
+	assert.Equal(t, 1, ExifOrientation([]byte{0xFF, 0xD8, 0xFF, 0xE1, 0xFF}, "image/jpeg"), "truncated")
+}
+
+func TestApplyOrientation(t *testing.T) {
+	// 2x1: red then blue
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	red, blue := color.RGBA{255, 0, 0, 255}, color.RGBA{0, 0, 255, 255}
+	src.Set(0, 0, red)
+	src.Set(1, 0, blue)
+
+	cases := []struct {
+		o          int
+		w, h       int
+		first, end color.RGBA // pixel at (0,0) and at (w-1,h-1)
+	}{
+		{1, 2, 1, red, blue},
+		{2, 2, 1, blue, red},
+		{3, 2, 1, blue, red},
+		{6, 1, 2, red, blue}, // rotated clockwise: red on top
+		{8, 1, 2, blue, red},
+	}
+	for _, c := range cases {
+		out := ApplyOrientation(src, c.o)
+		assert.Equal(t, c.w, out.Bounds().Dx(), "orientation %d", c.o)
+		assert.Equal(t, c.h, out.Bounds().Dy(), "orientation %d", c.o)
+		assert.Equal(t, c.first, color.RGBAModel.Convert(out.At(0, 0)), "orientation %d", c.o)
+		assert.Equal(t, c.end, color.RGBAModel.Convert(out.At(c.w-1, c.h-1)), "orientation %d", c.o)
+	}
+}
+
+func TestProcess_AppliesOrientation(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, img, nil))
+
+	results, meta, err := Process(withExif(buf.Bytes(), 6, binary.BigEndian), []ResizeConfig{{Width: 20}}, nil, 1000, 1000, []Encoder{JPEGEncoder{Quality: 85}})
+	assert.NoError(t, err)
+	assert.Equal(t, 20, meta.Width)
+	assert.Equal(t, 40, meta.Height)
+
+	out, err := jpeg.Decode(bytes.NewReader(results[0].Data))
+	assert.NoError(t, err)
+	assert.Equal(t, 20, out.Bounds().Dx())
+	assert.Equal(t, 40, out.Bounds().Dy(), "portrait after rotation")
+}
+
+func TestCommandEncoder_WebP(t *testing.T) {
+	enc := NewCWebPEncoder("cwebp", 80)
+	if !enc.Available() {
+		t.Skip("cwebp not installed")
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, enc.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 16, 16))))
+	typ, err := DetectType(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, "image/webp", typ)
+}
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/rs/zerolog"
 
 	appconfig "github.com/baechuer/cityevents/services/media-worker/internal/config"
@@ -83,6 +85,19 @@ func (c *S3Client) PutPublicObject(ctx context.Context, objectKey string, data i
 	return nil
 }
 
+// CopyPublicObject copies an object within the public bucket.
+func (c *S3Client) CopyPublicObject(ctx context.Context, srcKey, dstKey string) error {
+	_, err := c.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(c.publicBucket),
+		CopySource: aws.String(c.publicBucket + "/" + srcKey),
+		Key:        aws.String(dstKey),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy object %s to %s: %w", srcKey, dstKey, err)
+	}
+	return nil
+}
+
 // DeleteRawObject deletes an object from the raw bucket.
 func (c *S3Client) DeleteRawObject(ctx context.Context, objectKey string) error {
 	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
@@ -91,3 +106,18 @@ func (c *S3Client) DeleteRawObject(ctx context.Context, objectKey string) error
 	})
 	return err
 }
+
+// DeletePublicObject deletes an object from the public bucket.
+func (c *S3Client) DeletePublicObject(ctx context.Context, objectKey string) error {
+	_, err := c.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(c.publicBucket),
+		Key:    aws.String(objectKey),
+	})
+	return err
+}
+
+// IsNotFound reports whether err means the object does not exist.
+func IsNotFound(err error) bool {
+	var nsk *types.NoSuchKey
+	return errors.As(err, &nsk)
+}
@@ -28,6 +28,13 @@ func NewClient(t *testing.T) *Client {
 }
 
 func (c *Client) Post(path string, body any) (int, map[string]any) {
+	status, resMap, _ := c.PostWithHeaders(path, body, nil)
+	return status, resMap
+}
+
+// PostWithHeaders sends extra request headers (e.g. If-Match) and also
+// returns the response headers (e.g. ETag).
+func (c *Client) PostWithHeaders(path string, body any, headers map[string]string) (int, map[string]any, http.Header) {
 	b, err := json.Marshal(body)
 	require.NoError(c.t, err)
 
@@ -37,6 +44,9 @@ func (c *Client) Post(path string, body any) (int, map[string]any) {
 	if c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
 	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
 
 	resp, err := c.client.Do(req)
 	require.NoError(c.t, err)
@@ -46,7 +56,7 @@ func (c *Client) Post(path string, body any) (int, map[string]any) {
 	// ignore decode error for 204/empty
 	_ = json.NewDecoder(resp.Body).Decode(&resMap)
 
-	return resp.StatusCode, resMap
+	return resp.StatusCode, resMap, resp.Header
 }
 
 func (c *Client) Get(path string) (int, map[string]any) {
@@ -122,7 +132,7 @@ func TestE2E_Lifecycle(t *testing.T) {
 	t.Log("Creating Event...")
 	startTime := time.Now().Add(24 * time.Hour).Format(time.RFC3339)
 	endTime := time.Now().Add(26 * time.Hour).Format(time.RFC3339)
-	status, body = organizer.Post("/api/events", map[string]any{
+	status, body, header := organizer.PostWithHeaders("/api/events", map[string]any{
 		"title":       "E2E Test Event",
 		"description": "An event for testing",
 		"city":        "TestCity",
@@ -130,13 +140,20 @@ func TestE2E_Lifecycle(t *testing.T) {
 		"start_time":  startTime,
 		"end_time":    endTime,
 		"capacity":    100,
-	})
+	}, nil)
 	require.Equal(t, http.StatusCreated, status)
 	eventID := body["id"].(string)
+	etag := header.Get("ETag")
+	require.NotEmpty(t, etag, "create should return an ETag")
 
 	// 4. Publish Event
 	t.Log("Publishing Event...")
+	// Writes without If-Match are rejected rather than applied blindly.
 	status, _ = organizer.Post(fmt.Sprintf("/api/events/%s/publish", eventID), nil)
+	require.Equal(t, http.StatusPreconditionRequired, status)
+
+	status, _, _ = organizer.PostWithHeaders(fmt.Sprintf("/api/events/%s/publish", eventID), nil,
+		map[string]string{"If-Match": etag})
 	require.Equal(t, http.StatusOK, status)
 
 	// 5. Verify in Feed (Draft -> Published)